@@ -1,9 +0,0 @@
-package main
-
-import (
-	"github.com/niko-cb/uct/internal/infrastructure/web/server"
-)
-
-func main() {
-	server.NewServer().Run()
-}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/niko-cb/uct/internal/di"
+	"github.com/niko-cb/uct/internal/infrastructure/web/config"
+)
+
+const dateFormat = "2006-01-02"
+
+// newApp builds the application container for an admin command, reporting the errors on stderr
+func newApp(ctx context.Context, opts ...config.Option) (*di.App, func(), bool) {
+	cfg, err := config.Parse(opts...)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "invalid configuration: %v\n", err)
+		return nil, nil, false
+	}
+	app, cleanup, err := di.NewApp(ctx, cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "application setup error: %v\n", err)
+		return nil, nil, false
+	}
+	return app, cleanup, true
+}
+
+// subcommand splits the subcommand of a command (e.g. create in "company create") from its flags
+func subcommand(name string, args []string) (string, []string, bool) {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		fmt.Fprintf(os.Stderr, "missing %s command\n\n%s", name, usage)
+		return "", nil, false
+	}
+	return args[0], args[1:], true
+}
+
+// parseFlags parses the flags of a command and checks the required ones are set
+func parseFlags(flags *flag.FlagSet, args []string, required ...string) bool {
+	if err := flags.Parse(args); err != nil {
+		return false
+	}
+	set := map[string]bool{}
+	flags.Visit(func(f *flag.Flag) { set[f.Name] = true })
+	for _, name := range required {
+		if !set[name] {
+			fmt.Fprintf(os.Stderr, "flag -%s is required\n", name)
+			flags.Usage()
+			return false
+		}
+	}
+	return true
+}
+
+// dateFlag is a flag holding a date, e.g. 2024-04-01
+type dateFlag struct {
+	time.Time
+}
+
+func (d *dateFlag) String() string {
+	if d.IsZero() {
+		return ""
+	}
+	return d.Format(dateFormat)
+}
+
+func (d *dateFlag) Set(s string) (err error) {
+	d.Time, err = time.Parse(dateFormat, s)
+	return err
+}
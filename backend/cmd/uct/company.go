@@ -0,0 +1,191 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/ericlagergren/decimal"
+	"github.com/niko-cb/uct/internal/domain/entity"
+	"github.com/niko-cb/uct/internal/domain/repository"
+	"github.com/niko-cb/uct/internal/domain/tax"
+	"github.com/volatiletech/sqlboiler/v4/types"
+)
+
+// company runs the company command and returns the exit code
+func company(args []string) int {
+	command, args, ok := subcommand("company", args)
+	if !ok {
+		return 2
+	}
+
+	switch command {
+	case "create":
+		return createCompany(args)
+	case "balance":
+		return companyBalance(args)
+	case "tax-rounding":
+		return companyTaxRounding(args)
+	case "registration-number":
+		return companyRegistrationNumber(args)
+	default:
+		fmt.Fprintf(os.Stderr, "unknown company command %q\n\n%s", command, usage)
+		return 2
+	}
+}
+
+func createCompany(args []string) int {
+	ctx := context.Background()
+
+	c := &entity.Company{}
+	flags := flag.NewFlagSet("company create", flag.ContinueOnError)
+	flags.StringVar(&c.Name, "name", "", "name of the company")
+	flags.StringVar(&c.OwnerName, "owner", "", "name of the owner of the company")
+	flags.StringVar(&c.Phone, "phone", "", "phone number of the company")
+	flags.StringVar(&c.Address, "address", "", "address of the company")
+	flags.StringVar(&c.BaseCurrency, "base-currency", "", "currency the reports of the company are in (default JPY)")
+	flags.StringVar(&c.RegistrationNumber, "registration-number", "", "registration number as qualified invoice issuer, T and 13 digits")
+	if !parseFlags(flags, args, "name") {
+		return 2
+	}
+
+	app, cleanup, ok := newApp(ctx)
+	if !ok {
+		return 1
+	}
+	defer cleanup()
+
+	if err := app.Companies.CreateCompany(ctx, c); err != nil {
+		fmt.Fprintf(os.Stderr, "company create failed: %v\n", err)
+		return 1
+	}
+	fmt.Printf("created company %d (%s)\n", c.ID, c.BaseCurrency)
+	return 0
+}
+
+// companyBalance sets or unsets the balance the cash forecast compares the upcoming payouts to
+func companyBalance(args []string) int {
+	ctx := context.Background()
+
+	flags := flag.NewFlagSet("company balance", flag.ContinueOnError)
+	id := flags.Int64("id", 0, "id of the company")
+	amount := flags.String("amount", "", "balance available to pay the invoices, e.g. 5000000.00")
+	unset := flags.Bool("unset", false, "remove the balance of the company")
+	if !parseFlags(flags, args, "id") {
+		return 2
+	}
+
+	var balance types.NullDecimal
+	if !*unset {
+		d, ok := new(decimal.Big).SetString(*amount)
+		if !ok || d.IsNaN(0) || d.IsInf(0) {
+			fmt.Fprintf(os.Stderr, "invalid -amount %q, expected a number (or -unset)\n", *amount)
+			return 2
+		}
+		balance = types.NewNullDecimal(d.Quantize(2))
+	}
+
+	app, cleanup, ok := newApp(ctx)
+	if !ok {
+		return 1
+	}
+	defer cleanup()
+
+	err := app.Companies.UpdateAvailableBalance(ctx, *id, balance)
+	if errors.Is(err, repository.ErrNotFound) {
+		fmt.Fprintf(os.Stderr, "no company with id %d\n", *id)
+		return 1
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "company balance failed: %v\n", err)
+		return 1
+	}
+	if *unset {
+		fmt.Printf("unset the available balance of company %d\n", *id)
+	} else {
+		fmt.Printf("set the available balance of company %d to %s\n", *id, balance.String())
+	}
+	return 0
+}
+
+// companyTaxRounding sets how the consumption tax of the invoices of a company is rounded to the yen
+func companyTaxRounding(args []string) int {
+	ctx := context.Background()
+
+	flags := flag.NewFlagSet("company tax-rounding", flag.ContinueOnError)
+	id := flags.Int64("id", 0, "id of the company")
+	mode := flags.String("mode", "", "floor, round (half away from zero) or ceil")
+	if !parseFlags(flags, args, "id", "mode") {
+		return 2
+	}
+	rounding, err := tax.ParseRounding(*mode)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "invalid -mode: %v\n", err)
+		return 2
+	}
+
+	app, cleanup, ok := newApp(ctx)
+	if !ok {
+		return 1
+	}
+	defer cleanup()
+
+	err = app.Companies.UpdateTaxRounding(ctx, *id, rounding)
+	if errors.Is(err, repository.ErrNotFound) {
+		fmt.Fprintf(os.Stderr, "no company with id %d\n", *id)
+		return 1
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "company tax-rounding failed: %v\n", err)
+		return 1
+	}
+	fmt.Printf("set the tax rounding of company %d to %s, run \"uct invoices recalc\" to apply it to the existing invoices\n", *id, rounding)
+	return 0
+}
+
+// companyRegistrationNumber sets or unsets the registration number of a company as qualified invoice issuer
+func companyRegistrationNumber(args []string) int {
+	ctx := context.Background()
+
+	flags := flag.NewFlagSet("company registration-number", flag.ContinueOnError)
+	id := flags.Int64("id", 0, "id of the company")
+	number := flags.String("number", "", "registration number as qualified invoice issuer, T and 13 digits")
+	unset := flags.Bool("unset", false, "remove the registration number of the company")
+	if !parseFlags(flags, args, "id") {
+		return 2
+	}
+	if !*unset {
+		normalized, err := tax.ParseRegistrationNumber(*number)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "invalid -number: %v (or -unset)\n", err)
+			return 2
+		}
+		*number = normalized
+	} else {
+		*number = ""
+	}
+
+	app, cleanup, ok := newApp(ctx)
+	if !ok {
+		return 1
+	}
+	defer cleanup()
+
+	err := app.Companies.UpdateRegistrationNumber(ctx, *id, *number)
+	if errors.Is(err, repository.ErrNotFound) {
+		fmt.Fprintf(os.Stderr, "no company with id %d\n", *id)
+		return 1
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "company registration-number failed: %v\n", err)
+		return 1
+	}
+	if *unset {
+		fmt.Printf("unset the registration number of company %d\n", *id)
+	} else {
+		fmt.Printf("set the registration number of company %d to %s\n", *id, *number)
+	}
+	return 0
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/niko-cb/uct/internal/di"
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/health"
+	"github.com/niko-cb/uct/internal/infrastructure/web/config"
+)
+
+// minJwtSecretLength is the length under which the JWT secret is reported as weak (256 bits for HS256)
+const minJwtSecretLength = 32
+
+// doctor checks the configuration and the database, prints a report and returns the exit code
+func doctor(args []string) int {
+	ctx := context.Background()
+
+	flags := flag.NewFlagSet("doctor", flag.ContinueOnError)
+	if !parseFlags(flags, args) {
+		return 2
+	}
+
+	r := &report{}
+	defer r.print()
+
+	// The database is only tried once: doctor reports an unreachable database rather than waiting for it
+	cfg, err := config.Parse(func(cfg *config.Config) { cfg.DbConnectAttempts = 1 })
+	if err != nil {
+		r.fail("config", err.Error())
+		return 1
+	}
+	r.ok("config", fmt.Sprintf("%s storage", cfg.DbDriver))
+	if len(cfg.JwtSecret) < minJwtSecretLength {
+		r.warn("jwt secret", fmt.Sprintf("shorter than %d characters, tokens could be forged by brute force", minJwtSecretLength))
+	}
+	if cfg.DbDriver == config.DriverMemory {
+		r.warn("storage", "the memory store is lost when the server stops")
+	}
+
+	app, cleanup, err := di.NewApp(ctx, cfg)
+	if err != nil {
+		r.fail("database", err.Error())
+		return 1
+	}
+	defer cleanup()
+
+	readiness := app.Checker.Ready(ctx)
+	names := make([]string, 0, len(readiness.Checks))
+	for name := range readiness.Checks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if result := readiness.Checks[name]; result != health.StatusOK {
+			r.fail(name, result)
+			continue
+		}
+		r.ok(name, "")
+	}
+
+	if app.Migrator != nil {
+		statuses, err := app.Migrator.Status(ctx)
+		if err != nil {
+			r.fail("migration status", err.Error())
+		}
+		for _, s := range statuses {
+			switch {
+			case s.ChecksumMismatch:
+				r.fail("migration status", fmt.Sprintf("%d_%s was modified after it was applied", s.Version, s.Name))
+			case !s.Applied:
+				r.warn("migration status", fmt.Sprintf("%d_%s is pending, run uct migrate up", s.Version, s.Name))
+			}
+		}
+	}
+
+	if r.failed {
+		return 1
+	}
+	return 0
+}
+
+// report collects the results of the checks of doctor
+type report struct {
+	lines  []string
+	failed bool
+}
+
+func (r *report) ok(check, detail string) {
+	r.add("ok", check, detail)
+}
+
+func (r *report) warn(check, detail string) {
+	r.add("warn", check, detail)
+}
+
+func (r *report) fail(check, detail string) {
+	r.failed = true
+	r.add("FAIL", check, detail)
+}
+
+func (r *report) add(level, check, detail string) {
+	line := fmt.Sprintf("%-5s %s", level, check)
+	if detail != "" {
+		line += ": " + detail
+	}
+	r.lines = append(r.lines, line)
+}
+
+func (r *report) print() {
+	for _, line := range r.lines {
+		fmt.Fprintln(os.Stdout, line)
+	}
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ericlagergren/decimal"
+	"github.com/niko-cb/uct/internal/domain/entity"
+	"github.com/volatiletech/sqlboiler/v4/types"
+)
+
+// fxRateColumns are the columns of a CSV of exchange rates, in any order
+var fxRateColumns = []string{"date", "currency", "base_currency", "rate"}
+
+// fx runs the fx command and returns the exit code
+func fx(args []string) int {
+	command, args, ok := subcommand("fx", args)
+	if !ok {
+		return 2
+	}
+
+	switch command {
+	case "import":
+		return importFXRates(args)
+	default:
+		fmt.Fprintf(os.Stderr, "unknown fx command %q\n\n%s", command, usage)
+		return 2
+	}
+}
+
+// importFXRates saves the daily exchange rates of a CSV file, e.g. the reference rates published by a bank
+func importFXRates(args []string) int {
+	ctx := context.Background()
+
+	flags := flag.NewFlagSet("fx import", flag.ContinueOnError)
+	file := flags.String("file", "", "CSV file of the rates, with a date,currency,base_currency,rate header")
+	if !parseFlags(flags, args, "file") {
+		return 2
+	}
+
+	f, err := os.Open(*file)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to open %s: %v\n", *file, err)
+		return 1
+	}
+	defer f.Close()
+	rates, err := readFXRates(f)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "invalid %s: %v\n", *file, err)
+		return 1
+	}
+
+	app, cleanup, ok := newApp(ctx)
+	if !ok {
+		return 1
+	}
+	defer cleanup()
+
+	count, err := app.FXRates.ImportFXRates(ctx, rates)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "fx import failed: %v\n", err)
+		return 1
+	}
+	fmt.Printf("imported %d rates\n", count)
+	return 0
+}
+
+// readFXRates reads the rates of a CSV, one a line: the value of 1 currency in the base currency on a date (YYYY-MM-DD)
+func readFXRates(r io.Reader) ([]*entity.FXRate, error) {
+	reader := csv.NewReader(r)
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, errors.New("empty file")
+	}
+	if err != nil {
+		return nil, err
+	}
+	index := map[string]int{}
+	for i, column := range header {
+		index[strings.ToLower(strings.TrimSpace(column))] = i
+	}
+	for _, column := range fxRateColumns {
+		if _, ok := index[column]; !ok {
+			return nil, fmt.Errorf("missing column %q, expected a %s header", column, strings.Join(fxRateColumns, ","))
+		}
+	}
+
+	var rates []*entity.FXRate
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return rates, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := reader.FieldPos(0)
+		value := func(column string) string { return strings.TrimSpace(record[index[column]]) }
+
+		date, err := time.Parse(dateFormat, value("date"))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid date %q, expected YYYY-MM-DD", line, value("date"))
+		}
+		rate, ok := new(decimal.Big).SetString(value("rate"))
+		if !ok || rate.IsNaN(0) || rate.IsInf(0) {
+			return nil, fmt.Errorf("line %d: invalid rate %q, expected a number", line, value("rate"))
+		}
+		rates = append(rates, &entity.FXRate{
+			Date:         date,
+			Currency:     strings.ToUpper(value("currency")),
+			BaseCurrency: strings.ToUpper(value("base_currency")),
+			Rate:         types.NewDecimal(rate),
+		})
+	}
+}
@@ -0,0 +1,247 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/niko-cb/uct/internal/domain/entity"
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+	"github.com/niko-cb/uct/internal/domain/repository"
+)
+
+// exportPageSize is how many invoices are read at once by invoices export
+const exportPageSize = 1000
+
+// invoices runs the invoices command and returns the exit code
+func invoices(args []string) int {
+	command, args, ok := subcommand("invoices", args)
+	if !ok {
+		return 2
+	}
+
+	switch command {
+	case "recalc":
+		return recalcInvoices(args)
+	case "export":
+		return exportInvoices(args)
+	case "import-ubl":
+		return importUBL(args)
+	case "export-ubl":
+		return exportUBL(args)
+	default:
+		fmt.Fprintf(os.Stderr, "unknown invoices command %q\n\n%s", command, usage)
+		return 2
+	}
+}
+
+// recalcInvoices recalculates the amounts of the invoices due in a date range, e.g. after a fee change
+func recalcInvoices(args []string) int {
+	ctx := context.Background()
+
+	var from, to dateFlag
+	flags := flag.NewFlagSet("invoices recalc", flag.ContinueOnError)
+	flags.Var(&from, "from", "first due date of the invoices to recalculate (YYYY-MM-DD)")
+	flags.Var(&to, "to", "last due date of the invoices to recalculate (YYYY-MM-DD)")
+	dryRun := flags.Bool("dry-run", false, "only list the invoices whose amounts would change")
+	if !parseFlags(flags, args, "from", "to") {
+		return 2
+	}
+
+	app, cleanup, ok := newApp(ctx)
+	if !ok {
+		return 1
+	}
+	defer cleanup()
+
+	result, err := app.Invoices.RecalculateInvoices(ctx, from.Time, to.Time, *dryRun)
+	for _, id := range result.Changed {
+		fmt.Printf("invoice %d\n", id)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "invoices recalc failed: %v\n", err)
+		return 1
+	}
+
+	verb := "recalculated"
+	if *dryRun {
+		verb = "would recalculate"
+	}
+	fmt.Printf("%s %d of %d invoices\n", verb, len(result.Changed), result.Checked)
+	return 0
+}
+
+// exportInvoices writes the invoices due in a date range as CSV or JSON
+func exportInvoices(args []string) int {
+	ctx := context.Background()
+
+	var from, to dateFlag
+	flags := flag.NewFlagSet("invoices export", flag.ContinueOnError)
+	flags.Var(&from, "from", "first due date of the invoices to export (YYYY-MM-DD)")
+	flags.Var(&to, "to", "last due date of the invoices to export (YYYY-MM-DD)")
+	format := flags.String("format", "csv", "csv, or json for the invoices as returned by the API, with their credit notes")
+	output := flags.String("output", "", "file to write to (defaults to stdout)")
+	if !parseFlags(flags, args, "from", "to") {
+		return 2
+	}
+
+	var w recordWriter[*models.Invoice]
+	switch *format {
+	case "csv":
+		w = &csvInvoiceWriter{}
+	case "json":
+		w = &jsonWriter[*models.Invoice]{marshal: func(invoice *models.Invoice) any { return entity.NewInvoiceDetails(invoice) }}
+	default:
+		fmt.Fprintf(os.Stderr, "unknown format %q\n", *format)
+		return 2
+	}
+
+	app, cleanup, ok := newApp(ctx)
+	if !ok {
+		return 1
+	}
+	defer cleanup()
+
+	out, closeOutput, ok := createOutput(*output)
+	if !ok {
+		return 1
+	}
+	defer closeOutput()
+
+	list := func(page repository.Page) ([]*models.Invoice, error) {
+		return app.Invoices.GetInvoicesByDateRange(ctx, from.Time, to.Time, page)
+	}
+	count, err := export(list, func(invoice *models.Invoice) int64 { return invoice.ID }, w, out)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "invoices export failed: %v\n", err)
+		return 1
+	}
+
+	if *output != "" {
+		fmt.Printf("exported %d invoices to %s\n", count, *output)
+	}
+	return 0
+}
+
+// createOutput creates the file an export is written to, stdout without path
+func createOutput(path string) (io.Writer, func(), bool) {
+	if path == "" {
+		return os.Stdout, func() {}, true
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to create %s: %v\n", path, err)
+		return nil, nil, false
+	}
+	return f, func() { f.Close() }, true
+}
+
+// export writes the records list returns page by page, and returns how many were written.
+// The pages continue after the id of the last record, so an export of any size runs in constant memory
+func export[T any](list func(page repository.Page) ([]T, error), id func(T) int64, w recordWriter[T], out io.Writer) (int, error) {
+	if err := w.begin(out); err != nil {
+		return 0, err
+	}
+
+	count := 0
+	page := repository.Page{Limit: exportPageSize}
+	for {
+		records, err := list(page)
+		if err != nil {
+			return count, err
+		}
+		for _, record := range records {
+			if err := w.write(record); err != nil {
+				return count, err
+			}
+			count++
+		}
+		if len(records) < page.Limit {
+			return count, w.end()
+		}
+		page.AfterID = id(records[len(records)-1])
+	}
+}
+
+// recordWriter writes records, e.g. invoices, in an export format
+type recordWriter[T any] interface {
+	begin(w io.Writer) error
+	write(record T) error
+	end() error
+}
+
+// csvInvoiceWriter writes the invoices as CSV, with a header line and their amount left once credited
+type csvInvoiceWriter struct {
+	w *csv.Writer
+}
+
+func (c *csvInvoiceWriter) begin(w io.Writer) error {
+	c.w = csv.NewWriter(w)
+	return c.w.Write([]string{"id", "company_id", "client_id", "issue_date", "due_date",
+		"payment_amount", "fee_amount", "tax_amount", "total_amount", "outstanding_amount", "currency", "fx_rate", "status", "version"})
+}
+
+func (c *csvInvoiceWriter) write(invoice *models.Invoice) error {
+	return c.w.Write([]string{
+		strconv.FormatInt(invoice.ID, 10),
+		strconv.FormatInt(invoice.CompanyID, 10),
+		strconv.FormatInt(invoice.ClientID, 10),
+		invoice.IssueDate.Format(dateFormat),
+		invoice.DueDate.Format(dateFormat),
+		invoice.PaymentAmount.String(),
+		invoice.FeeAmount.String(),
+		invoice.TaxAmount.String(),
+		invoice.TotalAmount.String(),
+		entity.OutstandingAmount(invoice).String(),
+		invoice.Currency,
+		invoice.FXRate.String(),
+		invoice.Status,
+		strconv.FormatInt(invoice.Version, 10),
+	})
+}
+
+func (c *csvInvoiceWriter) end() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// jsonWriter writes the records as a JSON array, written as they come
+type jsonWriter[T any] struct {
+	// marshal returns the value a record is written as, the record itself if nil
+	marshal func(T) any
+	w       io.Writer
+	count   int
+}
+
+func (j *jsonWriter[T]) begin(w io.Writer) error {
+	j.w = w
+	_, err := io.WriteString(w, "[")
+	return err
+}
+
+func (j *jsonWriter[T]) write(record T) error {
+	var value any = record
+	if j.marshal != nil {
+		value = j.marshal(record)
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	if j.count > 0 {
+		data = append([]byte(",\n"), data...)
+	}
+	j.count++
+	_, err = j.w.Write(data)
+	return err
+}
+
+func (j *jsonWriter[T]) end() error {
+	_, err := io.WriteString(j.w, "]\n")
+	return err
+}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"archive/zip"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"iter"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/niko-cb/uct/internal/domain/entity"
+	"github.com/niko-cb/uct/internal/domain/nta"
+	"github.com/niko-cb/uct/internal/domain/tax"
+)
+
+// issuers runs the issuers command and returns the exit code
+func issuers(args []string) int {
+	command, args, ok := subcommand("issuers", args)
+	if !ok {
+		return 2
+	}
+
+	switch command {
+	case "import":
+		return importIssuers(args)
+	case "check":
+		return checkIssuer(args)
+	default:
+		fmt.Fprintf(os.Stderr, "unknown issuers command %q\n\n%s", command, usage)
+		return 2
+	}
+}
+
+// importIssuers saves the qualified invoice issuers of a file downloaded from the publication site of the National
+// Tax Agency: the full data or a daily difference, as CSV or as the zip archive of the download
+func importIssuers(args []string) int {
+	ctx := context.Background()
+
+	flags := flag.NewFlagSet("issuers import", flag.ContinueOnError)
+	file := flags.String("file", "", "CSV file of the issuers, Unicode version, or the zip archive of CSV files")
+	if !parseFlags(flags, args, "file") {
+		return 2
+	}
+
+	var read iter.Seq2[*entity.InvoiceIssuer, error]
+	if strings.EqualFold(filepath.Ext(*file), ".zip") {
+		archive, err := zip.OpenReader(*file)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "failed to open %s: %v\n", *file, err)
+			return 1
+		}
+		defer archive.Close()
+		read = readArchiveIssuers(archive)
+	} else {
+		f, err := os.Open(*file)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "failed to open %s: %v\n", *file, err)
+			return 1
+		}
+		defer f.Close()
+		read = nta.NewReader(f).All()
+	}
+
+	app, cleanup, ok := newApp(ctx)
+	if !ok {
+		return 1
+	}
+	defer cleanup()
+
+	saved, deleted, err := app.InvoiceIssuers.ImportInvoiceIssuers(ctx, read)
+	if errors.Is(err, nta.ErrInvalidRecord) {
+		fmt.Fprintf(os.Stderr, "invalid %s after %d issuers: %v\n", *file, saved+deleted, err)
+		return 1
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "issuers import failed after %d issuers: %v\n", saved+deleted, err)
+		return 1
+	}
+	fmt.Printf("imported %d issuers, deleted %d\n", saved, deleted)
+	return 0
+}
+
+// readArchiveIssuers reads the issuers of every CSV file of a zip archive, in the order of the archive
+func readArchiveIssuers(archive *zip.ReadCloser) iter.Seq2[*entity.InvoiceIssuer, error] {
+	return func(yield func(*entity.InvoiceIssuer, error) bool) {
+		for _, file := range archive.File {
+			if !strings.EqualFold(filepath.Ext(file.Name), ".csv") {
+				continue
+			}
+			f, err := file.Open()
+			if err != nil {
+				yield(nil, fmt.Errorf("%s: %w", file.Name, err))
+				return
+			}
+			for issuer, err := range nta.NewReader(f).All() {
+				if err != nil {
+					err = fmt.Errorf("%s: %w", file.Name, err)
+				}
+				if !yield(issuer, err) || err != nil {
+					f.Close()
+					return
+				}
+			}
+			f.Close()
+		}
+	}
+}
+
+// checkIssuer prints whether a registration number is of a registered qualified invoice issuer on a date
+func checkIssuer(args []string) int {
+	ctx := context.Background()
+
+	var date dateFlag
+	flags := flag.NewFlagSet("issuers check", flag.ContinueOnError)
+	number := flags.String("number", "", "registration number, T and 13 digits")
+	flags.Var(&date, "date", "date of the check, e.g. the issue date of an invoice, YYYY-MM-DD (default today)")
+	if !parseFlags(flags, args, "number") {
+		return 2
+	}
+	if date.IsZero() {
+		now := time.Now()
+		date.Time = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	}
+
+	app, cleanup, ok := newApp(ctx)
+	if !ok {
+		return 1
+	}
+	defer cleanup()
+
+	status, issuer, err := app.InvoiceIssuers.CheckRegistration(ctx, *number, date.Time)
+	if errors.Is(err, tax.ErrInvalidRegistrationNumber) {
+		fmt.Fprintf(os.Stderr, "invalid -number: %v\n", err)
+		return 2
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "issuers check failed: %v\n", err)
+		return 1
+	}
+	if issuer != nil {
+		fmt.Printf("%s on %s: %s (%s)\n", issuer.RegistrationNumber, date.Format(dateFormat), status, issuer.Name)
+	} else {
+		fmt.Printf("%s on %s: %s\n", strings.ToUpper(strings.TrimSpace(*number)), date.Format(dateFormat), status)
+	}
+	// Like grep, the exit code tells whether the number is registered
+	if status != entity.IssuerRegistered {
+		return 1
+	}
+	return 0
+}
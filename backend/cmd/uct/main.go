@@ -0,0 +1,87 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/log"
+	"github.com/rs/zerolog"
+)
+
+const usage = `Usage: uct [command]
+
+Commands:
+  serve                 start the API server (default)
+  serve --mock          start the API server on an in-memory store filled with sample data
+  migrate up            apply the pending migrations
+  migrate down [steps]  revert the last applied migrations (default 1)
+  migrate status        list the migrations and whether they are applied
+  seed                  fill the database with deterministic sample data, or write it as SQL or JSON
+  token mint            mint a JWT for a user
+  invoices recalc       recalculate the fee, tax and total amount of invoices
+  invoices export       export invoices as CSV or JSON
+  invoices import-ubl   import a UBL / JP PINT electronic invoice received by a company
+  invoices export-ubl   export an invoice as a UBL / JP PINT electronic invoice
+  credit-notes export   export credit notes as CSV or JSON
+  company create        create a company
+  company balance       set the balance available to pay the invoices of a company
+  company tax-rounding  set how the consumption tax of the invoices of a company is rounded
+  company registration-number
+                        set the registration number of a company as qualified invoice issuer
+  fx import             import daily exchange rates from a CSV file
+  issuers import        import the qualified invoice issuers published by the National Tax Agency
+  issuers check         check a registration number is of a registered qualified invoice issuer
+  recurring generate    create the invoices of the recurring invoices due
+  user create           create a user
+  user reset-password   replace the password of a user
+  doctor                check the configuration and the database
+
+Run "uct <command> -h" for the flags of a command.
+The configuration is read from the same environment variables as the server.
+`
+
+func main() {
+	command := "serve"
+	args := os.Args[1:]
+	if len(args) > 0 {
+		command, args = args[0], args[1:]
+	}
+
+	// The admin commands print their results on stdout, so only the warnings and errors are logged, on stderr
+	if command != "serve" {
+		log.SetOutput(os.Stderr)
+		zerolog.SetGlobalLevel(zerolog.WarnLevel)
+	}
+
+	switch command {
+	case "serve":
+		os.Exit(serve(args))
+	case "migrate":
+		os.Exit(migrate(args))
+	case "seed":
+		os.Exit(seedData(args))
+	case "token":
+		os.Exit(token(args))
+	case "invoices":
+		os.Exit(invoices(args))
+	case "credit-notes":
+		os.Exit(creditNotes(args))
+	case "company":
+		os.Exit(company(args))
+	case "fx":
+		os.Exit(fx(args))
+	case "issuers":
+		os.Exit(issuers(args))
+	case "recurring":
+		os.Exit(recurring(args))
+	case "user":
+		os.Exit(user(args))
+	case "doctor":
+		os.Exit(doctor(args))
+	case "help", "-h", "--help":
+		fmt.Print(usage)
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n%s", command, usage)
+		os.Exit(2)
+	}
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+)
+
+// recurring runs the recurring command and returns the exit code
+func recurring(args []string) int {
+	command, args, ok := subcommand("recurring", args)
+	if !ok {
+		return 2
+	}
+
+	switch command {
+	case "generate":
+		return generateRecurringInvoices(args)
+	default:
+		fmt.Fprintf(os.Stderr, "unknown recurring command %q\n\n%s", command, usage)
+		return 2
+	}
+}
+
+// generateRecurringInvoices creates the invoices of the recurring invoices due, like the server does in the background,
+// e.g. from a cron job when the server generator is disabled
+func generateRecurringInvoices(args []string) int {
+	ctx := context.Background()
+
+	asOf := dateFlag{Time: time.Now().UTC().Truncate(24 * time.Hour)}
+	flags := flag.NewFlagSet("recurring generate", flag.ContinueOnError)
+	flags.Var(&asOf, "as-of", "date up to which the occurrences are billed (YYYY-MM-DD, default today)")
+	if !parseFlags(flags, args) {
+		return 2
+	}
+
+	app, cleanup, ok := newApp(ctx)
+	if !ok {
+		return 1
+	}
+	defer cleanup()
+
+	result, err := app.RecurringInvoices.GenerateInvoices(ctx, asOf.Time)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "recurring generate failed: %v\n", err)
+		return 1
+	}
+	for _, id := range result.Generated {
+		fmt.Printf("invoice %d\n", id)
+	}
+	fmt.Printf("generated %d invoices\n", len(result.Generated))
+	if result.Failed > 0 {
+		fmt.Fprintf(os.Stderr, "%d recurring invoices failed, see the logs\n", result.Failed)
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/niko-cb/uct/internal/application/seed"
+	"github.com/niko-cb/uct/internal/infrastructure/web/config"
+)
+
+// seedData runs the seed command and returns the exit code
+func seedData(args []string) int {
+	ctx := context.Background()
+	opts := seed.DefaultOptions()
+
+	from, to := dateFlag{opts.IssueFrom}, dateFlag{opts.IssueTo}
+	flags := flag.NewFlagSet("seed", flag.ContinueOnError)
+	flags.Int64Var(&opts.Seed, "seed", opts.Seed, "seed of the sample data, the same flags always produce the same data")
+	flags.IntVar(&opts.Companies, "companies", opts.Companies, "number of companies")
+	flags.IntVar(&opts.UsersPerCompany, "users-per-company", opts.UsersPerCompany, "number of users of each company")
+	flags.IntVar(&opts.ClientsPerCompany, "clients-per-company", opts.ClientsPerCompany, "number of clients of each company, with a bank account each")
+	flags.IntVar(&opts.Invoices, "invoices", opts.Invoices, "number of invoices, spread over the companies")
+	flags.Var(&from, "from", "first issue date of the invoices (YYYY-MM-DD)")
+	flags.Var(&to, "to", "last issue date of the invoices (YYYY-MM-DD)")
+	terms := flags.String("terms", joinInts(opts.PaymentTerms), "days between the issue and the due date, one is picked per invoice")
+	flags.IntVar(&opts.MinAmount, "min-amount", opts.MinAmount, "smallest payment amount")
+	flags.IntVar(&opts.MaxAmount, "max-amount", opts.MaxAmount, "largest payment amount")
+	flags.IntVar(&opts.AmountStep, "amount-step", opts.AmountStep, "payment amounts are rounded to a multiple of this")
+	flags.StringVar(&opts.Amounts, "amounts", opts.Amounts, "distribution of the payment amounts: uniform, or log for mostly small invoices")
+	statuses := flags.String("statuses", seed.FormatWeights(opts.Statuses), "relative weights of the invoice statuses")
+	flags.IntVar(&opts.BatchSize, "batch-size", opts.BatchSize, "rows per INSERT statement and transaction")
+	format := flags.String("format", "db", "db to insert in the configured database, sql or json to write fixtures")
+	dialect := flags.String("dialect", seed.DialectMySQL, "SQL dialect of the sql format: mysql or postgres")
+	output := flags.String("output", "", "file to write the sql and json formats to (defaults to stdout)")
+	if !parseFlags(flags, args) {
+		return 2
+	}
+
+	opts.IssueFrom, opts.IssueTo = from.Time, to.Time
+	var err error
+	if opts.PaymentTerms, err = parseInts(*terms); err != nil {
+		fmt.Fprintf(os.Stderr, "invalid -terms: %v\n", err)
+		return 2
+	}
+	if opts.Statuses, err = seed.ParseWeights(*statuses); err != nil {
+		fmt.Fprintf(os.Stderr, "invalid -statuses: %v\n", err)
+		return 2
+	}
+	if err := opts.Validate(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+
+	switch *format {
+	case "db":
+		return seedDatabase(ctx, opts)
+	case "sql", "json":
+		ds, err := seed.Generate(opts)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 2
+		}
+		return writeFixture(*output, func(w io.Writer) error {
+			if *format == "json" {
+				return seed.WriteJSON(w, ds)
+			}
+			return seed.WriteSQL(w, ds, *dialect, opts.BatchSize)
+		})
+	default:
+		fmt.Fprintf(os.Stderr, "unknown format %q\n", *format)
+		return 2
+	}
+}
+
+// seedDatabase inserts the sample data in the configured database
+func seedDatabase(ctx context.Context, opts seed.Options) int {
+	// The memory store would be seeded by di.NewApp, and lost on exit anyway
+	app, cleanup, ok := newApp(ctx, func(cfg *config.Config) { cfg.MemorySeed = 0 })
+	if !ok {
+		return 1
+	}
+	defer cleanup()
+
+	ds, err := app.Seeder.Seed(ctx, opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "seed failed: %v\n", err)
+		return 1
+	}
+	fmt.Printf("seeded the %s database with seed %d: %d companies, %d users, %d clients and %d invoices\n",
+		app.Config.DbDriver, opts.Seed, len(ds.Companies), len(ds.Users), len(ds.Clients), len(ds.Invoices))
+	return 0
+}
+
+// writeFixture writes a fixture to a file, or to stdout when no file is given
+func writeFixture(output string, write func(w io.Writer) error) int {
+	out := os.Stdout
+	if output != "" {
+		f, err := os.Create(output)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "failed to create %s: %v\n", output, err)
+			return 1
+		}
+		defer f.Close()
+		out = f
+	}
+
+	if err := write(out); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to write the fixture: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// parseInts parses integers separated by commas, e.g. 30,60
+func parseInts(s string) ([]int, error) {
+	var values []int
+	for _, field := range strings.Split(s, ",") {
+		value, err := strconv.Atoi(strings.TrimSpace(field))
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+func joinInts(values []int) string {
+	fields := make([]string, len(values))
+	for i, value := range values {
+		fields[i] = strconv.Itoa(value)
+	}
+	return strings.Join(fields, ",")
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/niko-cb/uct/internal/di"
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/log"
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/trace"
+	"github.com/niko-cb/uct/internal/infrastructure/web/config"
+	"github.com/niko-cb/uct/internal/infrastructure/web/server"
+)
+
+// serve starts the API server and returns the exit code once it has shut down.
+// Everything is released in order: the HTTP server drains first, then the connection
+// pool is closed and finally the remaining spans are flushed
+func serve(args []string) int {
+	ctx := context.Background()
+
+	flags := flag.NewFlagSet("serve", flag.ContinueOnError)
+	mock := flags.Bool("mock", false, "serve from an in-memory store filled with deterministic sample data, no database needed")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	var opts []config.Option
+	if *mock {
+		opts = append(opts, func(cfg *config.Config) {
+			cfg.DbDriver = config.DriverMemory
+			if cfg.MemorySeed == 0 {
+				cfg.MemorySeed = 1
+			}
+		})
+	}
+
+	cfg, err := config.Parse(opts...)
+	if err != nil {
+		log.Error(ctx, fmt.Errorf("invalid configuration: %+v", err))
+		return 1
+	}
+
+	// Tracing is set up before connecting so the startup queries are traced too
+	shutdownTracing, err := trace.Init(ctx, cfg)
+	if err != nil {
+		log.Error(ctx, fmt.Errorf("tracing setup error: %+v", err))
+		return 1
+	}
+	defer func() {
+		if err := shutdownTracing(ctx); err != nil {
+			log.Error(ctx, fmt.Errorf("tracing shutdown error: %+v", err))
+		}
+	}()
+
+	app, cleanup, err := di.NewApp(ctx, cfg)
+	if err != nil {
+		log.Error(ctx, fmt.Errorf("application setup error: %+v", err))
+		return 1
+	}
+	defer cleanup()
+
+	server.NewServer(app).Run()
+	return 0
+}
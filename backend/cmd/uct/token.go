@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/niko-cb/uct/internal/domain/repository"
+	webtoken "github.com/niko-cb/uct/internal/infrastructure/web/token"
+)
+
+// token runs the token command and returns the exit code
+func token(args []string) int {
+	command, args, ok := subcommand("token", args)
+	if !ok {
+		return 2
+	}
+	if command != "mint" {
+		fmt.Fprintf(os.Stderr, "unknown token command %q\n\n%s", command, usage)
+		return 2
+	}
+	ctx := context.Background()
+
+	flags := flag.NewFlagSet("token mint", flag.ContinueOnError)
+	userID := flags.Int64("user", 0, "id of the user the token is for")
+	companyID := flags.Int64("company", 0, "id of the company of the user (defaults to the user's company)")
+	roles := flags.String("roles", "", "comma separated roles of the user")
+	ttl := flags.Duration("ttl", time.Hour, "validity of the token")
+	if !parseFlags(flags, args, "user") {
+		return 2
+	}
+
+	app, cleanup, ok := newApp(ctx)
+	if !ok {
+		return 1
+	}
+	defer cleanup()
+
+	// The token is only minted for an existing user, of the company it claims
+	user, err := app.Users.GetUser(ctx, *userID)
+	if errors.Is(err, repository.ErrNotFound) {
+		fmt.Fprintf(os.Stderr, "user %d not found\n", *userID)
+		return 1
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to get user %d: %v\n", *userID, err)
+		return 1
+	}
+	if *companyID == 0 {
+		*companyID = user.CompanyID
+	}
+	if *companyID != user.CompanyID {
+		fmt.Fprintf(os.Stderr, "user %d belongs to company %d, not %d\n", user.ID, user.CompanyID, *companyID)
+		return 1
+	}
+
+	var roleList []string
+	for _, role := range strings.Split(*roles, ",") {
+		if role = strings.TrimSpace(role); role != "" {
+			roleList = append(roleList, role)
+		}
+	}
+
+	signed, err := webtoken.Mint(app.Config.JwtSecret, user.ID, *companyID, roleList, *ttl, time.Now())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to mint token: %v\n", err)
+		return 1
+	}
+	fmt.Println(signed)
+	return 0
+}
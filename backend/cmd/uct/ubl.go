@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+)
+
+// importUBL creates the invoice of a UBL document, e.g. an electronic invoice received from a client by e-mail
+func importUBL(args []string) int {
+	ctx := context.Background()
+
+	flags := flag.NewFlagSet("invoices import-ubl", flag.ContinueOnError)
+	company := flags.Int64("company", 0, "id of the company receiving the invoice")
+	file := flags.String("file", "", "UBL 2.1 XML invoice, following JP PINT")
+	if !parseFlags(flags, args, "company", "file") {
+		return 2
+	}
+
+	document, err := os.ReadFile(*file)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to read %s: %v\n", *file, err)
+		return 1
+	}
+
+	app, cleanup, ok := newApp(ctx)
+	if !ok {
+		return 1
+	}
+	defer cleanup()
+
+	invoice, err := app.UBL.ImportInvoice(ctx, *company, document)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "invoices import-ubl failed: %v\n", err)
+		return 1
+	}
+	fmt.Printf("imported invoice %d of client %d, total %s %s\n", invoice.ID, invoice.ClientID, invoice.TotalAmount, invoice.Currency)
+	return 0
+}
+
+// exportUBL writes an invoice as a UBL document, the electronic invoice its client issued
+func exportUBL(args []string) int {
+	ctx := context.Background()
+
+	flags := flag.NewFlagSet("invoices export-ubl", flag.ContinueOnError)
+	id := flags.Int64("id", 0, "id of the invoice")
+	output := flags.String("output", "", "file to write to (defaults to stdout)")
+	if !parseFlags(flags, args, "id") {
+		return 2
+	}
+
+	app, cleanup, ok := newApp(ctx)
+	if !ok {
+		return 1
+	}
+	defer cleanup()
+
+	document, err := app.UBL.ExportInvoice(ctx, *id)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "invoices export-ubl failed: %v\n", err)
+		return 1
+	}
+
+	out, closeOutput, ok := createOutput(*output)
+	if !ok {
+		return 1
+	}
+	defer closeOutput()
+	if _, err := out.Write(document); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to write the invoice: %v\n", err)
+		return 1
+	}
+	if *output != "" {
+		fmt.Printf("exported invoice %d to %s\n", *id, *output)
+	}
+	return 0
+}
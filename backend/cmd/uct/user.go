@@ -0,0 +1,111 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/niko-cb/uct/internal/domain/entity"
+	"github.com/niko-cb/uct/internal/domain/repository"
+)
+
+// user runs the user command and returns the exit code
+func user(args []string) int {
+	command, args, ok := subcommand("user", args)
+	if !ok {
+		return 2
+	}
+
+	switch command {
+	case "create":
+		return createUser(args)
+	case "reset-password":
+		return resetPassword(args)
+	default:
+		fmt.Fprintf(os.Stderr, "unknown user command %q\n\n%s", command, usage)
+		return 2
+	}
+}
+
+func createUser(args []string) int {
+	ctx := context.Background()
+
+	u := &entity.User{}
+	flags := flag.NewFlagSet("user create", flag.ContinueOnError)
+	flags.Int64Var(&u.CompanyID, "company", 0, "id of the company of the user")
+	flags.StringVar(&u.Name, "name", "", "name of the user")
+	flags.StringVar(&u.Email, "email", "", "email of the user, which must be unique")
+	flags.StringVar(&u.Password, "password", "", "password of the user (a random one is generated and printed if not set)")
+	if !parseFlags(flags, args, "company", "name", "email") {
+		return 2
+	}
+	generated := u.Password == ""
+	if generated {
+		u.Password = randomPassword()
+	}
+
+	app, cleanup, ok := newApp(ctx)
+	if !ok {
+		return 1
+	}
+	defer cleanup()
+
+	if err := app.Users.CreateUser(ctx, u); err != nil {
+		fmt.Fprintf(os.Stderr, "user create failed: %v\n", err)
+		return 1
+	}
+	fmt.Printf("created user %d\n", u.ID)
+	if generated {
+		fmt.Printf("password: %s\n", u.Password)
+	}
+	return 0
+}
+
+func resetPassword(args []string) int {
+	ctx := context.Background()
+
+	flags := flag.NewFlagSet("user reset-password", flag.ContinueOnError)
+	email := flags.String("email", "", "email of the user")
+	password := flags.String("password", "", "new password (a random one is generated and printed if not set)")
+	if !parseFlags(flags, args, "email") {
+		return 2
+	}
+	generated := *password == ""
+	if generated {
+		*password = randomPassword()
+	}
+
+	app, cleanup, ok := newApp(ctx)
+	if !ok {
+		return 1
+	}
+	defer cleanup()
+
+	err := app.Users.ResetPassword(ctx, *email, *password)
+	if errors.Is(err, repository.ErrNotFound) {
+		fmt.Fprintf(os.Stderr, "no user with email %s\n", *email)
+		return 1
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "user reset-password failed: %v\n", err)
+		return 1
+	}
+	fmt.Printf("reset the password of %s\n", *email)
+	if generated {
+		fmt.Printf("password: %s\n", *password)
+	}
+	return 0
+}
+
+// randomPassword generates a password of 16 URL-safe characters
+func randomPassword() string {
+	b := make([]byte, 12)
+	if _, err := rand.Read(b); err != nil {
+		panic(fmt.Sprintf("failed to generate a password: %v", err))
+	}
+	return base64.RawURLEncoding.EncodeToString(b)
+}
@@ -0,0 +1,127 @@
+package seed
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/types"
+)
+
+// The SQL dialects fixtures are written in
+const (
+	DialectMySQL    = "mysql"
+	DialectPostgres = "postgres"
+)
+
+// WriteJSON writes the data as a JSON fixture, one array per table
+func WriteJSON(w io.Writer, ds *Dataset) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(ds)
+}
+
+// WriteSQL writes the data as a SQL script for an empty, migrated database: multi-row INSERT
+// statements of batchSize rows in a single transaction
+func WriteSQL(w io.Writer, ds *Dataset, dialect string, batchSize int) error {
+	if dialect != DialectMySQL && dialect != DialectPostgres {
+		return fmt.Errorf("unknown SQL dialect %q, expected %s or %s", dialect, DialectMySQL, DialectPostgres)
+	}
+	if batchSize < 1 {
+		return fmt.Errorf("invalid batch size %d", batchSize)
+	}
+
+	b := bufio.NewWriter(w)
+	fmt.Fprintf(b, "-- %d companies, %d users, %d clients, %d bank accounts and %d invoices generated by uct seed\n",
+		len(ds.Companies), len(ds.Users), len(ds.Clients), len(ds.BankAccounts), len(ds.Invoices))
+	fmt.Fprintln(b, "BEGIN;")
+	for _, table := range ds.tables() {
+		for start := 0; start < len(table.rows); start += batchSize {
+			fmt.Fprintf(b, "\nINSERT INTO %s (%s) VALUES\n", table.name, strings.Join(table.columns, ", "))
+			batch := table.rows[start:min(start+batchSize, len(table.rows))]
+			for i, row := range batch {
+				literals := make([]string, len(row))
+				for j, value := range row {
+					literals[j] = sqlLiteral(value, dialect)
+				}
+				separator := ","
+				if i == len(batch)-1 {
+					separator = ";"
+				}
+				fmt.Fprintf(b, "(%s)%s\n", strings.Join(literals, ", "), separator)
+			}
+		}
+		if dialect == DialectPostgres && len(table.rows) > 0 {
+			// Identity sequences ignore explicit ids, unlike AUTO_INCREMENT
+			fmt.Fprintf(b, "SELECT setval(pg_get_serial_sequence('%s', 'id'), (SELECT MAX(id) FROM %s));\n", table.name, table.name)
+		}
+	}
+	fmt.Fprintln(b, "\nCOMMIT;")
+	return b.Flush()
+}
+
+// table is the rows of a table, in the columns the seeder sets
+type table struct {
+	name    string
+	columns []string
+	rows    [][]interface{}
+}
+
+// tables returns the rows of every table, parents first for the foreign keys
+func (ds *Dataset) tables() []table {
+	companies := table{name: "companies", columns: []string{"id", "name", "owner_name", "phone", "address"}}
+	for _, c := range ds.Companies {
+		companies.rows = append(companies.rows, []interface{}{c.ID, c.Name, c.OwnerName, c.Phone, c.Address})
+	}
+	users := table{name: "users", columns: []string{"id", "company_id", "name", "email", "password"}}
+	for _, u := range ds.Users {
+		users.rows = append(users.rows, []interface{}{u.ID, u.CompanyID, u.Name, u.Email, u.Password})
+	}
+	clients := table{name: "clients", columns: []string{"id", "company_id", "name", "phone", "address"}}
+	for _, c := range ds.Clients {
+		clients.rows = append(clients.rows, []interface{}{c.ID, c.CompanyID, c.Name, c.Phone, c.Address})
+	}
+	accounts := table{name: "bank_accounts", columns: []string{"id", "client_id", "bank_name", "branch", "account_no", "holder"}}
+	for _, a := range ds.BankAccounts {
+		accounts.rows = append(accounts.rows, []interface{}{a.ID, a.ClientID, a.BankName, a.Branch, a.AccountNo, a.Holder})
+	}
+	invoices := table{name: "invoices", columns: []string{"id", "company_id", "client_id", "issue_date", "due_date",
+		"payment_amount", "fee_amount", "tax_amount", "total_amount", "status"}}
+	for _, i := range ds.Invoices {
+		invoices.rows = append(invoices.rows, []interface{}{i.ID, i.CompanyID, i.ClientID, i.IssueDate, i.DueDate,
+			i.PaymentAmount, i.FeeAmount, i.TaxAmount, i.TotalAmount, i.Status})
+	}
+	return []table{companies, users, clients, accounts, invoices}
+}
+
+// sqlLiteral writes a value as a SQL literal
+func sqlLiteral(value interface{}, dialect string) string {
+	switch v := value.(type) {
+	case int64:
+		return fmt.Sprint(v)
+	case string:
+		// MySQL also treats backslashes as escape characters in strings, Postgres doesn't
+		if dialect == DialectMySQL {
+			v = strings.ReplaceAll(v, `\`, `\\`)
+		}
+		return "'" + strings.ReplaceAll(v, "'", "''") + "'"
+	case null.String:
+		if !v.Valid {
+			return "NULL"
+		}
+		return sqlLiteral(v.String, dialect)
+	case time.Time:
+		return "'" + v.Format("2006-01-02") + "'"
+	case types.Decimal:
+		if v.Big == nil {
+			return "NULL"
+		}
+		return v.String()
+	default:
+		panic(fmt.Sprintf("unsupported SQL literal %T", value))
+	}
+}
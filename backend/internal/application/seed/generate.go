@@ -0,0 +1,182 @@
+package seed
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/ericlagergren/decimal"
+	"github.com/niko-cb/uct/internal/application/usecase"
+	"github.com/niko-cb/uct/internal/domain/entity"
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+	"github.com/niko-cb/uct/internal/domain/tax"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/types"
+)
+
+// PasswordHash is the bcrypt hash of Password. It is computed once, and not on every run,
+// because bcrypt salts are random: the fixtures would differ and large seeds would take minutes
+const PasswordHash = "$2a$10$YRzTT9djXUXGzIwR7W1biesLE/YGclKspLJ.eGWx4FxfnGVHVFcGm"
+
+// Dataset is the generated data, the rows of every table numbered from 1
+type Dataset struct {
+	Companies    []*models.Company     `json:"companies"`
+	Users        []*models.User        `json:"users"`
+	Clients      []*models.Client      `json:"clients"`
+	BankAccounts []*models.BankAccount `json:"bank_accounts"`
+	Invoices     []*models.Invoice     `json:"invoices"`
+}
+
+// Generate generates the data described by the options. It only depends on the options:
+// the same options always produce the same rows, and the invoice amounts are computed like the API does
+func Generate(opts Options) (*Dataset, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	r := rand.New(rand.NewSource(opts.Seed)) // nolint: gosec
+	ds := &Dataset{}
+	clientsByCompany := map[int64][]int64{}
+
+	for i := 0; i < opts.Companies; i++ {
+		address, phone := contact(r)
+		company := &models.Company{
+			ID:        int64(len(ds.Companies) + 1),
+			Name:      companyName(r),
+			OwnerName: personName(r),
+			Phone:     null.StringFrom(phone),
+			Address:   null.StringFrom(address),
+		}
+		ds.Companies = append(ds.Companies, company)
+
+		for j := 0; j < opts.UsersPerCompany; j++ {
+			id := int64(len(ds.Users) + 1)
+			ds.Users = append(ds.Users, &models.User{
+				ID:        id,
+				CompanyID: company.ID,
+				Name:      personName(r),
+				Email:     userEmail(id),
+				Password:  PasswordHash,
+			})
+		}
+
+		for j := 0; j < opts.ClientsPerCompany; j++ {
+			address, phone := contact(r)
+			client := &models.Client{
+				ID:        int64(len(ds.Clients) + 1),
+				CompanyID: company.ID,
+				Name:      companyName(r),
+				Phone:     null.StringFrom(phone),
+				Address:   null.StringFrom(address),
+				Version:   1,
+			}
+			ds.Clients = append(ds.Clients, client)
+			clientsByCompany[company.ID] = append(clientsByCompany[company.ID], client.ID)
+
+			ds.BankAccounts = append(ds.BankAccounts, &models.BankAccount{
+				ID:        int64(len(ds.BankAccounts) + 1),
+				ClientID:  client.ID,
+				BankName:  pick(r, banks),
+				Branch:    pick(r, branches),
+				AccountNo: accountNo(r),
+				Holder:    client.Name,
+				Version:   1,
+			})
+		}
+	}
+
+	from := date(opts.IssueFrom)
+	days := int(date(opts.IssueTo).Sub(from).Hours()/24) + 1
+	statuses := newWeighted(opts.Statuses)
+	for i := 0; i < opts.Invoices; i++ {
+		company := pick(r, ds.Companies)
+		issueDate := from.AddDate(0, 0, r.Intn(days))
+		invoice := &entity.Invoice{
+			CompanyID:     company.ID,
+			ClientID:      pick(r, clientsByCompany[company.ID]),
+			IssueDate:     issueDate,
+			DueDate:       issueDate.AddDate(0, 0, pick(r, opts.PaymentTerms)),
+			PaymentAmount: paymentAmount(r, opts),
+			Status:        statuses.pick(r),
+		}
+		// The seeded companies round the tax the default way
+		if err := usecase.CalculateAmounts(invoice, tax.DefaultRounding); err != nil {
+			return nil, err
+		}
+
+		ds.Invoices = append(ds.Invoices, &models.Invoice{
+			ID:            int64(len(ds.Invoices) + 1),
+			CompanyID:     invoice.CompanyID,
+			ClientID:      invoice.ClientID,
+			IssueDate:     invoice.IssueDate,
+			DueDate:       invoice.DueDate,
+			PaymentAmount: toDecimal(invoice.PaymentAmount),
+			FeeAmount:     toDecimal(invoice.FeeAmount),
+			TaxAmount:     toDecimal(invoice.TaxAmount),
+			TotalAmount:   toDecimal(invoice.TotalAmount),
+			Status:        invoice.Status,
+			Version:       1,
+		})
+	}
+
+	return ds, nil
+}
+
+// paymentAmount draws an amount from the distribution of the options, rounded to the amount step
+func paymentAmount(r *rand.Rand, opts Options) float64 {
+	min, max := float64(opts.MinAmount), float64(opts.MaxAmount)
+	var amount float64
+	switch opts.Amounts {
+	case AmountsLog:
+		amount = math.Exp(math.Log(min) + r.Float64()*(math.Log(max)-math.Log(min)))
+	default:
+		amount = min + r.Float64()*(max-min)
+	}
+	step := float64(opts.AmountStep)
+	return math.Min(max, math.Max(min, math.Round(amount/step)*step))
+}
+
+// toDecimal converts an amount to the DECIMAL(15,2) the database stores
+func toDecimal(amount float64) types.Decimal {
+	d, _ := new(decimal.Big).SetString(strconv.FormatFloat(amount, 'f', 2, 64))
+	return types.NewDecimal(d)
+}
+
+// weighted picks values with a probability proportional to their weight
+type weighted struct {
+	values  []string
+	weights []int
+	total   int
+}
+
+func newWeighted(weights map[string]int) *weighted {
+	w := &weighted{}
+	// Maps are iterated in random order, sorting keeps the picks deterministic
+	for value := range weights {
+		w.values = append(w.values, value)
+	}
+	sort.Strings(w.values)
+	for _, value := range w.values {
+		w.weights = append(w.weights, weights[value])
+		w.total += weights[value]
+	}
+	return w
+}
+
+func (w *weighted) pick(r *rand.Rand) string {
+	n := r.Intn(w.total)
+	for i, weight := range w.weights {
+		if n < weight {
+			return w.values[i]
+		}
+		n -= weight
+	}
+	return w.values[len(w.values)-1]
+}
+
+// date truncates t to a date, the way the DATE columns store it
+func date(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
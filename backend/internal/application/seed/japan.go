@@ -0,0 +1,89 @@
+package seed
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// The pieces the Japanese company names, people, addresses and bank accounts are made of
+
+var (
+	nameStems = []string{"さくら", "みなと", "ひかり", "やまと", "あおば", "東京", "大阪", "名古屋", "北海", "九州",
+		"富士", "日本", "中央", "東洋", "昭和", "大和", "北斗", "新星", "丸の内", "湘南"}
+	nameTrades = []string{"商事", "物産", "工業", "電機", "システムズ", "テック", "デザイン", "ロジスティクス", "フーズ", "建設",
+		"印刷", "不動産", "製作所", "精機", "企画", "コンサルティング", "ソリューションズ", "マーケティング", "食品", "運輸"}
+	// corporateForms are weighted like the register: mostly 株式会社, then 合同会社 and the legacy 有限会社
+	corporateForms = []string{"株式会社", "株式会社", "株式会社", "株式会社", "株式会社", "株式会社", "合同会社", "合同会社", "有限会社"}
+
+	surnames = []string{"佐藤", "鈴木", "高橋", "田中", "伊藤", "渡辺", "山本", "中村", "小林", "加藤",
+		"吉田", "山田", "佐々木", "山口", "松本", "井上", "木村", "林", "斎藤", "清水"}
+	givenNames = []string{"太郎", "花子", "健一", "美咲", "翔", "陽子", "大輔", "さくら", "直樹", "由美",
+		"拓也", "恵", "誠", "愛", "浩", "真由美", "翼", "結衣", "蓮", "葵"}
+
+	// areas are a prefecture and a city with their phone area code
+	areas = []struct {
+		prefecture, city, areaCode string
+	}{
+		{"東京都", "千代田区丸の内", "03"},
+		{"東京都", "渋谷区道玄坂", "03"},
+		{"東京都", "港区芝浦", "03"},
+		{"大阪府", "大阪市北区梅田", "06"},
+		{"愛知県", "名古屋市中村区名駅", "052"},
+		{"福岡県", "福岡市博多区博多駅前", "092"},
+		{"北海道", "札幌市中央区北一条西", "011"},
+		{"神奈川県", "横浜市西区みなとみらい", "045"},
+		{"京都府", "京都市下京区烏丸通", "075"},
+		{"宮城県", "仙台市青葉区中央", "022"},
+		{"広島県", "広島市中区紙屋町", "082"},
+		{"兵庫県", "神戸市中央区三宮町", "078"},
+	}
+
+	banks = []string{"みずほ銀行", "三菱UFJ銀行", "三井住友銀行", "りそな銀行", "ゆうちょ銀行", "楽天銀行",
+		"住信SBIネット銀行", "横浜銀行", "福岡銀行", "千葉銀行"}
+	branches = []string{"本店営業部", "東京営業部", "新宿支店", "渋谷支店", "池袋支店", "梅田支店", "名駅支店",
+		"札幌支店", "博多支店", "横浜駅前支店"}
+)
+
+// companyName is a corporate name, the legal form put before (前株) or after (後株) the name
+func companyName(r *rand.Rand) string {
+	name := pick(r, nameStems) + pick(r, nameTrades)
+	form := pick(r, corporateForms)
+	if r.Intn(2) == 0 {
+		return form + name
+	}
+	return name + form
+}
+
+// personName is a surname and a given name, separated by a space as in most forms
+func personName(r *rand.Rand) string {
+	return pick(r, surnames) + " " + pick(r, givenNames)
+}
+
+// contact returns an address and a phone number of the same area
+func contact(r *rand.Rand) (string, string) {
+	area := pick(r, areas)
+	address := fmt.Sprintf("%s%s%d-%d-%d", area.prefecture, area.city, r.Intn(5)+1, r.Intn(20)+1, r.Intn(30)+1)
+	// Landline numbers have 10 digits: the area code, the local exchange (which can't start
+	// with 0, the trunk prefix) and a 4 digit subscriber number
+	digits := 6 - len(area.areaCode)
+	exchange := pow10(digits-1) + r.Intn(9*pow10(digits-1))
+	phone := fmt.Sprintf("%s-%d-%04d", area.areaCode, exchange, r.Intn(10000))
+	return address, phone
+}
+
+// accountNo is a 7 digit bank account number
+func accountNo(r *rand.Rand) string {
+	return fmt.Sprintf("%07d", r.Intn(10000000))
+}
+
+func pick[T any](r *rand.Rand, values []T) T {
+	return values[r.Intn(len(values))]
+}
+
+func pow10(n int) int {
+	p := 1
+	for i := 0; i < n; i++ {
+		p *= 10
+	}
+	return p
+}
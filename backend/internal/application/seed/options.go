@@ -0,0 +1,142 @@
+package seed
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The distributions of the payment amounts
+const (
+	// AmountsUniform draws every amount between the minimum and the maximum with the same probability
+	AmountsUniform = "uniform"
+	// AmountsLog draws amounts uniformly on a log scale, so small invoices are the most frequent like in real books
+	AmountsLog = "log"
+)
+
+// maxBatchSize keeps a multi-row INSERT under the 65,535 placeholders MySQL and Postgres allow per statement
+const maxBatchSize = 5000
+
+// Password is the password of every seeded user
+const Password = "password123"
+
+// Options configure the generated data. The same options always generate the same data
+type Options struct {
+	// Seed of the random generator
+	Seed int64
+
+	Companies         int
+	UsersPerCompany   int
+	ClientsPerCompany int
+	Invoices          int
+
+	// IssueFrom and IssueTo bound the issue dates of the invoices, both included
+	IssueFrom time.Time
+	IssueTo   time.Time
+	// PaymentTerms are the numbers of days between the issue and the due date, one is picked per invoice
+	PaymentTerms []int
+
+	// MinAmount and MaxAmount bound the payment amounts, which are rounded to AmountStep
+	MinAmount  int
+	MaxAmount  int
+	AmountStep int
+	// Amounts is the distribution of the payment amounts, AmountsUniform or AmountsLog
+	Amounts string
+
+	// Statuses are the relative weights of the invoice statuses, e.g. {"paid": 3, "unprocessed": 1}
+	Statuses map[string]int
+
+	// BatchSize is the number of rows per INSERT statement and transaction
+	BatchSize int
+}
+
+// DefaultOptions are the options of the mock server data: 3 companies with 2 users and 4 clients each,
+// and 60 invoices issued around 2024-04-01
+func DefaultOptions() Options {
+	return Options{
+		Seed:              1,
+		Companies:         3,
+		UsersPerCompany:   2,
+		ClientsPerCompany: 4,
+		Invoices:          60,
+		IssueFrom:         time.Date(2024, time.February, 16, 0, 0, 0, 0, time.UTC),
+		IssueTo:           time.Date(2024, time.May, 15, 0, 0, 0, 0, time.UTC),
+		PaymentTerms:      []int{30, 45, 60},
+		MinAmount:         10000,
+		MaxAmount:         1000000,
+		AmountStep:        1000,
+		Amounts:           AmountsLog,
+		Statuses:          map[string]int{"unprocessed": 50, "processing": 15, "paid": 30, "error": 5},
+		BatchSize:         1000,
+	}
+}
+
+// Validate checks the options can generate data
+func (o Options) Validate() error {
+	switch {
+	case o.Seed == 0:
+		return errors.New("the seed must not be 0")
+	case o.Companies < 1:
+		return errors.New("at least one company is required")
+	case o.UsersPerCompany < 0 || o.Invoices < 0:
+		return errors.New("the numbers of users and invoices must not be negative")
+	case o.ClientsPerCompany < 1 && o.Invoices > 0:
+		return errors.New("invoices require at least one client per company")
+	case o.IssueFrom.IsZero() || o.IssueTo.IsZero() || o.IssueTo.Before(o.IssueFrom):
+		return errors.New("the issue date range is invalid")
+	case len(o.PaymentTerms) == 0:
+		return errors.New("at least one payment term is required")
+	case o.AmountStep < 1 || o.MinAmount < o.AmountStep || o.MaxAmount < o.MinAmount:
+		return errors.New("the amounts must satisfy 0 < step <= min <= max")
+	case o.Amounts != AmountsUniform && o.Amounts != AmountsLog:
+		return fmt.Errorf("unknown amount distribution %q, expected %s or %s", o.Amounts, AmountsUniform, AmountsLog)
+	case o.BatchSize < 1 || o.BatchSize > maxBatchSize:
+		return fmt.Errorf("the batch size must be between 1 and %d", maxBatchSize)
+	}
+	for _, term := range o.PaymentTerms {
+		if term < 0 {
+			return fmt.Errorf("invalid payment term %d", term)
+		}
+	}
+	total := 0
+	for status, weight := range o.Statuses {
+		if weight < 0 {
+			return fmt.Errorf("invalid weight %d of status %s", weight, status)
+		}
+		total += weight
+	}
+	if total == 0 {
+		return errors.New("at least one status must have a positive weight")
+	}
+	return nil
+}
+
+// ParseWeights parses weights written as name=weight pairs separated by commas, e.g. "paid=3,unprocessed=1"
+func ParseWeights(s string) (map[string]int, error) {
+	weights := map[string]int{}
+	for _, pair := range strings.Split(s, ",") {
+		name, value, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid weight %q, expected name=weight", pair)
+		}
+		weight, err := strconv.Atoi(value)
+		if err != nil || weight < 0 {
+			return nil, fmt.Errorf("invalid weight %q, expected a positive integer", pair)
+		}
+		weights[name] = weight
+	}
+	return weights, nil
+}
+
+// FormatWeights formats weights the way ParseWeights parses them, sorted by name
+func FormatWeights(weights map[string]int) string {
+	pairs := make([]string, 0, len(weights))
+	for name, weight := range weights {
+		pairs = append(pairs, fmt.Sprintf("%s=%d", name, weight))
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
@@ -0,0 +1,103 @@
+package seed
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/niko-cb/uct/internal/domain/repository"
+)
+
+// Seeder inserts generated data in the storage
+type Seeder struct {
+	repo        repository.SeedRepository
+	transaction repository.Transaction
+}
+
+func NewSeeder(repo repository.SeedRepository, transaction repository.Transaction) *Seeder {
+	return &Seeder{
+		repo:        repo,
+		transaction: transaction,
+	}
+}
+
+// Seed generates the data described by the options and inserts it after the existing rows: the ids are
+// shifted by the largest ones in the storage, so an empty database always gets the same ids.
+// Rows are inserted BatchSize at a time with multi-row statements, each batch in its own transaction,
+// so a failure keeps the batches inserted before it. The inserted data is returned
+func (s *Seeder) Seed(ctx context.Context, opts Options) (*Dataset, error) {
+	ds, err := Generate(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	ids, err := s.repo.MaxIDs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the largest ids: %w", err)
+	}
+	ds.shift(ids)
+
+	// Parents first, for the foreign keys
+	if err := insertBatches(ctx, s.transaction, "companies", ds.Companies, opts.BatchSize, s.repo.InsertCompanies); err != nil {
+		return nil, err
+	}
+	if err := insertBatches(ctx, s.transaction, "users", ds.Users, opts.BatchSize, s.repo.InsertUsers); err != nil {
+		return nil, err
+	}
+	if err := insertBatches(ctx, s.transaction, "clients", ds.Clients, opts.BatchSize, s.repo.InsertClients); err != nil {
+		return nil, err
+	}
+	if err := insertBatches(ctx, s.transaction, "bank accounts", ds.BankAccounts, opts.BatchSize, s.repo.InsertBankAccounts); err != nil {
+		return nil, err
+	}
+	if err := insertBatches(ctx, s.transaction, "invoices", ds.Invoices, opts.BatchSize, s.repo.InsertInvoices); err != nil {
+		return nil, err
+	}
+
+	return ds, nil
+}
+
+// insertBatches inserts rows size at a time, each batch in a transaction
+func insertBatches[T any](ctx context.Context, transaction repository.Transaction, name string, rows []T, size int,
+	insert func(ctx context.Context, rows []T) error) error {
+	for start := 0; start < len(rows); start += size {
+		batch := rows[start:min(start+size, len(rows))]
+		err := transaction.DoInTx(ctx, func(ctx context.Context) error {
+			return insert(ctx, batch)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to seed %s %d to %d: %w", name, start+1, start+len(batch), err)
+		}
+	}
+	return nil
+}
+
+// shift renumbers the rows after the given ids, updating the references between them.
+// User emails are derived from the ids, so they stay unique when seeding the same database twice
+func (ds *Dataset) shift(ids repository.SeedIDs) {
+	for _, company := range ds.Companies {
+		company.ID += ids.Companies
+	}
+	for _, user := range ds.Users {
+		user.ID += ids.Users
+		user.CompanyID += ids.Companies
+		user.Email = userEmail(user.ID)
+	}
+	for _, client := range ds.Clients {
+		client.ID += ids.Clients
+		client.CompanyID += ids.Companies
+	}
+	for _, account := range ds.BankAccounts {
+		account.ID += ids.BankAccounts
+		account.ClientID += ids.Clients
+	}
+	for _, invoice := range ds.Invoices {
+		invoice.ID += ids.Invoices
+		invoice.CompanyID += ids.Companies
+		invoice.ClientID += ids.Clients
+	}
+}
+
+// userEmail is the email of the seeded user of an id
+func userEmail(id int64) string {
+	return fmt.Sprintf("user%d@example.com", id)
+}
@@ -0,0 +1,139 @@
+package seed_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/niko-cb/uct/internal/application/seed"
+	"github.com/niko-cb/uct/internal/application/usecase"
+	"github.com/niko-cb/uct/internal/conversion"
+	"github.com/niko-cb/uct/internal/domain/entity"
+	"github.com/niko-cb/uct/internal/domain/tax"
+	"github.com/niko-cb/uct/internal/infrastructure/gateway"
+	"github.com/niko-cb/uct/internal/infrastructure/persistent/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// TestGenerate_Deterministic tests the same options always generate the same data, and another seed other data
+func TestGenerate_Deterministic(t *testing.T) {
+	generate := func(s int64) string {
+		opts := seed.DefaultOptions()
+		opts.Seed = s
+		ds, err := seed.Generate(opts)
+		require.NoError(t, err)
+		data, err := json.Marshal(ds)
+		require.NoError(t, err)
+		return string(data)
+	}
+
+	assert.Equal(t, generate(1), generate(1))
+	assert.NotEqual(t, generate(1), generate(2))
+}
+
+// TestGenerate_Options tests the counts, dates, amounts and statuses follow the options
+func TestGenerate_Options(t *testing.T) {
+	opts := seed.DefaultOptions()
+	opts.Companies, opts.UsersPerCompany, opts.ClientsPerCompany, opts.Invoices = 5, 3, 2, 500
+	opts.IssueFrom = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	opts.IssueTo = time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+	opts.PaymentTerms = []int{30, 60}
+	opts.MinAmount, opts.MaxAmount, opts.AmountStep, opts.Amounts = 5000, 50000, 500, seed.AmountsUniform
+	opts.Statuses = map[string]int{"paid": 1, "error": 0, "unprocessed": 3}
+
+	ds, err := seed.Generate(opts)
+	require.NoError(t, err)
+	require.Len(t, ds.Companies, 5)
+	require.Len(t, ds.Users, 15)
+	require.Len(t, ds.Clients, 10)
+	require.Len(t, ds.BankAccounts, 10)
+	require.Len(t, ds.Invoices, 500)
+
+	clientCompany := map[int64]int64{}
+	for _, client := range ds.Clients {
+		clientCompany[client.ID] = client.CompanyID
+	}
+	statuses := map[string]int{}
+	for _, invoice := range ds.Invoices {
+		assert.Equal(t, clientCompany[invoice.ClientID], invoice.CompanyID, "the client belongs to the company")
+		assert.False(t, invoice.IssueDate.Before(opts.IssueFrom) || invoice.IssueDate.After(opts.IssueTo))
+		assert.Contains(t, []int{30, 60}, int(invoice.DueDate.Sub(invoice.IssueDate).Hours()/24))
+
+		amount := conversion.DecimalToFloat(invoice.PaymentAmount)
+		assert.True(t, amount >= 5000 && amount <= 50000, amount)
+		assert.Zero(t, int(amount)%500)
+
+		// The other amounts are the ones the API computes
+		expected := &entity.Invoice{PaymentAmount: amount}
+		require.NoError(t, usecase.CalculateAmounts(expected, tax.DefaultRounding))
+		assert.InDelta(t, expected.TotalAmount, conversion.DecimalToFloat(invoice.TotalAmount), 0.005)
+		statuses[invoice.Status]++
+	}
+	assert.Zero(t, statuses["error"])
+	assert.Greater(t, statuses["unprocessed"], statuses["paid"])
+
+	opts.BatchSize = 0
+	_, err = seed.Generate(opts)
+	assert.Error(t, err)
+}
+
+// TestPasswordHash makes sure the precomputed hash matches the password of the seeded users
+func TestPasswordHash(t *testing.T) {
+	assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(seed.PasswordHash), []byte(seed.Password)))
+}
+
+// TestSeeder_Seed tests seeding twice appends the rows after the existing ones, in batches
+func TestSeeder_Seed(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	seeder := seed.NewSeeder(gateway.NewSeedMemoryGateway(store), memory.NewTransaction(store))
+
+	opts := seed.DefaultOptions()
+	opts.BatchSize = 7
+	first, err := seeder.Seed(ctx, opts)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), first.Invoices[0].ID)
+
+	second, err := seeder.Seed(ctx, opts)
+	require.NoError(t, err)
+	assert.Equal(t, int64(61), second.Invoices[0].ID)
+	assert.Equal(t, int64(4), second.Companies[0].ID)
+	assert.Equal(t, first.Invoices[0].CompanyID+3, second.Invoices[0].CompanyID)
+	assert.Equal(t, "user7@example.com", second.Users[0].Email)
+
+	require.NoError(t, store.Read(ctx, func(tables *memory.Tables) error {
+		assert.Len(t, tables.Companies, 6)
+		assert.Len(t, tables.Users, 12)
+		assert.Len(t, tables.BankAccounts, 24)
+		assert.Len(t, tables.Invoices, 120)
+		return nil
+	}))
+}
+
+// TestWriteSQL tests the statements are batched, the strings escaped and the Postgres sequences moved
+func TestWriteSQL(t *testing.T) {
+	opts := seed.DefaultOptions()
+	ds, err := seed.Generate(opts)
+	require.NoError(t, err)
+	ds.Companies[0].Name = `O'Reilly \ 株式会社`
+
+	var mysql bytes.Buffer
+	require.NoError(t, seed.WriteSQL(&mysql, ds, seed.DialectMySQL, 25))
+	script := mysql.String()
+	assert.Equal(t, 3, strings.Count(script, "INSERT INTO invoices "), "60 invoices by 25")
+	assert.Contains(t, script, `'O''Reilly \\ 株式会社'`)
+	assert.NotContains(t, script, "setval")
+	assert.True(t, strings.HasSuffix(script, "COMMIT;\n"))
+
+	var postgres bytes.Buffer
+	require.NoError(t, seed.WriteSQL(&postgres, ds, seed.DialectPostgres, 25))
+	assert.Contains(t, postgres.String(), `'O''Reilly \ 株式会社'`)
+	assert.Equal(t, 5, strings.Count(postgres.String(), "setval"))
+
+	assert.Error(t, seed.WriteSQL(&postgres, ds, "sqlite", 25))
+}
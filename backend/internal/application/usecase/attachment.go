@@ -0,0 +1,123 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/niko-cb/uct/internal/domain/entity"
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+	"github.com/niko-cb/uct/internal/domain/repository"
+	"github.com/niko-cb/uct/internal/domain/service"
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/log"
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/trace"
+)
+
+type AttachmentUsecase interface {
+	CreateAttachment(ctx context.Context, attachment *entity.Attachment) (*models.InvoiceAttachment, bool, error)
+	GetAttachments(ctx context.Context, invoiceID int64) ([]*models.InvoiceAttachment, error)
+	OpenAttachment(ctx context.Context, invoiceID int64, id int64) (*models.InvoiceAttachment, io.ReadCloser, error)
+}
+
+var _ AttachmentUsecase = &attachmentUsecase{}
+
+type attachmentUsecase struct {
+	attachmentService service.AttachmentService
+	invoiceService    service.InvoiceService
+	blobs             repository.BlobStore
+	transaction       repository.Transaction
+}
+
+func NewAttachmentUsecase(attachmentService service.AttachmentService, invoiceService service.InvoiceService,
+	blobs repository.BlobStore, transaction repository.Transaction) AttachmentUsecase {
+	return &attachmentUsecase{
+		attachmentService: attachmentService,
+		invoiceService:    invoiceService,
+		blobs:             blobs,
+		transaction:       transaction,
+	}
+}
+
+// CreateAttachment attaches a document to an invoice, and returns its metadata and whether it was created.
+// The content is stored once under its SHA-256, before the metadata so an attachment always has its content;
+// attaching the same file to the invoice again returns the existing attachment. The invoice is checked in the
+// transaction of the metadata, so it can't be deleted in between. It returns repository.ErrNotFound without invoice
+func (u *attachmentUsecase) CreateAttachment(ctx context.Context, attachment *entity.Attachment) (_ *models.InvoiceAttachment, _ bool, err error) {
+	ctx, span := trace.Start(ctx, "AttachmentUsecase.CreateAttachment")
+	defer trace.End(span, &err)
+
+	attachmentM := u.attachmentService.EntityToModel(attachment)
+	var created bool
+	err = u.transaction.DoInTx(ctx, func(ctx context.Context) error {
+		if _, err := u.invoiceService.GetInvoice(ctx, attachment.InvoiceID); err != nil {
+			return err
+		}
+
+		key := entity.BlobKey(attachmentM.Sha256)
+		exists, err := u.blobs.Exists(ctx, key)
+		if err != nil {
+			log.Error(ctx, fmt.Errorf("failed to check the content of the attachment: %+v", err))
+			return err
+		}
+		if !exists {
+			if err := u.blobs.Put(ctx, key, attachmentM.ContentType, attachment.Content); err != nil {
+				log.Error(ctx, fmt.Errorf("failed to store the content of the attachment: %+v", err))
+				return err
+			}
+		}
+
+		created, err = u.attachmentService.CreateAttachment(ctx, attachmentM)
+		if err != nil {
+			log.Error(ctx, fmt.Errorf("failed to create attachment: %+v", err))
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	return attachmentM, created, nil
+}
+
+// GetAttachments retrieves the metadata of the attachments of an invoice, ordered by id,
+// or repository.ErrNotFound without invoice
+func (u *attachmentUsecase) GetAttachments(ctx context.Context, invoiceID int64) (_ []*models.InvoiceAttachment, err error) {
+	ctx, span := trace.Start(ctx, "AttachmentUsecase.GetAttachments")
+	defer trace.End(span, &err)
+
+	if _, err := u.invoiceService.GetInvoice(ctx, invoiceID); err != nil {
+		return nil, err
+	}
+	attachments, err := u.attachmentService.GetAttachmentsByInvoice(ctx, invoiceID)
+	if err != nil {
+		return nil, err
+	}
+	if attachments == nil {
+		attachments = []*models.InvoiceAttachment{}
+	}
+	return attachments, nil
+}
+
+// OpenAttachment returns the metadata and the content of an attachment of an invoice, which the caller closes.
+// It returns repository.ErrNotFound if the invoice has no such attachment
+func (u *attachmentUsecase) OpenAttachment(ctx context.Context, invoiceID int64, id int64) (_ *models.InvoiceAttachment, _ io.ReadCloser, err error) {
+	ctx, span := trace.Start(ctx, "AttachmentUsecase.OpenAttachment")
+	defer trace.End(span, &err)
+
+	attachment, err := u.attachmentService.GetAttachment(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if attachment.InvoiceID != invoiceID {
+		return nil, nil, repository.ErrNotFound
+	}
+	content, err := u.blobs.Get(ctx, entity.BlobKey(attachment.Sha256))
+	if err != nil {
+		// Not wrapped: missing content is an inconsistency of the stores, not a missing attachment
+		log.Error(ctx, fmt.Errorf("failed to read the content of attachment %d: %+v", id, err))
+		return nil, nil, fmt.Errorf("failed to read the content of attachment %d: %v", id, err)
+	}
+
+	return attachment, content, nil
+}
@@ -0,0 +1,83 @@
+package usecase_test
+
+import (
+	"context"
+	"io"
+	"sync/atomic"
+	"testing"
+
+	"github.com/niko-cb/uct/internal/domain/entity"
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+	"github.com/niko-cb/uct/internal/domain/repository"
+	"github.com/niko-cb/uct/internal/infrastructure/persistent/blob"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingStore counts the blobs written to the store it wraps
+type countingStore struct {
+	repository.BlobStore
+	puts atomic.Int64
+}
+
+func (s *countingStore) Put(ctx context.Context, key string, contentType string, content []byte) error {
+	s.puts.Add(1)
+	return s.BlobStore.Put(ctx, key, contentType, content)
+}
+
+// TestAttachments tests the documents attached to the invoices, their content being stored once
+// however many invoices it is attached to
+func TestAttachments(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture(t)
+	blobs := &countingStore{BlobStore: blob.NewMemoryStore()}
+	attachments := f.attachments(blobs)
+
+	for range 2 {
+		require.NoError(t, f.invoices.CreateInvoice(ctx, &entity.Invoice{
+			CompanyID: 1, ClientID: 1, IssueDate: day("2024-07-01"), DueDate: day("2024-07-31"),
+			PaymentAmount: 10000, Status: "unprocessed",
+		}))
+	}
+	content := []byte("%PDF-1.7\n")
+	attach := func(invoiceID int64, fileName string) (*models.InvoiceAttachment, bool, error) {
+		return attachments.CreateAttachment(ctx, &entity.Attachment{
+			InvoiceID: invoiceID, FileName: fileName, ContentType: entity.AttachmentTypePDF, Content: content,
+		})
+	}
+
+	pdf, created, err := attach(1, "supplier.pdf")
+	require.NoError(t, err)
+	assert.True(t, created)
+	assert.Equal(t, int64(len(content)), pdf.Size)
+	assert.Equal(t, "0716f9264c9fe19f5d7455276107f3ddcc1d3497f63d60689a73558ae8a1bf5e", pdf.Sha256)
+
+	// Attached again to the same invoice, the file is not duplicated; attached to another one, its content is shared
+	again, created, err := attach(1, "copy.pdf")
+	require.NoError(t, err)
+	assert.False(t, created)
+	assert.Equal(t, pdf.ID, again.ID)
+	shared, created, err := attach(2, "supplier.pdf")
+	require.NoError(t, err)
+	assert.True(t, created)
+	assert.Equal(t, int64(1), blobs.puts.Load())
+	_, _, err = attach(3, "supplier.pdf")
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+
+	listed, err := attachments.GetAttachments(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, listed, 1)
+	assert.Equal(t, "supplier.pdf", listed[0].FileName)
+	_, err = attachments.GetAttachments(ctx, 3)
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+
+	opened, reader, err := attachments.OpenAttachment(ctx, 2, shared.ID)
+	require.NoError(t, err)
+	defer reader.Close()
+	read, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	assert.Equal(t, content, read)
+	assert.Equal(t, entity.AttachmentTypePDF, opened.ContentType)
+	_, _, err = attachments.OpenAttachment(ctx, 1, shared.ID)
+	assert.ErrorIs(t, err, repository.ErrNotFound, "an attachment of another invoice")
+}
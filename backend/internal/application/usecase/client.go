@@ -0,0 +1,60 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/niko-cb/uct/internal/domain/entity"
+	"github.com/niko-cb/uct/internal/domain/repository"
+	"github.com/niko-cb/uct/internal/domain/service"
+	"github.com/niko-cb/uct/internal/domain/tax"
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/trace"
+)
+
+type ClientUsecase interface {
+	CreateClient(ctx context.Context, client *entity.Client, accounts ...*entity.BankAccount) error
+}
+
+var _ ClientUsecase = &clientUsecase{}
+
+type clientUsecase struct {
+	clientService service.ClientService
+	transaction   repository.Transaction
+}
+
+func NewClientUsecase(clientService service.ClientService, transaction repository.Transaction) ClientUsecase {
+	return &clientUsecase{
+		clientService: clientService,
+		transaction:   transaction,
+	}
+}
+
+// CreateClient saves a client along with its bank accounts in one transaction, and sets their ids and versions.
+// It returns tax.ErrInvalidRegistrationNumber for an invalid registration number
+func (u *clientUsecase) CreateClient(ctx context.Context, client *entity.Client, accounts ...*entity.BankAccount) (err error) {
+	ctx, span := trace.Start(ctx, "ClientUsecase.CreateClient")
+	defer trace.End(span, &err)
+
+	if client.RegistrationNumber != "" {
+		if client.RegistrationNumber, err = tax.ParseRegistrationNumber(client.RegistrationNumber); err != nil {
+			return err
+		}
+	}
+
+	return u.transaction.DoInTx(ctx, func(ctx context.Context) error {
+		clientM := u.clientService.EntityToModel(client)
+		if err := u.clientService.CreateClient(ctx, clientM); err != nil {
+			return err
+		}
+		client.ID, client.Version = clientM.ID, clientM.Version
+
+		for _, account := range accounts {
+			account.ClientID = client.ID
+			accountM := u.clientService.BankAccountEntityToModel(account)
+			if err := u.clientService.CreateBankAccount(ctx, accountM); err != nil {
+				return err
+			}
+			account.ID, account.Version = accountM.ID, accountM.Version
+		}
+		return nil
+	})
+}
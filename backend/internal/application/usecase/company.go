@@ -0,0 +1,115 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/niko-cb/uct/internal/domain/entity"
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+	"github.com/niko-cb/uct/internal/domain/repository"
+	"github.com/niko-cb/uct/internal/domain/service"
+	"github.com/niko-cb/uct/internal/domain/tax"
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/trace"
+	"github.com/volatiletech/sqlboiler/v4/types"
+)
+
+type CompanyUsecase interface {
+	CreateCompany(ctx context.Context, company *entity.Company) error
+	GetCompany(ctx context.Context, id int64) (*models.Company, error)
+	UpdateAvailableBalance(ctx context.Context, id int64, balance types.NullDecimal) error
+	UpdateTaxRounding(ctx context.Context, id int64, rounding tax.Rounding) error
+	UpdateRegistrationNumber(ctx context.Context, id int64, registrationNumber string) error
+}
+
+var _ CompanyUsecase = &companyUsecase{}
+
+type companyUsecase struct {
+	companyService service.CompanyService
+	transaction    repository.Transaction
+}
+
+func NewCompanyUsecase(companyService service.CompanyService, transaction repository.Transaction) CompanyUsecase {
+	return &companyUsecase{
+		companyService: companyService,
+		transaction:    transaction,
+	}
+}
+
+// CreateCompany saves a company and sets its id. It returns ErrInvalidCurrency for an unknown base currency,
+// and tax.ErrInvalidRegistrationNumber for an invalid registration number
+func (u *companyUsecase) CreateCompany(ctx context.Context, company *entity.Company) (err error) {
+	ctx, span := trace.Start(ctx, "CompanyUsecase.CreateCompany")
+	defer trace.End(span, &err)
+
+	if _, ok := entity.CurrencyDigits[company.BaseCurrency]; company.BaseCurrency != "" && !ok {
+		return fmt.Errorf("%w: %q", ErrInvalidCurrency, company.BaseCurrency)
+	}
+	if company.RegistrationNumber != "" {
+		if company.RegistrationNumber, err = tax.ParseRegistrationNumber(company.RegistrationNumber); err != nil {
+			return err
+		}
+	}
+
+	companyM := u.companyService.EntityToModel(company)
+	err = u.transaction.DoInTx(ctx, func(ctx context.Context) error {
+		return u.companyService.CreateCompany(ctx, companyM)
+	})
+	if err != nil {
+		return err
+	}
+
+	company.ID = companyM.ID
+	company.OwnerName = companyM.OwnerName
+	company.BaseCurrency = companyM.BaseCurrency
+	return nil
+}
+
+// GetCompany retrieves a saved company, or repository.ErrNotFound
+func (u *companyUsecase) GetCompany(ctx context.Context, id int64) (_ *models.Company, err error) {
+	ctx, span := trace.Start(ctx, "CompanyUsecase.GetCompany")
+	defer trace.End(span, &err)
+
+	return u.companyService.GetCompany(ctx, id)
+}
+
+// UpdateAvailableBalance sets the balance available to pay the invoices of a company, compared to the cash forecast.
+// A NULL balance unsets it. It returns repository.ErrNotFound if there is no such company
+func (u *companyUsecase) UpdateAvailableBalance(ctx context.Context, id int64, balance types.NullDecimal) (err error) {
+	ctx, span := trace.Start(ctx, "CompanyUsecase.UpdateAvailableBalance")
+	defer trace.End(span, &err)
+
+	return u.transaction.DoInTx(ctx, func(ctx context.Context) error {
+		return u.companyService.UpdateAvailableBalance(ctx, id, balance)
+	})
+}
+
+// UpdateTaxRounding sets how the consumption tax of the invoices of a company is rounded, for the invoices
+// created or recalculated from now on. It returns repository.ErrNotFound if there is no such company
+func (u *companyUsecase) UpdateTaxRounding(ctx context.Context, id int64, rounding tax.Rounding) (err error) {
+	ctx, span := trace.Start(ctx, "CompanyUsecase.UpdateTaxRounding")
+	defer trace.End(span, &err)
+
+	if _, err := tax.ParseRounding(string(rounding)); err != nil {
+		return err
+	}
+	return u.transaction.DoInTx(ctx, func(ctx context.Context) error {
+		return u.companyService.UpdateTaxRounding(ctx, id, rounding)
+	})
+}
+
+// UpdateRegistrationNumber sets the registration number of a company as qualified invoice issuer, or unsets it
+// if empty. It returns tax.ErrInvalidRegistrationNumber for an invalid number, and repository.ErrNotFound
+// if there is no such company
+func (u *companyUsecase) UpdateRegistrationNumber(ctx context.Context, id int64, registrationNumber string) (err error) {
+	ctx, span := trace.Start(ctx, "CompanyUsecase.UpdateRegistrationNumber")
+	defer trace.End(span, &err)
+
+	if registrationNumber != "" {
+		if registrationNumber, err = tax.ParseRegistrationNumber(registrationNumber); err != nil {
+			return err
+		}
+	}
+	return u.transaction.DoInTx(ctx, func(ctx context.Context) error {
+		return u.companyService.UpdateRegistrationNumber(ctx, id, registrationNumber)
+	})
+}
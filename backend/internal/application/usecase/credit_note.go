@@ -0,0 +1,224 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/niko-cb/uct/internal/conversion"
+	"github.com/niko-cb/uct/internal/domain/entity"
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+	"github.com/niko-cb/uct/internal/domain/repository"
+	"github.com/niko-cb/uct/internal/domain/service"
+	"github.com/niko-cb/uct/internal/domain/tax"
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/log"
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/trace"
+)
+
+var (
+	// ErrCreditExceedsBalance is returned when the credit notes of an invoice would credit more than its payment amount
+	ErrCreditExceedsBalance = errors.New("the credit notes exceed the payment amount of the invoice")
+	// ErrInvoiceCredited is returned when changing the currency or the company of an invoice with credit notes
+	ErrInvoiceCredited = errors.New("the invoice has credit notes, its currency and company can't change")
+	// ErrInvalidCreditNoteStatus is returned for a status which is not one of entity.CreditNoteStatuses
+	ErrInvalidCreditNoteStatus = errors.New("unknown credit note status, expected issued, applied or void")
+	// ErrCreditNoteTransition is returned when a credit note can't move from its status to the requested one
+	ErrCreditNoteTransition = errors.New("the credit note can't move to this status")
+)
+
+type CreditNoteUsecase interface {
+	CreateCreditNote(ctx context.Context, creditNote *entity.CreditNote) (*models.CreditNote, error)
+	GetCreditNote(ctx context.Context, id int64) (*models.CreditNote, error)
+	GetCreditNotesByInvoice(ctx context.Context, invoiceID int64) ([]*models.CreditNote, error)
+	GetCreditNotesByDateRange(ctx context.Context, from time.Time, to time.Time, page repository.Page) ([]*models.CreditNote, error)
+	UpdateCreditNoteStatus(ctx context.Context, id int64, version int64, status string) (*models.CreditNote, error)
+}
+
+var _ CreditNoteUsecase = &creditNoteUsecase{}
+
+type creditNoteUsecase struct {
+	creditNoteService service.CreditNoteService
+	invoiceService    service.InvoiceService
+	companyService    service.CompanyService
+	transaction       repository.Transaction
+}
+
+func NewCreditNoteUsecase(creditNoteService service.CreditNoteService, invoiceService service.InvoiceService,
+	companyService service.CompanyService, transaction repository.Transaction) CreditNoteUsecase {
+	return &creditNoteUsecase{
+		creditNoteService: creditNoteService,
+		invoiceService:    invoiceService,
+		companyService:    companyService,
+		transaction:       transaction,
+	}
+}
+
+// CreateCreditNote saves a credit note of an invoice, reversing the fee and its tax under the fee policy:
+// the difference between the amounts of the payment left to the invoice before and after the credit.
+// An invoice with payments may so become paid, its status being derived from them and its credit notes.
+// The invoice moves to a new version even if its status doesn't change, so concurrent credit notes can't both pass
+// the balance check: the second one fails with repository.ErrVersionConflict. It returns repository.ErrNotFound
+// without invoice, and ErrCreditExceedsBalance if the invoice has less left
+func (u *creditNoteUsecase) CreateCreditNote(ctx context.Context, creditNote *entity.CreditNote) (_ *models.CreditNote, err error) {
+	ctx, span := trace.Start(ctx, "CreditNoteUsecase.CreateCreditNote")
+	defer trace.End(span, &err)
+
+	var creditNoteM *models.CreditNote
+	err = u.transaction.DoInTx(ctx, func(ctx context.Context) error {
+		invoice, err := u.invoiceService.GetInvoice(ctx, creditNote.InvoiceID)
+		if err != nil {
+			return err
+		}
+		company, err := u.companyService.GetCompany(ctx, invoice.CompanyID)
+		if err != nil {
+			return fmt.Errorf("company %d: %w", invoice.CompanyID, err)
+		}
+		rounding, err := tax.ParseRounding(company.TaxRounding)
+		if err != nil {
+			return err
+		}
+		if err := ReverseAmounts(creditNote, invoice, rounding); err != nil {
+			return err
+		}
+		creditNote.Status = entity.CreditNoteStatusIssued
+
+		creditNoteM, err = u.creditNoteService.EntityToModel(creditNote)
+		if err != nil {
+			log.Error(ctx, fmt.Errorf("failed to convert entity to model: %+v", err))
+			return err
+		}
+		if err := u.creditNoteService.CreateCreditNote(ctx, creditNoteM); err != nil {
+			log.Error(ctx, fmt.Errorf("failed to create credit note: %+v", err))
+			return err
+		}
+		credited, err := u.invoiceService.GetInvoice(ctx, invoice.ID)
+		if err != nil {
+			return err
+		}
+		if err := u.invoiceService.UpdateInvoiceStatus(ctx, invoice.ID, invoice.Version, entity.PaymentStatus(credited)); err != nil {
+			return err
+		}
+
+		// Read it back as stored (rounded amounts, date without time)
+		creditNoteM, err = u.creditNoteService.GetCreditNote(ctx, creditNoteM.ID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	creditNote.ID = creditNoteM.ID
+	return creditNoteM, nil
+}
+
+// GetCreditNote retrieves a saved credit note, or repository.ErrNotFound
+func (u *creditNoteUsecase) GetCreditNote(ctx context.Context, id int64) (_ *models.CreditNote, err error) {
+	ctx, span := trace.Start(ctx, "CreditNoteUsecase.GetCreditNote")
+	defer trace.End(span, &err)
+
+	return u.creditNoteService.GetCreditNote(ctx, id)
+}
+
+// GetCreditNotesByInvoice retrieves the credit notes of an invoice, or repository.ErrNotFound without invoice
+func (u *creditNoteUsecase) GetCreditNotesByInvoice(ctx context.Context, invoiceID int64) (_ []*models.CreditNote, err error) {
+	ctx, span := trace.Start(ctx, "CreditNoteUsecase.GetCreditNotesByInvoice")
+	defer trace.End(span, &err)
+
+	if _, err := u.invoiceService.GetInvoice(ctx, invoiceID); err != nil {
+		return nil, err
+	}
+	return u.creditNoteService.GetCreditNotesByInvoice(ctx, invoiceID)
+}
+
+// GetCreditNotesByDateRange retrieves the credit notes issued between from and to
+func (u *creditNoteUsecase) GetCreditNotesByDateRange(ctx context.Context, from time.Time, to time.Time, page repository.Page) (_ []*models.CreditNote, err error) {
+	ctx, span := trace.Start(ctx, "CreditNoteUsecase.GetCreditNotesByDateRange")
+	defer trace.End(span, &err)
+
+	return u.creditNoteService.GetCreditNotesByDateRange(ctx, from, to, page)
+}
+
+// UpdateCreditNoteStatus moves a credit note to a status if it is still at version (or at any version with AnyVersion),
+// and returns it with its new version. It returns ErrInvalidCreditNoteStatus for an unknown status,
+// and ErrCreditNoteTransition if the credit note can't move to it, e.g. once void. Voiding a credit note of an invoice
+// with payments may move it back to partially paid
+func (u *creditNoteUsecase) UpdateCreditNoteStatus(ctx context.Context, id int64, version int64, status string) (_ *models.CreditNote, err error) {
+	ctx, span := trace.Start(ctx, "CreditNoteUsecase.UpdateCreditNoteStatus")
+	defer trace.End(span, &err)
+
+	if !slices.Contains(entity.CreditNoteStatuses, status) {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidCreditNoteStatus, status)
+	}
+
+	var creditNoteM *models.CreditNote
+	err = u.transaction.DoInTx(ctx, func(ctx context.Context) error {
+		current, err := u.creditNoteService.GetCreditNote(ctx, id)
+		if err != nil {
+			return err
+		}
+		if version == AnyVersion {
+			version = current.Version
+		}
+		// A stale version fails as the update would, before judging a transition from a status the client didn't see
+		if version != current.Version {
+			return repository.ErrVersionConflict
+		}
+		if !entity.CanTransitionCreditNote(current.Status, status) {
+			return fmt.Errorf("%w: from %s to %s", ErrCreditNoteTransition, current.Status, status)
+		}
+
+		if err := u.creditNoteService.UpdateCreditNoteStatus(ctx, id, version, status); err != nil {
+			return err
+		}
+		if err := syncPaymentStatus(ctx, u.invoiceService, current.InvoiceID); err != nil {
+			return err
+		}
+		creditNoteM, err = u.creditNoteService.GetCreditNote(ctx, id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return creditNoteM, nil
+}
+
+// ReverseAmounts sets the fee, tax and total amount of a credit note of an invoice, the fee and tax of the payment
+// left to the invoice minus the ones of what is left after the credit. Crediting all of the payment so reverses all
+// of the fee and its tax. It returns ErrCreditExceedsBalance if the invoice has less payment left than credited
+func ReverseAmounts(creditNote *entity.CreditNote, invoice *models.Invoice, rounding tax.Rounding) error {
+	left := conversion.DecimalToFloat(invoice.PaymentAmount) - creditedAmount(invoice)
+	if math.Round(creditNote.PaymentAmount*100) > math.Round(left*100) {
+		return fmt.Errorf("%w: %.2f credited, %.2f left", ErrCreditExceedsBalance, creditNote.PaymentAmount, left)
+	}
+
+	before := &entity.Invoice{Currency: invoice.Currency, PaymentAmount: left}
+	after := &entity.Invoice{Currency: invoice.Currency, PaymentAmount: left - creditNote.PaymentAmount}
+	for _, amounts := range []*entity.Invoice{before, after} {
+		if err := CalculateAmounts(amounts, rounding); err != nil {
+			return err
+		}
+	}
+
+	creditNote.FeeAmount = roundCents(roundCents(before.FeeAmount) - roundCents(after.FeeAmount))
+	creditNote.TaxAmount = roundCents(before.TaxAmount - after.TaxAmount)
+	creditNote.TotalAmount = roundCents(creditNote.PaymentAmount + creditNote.FeeAmount + creditNote.TaxAmount)
+	return nil
+}
+
+// creditedAmount returns the payment amount credited by the credit notes of an invoice which are not void
+func creditedAmount(invoice *models.Invoice) float64 {
+	var credited float64
+	for _, credit := range entity.Credits(invoice.R.GetCreditNotes()) {
+		credited += conversion.DecimalToFloat(credit.PaymentAmount)
+	}
+	return roundCents(credited)
+}
+
+// roundCents rounds an amount to cents, half away from zero as the database does
+func roundCents(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
@@ -0,0 +1,128 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/niko-cb/uct/internal/application/usecase"
+	"github.com/niko-cb/uct/internal/domain/entity"
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+	"github.com/niko-cb/uct/internal/domain/repository"
+	"github.com/niko-cb/uct/internal/domain/service"
+	"github.com/niko-cb/uct/internal/infrastructure/gateway"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCreditNotes tests the reversal of the fee and its tax, the balance left to credit, the status transitions
+// and the updates of a credited invoice
+func TestCreditNotes(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture(t)
+	creditNotes := f.creditNotes()
+
+	invoice := &entity.Invoice{
+		CompanyID: 1, ClientID: 1, IssueDate: day("2024-07-01"), DueDate: day("2024-07-31"),
+		PaymentAmount: 10000, Status: "unprocessed",
+	}
+	require.NoError(t, f.invoices.CreateInvoice(ctx, invoice))
+	credit := func(amount float64) (*models.CreditNote, error) {
+		return creditNotes.CreateCreditNote(ctx, &entity.CreditNote{
+			InvoiceID: 1, IssueDate: day("2024-07-05"), Reason: "overbilled", PaymentAmount: amount,
+		})
+	}
+	amounts := func(creditNote *models.CreditNote) []string {
+		return []string{creditNote.PaymentAmount.String(), creditNote.FeeAmount.String(),
+			creditNote.TaxAmount.String(), creditNote.TotalAmount.String()}
+	}
+
+	// The fee of 10000 is 400.00 and its tax 40, the fee of 7499.50 is 299.98 and its tax 29 (floored)
+	partial, err := credit(2500.50)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"2500.50", "100.02", "11.00", "2611.52"}, amounts(partial))
+	assert.Equal(t, entity.CreditNoteStatusIssued, partial.Status)
+
+	_, err = credit(7499.51)
+	assert.ErrorIs(t, err, usecase.ErrCreditExceedsBalance)
+	_, err = creditNotes.CreateCreditNote(ctx, &entity.CreditNote{InvoiceID: 2, IssueDate: day("2024-07-05"), PaymentAmount: 1})
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+
+	// Crediting the rest reverses the rest of the fee and its tax, nothing is outstanding anymore
+	rest, err := credit(7499.50)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"7499.50", "299.98", "29.00", "7828.48"}, amounts(rest))
+	saved, err := f.invoices.GetInvoice(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "0.00", entity.OutstandingAmount(saved).String())
+
+	// A void credit note doesn't count anymore, and is final
+	_, err = creditNotes.UpdateCreditNoteStatus(ctx, rest.ID, rest.Version, "cancelled")
+	assert.ErrorIs(t, err, usecase.ErrInvalidCreditNoteStatus)
+	voided, err := creditNotes.UpdateCreditNoteStatus(ctx, rest.ID, rest.Version, entity.CreditNoteStatusVoid)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), voided.Version)
+	_, err = creditNotes.UpdateCreditNoteStatus(ctx, rest.ID, usecase.AnyVersion, entity.CreditNoteStatusApplied)
+	assert.ErrorIs(t, err, usecase.ErrCreditNoteTransition)
+	_, err = creditNotes.UpdateCreditNoteStatus(ctx, partial.ID, 2, entity.CreditNoteStatusApplied)
+	assert.ErrorIs(t, err, repository.ErrVersionConflict)
+	applied, err := creditNotes.UpdateCreditNoteStatus(ctx, partial.ID, partial.Version, entity.CreditNoteStatusApplied)
+	require.NoError(t, err)
+	assert.Equal(t, entity.CreditNoteStatusApplied, applied.Status)
+
+	saved, err = f.invoices.GetInvoice(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "7828.48", entity.OutstandingAmount(saved).String())
+	listed, err := creditNotes.GetCreditNotesByInvoice(ctx, 1)
+	require.NoError(t, err)
+	assert.Len(t, listed, 2)
+	_, err = creditNotes.GetCreditNotesByInvoice(ctx, 2)
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+
+	// The invoice can't go below what is credited, nor change currency
+	invoice.ID, invoice.Version, invoice.PaymentAmount = 1, usecase.AnyVersion, 2500
+	_, err = f.invoices.UpdateInvoice(ctx, invoice)
+	assert.ErrorIs(t, err, usecase.ErrCreditExceedsBalance)
+	invoice.PaymentAmount, invoice.Currency = 5000, "USD"
+	_, err = f.invoices.UpdateInvoice(ctx, invoice)
+	assert.ErrorIs(t, err, usecase.ErrInvoiceCredited)
+	invoice.Currency = ""
+	updated, err := f.invoices.UpdateInvoice(ctx, invoice)
+	require.NoError(t, err)
+	// 5220.00 - 2611.52
+	assert.Equal(t, "2608.48", entity.OutstandingAmount(updated).String())
+}
+
+// staleInvoiceService is an InvoiceService whose invoices are changed concurrently once read the first time
+type staleInvoiceService struct {
+	service.InvoiceService
+	read bool
+}
+
+func (s *staleInvoiceService) GetInvoice(ctx context.Context, id int64) (*models.Invoice, error) {
+	invoice, err := s.InvoiceService.GetInvoice(ctx, id)
+	if err == nil && !s.read {
+		s.read = true
+		invoice.Version--
+	}
+	return invoice, err
+}
+
+// TestCreditNotes_Conflict tests a credit note of an invoice changed since its balance was checked is rolled back
+func TestCreditNotes_Conflict(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture(t)
+	creditNotes := usecase.NewCreditNoteUsecase(service.NewCreditNoteService(gateway.NewCreditNoteMemoryGateway(f.store)),
+		&staleInvoiceService{InvoiceService: f.invoiceService}, f.companyService, f.transaction)
+
+	require.NoError(t, f.invoices.CreateInvoice(ctx, &entity.Invoice{
+		CompanyID: 1, ClientID: 1, IssueDate: day("2024-07-01"), DueDate: day("2024-07-31"),
+		PaymentAmount: 10000, Status: "unprocessed",
+	}))
+	_, err := creditNotes.CreateCreditNote(ctx, &entity.CreditNote{
+		InvoiceID: 1, IssueDate: day("2024-07-05"), Reason: "overbilled", PaymentAmount: 10000,
+	})
+	assert.ErrorIs(t, err, repository.ErrVersionConflict)
+	listed, err := f.creditNotes().GetCreditNotesByInvoice(ctx, 1)
+	require.NoError(t, err)
+	assert.Empty(t, listed)
+}
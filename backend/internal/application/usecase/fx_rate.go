@@ -0,0 +1,101 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/niko-cb/uct/internal/domain/entity"
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+	"github.com/niko-cb/uct/internal/domain/repository"
+	"github.com/niko-cb/uct/internal/domain/service"
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/trace"
+)
+
+var (
+	// ErrInvalidCurrency is returned for a currency which is not one of entity.CurrencyDigits
+	ErrInvalidCurrency = errors.New("unknown currency, expected JPY, USD, EUR, GBP or CNY")
+	// ErrInvalidFXRate is returned when importing a rate which is not positive, or between a currency and itself
+	ErrInvalidFXRate = errors.New("invalid fx rate")
+	// ErrNoFXRate is returned when converting an invoice without a recent enough rate of its currency
+	ErrNoFXRate = errors.New("no fx rate of the currency of the invoice")
+)
+
+// fxRateMaxAge is how old the rate of an invoice can be: the rates are published on business days only,
+// and a week covers the longest holidays. An older rate means the rates are not imported anymore
+const fxRateMaxAge = 7 * 24 * time.Hour
+
+// fxRateBatchSize is how many rates are saved by statement
+const fxRateBatchSize = 1000
+
+type FXRateUsecase interface {
+	ImportFXRates(ctx context.Context, rates []*entity.FXRate) (int, error)
+}
+
+var _ FXRateUsecase = &fxRateUsecase{}
+
+type fxRateUsecase struct {
+	fxRateService service.FXRateService
+	transaction   repository.Transaction
+}
+
+func NewFXRateUsecase(fxRateService service.FXRateService, transaction repository.Transaction) FXRateUsecase {
+	return &fxRateUsecase{
+		fxRateService: fxRateService,
+		transaction:   transaction,
+	}
+}
+
+// ImportFXRates saves daily exchange rates, replacing the ones already saved for the same currencies and day.
+// The rates are validated first and saved in one transaction, so nothing is saved if one is invalid.
+// When a day is given twice, the last rate wins. It returns the number of rates saved
+func (u *fxRateUsecase) ImportFXRates(ctx context.Context, rates []*entity.FXRate) (_ int, err error) {
+	ctx, span := trace.Start(ctx, "FXRateUsecase.ImportFXRates")
+	defer trace.End(span, &err)
+
+	index := map[string]int{}
+	var saved []*models.FXRate
+	for i, rate := range rates {
+		if err := validateFXRate(rate); err != nil {
+			return 0, fmt.Errorf("rate %d: %w", i+1, err)
+		}
+		rateM := u.fxRateService.EntityToModel(rate)
+		key := rate.Currency + "/" + rate.BaseCurrency + "/" + rate.Date.Format("2006-01-02")
+		if j, ok := index[key]; ok {
+			saved[j] = rateM
+			continue
+		}
+		index[key] = len(saved)
+		saved = append(saved, rateM)
+	}
+
+	err = u.transaction.DoInTx(ctx, func(ctx context.Context) error {
+		for start := 0; start < len(saved); start += fxRateBatchSize {
+			if err := u.fxRateService.UpsertFXRates(ctx, saved[start:min(start+fxRateBatchSize, len(saved))]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return len(saved), nil
+}
+
+// validateFXRate checks the currencies of a rate are known and different, and the rate positive
+func validateFXRate(rate *entity.FXRate) error {
+	for _, currency := range []string{rate.Currency, rate.BaseCurrency} {
+		if _, ok := entity.CurrencyDigits[currency]; !ok {
+			return fmt.Errorf("%w: %q", ErrInvalidCurrency, currency)
+		}
+	}
+	if rate.Currency == rate.BaseCurrency {
+		return fmt.Errorf("%w: %s in %s", ErrInvalidFXRate, rate.Currency, rate.BaseCurrency)
+	}
+	if rate.Date.IsZero() || rate.Rate.Big == nil || rate.Rate.Sign() <= 0 {
+		return fmt.Errorf("%w: expected a day and a positive rate", ErrInvalidFXRate)
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ericlagergren/decimal"
+	"github.com/niko-cb/uct/internal/application/usecase"
+	"github.com/niko-cb/uct/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/sqlboiler/v4/types"
+)
+
+// TestInvoiceFXRates tests the import of the rates, and the rate saved with an invoice of another currency
+// than the base currency of its company
+func TestInvoiceFXRates(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture(t)
+	fxRates := usecase.NewFXRateUsecase(f.fxRateService, f.transaction)
+
+	rate := func(currency, date string, rate int64) *entity.FXRate {
+		return &entity.FXRate{Date: day(date), Currency: currency, BaseCurrency: "JPY", Rate: types.NewDecimal(decimal.New(rate, 2))}
+	}
+
+	// Nothing is saved when a rate is invalid
+	_, err := fxRates.ImportFXRates(ctx, []*entity.FXRate{rate("USD", "2024-07-01", 16100), rate("XYZ", "2024-07-01", 100)})
+	assert.ErrorIs(t, err, usecase.ErrInvalidCurrency)
+	_, err = fxRates.ImportFXRates(ctx, []*entity.FXRate{rate("JPY", "2024-07-01", 100)})
+	assert.ErrorIs(t, err, usecase.ErrInvalidFXRate)
+	_, err = fxRates.ImportFXRates(ctx, []*entity.FXRate{rate("USD", "2024-07-01", -16100)})
+	assert.ErrorIs(t, err, usecase.ErrInvalidFXRate)
+
+	// The last rate of a day wins
+	count, err := fxRates.ImportFXRates(ctx, []*entity.FXRate{
+		rate("USD", "2024-07-01", 16000), rate("USD", "2024-07-02", 16150), rate("USD", "2024-07-01", 16100),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	// The rate of the day before the issue date is saved, and the tax is rounded to the cent
+	invoice := &entity.Invoice{
+		CompanyID: 1, ClientID: 1, IssueDate: day("2024-07-01"), DueDate: day("2024-07-31"),
+		PaymentAmount: 1234.56, Currency: "USD", Status: "unprocessed",
+	}
+	require.NoError(t, f.invoices.CreateInvoice(ctx, invoice))
+	saved, err := f.invoiceRepo.GetInvoice(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "USD", saved.Currency)
+	assert.Equal(t, "161.00000000", saved.FXRate.String())
+	// 49.38 * 10% = 4.938
+	assert.Equal(t, "4.93", saved.TaxAmount.String())
+
+	// The rate is a week old at most
+	for _, issued := range []string{"2024-06-30", "2024-07-10"} {
+		err = f.invoices.CreateInvoice(ctx, &entity.Invoice{
+			CompanyID: 1, ClientID: 1, IssueDate: day(issued), DueDate: day("2024-07-31"),
+			PaymentAmount: 100, Currency: "USD", Status: "unprocessed",
+		})
+		assert.ErrorIs(t, err, usecase.ErrNoFXRate, issued)
+	}
+	require.NoError(t, f.invoices.CreateInvoice(ctx, &entity.Invoice{
+		CompanyID: 1, ClientID: 1, IssueDate: day("2024-07-09"), DueDate: day("2024-07-31"),
+		PaymentAmount: 100, Currency: "USD", Status: "unprocessed",
+	}))
+
+	// A rate imported later doesn't change the rate of the invoice, unless its currency changes
+	_, err = fxRates.ImportFXRates(ctx, []*entity.FXRate{rate("USD", "2024-07-01", 15000), rate("EUR", "2024-07-01", 17400)})
+	require.NoError(t, err)
+	invoice.ID, invoice.Version = 1, usecase.AnyVersion
+	updated, err := f.invoices.UpdateInvoice(ctx, invoice)
+	require.NoError(t, err)
+	assert.Equal(t, "161.00000000", updated.FXRate.String())
+
+	invoice.Currency, invoice.Version = "EUR", usecase.AnyVersion
+	updated, err = f.invoices.UpdateInvoice(ctx, invoice)
+	require.NoError(t, err)
+	assert.Equal(t, "174.00000000", updated.FXRate.String())
+
+	// Without currency, the invoice is in the base currency
+	invoice.Currency, invoice.Version = "", usecase.AnyVersion
+	updated, err = f.invoices.UpdateInvoice(ctx, invoice)
+	require.NoError(t, err)
+	assert.Equal(t, "JPY", updated.Currency)
+	assert.Equal(t, "1.00000000", updated.FXRate.String())
+	assert.Equal(t, "4.00", updated.TaxAmount.String())
+}
@@ -0,0 +1,84 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/niko-cb/uct/internal/application/usecase"
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+	"github.com/niko-cb/uct/internal/domain/repository"
+	"github.com/niko-cb/uct/internal/domain/service"
+	"github.com/niko-cb/uct/internal/infrastructure/gateway"
+	"github.com/niko-cb/uct/internal/infrastructure/persistent/memory"
+	"github.com/stretchr/testify/require"
+)
+
+// day parses a date of the tests, YYYY-MM-DD
+func day(s string) time.Time {
+	d, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// fixture is a memory store with the company 1, rounding the tax down in JPY, and its client 1,
+// and the invoice usecase with its services on it
+type fixture struct {
+	store          *memory.Store
+	transaction    repository.Transaction
+	invoiceRepo    repository.InvoiceRepository
+	invoiceService service.InvoiceService
+	companyService service.CompanyService
+	fxRateService  service.FXRateService
+	invoices       usecase.InvoiceUsecase
+}
+
+func newFixture(t *testing.T) *fixture {
+	store := memory.NewStore()
+	require.NoError(t, store.Write(context.Background(), func(tables *memory.Tables) error {
+		tables.Companies[1] = models.Company{ID: 1, Name: "company", TaxRounding: "floor", BaseCurrency: "JPY"}
+		tables.Clients[1] = models.Client{ID: 1, CompanyID: 1, Name: "client", Version: 1}
+		return nil
+	}))
+
+	f := &fixture{
+		store:          store,
+		transaction:    memory.NewTransaction(store),
+		invoiceRepo:    gateway.NewInvoiceMemoryGateway(store),
+		companyService: service.NewCompanyService(gateway.NewCompanyMemoryGateway(store)),
+		fxRateService:  service.NewFXRateService(gateway.NewFXRateMemoryGateway(store)),
+	}
+	f.invoiceService = service.NewInvoiceService(f.invoiceRepo)
+	f.invoices = usecase.NewInvoiceUsecase(f.invoiceService, f.companyService, f.fxRateService, f.transaction)
+	return f
+}
+
+// creditNotes returns the credit note usecase on the store of the fixture
+func (f *fixture) creditNotes() usecase.CreditNoteUsecase {
+	return usecase.NewCreditNoteUsecase(service.NewCreditNoteService(gateway.NewCreditNoteMemoryGateway(f.store)),
+		f.invoiceService, f.companyService, f.transaction)
+}
+
+// payments returns the payment usecase on the store of the fixture
+func (f *fixture) payments() usecase.PaymentUsecase {
+	return usecase.NewPaymentUsecase(service.NewPaymentService(gateway.NewPaymentMemoryGateway(f.store)),
+		f.invoiceService, f.transaction)
+}
+
+// recurringInvoiceService returns the recurring invoice service on the store of the fixture
+func (f *fixture) recurringInvoiceService() service.RecurringInvoiceService {
+	return service.NewRecurringInvoiceService(gateway.NewRecurringInvoiceMemoryGateway(f.store))
+}
+
+// recurringInvoices returns the recurring invoice usecase on a recurring invoice service, e.g. the one of the fixture
+func (f *fixture) recurringInvoices(recurringService service.RecurringInvoiceService) usecase.RecurringInvoiceUsecase {
+	return usecase.NewRecurringInvoiceUsecase(recurringService, f.companyService, f.invoices, f.transaction)
+}
+
+// attachments returns the attachment usecase on the store of the fixture, storing the content in blobs
+func (f *fixture) attachments(blobs repository.BlobStore) usecase.AttachmentUsecase {
+	return usecase.NewAttachmentUsecase(service.NewAttachmentService(gateway.NewAttachmentMemoryGateway(f.store)),
+		f.invoiceService, blobs, f.transaction)
+}
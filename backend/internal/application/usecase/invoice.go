@@ -2,59 +2,103 @@ package usecase
 
 import (
 	"context"
-	"database/sql"
+	"errors"
 	"fmt"
+	"github.com/ericlagergren/decimal"
+	"github.com/niko-cb/uct/internal/conversion"
 	"github.com/niko-cb/uct/internal/domain/entity/models"
+	"math"
 	"time"
 
 	"github.com/niko-cb/uct/internal/infrastructure/monitor/log"
-
-	"github.com/niko-cb/uct/internal/infrastructure/persistent/mysql/transaction"
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/metrics"
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/trace"
+	"github.com/volatiletech/sqlboiler/v4/types"
 
 	"github.com/niko-cb/uct/internal/domain/entity"
 	"github.com/niko-cb/uct/internal/domain/repository"
 	"github.com/niko-cb/uct/internal/domain/service"
+	"github.com/niko-cb/uct/internal/domain/tax"
 )
 
 type InvoiceUsecase interface {
 	CreateInvoice(ctx context.Context, invoice *entity.Invoice) error
-	GetInvoicesByDateRange(ctx context.Context, from time.Time, to time.Time) ([]*models.Invoice, error)
+	GetInvoice(ctx context.Context, id int64) (*models.Invoice, error)
+	GetInvoicesByDateRange(ctx context.Context, from time.Time, to time.Time, page repository.Page) ([]*models.Invoice, error)
+	GetInvoicesByInstallmentDateRange(ctx context.Context, from time.Time, to time.Time, page repository.Page) ([]*models.Invoice, error)
+	UpdateInvoice(ctx context.Context, invoice *entity.Invoice) (*models.Invoice, error)
+	DeleteInvoice(ctx context.Context, id int64, version int64) error
+	RecalculateInvoices(ctx context.Context, from time.Time, to time.Time, dryRun bool) (RecalculateResult, error)
+}
+
+// RecalculateResult counts the invoices checked by RecalculateInvoices, and the ones whose amounts changed
+type RecalculateResult struct {
+	Checked int
+	Changed []int64
 }
 
+// ErrPaymentAmountMismatch is returned when an invoice has items and a payment amount which is not their total
+var ErrPaymentAmountMismatch = errors.New("payment_amount doesn't match the total of the items")
+
+// ErrInstallmentsMismatch is returned when the installments of an invoice don't add up to its total amount
+var ErrInstallmentsMismatch = errors.New("the installments don't add up to the total amount")
+
+// AnyVersion can be given as the expected version of an invoice to update or delete it whatever its version
+const AnyVersion int64 = 0
+
 var _ InvoiceUsecase = &invoiceUsecase{}
 
 type invoiceUsecase struct {
 	invoiceService service.InvoiceService
+	companyService service.CompanyService
+	fxRateService  service.FXRateService
 	transaction    repository.Transaction
 }
 
-func NewInvoiceUsecase(invoiceService service.InvoiceService, transaction repository.Transaction) InvoiceUsecase {
+func NewInvoiceUsecase(invoiceService service.InvoiceService, companyService service.CompanyService,
+	fxRateService service.FXRateService, transaction repository.Transaction) InvoiceUsecase {
 	return &invoiceUsecase{
 		invoiceService: invoiceService,
+		companyService: companyService,
+		fxRateService:  fxRateService,
 		transaction:    transaction,
 	}
 }
 
-// CreateInvoice saves invoices to the database after calculating the fee, tax, and total amount
-func (u *invoiceUsecase) CreateInvoice(ctx context.Context, invoice *entity.Invoice) error {
-	// calculate fee, tax, and total amount
+// CreateInvoice saves invoices to the database after calculating the amounts of the items, the fee, tax, and total amount.
+// The tax is rounded the way the company of the invoice configured, it returns repository.ErrNotFound without company.
+// The rate converting the currency of the invoice to the base currency of the company on the issue date is saved
+// with it, or ErrNoFXRate is returned. The id of the invoice is set once saved
+func (u *invoiceUsecase) CreateInvoice(ctx context.Context, invoice *entity.Invoice) (err error) {
+	ctx, span := trace.Start(ctx, "InvoiceUsecase.CreateInvoice")
+	defer trace.End(span, &err)
 
-	// 4% fee
-	invoice.FeeAmount = invoice.PaymentAmount * 0.04
-
-	// 10% tax
-	invoice.TaxAmount = invoice.FeeAmount * 1.10
-
-	// total amount = payment amount + fee amount + tax amount
-	invoice.TotalAmount = invoice.PaymentAmount + invoice.FeeAmount + invoice.TaxAmount
+	if err := CalculateItems(invoice); err != nil {
+		return err
+	}
 
 	// Even though it's just one operation, we still want to wrap it in a transaction
 	// to ensure that the operation is atomic. If the operation fails, we want to roll back
 	// the entire operation to avoid any partial data being saved to the database.
-	return u.transaction.DoInTx(ctx, func(ctx context.Context) error {
-
-		// Get the transaction from the context
-		tx := ctx.Value(u.transaction.(*transaction.Transaction).CtxTxKey()).(*sql.Tx)
+	// The repositories pick the transaction up from the context
+	err = u.transaction.DoInTx(ctx, func(ctx context.Context) error {
+		company, err := u.company(ctx, invoice.CompanyID)
+		if err != nil {
+			return err
+		}
+		if invoice.FXRate, err = u.fxRate(ctx, invoice, company); err != nil {
+			return err
+		}
+		rounding, err := tax.ParseRounding(company.TaxRounding)
+		if err != nil {
+			return err
+		}
+		if err := CalculateAmounts(invoice, rounding); err != nil {
+			return err
+		}
+		if err := CheckInstallments(invoice); err != nil {
+			return err
+		}
 
 		invoiceM, err := u.invoiceService.EntityToModel(ctx, invoice)
 		if err != nil {
@@ -63,21 +107,43 @@ func (u *invoiceUsecase) CreateInvoice(ctx context.Context, invoice *entity.Invo
 		}
 
 		// Create invoices
-		err = u.invoiceService.CreateInvoice(ctx, tx, invoiceM)
+		err = u.invoiceService.CreateInvoice(ctx, invoiceM)
 		if err != nil {
 			log.Error(ctx, fmt.Errorf("failed to upsert invoices: %+v", err))
 			return err
 		}
 
+		invoice.ID = invoiceM.ID
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+
+	metrics.InvoiceCreated(invoice.Status, invoice.PaymentAmount, invoice.FeeAmount, invoice.TaxAmount, invoice.TotalAmount)
+	return nil
+}
+
+// GetInvoice retrieves a saved invoice, or repository.ErrNotFound
+func (u *invoiceUsecase) GetInvoice(ctx context.Context, id int64) (_ *models.Invoice, err error) {
+	ctx, span := trace.Start(ctx, "InvoiceUsecase.GetInvoice")
+	defer trace.End(span, &err)
+
+	invoice, err := u.invoiceService.GetInvoice(ctx, id)
+	if err != nil {
+		return nil, err
+	}
 
+	return invoice, nil
 }
 
 // GetInvoicesByDateRange retrieves saved invoices from the database
-func (u *invoiceUsecase) GetInvoicesByDateRange(ctx context.Context, from time.Time, to time.Time) ([]*models.Invoice, error) {
+func (u *invoiceUsecase) GetInvoicesByDateRange(ctx context.Context, from time.Time, to time.Time, page repository.Page) (_ []*models.Invoice, err error) {
+	ctx, span := trace.Start(ctx, "InvoiceUsecase.GetInvoicesByDateRange")
+	defer trace.End(span, &err)
+
 	log.Info(ctx, "listing invoices")
-	invoices, err := u.invoiceService.GetInvoicesByDateRange(ctx, from, to)
+	invoices, err := u.invoiceService.GetInvoicesByDateRange(ctx, from, to, page)
 	if err != nil {
 		log.Error(ctx, fmt.Errorf("failed to get invoices: %+v", err))
 		return nil, err
@@ -85,3 +151,394 @@ func (u *invoiceUsecase) GetInvoicesByDateRange(ctx context.Context, from time.T
 
 	return invoices, nil
 }
+
+// GetInvoicesByInstallmentDateRange retrieves the saved invoices with an installment due between from and to
+func (u *invoiceUsecase) GetInvoicesByInstallmentDateRange(ctx context.Context, from time.Time, to time.Time, page repository.Page) (_ []*models.Invoice, err error) {
+	ctx, span := trace.Start(ctx, "InvoiceUsecase.GetInvoicesByInstallmentDateRange")
+	defer trace.End(span, &err)
+
+	return u.invoiceService.GetInvoicesByInstallmentDateRange(ctx, from, to, page)
+}
+
+// UpdateInvoice recalculates the amounts of an invoice and saves it if it is still at invoice.Version
+// (or at any version with AnyVersion). It returns the saved invoice with its new version.
+// The rate saved on creation is kept, unless the currency or the company changes, which the credit notes prevent
+// (ErrInvoiceCredited). The payment amount can't go below what they credit (ErrCreditExceedsBalance), nor the total
+// amount below what was paid (ErrPaymentExceedsBalance). With payments, the status is derived from them
+func (u *invoiceUsecase) UpdateInvoice(ctx context.Context, invoice *entity.Invoice) (_ *models.Invoice, err error) {
+	ctx, span := trace.Start(ctx, "InvoiceUsecase.UpdateInvoice")
+	defer trace.End(span, &err)
+
+	if err := CalculateItems(invoice); err != nil {
+		return nil, err
+	}
+
+	var invoiceM *models.Invoice
+	err = u.transaction.DoInTx(ctx, func(ctx context.Context) error {
+		current, err := u.invoiceService.GetInvoice(ctx, invoice.ID)
+		if err != nil {
+			return err
+		}
+		if invoice.Version == AnyVersion {
+			invoice.Version = current.Version
+		}
+
+		company, err := u.company(ctx, invoice.CompanyID)
+		if err != nil {
+			return err
+		}
+		if invoice.Currency == "" {
+			invoice.Currency = company.BaseCurrency
+		}
+		if err := checkCredits(invoice, current); err != nil {
+			return err
+		}
+		if invoice.Currency == current.Currency && invoice.CompanyID == current.CompanyID {
+			invoice.FXRate = current.FXRate
+		} else if invoice.FXRate, err = u.fxRate(ctx, invoice, company); err != nil {
+			return err
+		}
+		rounding, err := tax.ParseRounding(company.TaxRounding)
+		if err != nil {
+			return err
+		}
+		if err := CalculateAmounts(invoice, rounding); err != nil {
+			return err
+		}
+		if err := CheckInstallments(invoice); err != nil {
+			return err
+		}
+		if invoice.Status, err = paidStatus(invoice, current); err != nil {
+			return err
+		}
+
+		invoiceM, err = u.invoiceService.EntityToModel(ctx, invoice)
+		if err != nil {
+			log.Error(ctx, fmt.Errorf("failed to convert entity to model: %+v", err))
+			return err
+		}
+
+		if err := u.invoiceService.UpdateInvoice(ctx, invoiceM); err != nil {
+			return err
+		}
+
+		// Read it back as stored (rounded amounts, dates without time)
+		invoiceM, err = u.invoiceService.GetInvoice(ctx, invoice.ID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return invoiceM, nil
+}
+
+// DeleteInvoice deletes a saved invoice if it is still at the given version (or at any version with AnyVersion)
+func (u *invoiceUsecase) DeleteInvoice(ctx context.Context, id int64, version int64) (err error) {
+	ctx, span := trace.Start(ctx, "InvoiceUsecase.DeleteInvoice")
+	defer trace.End(span, &err)
+
+	return u.transaction.DoInTx(ctx, func(ctx context.Context) error {
+		if version == AnyVersion {
+			current, err := u.invoiceService.GetInvoice(ctx, id)
+			if err != nil {
+				return err
+			}
+			version = current.Version
+		}
+
+		return u.invoiceService.DeleteInvoice(ctx, id, version)
+	})
+}
+
+// recalculatePageSize is how many invoices RecalculateInvoices reads at once
+const recalculatePageSize = 500
+
+// RecalculateInvoices recalculates the fee, tax and total amount of the invoices due between from and to,
+// e.g. after the fee policy changed, and saves the ones which differ (unless dryRun).
+// Each invoice is saved in its own transaction at the version it was read, so concurrent changes are not overwritten
+func (u *invoiceUsecase) RecalculateInvoices(ctx context.Context, from time.Time, to time.Time, dryRun bool) (result RecalculateResult, err error) {
+	ctx, span := trace.Start(ctx, "InvoiceUsecase.RecalculateInvoices")
+	defer trace.End(span, &err)
+
+	roundings := map[int64]tax.Rounding{}
+	page := repository.Page{Limit: recalculatePageSize}
+	for {
+		invoices, err := u.invoiceService.GetInvoicesByDateRange(ctx, from, to, page)
+		if err != nil {
+			return result, err
+		}
+
+		for _, invoiceM := range invoices {
+			result.Checked++
+			invoice := invoiceEntity(invoiceM)
+			rounding, ok := roundings[invoice.CompanyID]
+			if !ok {
+				if rounding, err = u.taxRounding(ctx, invoice.CompanyID); err != nil {
+					return result, err
+				}
+				roundings[invoice.CompanyID] = rounding
+			}
+			if err := CalculateAmounts(invoice, rounding); err != nil {
+				return result, fmt.Errorf("failed to recalculate invoice %d: %w", invoice.ID, err)
+			}
+			if sameCents(invoice.FeeAmount, invoiceM.FeeAmount) && sameCents(invoice.TaxAmount, invoiceM.TaxAmount) &&
+				sameCents(invoice.TotalAmount, invoiceM.TotalAmount) && sameSubtotals(invoice.TaxSubtotals, invoiceM.R.GetInvoiceTaxSubtotals()) {
+				continue
+			}
+
+			if err := settleInstallments(invoice); err != nil {
+				return result, fmt.Errorf("failed to recalculate invoice %d: %w", invoice.ID, err)
+			}
+			result.Changed = append(result.Changed, invoice.ID)
+			if dryRun {
+				continue
+			}
+			if _, err := u.UpdateInvoice(ctx, invoice); err != nil {
+				return result, fmt.Errorf("failed to recalculate invoice %d: %w", invoice.ID, err)
+			}
+		}
+
+		if len(invoices) < page.Limit {
+			return result, nil
+		}
+		page.AfterID = invoices[len(invoices)-1].ID
+	}
+}
+
+// checkCredits checks an update keeps the credit notes of an invoice valid: it can't credit more than the new payment
+// amount, nor change the currency or the company the credit notes are in
+func checkCredits(invoice *entity.Invoice, current *models.Invoice) error {
+	if len(entity.Credits(current.R.GetCreditNotes())) == 0 {
+		return nil
+	}
+	if invoice.Currency != current.Currency || invoice.CompanyID != current.CompanyID {
+		return ErrInvoiceCredited
+	}
+	if credited := creditedAmount(current); math.Round(invoice.PaymentAmount*100) < math.Round(credited*100) {
+		return fmt.Errorf("%w: %.2f credited, payment amount %.2f", ErrCreditExceedsBalance, credited, invoice.PaymentAmount)
+	}
+	return nil
+}
+
+// invoiceEntity converts a saved invoice back to an entity, with its rate
+func invoiceEntity(invoice *models.Invoice) *entity.Invoice {
+	return &entity.Invoice{
+		ID:            invoice.ID,
+		CompanyID:     invoice.CompanyID,
+		ClientID:      invoice.ClientID,
+		IssueDate:     invoice.IssueDate,
+		DueDate:       invoice.DueDate,
+		PaymentAmount: conversion.DecimalToFloat(invoice.PaymentAmount),
+		FeeAmount:     conversion.DecimalToFloat(invoice.FeeAmount),
+		TaxAmount:     conversion.DecimalToFloat(invoice.TaxAmount),
+		TotalAmount:   conversion.DecimalToFloat(invoice.TotalAmount),
+		Status:        invoice.Status,
+		Version:       invoice.Version,
+		Currency:      invoice.Currency,
+		FXRate:        invoice.FXRate,
+		Items:         invoiceItemEntities(invoice.R.GetInvoiceItems()),
+		Installments:  installmentEntities(invoice.R.GetInvoiceInstallments()),
+	}
+}
+
+// installmentEntities converts saved installments back to entities
+func installmentEntities(installments models.InvoiceInstallmentSlice) []*entity.InvoiceInstallment {
+	if len(installments) == 0 {
+		return nil
+	}
+	entities := make([]*entity.InvoiceInstallment, len(installments))
+	for i, installment := range installments {
+		entities[i] = &entity.InvoiceInstallment{
+			DueDate: installment.DueDate,
+			Amount:  conversion.DecimalToFloat(installment.Amount),
+		}
+	}
+	return entities
+}
+
+// invoiceItemEntities converts saved items back to entities
+func invoiceItemEntities(items models.InvoiceItemSlice) []*entity.InvoiceItem {
+	if len(items) == 0 {
+		return nil
+	}
+	entities := make([]*entity.InvoiceItem, len(items))
+	for i, item := range items {
+		entities[i] = &entity.InvoiceItem{
+			Description: item.Description,
+			Quantity:    conversion.DecimalToFloat(item.Quantity),
+			UnitPrice:   conversion.DecimalToFloat(item.UnitPrice),
+			TaxCategory: item.TaxCategory,
+			Amount:      conversion.DecimalToFloat(item.Amount),
+		}
+	}
+	return entities
+}
+
+// company returns the company of an invoice, or repository.ErrNotFound
+func (u *invoiceUsecase) company(ctx context.Context, companyID int64) (*models.Company, error) {
+	company, err := u.companyService.GetCompany(ctx, companyID)
+	if err != nil {
+		return nil, fmt.Errorf("company %d: %w", companyID, err)
+	}
+	return company, nil
+}
+
+// taxRounding returns how the company rounds the consumption tax, or repository.ErrNotFound
+func (u *invoiceUsecase) taxRounding(ctx context.Context, companyID int64) (tax.Rounding, error) {
+	company, err := u.company(ctx, companyID)
+	if err != nil {
+		return "", err
+	}
+	return tax.ParseRounding(company.TaxRounding)
+}
+
+// fxRate returns the rate converting the currency of an invoice (the base currency of its company if empty,
+// which it then sets) to the base currency on its issue date: 1 for the base currency itself, or the latest rate
+// imported in the week before. It returns ErrNoFXRate without one
+func (u *invoiceUsecase) fxRate(ctx context.Context, invoice *entity.Invoice, company *models.Company) (types.Decimal, error) {
+	if invoice.Currency == "" {
+		invoice.Currency = company.BaseCurrency
+	}
+	if invoice.Currency == company.BaseCurrency {
+		return types.NewDecimal(decimal.New(1, 0)), nil
+	}
+
+	rate, err := u.fxRateService.GetFXRate(ctx, invoice.Currency, company.BaseCurrency, invoice.IssueDate)
+	if errors.Is(err, repository.ErrNotFound) || (err == nil && invoice.IssueDate.Sub(rate.RateDate) > fxRateMaxAge) {
+		return types.Decimal{}, fmt.Errorf("%w: %s in %s on %s", ErrNoFXRate,
+			invoice.Currency, company.BaseCurrency, invoice.IssueDate.Format("2006-01-02"))
+	}
+	if err != nil {
+		return types.Decimal{}, err
+	}
+	return rate.Rate, nil
+}
+
+// sameSubtotals reports whether calculated tax subtotals are the stored ones
+func sameSubtotals(calculated []*entity.InvoiceTaxSubtotal, stored models.InvoiceTaxSubtotalSlice) bool {
+	if len(calculated) != len(stored) {
+		return false
+	}
+	for i, subtotal := range calculated {
+		if subtotal.TaxCategory != stored[i].TaxCategory || !sameCents(subtotal.Amount, stored[i].Amount) ||
+			!sameCents(subtotal.TaxAmount, stored[i].TaxAmount) {
+			return false
+		}
+	}
+	return true
+}
+
+// sameCents reports whether a calculated amount is the stored one once rounded to cents, as the database does
+func sameCents(calculated float64, stored types.Decimal) bool {
+	return math.Round(calculated*100) == math.Round(conversion.DecimalToFloat(stored)*100)
+}
+
+// CalculateItems sets the amount of each item of an invoice, quantity * unit price rounded to cents,
+// and the payment amount to their total. A payment amount given with the items must be that total,
+// else it returns ErrPaymentAmountMismatch. Invoices without items keep their payment amount
+func CalculateItems(invoice *entity.Invoice) error {
+	if len(invoice.Items) == 0 {
+		return nil
+	}
+
+	var total float64
+	for _, item := range invoice.Items {
+		item.Amount = math.Round(item.Quantity*item.UnitPrice*100) / 100
+		total += item.Amount
+	}
+	total = math.Round(total*100) / 100
+	if invoice.PaymentAmount != 0 && math.Round(invoice.PaymentAmount*100) != math.Round(total*100) {
+		return fmt.Errorf("%w: %.2f, the items total %.2f", ErrPaymentAmountMismatch, invoice.PaymentAmount, total)
+	}
+	invoice.PaymentAmount = total
+	return nil
+}
+
+// CalculateAmounts sets the fee, tax and total amount of an invoice from its payment amount, and the tax subtotals
+// of its items. The consumption tax is rounded to the yen (the minor unit of the currency of the invoice) once per rate,
+// as the qualified invoice system requires
+func CalculateAmounts(invoice *entity.Invoice, rounding tax.Rounding) error {
+	digits := entity.CurrencyDigits[invoice.Currency]
+
+	// 4% fee
+	invoice.FeeAmount = invoice.PaymentAmount * 0.04
+
+	// 10% tax on the fee, which is tax excluded
+	feeTax, err := tax.Excluded([]tax.Line{{Category: entity.TaxCategoryStandard, Amount: cents(invoice.FeeAmount)}}, rounding, digits)
+	if err != nil {
+		return err
+	}
+	invoice.TaxAmount = bigToFloat(feeTax.Tax)
+
+	// The tax included in the items, which is already in the payment amount
+	lines := make([]tax.Line, len(invoice.Items))
+	for i, item := range invoice.Items {
+		lines[i] = tax.Line{Category: item.TaxCategory, Amount: cents(item.Amount)}
+	}
+	included, err := tax.Included(lines, rounding, digits)
+	if err != nil {
+		return err
+	}
+	invoice.TaxSubtotals = nil
+	for _, subtotal := range included.Subtotals {
+		invoice.TaxSubtotals = append(invoice.TaxSubtotals, &entity.InvoiceTaxSubtotal{
+			TaxCategory: subtotal.Category,
+			Rate:        int(subtotal.Rate),
+			Amount:      bigToFloat(subtotal.Amount),
+			TaxAmount:   bigToFloat(subtotal.Tax),
+		})
+	}
+
+	// total amount = payment amount + fee amount + tax amount
+	invoice.TotalAmount = invoice.PaymentAmount + invoice.FeeAmount + invoice.TaxAmount
+	return nil
+}
+
+// CheckInstallments checks the installments of an invoice, if any, add up to its total amount to the cent,
+// else it returns ErrInstallmentsMismatch
+func CheckInstallments(invoice *entity.Invoice) error {
+	if len(invoice.Installments) == 0 {
+		return nil
+	}
+
+	var total float64
+	for _, installment := range invoice.Installments {
+		total += installment.Amount
+	}
+	if math.Round(total*100) != math.Round(invoice.TotalAmount*100) {
+		return fmt.Errorf("%w: %.2f, the total amount %.2f", ErrInstallmentsMismatch, total, invoice.TotalAmount)
+	}
+	return nil
+}
+
+// settleInstallments moves the difference between the total amount of a recalculated invoice and its installments
+// to the last installment, the earlier ones having maybe been paid already. It returns ErrInstallmentsMismatch
+// if the total amount went down by as much as the last installment, which can't be left with nothing to pay
+func settleInstallments(invoice *entity.Invoice) error {
+	if len(invoice.Installments) == 0 {
+		return nil
+	}
+	var total float64
+	for _, installment := range invoice.Installments {
+		total += installment.Amount
+	}
+	last := invoice.Installments[len(invoice.Installments)-1]
+	amount := roundCents(last.Amount + invoice.TotalAmount - total)
+	if amount <= 0 {
+		return fmt.Errorf("%w: %.2f, the total amount %.2f leaves %.2f to the last installment",
+			ErrInstallmentsMismatch, total, invoice.TotalAmount, amount)
+	}
+	last.Amount = amount
+	return nil
+}
+
+// cents converts an amount to a decimal, rounded to cents as it is stored
+func cents(amount float64) *decimal.Big {
+	return decimal.New(int64(math.Round(amount*100)), 2)
+}
+
+func bigToFloat(d *decimal.Big) float64 {
+	f, _ := d.Float64()
+	return f
+}
@@ -0,0 +1,140 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"time"
+
+	"github.com/niko-cb/uct/internal/domain/entity"
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+	"github.com/niko-cb/uct/internal/domain/repository"
+	"github.com/niko-cb/uct/internal/domain/service"
+	"github.com/niko-cb/uct/internal/domain/tax"
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/trace"
+)
+
+// invoiceIssuerBatchSize is how many issuers are saved by transaction: the full data has millions of them
+const invoiceIssuerBatchSize = 1000
+
+type InvoiceIssuerUsecase interface {
+	ImportInvoiceIssuers(ctx context.Context, issuers iter.Seq2[*entity.InvoiceIssuer, error]) (saved int, deleted int, err error)
+	CheckRegistration(ctx context.Context, registrationNumber string, on time.Time) (string, *models.InvoiceIssuer, error)
+}
+
+var _ InvoiceIssuerUsecase = &invoiceIssuerUsecase{}
+
+type invoiceIssuerUsecase struct {
+	invoiceIssuerService service.InvoiceIssuerService
+	transaction          repository.Transaction
+}
+
+func NewInvoiceIssuerUsecase(invoiceIssuerService service.InvoiceIssuerService, transaction repository.Transaction) InvoiceIssuerUsecase {
+	return &invoiceIssuerUsecase{
+		invoiceIssuerService: invoiceIssuerService,
+		transaction:          transaction,
+	}
+}
+
+// ImportInvoiceIssuers saves the issuers read from the dataset of the National Tax Agency, replacing the ones of
+// the same registration number, and deletes the ones marked deleted. They are saved by batches, each in its own
+// transaction: when reading fails, the batches before are kept, and importing the file again completes them.
+// It returns the number of issuers saved and deleted
+func (u *invoiceIssuerUsecase) ImportInvoiceIssuers(ctx context.Context, issuers iter.Seq2[*entity.InvoiceIssuer, error]) (saved int, deleted int, err error) {
+	ctx, span := trace.Start(ctx, "InvoiceIssuerUsecase.ImportInvoiceIssuers")
+	defer trace.End(span, &err)
+
+	// The last state of each issuer of the batch, in the order they were read
+	batch := map[string]*entity.InvoiceIssuer{}
+	var order []string
+	flush := func() error {
+		if len(order) == 0 {
+			return nil
+		}
+		var upserts []*models.InvoiceIssuer
+		var deletes []string
+		for _, number := range order {
+			if issuer := batch[number]; issuer.Deleted {
+				deletes = append(deletes, number)
+			} else {
+				upserts = append(upserts, u.invoiceIssuerService.EntityToModel(issuer))
+			}
+		}
+		err := u.transaction.DoInTx(ctx, func(ctx context.Context) error {
+			if err := u.invoiceIssuerService.UpsertInvoiceIssuers(ctx, upserts); err != nil {
+				return err
+			}
+			return u.invoiceIssuerService.DeleteInvoiceIssuers(ctx, deletes)
+		})
+		if err != nil {
+			return err
+		}
+		saved, deleted = saved+len(upserts), deleted+len(deletes)
+		clear(batch)
+		order = order[:0]
+		return nil
+	}
+
+	for issuer, err := range issuers {
+		if err != nil {
+			return saved, deleted, err
+		}
+		if _, ok := batch[issuer.RegistrationNumber]; !ok {
+			order = append(order, issuer.RegistrationNumber)
+		}
+		batch[issuer.RegistrationNumber] = issuer
+		if len(order) == invoiceIssuerBatchSize {
+			if err := flush(); err != nil {
+				return saved, deleted, err
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		return saved, deleted, err
+	}
+	return saved, deleted, nil
+}
+
+// CheckRegistration returns the registration status of a registration number on a date, one of the Issuer
+// statuses of entity, with its issuer if it was imported. It returns tax.ErrInvalidRegistrationNumber
+// for an invalid number
+func (u *invoiceIssuerUsecase) CheckRegistration(ctx context.Context, registrationNumber string, on time.Time) (_ string, _ *models.InvoiceIssuer, err error) {
+	ctx, span := trace.Start(ctx, "InvoiceIssuerUsecase.CheckRegistration")
+	defer trace.End(span, &err)
+
+	number, err := tax.ParseRegistrationNumber(registrationNumber)
+	if err != nil {
+		return "", nil, err
+	}
+	issuer, err := u.invoiceIssuerService.GetInvoiceIssuer(ctx, number)
+	if errors.Is(err, repository.ErrNotFound) {
+		return entity.IssuerNotRegistered, nil, nil
+	}
+	if err != nil {
+		return "", nil, err
+	}
+	return issuerStatus(number, issuer, on), issuer, nil
+}
+
+// issuerStatus returns the registration status of a registration number on a date, given its issuer, nil if
+// the number was not imported
+func issuerStatus(registrationNumber string, issuer *models.InvoiceIssuer, on time.Time) string {
+	if registrationNumber == "" {
+		return entity.IssuerNoRegistrationNumber
+	}
+	if _, err := tax.ParseRegistrationNumber(registrationNumber); err != nil {
+		return entity.IssuerInvalidRegistrationNumber
+	}
+	switch {
+	case issuer == nil:
+		return entity.IssuerNotRegistered
+	case issuer.RegisteredOn.After(on):
+		return entity.IssuerNotYetRegistered
+	case issuer.RevokedOn.Valid && !issuer.RevokedOn.Time.After(on):
+		return entity.IssuerRevoked
+	case issuer.ExpiredOn.Valid && !issuer.ExpiredOn.Time.After(on):
+		return entity.IssuerExpired
+	default:
+		return entity.IssuerRegistered
+	}
+}
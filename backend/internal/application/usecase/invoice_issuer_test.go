@@ -0,0 +1,125 @@
+package usecase_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ericlagergren/decimal"
+	"github.com/niko-cb/uct/internal/application/usecase"
+	"github.com/niko-cb/uct/internal/domain/entity"
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+	"github.com/niko-cb/uct/internal/domain/nta"
+	"github.com/niko-cb/uct/internal/domain/repository"
+	"github.com/niko-cb/uct/internal/domain/service"
+	"github.com/niko-cb/uct/internal/domain/tax"
+	"github.com/niko-cb/uct/internal/infrastructure/gateway"
+	"github.com/niko-cb/uct/internal/infrastructure/persistent/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/types"
+)
+
+// TestInvoiceIssuers tests importing the issuers of the National Tax Agency, checking a registration number
+// and flagging the invoices of the clients which were not registered on the issue date
+func TestInvoiceIssuers(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	issuers := usecase.NewInvoiceIssuerUsecase(service.NewInvoiceIssuerService(gateway.NewInvoiceIssuerMemoryGateway(store)),
+		memory.NewTransaction(store))
+
+	// An issuer deleted from the publication is removed when it was imported before
+	require.NoError(t, store.Write(ctx, func(tables *memory.Tables) error {
+		tables.Issuers[1] = models.InvoiceIssuer{ID: 1, RegistrationNumber: "T8200001000005", Name: "deleted", RegisteredOn: day("2023-10-01")}
+		return nil
+	}))
+	f, err := os.Open(filepath.Join("..", "..", "domain", "nta", "testdata", "issuers.csv"))
+	require.NoError(t, err)
+	defer f.Close()
+	saved, deleted, err := issuers.ImportInvoiceIssuers(ctx, nta.NewReader(f).All())
+	require.NoError(t, err)
+	assert.Equal(t, 5, saved)
+	assert.Equal(t, 1, deleted)
+
+	// Importing a later difference replaces the issuer
+	saved, deleted, err = issuers.ImportInvoiceIssuers(ctx, nta.NewReader(strings.NewReader(
+		`1,T2010401000001,04,0,2,1,1,2023-10-01,2024-09-30,2024-09-30,,,,,,,,,株式会社サンプル商事`+"\n")).All())
+	require.NoError(t, err)
+	assert.Equal(t, 1, saved)
+	assert.Equal(t, 0, deleted)
+
+	// The reading error stops the import
+	_, _, err = issuers.ImportInvoiceIssuers(ctx, nta.NewReader(strings.NewReader("date,currency\n")).All())
+	assert.ErrorIs(t, err, nta.ErrInvalidRecord)
+
+	tests := []struct {
+		number string
+		on     string
+		status string
+	}{
+		{"t2010401000001", "2024-09-29", entity.IssuerRegistered},
+		{"T2010401000001", "2024-09-30", entity.IssuerRevoked},
+		{"T2010401000001", "2023-09-30", entity.IssuerNotYetRegistered},
+		{"T4010001000003", "2024-06-30", entity.IssuerExpired},
+		{"T5300001000006", "2024-06-30", entity.IssuerRegistered},
+		{"T8200001000005", "2024-06-30", entity.IssuerNotRegistered},
+		{"T7123456789012", "2024-06-30", entity.IssuerNotRegistered},
+	}
+	for _, tt := range tests {
+		status, _, err := issuers.CheckRegistration(ctx, tt.number, day(tt.on))
+		require.NoError(t, err)
+		assert.Equal(t, tt.status, status, "%s on %s", tt.number, tt.on)
+	}
+	_, _, err = issuers.CheckRegistration(ctx, "T1234567890123", day("2024-06-30"))
+	assert.ErrorIs(t, err, tax.ErrInvalidRegistrationNumber)
+
+	require.NoError(t, store.Write(ctx, func(tables *memory.Tables) error {
+		tables.Companies[1] = models.Company{ID: 1, Name: "company", BaseCurrency: "JPY"}
+		for id, client := range []struct {
+			number string
+			issued string
+		}{
+			{"T2010401000001", "2024-09-02"}, // registered
+			{"T2010401000001", "2024-10-01"}, // revoked on 2024-09-30
+			{"T4010001000003", "2024-07-01"}, // expired on 2024-06-30
+			{"T7123456789012", "2024-08-01"}, // never imported
+			{"", "2024-08-01"},
+			{"T5300001000006", "2023-12-31"}, // registered on 2024-01-01, before the report
+		} {
+			clientID := int64(id + 1)
+			tables.Clients[clientID] = models.Client{ID: clientID, CompanyID: 1, Name: "client",
+				RegistrationNumber: null.NewString(client.number, client.number != ""), Version: 1}
+			tables.Invoices[clientID] = models.Invoice{
+				ID: clientID, CompanyID: 1, ClientID: clientID, IssueDate: day(client.issued), DueDate: day(client.issued).AddDate(0, 1, 0),
+				PaymentAmount: types.NewDecimal(decimal.New(10000, 0)), FeeAmount: types.NewDecimal(decimal.New(400, 0)),
+				TaxAmount: types.NewDecimal(decimal.New(40, 0)), TotalAmount: types.NewDecimal(decimal.New(10440, 0)),
+				Status: "unprocessed", Currency: "JPY", FXRate: types.NewDecimal(decimal.New(1, 0)), Version: 1,
+			}
+		}
+		return nil
+	}))
+	reports := usecase.NewReportUsecase(
+		service.NewReportService(gateway.NewReportMemoryGateway(store)),
+		service.NewCompanyService(gateway.NewCompanyMemoryGateway(store)),
+	)
+
+	report, err := reports.GetIssuerReport(ctx, repository.IssuerFilter{CompanyID: 1, From: day("2024-01-01"), To: day("2024-12-31")})
+	require.NoError(t, err)
+	var statuses []string
+	for _, invoice := range report.Invoices {
+		statuses = append(statuses, invoice.RegistrationNumber+" "+invoice.IssueDate+" "+invoice.Status)
+	}
+	assert.Equal(t, []string{
+		"T4010001000003 2024-07-01 expired",
+		"T7123456789012 2024-08-01 not_registered",
+		" 2024-08-01 no_registration_number",
+		"T2010401000001 2024-10-01 revoked",
+	}, statuses)
+	assert.Equal(t, "10440", report.Invoices[0].TotalAmount.String())
+
+	_, err = reports.GetIssuerReport(ctx, repository.IssuerFilter{CompanyID: 2, From: day("2024-01-01"), To: day("2024-12-31")})
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+}
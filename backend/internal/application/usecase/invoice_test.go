@@ -6,10 +6,14 @@ import (
 	"testing"
 	"time"
 
+	"github.com/niko-cb/uct/internal/application/usecase"
 	"github.com/niko-cb/uct/internal/domain/entity"
 	"github.com/niko-cb/uct/internal/domain/entity/models"
+	"github.com/niko-cb/uct/internal/domain/repository"
+	"github.com/niko-cb/uct/internal/domain/tax"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 // Mocking the usecase
@@ -22,8 +26,8 @@ func (m *MockInvoiceUsecase) CreateInvoice(ctx context.Context, invoice *entity.
 	return args.Error(0)
 }
 
-func (m *MockInvoiceUsecase) GetInvoicesByDateRange(ctx context.Context, from time.Time, to time.Time) ([]*models.Invoice, error) {
-	args := m.Called(ctx, from, to)
+func (m *MockInvoiceUsecase) GetInvoicesByDateRange(ctx context.Context, from time.Time, to time.Time, page repository.Page) ([]*models.Invoice, error) {
+	args := m.Called(ctx, from, to, page)
 	return args.Get(0).([]*models.Invoice), args.Error(1)
 }
 
@@ -84,13 +88,108 @@ func TestGetInvoicesByDateRange_UseCase(t *testing.T) {
 	}
 
 	// Setup mock expectations
-	mockInvoiceUsecase.On("GetInvoicesByDateRange", mock.Anything, mock.Anything, mock.Anything).Return(expectedInvoices, nil)
+	mockInvoiceUsecase.On("GetInvoicesByDateRange", mock.Anything, mock.Anything, mock.Anything, repository.Page{}).Return(expectedInvoices, nil)
 
 	// Call
-	invoices, err := mockInvoiceUsecase.GetInvoicesByDateRange(ctx, time.Now().Add(-30*24*time.Hour), time.Now())
+	invoices, err := mockInvoiceUsecase.GetInvoicesByDateRange(ctx, time.Now().Add(-30*24*time.Hour), time.Now(), repository.Page{})
 
 	// Assert no error and correct result
 	assert.NoError(t, err)
 	assert.Equal(t, expectedInvoices, invoices)
 	mockInvoiceUsecase.AssertExpectations(t)
 }
+
+// TestCalculateItems tests the payment amount is derived from the items, and must match them when given
+func TestCalculateItems(t *testing.T) {
+	items := func() []*entity.InvoiceItem {
+		return []*entity.InvoiceItem{
+			{Description: "consulting", Quantity: 3, UnitPrice: 3333.333, TaxCategory: entity.TaxCategoryStandard},
+			{Description: "lunch boxes", Quantity: 0.5, UnitPrice: 1080, TaxCategory: entity.TaxCategoryReduced},
+		}
+	}
+	tests := []struct {
+		name          string
+		paymentAmount float64
+		items         []*entity.InvoiceItem
+		want          float64
+		err           error
+	}{
+		{"derived", 0, items(), 10540, nil},
+		{"matching", 10540.004, items(), 10540, nil},
+		{"mismatch", 10541, items(), 0, usecase.ErrPaymentAmountMismatch},
+		{"without items", 500, nil, 500, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			invoice := &entity.Invoice{PaymentAmount: tt.paymentAmount, Items: tt.items}
+
+			err := usecase.CalculateItems(invoice)
+
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, invoice.PaymentAmount)
+			if tt.items != nil {
+				assert.Equal(t, []float64{10000, 540}, []float64{invoice.Items[0].Amount, invoice.Items[1].Amount})
+			}
+		})
+	}
+}
+
+// TestCalculateAmounts tests the fee, its tax rounded the way of the company, and the tax subtotals of the items
+func TestCalculateAmounts(t *testing.T) {
+	item := func(amount float64, category string) *entity.InvoiceItem {
+		return &entity.InvoiceItem{Description: "item", Quantity: 1, UnitPrice: amount, TaxCategory: category, Amount: amount}
+	}
+	tests := []struct {
+		name          string
+		paymentAmount float64
+		items         []*entity.InvoiceItem
+		rounding      tax.Rounding
+		fee, tax      float64
+		subtotals     []entity.InvoiceTaxSubtotal
+	}{
+		{"exact", 10000, nil, tax.RoundingFloor, 400, 40, nil},
+		{"fee tax floor", 10010, nil, tax.RoundingFloor, 400.4, 40, nil},
+		{"fee tax round", 10010, nil, tax.RoundingRound, 400.4, 40, nil},
+		{"fee tax ceil", 10010, nil, tax.RoundingCeil, 400.4, 41, nil},
+		{"fee tax half", 12500, nil, tax.RoundingRound, 500, 50, nil},
+		{"fee tax half up", 12625, nil, tax.RoundingRound, 505, 51, nil},
+		{
+			"items by rate", 3240,
+			[]*entity.InvoiceItem{item(1000, "standard"), item(1000, "reduced"), item(1240, "reduced")},
+			tax.RoundingFloor, 129.6, 12,
+			[]entity.InvoiceTaxSubtotal{
+				{TaxCategory: "standard", Rate: 10, Amount: 1000, TaxAmount: 90},
+				{TaxCategory: "reduced", Rate: 8, Amount: 2240, TaxAmount: 165},
+			},
+		},
+		{
+			"items rounded once per rate", 315,
+			[]*entity.InvoiceItem{item(105, "standard"), item(105, "standard"), item(105, "standard")},
+			tax.RoundingCeil, 12.6, 2,
+			[]entity.InvoiceTaxSubtotal{{TaxCategory: "standard", Rate: 10, Amount: 315, TaxAmount: 29}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			invoice := &entity.Invoice{PaymentAmount: tt.paymentAmount, Items: tt.items}
+
+			require.NoError(t, usecase.CalculateAmounts(invoice, tt.rounding))
+
+			assert.InDelta(t, tt.fee, invoice.FeeAmount, 1e-9)
+			assert.Equal(t, tt.tax, invoice.TaxAmount)
+			assert.InDelta(t, tt.paymentAmount+tt.fee+tt.tax, invoice.TotalAmount, 1e-9)
+			var subtotals []entity.InvoiceTaxSubtotal
+			for _, subtotal := range invoice.TaxSubtotals {
+				subtotals = append(subtotals, *subtotal)
+			}
+			assert.Equal(t, tt.subtotals, subtotals)
+		})
+	}
+
+	err := usecase.CalculateAmounts(&entity.Invoice{PaymentAmount: 100}, "truncate")
+	assert.ErrorIs(t, err, tax.ErrInvalidRounding)
+}
@@ -0,0 +1,135 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/niko-cb/uct/internal/domain/entity"
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+	"github.com/niko-cb/uct/internal/domain/repository"
+	"github.com/niko-cb/uct/internal/domain/service"
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/log"
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/trace"
+	"github.com/volatiletech/sqlboiler/v4/types"
+)
+
+// ErrPaymentExceedsBalance is returned when the payments of an invoice would pay more than its outstanding balance
+var ErrPaymentExceedsBalance = errors.New("the payments exceed the outstanding balance of the invoice")
+
+type PaymentUsecase interface {
+	RecordPayment(ctx context.Context, payment *entity.Payment) (*models.Invoice, error)
+	GetPayments(ctx context.Context, invoiceID int64) (models.InvoicePaymentSlice, error)
+}
+
+var _ PaymentUsecase = &paymentUsecase{}
+
+type paymentUsecase struct {
+	paymentService service.PaymentService
+	invoiceService service.InvoiceService
+	transaction    repository.Transaction
+}
+
+func NewPaymentUsecase(paymentService service.PaymentService, invoiceService service.InvoiceService,
+	transaction repository.Transaction) PaymentUsecase {
+	return &paymentUsecase{
+		paymentService: paymentService,
+		invoiceService: invoiceService,
+		transaction:    transaction,
+	}
+}
+
+// RecordPayment saves a payment of an invoice, part of its outstanding balance or all of it, and returns the invoice
+// with its status derived from its payments: partially_paid, or paid once nothing is left. The invoice moves to
+// a new version even if its status doesn't change, so concurrent payments can't both pass the balance check: the
+// second one fails with repository.ErrVersionConflict. It returns repository.ErrNotFound without invoice,
+// and ErrPaymentExceedsBalance if less is outstanding than paid
+func (u *paymentUsecase) RecordPayment(ctx context.Context, payment *entity.Payment) (_ *models.Invoice, err error) {
+	ctx, span := trace.Start(ctx, "PaymentUsecase.RecordPayment")
+	defer trace.End(span, &err)
+
+	var invoiceM *models.Invoice
+	err = u.transaction.DoInTx(ctx, func(ctx context.Context) error {
+		invoice, err := u.invoiceService.GetInvoice(ctx, payment.InvoiceID)
+		if err != nil {
+			return err
+		}
+		outstanding := entity.OutstandingAmount(invoice)
+		if amount := cents(payment.Amount); amount.Cmp(outstanding.Big) > 0 {
+			return fmt.Errorf("%w: %s paid, %s outstanding", ErrPaymentExceedsBalance, amount, outstanding.Big)
+		}
+
+		paymentM, err := u.paymentService.EntityToModel(payment)
+		if err != nil {
+			log.Error(ctx, fmt.Errorf("failed to convert entity to model: %+v", err))
+			return err
+		}
+		if err := u.paymentService.CreatePayment(ctx, paymentM); err != nil {
+			log.Error(ctx, fmt.Errorf("failed to create payment: %+v", err))
+			return err
+		}
+		payment.ID = paymentM.ID
+
+		paid, err := u.invoiceService.GetInvoice(ctx, invoice.ID)
+		if err != nil {
+			return err
+		}
+		if err := u.invoiceService.UpdateInvoiceStatus(ctx, invoice.ID, invoice.Version, entity.PaymentStatus(paid)); err != nil {
+			return err
+		}
+		invoiceM, err = u.invoiceService.GetInvoice(ctx, invoice.ID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return invoiceM, nil
+}
+
+// GetPayments retrieves the payments of an invoice, ordered by id, or repository.ErrNotFound without invoice
+func (u *paymentUsecase) GetPayments(ctx context.Context, invoiceID int64) (_ models.InvoicePaymentSlice, err error) {
+	ctx, span := trace.Start(ctx, "PaymentUsecase.GetPayments")
+	defer trace.End(span, &err)
+
+	invoice, err := u.invoiceService.GetInvoice(ctx, invoiceID)
+	if err != nil {
+		return nil, err
+	}
+	payments := invoice.R.GetInvoicePayments()
+	if payments == nil {
+		payments = models.InvoicePaymentSlice{}
+	}
+	return payments, nil
+}
+
+// paidStatus returns the status of an invoice being updated, derived from the payments and the credit notes of the
+// current one if it has payments. It returns ErrPaymentExceedsBalance if the new total amount is less than they cover
+func paidStatus(invoice *entity.Invoice, current *models.Invoice) (string, error) {
+	if len(current.R.GetInvoicePayments()) == 0 {
+		return invoice.Status, nil
+	}
+
+	updated := &models.Invoice{Status: invoice.Status, TotalAmount: types.NewDecimal(cents(invoice.TotalAmount))}
+	updated.R = updated.R.NewStruct()
+	updated.R.CreditNotes = current.R.GetCreditNotes()
+	updated.R.InvoicePayments = current.R.GetInvoicePayments()
+	if outstanding := entity.OutstandingAmount(updated); outstanding.Big.Sign() < 0 {
+		return "", fmt.Errorf("%w: total amount %.2f, %s paid", ErrPaymentExceedsBalance,
+			invoice.TotalAmount, entity.PaidAmount(current).Big)
+	}
+	return entity.PaymentStatus(updated), nil
+}
+
+// syncPaymentStatus sets the status of an invoice with payments to the one derived from them and its credit notes,
+// after they changed. Invoices without payments, or already at that status, are left alone
+func syncPaymentStatus(ctx context.Context, invoiceService service.InvoiceService, invoiceID int64) error {
+	invoice, err := invoiceService.GetInvoice(ctx, invoiceID)
+	if err != nil {
+		return err
+	}
+	if status := entity.PaymentStatus(invoice); status != invoice.Status {
+		return invoiceService.UpdateInvoiceStatus(ctx, invoice.ID, invoice.Version, status)
+	}
+	return nil
+}
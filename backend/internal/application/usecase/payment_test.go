@@ -0,0 +1,98 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/niko-cb/uct/internal/application/usecase"
+	"github.com/niko-cb/uct/internal/domain/entity"
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+	"github.com/niko-cb/uct/internal/domain/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPayments tests the installments of an invoice, the partial payments against it and the status derived
+// from them and the credit notes
+func TestPayments(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture(t)
+	creditNotes := f.creditNotes()
+	payments := f.payments()
+
+	newInvoice := func(installments ...float64) *entity.Invoice {
+		invoice := &entity.Invoice{
+			CompanyID: 1, ClientID: 1, IssueDate: day("2024-07-01"), DueDate: day("2024-07-31"),
+			PaymentAmount: 10000, Status: "unprocessed",
+		}
+		for i, amount := range installments {
+			dueDate := day("2024-07-31").AddDate(0, 0, (i+1-len(installments))*16)
+			invoice.Installments = append(invoice.Installments, &entity.InvoiceInstallment{DueDate: dueDate, Amount: amount})
+		}
+		return invoice
+	}
+	pay := func(amount float64) (*models.Invoice, error) {
+		return payments.RecordPayment(ctx, &entity.Payment{InvoiceID: 1, PaymentDate: day("2024-07-15"), Amount: amount})
+	}
+
+	// The total amount of 10000 is 10440.00, due in two installments on 2024-07-15 and 2024-07-31
+	require.NoError(t, f.invoices.CreateInvoice(ctx, newInvoice(5000, 5440)))
+	err := f.invoices.CreateInvoice(ctx, newInvoice(5000, 5000))
+	assert.ErrorIs(t, err, usecase.ErrInstallmentsMismatch)
+
+	listed, err := f.invoices.GetInvoicesByInstallmentDateRange(ctx, day("2024-07-15"), day("2024-07-15"), repository.Page{})
+	require.NoError(t, err)
+	require.Len(t, listed, 1)
+	installments := listed[0].R.GetInvoiceInstallments()
+	require.Len(t, installments, 2)
+	assert.Equal(t, []int{1, 2}, []int{installments[0].InstallmentNo, installments[1].InstallmentNo})
+	assert.Equal(t, "5440.00", installments[1].Amount.String())
+	listed, err = f.invoices.GetInvoicesByInstallmentDateRange(ctx, day("2024-07-16"), day("2024-07-30"), repository.Page{})
+	require.NoError(t, err)
+	assert.Empty(t, listed)
+
+	// The first installment is paid, the invoice is partially paid
+	invoice, err := pay(5000)
+	require.NoError(t, err)
+	assert.Equal(t, entity.InvoiceStatusPartiallyPaid, invoice.Status)
+	assert.Equal(t, "5440.00", entity.OutstandingAmount(invoice).String())
+	assert.Equal(t, int64(2), invoice.Version)
+	_, err = pay(5440.01)
+	assert.ErrorIs(t, err, usecase.ErrPaymentExceedsBalance)
+	_, err = payments.RecordPayment(ctx, &entity.Payment{InvoiceID: 2, PaymentDate: day("2024-07-15"), Amount: 1})
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+
+	// A credit note of 1000 (1044.00 with the fee and its tax) leaves 4396.00, which pays the invoice
+	creditNote, err := creditNotes.CreateCreditNote(ctx, &entity.CreditNote{
+		InvoiceID: 1, IssueDate: day("2024-07-20"), Reason: "overbilled", PaymentAmount: 1000,
+	})
+	require.NoError(t, err)
+	invoice, err = pay(4396)
+	require.NoError(t, err)
+	assert.Equal(t, entity.InvoiceStatusPaid, invoice.Status)
+	assert.Equal(t, "0.00", entity.OutstandingAmount(invoice).String())
+	paid, err := payments.GetPayments(ctx, 1)
+	require.NoError(t, err)
+	assert.Len(t, paid, 2)
+
+	// Voiding the credit note leaves its amount to pay again
+	_, err = creditNotes.UpdateCreditNoteStatus(ctx, creditNote.ID, usecase.AnyVersion, entity.CreditNoteStatusVoid)
+	require.NoError(t, err)
+	invoice, err = f.invoices.GetInvoice(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, entity.InvoiceStatusPartiallyPaid, invoice.Status)
+	assert.Equal(t, "1044.00", entity.OutstandingAmount(invoice).String())
+
+	// An update keeps the status derived from the payments, and can't lower the total amount below them
+	update := newInvoice()
+	update.ID = 1
+	updated, err := f.invoices.UpdateInvoice(ctx, update)
+	require.NoError(t, err)
+	assert.Equal(t, entity.InvoiceStatusPartiallyPaid, updated.Status)
+	assert.Empty(t, updated.R.GetInvoiceInstallments())
+	update = newInvoice()
+	update.ID = 1
+	update.PaymentAmount = 8000
+	_, err = f.invoices.UpdateInvoice(ctx, update)
+	assert.ErrorIs(t, err, usecase.ErrPaymentExceedsBalance)
+}
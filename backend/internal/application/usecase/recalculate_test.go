@@ -0,0 +1,106 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/niko-cb/uct/internal/application/usecase"
+	"github.com/niko-cb/uct/internal/conversion"
+	"github.com/niko-cb/uct/internal/domain/entity"
+	"github.com/niko-cb/uct/internal/domain/tax"
+	"github.com/niko-cb/uct/internal/infrastructure/persistent/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRecalculateInvoices tests only the invoices whose stored amounts differ from the calculated ones are saved,
+// e.g. after the company changed its tax rounding
+func TestRecalculateInvoices(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture(t)
+
+	due := day("2024-04-30")
+	for _, amount := range []float64{10000, 20000, 30010} {
+		require.NoError(t, f.invoices.CreateInvoice(ctx, &entity.Invoice{
+			CompanyID: 1, ClientID: 1, IssueDate: due.AddDate(0, 0, -30), DueDate: due, PaymentAmount: amount, Status: "unprocessed",
+		}))
+	}
+
+	// The second invoice was saved with an outdated fee
+	stale, err := f.invoiceRepo.GetInvoice(ctx, 2)
+	require.NoError(t, err)
+	stale.FeeAmount, _ = conversion.ConvertToDecimal(600)
+	require.NoError(t, f.invoiceRepo.UpdateInvoice(ctx, stale))
+
+	from, to := due.AddDate(0, -1, 0), due
+	result, err := f.invoices.RecalculateInvoices(ctx, from, to, true)
+	require.NoError(t, err)
+	assert.Equal(t, usecase.RecalculateResult{Checked: 3, Changed: []int64{2}}, result)
+	unchanged, err := f.invoiceRepo.GetInvoice(ctx, 2)
+	require.NoError(t, err)
+	assert.Equal(t, "600.00", unchanged.FeeAmount.String())
+
+	result, err = f.invoices.RecalculateInvoices(ctx, from, to, false)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{2}, result.Changed)
+	fixed, err := f.invoiceRepo.GetInvoice(ctx, 2)
+	require.NoError(t, err)
+	assert.Equal(t, "800.00", fixed.FeeAmount.String())
+	assert.Equal(t, int64(3), fixed.Version)
+
+	result, err = f.invoices.RecalculateInvoices(ctx, from, to, false)
+	require.NoError(t, err)
+	assert.Empty(t, result.Changed)
+
+	// Rounding the tax up only changes the invoice whose fee tax has a fraction of yen (1200.40 * 10%)
+	require.NoError(t, f.companyService.UpdateTaxRounding(ctx, 1, tax.RoundingCeil))
+	result, err = f.invoices.RecalculateInvoices(ctx, from, to, false)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{3}, result.Changed)
+	rounded, err := f.invoiceRepo.GetInvoice(ctx, 3)
+	require.NoError(t, err)
+	assert.Equal(t, "121.00", rounded.TaxAmount.String())
+}
+
+// TestRecalculateInvoices_Installments tests the difference of a recalculated total amount goes to the last installment,
+// and a total amount going down by as much as the last installment is refused
+func TestRecalculateInvoices_Installments(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture(t)
+
+	// The total amount of 10000 is 10440.00, saved with an outdated fee of 600 as 10640.00 in two installments
+	require.NoError(t, f.invoices.CreateInvoice(ctx, &entity.Invoice{
+		CompanyID: 1, ClientID: 1, IssueDate: day("2024-07-01"), DueDate: day("2024-07-31"), PaymentAmount: 10000,
+		Status: "unprocessed", Installments: []*entity.InvoiceInstallment{
+			{DueDate: day("2024-07-15"), Amount: 5000}, {DueDate: day("2024-07-31"), Amount: 5440},
+		},
+	}))
+	installments := func(amounts ...float64) {
+		require.NoError(t, f.store.Write(ctx, func(tables *memory.Tables) error {
+			invoice := tables.Invoices[1]
+			invoice.FeeAmount, _ = conversion.ConvertToDecimal(600)
+			invoice.TotalAmount, _ = conversion.ConvertToDecimal(10640)
+			tables.Invoices[1] = invoice
+			for id, installment := range tables.Installments {
+				installment.Amount, _ = conversion.ConvertToDecimal(amounts[installment.InstallmentNo-1])
+				tables.Installments[id] = installment
+			}
+			return nil
+		}))
+	}
+	from, to := day("2024-07-01"), day("2024-07-31")
+
+	installments(10500, 140)
+	_, err := f.invoices.RecalculateInvoices(ctx, from, to, true)
+	assert.ErrorIs(t, err, usecase.ErrInstallmentsMismatch)
+
+	installments(5000, 5640)
+	result, err := f.invoices.RecalculateInvoices(ctx, from, to, false)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{1}, result.Changed)
+	fixed, err := f.invoiceRepo.GetInvoice(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "10440.00", fixed.TotalAmount.String())
+	assert.Equal(t, []string{"5000.00", "5440.00"}, []string{fixed.R.GetInvoiceInstallments()[0].Amount.String(),
+		fixed.R.GetInvoiceInstallments()[1].Amount.String()})
+}
@@ -0,0 +1,328 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/niko-cb/uct/internal/conversion"
+	"github.com/niko-cb/uct/internal/domain/entity"
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+	"github.com/niko-cb/uct/internal/domain/repository"
+	"github.com/niko-cb/uct/internal/domain/service"
+	"github.com/niko-cb/uct/internal/domain/tax"
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/log"
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/trace"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/types"
+)
+
+var (
+	// ErrInvalidRecurringStatus is returned for a status which is not one of entity.RecurringStatuses
+	ErrInvalidRecurringStatus = errors.New("unknown recurring invoice status, expected active or paused")
+	// ErrNoOccurrence is returned when the schedule of a recurring invoice ends before its first occurrence
+	ErrNoOccurrence = errors.New("the schedule of the recurring invoice has no occurrence")
+)
+
+type RecurringInvoiceUsecase interface {
+	CreateRecurringInvoice(ctx context.Context, recurring *entity.RecurringInvoice) (*models.RecurringInvoice, error)
+	GetRecurringInvoice(ctx context.Context, id int64) (*models.RecurringInvoice, error)
+	GetRecurringInvoicesByClient(ctx context.Context, clientID int64) ([]*models.RecurringInvoice, error)
+	UpdateRecurringInvoiceStatus(ctx context.Context, id int64, version int64, status string, asOf time.Time) (*models.RecurringInvoice, error)
+	PreviewOccurrences(ctx context.Context, id int64, asOf time.Time, count int) ([]*entity.Occurrence, error)
+	GenerateInvoices(ctx context.Context, asOf time.Time) (GenerateResult, error)
+}
+
+// maxGenerateRetries is how many times an occurrence is generated again after a version conflict, before its
+// recurring invoice is counted as failed until the next run
+const maxGenerateRetries = 3
+
+// GenerateResult lists the invoices created by GenerateInvoices, and counts the recurring invoices which failed
+type GenerateResult struct {
+	Generated []int64
+	// Failed recurring invoices keep their occurrence, billed by the next run
+	Failed int
+}
+
+var _ RecurringInvoiceUsecase = &recurringInvoiceUsecase{}
+
+type recurringInvoiceUsecase struct {
+	recurringInvoiceService service.RecurringInvoiceService
+	companyService          service.CompanyService
+	invoices                InvoiceUsecase
+	transaction             repository.Transaction
+}
+
+func NewRecurringInvoiceUsecase(recurringInvoiceService service.RecurringInvoiceService, companyService service.CompanyService,
+	invoices InvoiceUsecase, transaction repository.Transaction) RecurringInvoiceUsecase {
+	return &recurringInvoiceUsecase{
+		recurringInvoiceService: recurringInvoiceService,
+		companyService:          companyService,
+		invoices:                invoices,
+		transaction:             transaction,
+	}
+}
+
+// CreateRecurringInvoice saves a recurring invoice, active from its first occurrence on or after its start date.
+// The currency is the base currency of the company if empty. It returns repository.ErrNotFound without company,
+// and ErrNoOccurrence if the schedule ends before its first occurrence
+func (u *recurringInvoiceUsecase) CreateRecurringInvoice(ctx context.Context, recurring *entity.RecurringInvoice) (_ *models.RecurringInvoice, err error) {
+	ctx, span := trace.Start(ctx, "RecurringInvoiceUsecase.CreateRecurringInvoice")
+	defer trace.End(span, &err)
+
+	var recurringM *models.RecurringInvoice
+	err = u.transaction.DoInTx(ctx, func(ctx context.Context) error {
+		company, err := u.companyService.GetCompany(ctx, recurring.CompanyID)
+		if err != nil {
+			return fmt.Errorf("company %d: %w", recurring.CompanyID, err)
+		}
+		if recurring.Currency == "" {
+			recurring.Currency = company.BaseCurrency
+		}
+		recurring.Status = entity.RecurringStatusActive
+
+		recurringM, err = u.recurringInvoiceService.EntityToModel(recurring)
+		if err != nil {
+			log.Error(ctx, fmt.Errorf("failed to convert entity to model: %+v", err))
+			return err
+		}
+		next, ok := entity.NextOccurrence(recurringM, recurringM.StartDate)
+		if !ok {
+			return ErrNoOccurrence
+		}
+		recurringM.NextDate = null.TimeFrom(next)
+		if err := u.recurringInvoiceService.CreateRecurringInvoice(ctx, recurringM); err != nil {
+			log.Error(ctx, fmt.Errorf("failed to create recurring invoice: %+v", err))
+			return err
+		}
+
+		// Read it back as stored (rounded amount, dates without time)
+		recurringM, err = u.recurringInvoiceService.GetRecurringInvoice(ctx, recurringM.ID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	recurring.ID = recurringM.ID
+	return recurringM, nil
+}
+
+// GetRecurringInvoice retrieves a saved recurring invoice, or repository.ErrNotFound
+func (u *recurringInvoiceUsecase) GetRecurringInvoice(ctx context.Context, id int64) (_ *models.RecurringInvoice, err error) {
+	ctx, span := trace.Start(ctx, "RecurringInvoiceUsecase.GetRecurringInvoice")
+	defer trace.End(span, &err)
+
+	return u.recurringInvoiceService.GetRecurringInvoice(ctx, id)
+}
+
+// GetRecurringInvoicesByClient retrieves the recurring invoices of a client
+func (u *recurringInvoiceUsecase) GetRecurringInvoicesByClient(ctx context.Context, clientID int64) (_ []*models.RecurringInvoice, err error) {
+	ctx, span := trace.Start(ctx, "RecurringInvoiceUsecase.GetRecurringInvoicesByClient")
+	defer trace.End(span, &err)
+
+	return u.recurringInvoiceService.GetRecurringInvoicesByClient(ctx, clientID)
+}
+
+// UpdateRecurringInvoiceStatus pauses or resumes a recurring invoice if it is still at version (or at any version
+// with AnyVersion), and returns it with its new version. A resumed recurring invoice skips the occurrences before
+// asOf, missed while it was paused, which don't count toward its maximum. It returns ErrInvalidRecurringStatus
+// for an unknown status
+func (u *recurringInvoiceUsecase) UpdateRecurringInvoiceStatus(ctx context.Context, id int64, version int64, status string, asOf time.Time) (_ *models.RecurringInvoice, err error) {
+	ctx, span := trace.Start(ctx, "RecurringInvoiceUsecase.UpdateRecurringInvoiceStatus")
+	defer trace.End(span, &err)
+
+	if !slices.Contains(entity.RecurringStatuses, status) {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidRecurringStatus, status)
+	}
+
+	var recurringM *models.RecurringInvoice
+	err = u.transaction.DoInTx(ctx, func(ctx context.Context) error {
+		current, err := u.recurringInvoiceService.GetRecurringInvoice(ctx, id)
+		if err != nil {
+			return err
+		}
+		if version == AnyVersion {
+			version = current.Version
+		}
+		if version != current.Version {
+			return repository.ErrVersionConflict
+		}
+		if status == current.Status {
+			recurringM = current
+			return nil
+		}
+
+		next, skipped := current.NextDate, current.SkippedCount
+		if status == entity.RecurringStatusActive {
+			next, skipped = resume(current, asOf)
+		}
+		if err := u.recurringInvoiceService.UpdateRecurringInvoiceSchedule(ctx, id, version, status, next, skipped); err != nil {
+			return err
+		}
+		recurringM, err = u.recurringInvoiceService.GetRecurringInvoice(ctx, id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return recurringM, nil
+}
+
+// PreviewOccurrences returns the next count invoices a recurring invoice will generate, with their amounts calculated
+// like CreateInvoice does and rounded to cents as they are stored. A paused one is previewed as if resumed at asOf
+func (u *recurringInvoiceUsecase) PreviewOccurrences(ctx context.Context, id int64, asOf time.Time, count int) (_ []*entity.Occurrence, err error) {
+	ctx, span := trace.Start(ctx, "RecurringInvoiceUsecase.PreviewOccurrences")
+	defer trace.End(span, &err)
+
+	recurring, err := u.recurringInvoiceService.GetRecurringInvoice(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	company, err := u.companyService.GetCompany(ctx, recurring.CompanyID)
+	if err != nil {
+		return nil, fmt.Errorf("company %d: %w", recurring.CompanyID, err)
+	}
+	rounding, err := tax.ParseRounding(company.TaxRounding)
+	if err != nil {
+		return nil, err
+	}
+
+	occurrences := []*entity.Occurrence{}
+	next := recurring.NextDate
+	if recurring.Status == entity.RecurringStatusPaused {
+		resumed := *recurring
+		resumed.NextDate, resumed.SkippedCount = resume(recurring, asOf)
+		recurring, next = &resumed, resumed.NextDate
+	}
+	if !next.Valid {
+		return occurrences, nil
+	}
+	// The schedule is walked once to the next occurrence, then the occurrences are taken by index
+	n, ok := entity.NextOccurrenceIndex(recurring, next.Time)
+	for ; ok && len(occurrences) < count && entity.InSchedule(recurring, n); n++ {
+		invoice := occurrenceInvoice(recurring, entity.NthOccurrence(recurring, n))
+		if err := CalculateAmounts(invoice, rounding); err != nil {
+			return nil, err
+		}
+		occurrences = append(occurrences, &entity.Occurrence{
+			IssueDate: invoice.IssueDate, DueDate: invoice.DueDate,
+			PaymentAmount: types.NewDecimal(cents(invoice.PaymentAmount)), FeeAmount: types.NewDecimal(cents(invoice.FeeAmount)),
+			TaxAmount: types.NewDecimal(cents(invoice.TaxAmount)), TotalAmount: types.NewDecimal(cents(invoice.TotalAmount)),
+			Currency: invoice.Currency,
+		})
+	}
+
+	return occurrences, nil
+}
+
+// GenerateInvoices creates the invoices of the occurrences due on or before asOf of the active recurring invoices,
+// through CreateInvoice. Each invoice is created with the move of its recurring invoice to the next occurrence,
+// in a transaction at the version read: an occurrence is billed once however many generators run, and a generator
+// stopped halfway bills the occurrences left on its next run. The recurring invoices which fail (e.g. without
+// rate for their currency, or still changed concurrently after maxGenerateRetries) are logged and counted, and don't
+// stop the others. It stops with the error of ctx once cancelled
+func (u *recurringInvoiceUsecase) GenerateInvoices(ctx context.Context, asOf time.Time) (result GenerateResult, err error) {
+	ctx, span := trace.Start(ctx, "RecurringInvoiceUsecase.GenerateInvoices")
+	defer trace.End(span, &err)
+
+	due, err := u.recurringInvoiceService.GetDueRecurringInvoices(ctx, asOf)
+	if err != nil {
+		return result, err
+	}
+
+	for _, recurring := range due {
+		// The occurrences missed since the last run are caught up one by one
+		conflicts := 0
+		for {
+			if err := ctx.Err(); err != nil {
+				return result, err
+			}
+			invoiceID, err := u.generate(ctx, recurring.ID, asOf)
+			if errors.Is(err, repository.ErrVersionConflict) && conflicts < maxGenerateRetries {
+				// Changed by a concurrent generator or user, read again
+				conflicts++
+				continue
+			}
+			if err != nil {
+				log.Error(ctx, fmt.Errorf("failed to generate the invoice of recurring invoice %d: %+v", recurring.ID, err))
+				result.Failed++
+				break
+			}
+			if invoiceID == 0 {
+				break
+			}
+			result.Generated = append(result.Generated, invoiceID)
+			conflicts = 0
+		}
+	}
+
+	return result, nil
+}
+
+// generate creates the invoice of the next occurrence of a recurring invoice if it is active and due on or before
+// asOf, and moves it to the occurrence after. It returns the id of the invoice, 0 if there was nothing to bill
+func (u *recurringInvoiceUsecase) generate(ctx context.Context, id int64, asOf time.Time) (invoiceID int64, err error) {
+	err = u.transaction.DoInTx(ctx, func(ctx context.Context) error {
+		invoiceID = 0
+		recurring, err := u.recurringInvoiceService.GetRecurringInvoice(ctx, id)
+		if err != nil {
+			return err
+		}
+		if recurring.Status != entity.RecurringStatusActive || !recurring.NextDate.Valid || recurring.NextDate.Time.After(asOf) {
+			return nil
+		}
+
+		invoice := occurrenceInvoice(recurring, recurring.NextDate.Time)
+		if err := u.invoices.CreateInvoice(ctx, invoice); err != nil {
+			return err
+		}
+		err = u.recurringInvoiceService.UpdateRecurringInvoiceSchedule(ctx, id, recurring.Version, recurring.Status,
+			nextOccurrence(recurring, recurring.NextDate.Time), recurring.SkippedCount)
+		if err != nil {
+			return err
+		}
+		invoiceID = invoice.ID
+		return nil
+	})
+	return invoiceID, err
+}
+
+// occurrenceInvoice returns the invoice of a recurring invoice issued on an occurrence
+func occurrenceInvoice(recurring *models.RecurringInvoice, occurrence time.Time) *entity.Invoice {
+	return &entity.Invoice{
+		CompanyID:     recurring.CompanyID,
+		ClientID:      recurring.ClientID,
+		IssueDate:     occurrence,
+		DueDate:       occurrence.AddDate(0, 0, recurring.DueDays),
+		PaymentAmount: conversion.DecimalToFloat(recurring.PaymentAmount),
+		Currency:      recurring.Currency,
+		Status:        entity.InvoiceStatusUnprocessed,
+	}
+}
+
+// nextOccurrence returns the occurrence of a recurring invoice after the one given, null once its schedule ended
+func nextOccurrence(recurring *models.RecurringInvoice, occurrence time.Time) null.Time {
+	next, ok := entity.NextOccurrence(recurring, occurrence.AddDate(0, 0, 1))
+	return null.NewTime(next, ok)
+}
+
+// resume returns the next occurrence of a recurring invoice resumed at asOf, skipping the ones before,
+// and the number of occurrences it skipped with them
+func resume(recurring *models.RecurringInvoice, asOf time.Time) (null.Time, int) {
+	if !recurring.NextDate.Valid || !recurring.NextDate.Time.Before(asOf) {
+		return recurring.NextDate, recurring.SkippedCount
+	}
+	n, _ := entity.NextOccurrenceIndex(recurring, recurring.NextDate.Time)
+	resumed := *recurring
+	for ; entity.NthOccurrence(recurring, n).Before(asOf); n++ {
+		resumed.SkippedCount++
+	}
+	if !entity.InSchedule(&resumed, n) {
+		return null.Time{}, resumed.SkippedCount
+	}
+	return null.TimeFrom(entity.NthOccurrence(recurring, n)), resumed.SkippedCount
+}
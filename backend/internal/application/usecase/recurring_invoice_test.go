@@ -0,0 +1,253 @@
+package usecase_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/niko-cb/uct/internal/application/usecase"
+	"github.com/niko-cb/uct/internal/domain/entity"
+	"github.com/niko-cb/uct/internal/domain/repository"
+	"github.com/niko-cb/uct/internal/domain/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/null/v8"
+)
+
+func issueDates(occurrences []*entity.Occurrence) []string {
+	dates := make([]string, len(occurrences))
+	for i, occurrence := range occurrences {
+		dates[i] = occurrence.IssueDate.Format("2006-01-02")
+	}
+	return dates
+}
+
+// TestRecurringInvoices_Schedule tests the occurrences of the frequencies, clamped to the end of the shorter months,
+// until the end date or the maximum number of occurrences
+func TestRecurringInvoices_Schedule(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture(t)
+	recurring := f.recurringInvoices(f.recurringInvoiceService())
+	end := day("2024-06-30")
+
+	tests := []struct {
+		name      string
+		recurring *entity.RecurringInvoice
+		want      []string
+	}{
+		{
+			name:      "monthly on the 31st until the end date",
+			recurring: &entity.RecurringInvoice{Frequency: "monthly", DayOfMonth: 31, StartDate: day("2024-01-15"), EndDate: &end},
+			want:      []string{"2024-01-31", "2024-02-29", "2024-03-31", "2024-04-30", "2024-05-31", "2024-06-30"},
+		},
+		{
+			name:      "monthly from after the day of the start month",
+			recurring: &entity.RecurringInvoice{Frequency: "monthly", DayOfMonth: 10, StartDate: day("2024-11-20"), MaxOccurrences: 3},
+			want:      []string{"2024-12-10", "2025-01-10", "2025-02-10"},
+		},
+		{
+			name:      "every 3 months at the end of the month",
+			recurring: &entity.RecurringInvoice{Frequency: "month_end", Every: 3, StartDate: day("2024-02-10"), MaxOccurrences: 4},
+			want:      []string{"2024-02-29", "2024-05-31", "2024-08-31", "2024-11-30"},
+		},
+		{
+			name:      "every 2 weeks",
+			recurring: &entity.RecurringInvoice{Frequency: "weekly", Every: 2, StartDate: day("2024-01-03"), MaxOccurrences: 3},
+			want:      []string{"2024-01-03", "2024-01-17", "2024-01-31"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.recurring.CompanyID, tt.recurring.ClientID, tt.recurring.PaymentAmount = 1, 1, 110000
+			if tt.recurring.Every == 0 {
+				tt.recurring.Every = 1
+			}
+			created, err := recurring.CreateRecurringInvoice(ctx, tt.recurring)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want[0], created.NextDate.Time.Format("2006-01-02"))
+			assert.Equal(t, "JPY", created.Currency)
+
+			occurrences, err := recurring.PreviewOccurrences(ctx, created.ID, day("2024-01-01"), 12)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, issueDates(occurrences))
+		})
+	}
+
+	// A schedule whose first occurrence is after its end date is refused
+	end = day("2024-01-30")
+	_, err := recurring.CreateRecurringInvoice(ctx, &entity.RecurringInvoice{
+		CompanyID: 1, ClientID: 1, Frequency: "monthly", Every: 1, DayOfMonth: 31, StartDate: day("2024-01-01"),
+		EndDate: &end, PaymentAmount: 1000,
+	})
+	assert.ErrorIs(t, err, usecase.ErrNoOccurrence)
+	_, err = recurring.CreateRecurringInvoice(ctx, &entity.RecurringInvoice{
+		CompanyID: 2, ClientID: 1, Frequency: "weekly", Every: 1, StartDate: day("2024-01-01"), PaymentAmount: 1000,
+	})
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+}
+
+// TestRecurringInvoices_Generate tests the invoices are generated once per occurrence, the missed ones caught up,
+// and the occurrences of a paused recurring invoice skipped
+func TestRecurringInvoices_Generate(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture(t)
+	recurring, invoices := f.recurringInvoices(f.recurringInvoiceService()), f.invoices
+	end := day("2024-06-30")
+	created, err := recurring.CreateRecurringInvoice(ctx, &entity.RecurringInvoice{
+		CompanyID: 1, ClientID: 1, Frequency: "monthly", Every: 1, DayOfMonth: 31, StartDate: day("2024-01-01"),
+		EndDate: &end, PaymentAmount: 110000, DueDays: 30,
+	})
+	require.NoError(t, err)
+
+	occurrences, err := recurring.PreviewOccurrences(ctx, created.ID, day("2024-01-01"), 2)
+	require.NoError(t, err)
+	require.Len(t, occurrences, 2)
+	assert.Equal(t, day("2024-01-31"), occurrences[0].IssueDate)
+	assert.Equal(t, day("2024-03-01"), occurrences[0].DueDate)
+	assert.Equal(t, []string{"110000.00", "4400.00", "440.00", "114840.00"}, []string{occurrences[0].PaymentAmount.String(),
+		occurrences[0].FeeAmount.String(), occurrences[0].TaxAmount.String(), occurrences[0].TotalAmount.String()})
+	assert.Equal(t, "JPY", occurrences[0].Currency)
+
+	// Two generators running at once bill each occurrence until the end of March once
+	var wg sync.WaitGroup
+	results := make([]usecase.GenerateResult, 2)
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var err error
+			results[i], err = recurring.GenerateInvoices(ctx, day("2024-03-31"))
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+	generated := append(results[0].Generated, results[1].Generated...)
+	assert.Len(t, generated, 3)
+	assert.Zero(t, results[0].Failed+results[1].Failed)
+
+	result, err := recurring.GenerateInvoices(ctx, day("2024-03-31"))
+	require.NoError(t, err)
+	assert.Empty(t, result.Generated)
+
+	listed, err := invoices.GetInvoicesByDateRange(ctx, day("2024-01-01"), day("2024-12-31"), repository.Page{})
+	require.NoError(t, err)
+	require.Len(t, listed, 3)
+	assert.Equal(t, "2024-02-29", listed[1].IssueDate.Format("2006-01-02"))
+	assert.Equal(t, "2024-03-30", listed[1].DueDate.Format("2006-01-02"))
+	assert.Equal(t, "114840.00", listed[1].TotalAmount.String())
+	assert.Equal(t, entity.InvoiceStatusUnprocessed, listed[1].Status)
+
+	// Paused in April, the occurrence of April is skipped once resumed in May
+	loaded, err := recurring.GetRecurringInvoice(ctx, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "2024-04-30", loaded.NextDate.Time.Format("2006-01-02"))
+	_, err = recurring.UpdateRecurringInvoiceStatus(ctx, created.ID, loaded.Version, "stopped", day("2024-04-01"))
+	assert.ErrorIs(t, err, usecase.ErrInvalidRecurringStatus)
+	_, err = recurring.UpdateRecurringInvoiceStatus(ctx, created.ID, loaded.Version-1, entity.RecurringStatusPaused, day("2024-04-01"))
+	assert.ErrorIs(t, err, repository.ErrVersionConflict)
+	paused, err := recurring.UpdateRecurringInvoiceStatus(ctx, created.ID, loaded.Version, entity.RecurringStatusPaused, day("2024-04-01"))
+	require.NoError(t, err)
+	assert.Equal(t, entity.RecurringStatusPaused, paused.Status)
+
+	result, err = recurring.GenerateInvoices(ctx, day("2024-05-15"))
+	require.NoError(t, err)
+	assert.Empty(t, result.Generated)
+	occurrences, err = recurring.PreviewOccurrences(ctx, created.ID, day("2024-05-15"), 12)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"2024-05-31", "2024-06-30"}, issueDates(occurrences), "previewed as if resumed")
+
+	resumed, err := recurring.UpdateRecurringInvoiceStatus(ctx, created.ID, usecase.AnyVersion, entity.RecurringStatusActive, day("2024-05-15"))
+	require.NoError(t, err)
+	assert.Equal(t, "2024-05-31", resumed.NextDate.Time.Format("2006-01-02"))
+
+	// The last occurrences end the schedule
+	result, err = recurring.GenerateInvoices(ctx, day("2024-12-31"))
+	require.NoError(t, err)
+	assert.Len(t, result.Generated, 2)
+	loaded, err = recurring.GetRecurringInvoice(ctx, created.ID)
+	require.NoError(t, err)
+	assert.False(t, loaded.NextDate.Valid)
+	occurrences, err = recurring.PreviewOccurrences(ctx, created.ID, day("2024-12-31"), 12)
+	require.NoError(t, err)
+	assert.Empty(t, occurrences)
+	listed, err = invoices.GetInvoicesByDateRange(ctx, day("2024-01-01"), day("2024-12-31"), repository.Page{})
+	require.NoError(t, err)
+	assert.Len(t, listed, 5)
+}
+
+// conflictingService is a RecurringInvoiceService whose recurring invoices are always changed concurrently
+type conflictingService struct {
+	service.RecurringInvoiceService
+	updates int
+}
+
+func (s *conflictingService) UpdateRecurringInvoiceSchedule(context.Context, int64, int64, string, null.Time, int) error {
+	s.updates++
+	return repository.ErrVersionConflict
+}
+
+// TestRecurringInvoices_Conflicts tests a recurring invoice still changed concurrently after the retries fails
+// without stopping the generation, and a cancelled generation stops
+func TestRecurringInvoices_Conflicts(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture(t)
+	conflicting := &conflictingService{RecurringInvoiceService: f.recurringInvoiceService()}
+	recurring, invoices := f.recurringInvoices(conflicting), f.invoices
+
+	start := day("2024-01-01")
+	_, err := recurring.CreateRecurringInvoice(ctx, &entity.RecurringInvoice{
+		CompanyID: 1, ClientID: 1, Frequency: "weekly", Every: 1, StartDate: start, PaymentAmount: 1000,
+	})
+	require.NoError(t, err)
+
+	result, err := recurring.GenerateInvoices(ctx, start.AddDate(0, 0, 14))
+	require.NoError(t, err)
+	assert.Empty(t, result.Generated)
+	assert.Equal(t, 1, result.Failed)
+	assert.Equal(t, 4, conflicting.updates, "the first attempt and 3 retries")
+	listed, err := invoices.GetInvoicesByDateRange(ctx, start, start.AddDate(1, 0, 0), repository.Page{})
+	require.NoError(t, err)
+	assert.Empty(t, listed, "the invoices are rolled back with the conflicts")
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = recurring.GenerateInvoices(cancelled, start.AddDate(0, 0, 14))
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+// TestRecurringInvoices_MaxOccurrences tests the occurrences skipped while paused don't count toward the maximum,
+// which bounds the invoices generated
+func TestRecurringInvoices_MaxOccurrences(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture(t)
+	recurring := f.recurringInvoices(f.recurringInvoiceService())
+	created, err := recurring.CreateRecurringInvoice(ctx, &entity.RecurringInvoice{
+		CompanyID: 1, ClientID: 1, Frequency: "monthly", Every: 1, DayOfMonth: 31, StartDate: day("2024-01-01"),
+		MaxOccurrences: 3, PaymentAmount: 110000, DueDays: 30,
+	})
+	require.NoError(t, err)
+	result, err := recurring.GenerateInvoices(ctx, day("2024-01-31"))
+	require.NoError(t, err)
+	assert.Len(t, result.Generated, 1)
+
+	// Paused over February, its occurrence is skipped and the two invoices left are billed in March and April
+	_, err = recurring.UpdateRecurringInvoiceStatus(ctx, created.ID, usecase.AnyVersion, entity.RecurringStatusPaused, day("2024-02-01"))
+	require.NoError(t, err)
+	occurrences, err := recurring.PreviewOccurrences(ctx, created.ID, day("2024-03-15"), 12)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"2024-03-31", "2024-04-30"}, issueDates(occurrences), "previewed as if resumed")
+	resumed, err := recurring.UpdateRecurringInvoiceStatus(ctx, created.ID, usecase.AnyVersion, entity.RecurringStatusActive, day("2024-03-15"))
+	require.NoError(t, err)
+	assert.Equal(t, "2024-03-31", resumed.NextDate.Time.Format("2006-01-02"))
+	assert.Equal(t, 1, resumed.SkippedCount)
+
+	result, err = recurring.GenerateInvoices(ctx, day("2024-12-31"))
+	require.NoError(t, err)
+	assert.Len(t, result.Generated, 2)
+	listed, err := f.invoices.GetInvoicesByDateRange(ctx, day("2024-01-01"), day("2024-12-31"), repository.Page{})
+	require.NoError(t, err)
+	assert.Len(t, listed, 3)
+	loaded, err := recurring.GetRecurringInvoice(ctx, created.ID)
+	require.NoError(t, err)
+	assert.False(t, loaded.NextDate.Valid)
+}
@@ -0,0 +1,234 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ericlagergren/decimal"
+	"github.com/volatiletech/sqlboiler/v4/types"
+
+	"github.com/niko-cb/uct/internal/domain/entity"
+	"github.com/niko-cb/uct/internal/domain/repository"
+	"github.com/niko-cb/uct/internal/domain/service"
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/trace"
+)
+
+var (
+	// ErrNoAvailableBalance is returned when comparing the cash forecast to the balance of a company which has none
+	ErrNoAvailableBalance = errors.New("the company has no available balance configured")
+	// ErrBaseCurrencyMismatch is returned when the cash forecast of a company is asked in another base currency
+	ErrBaseCurrencyMismatch = errors.New("base_currency is not the base currency of the company")
+)
+
+type ReportUsecase interface {
+	GetAgingReport(ctx context.Context, asOf time.Time, baseCurrency string) (*entity.AgingReport, error)
+	GetCashForecast(ctx context.Context, opts CashForecastOptions) (*entity.CashForecast, error)
+	GetIssuerReport(ctx context.Context, filter repository.IssuerFilter) (*entity.IssuerReport, error)
+}
+
+// CashForecastOptions are the parameters of the cash forecast
+type CashForecastOptions struct {
+	// From and To bound the due dates of the invoices, both included
+	From, To    time.Time
+	Granularity string
+	// CompanyID restricts the forecast to a company, every company if 0
+	CompanyID int64
+	// BaseCurrency restricts the forecast to the companies of a base currency: by default the one of the company,
+	// or entity.DefaultCurrency for every company
+	BaseCurrency string
+	// ProjectOverdue moves the invoices overdue on Today to the next business day
+	ProjectOverdue bool
+	// CompareBalance compares the payouts to the available balance of the company
+	CompareBalance bool
+	Today          time.Time
+}
+
+var _ ReportUsecase = &reportUsecase{}
+
+type reportUsecase struct {
+	reportService  service.ReportService
+	companyService service.CompanyService
+}
+
+func NewReportUsecase(reportService service.ReportService, companyService service.CompanyService) ReportUsecase {
+	return &reportUsecase{
+		reportService:  reportService,
+		companyService: companyService,
+	}
+}
+
+// GetAgingReport buckets the invoices outstanding on asOf of the companies of a base currency (entity.DefaultCurrency
+// if empty) by days past their due date, by client and in total
+func (u *reportUsecase) GetAgingReport(ctx context.Context, asOf time.Time, baseCurrency string) (_ *entity.AgingReport, err error) {
+	ctx, span := trace.Start(ctx, "ReportUsecase.GetAgingReport")
+	defer trace.End(span, &err)
+
+	if baseCurrency == "" {
+		baseCurrency = entity.DefaultCurrency
+	}
+	clients, err := u.reportService.GetAging(ctx, asOf, baseCurrency)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &entity.AgingReport{
+		AsOf:         asOf.Format("2006-01-02"),
+		BaseCurrency: baseCurrency,
+		Totals:       entity.NewAgingBreakdown(),
+		Clients:      clients,
+	}
+	if report.Clients == nil {
+		report.Clients = []*entity.ClientAging{}
+	}
+	for _, client := range clients {
+		for bucket := 0; bucket < entity.AgingBuckets; bucket++ {
+			amount := client.Bucket(bucket)
+			report.Totals.Add(bucket, amount.Count, amount.Amount)
+		}
+		for _, original := range client.Currencies {
+			report.Totals.AddOriginal(original.Currency, original.Count, original.Amount)
+		}
+	}
+	return report, nil
+}
+
+// GetCashForecast sums the open invoices by period of their due date, every period from opts.From to opts.To
+// being listed. With opts.CompareBalance, the balance remaining after each period is computed.
+// The forecast of an unknown company returns repository.ErrNotFound
+func (u *reportUsecase) GetCashForecast(ctx context.Context, opts CashForecastOptions) (_ *entity.CashForecast, err error) {
+	ctx, span := trace.Start(ctx, "ReportUsecase.GetCashForecast")
+	defer trace.End(span, &err)
+
+	forecast := &entity.CashForecast{
+		From:           opts.From.Format("2006-01-02"),
+		To:             opts.To.Format("2006-01-02"),
+		Granularity:    opts.Granularity,
+		CompanyID:      opts.CompanyID,
+		ProjectOverdue: opts.ProjectOverdue,
+		Totals:         entity.NewCashFlow(),
+		Periods:        []*entity.CashForecastPeriod{},
+	}
+	baseCurrency := opts.BaseCurrency
+	if opts.CompanyID != 0 {
+		company, err := u.companyService.GetCompany(ctx, opts.CompanyID)
+		if err != nil {
+			return nil, err
+		}
+		if baseCurrency != "" && baseCurrency != company.BaseCurrency {
+			return nil, fmt.Errorf("%w: %s", ErrBaseCurrencyMismatch, company.BaseCurrency)
+		}
+		baseCurrency = company.BaseCurrency
+		if opts.CompareBalance {
+			if company.AvailableBalance.Big == nil {
+				return nil, ErrNoAvailableBalance
+			}
+			forecast.AvailableBalance = company.AvailableBalance
+		}
+	}
+	if baseCurrency == "" {
+		baseCurrency = entity.DefaultCurrency
+	}
+	forecast.BaseCurrency = baseCurrency
+
+	filter := repository.CashFlowFilter{CompanyID: opts.CompanyID, BaseCurrency: baseCurrency, From: opts.From, To: opts.To}
+	if opts.ProjectOverdue {
+		filter.OverdueBefore = opts.Today
+	}
+	flows, err := u.reportService.GetCashFlows(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	byStart := map[time.Time]*entity.CashForecastPeriod{}
+	for start := entity.PeriodStart(opts.Granularity, opts.From); !start.After(opts.To); start = entity.NextPeriod(opts.Granularity, start) {
+		period := &entity.CashForecastPeriod{
+			Start:    maxDate(start, opts.From).Format("2006-01-02"),
+			End:      minDate(entity.NextPeriod(opts.Granularity, start).AddDate(0, 0, -1), opts.To).Format("2006-01-02"),
+			CashFlow: entity.NewCashFlow(),
+		}
+		byStart[start] = period
+		forecast.Periods = append(forecast.Periods, period)
+	}
+
+	projected := entity.NextBusinessDay(opts.Today)
+	for _, flow := range flows {
+		date := flow.DueDate
+		if opts.ProjectOverdue && date.Before(opts.Today) {
+			date = projected
+		}
+		period, ok := byStart[entity.PeriodStart(opts.Granularity, date)]
+		if !ok || date.Before(opts.From) {
+			// Projected past the end of the forecast, or before its start
+			continue
+		}
+		period.Add(flow.CashFlow)
+		forecast.Totals.Add(flow.CashFlow)
+	}
+
+	if forecast.AvailableBalance.Big != nil {
+		remaining := new(decimal.Big).Copy(forecast.AvailableBalance.Big)
+		for _, period := range forecast.Periods {
+			remaining = new(decimal.Big).Sub(remaining, period.TotalAmount.Big)
+			period.RemainingBalance = types.NewNullDecimal(remaining)
+			if remaining.Sign() < 0 && forecast.ShortfallFrom == "" {
+				forecast.ShortfallFrom = period.Start
+			}
+		}
+	}
+	return forecast, nil
+}
+
+// GetIssuerReport flags the invoices issued from filter.From to filter.To whose client was not a registered qualified
+// invoice issuer on the issue date, with the reason. The report of an unknown company returns repository.ErrNotFound
+func (u *reportUsecase) GetIssuerReport(ctx context.Context, filter repository.IssuerFilter) (_ *entity.IssuerReport, err error) {
+	ctx, span := trace.Start(ctx, "ReportUsecase.GetIssuerReport")
+	defer trace.End(span, &err)
+
+	if filter.CompanyID != 0 {
+		if _, err := u.companyService.GetCompany(ctx, filter.CompanyID); err != nil {
+			return nil, err
+		}
+	}
+	checks, err := u.reportService.GetUnregisteredIssuerInvoices(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &entity.IssuerReport{
+		From:      filter.From.Format("2006-01-02"),
+		To:        filter.To.Format("2006-01-02"),
+		CompanyID: filter.CompanyID,
+		Invoices:  make([]*entity.FlaggedInvoice, 0, len(checks)),
+	}
+	for _, check := range checks {
+		report.Invoices = append(report.Invoices, &entity.FlaggedInvoice{
+			InvoiceID:          check.InvoiceID,
+			CompanyID:          check.CompanyID,
+			ClientID:           check.ClientID,
+			ClientName:         check.ClientName,
+			RegistrationNumber: check.RegistrationNumber,
+			IssueDate:          check.IssueDate.Format("2006-01-02"),
+			Currency:           check.Currency,
+			TaxAmount:          check.TaxAmount,
+			TotalAmount:        check.TotalAmount,
+			Status:             issuerStatus(check.RegistrationNumber, check.Issuer, check.IssueDate),
+		})
+	}
+	return report, nil
+}
+
+func minDate(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
+
+func maxDate(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,112 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ericlagergren/decimal"
+	"github.com/niko-cb/uct/internal/application/usecase"
+	"github.com/niko-cb/uct/internal/conversion"
+	"github.com/niko-cb/uct/internal/domain/entity"
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+	"github.com/niko-cb/uct/internal/domain/repository"
+	"github.com/niko-cb/uct/internal/domain/service"
+	"github.com/niko-cb/uct/internal/infrastructure/gateway"
+	"github.com/niko-cb/uct/internal/infrastructure/persistent/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/sqlboiler/v4/types"
+)
+
+// TestGetCashForecast tests the periods, the projection of the overdue invoices and the comparison to the balance
+func TestGetCashForecast(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	require.NoError(t, store.Write(ctx, func(tables *memory.Tables) error {
+		tables.Companies[1] = models.Company{ID: 1, Name: "company", BaseCurrency: "JPY", AvailableBalance: types.NewNullDecimal(decimal.New(5000000, 2))}
+		tables.Companies[2] = models.Company{ID: 2, Name: "other", BaseCurrency: "JPY"}
+		tables.Clients[1] = models.Client{ID: 1, CompanyID: 1, Name: "client", Version: 1}
+		tables.Clients[2] = models.Client{ID: 2, CompanyID: 2, Name: "client", Version: 1}
+		for id, invoice := range []struct {
+			companyID int64
+			due       string
+			amount    float64
+			status    string
+		}{
+			{1, "2024-07-01", 10000, "unprocessed"}, // overdue on 2024-07-05
+			{1, "2024-07-09", 20000, "processing"},
+			{1, "2024-07-09", 20000, "paid"},
+			{1, "2024-07-22", 30000, "error"},
+			{1, "2024-08-05", 10000, "unprocessed"}, // after the forecast
+			{2, "2024-07-10", 10000, "unprocessed"},
+		} {
+			amount := func(f float64) types.Decimal {
+				d, _ := conversion.ConvertToDecimal(f)
+				return d
+			}
+			tables.Invoices[int64(id+1)] = models.Invoice{
+				ID: int64(id + 1), CompanyID: invoice.companyID, ClientID: invoice.companyID,
+				IssueDate: day(invoice.due).AddDate(0, 0, -30), DueDate: day(invoice.due),
+				PaymentAmount: amount(invoice.amount), FeeAmount: amount(invoice.amount * 0.04),
+				TaxAmount: amount(invoice.amount * 0.004), TotalAmount: amount(invoice.amount * 1.044),
+				Status: invoice.status, Currency: "JPY", FXRate: types.NewDecimal(decimal.New(1, 0)), Version: 1,
+			}
+		}
+		return nil
+	}))
+	reports := usecase.NewReportUsecase(
+		service.NewReportService(gateway.NewReportMemoryGateway(store)),
+		service.NewCompanyService(gateway.NewCompanyMemoryGateway(store)),
+	)
+
+	// 2024-07-05 is a Friday, the next business day is Monday 2024-07-08
+	opts := usecase.CashForecastOptions{
+		From: day("2024-07-05"), To: day("2024-07-31"), Granularity: entity.GranularityWeek, CompanyID: 1, Today: day("2024-07-05"),
+	}
+	forecast, err := reports.GetCashForecast(ctx, opts)
+	require.NoError(t, err)
+	require.Len(t, forecast.Periods, 5)
+	assert.Equal(t, []string{"2024-07-05", "2024-07-07"}, []string{forecast.Periods[0].Start, forecast.Periods[0].End})
+	assert.Equal(t, []string{"2024-07-08", "2024-07-14"}, []string{forecast.Periods[1].Start, forecast.Periods[1].End})
+	assert.Equal(t, []string{"2024-07-29", "2024-07-31"}, []string{forecast.Periods[4].Start, forecast.Periods[4].End})
+	assert.Equal(t, int64(1), forecast.Periods[1].Count)
+	assert.Equal(t, int64(1), forecast.Periods[3].Count)
+	assert.Equal(t, int64(2), forecast.Totals.Count)
+	assert.Equal(t, "50000.00", forecast.Totals.PaymentAmount.String())
+	assert.Equal(t, "2000.00", forecast.Totals.FeeAmount.String())
+	assert.Equal(t, "200.00", forecast.Totals.TaxAmount.String())
+	assert.Equal(t, "52200.00", forecast.Totals.TotalAmount.String())
+	assert.Nil(t, forecast.AvailableBalance.Big)
+	assert.Nil(t, forecast.Periods[0].RemainingBalance.Big)
+
+	// The overdue invoice is paid on the next business day, and the payouts exceed the balance in the 4th week
+	opts.ProjectOverdue, opts.CompareBalance = true, true
+	forecast, err = reports.GetCashForecast(ctx, opts)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), forecast.Periods[1].Count)
+	assert.Equal(t, int64(3), forecast.Totals.Count)
+	assert.Equal(t, "50000.00", forecast.AvailableBalance.String())
+	remaining := make([]string, len(forecast.Periods))
+	for i, period := range forecast.Periods {
+		remaining[i] = period.RemainingBalance.String()
+	}
+	assert.Equal(t, []string{"50000.00", "18680.00", "18680.00", "-12640.00", "-12640.00"}, remaining)
+	assert.Equal(t, "2024-07-22", forecast.ShortfallFrom)
+
+	// A single month, clipped to the range, and every company
+	forecast, err = reports.GetCashForecast(ctx, usecase.CashForecastOptions{
+		From: day("2024-07-05"), To: day("2024-07-31"), Granularity: entity.GranularityMonth, Today: day("2024-07-05"),
+	})
+	require.NoError(t, err)
+	require.Len(t, forecast.Periods, 1)
+	assert.Equal(t, []string{"2024-07-05", "2024-07-31"}, []string{forecast.Periods[0].Start, forecast.Periods[0].End})
+	assert.Equal(t, int64(3), forecast.Totals.Count)
+	assert.Empty(t, forecast.ShortfallFrom)
+
+	opts.CompanyID = 2
+	_, err = reports.GetCashForecast(ctx, opts)
+	assert.ErrorIs(t, err, usecase.ErrNoAvailableBalance)
+	opts.CompanyID = 3
+	_, err = reports.GetCashForecast(ctx, opts)
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+}
@@ -0,0 +1,199 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"unicode"
+
+	"github.com/niko-cb/uct/internal/domain/entity"
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+	"github.com/niko-cb/uct/internal/domain/repository"
+	"github.com/niko-cb/uct/internal/domain/service"
+	"github.com/niko-cb/uct/internal/domain/ubl"
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/log"
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/trace"
+)
+
+// ErrImportedTaxMismatch is returned when the tax calculated for the items of an imported invoice is not the tax
+// of its document, the company rounding the consumption tax differently from its client
+var ErrImportedTaxMismatch = errors.New("the tax calculated for the items doesn't match the tax of the document")
+
+// maxDocumentName is the length of the file names of the imported documents, without their extension
+const maxDocumentName = 200
+
+type UBLUsecase interface {
+	ImportInvoice(ctx context.Context, companyID int64, document []byte) (*models.Invoice, error)
+	ExportInvoice(ctx context.Context, id int64) ([]byte, error)
+}
+
+var _ UBLUsecase = &ublUsecase{}
+
+type ublUsecase struct {
+	invoices       InvoiceUsecase
+	attachments    AttachmentUsecase
+	companyService service.CompanyService
+	clientService  service.ClientService
+	transaction    repository.Transaction
+}
+
+func NewUBLUsecase(invoices InvoiceUsecase, attachments AttachmentUsecase, companyService service.CompanyService,
+	clientService service.ClientService, transaction repository.Transaction) UBLUsecase {
+	return &ublUsecase{
+		invoices:       invoices,
+		attachments:    attachments,
+		companyService: companyService,
+		clientService:  clientService,
+		transaction:    transaction,
+	}
+}
+
+// ImportInvoice creates the invoice of a UBL document received by a company, and attaches the document to it.
+// The seller is the client with its registration number, else the one with its name and no registration number
+// (which gets it), else a new client. The invoice is created like CreateInvoice does, and its tax must be the tax
+// of the document, else it returns ErrImportedTaxMismatch. It returns ubl.ErrMalformedDocument, ubl.ErrInvalidDocument
+// or ubl.ErrUnsupportedDocument for a document it can't import, and repository.ErrNotFound without company
+func (u *ublUsecase) ImportInvoice(ctx context.Context, companyID int64, document []byte) (_ *models.Invoice, err error) {
+	ctx, span := trace.Start(ctx, "UBLUsecase.ImportInvoice")
+	defer trace.End(span, &err)
+
+	doc, err := ubl.Parse(document)
+	if err != nil {
+		return nil, err
+	}
+	imported, err := ubl.Import(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	invoice := imported.Invoice
+	err = u.transaction.DoInTx(ctx, func(ctx context.Context) error {
+		if _, err := u.companyService.GetCompany(ctx, companyID); err != nil {
+			return fmt.Errorf("company %d: %w", companyID, err)
+		}
+		seller := imported.Seller
+		seller.CompanyID = companyID
+		if err := u.seller(ctx, seller); err != nil {
+			return err
+		}
+
+		invoice.CompanyID, invoice.ClientID = companyID, seller.ID
+		if err := u.invoices.CreateInvoice(ctx, invoice); err != nil {
+			return err
+		}
+		if !sameTaxSubtotals(invoice.TaxSubtotals, imported.TaxSubtotals) {
+			return fmt.Errorf("%w: the tax of the document is %s, the tax calculated %s", ErrImportedTaxMismatch,
+				formatSubtotals(imported.TaxSubtotals), formatSubtotals(invoice.TaxSubtotals))
+		}
+
+		_, _, err := u.attachments.CreateAttachment(ctx, &entity.Attachment{
+			InvoiceID:   invoice.ID,
+			FileName:    documentFileName(doc.ID),
+			ContentType: entity.AttachmentTypeXML,
+			Content:     document,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return u.invoices.GetInvoice(ctx, invoice.ID)
+}
+
+// seller sets the id of the client issuing an imported invoice, creating the client if the company has none
+func (u *ublUsecase) seller(ctx context.Context, seller *entity.Client) error {
+	client, err := u.clientService.FindClient(ctx, seller.CompanyID, seller.RegistrationNumber, seller.Name)
+	if errors.Is(err, repository.ErrNotFound) {
+		clientM := u.clientService.EntityToModel(seller)
+		if err := u.clientService.CreateClient(ctx, clientM); err != nil {
+			log.Error(ctx, fmt.Errorf("failed to create client: %+v", err))
+			return err
+		}
+		seller.ID, seller.Version = clientM.ID, clientM.Version
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	seller.ID, seller.Version = client.ID, client.Version
+	if seller.RegistrationNumber != "" && !client.RegistrationNumber.Valid {
+		if err := u.clientService.UpdateClientRegistrationNumber(ctx, client.ID, client.Version, seller.RegistrationNumber); err != nil {
+			log.Error(ctx, fmt.Errorf("failed to update the registration number of the client: %+v", err))
+			return err
+		}
+	}
+	return nil
+}
+
+// ExportInvoice returns an invoice with its items as the UBL document its client issued to its company.
+// It returns ubl.ErrUnsupportedDocument for an invoice without items, ubl.ErrInvalidDocument if the document
+// breaks a rule (e.g. the client has no address), and repository.ErrNotFound without invoice
+func (u *ublUsecase) ExportInvoice(ctx context.Context, id int64) (_ []byte, err error) {
+	ctx, span := trace.Start(ctx, "UBLUsecase.ExportInvoice")
+	defer trace.End(span, &err)
+
+	invoice, err := u.invoices.GetInvoice(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	company, err := u.companyService.GetCompany(ctx, invoice.CompanyID)
+	if err != nil {
+		return nil, fmt.Errorf("company %d: %w", invoice.CompanyID, err)
+	}
+	client, err := u.clientService.GetClient(ctx, invoice.ClientID)
+	if err != nil {
+		return nil, fmt.Errorf("client %d: %w", invoice.ClientID, err)
+	}
+
+	doc, err := ubl.Export(invoice, company, client)
+	if err != nil {
+		return nil, err
+	}
+	if err := ubl.Validate(doc); err != nil {
+		return nil, err
+	}
+	return ubl.Marshal(doc)
+}
+
+// sameTaxSubtotals reports whether calculated tax subtotals are the ones of a document, to the cent
+func sameTaxSubtotals(calculated, document []*entity.InvoiceTaxSubtotal) bool {
+	if len(calculated) != len(document) {
+		return false
+	}
+	for i, subtotal := range calculated {
+		if subtotal.TaxCategory != document[i].TaxCategory || subtotal.Rate != document[i].Rate ||
+			math.Round(subtotal.Amount*100) != math.Round(document[i].Amount*100) ||
+			math.Round(subtotal.TaxAmount*100) != math.Round(document[i].TaxAmount*100) {
+			return false
+		}
+	}
+	return true
+}
+
+// formatSubtotals formats the tax of tax subtotals, e.g. "10%: 1000.00, 8%: 80.00"
+func formatSubtotals(subtotals []*entity.InvoiceTaxSubtotal) string {
+	parts := make([]string, len(subtotals))
+	for i, subtotal := range subtotals {
+		parts[i] = fmt.Sprintf("%d%%: %.2f", subtotal.Rate, subtotal.TaxAmount)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// documentFileName returns the file name of an imported document: its invoice number, the characters
+// other than letters, digits, dots, dashes and underscores replaced by underscores
+func documentFileName(number string) string {
+	name := []rune(strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '.' || r == '-' || r == '_' {
+			return r
+		}
+		return '_'
+	}, number))
+	if len(name) > maxDocumentName {
+		name = name[:maxDocumentName]
+	}
+	return string(name) + ".xml"
+}
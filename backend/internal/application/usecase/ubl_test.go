@@ -0,0 +1,94 @@
+package usecase_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/niko-cb/uct/internal/application/usecase"
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+	"github.com/niko-cb/uct/internal/domain/repository"
+	"github.com/niko-cb/uct/internal/domain/service"
+	"github.com/niko-cb/uct/internal/domain/ubl"
+	"github.com/niko-cb/uct/internal/infrastructure/gateway"
+	"github.com/niko-cb/uct/internal/infrastructure/persistent/blob"
+	"github.com/niko-cb/uct/internal/infrastructure/persistent/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/null/v8"
+)
+
+// TestUBL tests importing the UBL invoices of the clients, matched by registration number or name,
+// and exporting them back
+func TestUBL(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	require.NoError(t, store.Write(ctx, func(tables *memory.Tables) error {
+		tables.Companies[1] = models.Company{ID: 1, Name: "Sample Company", Address: null.StringFrom("4-5-6 Umeda Osaka"),
+			TaxRounding: "floor", BaseCurrency: "JPY"}
+		// Rounding up, the tax of the reduced rate is 1 yen more than the one of the sample
+		tables.Companies[2] = models.Company{ID: 2, Name: "Other Company", TaxRounding: "ceil", BaseCurrency: "JPY"}
+		tables.Clients[1] = models.Client{ID: 1, CompanyID: 1, Name: "Yamada Trading Co., Ltd.",
+			Address: null.StringFrom("1-2-3 Marunouchi Chiyoda-ku Tokyo"), Version: 1}
+		return nil
+	}))
+	invoiceService := service.NewInvoiceService(gateway.NewInvoiceMemoryGateway(store))
+	companyService := service.NewCompanyService(gateway.NewCompanyMemoryGateway(store))
+	clientService := service.NewClientService(gateway.NewClientMemoryGateway(store))
+	transaction := memory.NewTransaction(store)
+	invoices := usecase.NewInvoiceUsecase(invoiceService, companyService,
+		service.NewFXRateService(gateway.NewFXRateMemoryGateway(store)), transaction)
+	attachments := usecase.NewAttachmentUsecase(service.NewAttachmentService(gateway.NewAttachmentMemoryGateway(store)),
+		invoiceService, blob.NewMemoryStore(), transaction)
+	use := usecase.NewUBLUsecase(invoices, attachments, companyService, clientService, transaction)
+
+	document, err := os.ReadFile(filepath.Join("..", "..", "domain", "ubl", "testdata", "invoice.xml"))
+	require.NoError(t, err)
+
+	// The client with the name of the seller gets its registration number
+	invoice, err := use.ImportInvoice(ctx, 1, document)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), invoice.ClientID)
+	assert.Equal(t, "115077.00", invoice.PaymentAmount.String())
+	require.Len(t, invoice.R.InvoiceItems, 3)
+	require.Len(t, invoice.R.InvoiceTaxSubtotals, 2)
+	assert.Equal(t, "376.00", invoice.R.InvoiceTaxSubtotals[1].TaxAmount.String())
+	client, err := clientService.GetClient(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, null.StringFrom("T7123456789012"), client.RegistrationNumber)
+	attached, err := attachments.GetAttachments(ctx, invoice.ID)
+	require.NoError(t, err)
+	require.Len(t, attached, 1)
+	assert.Equal(t, "INV-2024-0042.xml", attached[0].FileName)
+
+	// Then it is found by its registration number
+	again, err := use.ImportInvoice(ctx, 1, document)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), again.ClientID)
+
+	// A new client of another company, rolled back with its invoice when the tax doesn't match
+	_, err = use.ImportInvoice(ctx, 2, document)
+	assert.ErrorIs(t, err, usecase.ErrImportedTaxMismatch)
+	_, err = clientService.FindClient(ctx, 2, "T7123456789012", "Yamada Trading Co., Ltd.")
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+
+	_, err = use.ImportInvoice(ctx, 3, document)
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+	_, err = use.ImportInvoice(ctx, 1, []byte("not xml"))
+	assert.ErrorIs(t, err, ubl.ErrMalformedDocument)
+
+	// Exported, the invoice reads back the same
+	exported, err := use.ExportInvoice(ctx, invoice.ID)
+	require.NoError(t, err)
+	doc, err := ubl.Parse(exported)
+	require.NoError(t, err)
+	assert.Equal(t, "T7123456789012", ubl.RegistrationNumber(doc.AccountingSupplierParty.Party))
+	assert.Equal(t, "115077", doc.LegalMonetaryTotal.PayableAmount.Value)
+	reimported, err := use.ImportInvoice(ctx, 1, exported)
+	require.NoError(t, err)
+	assert.Equal(t, invoice.TotalAmount, reimported.TotalAmount)
+
+	_, err = use.ExportInvoice(ctx, 99)
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+}
@@ -0,0 +1,100 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/niko-cb/uct/internal/domain/entity"
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+	"github.com/niko-cb/uct/internal/domain/repository"
+	"github.com/niko-cb/uct/internal/domain/service"
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/trace"
+)
+
+// MinPasswordLength is the minimum length of a user's password
+const MinPasswordLength = 8
+
+// ErrPasswordTooShort is returned for a password shorter than MinPasswordLength
+var ErrPasswordTooShort = errors.New("password must be at least 8 characters")
+
+type UserUsecase interface {
+	CreateUser(ctx context.Context, user *entity.User) error
+	GetUser(ctx context.Context, id int64) (*models.User, error)
+	ResetPassword(ctx context.Context, email string, password string) error
+}
+
+var _ UserUsecase = &userUsecase{}
+
+type userUsecase struct {
+	userService service.UserService
+	transaction repository.Transaction
+}
+
+func NewUserUsecase(userService service.UserService, transaction repository.Transaction) UserUsecase {
+	return &userUsecase{
+		userService: userService,
+		transaction: transaction,
+	}
+}
+
+// CreateUser saves a user with its password hashed, and sets its id
+func (u *userUsecase) CreateUser(ctx context.Context, user *entity.User) (err error) {
+	ctx, span := trace.Start(ctx, "UserUsecase.CreateUser")
+	defer trace.End(span, &err)
+
+	userM := u.userService.EntityToModel(user)
+	if userM.Password, err = hashPassword(user.Password); err != nil {
+		return err
+	}
+
+	err = u.transaction.DoInTx(ctx, func(ctx context.Context) error {
+		return u.userService.CreateUser(ctx, userM)
+	})
+	if err != nil {
+		return err
+	}
+
+	user.ID = userM.ID
+	return nil
+}
+
+// GetUser retrieves a saved user, or repository.ErrNotFound
+func (u *userUsecase) GetUser(ctx context.Context, id int64) (_ *models.User, err error) {
+	ctx, span := trace.Start(ctx, "UserUsecase.GetUser")
+	defer trace.End(span, &err)
+
+	return u.userService.GetUser(ctx, id)
+}
+
+// ResetPassword replaces the password of the user with an email, or returns repository.ErrNotFound
+func (u *userUsecase) ResetPassword(ctx context.Context, email string, password string) (err error) {
+	ctx, span := trace.Start(ctx, "UserUsecase.ResetPassword")
+	defer trace.End(span, &err)
+
+	hash, err := hashPassword(password)
+	if err != nil {
+		return err
+	}
+
+	return u.transaction.DoInTx(ctx, func(ctx context.Context) error {
+		user, err := u.userService.GetUserByEmail(ctx, email)
+		if err != nil {
+			return err
+		}
+		return u.userService.UpdateUserPassword(ctx, user.ID, hash)
+	})
+}
+
+// hashPassword hashes a password with bcrypt, so the stored passwords can't be read back
+func hashPassword(password string) (string, error) {
+	if len(password) < MinPasswordLength {
+		return "", ErrPasswordTooShort
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
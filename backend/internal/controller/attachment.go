@@ -0,0 +1,169 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/friendsofgo/errors"
+	"github.com/niko-cb/uct/internal/application/usecase"
+	"github.com/niko-cb/uct/internal/domain/entity"
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/trace"
+	"github.com/niko-cb/uct/internal/infrastructure/web/config"
+)
+
+var (
+	// ErrInvalidAttachment is returned when an attachment has no content or no valid file name
+	ErrInvalidAttachment = errors.New("invalid attachment")
+	// ErrAttachmentTooLarge is returned when an attachment is larger than the configured maximum size
+	ErrAttachmentTooLarge = errors.New("the attachment is too large")
+	// ErrUnsupportedContentType is returned when an attachment is not one of entity.AttachmentTypes,
+	// or its content doesn't match the declared type
+	ErrUnsupportedContentType = errors.New("unsupported attachment type, expected a PDF, PNG, JPEG or XML document")
+)
+
+// maxFileNameLength is the length of the file_name column
+const maxFileNameLength = 255
+
+type AttachmentController struct {
+	use usecase.AttachmentUsecase
+	// maxSize is the largest attachment accepted, in bytes
+	maxSize int64
+}
+
+func NewAttachmentController(use usecase.AttachmentUsecase, cfg *config.Config) *AttachmentController {
+	return &AttachmentController{use: use, maxSize: cfg.AttachmentMaxSize}
+}
+
+// CreateAttachment attaches the file read from content to the invoice invoiceID, and returns its metadata and whether
+// it was created: the same file attached again returns the existing attachment. The file name is reduced to its
+// base name, and the content type is the one of the content, which must match the declared one if any
+func (con *AttachmentController) CreateAttachment(ctx context.Context, invoiceID string, fileName, contentType string, content io.Reader) (_ *models.InvoiceAttachment, _ bool, err error) {
+	ctx, span := trace.Start(ctx, "AttachmentController.CreateAttachment")
+	defer trace.End(span, &err)
+
+	id, err := parseID(invoiceID)
+	if err != nil {
+		return nil, false, err
+	}
+	if content == nil {
+		return nil, false, fmt.Errorf("%w: file is required", ErrInvalidAttachment)
+	}
+	fileName, err = baseFileName(fileName)
+	if err != nil {
+		return nil, false, fmt.Errorf("%w: %s", ErrInvalidAttachment, err)
+	}
+	read, err := io.ReadAll(io.LimitReader(content, con.maxSize+1))
+	if err != nil {
+		return nil, false, fmt.Errorf("%w: %s", ErrInvalidAttachment, err)
+	}
+	if int64(len(read)) > con.maxSize {
+		return nil, false, fmt.Errorf("%w: more than %d bytes", ErrAttachmentTooLarge, con.maxSize)
+	}
+	if len(read) == 0 {
+		return nil, false, fmt.Errorf("%w: the file is empty", ErrInvalidAttachment)
+	}
+	contentType, err = attachmentType(contentType, read)
+	if err != nil {
+		return nil, false, err
+	}
+
+	attachment, created, err := con.use.CreateAttachment(ctx, &entity.Attachment{
+		InvoiceID:   id,
+		FileName:    fileName,
+		ContentType: contentType,
+		Content:     read,
+	})
+	if err != nil {
+		return nil, false, errors.Wrap(err, "failed to create attachment")
+	}
+
+	return attachment, created, nil
+}
+
+// baseFileName returns the last element of a file name, which browsers may send with its path
+func baseFileName(fileName string) (string, error) {
+	fileName = fileName[strings.LastIndexAny(fileName, `/\`)+1:]
+	switch {
+	case fileName == "" || fileName == "." || fileName == "..":
+		return "", errors.New("file name is required")
+	case !utf8.ValidString(fileName) || strings.ContainsFunc(fileName, unicode.IsControl):
+		return "", errors.New("file name must be printable UTF-8")
+	case utf8.RuneCountInString(fileName) > maxFileNameLength:
+		return "", fmt.Errorf("file name must be at most %d characters", maxFileNameLength)
+	}
+	return fileName, nil
+}
+
+// attachmentType returns the type of the content, sniffed from its first bytes. A declared type other than
+// application/octet-stream must be the same, so a file can't be served as something else than it is
+func attachmentType(declared string, content []byte) (string, error) {
+	sniffed := mediaType(http.DetectContentType(content))
+	if !slices.Contains(entity.AttachmentTypes, sniffed) {
+		return "", fmt.Errorf("%w: the content is %s", ErrUnsupportedContentType, sniffed)
+	}
+	if declared = mediaType(declared); declared != "" && declared != "application/octet-stream" && declared != sniffed {
+		return "", fmt.Errorf("%w: the content is %s, not %s", ErrUnsupportedContentType, sniffed, declared)
+	}
+	return sniffed, nil
+}
+
+// mediaType returns the media type of a Content-Type without its parameters, text/xml as application/xml
+func mediaType(contentType string) string {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return ""
+	}
+	if mediaType == "text/xml" {
+		return entity.AttachmentTypeXML
+	}
+	return mediaType
+}
+
+// GetAttachments lists the metadata of the attachments of the invoice invoiceID
+func (con *AttachmentController) GetAttachments(ctx context.Context, invoiceID string) (_ []*models.InvoiceAttachment, err error) {
+	ctx, span := trace.Start(ctx, "AttachmentController.GetAttachments")
+	defer trace.End(span, &err)
+
+	id, err := parseID(invoiceID)
+	if err != nil {
+		return nil, err
+	}
+
+	attachments, err := con.use.GetAttachments(ctx, id)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to retrieve the attachments of the invoice")
+	}
+
+	return attachments, nil
+}
+
+// OpenAttachment returns the metadata and the content of the attachment id of the invoice invoiceID,
+// which the caller closes
+func (con *AttachmentController) OpenAttachment(ctx context.Context, invoiceID, id string) (_ *models.InvoiceAttachment, _ io.ReadCloser, err error) {
+	ctx, span := trace.Start(ctx, "AttachmentController.OpenAttachment")
+	defer trace.End(span, &err)
+
+	parsedInvoiceID, err := parseID(invoiceID)
+	if err != nil {
+		return nil, nil, err
+	}
+	attachmentID, err := parseID(id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	attachment, content, err := con.use.OpenAttachment(ctx, parsedInvoiceID, attachmentID)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to retrieve the attachment")
+	}
+
+	return attachment, content, nil
+}
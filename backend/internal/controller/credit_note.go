@@ -0,0 +1,156 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/niko-cb/uct/internal/application/usecase"
+	"github.com/niko-cb/uct/internal/domain/entity"
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/trace"
+)
+
+// ErrInvalidCreditNote is returned when a credit note is incomplete or credits a non-positive amount
+var ErrInvalidCreditNote = errors.New("invalid credit note")
+
+type CreditNoteController struct {
+	use usecase.CreditNoteUsecase
+}
+
+func NewCreditNoteController(use usecase.CreditNoteUsecase) *CreditNoteController {
+	return &CreditNoteController{use: use}
+}
+
+// CreateCreditNote saves a credit note of the invoice invoiceID
+func (con *CreditNoteController) CreateCreditNote(ctx context.Context, invoiceID string, creditNote *entity.CreditNote) (_ *models.CreditNote, err error) {
+	ctx, span := trace.Start(ctx, "CreditNoteController.CreateCreditNote")
+	defer trace.End(span, &err)
+
+	id, err := parseID(invoiceID)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateCreditNote(creditNote); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCreditNote, err)
+	}
+	creditNote.InvoiceID = id
+
+	created, err := con.use.CreateCreditNote(ctx, creditNote)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create credit note")
+	}
+
+	return created, nil
+}
+
+// validateCreditNote checks a credit note, its fee, tax and total amount are calculated
+func validateCreditNote(creditNote *entity.CreditNote) error {
+	if creditNote == nil {
+		return errors.New("credit note is required")
+	}
+	if creditNote.IssueDate.IsZero() {
+		return errors.New("issue_date is required")
+	}
+	if strings.TrimSpace(creditNote.Reason) == "" {
+		return errors.New("reason is required")
+	}
+	if creditNote.PaymentAmount <= 0 {
+		return errors.New("payment_amount must be positive")
+	}
+	return nil
+}
+
+// GetCreditNote returns the credit note id
+func (con *CreditNoteController) GetCreditNote(ctx context.Context, id string) (_ *models.CreditNote, err error) {
+	ctx, span := trace.Start(ctx, "CreditNoteController.GetCreditNote")
+	defer trace.End(span, &err)
+
+	creditNoteID, err := parseID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	creditNote, err := con.use.GetCreditNote(ctx, creditNoteID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to retrieve credit note")
+	}
+
+	return creditNote, nil
+}
+
+// GetCreditNotesByInvoice lists the credit notes of the invoice invoiceID
+func (con *CreditNoteController) GetCreditNotesByInvoice(ctx context.Context, invoiceID string) (_ []*models.CreditNote, err error) {
+	ctx, span := trace.Start(ctx, "CreditNoteController.GetCreditNotesByInvoice")
+	defer trace.End(span, &err)
+
+	id, err := parseID(invoiceID)
+	if err != nil {
+		return nil, err
+	}
+
+	creditNotes, err := con.use.GetCreditNotesByInvoice(ctx, id)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to retrieve the credit notes of the invoice")
+	}
+
+	return creditNotes, nil
+}
+
+// GetCreditNotesByDateRange lists the credit notes issued between from and to, paginated as the invoices
+func (con *CreditNoteController) GetCreditNotesByDateRange(ctx context.Context, from, to, limit, after string) (_ []*models.CreditNote, next int64, err error) {
+	ctx, span := trace.Start(ctx, "CreditNoteController.GetCreditNotesByDateRange")
+	defer trace.End(span, &err)
+
+	fromDate, err := time.Parse("2006-01-02", from)
+	if err != nil {
+		return nil, 0, errors.Wrap(ErrInvalidDate, "from")
+	}
+	toDate, err := time.Parse("2006-01-02", to)
+	if err != nil {
+		return nil, 0, errors.Wrap(ErrInvalidDate, "to")
+	}
+
+	page, err := parsePage(limit, after)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// One more credit note is fetched to know whether there is a next page
+	if page.Limit > 0 {
+		page.Limit++
+	}
+	creditNotes, err := con.use.GetCreditNotesByDateRange(ctx, fromDate, toDate, page)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "failed to retrieve credit notes by date range")
+	}
+	if page.Limit > 0 && len(creditNotes) == page.Limit {
+		creditNotes = creditNotes[:page.Limit-1]
+		next = creditNotes[len(creditNotes)-1].ID
+	}
+
+	return creditNotes, next, nil
+}
+
+// UpdateCreditNoteStatus moves the credit note id to a status, provided it is still at version (or usecase.AnyVersion)
+func (con *CreditNoteController) UpdateCreditNoteStatus(ctx context.Context, id string, version int64, status *entity.CreditNoteStatus) (_ *models.CreditNote, err error) {
+	ctx, span := trace.Start(ctx, "CreditNoteController.UpdateCreditNoteStatus")
+	defer trace.End(span, &err)
+
+	creditNoteID, err := parseID(id)
+	if err != nil {
+		return nil, err
+	}
+	if status == nil {
+		return nil, errors.Wrap(usecase.ErrInvalidCreditNoteStatus, "status is required")
+	}
+
+	updated, err := con.use.UpdateCreditNoteStatus(ctx, creditNoteID, version, status.Status)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to update credit note")
+	}
+
+	return updated, nil
+}
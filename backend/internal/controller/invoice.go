@@ -2,14 +2,40 @@ package controller
 
 import (
 	"context"
+	"fmt"
 	"github.com/friendsofgo/errors"
 	"github.com/niko-cb/uct/internal/domain/entity/models"
+	"github.com/niko-cb/uct/internal/domain/repository"
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/trace"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/niko-cb/uct/internal/application/usecase"
 	"github.com/niko-cb/uct/internal/domain/entity"
 )
 
+// ErrInvalidID is returned when the id in the path is not a positive integer
+var ErrInvalidID = errors.New("invalid id, expected a positive integer")
+
+// ErrInvalidItem is returned when an item of an invoice is incomplete or has an unknown tax category
+var ErrInvalidItem = errors.New("invalid invoice item")
+
+// ErrInvalidInstallment is returned when an installment of an invoice is incomplete, or out of order
+var ErrInvalidInstallment = errors.New("invalid invoice installment")
+
+// ErrInvalidDateField is returned when invoices are listed by a date which is not one of the list dates
+var ErrInvalidDateField = errors.New("invalid by, expected due_date or installment_due_date")
+
+// The dates the invoices can be listed by
+const (
+	// ListByDueDate lists the invoices by their due date, the default
+	ListByDueDate = "due_date"
+	// ListByInstallmentDueDate lists the invoices by the due dates of their installments
+	ListByInstallmentDueDate = "installment_due_date"
+)
+
 type InvoiceController struct {
 	use usecase.InvoiceUsecase
 }
@@ -19,7 +45,10 @@ func NewInvoiceController(use usecase.InvoiceUsecase) *InvoiceController {
 
 }
 
-func (con *InvoiceController) CreateInvoice(ctx context.Context, invoice *entity.Invoice) error {
+func (con *InvoiceController) CreateInvoice(ctx context.Context, invoice *entity.Invoice) (err error) {
+	ctx, span := trace.Start(ctx, "InvoiceController.CreateInvoice")
+	defer trace.End(span, &err)
+
 	// Validate the invoice with a centralized validation function
 	if err := validateInvoice(invoice); err != nil {
 		return errors.Wrap(err, "invoice validation failed")
@@ -50,26 +79,203 @@ func validateInvoice(invoice *entity.Invoice) error {
 	if invoice.DueDate.IsZero() {
 		return errors.New("due_date is required")
 	}
+	if err := validateCurrency(invoice.Currency); err != nil {
+		return errors.Wrap(err, "currency")
+	}
+	for i, item := range invoice.Items {
+		if err := validateItem(item); err != nil {
+			return fmt.Errorf("%w: items[%d] %s", ErrInvalidItem, i, err)
+		}
+	}
+	for i := range invoice.Installments {
+		if err := validateInstallment(invoice, i); err != nil {
+			return fmt.Errorf("%w: installments[%d] %s", ErrInvalidInstallment, i, err)
+		}
+	}
+	return nil
+}
+
+// validateInstallment checks the i-th installment of an invoice: they are due one after the other, from the issue date,
+// and the last one at the due date of the invoice. Their total is checked once the total amount is calculated
+func validateInstallment(invoice *entity.Invoice, i int) error {
+	installment := invoice.Installments[i]
+	if installment == nil {
+		return errors.New("is null")
+	}
+	if installment.DueDate.IsZero() {
+		return errors.New("due_date is required")
+	}
+	if installment.Amount <= 0 {
+		return errors.New("amount must be positive")
+	}
+	if installment.DueDate.Before(invoice.IssueDate) {
+		return errors.New("due_date can't be before the issue_date of the invoice")
+	}
+	if i > 0 && invoice.Installments[i-1] != nil && !installment.DueDate.After(invoice.Installments[i-1].DueDate) {
+		return errors.New("due_date must be after the one of the previous installment")
+	}
+	if i == len(invoice.Installments)-1 && !installment.DueDate.Equal(invoice.DueDate) {
+		return errors.New("due_date of the last installment must be the due_date of the invoice")
+	}
+	return nil
+}
+
+// validateItem checks an item of an invoice, its amount is calculated
+func validateItem(item *entity.InvoiceItem) error {
+	if item == nil {
+		return errors.New("is null")
+	}
+	if strings.TrimSpace(item.Description) == "" {
+		return errors.New("description is required")
+	}
+	if item.Quantity <= 0 {
+		return errors.New("quantity must be positive")
+	}
+	if item.UnitPrice < 0 {
+		return errors.New("unit_price can't be negative")
+	}
+	if !slices.Contains(entity.TaxCategories, item.TaxCategory) {
+		return fmt.Errorf("tax_category must be one of %s", strings.Join(entity.TaxCategories, ", "))
+	}
 	return nil
 }
 
-func (con *InvoiceController) GetInvoicesByDateRange(ctx context.Context, from string, to string) ([]*models.Invoice, error) {
+// validateCurrency checks an optional currency is known
+func validateCurrency(currency string) error {
+	if _, ok := entity.CurrencyDigits[currency]; currency != "" && !ok {
+		return fmt.Errorf("%w: %q", usecase.ErrInvalidCurrency, currency)
+	}
+	return nil
+}
+
+// GetInvoicesByDateRange lists the invoices due between from and to, or with an installment due then if by is
+// ListByInstallmentDueDate. With a limit, it returns a page of the list after the given id, and the id to continue
+// after (next) unless it is the last page
+func (con *InvoiceController) GetInvoicesByDateRange(ctx context.Context, from, to, by, limit, after string) (_ []*models.Invoice, next int64, err error) {
+	ctx, span := trace.Start(ctx, "InvoiceController.GetInvoicesByDateRange")
+	defer trace.End(span, &err)
+
 	// Validate start_date
 	fromDate, err := time.Parse("2006-01-02", from)
 	if err != nil {
-		return nil, errors.Wrap(err, "invalid start_date format, expected YYYY-MM-DD")
+		return nil, 0, errors.Wrap(err, "invalid start_date format, expected YYYY-MM-DD")
 	}
 
 	// Validate end_date
 	toDate, err := time.Parse("2006-01-02", to)
 	if err != nil {
-		return nil, errors.Wrap(err, "invalid end_date format, expected YYYY-MM-DD")
+		return nil, 0, errors.Wrap(err, "invalid end_date format, expected YYYY-MM-DD")
+	}
+
+	list := con.use.GetInvoicesByDateRange
+	switch by {
+	case "", ListByDueDate:
+	case ListByInstallmentDueDate:
+		list = con.use.GetInvoicesByInstallmentDateRange
+	default:
+		return nil, 0, fmt.Errorf("%w: %q", ErrInvalidDateField, by)
+	}
+
+	page, err := parsePage(limit, after)
+	if err != nil {
+		return nil, 0, err
 	}
 
-	invoices, err := con.use.GetInvoicesByDateRange(ctx, fromDate, toDate)
+	// One more invoice is fetched to know whether there is a next page
+	if page.Limit > 0 {
+		page.Limit++
+	}
+	invoices, err := list(ctx, fromDate, toDate, page)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "failed to retrieve invoices by date range")
+	}
+	if page.Limit > 0 && len(invoices) == page.Limit {
+		invoices = invoices[:page.Limit-1]
+		next = invoices[len(invoices)-1].ID
+	}
+
+	return invoices, next, nil
+}
+
+func (con *InvoiceController) GetInvoice(ctx context.Context, id string) (_ *models.Invoice, err error) {
+	ctx, span := trace.Start(ctx, "InvoiceController.GetInvoice")
+	defer trace.End(span, &err)
+
+	invoiceID, err := parseID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	invoice, err := con.use.GetInvoice(ctx, invoiceID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to retrieve invoice")
+	}
+
+	return invoice, nil
+}
+
+// UpdateInvoice replaces the invoice id, provided it is still at version (or usecase.AnyVersion)
+func (con *InvoiceController) UpdateInvoice(ctx context.Context, id string, version int64, invoice *entity.Invoice) (_ *models.Invoice, err error) {
+	ctx, span := trace.Start(ctx, "InvoiceController.UpdateInvoice")
+	defer trace.End(span, &err)
+
+	invoiceID, err := parseID(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateInvoice(invoice); err != nil {
+		return nil, errors.Wrap(err, "invoice validation failed")
+	}
+	invoice.ID = invoiceID
+	invoice.Version = version
+
+	updated, err := con.use.UpdateInvoice(ctx, invoice)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to update invoice")
+	}
+
+	return updated, nil
+}
+
+// DeleteInvoice deletes the invoice id, provided it is still at version (or usecase.AnyVersion)
+func (con *InvoiceController) DeleteInvoice(ctx context.Context, id string, version int64) (err error) {
+	ctx, span := trace.Start(ctx, "InvoiceController.DeleteInvoice")
+	defer trace.End(span, &err)
+
+	invoiceID, err := parseID(id)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to retrieve invoices by date range")
+		return err
 	}
 
-	return invoices, nil
+	if err := con.use.DeleteInvoice(ctx, invoiceID, version); err != nil {
+		return errors.Wrap(err, "failed to delete invoice")
+	}
+
+	return nil
+}
+
+// parsePage validates the pagination parameters, both optional
+func parsePage(limit, after string) (page repository.Page, err error) {
+	if limit != "" {
+		page.Limit, err = strconv.Atoi(limit)
+		if err != nil || page.Limit <= 0 {
+			return page, errors.New("invalid limit, expected a positive integer")
+		}
+	}
+	if after != "" {
+		page.AfterID, err = strconv.ParseInt(after, 10, 64)
+		if err != nil || page.AfterID < 0 {
+			return page, errors.New("invalid after, expected an invoice id")
+		}
+	}
+	return page, nil
+}
+
+// parseID validates an id given in the path
+func parseID(id string) (int64, error) {
+	invoiceID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil || invoiceID <= 0 {
+		return 0, ErrInvalidID
+	}
+	return invoiceID, nil
 }
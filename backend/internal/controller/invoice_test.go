@@ -5,9 +5,11 @@ import (
 	"testing"
 	"time"
 
+	"github.com/niko-cb/uct/internal/application/usecase"
 	"github.com/niko-cb/uct/internal/controller"
 	"github.com/niko-cb/uct/internal/domain/entity"
 	"github.com/niko-cb/uct/internal/domain/entity/models"
+	"github.com/niko-cb/uct/internal/domain/repository"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -22,11 +24,42 @@ func (m *MockInvoiceUsecase) CreateInvoice(ctx context.Context, invoice *entity.
 	return args.Error(0)
 }
 
-func (m *MockInvoiceUsecase) GetInvoicesByDateRange(ctx context.Context, from, to time.Time) ([]*models.Invoice, error) {
-	args := m.Called(ctx, from, to)
+func (m *MockInvoiceUsecase) GetInvoicesByDateRange(ctx context.Context, from, to time.Time, page repository.Page) ([]*models.Invoice, error) {
+	args := m.Called(ctx, from, to, page)
 	return args.Get(0).([]*models.Invoice), args.Error(1)
 }
 
+func (m *MockInvoiceUsecase) GetInvoicesByInstallmentDateRange(ctx context.Context, from, to time.Time, page repository.Page) ([]*models.Invoice, error) {
+	args := m.Called(ctx, from, to, page)
+	return args.Get(0).([]*models.Invoice), args.Error(1)
+}
+
+func (m *MockInvoiceUsecase) GetInvoice(ctx context.Context, id int64) (*models.Invoice, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Invoice), args.Error(1)
+}
+
+func (m *MockInvoiceUsecase) UpdateInvoice(ctx context.Context, invoice *entity.Invoice) (*models.Invoice, error) {
+	args := m.Called(ctx, invoice)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Invoice), args.Error(1)
+}
+
+func (m *MockInvoiceUsecase) DeleteInvoice(ctx context.Context, id int64, version int64) error {
+	args := m.Called(ctx, id, version)
+	return args.Error(0)
+}
+
+func (m *MockInvoiceUsecase) RecalculateInvoices(ctx context.Context, from time.Time, to time.Time, dryRun bool) (usecase.RecalculateResult, error) {
+	args := m.Called(ctx, from, to, dryRun)
+	return args.Get(0).(usecase.RecalculateResult), args.Error(1)
+}
+
 func TestCreateInvoice_ValidInvoice(t *testing.T) {
 	ctx := context.Background()
 
@@ -47,8 +80,8 @@ func TestCreateInvoice_ValidInvoice(t *testing.T) {
 		DueDate:       time.Now().Add(30 * 24 * time.Hour), // Add DueDate
 	}
 
-	// Set expectations for the mock
-	mockUsecase.On("CreateInvoice", ctx, invoice).Return(nil)
+	// Set expectations for the mock (the controller passes down a traced child context)
+	mockUsecase.On("CreateInvoice", mock.Anything, invoice).Return(nil)
 
 	// Call
 	err := c.CreateInvoice(ctx, invoice)
@@ -99,14 +132,15 @@ func TestGetInvoicesByDateRange_ValidDates(t *testing.T) {
 	toDate, _ := time.Parse("2006-01-02", "2024-01-31")
 
 	// Set up mock return value
-	mockUsecase.On("GetInvoicesByDateRange", ctx, fromDate, toDate).Return([]*models.Invoice{}, nil)
+	mockUsecase.On("GetInvoicesByDateRange", mock.Anything, fromDate, toDate, repository.Page{}).Return([]*models.Invoice{}, nil)
 
 	// Call with valid dates
-	invoices, err := c.GetInvoicesByDateRange(ctx, "2024-01-01", "2024-01-31")
+	invoices, next, err := c.GetInvoicesByDateRange(ctx, "2024-01-01", "2024-01-31", "", "", "")
 
 	// Assert that there are no errors and the result is as expected
 	assert.NoError(t, err)
 	assert.NotNil(t, invoices)
+	assert.Zero(t, next)
 	mockUsecase.AssertExpectations(t)
 }
 
@@ -120,10 +154,110 @@ func TestGetInvoicesByDateRange_InvalidDates(t *testing.T) {
 	c := controller.NewInvoiceController(mockUsecase)
 
 	// Call with invalid start_date
-	_, err := c.GetInvoicesByDateRange(ctx, "invalid-date", "2024-01-31")
+	_, _, err := c.GetInvoicesByDateRange(ctx, "invalid-date", "2024-01-31", "", "", "")
 
 	// Adjust the expected error message to match the detailed error output
 	expectedErr := "invalid start_date format, expected YYYY-MM-DD: parsing time \"invalid-date\" as \"2006-01-02\": cannot parse \"invalid-date\" as \"2006\""
 	assert.Error(t, err)
 	assert.EqualError(t, err, expectedErr)
 }
+
+func TestGetInvoice_InvalidID(t *testing.T) {
+	c := controller.NewInvoiceController(new(MockInvoiceUsecase))
+
+	for _, id := range []string{"", "abc", "0", "-1"} {
+		_, err := c.GetInvoice(context.Background(), id)
+		assert.ErrorIs(t, err, controller.ErrInvalidID, id)
+	}
+}
+
+func TestUpdateInvoice_SetsIDAndVersion(t *testing.T) {
+	ctx := context.Background()
+
+	mockUsecase := new(MockInvoiceUsecase)
+	c := controller.NewInvoiceController(mockUsecase)
+
+	invoice := &entity.Invoice{
+		CompanyID:     1,
+		ClientID:      1,
+		PaymentAmount: 10000.0,
+		Status:        "unprocessed",
+		IssueDate:     time.Now(),
+		DueDate:       time.Now().Add(30 * 24 * time.Hour),
+	}
+	updated := &models.Invoice{ID: 7, Version: 3}
+	mockUsecase.On("UpdateInvoice", mock.Anything, mock.MatchedBy(func(i *entity.Invoice) bool {
+		return i.ID == 7 && i.Version == 2
+	})).Return(updated, nil)
+
+	result, err := c.UpdateInvoice(ctx, "7", 2, invoice)
+
+	assert.NoError(t, err)
+	assert.Equal(t, updated, result)
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestGetInvoicesByDateRange_Page(t *testing.T) {
+	ctx := context.Background()
+
+	mockUsecase := new(MockInvoiceUsecase)
+	c := controller.NewInvoiceController(mockUsecase)
+
+	fromDate, _ := time.Parse("2006-01-02", "2024-01-01")
+	toDate, _ := time.Parse("2006-01-02", "2024-01-31")
+
+	// One more invoice than the limit is fetched to know whether there is a next page
+	mockUsecase.On("GetInvoicesByDateRange", mock.Anything, fromDate, toDate, repository.Page{AfterID: 10, Limit: 3}).
+		Return([]*models.Invoice{{ID: 11}, {ID: 12}, {ID: 13}}, nil)
+
+	invoices, next, err := c.GetInvoicesByDateRange(ctx, "2024-01-01", "2024-01-31", "", "2", "10")
+
+	assert.NoError(t, err)
+	assert.Len(t, invoices, 2)
+	assert.Equal(t, int64(12), next)
+	mockUsecase.AssertExpectations(t)
+
+	_, _, err = c.GetInvoicesByDateRange(ctx, "2024-01-01", "2024-01-31", "", "0", "")
+	assert.Error(t, err)
+}
+
+func TestGetInvoicesByDateRange_ByInstallmentDueDate(t *testing.T) {
+	ctx := context.Background()
+
+	mockUsecase := new(MockInvoiceUsecase)
+	c := controller.NewInvoiceController(mockUsecase)
+
+	fromDate, _ := time.Parse("2006-01-02", "2024-01-01")
+	toDate, _ := time.Parse("2006-01-02", "2024-01-31")
+	mockUsecase.On("GetInvoicesByInstallmentDateRange", mock.Anything, fromDate, toDate, repository.Page{}).
+		Return([]*models.Invoice{{ID: 7}}, nil)
+
+	invoices, _, err := c.GetInvoicesByDateRange(ctx, "2024-01-01", "2024-01-31", controller.ListByInstallmentDueDate, "", "")
+
+	assert.NoError(t, err)
+	assert.Len(t, invoices, 1)
+	mockUsecase.AssertExpectations(t)
+
+	_, _, err = c.GetInvoicesByDateRange(ctx, "2024-01-01", "2024-01-31", "issue_date", "", "")
+	assert.ErrorIs(t, err, controller.ErrInvalidDateField)
+}
+
+func TestCreateInvoice_InvalidInstallments(t *testing.T) {
+	ctx := context.Background()
+	c := controller.NewInvoiceController(new(MockInvoiceUsecase))
+
+	issued := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	june := time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC)
+	july := time.Date(2024, 7, 31, 0, 0, 0, 0, time.UTC)
+	for name, installments := range map[string][]*entity.InvoiceInstallment{
+		"no amount":             {{DueDate: june}, {DueDate: july, Amount: 100}},
+		"out of order":          {{DueDate: july, Amount: 100}, {DueDate: june, Amount: 100}},
+		"last not the due date": {{DueDate: june, Amount: 100}},
+		"before the issue date": {{DueDate: issued.AddDate(0, 0, -1), Amount: 100}, {DueDate: july, Amount: 100}},
+	} {
+		invoice := &entity.Invoice{CompanyID: 1, ClientID: 1, IssueDate: issued, DueDate: july, PaymentAmount: 200,
+			Installments: installments}
+		err := c.CreateInvoice(ctx, invoice)
+		assert.ErrorIs(t, err, controller.ErrInvalidInstallment, name)
+	}
+}
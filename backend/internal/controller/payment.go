@@ -0,0 +1,78 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/friendsofgo/errors"
+	"github.com/niko-cb/uct/internal/application/usecase"
+	"github.com/niko-cb/uct/internal/domain/entity"
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/trace"
+)
+
+// ErrInvalidPayment is returned when a payment is incomplete or pays a non-positive amount
+var ErrInvalidPayment = errors.New("invalid payment")
+
+type PaymentController struct {
+	use usecase.PaymentUsecase
+}
+
+func NewPaymentController(use usecase.PaymentUsecase) *PaymentController {
+	return &PaymentController{use: use}
+}
+
+// RecordPayment saves a payment of the invoice invoiceID, and returns the invoice with its derived status
+func (con *PaymentController) RecordPayment(ctx context.Context, invoiceID string, payment *entity.Payment) (_ *models.Invoice, err error) {
+	ctx, span := trace.Start(ctx, "PaymentController.RecordPayment")
+	defer trace.End(span, &err)
+
+	id, err := parseID(invoiceID)
+	if err != nil {
+		return nil, err
+	}
+	if err := validatePayment(payment); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidPayment, err)
+	}
+	payment.ID = 0
+	payment.InvoiceID = id
+
+	invoice, err := con.use.RecordPayment(ctx, payment)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to record payment")
+	}
+
+	return invoice, nil
+}
+
+// validatePayment checks a payment, the reference being optional
+func validatePayment(payment *entity.Payment) error {
+	if payment == nil {
+		return errors.New("payment is required")
+	}
+	if payment.PaymentDate.IsZero() {
+		return errors.New("payment_date is required")
+	}
+	if payment.Amount <= 0 {
+		return errors.New("amount must be positive")
+	}
+	return nil
+}
+
+// GetPayments lists the payments of the invoice invoiceID
+func (con *PaymentController) GetPayments(ctx context.Context, invoiceID string) (_ models.InvoicePaymentSlice, err error) {
+	ctx, span := trace.Start(ctx, "PaymentController.GetPayments")
+	defer trace.End(span, &err)
+
+	id, err := parseID(invoiceID)
+	if err != nil {
+		return nil, err
+	}
+
+	payments, err := con.use.GetPayments(ctx, id)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to retrieve the payments of the invoice")
+	}
+
+	return payments, nil
+}
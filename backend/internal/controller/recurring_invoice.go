@@ -0,0 +1,183 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/niko-cb/uct/internal/application/usecase"
+	"github.com/niko-cb/uct/internal/domain/entity"
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/trace"
+)
+
+var (
+	// ErrInvalidRecurringInvoice is returned when a recurring invoice is incomplete or its schedule is invalid
+	ErrInvalidRecurringInvoice = errors.New("invalid recurring invoice")
+	// ErrInvalidCount is returned when the number of occurrences to preview is not a positive integer up to MaxPreviewCount
+	ErrInvalidCount = errors.New("invalid count, expected a positive integer up to 120")
+)
+
+// defaultPreviewCount is the number of occurrences previewed when no count is given
+const defaultPreviewCount = 12
+
+// MaxPreviewCount is the most occurrences previewed by a request, ten years of a monthly recurring invoice
+const MaxPreviewCount = 120
+
+type RecurringInvoiceController struct {
+	use usecase.RecurringInvoiceUsecase
+	// now returns the current time, the date the paused recurring invoices are resumed at
+	now func() time.Time
+}
+
+func NewRecurringInvoiceController(use usecase.RecurringInvoiceUsecase) *RecurringInvoiceController {
+	return &RecurringInvoiceController{use: use, now: time.Now}
+}
+
+// CreateRecurringInvoice saves a recurring invoice, active from its start date
+func (con *RecurringInvoiceController) CreateRecurringInvoice(ctx context.Context, recurring *entity.RecurringInvoice) (_ *models.RecurringInvoice, err error) {
+	ctx, span := trace.Start(ctx, "RecurringInvoiceController.CreateRecurringInvoice")
+	defer trace.End(span, &err)
+
+	if err := validateRecurringInvoice(recurring); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidRecurringInvoice, err)
+	}
+	recurring.ID = 0
+	if recurring.Every == 0 {
+		recurring.Every = 1
+	}
+
+	created, err := con.use.CreateRecurringInvoice(ctx, recurring)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create recurring invoice")
+	}
+
+	return created, nil
+}
+
+// validateRecurringInvoice checks a recurring invoice, every defaulting to 1 and the currency to the base one
+func validateRecurringInvoice(recurring *entity.RecurringInvoice) error {
+	if recurring == nil {
+		return errors.New("recurring invoice is required")
+	}
+	if recurring.CompanyID == 0 {
+		return errors.New("company_id is required")
+	}
+	if recurring.ClientID == 0 {
+		return errors.New("client_id is required")
+	}
+	if !slices.Contains(entity.RecurringFrequencies, recurring.Frequency) {
+		return fmt.Errorf("frequency must be one of %s", strings.Join(entity.RecurringFrequencies, ", "))
+	}
+	if recurring.Every < 0 {
+		return errors.New("every must be positive")
+	}
+	if recurring.Frequency == entity.RecurringFrequencyMonthly && (recurring.DayOfMonth < 1 || recurring.DayOfMonth > 31) {
+		return errors.New("day_of_month must be between 1 and 31 for a monthly recurring invoice")
+	}
+	if recurring.Frequency != entity.RecurringFrequencyMonthly && recurring.DayOfMonth != 0 {
+		return errors.New("day_of_month is only for a monthly recurring invoice")
+	}
+	if recurring.StartDate.IsZero() {
+		return errors.New("start_date is required")
+	}
+	if recurring.EndDate != nil && recurring.EndDate.Before(recurring.StartDate) {
+		return errors.New("end_date can't be before the start_date")
+	}
+	if recurring.MaxOccurrences < 0 {
+		return errors.New("max_occurrences can't be negative")
+	}
+	if recurring.PaymentAmount <= 0 {
+		return errors.New("payment_amount must be positive")
+	}
+	if recurring.DueDays < 0 {
+		return errors.New("due_days can't be negative")
+	}
+	return validateCurrency(recurring.Currency)
+}
+
+// GetRecurringInvoice returns the recurring invoice id
+func (con *RecurringInvoiceController) GetRecurringInvoice(ctx context.Context, id string) (_ *models.RecurringInvoice, err error) {
+	ctx, span := trace.Start(ctx, "RecurringInvoiceController.GetRecurringInvoice")
+	defer trace.End(span, &err)
+
+	recurringID, err := parseID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	recurring, err := con.use.GetRecurringInvoice(ctx, recurringID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to retrieve recurring invoice")
+	}
+
+	return recurring, nil
+}
+
+// GetRecurringInvoicesByClient lists the recurring invoices of the client clientID
+func (con *RecurringInvoiceController) GetRecurringInvoicesByClient(ctx context.Context, clientID string) (_ []*models.RecurringInvoice, err error) {
+	ctx, span := trace.Start(ctx, "RecurringInvoiceController.GetRecurringInvoicesByClient")
+	defer trace.End(span, &err)
+
+	id, err := parseID(clientID)
+	if err != nil {
+		return nil, errors.Wrap(err, "client_id")
+	}
+
+	recurring, err := con.use.GetRecurringInvoicesByClient(ctx, id)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to retrieve the recurring invoices of the client")
+	}
+
+	return recurring, nil
+}
+
+// UpdateRecurringInvoiceStatus pauses or resumes the recurring invoice id, provided it is still at version
+// (or usecase.AnyVersion). A resumed one bills the occurrences from today
+func (con *RecurringInvoiceController) UpdateRecurringInvoiceStatus(ctx context.Context, id string, version int64, status *entity.RecurringInvoiceStatus) (_ *models.RecurringInvoice, err error) {
+	ctx, span := trace.Start(ctx, "RecurringInvoiceController.UpdateRecurringInvoiceStatus")
+	defer trace.End(span, &err)
+
+	recurringID, err := parseID(id)
+	if err != nil {
+		return nil, err
+	}
+	if status == nil {
+		return nil, errors.Wrap(usecase.ErrInvalidRecurringStatus, "status is required")
+	}
+
+	updated, err := con.use.UpdateRecurringInvoiceStatus(ctx, recurringID, version, status.Status, day(con.now()))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to update recurring invoice")
+	}
+
+	return updated, nil
+}
+
+// PreviewOccurrences returns the next count invoices of the recurring invoice id, defaultPreviewCount if empty
+func (con *RecurringInvoiceController) PreviewOccurrences(ctx context.Context, id, count string) (_ []*entity.Occurrence, err error) {
+	ctx, span := trace.Start(ctx, "RecurringInvoiceController.PreviewOccurrences")
+	defer trace.End(span, &err)
+
+	recurringID, err := parseID(id)
+	if err != nil {
+		return nil, err
+	}
+	n := defaultPreviewCount
+	if count != "" {
+		if n, err = strconv.Atoi(count); err != nil || n <= 0 || n > MaxPreviewCount {
+			return nil, ErrInvalidCount
+		}
+	}
+
+	occurrences, err := con.use.PreviewOccurrences(ctx, recurringID, day(con.now()), n)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to preview the occurrences of the recurring invoice")
+	}
+
+	return occurrences, nil
+}
@@ -0,0 +1,180 @@
+package controller
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/niko-cb/uct/internal/application/usecase"
+	"github.com/niko-cb/uct/internal/domain/entity"
+	"github.com/niko-cb/uct/internal/domain/repository"
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/trace"
+)
+
+// maxForecastDays is the longest range of the cash forecast and of the issuer report, three years
+const maxForecastDays = 3 * 366
+
+var (
+	// ErrInvalidDate is returned when a date parameter is not formatted as YYYY-MM-DD
+	ErrInvalidDate = errors.New("invalid date, expected YYYY-MM-DD")
+	// ErrInvalidRange is returned when a range ends before it starts, or is too long
+	ErrInvalidRange = errors.New("invalid range, expected from on or before to, and at most three years between them")
+	// ErrInvalidGranularity is returned for a granularity other than day, week and month
+	ErrInvalidGranularity = errors.New("invalid granularity, expected day, week or month")
+	// ErrInvalidBool is returned when a boolean parameter is not true or false
+	ErrInvalidBool = errors.New("invalid boolean, expected true or false")
+	// ErrCompanyRequired is returned when comparing to the available balance without a company
+	ErrCompanyRequired = errors.New("company_id is required to compare to the available balance")
+)
+
+// CashForecastParams are the query parameters of the cash forecast, all optional
+type CashForecastParams struct {
+	From, To, Granularity, CompanyID, ProjectOverdue, CompareBalance, BaseCurrency string
+}
+
+// IssuerReportParams are the query parameters of the issuer report, all optional
+type IssuerReportParams struct {
+	From, To, CompanyID string
+}
+
+type ReportController struct {
+	use usecase.ReportUsecase
+	// now returns the current time, the default date of the reports
+	now func() time.Time
+}
+
+func NewReportController(use usecase.ReportUsecase) *ReportController {
+	return &ReportController{use: use, now: time.Now}
+}
+
+// GetAgingReport returns the aging report as of a date, today if empty, in a base currency, JPY if empty
+func (con *ReportController) GetAgingReport(ctx context.Context, asOf, baseCurrency string) (_ *entity.AgingReport, err error) {
+	ctx, span := trace.Start(ctx, "ReportController.GetAgingReport")
+	defer trace.End(span, &err)
+
+	date, err := parseDate(asOf, con.now())
+	if err != nil {
+		return nil, errors.Wrap(err, "as_of")
+	}
+	if err := validateCurrency(baseCurrency); err != nil {
+		return nil, errors.Wrap(err, "base_currency")
+	}
+
+	report, err := con.use.GetAgingReport(ctx, date, baseCurrency)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build the aging report")
+	}
+
+	return report, nil
+}
+
+// GetCashForecast returns the cash forecast, by default of every company, by day, from today and for 30 days
+func (con *ReportController) GetCashForecast(ctx context.Context, params CashForecastParams) (_ *entity.CashForecast, err error) {
+	ctx, span := trace.Start(ctx, "ReportController.GetCashForecast")
+	defer trace.End(span, &err)
+
+	now := con.now()
+	opts := usecase.CashForecastOptions{Granularity: params.Granularity, Today: day(now)}
+	if opts.From, err = parseDate(params.From, now); err != nil {
+		return nil, errors.Wrap(err, "from")
+	}
+	if opts.To, err = parseDate(params.To, opts.From.AddDate(0, 0, 30)); err != nil {
+		return nil, errors.Wrap(err, "to")
+	}
+	if opts.To.Before(opts.From) || opts.To.Sub(opts.From) > maxForecastDays*24*time.Hour {
+		return nil, ErrInvalidRange
+	}
+	if opts.Granularity == "" {
+		opts.Granularity = entity.GranularityDay
+	}
+	if !slices.Contains(entity.Granularities, opts.Granularity) {
+		return nil, ErrInvalidGranularity
+	}
+	if params.CompanyID != "" {
+		if opts.CompanyID, err = parseID(params.CompanyID); err != nil {
+			return nil, errors.Wrap(err, "company_id")
+		}
+	}
+	if opts.ProjectOverdue, err = parseBool(params.ProjectOverdue); err != nil {
+		return nil, errors.Wrap(err, "project_overdue")
+	}
+	if opts.CompareBalance, err = parseBool(params.CompareBalance); err != nil {
+		return nil, errors.Wrap(err, "compare_balance")
+	}
+	if opts.CompareBalance && opts.CompanyID == 0 {
+		return nil, ErrCompanyRequired
+	}
+	if err := validateCurrency(params.BaseCurrency); err != nil {
+		return nil, errors.Wrap(err, "base_currency")
+	}
+	opts.BaseCurrency = params.BaseCurrency
+
+	forecast, err := con.use.GetCashForecast(ctx, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build the cash forecast")
+	}
+
+	return forecast, nil
+}
+
+// GetIssuerReport returns the invoices whose client was not a registered issuer on the issue date, by default
+// of every company, issued in the 30 days up to today
+func (con *ReportController) GetIssuerReport(ctx context.Context, params IssuerReportParams) (_ *entity.IssuerReport, err error) {
+	ctx, span := trace.Start(ctx, "ReportController.GetIssuerReport")
+	defer trace.End(span, &err)
+
+	var filter repository.IssuerFilter
+	if filter.To, err = parseDate(params.To, con.now()); err != nil {
+		return nil, errors.Wrap(err, "to")
+	}
+	if filter.From, err = parseDate(params.From, filter.To.AddDate(0, 0, -30)); err != nil {
+		return nil, errors.Wrap(err, "from")
+	}
+	if filter.To.Before(filter.From) || filter.To.Sub(filter.From) > maxForecastDays*24*time.Hour {
+		return nil, ErrInvalidRange
+	}
+	if params.CompanyID != "" {
+		if filter.CompanyID, err = parseID(params.CompanyID); err != nil {
+			return nil, errors.Wrap(err, "company_id")
+		}
+	}
+
+	report, err := con.use.GetIssuerReport(ctx, filter)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build the issuer report")
+	}
+
+	return report, nil
+}
+
+// parseBool validates a boolean parameter, false if it is empty
+func parseBool(b string) (bool, error) {
+	if b == "" {
+		return false, nil
+	}
+	parsed, err := strconv.ParseBool(b)
+	if err != nil {
+		return false, ErrInvalidBool
+	}
+	return parsed, nil
+}
+
+// parseDate validates a date parameter, returning the date of fallback if it is empty
+func parseDate(date string, fallback time.Time) (time.Time, error) {
+	if date == "" {
+		return day(fallback), nil
+	}
+	parsed, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return time.Time{}, ErrInvalidDate
+	}
+	return parsed, nil
+}
+
+// day returns the date of a time in UTC, at midnight
+func day(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
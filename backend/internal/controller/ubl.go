@@ -0,0 +1,72 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/friendsofgo/errors"
+	"github.com/niko-cb/uct/internal/application/usecase"
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/trace"
+	"github.com/niko-cb/uct/internal/infrastructure/web/config"
+)
+
+// ErrInvalidDocument is returned when a UBL document to import is empty
+var ErrInvalidDocument = errors.New("invalid document")
+
+type UBLController struct {
+	use usecase.UBLUsecase
+	// maxSize is the largest document accepted, in bytes, the maximum size of the attachments it is saved as
+	maxSize int64
+}
+
+func NewUBLController(use usecase.UBLUsecase, cfg *config.Config) *UBLController {
+	return &UBLController{use: use, maxSize: cfg.AttachmentMaxSize}
+}
+
+// ImportInvoice creates the invoice of the UBL document read from content, received by the company companyID
+func (con *UBLController) ImportInvoice(ctx context.Context, companyID string, content io.Reader) (_ *models.Invoice, err error) {
+	ctx, span := trace.Start(ctx, "UBLController.ImportInvoice")
+	defer trace.End(span, &err)
+
+	id, err := parseID(companyID)
+	if err != nil {
+		return nil, errors.Wrap(err, "company_id")
+	}
+	read, err := io.ReadAll(io.LimitReader(content, con.maxSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidDocument, err)
+	}
+	if int64(len(read)) > con.maxSize {
+		return nil, fmt.Errorf("%w: more than %d bytes", ErrAttachmentTooLarge, con.maxSize)
+	}
+	if len(read) == 0 {
+		return nil, fmt.Errorf("%w: the document is empty", ErrInvalidDocument)
+	}
+
+	invoice, err := con.use.ImportInvoice(ctx, id, read)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to import invoice")
+	}
+
+	return invoice, nil
+}
+
+// ExportInvoice returns the invoice id as a UBL document
+func (con *UBLController) ExportInvoice(ctx context.Context, id string) (_ []byte, err error) {
+	ctx, span := trace.Start(ctx, "UBLController.ExportInvoice")
+	defer trace.End(span, &err)
+
+	invoiceID, err := parseID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	document, err := con.use.ExportInvoice(ctx, invoiceID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to export invoice")
+	}
+
+	return document, nil
+}
@@ -10,3 +10,12 @@ func ConvertToDecimal(amount float64) (types.Decimal, error) {
 	err := d.Scan(amount)
 	return d, err
 }
+
+// DecimalToFloat converts a types.Decimal read from the database to float64, 0 if it is not set
+func DecimalToFloat(d types.Decimal) float64 {
+	if d.Big == nil {
+		return 0
+	}
+	f, _ := d.Float64()
+	return f
+}
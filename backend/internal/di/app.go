@@ -0,0 +1,97 @@
+package di
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/niko-cb/uct/internal/application/seed"
+	"github.com/niko-cb/uct/internal/application/usecase"
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/health"
+	"github.com/niko-cb/uct/internal/infrastructure/persistent/migration"
+	"github.com/niko-cb/uct/internal/infrastructure/persistent/sqldb"
+	"github.com/niko-cb/uct/internal/infrastructure/web/config"
+	"github.com/niko-cb/uct/internal/infrastructure/web/handler"
+)
+
+// App is the application container. It is built once on startup and holds the
+// shared dependencies (configuration, readiness checker, ...) and every handler
+type App struct {
+	Config *config.Config
+	// Migrator is nil when the storage has no schema (memory store)
+	Migrator *migration.Migrator
+	Checker  *health.Checker
+
+	HealthHandler           handler.IHealthHandler
+	InvoiceHandler          handler.IInvoiceHandler
+	CreditNoteHandler       handler.ICreditNoteHandler
+	PaymentHandler          handler.IPaymentHandler
+	AttachmentHandler       handler.IAttachmentHandler
+	UBLHandler              handler.IUBLHandler
+	RecurringInvoiceHandler handler.IRecurringInvoiceHandler
+	ReportHandler           handler.IReportHandler
+
+	// The usecases, for the admin commands
+	Companies   usecase.CompanyUsecase
+	Users       usecase.UserUsecase
+	Clients     usecase.ClientUsecase
+	Invoices    usecase.InvoiceUsecase
+	CreditNotes usecase.CreditNoteUsecase
+	// RecurringInvoices also generates the invoices of the recurring invoices, in the background of the server
+	RecurringInvoices usecase.RecurringInvoiceUsecase
+	FXRates           usecase.FXRateUsecase
+	UBL               usecase.UBLUsecase
+	// InvoiceIssuers imports the qualified invoice issuers of the National Tax Agency and checks a registration number
+	InvoiceIssuers usecase.InvoiceIssuerUsecase
+	Seeder         *seed.Seeder
+}
+
+// NewApp builds the application container on the storage selected by the configuration
+func NewApp(ctx context.Context, cfg *config.Config) (*App, func(), error) {
+	switch cfg.DbDriver {
+	case config.DriverMySQL:
+		return initializeMySQLApp(cfg)
+	case config.DriverPostgres:
+		return initializePostgresApp(cfg)
+	case config.DriverMemory:
+		app, cleanup, err := initializeMemoryApp(cfg)
+		if err != nil {
+			return nil, nil, err
+		}
+		if cfg.MemorySeed != 0 {
+			opts := seed.DefaultOptions()
+			opts.Seed = cfg.MemorySeed
+			if _, err := app.Seeder.Seed(ctx, opts); err != nil {
+				cleanup()
+				return nil, nil, fmt.Errorf("failed to seed the memory store: %w", err)
+			}
+		}
+		return app, cleanup, nil
+	default:
+		return nil, nil, fmt.Errorf("unknown database driver: %s", cfg.DbDriver)
+	}
+}
+
+// NewMigrator builds the migrator of the database selected by the configuration
+func NewMigrator(cfg *config.Config) (*migration.Migrator, func(), error) {
+	switch cfg.DbDriver {
+	case config.DriverMySQL:
+		return initializeMySQLMigrator(cfg)
+	case config.DriverPostgres:
+		return initializePostgresMigrator(cfg)
+	default:
+		return nil, nil, fmt.Errorf("the %s storage has no schema to migrate", cfg.DbDriver)
+	}
+}
+
+// newChecker creates the readiness checker from the configuration
+func newChecker(cfg *config.Config) *health.Checker {
+	return health.NewChecker(cfg.ReadinessTimeout)
+}
+
+// newDatabaseChecker creates the readiness checker, checking the database is reachable and up to date
+func newDatabaseChecker(cfg *config.Config, pool *sqldb.Pool, migrator *migration.Migrator) *health.Checker {
+	checker := newChecker(cfg)
+	checker.Register("database", pool.Ping)
+	checker.Register("migrations", migrator.Check)
+	return checker
+}
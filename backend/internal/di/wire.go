@@ -5,25 +5,221 @@ package di
 
 import (
 	"github.com/google/wire"
+	"github.com/niko-cb/uct/internal/application/seed"
 	"github.com/niko-cb/uct/internal/application/usecase"
 	"github.com/niko-cb/uct/internal/controller"
+	"github.com/niko-cb/uct/internal/domain/repository"
 	"github.com/niko-cb/uct/internal/domain/service"
 	"github.com/niko-cb/uct/internal/infrastructure/gateway"
+	"github.com/niko-cb/uct/internal/infrastructure/persistent/blob"
+	"github.com/niko-cb/uct/internal/infrastructure/persistent/memory"
+	"github.com/niko-cb/uct/internal/infrastructure/persistent/migration"
 	"github.com/niko-cb/uct/internal/infrastructure/persistent/mysql"
-	"github.com/niko-cb/uct/internal/infrastructure/persistent/mysql/transaction"
+	"github.com/niko-cb/uct/internal/infrastructure/persistent/postgres"
+	"github.com/niko-cb/uct/internal/infrastructure/persistent/sqldb"
 	"github.com/niko-cb/uct/internal/infrastructure/web/config"
 	"github.com/niko-cb/uct/internal/infrastructure/web/handler"
 )
 
-func InitializeInvoiceHandler(cfg *config.Config) handler.IInvoiceHandler {
+// mysqlSet provides the MySQL connection pool and what is built on top of it
+var mysqlSet = wire.NewSet(
+	mysql.NewMySQLClient,
+	mysql.NewMigrator,
+	wire.FieldsOf(new(*mysql.MySQLClient), "Pool"),
+	sqldb.NewTransaction,
+	gateway.NewInvoiceGateway,
+	gateway.NewCompanyGateway,
+	gateway.NewUserGateway,
+	gateway.NewClientGateway,
+	gateway.NewSeedGateway,
+	gateway.NewReportGateway,
+	gateway.NewFXRateGateway,
+	gateway.NewCreditNoteGateway,
+	gateway.NewPaymentGateway,
+	gateway.NewRecurringInvoiceGateway,
+	gateway.NewAttachmentGateway,
+	gateway.NewInvoiceIssuerGateway,
+	blob.NewStore,
+)
+
+// postgresSet provides the Postgres connection pool and what is built on top of it
+var postgresSet = wire.NewSet(
+	postgres.NewPostgresClient,
+	postgres.NewMigrator,
+	wire.FieldsOf(new(*postgres.PostgresClient), "Pool"),
+	sqldb.NewTransaction,
+	gateway.NewInvoicePostgresGateway,
+	gateway.NewCompanyPostgresGateway,
+	gateway.NewUserPostgresGateway,
+	gateway.NewClientPostgresGateway,
+	gateway.NewSeedPostgresGateway,
+	gateway.NewReportPostgresGateway,
+	gateway.NewFXRatePostgresGateway,
+	gateway.NewCreditNotePostgresGateway,
+	gateway.NewPaymentPostgresGateway,
+	gateway.NewRecurringInvoicePostgresGateway,
+	gateway.NewAttachmentPostgresGateway,
+	gateway.NewInvoiceIssuerPostgresGateway,
+	blob.NewStore,
+)
+
+// memorySet provides the in-memory store and what is built on top of it
+var memorySet = wire.NewSet(
+	memory.NewStore,
+	memory.NewTransaction,
+	gateway.NewInvoiceMemoryGateway,
+	gateway.NewCompanyMemoryGateway,
+	gateway.NewUserMemoryGateway,
+	gateway.NewClientMemoryGateway,
+	gateway.NewSeedMemoryGateway,
+	gateway.NewReportMemoryGateway,
+	gateway.NewFXRateMemoryGateway,
+	gateway.NewCreditNoteMemoryGateway,
+	gateway.NewPaymentMemoryGateway,
+	gateway.NewRecurringInvoiceMemoryGateway,
+	gateway.NewAttachmentMemoryGateway,
+	gateway.NewInvoiceIssuerMemoryGateway,
+	// The content of the attachments is as throwaway as the rest of the store
+	blob.NewMemoryStore,
+	wire.Bind(new(repository.BlobStore), new(*blob.MemoryStore)),
+)
+
+// invoiceSet provides the invoice resource, from the handler down to the service
+var invoiceSet = wire.NewSet(
+	handler.NewInvoiceHandler,
+	controller.NewInvoiceController,
+	usecase.NewInvoiceUsecase,
+	service.NewInvoiceService,
+)
+
+// creditNoteSet provides the credit note resource, from the handler down to the service
+var creditNoteSet = wire.NewSet(
+	handler.NewCreditNoteHandler,
+	controller.NewCreditNoteController,
+	usecase.NewCreditNoteUsecase,
+	service.NewCreditNoteService,
+)
+
+// paymentSet provides the payments of the invoices, from the handler down to the service
+var paymentSet = wire.NewSet(
+	handler.NewPaymentHandler,
+	controller.NewPaymentController,
+	usecase.NewPaymentUsecase,
+	service.NewPaymentService,
+)
+
+// recurringInvoiceSet provides the recurring invoices and their generator, from the handler down to the service
+var recurringInvoiceSet = wire.NewSet(
+	handler.NewRecurringInvoiceHandler,
+	controller.NewRecurringInvoiceController,
+	usecase.NewRecurringInvoiceUsecase,
+	service.NewRecurringInvoiceService,
+)
+
+// attachmentSet provides the documents attached to the invoices, from the handler down to the service
+var attachmentSet = wire.NewSet(
+	handler.NewAttachmentHandler,
+	controller.NewAttachmentController,
+	usecase.NewAttachmentUsecase,
+	service.NewAttachmentService,
+)
+
+// ublSet provides the import and export of the invoices as UBL documents, from the handler down to the usecase
+var ublSet = wire.NewSet(
+	handler.NewUBLHandler,
+	controller.NewUBLController,
+	usecase.NewUBLUsecase,
+)
+
+// reportSet provides the reports, from the handler down to the service
+var reportSet = wire.NewSet(
+	handler.NewReportHandler,
+	controller.NewReportController,
+	usecase.NewReportUsecase,
+	service.NewReportService,
+)
+
+// adminSet provides the usecases which have no endpoint yet, used by the admin commands and the seeder
+var adminSet = wire.NewSet(
+	usecase.NewCompanyUsecase,
+	service.NewCompanyService,
+	usecase.NewUserUsecase,
+	service.NewUserService,
+	usecase.NewClientUsecase,
+	service.NewClientService,
+	usecase.NewFXRateUsecase,
+	service.NewFXRateService,
+	usecase.NewInvoiceIssuerUsecase,
+	service.NewInvoiceIssuerService,
+	seed.NewSeeder,
+)
+
+func initializeMySQLApp(cfg *config.Config) (*App, func(), error) {
+	wire.Build(
+		wire.Struct(new(App), "*"),
+		mysqlSet,
+		invoiceSet,
+		creditNoteSet,
+		paymentSet,
+		recurringInvoiceSet,
+		attachmentSet,
+		ublSet,
+		reportSet,
+		adminSet,
+		newDatabaseChecker,
+		handler.NewHealthHandler,
+	)
+	return &App{}, nil, nil
+}
+
+func initializePostgresApp(cfg *config.Config) (*App, func(), error) {
+	wire.Build(
+		wire.Struct(new(App), "*"),
+		postgresSet,
+		invoiceSet,
+		creditNoteSet,
+		paymentSet,
+		recurringInvoiceSet,
+		attachmentSet,
+		ublSet,
+		reportSet,
+		adminSet,
+		newDatabaseChecker,
+		handler.NewHealthHandler,
+	)
+	return &App{}, nil, nil
+}
+
+func initializeMemoryApp(cfg *config.Config) (*App, func(), error) {
+	wire.Build(
+		wire.Struct(new(App), "Config", "Checker", "HealthHandler", "InvoiceHandler", "CreditNoteHandler", "PaymentHandler", "AttachmentHandler", "UBLHandler", "RecurringInvoiceHandler", "ReportHandler", "Companies", "Users", "Clients", "Invoices", "CreditNotes", "RecurringInvoices", "FXRates", "UBL", "InvoiceIssuers", "Seeder"),
+		memorySet,
+		invoiceSet,
+		creditNoteSet,
+		paymentSet,
+		recurringInvoiceSet,
+		attachmentSet,
+		ublSet,
+		reportSet,
+		adminSet,
+		newChecker,
+		handler.NewHealthHandler,
+	)
+	return &App{}, nil, nil
+}
+
+func initializeMySQLMigrator(cfg *config.Config) (*migration.Migrator, func(), error) {
 	wire.Build(
-		handler.NewInvoiceHandler,
-		controller.NewInvoiceController,
-		usecase.NewInvoiceUsecase,
-		service.NewInvoiceService,
-		gateway.NewInvoiceGateway,
 		mysql.NewMySQLClient,
-		transaction.NewTransaction,
+		mysql.NewMigrator,
+	)
+	return &migration.Migrator{}, nil, nil
+}
+
+func initializePostgresMigrator(cfg *config.Config) (*migration.Migrator, func(), error) {
+	wire.Build(
+		postgres.NewPostgresClient,
+		postgres.NewMigrator,
 	)
-	return &handler.InvoiceHandler{}
+	return &migration.Migrator{}, nil, nil
 }
@@ -7,25 +7,362 @@
 package di
 
 import (
+	"github.com/google/wire"
+	"github.com/niko-cb/uct/internal/application/seed"
 	"github.com/niko-cb/uct/internal/application/usecase"
 	"github.com/niko-cb/uct/internal/controller"
+	"github.com/niko-cb/uct/internal/domain/repository"
 	"github.com/niko-cb/uct/internal/domain/service"
 	"github.com/niko-cb/uct/internal/infrastructure/gateway"
+	"github.com/niko-cb/uct/internal/infrastructure/persistent/blob"
+	"github.com/niko-cb/uct/internal/infrastructure/persistent/memory"
+	"github.com/niko-cb/uct/internal/infrastructure/persistent/migration"
 	"github.com/niko-cb/uct/internal/infrastructure/persistent/mysql"
-	"github.com/niko-cb/uct/internal/infrastructure/persistent/mysql/transaction"
+	"github.com/niko-cb/uct/internal/infrastructure/persistent/postgres"
+	"github.com/niko-cb/uct/internal/infrastructure/persistent/sqldb"
 	"github.com/niko-cb/uct/internal/infrastructure/web/config"
 	"github.com/niko-cb/uct/internal/infrastructure/web/handler"
 )
 
 // Injectors from wire.go:
 
-func InitializeInvoiceHandler(cfg *config.Config) handler.IInvoiceHandler {
-	mySQLClient := mysql.NewMySQLClient(cfg)
+func initializeMySQLApp(cfg *config.Config) (*App, func(), error) {
+	mySQLClient, cleanup, err := mysql.NewMySQLClient(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	migrator, err := mysql.NewMigrator(mySQLClient)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	pool := mySQLClient.Pool
+	checker := newDatabaseChecker(cfg, pool, migrator)
+	iHealthHandler := handler.NewHealthHandler(checker)
 	invoiceRepository := gateway.NewInvoiceGateway(mySQLClient)
 	invoiceService := service.NewInvoiceService(invoiceRepository)
-	repositoryTransaction := transaction.NewTransaction(mySQLClient)
-	invoiceUsecase := usecase.NewInvoiceUsecase(invoiceService, repositoryTransaction)
+	companyRepository := gateway.NewCompanyGateway(mySQLClient)
+	companyService := service.NewCompanyService(companyRepository)
+	fxRateRepository := gateway.NewFXRateGateway(mySQLClient)
+	fxRateService := service.NewFXRateService(fxRateRepository)
+	transaction := sqldb.NewTransaction(pool)
+	invoiceUsecase := usecase.NewInvoiceUsecase(invoiceService, companyService, fxRateService, transaction)
 	invoiceController := controller.NewInvoiceController(invoiceUsecase)
 	iInvoiceHandler := handler.NewInvoiceHandler(invoiceController)
-	return iInvoiceHandler
+	creditNoteRepository := gateway.NewCreditNoteGateway(mySQLClient)
+	creditNoteService := service.NewCreditNoteService(creditNoteRepository)
+	creditNoteUsecase := usecase.NewCreditNoteUsecase(creditNoteService, invoiceService, companyService, transaction)
+	creditNoteController := controller.NewCreditNoteController(creditNoteUsecase)
+	iCreditNoteHandler := handler.NewCreditNoteHandler(creditNoteController)
+	paymentRepository := gateway.NewPaymentGateway(mySQLClient)
+	paymentService := service.NewPaymentService(paymentRepository)
+	paymentUsecase := usecase.NewPaymentUsecase(paymentService, invoiceService, transaction)
+	paymentController := controller.NewPaymentController(paymentUsecase)
+	iPaymentHandler := handler.NewPaymentHandler(paymentController)
+	attachmentRepository := gateway.NewAttachmentGateway(mySQLClient)
+	attachmentService := service.NewAttachmentService(attachmentRepository)
+	blobStore, err := blob.NewStore(cfg)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	attachmentUsecase := usecase.NewAttachmentUsecase(attachmentService, invoiceService, blobStore, transaction)
+	attachmentController := controller.NewAttachmentController(attachmentUsecase, cfg)
+	iAttachmentHandler := handler.NewAttachmentHandler(attachmentController)
+	clientRepository := gateway.NewClientGateway(mySQLClient)
+	clientService := service.NewClientService(clientRepository)
+	ublUsecase := usecase.NewUBLUsecase(invoiceUsecase, attachmentUsecase, companyService, clientService, transaction)
+	ublController := controller.NewUBLController(ublUsecase, cfg)
+	iublHandler := handler.NewUBLHandler(ublController)
+	recurringInvoiceRepository := gateway.NewRecurringInvoiceGateway(mySQLClient)
+	recurringInvoiceService := service.NewRecurringInvoiceService(recurringInvoiceRepository)
+	recurringInvoiceUsecase := usecase.NewRecurringInvoiceUsecase(recurringInvoiceService, companyService, invoiceUsecase, transaction)
+	recurringInvoiceController := controller.NewRecurringInvoiceController(recurringInvoiceUsecase)
+	iRecurringInvoiceHandler := handler.NewRecurringInvoiceHandler(recurringInvoiceController)
+	reportRepository := gateway.NewReportGateway(mySQLClient)
+	reportService := service.NewReportService(reportRepository)
+	reportUsecase := usecase.NewReportUsecase(reportService, companyService)
+	reportController := controller.NewReportController(reportUsecase)
+	iReportHandler := handler.NewReportHandler(reportController)
+	companyUsecase := usecase.NewCompanyUsecase(companyService, transaction)
+	userRepository := gateway.NewUserGateway(mySQLClient)
+	userService := service.NewUserService(userRepository)
+	userUsecase := usecase.NewUserUsecase(userService, transaction)
+	clientUsecase := usecase.NewClientUsecase(clientService, transaction)
+	fxRateUsecase := usecase.NewFXRateUsecase(fxRateService, transaction)
+	invoiceIssuerRepository := gateway.NewInvoiceIssuerGateway(mySQLClient)
+	invoiceIssuerService := service.NewInvoiceIssuerService(invoiceIssuerRepository)
+	invoiceIssuerUsecase := usecase.NewInvoiceIssuerUsecase(invoiceIssuerService, transaction)
+	seedRepository := gateway.NewSeedGateway(mySQLClient)
+	seeder := seed.NewSeeder(seedRepository, transaction)
+	app := &App{
+		Config:                  cfg,
+		Migrator:                migrator,
+		Checker:                 checker,
+		HealthHandler:           iHealthHandler,
+		InvoiceHandler:          iInvoiceHandler,
+		CreditNoteHandler:       iCreditNoteHandler,
+		PaymentHandler:          iPaymentHandler,
+		AttachmentHandler:       iAttachmentHandler,
+		UBLHandler:              iublHandler,
+		RecurringInvoiceHandler: iRecurringInvoiceHandler,
+		ReportHandler:           iReportHandler,
+		Companies:               companyUsecase,
+		Users:                   userUsecase,
+		Clients:                 clientUsecase,
+		Invoices:                invoiceUsecase,
+		CreditNotes:             creditNoteUsecase,
+		RecurringInvoices:       recurringInvoiceUsecase,
+		FXRates:                 fxRateUsecase,
+		UBL:                     ublUsecase,
+		InvoiceIssuers:          invoiceIssuerUsecase,
+		Seeder:                  seeder,
+	}
+	return app, func() {
+		cleanup()
+	}, nil
 }
+
+func initializePostgresApp(cfg *config.Config) (*App, func(), error) {
+	postgresClient, cleanup, err := postgres.NewPostgresClient(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	migrator, err := postgres.NewMigrator(postgresClient)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	pool := postgresClient.Pool
+	checker := newDatabaseChecker(cfg, pool, migrator)
+	iHealthHandler := handler.NewHealthHandler(checker)
+	invoiceRepository := gateway.NewInvoicePostgresGateway(postgresClient)
+	invoiceService := service.NewInvoiceService(invoiceRepository)
+	companyRepository := gateway.NewCompanyPostgresGateway(postgresClient)
+	companyService := service.NewCompanyService(companyRepository)
+	fxRateRepository := gateway.NewFXRatePostgresGateway(postgresClient)
+	fxRateService := service.NewFXRateService(fxRateRepository)
+	transaction := sqldb.NewTransaction(pool)
+	invoiceUsecase := usecase.NewInvoiceUsecase(invoiceService, companyService, fxRateService, transaction)
+	invoiceController := controller.NewInvoiceController(invoiceUsecase)
+	iInvoiceHandler := handler.NewInvoiceHandler(invoiceController)
+	creditNoteRepository := gateway.NewCreditNotePostgresGateway(postgresClient)
+	creditNoteService := service.NewCreditNoteService(creditNoteRepository)
+	creditNoteUsecase := usecase.NewCreditNoteUsecase(creditNoteService, invoiceService, companyService, transaction)
+	creditNoteController := controller.NewCreditNoteController(creditNoteUsecase)
+	iCreditNoteHandler := handler.NewCreditNoteHandler(creditNoteController)
+	paymentRepository := gateway.NewPaymentPostgresGateway(postgresClient)
+	paymentService := service.NewPaymentService(paymentRepository)
+	paymentUsecase := usecase.NewPaymentUsecase(paymentService, invoiceService, transaction)
+	paymentController := controller.NewPaymentController(paymentUsecase)
+	iPaymentHandler := handler.NewPaymentHandler(paymentController)
+	attachmentRepository := gateway.NewAttachmentPostgresGateway(postgresClient)
+	attachmentService := service.NewAttachmentService(attachmentRepository)
+	blobStore, err := blob.NewStore(cfg)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	attachmentUsecase := usecase.NewAttachmentUsecase(attachmentService, invoiceService, blobStore, transaction)
+	attachmentController := controller.NewAttachmentController(attachmentUsecase, cfg)
+	iAttachmentHandler := handler.NewAttachmentHandler(attachmentController)
+	clientRepository := gateway.NewClientPostgresGateway(postgresClient)
+	clientService := service.NewClientService(clientRepository)
+	ublUsecase := usecase.NewUBLUsecase(invoiceUsecase, attachmentUsecase, companyService, clientService, transaction)
+	ublController := controller.NewUBLController(ublUsecase, cfg)
+	iublHandler := handler.NewUBLHandler(ublController)
+	recurringInvoiceRepository := gateway.NewRecurringInvoicePostgresGateway(postgresClient)
+	recurringInvoiceService := service.NewRecurringInvoiceService(recurringInvoiceRepository)
+	recurringInvoiceUsecase := usecase.NewRecurringInvoiceUsecase(recurringInvoiceService, companyService, invoiceUsecase, transaction)
+	recurringInvoiceController := controller.NewRecurringInvoiceController(recurringInvoiceUsecase)
+	iRecurringInvoiceHandler := handler.NewRecurringInvoiceHandler(recurringInvoiceController)
+	reportRepository := gateway.NewReportPostgresGateway(postgresClient)
+	reportService := service.NewReportService(reportRepository)
+	reportUsecase := usecase.NewReportUsecase(reportService, companyService)
+	reportController := controller.NewReportController(reportUsecase)
+	iReportHandler := handler.NewReportHandler(reportController)
+	companyUsecase := usecase.NewCompanyUsecase(companyService, transaction)
+	userRepository := gateway.NewUserPostgresGateway(postgresClient)
+	userService := service.NewUserService(userRepository)
+	userUsecase := usecase.NewUserUsecase(userService, transaction)
+	clientUsecase := usecase.NewClientUsecase(clientService, transaction)
+	fxRateUsecase := usecase.NewFXRateUsecase(fxRateService, transaction)
+	invoiceIssuerRepository := gateway.NewInvoiceIssuerPostgresGateway(postgresClient)
+	invoiceIssuerService := service.NewInvoiceIssuerService(invoiceIssuerRepository)
+	invoiceIssuerUsecase := usecase.NewInvoiceIssuerUsecase(invoiceIssuerService, transaction)
+	seedRepository := gateway.NewSeedPostgresGateway(postgresClient)
+	seeder := seed.NewSeeder(seedRepository, transaction)
+	app := &App{
+		Config:                  cfg,
+		Migrator:                migrator,
+		Checker:                 checker,
+		HealthHandler:           iHealthHandler,
+		InvoiceHandler:          iInvoiceHandler,
+		CreditNoteHandler:       iCreditNoteHandler,
+		PaymentHandler:          iPaymentHandler,
+		AttachmentHandler:       iAttachmentHandler,
+		UBLHandler:              iublHandler,
+		RecurringInvoiceHandler: iRecurringInvoiceHandler,
+		ReportHandler:           iReportHandler,
+		Companies:               companyUsecase,
+		Users:                   userUsecase,
+		Clients:                 clientUsecase,
+		Invoices:                invoiceUsecase,
+		CreditNotes:             creditNoteUsecase,
+		RecurringInvoices:       recurringInvoiceUsecase,
+		FXRates:                 fxRateUsecase,
+		UBL:                     ublUsecase,
+		InvoiceIssuers:          invoiceIssuerUsecase,
+		Seeder:                  seeder,
+	}
+	return app, func() {
+		cleanup()
+	}, nil
+}
+
+func initializeMemoryApp(cfg *config.Config) (*App, func(), error) {
+	checker := newChecker(cfg)
+	iHealthHandler := handler.NewHealthHandler(checker)
+	store := memory.NewStore()
+	invoiceRepository := gateway.NewInvoiceMemoryGateway(store)
+	invoiceService := service.NewInvoiceService(invoiceRepository)
+	companyRepository := gateway.NewCompanyMemoryGateway(store)
+	companyService := service.NewCompanyService(companyRepository)
+	fxRateRepository := gateway.NewFXRateMemoryGateway(store)
+	fxRateService := service.NewFXRateService(fxRateRepository)
+	transaction := memory.NewTransaction(store)
+	invoiceUsecase := usecase.NewInvoiceUsecase(invoiceService, companyService, fxRateService, transaction)
+	invoiceController := controller.NewInvoiceController(invoiceUsecase)
+	iInvoiceHandler := handler.NewInvoiceHandler(invoiceController)
+	creditNoteRepository := gateway.NewCreditNoteMemoryGateway(store)
+	creditNoteService := service.NewCreditNoteService(creditNoteRepository)
+	creditNoteUsecase := usecase.NewCreditNoteUsecase(creditNoteService, invoiceService, companyService, transaction)
+	creditNoteController := controller.NewCreditNoteController(creditNoteUsecase)
+	iCreditNoteHandler := handler.NewCreditNoteHandler(creditNoteController)
+	paymentRepository := gateway.NewPaymentMemoryGateway(store)
+	paymentService := service.NewPaymentService(paymentRepository)
+	paymentUsecase := usecase.NewPaymentUsecase(paymentService, invoiceService, transaction)
+	paymentController := controller.NewPaymentController(paymentUsecase)
+	iPaymentHandler := handler.NewPaymentHandler(paymentController)
+	attachmentRepository := gateway.NewAttachmentMemoryGateway(store)
+	attachmentService := service.NewAttachmentService(attachmentRepository)
+	memoryStore := blob.NewMemoryStore()
+	attachmentUsecase := usecase.NewAttachmentUsecase(attachmentService, invoiceService, memoryStore, transaction)
+	attachmentController := controller.NewAttachmentController(attachmentUsecase, cfg)
+	iAttachmentHandler := handler.NewAttachmentHandler(attachmentController)
+	clientRepository := gateway.NewClientMemoryGateway(store)
+	clientService := service.NewClientService(clientRepository)
+	ublUsecase := usecase.NewUBLUsecase(invoiceUsecase, attachmentUsecase, companyService, clientService, transaction)
+	ublController := controller.NewUBLController(ublUsecase, cfg)
+	iublHandler := handler.NewUBLHandler(ublController)
+	recurringInvoiceRepository := gateway.NewRecurringInvoiceMemoryGateway(store)
+	recurringInvoiceService := service.NewRecurringInvoiceService(recurringInvoiceRepository)
+	recurringInvoiceUsecase := usecase.NewRecurringInvoiceUsecase(recurringInvoiceService, companyService, invoiceUsecase, transaction)
+	recurringInvoiceController := controller.NewRecurringInvoiceController(recurringInvoiceUsecase)
+	iRecurringInvoiceHandler := handler.NewRecurringInvoiceHandler(recurringInvoiceController)
+	reportRepository := gateway.NewReportMemoryGateway(store)
+	reportService := service.NewReportService(reportRepository)
+	reportUsecase := usecase.NewReportUsecase(reportService, companyService)
+	reportController := controller.NewReportController(reportUsecase)
+	iReportHandler := handler.NewReportHandler(reportController)
+	companyUsecase := usecase.NewCompanyUsecase(companyService, transaction)
+	userRepository := gateway.NewUserMemoryGateway(store)
+	userService := service.NewUserService(userRepository)
+	userUsecase := usecase.NewUserUsecase(userService, transaction)
+	clientUsecase := usecase.NewClientUsecase(clientService, transaction)
+	fxRateUsecase := usecase.NewFXRateUsecase(fxRateService, transaction)
+	invoiceIssuerRepository := gateway.NewInvoiceIssuerMemoryGateway(store)
+	invoiceIssuerService := service.NewInvoiceIssuerService(invoiceIssuerRepository)
+	invoiceIssuerUsecase := usecase.NewInvoiceIssuerUsecase(invoiceIssuerService, transaction)
+	seedRepository := gateway.NewSeedMemoryGateway(store)
+	seeder := seed.NewSeeder(seedRepository, transaction)
+	app := &App{
+		Config:                  cfg,
+		Checker:                 checker,
+		HealthHandler:           iHealthHandler,
+		InvoiceHandler:          iInvoiceHandler,
+		CreditNoteHandler:       iCreditNoteHandler,
+		PaymentHandler:          iPaymentHandler,
+		AttachmentHandler:       iAttachmentHandler,
+		UBLHandler:              iublHandler,
+		RecurringInvoiceHandler: iRecurringInvoiceHandler,
+		ReportHandler:           iReportHandler,
+		Companies:               companyUsecase,
+		Users:                   userUsecase,
+		Clients:                 clientUsecase,
+		Invoices:                invoiceUsecase,
+		CreditNotes:             creditNoteUsecase,
+		RecurringInvoices:       recurringInvoiceUsecase,
+		FXRates:                 fxRateUsecase,
+		UBL:                     ublUsecase,
+		InvoiceIssuers:          invoiceIssuerUsecase,
+		Seeder:                  seeder,
+	}
+	return app, func() {
+	}, nil
+}
+
+func initializeMySQLMigrator(cfg *config.Config) (*migration.Migrator, func(), error) {
+	mySQLClient, cleanup, err := mysql.NewMySQLClient(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	migrator, err := mysql.NewMigrator(mySQLClient)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	return migrator, func() {
+		cleanup()
+	}, nil
+}
+
+func initializePostgresMigrator(cfg *config.Config) (*migration.Migrator, func(), error) {
+	postgresClient, cleanup, err := postgres.NewPostgresClient(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	migrator, err := postgres.NewMigrator(postgresClient)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	return migrator, func() {
+		cleanup()
+	}, nil
+}
+
+// wire.go:
+
+// mysqlSet provides the MySQL connection pool and what is built on top of it
+var mysqlSet = wire.NewSet(mysql.NewMySQLClient, mysql.NewMigrator, wire.FieldsOf(new(*mysql.MySQLClient), "Pool"), sqldb.NewTransaction, gateway.NewInvoiceGateway, gateway.NewCompanyGateway, gateway.NewUserGateway, gateway.NewClientGateway, gateway.NewSeedGateway, gateway.NewReportGateway, gateway.NewFXRateGateway, gateway.NewCreditNoteGateway, gateway.NewPaymentGateway, gateway.NewRecurringInvoiceGateway, gateway.NewAttachmentGateway, gateway.NewInvoiceIssuerGateway, blob.NewStore)
+
+// postgresSet provides the Postgres connection pool and what is built on top of it
+var postgresSet = wire.NewSet(postgres.NewPostgresClient, postgres.NewMigrator, wire.FieldsOf(new(*postgres.PostgresClient), "Pool"), sqldb.NewTransaction, gateway.NewInvoicePostgresGateway, gateway.NewCompanyPostgresGateway, gateway.NewUserPostgresGateway, gateway.NewClientPostgresGateway, gateway.NewSeedPostgresGateway, gateway.NewReportPostgresGateway, gateway.NewFXRatePostgresGateway, gateway.NewCreditNotePostgresGateway, gateway.NewPaymentPostgresGateway, gateway.NewRecurringInvoicePostgresGateway, gateway.NewAttachmentPostgresGateway, gateway.NewInvoiceIssuerPostgresGateway, blob.NewStore)
+
+// memorySet provides the in-memory store and what is built on top of it
+var memorySet = wire.NewSet(memory.NewStore, memory.NewTransaction, gateway.NewInvoiceMemoryGateway, gateway.NewCompanyMemoryGateway, gateway.NewUserMemoryGateway, gateway.NewClientMemoryGateway, gateway.NewSeedMemoryGateway, gateway.NewReportMemoryGateway, gateway.NewFXRateMemoryGateway, gateway.NewCreditNoteMemoryGateway, gateway.NewPaymentMemoryGateway, gateway.NewRecurringInvoiceMemoryGateway, gateway.NewAttachmentMemoryGateway, gateway.NewInvoiceIssuerMemoryGateway, blob.NewMemoryStore, wire.Bind(new(repository.BlobStore), new(*blob.MemoryStore)))
+
+// invoiceSet provides the invoice resource, from the handler down to the service
+var invoiceSet = wire.NewSet(handler.NewInvoiceHandler, controller.NewInvoiceController, usecase.NewInvoiceUsecase, service.NewInvoiceService)
+
+// creditNoteSet provides the credit note resource, from the handler down to the service
+var creditNoteSet = wire.NewSet(handler.NewCreditNoteHandler, controller.NewCreditNoteController, usecase.NewCreditNoteUsecase, service.NewCreditNoteService)
+
+// paymentSet provides the payments of the invoices, from the handler down to the service
+var paymentSet = wire.NewSet(handler.NewPaymentHandler, controller.NewPaymentController, usecase.NewPaymentUsecase, service.NewPaymentService)
+
+// recurringInvoiceSet provides the recurring invoices and their generator, from the handler down to the service
+var recurringInvoiceSet = wire.NewSet(handler.NewRecurringInvoiceHandler, controller.NewRecurringInvoiceController, usecase.NewRecurringInvoiceUsecase, service.NewRecurringInvoiceService)
+
+// attachmentSet provides the documents attached to the invoices, from the handler down to the service
+var attachmentSet = wire.NewSet(handler.NewAttachmentHandler, controller.NewAttachmentController, usecase.NewAttachmentUsecase, service.NewAttachmentService)
+
+// ublSet provides the import and export of the invoices as UBL documents, from the handler down to the usecase
+var ublSet = wire.NewSet(handler.NewUBLHandler, controller.NewUBLController, usecase.NewUBLUsecase)
+
+// reportSet provides the reports, from the handler down to the service
+var reportSet = wire.NewSet(handler.NewReportHandler, controller.NewReportController, usecase.NewReportUsecase, service.NewReportService)
+
+// adminSet provides the usecases which have no endpoint yet, used by the admin commands and the seeder
+var adminSet = wire.NewSet(usecase.NewCompanyUsecase, service.NewCompanyService, usecase.NewUserUsecase, service.NewUserService, usecase.NewClientUsecase, service.NewClientService, usecase.NewFXRateUsecase, service.NewFXRateService, usecase.NewInvoiceIssuerUsecase, service.NewInvoiceIssuerService, seed.NewSeeder)
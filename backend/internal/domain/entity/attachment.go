@@ -0,0 +1,36 @@
+package entity
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// The content types of the attachments: the documents of the suppliers, their scans and their e-invoices
+const (
+	AttachmentTypePDF  = "application/pdf"
+	AttachmentTypePNG  = "image/png"
+	AttachmentTypeJPEG = "image/jpeg"
+	AttachmentTypeXML  = "application/xml"
+)
+
+// AttachmentTypes are the content types accepted for the attachments
+var AttachmentTypes = []string{AttachmentTypePDF, AttachmentTypePNG, AttachmentTypeJPEG, AttachmentTypeXML}
+
+// Attachment represents a document attached to an invoice, e.g. the original PDF of the supplier, with its content
+type Attachment struct {
+	InvoiceID   int64
+	FileName    string
+	ContentType string
+	Content     []byte
+}
+
+// ContentHash returns the hex SHA-256 of the content, which identifies it in the blob store
+func ContentHash(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// BlobKey returns the key of the content of hash in the blob store, spread over 256 prefixes
+func BlobKey(hash string) string {
+	return "sha256/" + hash[:2] + "/" + hash
+}
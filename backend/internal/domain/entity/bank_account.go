@@ -8,4 +8,5 @@ type BankAccount struct {
 	Branch    string `json:"branch"`
 	AccountNo string `json:"account_no"`
 	Holder    string `json:"holder"`
+	Version   int64  `json:"version"`
 }
@@ -7,4 +7,7 @@ type Client struct {
 	Name      string `json:"name"`
 	Phone     string `json:"phone"`
 	Address   string `json:"address"`
+	Version   int64  `json:"version"`
+	// RegistrationNumber is the number of the client as qualified invoice issuer, T followed by 13 digits, if known
+	RegistrationNumber string `json:"registration_number"`
 }
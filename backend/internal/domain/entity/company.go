@@ -7,4 +7,8 @@ type Company struct {
 	OwnerName string `json:"owner_name"`
 	Phone     string `json:"phone"`
 	Address   string `json:"address"`
+	// BaseCurrency is the currency the reports of the company are in, JPY if empty
+	BaseCurrency string `json:"base_currency"`
+	// RegistrationNumber is the number of the company as qualified invoice issuer, T followed by 13 digits, if any
+	RegistrationNumber string `json:"registration_number"`
 }
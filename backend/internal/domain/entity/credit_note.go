@@ -0,0 +1,77 @@
+package entity
+
+import (
+	"slices"
+	"time"
+
+	"github.com/ericlagergren/decimal"
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+	"github.com/volatiletech/sqlboiler/v4/types"
+)
+
+// The statuses of the credit notes
+const (
+	// CreditNoteStatusIssued is the status of a credit note received from the client, reducing the outstanding
+	// balance of its invoice
+	CreditNoteStatusIssued = "issued"
+	// CreditNoteStatusApplied is the status of a credit note deducted from a payout or refunded
+	CreditNoteStatusApplied = "applied"
+	// CreditNoteStatusVoid is the status of a cancelled credit note, which doesn't reduce the balance anymore
+	CreditNoteStatusVoid = "void"
+)
+
+// CreditNoteStatuses are the valid statuses of the credit notes
+var CreditNoteStatuses = []string{CreditNoteStatusIssued, CreditNoteStatusApplied, CreditNoteStatusVoid}
+
+// creditNoteTransitions are the statuses a credit note can move to from each status, applied and void being final
+var creditNoteTransitions = map[string][]string{
+	CreditNoteStatusIssued: {CreditNoteStatusApplied, CreditNoteStatusVoid},
+}
+
+// CanTransitionCreditNote reports whether a credit note can move from a status to another
+func CanTransitionCreditNote(from, to string) bool {
+	return slices.Contains(creditNoteTransitions[from], to)
+}
+
+// CreditNote represents a credit note, crediting part of the payment amount of an invoice.
+// Its amounts are in the currency of the invoice
+type CreditNote struct {
+	ID        int64     `json:"id"`
+	InvoiceID int64     `json:"invoice_id"`
+	IssueDate time.Time `json:"issue_date"`
+	Reason    string    `json:"reason"`
+	// PaymentAmount is the amount credited, the fee and its tax are reversed accordingly (calculated)
+	PaymentAmount float64 `json:"payment_amount"`
+	FeeAmount     float64 `json:"-"`
+	TaxAmount     float64 `json:"-"`
+	TotalAmount   float64 `json:"-"`
+	Status        string  `json:"-"`
+	Version       int64   `json:"-"`
+}
+
+// CreditNoteStatus is the body changing the status of a credit note
+type CreditNoteStatus struct {
+	Status string `json:"status"`
+}
+
+// Credits returns the credit notes of an invoice which reduce its balance, the ones which are not void
+func Credits(creditNotes models.CreditNoteSlice) models.CreditNoteSlice {
+	var credits models.CreditNoteSlice
+	for _, creditNote := range creditNotes {
+		if creditNote.Status != CreditNoteStatusVoid {
+			credits = append(credits, creditNote)
+		}
+	}
+	return credits
+}
+
+// OutstandingAmount returns the total amount of an invoice minus the total amount of its credits and its payments,
+// the credit notes and payments loaded with it
+func OutstandingAmount(invoice *models.Invoice) types.Decimal {
+	outstanding := new(decimal.Big).Copy(invoice.TotalAmount.Big)
+	for _, credit := range Credits(invoice.R.GetCreditNotes()) {
+		outstanding.Sub(outstanding, credit.TotalAmount.Big)
+	}
+	outstanding.Sub(outstanding, PaidAmount(invoice).Big)
+	return types.NewDecimal(outstanding)
+}
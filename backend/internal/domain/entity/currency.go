@@ -0,0 +1,61 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/volatiletech/sqlboiler/v4/types"
+)
+
+// The currencies of the invoices, ISO 4217 codes
+const (
+	CurrencyJPY = "JPY"
+	CurrencyUSD = "USD"
+	CurrencyEUR = "EUR"
+	CurrencyGBP = "GBP"
+	CurrencyCNY = "CNY"
+)
+
+// DefaultCurrency is the base currency of the companies which didn't configure one
+const DefaultCurrency = CurrencyJPY
+
+// CurrencyDigits are the valid currencies, and the number of digits of their minor unit: amounts in yen
+// have no decimals, amounts in dollars have cents
+var CurrencyDigits = map[string]int{
+	CurrencyJPY: 0,
+	CurrencyUSD: 2,
+	CurrencyEUR: 2,
+	CurrencyGBP: 2,
+	CurrencyCNY: 2,
+}
+
+// FXRate is the exchange rate of a currency on a day: 1 Currency = Rate BaseCurrency
+type FXRate struct {
+	Date         time.Time
+	Currency     string
+	BaseCurrency string
+	Rate         types.Decimal
+}
+
+// OriginalAmount is the number and the total amount of invoices in a currency, before conversion to the base currency
+type OriginalAmount struct {
+	Currency string        `json:"currency"`
+	Count    int64         `json:"count"`
+	Amount   types.Decimal `json:"amount"`
+}
+
+// addOriginal adds invoices to the amount of their currency, keeping the amounts ordered by currency.
+// The amounts are stored to the cent, which some databases don't keep in their sums
+func addOriginal(originals []*OriginalAmount, currency string, count int64, amount types.Decimal) []*OriginalAmount {
+	i := 0
+	for i < len(originals) && originals[i].Currency < currency {
+		i++
+	}
+	if i == len(originals) || originals[i].Currency != currency {
+		original := &OriginalAmount{Currency: currency, Amount: NewAgingAmount().Amount}
+		originals = append(originals[:i], append([]*OriginalAmount{original}, originals[i:]...)...)
+	}
+	originals[i].Count += count
+	addDecimal(&originals[i].Amount, amount)
+	originals[i].Amount.Quantize(2)
+	return originals
+}
@@ -1,6 +1,22 @@
 package entity
 
-import "time"
+import (
+	"time"
+
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+	"github.com/volatiletech/sqlboiler/v4/types"
+)
+
+// The consumption tax categories of the invoice items
+const (
+	// TaxCategoryStandard is the standard rate, 10%
+	TaxCategoryStandard = "standard"
+	// TaxCategoryReduced is the reduced rate of food and newspapers, 8%
+	TaxCategoryReduced = "reduced"
+)
+
+// TaxCategories are the valid tax categories of the invoice items
+var TaxCategories = []string{TaxCategoryStandard, TaxCategoryReduced}
 
 // Invoice represents the invoice data
 type Invoice struct {
@@ -14,4 +30,90 @@ type Invoice struct {
 	TaxAmount     float64   `json:"tax_amount"`
 	TotalAmount   float64   `json:"total_amount"`
 	Status        string    `json:"status"`
+	Version       int64     `json:"version"`
+	// Currency is the currency of the amounts, the base currency of the company if empty
+	Currency string `json:"currency"`
+	// FXRate converts the amounts to the base currency of the company, 1 Currency = FXRate base currency.
+	// It is the rate of the issue date, snapshotted when the invoice is created
+	FXRate types.Decimal `json:"-"`
+	// Items are the lines of the invoice. When there are some, the payment amount is the sum of their amounts
+	Items []*InvoiceItem `json:"items,omitempty"`
+	// TaxSubtotals are the consumption tax included in the items, by rate. They are calculated, not given
+	TaxSubtotals []*InvoiceTaxSubtotal `json:"-"`
+	// Installments split the total amount by due date, the last one due at the due date of the invoice.
+	// Without installments the total amount is due at once
+	Installments []*InvoiceInstallment `json:"installments,omitempty"`
+}
+
+// InvoiceItem is a line of an invoice
+type InvoiceItem struct {
+	Description string  `json:"description"`
+	Quantity    float64 `json:"quantity"`
+	// UnitPrice and Amount include the consumption tax
+	UnitPrice   float64 `json:"unit_price"`
+	TaxCategory string  `json:"tax_category"`
+	// Amount is quantity * unit price, calculated
+	Amount float64 `json:"amount"`
+}
+
+// InvoiceInstallment is a part of the total amount of an invoice, due at its own date
+type InvoiceInstallment struct {
+	DueDate time.Time `json:"due_date"`
+	Amount  float64   `json:"amount"`
+}
+
+// InvoiceTaxSubtotal is the total of the items of an invoice at a tax rate, and the tax it includes,
+// rounded once for the invoice
+type InvoiceTaxSubtotal struct {
+	TaxCategory string
+	Rate        int
+	Amount      float64
+	TaxAmount   float64
+}
+
+// InvoiceDetails is an invoice as stored with its items, its tax subtotals, its installments, its credit notes
+// and its payments, the body of the invoice responses
+type InvoiceDetails struct {
+	models.Invoice
+	Items        models.InvoiceItemSlice        `json:"items"`
+	TaxSubtotals models.InvoiceTaxSubtotalSlice `json:"tax_subtotals"`
+	Installments models.InvoiceInstallmentSlice `json:"installments"`
+	CreditNotes  models.CreditNoteSlice         `json:"credit_notes"`
+	Payments     models.InvoicePaymentSlice     `json:"payments"`
+	// PaidAmount is the total of the payments
+	PaidAmount types.Decimal `json:"paid_amount"`
+	// OutstandingAmount is the total amount minus the credit notes which are not void and the payments
+	OutstandingAmount types.Decimal `json:"outstanding_amount"`
+}
+
+// NewInvoiceDetails returns a stored invoice with the details loaded with it, if any
+func NewInvoiceDetails(invoice *models.Invoice) *InvoiceDetails {
+	details := &InvoiceDetails{
+		Invoice:      *invoice,
+		Items:        invoice.R.GetInvoiceItems(),
+		TaxSubtotals: invoice.R.GetInvoiceTaxSubtotals(),
+		Installments: invoice.R.GetInvoiceInstallments(),
+		CreditNotes:  invoice.R.GetCreditNotes(),
+		Payments:     invoice.R.GetInvoicePayments(),
+		PaidAmount:   PaidAmount(invoice),
+	}
+	if details.Items == nil {
+		details.Items = models.InvoiceItemSlice{}
+	}
+	if details.TaxSubtotals == nil {
+		details.TaxSubtotals = models.InvoiceTaxSubtotalSlice{}
+	}
+	if details.Installments == nil {
+		details.Installments = models.InvoiceInstallmentSlice{}
+	}
+	if details.CreditNotes == nil {
+		details.CreditNotes = models.CreditNoteSlice{}
+	}
+	if details.Payments == nil {
+		details.Payments = models.InvoicePaymentSlice{}
+	}
+	if invoice.TotalAmount.Big != nil {
+		details.OutstandingAmount = OutstandingAmount(invoice)
+	}
+	return details
 }
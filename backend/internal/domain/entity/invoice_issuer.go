@@ -0,0 +1,34 @@
+package entity
+
+import "time"
+
+// InvoiceIssuer is a qualified invoice issuer (適格請求書発行事業者) as published by the National Tax Agency
+type InvoiceIssuer struct {
+	RegistrationNumber string
+	Name               string
+	RegisteredOn       time.Time
+	// RevokedOn is the date the tax office revoked the registration (取消), zero if it didn't
+	RevokedOn time.Time
+	// ExpiredOn is the date the registration ceased, e.g. on request of the issuer (失効), zero if it didn't
+	ExpiredOn time.Time
+	// Deleted tells the issuer was removed from the published ones, e.g. registered by mistake
+	Deleted bool
+}
+
+// The registration statuses of the issuer of an invoice, its client, on the issue date
+const (
+	// IssuerRegistered is a registration number registered on the issue date
+	IssuerRegistered = "registered"
+	// IssuerNoRegistrationNumber is a client without registration number
+	IssuerNoRegistrationNumber = "no_registration_number"
+	// IssuerInvalidRegistrationNumber is a registration number whose check digit is wrong
+	IssuerInvalidRegistrationNumber = "invalid_registration_number"
+	// IssuerNotRegistered is a registration number the imported issuers don't have
+	IssuerNotRegistered = "not_registered"
+	// IssuerNotYetRegistered is a registration number registered after the issue date
+	IssuerNotYetRegistered = "not_yet_registered"
+	// IssuerRevoked is a registration number revoked on or before the issue date
+	IssuerRevoked = "revoked"
+	// IssuerExpired is a registration number which ceased on or before the issue date
+	IssuerExpired = "expired"
+)
@@ -29,6 +29,7 @@ type BankAccount struct {
 	Branch    string `boil:"branch" json:"branch" toml:"branch" yaml:"branch"`
 	AccountNo string `boil:"account_no" json:"account_no" toml:"account_no" yaml:"account_no"`
 	Holder    string `boil:"holder" json:"holder" toml:"holder" yaml:"holder"`
+	Version   int64  `boil:"version" json:"version" toml:"version" yaml:"version"`
 
 	R *bankAccountR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L bankAccountL  `boil:"-" json:"-" toml:"-" yaml:"-"`
@@ -41,6 +42,7 @@ var BankAccountColumns = struct {
 	Branch    string
 	AccountNo string
 	Holder    string
+	Version   string
 }{
 	ID:        "id",
 	ClientID:  "client_id",
@@ -48,6 +50,7 @@ var BankAccountColumns = struct {
 	Branch:    "branch",
 	AccountNo: "account_no",
 	Holder:    "holder",
+	Version:   "version",
 }
 
 var BankAccountTableColumns = struct {
@@ -57,6 +60,7 @@ var BankAccountTableColumns = struct {
 	Branch    string
 	AccountNo string
 	Holder    string
+	Version   string
 }{
 	ID:        "bank_accounts.id",
 	ClientID:  "bank_accounts.client_id",
@@ -64,6 +68,7 @@ var BankAccountTableColumns = struct {
 	Branch:    "bank_accounts.branch",
 	AccountNo: "bank_accounts.account_no",
 	Holder:    "bank_accounts.holder",
+	Version:   "bank_accounts.version",
 }
 
 // Generated where
@@ -123,6 +128,7 @@ var BankAccountWhere = struct {
 	Branch    whereHelperstring
 	AccountNo whereHelperstring
 	Holder    whereHelperstring
+	Version   whereHelperint64
 }{
 	ID:        whereHelperint64{field: "`bank_accounts`.`id`"},
 	ClientID:  whereHelperint64{field: "`bank_accounts`.`client_id`"},
@@ -130,6 +136,7 @@ var BankAccountWhere = struct {
 	Branch:    whereHelperstring{field: "`bank_accounts`.`branch`"},
 	AccountNo: whereHelperstring{field: "`bank_accounts`.`account_no`"},
 	Holder:    whereHelperstring{field: "`bank_accounts`.`holder`"},
+	Version:   whereHelperint64{field: "`bank_accounts`.`version`"},
 }
 
 // BankAccountRels is where relationship names are stored.
@@ -160,9 +167,9 @@ func (r *bankAccountR) GetClient() *Client {
 type bankAccountL struct{}
 
 var (
-	bankAccountAllColumns            = []string{"id", "client_id", "bank_name", "branch", "account_no", "holder"}
+	bankAccountAllColumns            = []string{"id", "client_id", "bank_name", "branch", "account_no", "holder", "version"}
 	bankAccountColumnsWithoutDefault = []string{"client_id", "bank_name", "branch", "account_no", "holder"}
-	bankAccountColumnsWithDefault    = []string{"id"}
+	bankAccountColumnsWithDefault    = []string{"id", "version"}
 	bankAccountPrimaryKeyColumns     = []string{"id"}
 	bankAccountGeneratedColumns      = []string{}
 )
@@ -4,15 +4,33 @@
 package models
 
 var TableNames = struct {
-	BankAccounts string
-	Clients      string
-	Companies    string
-	Invoices     string
-	Users        string
+	BankAccounts        string
+	Clients             string
+	Companies           string
+	CreditNotes         string
+	FXRates             string
+	InvoiceAttachments  string
+	InvoiceInstallments string
+	InvoiceIssuers      string
+	InvoiceItems        string
+	InvoicePayments     string
+	InvoiceTaxSubtotals string
+	Invoices            string
+	RecurringInvoices   string
+	Users               string
 }{
-	BankAccounts: "bank_accounts",
-	Clients:      "clients",
-	Companies:    "companies",
-	Invoices:     "invoices",
-	Users:        "users",
+	BankAccounts:        "bank_accounts",
+	Clients:             "clients",
+	Companies:           "companies",
+	CreditNotes:         "credit_notes",
+	FXRates:             "fx_rates",
+	InvoiceAttachments:  "invoice_attachments",
+	InvoiceInstallments: "invoice_installments",
+	InvoiceIssuers:      "invoice_issuers",
+	InvoiceItems:        "invoice_items",
+	InvoicePayments:     "invoice_payments",
+	InvoiceTaxSubtotals: "invoice_tax_subtotals",
+	Invoices:            "invoices",
+	RecurringInvoices:   "recurring_invoices",
+	Users:               "users",
 }
@@ -24,42 +24,52 @@ import (
 
 // Client is an object representing the database table.
 type Client struct {
-	ID        int64       `boil:"id" json:"id" toml:"id" yaml:"id"`
-	CompanyID int64       `boil:"company_id" json:"company_id" toml:"company_id" yaml:"company_id"`
-	Name      string      `boil:"name" json:"name" toml:"name" yaml:"name"`
-	Phone     null.String `boil:"phone" json:"phone,omitempty" toml:"phone" yaml:"phone,omitempty"`
-	Address   null.String `boil:"address" json:"address,omitempty" toml:"address" yaml:"address,omitempty"`
+	ID                 int64       `boil:"id" json:"id" toml:"id" yaml:"id"`
+	CompanyID          int64       `boil:"company_id" json:"company_id" toml:"company_id" yaml:"company_id"`
+	Name               string      `boil:"name" json:"name" toml:"name" yaml:"name"`
+	Phone              null.String `boil:"phone" json:"phone,omitempty" toml:"phone" yaml:"phone,omitempty"`
+	Address            null.String `boil:"address" json:"address,omitempty" toml:"address" yaml:"address,omitempty"`
+	Version            int64       `boil:"version" json:"version" toml:"version" yaml:"version"`
+	RegistrationNumber null.String `boil:"registration_number" json:"registration_number,omitempty" toml:"registration_number" yaml:"registration_number,omitempty"`
 
 	R *clientR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L clientL  `boil:"-" json:"-" toml:"-" yaml:"-"`
 }
 
 var ClientColumns = struct {
-	ID        string
-	CompanyID string
-	Name      string
-	Phone     string
-	Address   string
+	ID                 string
+	CompanyID          string
+	Name               string
+	Phone              string
+	Address            string
+	Version            string
+	RegistrationNumber string
 }{
-	ID:        "id",
-	CompanyID: "company_id",
-	Name:      "name",
-	Phone:     "phone",
-	Address:   "address",
+	ID:                 "id",
+	CompanyID:          "company_id",
+	Name:               "name",
+	Phone:              "phone",
+	Address:            "address",
+	Version:            "version",
+	RegistrationNumber: "registration_number",
 }
 
 var ClientTableColumns = struct {
-	ID        string
-	CompanyID string
-	Name      string
-	Phone     string
-	Address   string
+	ID                 string
+	CompanyID          string
+	Name               string
+	Phone              string
+	Address            string
+	Version            string
+	RegistrationNumber string
 }{
-	ID:        "clients.id",
-	CompanyID: "clients.company_id",
-	Name:      "clients.name",
-	Phone:     "clients.phone",
-	Address:   "clients.address",
+	ID:                 "clients.id",
+	CompanyID:          "clients.company_id",
+	Name:               "clients.name",
+	Phone:              "clients.phone",
+	Address:            "clients.address",
+	Version:            "clients.version",
+	RegistrationNumber: "clients.registration_number",
 }
 
 // Generated where
@@ -109,35 +119,42 @@ func (w whereHelpernull_String) IsNull() qm.QueryMod    { return qmhelper.WhereI
 func (w whereHelpernull_String) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
 
 var ClientWhere = struct {
-	ID        whereHelperint64
-	CompanyID whereHelperint64
-	Name      whereHelperstring
-	Phone     whereHelpernull_String
-	Address   whereHelpernull_String
+	ID                 whereHelperint64
+	CompanyID          whereHelperint64
+	Name               whereHelperstring
+	Phone              whereHelpernull_String
+	Address            whereHelpernull_String
+	Version            whereHelperint64
+	RegistrationNumber whereHelpernull_String
 }{
-	ID:        whereHelperint64{field: "`clients`.`id`"},
-	CompanyID: whereHelperint64{field: "`clients`.`company_id`"},
-	Name:      whereHelperstring{field: "`clients`.`name`"},
-	Phone:     whereHelpernull_String{field: "`clients`.`phone`"},
-	Address:   whereHelpernull_String{field: "`clients`.`address`"},
+	ID:                 whereHelperint64{field: "`clients`.`id`"},
+	CompanyID:          whereHelperint64{field: "`clients`.`company_id`"},
+	Name:               whereHelperstring{field: "`clients`.`name`"},
+	Phone:              whereHelpernull_String{field: "`clients`.`phone`"},
+	Address:            whereHelpernull_String{field: "`clients`.`address`"},
+	Version:            whereHelperint64{field: "`clients`.`version`"},
+	RegistrationNumber: whereHelpernull_String{field: "`clients`.`registration_number`"},
 }
 
 // ClientRels is where relationship names are stored.
 var ClientRels = struct {
-	Company      string
-	BankAccounts string
-	Invoices     string
+	Company           string
+	BankAccounts      string
+	Invoices          string
+	RecurringInvoices string
 }{
-	Company:      "Company",
-	BankAccounts: "BankAccounts",
-	Invoices:     "Invoices",
+	Company:           "Company",
+	BankAccounts:      "BankAccounts",
+	Invoices:          "Invoices",
+	RecurringInvoices: "RecurringInvoices",
 }
 
 // clientR is where relationships are stored.
 type clientR struct {
-	Company      *Company         `boil:"Company" json:"Company" toml:"Company" yaml:"Company"`
-	BankAccounts BankAccountSlice `boil:"BankAccounts" json:"BankAccounts" toml:"BankAccounts" yaml:"BankAccounts"`
-	Invoices     InvoiceSlice     `boil:"Invoices" json:"Invoices" toml:"Invoices" yaml:"Invoices"`
+	Company           *Company              `boil:"Company" json:"Company" toml:"Company" yaml:"Company"`
+	BankAccounts      BankAccountSlice      `boil:"BankAccounts" json:"BankAccounts" toml:"BankAccounts" yaml:"BankAccounts"`
+	Invoices          InvoiceSlice          `boil:"Invoices" json:"Invoices" toml:"Invoices" yaml:"Invoices"`
+	RecurringInvoices RecurringInvoiceSlice `boil:"RecurringInvoices" json:"RecurringInvoices" toml:"RecurringInvoices" yaml:"RecurringInvoices"`
 }
 
 // NewStruct creates a new relationship struct
@@ -166,13 +183,20 @@ func (r *clientR) GetInvoices() InvoiceSlice {
 	return r.Invoices
 }
 
+func (r *clientR) GetRecurringInvoices() RecurringInvoiceSlice {
+	if r == nil {
+		return nil
+	}
+	return r.RecurringInvoices
+}
+
 // clientL is where Load methods for each relationship are stored.
 type clientL struct{}
 
 var (
-	clientAllColumns            = []string{"id", "company_id", "name", "phone", "address"}
-	clientColumnsWithoutDefault = []string{"company_id", "name", "phone", "address"}
-	clientColumnsWithDefault    = []string{"id"}
+	clientAllColumns            = []string{"id", "company_id", "name", "phone", "address", "version", "registration_number"}
+	clientColumnsWithoutDefault = []string{"company_id", "name", "phone", "address", "registration_number"}
+	clientColumnsWithDefault    = []string{"id", "version"}
 	clientPrimaryKeyColumns     = []string{"id"}
 	clientGeneratedColumns      = []string{}
 )
@@ -521,6 +545,20 @@ func (o *Client) Invoices(mods ...qm.QueryMod) invoiceQuery {
 	return Invoices(queryMods...)
 }
 
+// RecurringInvoices retrieves all the recurring_invoice's RecurringInvoices with an executor.
+func (o *Client) RecurringInvoices(mods ...qm.QueryMod) recurringInvoiceQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("`recurring_invoices`.`client_id`=?", o.ID),
+	)
+
+	return RecurringInvoices(queryMods...)
+}
+
 // LoadCompany allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for an N-1 relationship.
 func (clientL) LoadCompany(ctx context.Context, e boil.ContextExecutor, singular bool, maybeClient interface{}, mods queries.Applicator) error {
@@ -867,6 +905,119 @@ func (clientL) LoadInvoices(ctx context.Context, e boil.ContextExecutor, singula
 	return nil
 }
 
+// LoadRecurringInvoices allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (clientL) LoadRecurringInvoices(ctx context.Context, e boil.ContextExecutor, singular bool, maybeClient interface{}, mods queries.Applicator) error {
+	var slice []*Client
+	var object *Client
+
+	if singular {
+		var ok bool
+		object, ok = maybeClient.(*Client)
+		if !ok {
+			object = new(Client)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeClient)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeClient))
+			}
+		}
+	} else {
+		s, ok := maybeClient.(*[]*Client)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeClient)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeClient))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &clientR{}
+		}
+		args[object.ID] = struct{}{}
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &clientR{}
+			}
+			args[obj.ID] = struct{}{}
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`recurring_invoices`),
+		qm.WhereIn(`recurring_invoices.client_id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load recurring_invoices")
+	}
+
+	var resultSlice []*RecurringInvoice
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice recurring_invoices")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on recurring_invoices")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for recurring_invoices")
+	}
+
+	if len(recurringInvoiceAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.RecurringInvoices = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &recurringInvoiceR{}
+			}
+			foreign.R.Client = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.ClientID {
+				local.R.RecurringInvoices = append(local.R.RecurringInvoices, foreign)
+				if foreign.R == nil {
+					foreign.R = &recurringInvoiceR{}
+				}
+				foreign.R.Client = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
 // SetCompany of the client to the related item.
 // Sets o.R.Company to related.
 // Adds o to related.R.Clients.
@@ -1020,6 +1171,59 @@ func (o *Client) AddInvoices(ctx context.Context, exec boil.ContextExecutor, ins
 	return nil
 }
 
+// AddRecurringInvoices adds the given related objects to the existing relationships
+// of the client, optionally inserting them as new records.
+// Appends related to o.R.RecurringInvoices.
+// Sets related.R.Client appropriately.
+func (o *Client) AddRecurringInvoices(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*RecurringInvoice) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.ClientID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE `recurring_invoices` SET %s WHERE %s",
+				strmangle.SetParamNames("`", "`", 0, []string{"client_id"}),
+				strmangle.WhereClause("`", "`", 0, recurringInvoicePrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.ClientID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &clientR{
+			RecurringInvoices: related,
+		}
+	} else {
+		o.R.RecurringInvoices = append(o.R.RecurringInvoices, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &recurringInvoiceR{
+				Client: o,
+			}
+		} else {
+			rel.R.Client = o
+		}
+	}
+	return nil
+}
+
 // Clients retrieves all the records using an executor.
 func Clients(mods ...qm.QueryMod) clientQuery {
 	mods = append(mods, qm.From("`clients`"))
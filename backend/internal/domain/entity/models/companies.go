@@ -19,81 +19,139 @@ import (
 	"github.com/volatiletech/sqlboiler/v4/queries"
 	"github.com/volatiletech/sqlboiler/v4/queries/qm"
 	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/sqlboiler/v4/types"
 	"github.com/volatiletech/strmangle"
 )
 
 // Company is an object representing the database table.
 type Company struct {
-	ID        int64       `boil:"id" json:"id" toml:"id" yaml:"id"`
-	Name      string      `boil:"name" json:"name" toml:"name" yaml:"name"`
-	OwnerName string      `boil:"owner_name" json:"owner_name" toml:"owner_name" yaml:"owner_name"`
-	Phone     null.String `boil:"phone" json:"phone,omitempty" toml:"phone" yaml:"phone,omitempty"`
-	Address   null.String `boil:"address" json:"address,omitempty" toml:"address" yaml:"address,omitempty"`
+	ID                 int64             `boil:"id" json:"id" toml:"id" yaml:"id"`
+	Name               string            `boil:"name" json:"name" toml:"name" yaml:"name"`
+	OwnerName          string            `boil:"owner_name" json:"owner_name" toml:"owner_name" yaml:"owner_name"`
+	Phone              null.String       `boil:"phone" json:"phone,omitempty" toml:"phone" yaml:"phone,omitempty"`
+	Address            null.String       `boil:"address" json:"address,omitempty" toml:"address" yaml:"address,omitempty"`
+	AvailableBalance   types.NullDecimal `boil:"available_balance" json:"available_balance,omitempty" toml:"available_balance" yaml:"available_balance,omitempty"`
+	TaxRounding        string            `boil:"tax_rounding" json:"tax_rounding" toml:"tax_rounding" yaml:"tax_rounding"`
+	BaseCurrency       string            `boil:"base_currency" json:"base_currency" toml:"base_currency" yaml:"base_currency"`
+	RegistrationNumber null.String       `boil:"registration_number" json:"registration_number,omitempty" toml:"registration_number" yaml:"registration_number,omitempty"`
 
 	R *companyR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L companyL  `boil:"-" json:"-" toml:"-" yaml:"-"`
 }
 
 var CompanyColumns = struct {
-	ID        string
-	Name      string
-	OwnerName string
-	Phone     string
-	Address   string
+	ID                 string
+	Name               string
+	OwnerName          string
+	Phone              string
+	Address            string
+	AvailableBalance   string
+	TaxRounding        string
+	BaseCurrency       string
+	RegistrationNumber string
 }{
-	ID:        "id",
-	Name:      "name",
-	OwnerName: "owner_name",
-	Phone:     "phone",
-	Address:   "address",
+	ID:                 "id",
+	Name:               "name",
+	OwnerName:          "owner_name",
+	Phone:              "phone",
+	Address:            "address",
+	AvailableBalance:   "available_balance",
+	TaxRounding:        "tax_rounding",
+	BaseCurrency:       "base_currency",
+	RegistrationNumber: "registration_number",
 }
 
 var CompanyTableColumns = struct {
-	ID        string
-	Name      string
-	OwnerName string
-	Phone     string
-	Address   string
+	ID                 string
+	Name               string
+	OwnerName          string
+	Phone              string
+	Address            string
+	AvailableBalance   string
+	TaxRounding        string
+	BaseCurrency       string
+	RegistrationNumber string
 }{
-	ID:        "companies.id",
-	Name:      "companies.name",
-	OwnerName: "companies.owner_name",
-	Phone:     "companies.phone",
-	Address:   "companies.address",
+	ID:                 "companies.id",
+	Name:               "companies.name",
+	OwnerName:          "companies.owner_name",
+	Phone:              "companies.phone",
+	Address:            "companies.address",
+	AvailableBalance:   "companies.available_balance",
+	TaxRounding:        "companies.tax_rounding",
+	BaseCurrency:       "companies.base_currency",
+	RegistrationNumber: "companies.registration_number",
 }
 
 // Generated where
 
+type whereHelpertypes_NullDecimal struct{ field string }
+
+func (w whereHelpertypes_NullDecimal) EQ(x types.NullDecimal) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, false, x)
+}
+func (w whereHelpertypes_NullDecimal) NEQ(x types.NullDecimal) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, true, x)
+}
+func (w whereHelpertypes_NullDecimal) LT(x types.NullDecimal) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelpertypes_NullDecimal) LTE(x types.NullDecimal) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelpertypes_NullDecimal) GT(x types.NullDecimal) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
+}
+func (w whereHelpertypes_NullDecimal) GTE(x types.NullDecimal) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+
+func (w whereHelpertypes_NullDecimal) IsNull() qm.QueryMod { return qmhelper.WhereIsNull(w.field) }
+func (w whereHelpertypes_NullDecimal) IsNotNull() qm.QueryMod {
+	return qmhelper.WhereIsNotNull(w.field)
+}
+
 var CompanyWhere = struct {
-	ID        whereHelperint64
-	Name      whereHelperstring
-	OwnerName whereHelperstring
-	Phone     whereHelpernull_String
-	Address   whereHelpernull_String
+	ID                 whereHelperint64
+	Name               whereHelperstring
+	OwnerName          whereHelperstring
+	Phone              whereHelpernull_String
+	Address            whereHelpernull_String
+	AvailableBalance   whereHelpertypes_NullDecimal
+	TaxRounding        whereHelperstring
+	BaseCurrency       whereHelperstring
+	RegistrationNumber whereHelpernull_String
 }{
-	ID:        whereHelperint64{field: "`companies`.`id`"},
-	Name:      whereHelperstring{field: "`companies`.`name`"},
-	OwnerName: whereHelperstring{field: "`companies`.`owner_name`"},
-	Phone:     whereHelpernull_String{field: "`companies`.`phone`"},
-	Address:   whereHelpernull_String{field: "`companies`.`address`"},
+	ID:                 whereHelperint64{field: "`companies`.`id`"},
+	Name:               whereHelperstring{field: "`companies`.`name`"},
+	OwnerName:          whereHelperstring{field: "`companies`.`owner_name`"},
+	Phone:              whereHelpernull_String{field: "`companies`.`phone`"},
+	Address:            whereHelpernull_String{field: "`companies`.`address`"},
+	AvailableBalance:   whereHelpertypes_NullDecimal{field: "`companies`.`available_balance`"},
+	TaxRounding:        whereHelperstring{field: "`companies`.`tax_rounding`"},
+	BaseCurrency:       whereHelperstring{field: "`companies`.`base_currency`"},
+	RegistrationNumber: whereHelpernull_String{field: "`companies`.`registration_number`"},
 }
 
 // CompanyRels is where relationship names are stored.
 var CompanyRels = struct {
-	Clients  string
-	Invoices string
-	Users    string
+	Clients           string
+	Invoices          string
+	RecurringInvoices string
+	Users             string
 }{
-	Clients:  "Clients",
-	Invoices: "Invoices",
-	Users:    "Users",
+	Clients:           "Clients",
+	Invoices:          "Invoices",
+	RecurringInvoices: "RecurringInvoices",
+	Users:             "Users",
 }
 
 // companyR is where relationships are stored.
 type companyR struct {
-	Clients  ClientSlice  `boil:"Clients" json:"Clients" toml:"Clients" yaml:"Clients"`
-	Invoices InvoiceSlice `boil:"Invoices" json:"Invoices" toml:"Invoices" yaml:"Invoices"`
-	Users    UserSlice    `boil:"Users" json:"Users" toml:"Users" yaml:"Users"`
+	Clients           ClientSlice           `boil:"Clients" json:"Clients" toml:"Clients" yaml:"Clients"`
+	Invoices          InvoiceSlice          `boil:"Invoices" json:"Invoices" toml:"Invoices" yaml:"Invoices"`
+	RecurringInvoices RecurringInvoiceSlice `boil:"RecurringInvoices" json:"RecurringInvoices" toml:"RecurringInvoices" yaml:"RecurringInvoices"`
+	Users             UserSlice             `boil:"Users" json:"Users" toml:"Users" yaml:"Users"`
 }
 
 // NewStruct creates a new relationship struct
@@ -115,6 +173,13 @@ func (r *companyR) GetInvoices() InvoiceSlice {
 	return r.Invoices
 }
 
+func (r *companyR) GetRecurringInvoices() RecurringInvoiceSlice {
+	if r == nil {
+		return nil
+	}
+	return r.RecurringInvoices
+}
+
 func (r *companyR) GetUsers() UserSlice {
 	if r == nil {
 		return nil
@@ -126,9 +191,9 @@ func (r *companyR) GetUsers() UserSlice {
 type companyL struct{}
 
 var (
-	companyAllColumns            = []string{"id", "name", "owner_name", "phone", "address"}
-	companyColumnsWithoutDefault = []string{"name", "phone", "address"}
-	companyColumnsWithDefault    = []string{"id", "owner_name"}
+	companyAllColumns            = []string{"id", "name", "owner_name", "phone", "address", "available_balance", "tax_rounding", "base_currency", "registration_number"}
+	companyColumnsWithoutDefault = []string{"name", "phone", "address", "available_balance", "registration_number"}
+	companyColumnsWithDefault    = []string{"id", "owner_name", "tax_rounding", "base_currency"}
 	companyPrimaryKeyColumns     = []string{"id"}
 	companyGeneratedColumns      = []string{}
 )
@@ -466,6 +531,20 @@ func (o *Company) Invoices(mods ...qm.QueryMod) invoiceQuery {
 	return Invoices(queryMods...)
 }
 
+// RecurringInvoices retrieves all the recurring_invoice's RecurringInvoices with an executor.
+func (o *Company) RecurringInvoices(mods ...qm.QueryMod) recurringInvoiceQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("`recurring_invoices`.`company_id`=?", o.ID),
+	)
+
+	return RecurringInvoices(queryMods...)
+}
+
 // Users retrieves all the user's Users with an executor.
 func (o *Company) Users(mods ...qm.QueryMod) userQuery {
 	var queryMods []qm.QueryMod
@@ -706,6 +785,119 @@ func (companyL) LoadInvoices(ctx context.Context, e boil.ContextExecutor, singul
 	return nil
 }
 
+// LoadRecurringInvoices allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (companyL) LoadRecurringInvoices(ctx context.Context, e boil.ContextExecutor, singular bool, maybeCompany interface{}, mods queries.Applicator) error {
+	var slice []*Company
+	var object *Company
+
+	if singular {
+		var ok bool
+		object, ok = maybeCompany.(*Company)
+		if !ok {
+			object = new(Company)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeCompany)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeCompany))
+			}
+		}
+	} else {
+		s, ok := maybeCompany.(*[]*Company)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeCompany)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeCompany))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &companyR{}
+		}
+		args[object.ID] = struct{}{}
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &companyR{}
+			}
+			args[obj.ID] = struct{}{}
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`recurring_invoices`),
+		qm.WhereIn(`recurring_invoices.company_id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load recurring_invoices")
+	}
+
+	var resultSlice []*RecurringInvoice
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice recurring_invoices")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on recurring_invoices")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for recurring_invoices")
+	}
+
+	if len(recurringInvoiceAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.RecurringInvoices = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &recurringInvoiceR{}
+			}
+			foreign.R.Company = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.CompanyID {
+				local.R.RecurringInvoices = append(local.R.RecurringInvoices, foreign)
+				if foreign.R == nil {
+					foreign.R = &recurringInvoiceR{}
+				}
+				foreign.R.Company = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
 // LoadUsers allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for a 1-M or N-M relationship.
 func (companyL) LoadUsers(ctx context.Context, e boil.ContextExecutor, singular bool, maybeCompany interface{}, mods queries.Applicator) error {
@@ -925,6 +1117,59 @@ func (o *Company) AddInvoices(ctx context.Context, exec boil.ContextExecutor, in
 	return nil
 }
 
+// AddRecurringInvoices adds the given related objects to the existing relationships
+// of the company, optionally inserting them as new records.
+// Appends related to o.R.RecurringInvoices.
+// Sets related.R.Company appropriately.
+func (o *Company) AddRecurringInvoices(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*RecurringInvoice) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.CompanyID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE `recurring_invoices` SET %s WHERE %s",
+				strmangle.SetParamNames("`", "`", 0, []string{"company_id"}),
+				strmangle.WhereClause("`", "`", 0, recurringInvoicePrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.CompanyID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &companyR{
+			RecurringInvoices: related,
+		}
+	} else {
+		o.R.RecurringInvoices = append(o.R.RecurringInvoices, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &recurringInvoiceR{
+				Company: o,
+			}
+		} else {
+			rel.R.Company = o
+		}
+	}
+	return nil
+}
+
 // AddUsers adds the given related objects to the existing relationships
 // of the company, optionally inserting them as new records.
 // Appends related to o.R.Users.
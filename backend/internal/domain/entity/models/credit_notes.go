@@ -0,0 +1,1271 @@
+// Code generated by SQLBoiler 4.16.2 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/sqlboiler/v4/types"
+	"github.com/volatiletech/strmangle"
+)
+
+// CreditNote is an object representing the database table.
+type CreditNote struct {
+	ID            int64         `boil:"id" json:"id" toml:"id" yaml:"id"`
+	InvoiceID     int64         `boil:"invoice_id" json:"invoice_id" toml:"invoice_id" yaml:"invoice_id"`
+	IssueDate     time.Time     `boil:"issue_date" json:"issue_date" toml:"issue_date" yaml:"issue_date"`
+	Reason        string        `boil:"reason" json:"reason" toml:"reason" yaml:"reason"`
+	PaymentAmount types.Decimal `boil:"payment_amount" json:"payment_amount" toml:"payment_amount" yaml:"payment_amount"`
+	FeeAmount     types.Decimal `boil:"fee_amount" json:"fee_amount" toml:"fee_amount" yaml:"fee_amount"`
+	TaxAmount     types.Decimal `boil:"tax_amount" json:"tax_amount" toml:"tax_amount" yaml:"tax_amount"`
+	TotalAmount   types.Decimal `boil:"total_amount" json:"total_amount" toml:"total_amount" yaml:"total_amount"`
+	Status        string        `boil:"status" json:"status" toml:"status" yaml:"status"`
+	Version       int64         `boil:"version" json:"version" toml:"version" yaml:"version"`
+
+	R *creditNoteR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L creditNoteL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var CreditNoteColumns = struct {
+	ID            string
+	InvoiceID     string
+	IssueDate     string
+	Reason        string
+	PaymentAmount string
+	FeeAmount     string
+	TaxAmount     string
+	TotalAmount   string
+	Status        string
+	Version       string
+}{
+	ID:            "id",
+	InvoiceID:     "invoice_id",
+	IssueDate:     "issue_date",
+	Reason:        "reason",
+	PaymentAmount: "payment_amount",
+	FeeAmount:     "fee_amount",
+	TaxAmount:     "tax_amount",
+	TotalAmount:   "total_amount",
+	Status:        "status",
+	Version:       "version",
+}
+
+var CreditNoteTableColumns = struct {
+	ID            string
+	InvoiceID     string
+	IssueDate     string
+	Reason        string
+	PaymentAmount string
+	FeeAmount     string
+	TaxAmount     string
+	TotalAmount   string
+	Status        string
+	Version       string
+}{
+	ID:            "credit_notes.id",
+	InvoiceID:     "credit_notes.invoice_id",
+	IssueDate:     "credit_notes.issue_date",
+	Reason:        "credit_notes.reason",
+	PaymentAmount: "credit_notes.payment_amount",
+	FeeAmount:     "credit_notes.fee_amount",
+	TaxAmount:     "credit_notes.tax_amount",
+	TotalAmount:   "credit_notes.total_amount",
+	Status:        "credit_notes.status",
+	Version:       "credit_notes.version",
+}
+
+// Generated where
+
+type whereHelpertime_Time struct{ field string }
+
+func (w whereHelpertime_Time) EQ(x time.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.EQ, x)
+}
+func (w whereHelpertime_Time) NEQ(x time.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.NEQ, x)
+}
+func (w whereHelpertime_Time) LT(x time.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelpertime_Time) LTE(x time.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelpertime_Time) GT(x time.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
+}
+func (w whereHelpertime_Time) GTE(x time.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+
+type whereHelpertypes_Decimal struct{ field string }
+
+func (w whereHelpertypes_Decimal) EQ(x types.Decimal) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.EQ, x)
+}
+func (w whereHelpertypes_Decimal) NEQ(x types.Decimal) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.NEQ, x)
+}
+func (w whereHelpertypes_Decimal) LT(x types.Decimal) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelpertypes_Decimal) LTE(x types.Decimal) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelpertypes_Decimal) GT(x types.Decimal) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
+}
+func (w whereHelpertypes_Decimal) GTE(x types.Decimal) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+
+var CreditNoteWhere = struct {
+	ID            whereHelperint64
+	InvoiceID     whereHelperint64
+	IssueDate     whereHelpertime_Time
+	Reason        whereHelperstring
+	PaymentAmount whereHelpertypes_Decimal
+	FeeAmount     whereHelpertypes_Decimal
+	TaxAmount     whereHelpertypes_Decimal
+	TotalAmount   whereHelpertypes_Decimal
+	Status        whereHelperstring
+	Version       whereHelperint64
+}{
+	ID:            whereHelperint64{field: "`credit_notes`.`id`"},
+	InvoiceID:     whereHelperint64{field: "`credit_notes`.`invoice_id`"},
+	IssueDate:     whereHelpertime_Time{field: "`credit_notes`.`issue_date`"},
+	Reason:        whereHelperstring{field: "`credit_notes`.`reason`"},
+	PaymentAmount: whereHelpertypes_Decimal{field: "`credit_notes`.`payment_amount`"},
+	FeeAmount:     whereHelpertypes_Decimal{field: "`credit_notes`.`fee_amount`"},
+	TaxAmount:     whereHelpertypes_Decimal{field: "`credit_notes`.`tax_amount`"},
+	TotalAmount:   whereHelpertypes_Decimal{field: "`credit_notes`.`total_amount`"},
+	Status:        whereHelperstring{field: "`credit_notes`.`status`"},
+	Version:       whereHelperint64{field: "`credit_notes`.`version`"},
+}
+
+// CreditNoteRels is where relationship names are stored.
+var CreditNoteRels = struct {
+	Invoice string
+}{
+	Invoice: "Invoice",
+}
+
+// creditNoteR is where relationships are stored.
+type creditNoteR struct {
+	Invoice *Invoice `boil:"Invoice" json:"Invoice" toml:"Invoice" yaml:"Invoice"`
+}
+
+// NewStruct creates a new relationship struct
+func (*creditNoteR) NewStruct() *creditNoteR {
+	return &creditNoteR{}
+}
+
+func (r *creditNoteR) GetInvoice() *Invoice {
+	if r == nil {
+		return nil
+	}
+	return r.Invoice
+}
+
+// creditNoteL is where Load methods for each relationship are stored.
+type creditNoteL struct{}
+
+var (
+	creditNoteAllColumns            = []string{"id", "invoice_id", "issue_date", "reason", "payment_amount", "fee_amount", "tax_amount", "total_amount", "status", "version"}
+	creditNoteColumnsWithoutDefault = []string{"invoice_id", "issue_date", "reason", "payment_amount", "fee_amount", "tax_amount", "total_amount"}
+	creditNoteColumnsWithDefault    = []string{"id", "status", "version"}
+	creditNotePrimaryKeyColumns     = []string{"id"}
+	creditNoteGeneratedColumns      = []string{}
+)
+
+type (
+	// CreditNoteSlice is an alias for a slice of pointers to CreditNote.
+	// This should almost always be used instead of []CreditNote.
+	CreditNoteSlice []*CreditNote
+	// CreditNoteHook is the signature for custom CreditNote hook methods
+	CreditNoteHook func(context.Context, boil.ContextExecutor, *CreditNote) error
+
+	creditNoteQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	creditNoteType                 = reflect.TypeOf(&CreditNote{})
+	creditNoteMapping              = queries.MakeStructMapping(creditNoteType)
+	creditNotePrimaryKeyMapping, _ = queries.BindMapping(creditNoteType, creditNoteMapping, creditNotePrimaryKeyColumns)
+	creditNoteInsertCacheMut       sync.RWMutex
+	creditNoteInsertCache          = make(map[string]insertCache)
+	creditNoteUpdateCacheMut       sync.RWMutex
+	creditNoteUpdateCache          = make(map[string]updateCache)
+	creditNoteUpsertCacheMut       sync.RWMutex
+	creditNoteUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var creditNoteAfterSelectMu sync.Mutex
+var creditNoteAfterSelectHooks []CreditNoteHook
+
+var creditNoteBeforeInsertMu sync.Mutex
+var creditNoteBeforeInsertHooks []CreditNoteHook
+var creditNoteAfterInsertMu sync.Mutex
+var creditNoteAfterInsertHooks []CreditNoteHook
+
+var creditNoteBeforeUpdateMu sync.Mutex
+var creditNoteBeforeUpdateHooks []CreditNoteHook
+var creditNoteAfterUpdateMu sync.Mutex
+var creditNoteAfterUpdateHooks []CreditNoteHook
+
+var creditNoteBeforeDeleteMu sync.Mutex
+var creditNoteBeforeDeleteHooks []CreditNoteHook
+var creditNoteAfterDeleteMu sync.Mutex
+var creditNoteAfterDeleteHooks []CreditNoteHook
+
+var creditNoteBeforeUpsertMu sync.Mutex
+var creditNoteBeforeUpsertHooks []CreditNoteHook
+var creditNoteAfterUpsertMu sync.Mutex
+var creditNoteAfterUpsertHooks []CreditNoteHook
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *CreditNote) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range creditNoteAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *CreditNote) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range creditNoteBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *CreditNote) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range creditNoteAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *CreditNote) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range creditNoteBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *CreditNote) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range creditNoteAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *CreditNote) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range creditNoteBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *CreditNote) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range creditNoteAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *CreditNote) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range creditNoteBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *CreditNote) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range creditNoteAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddCreditNoteHook registers your hook function for all future operations.
+func AddCreditNoteHook(hookPoint boil.HookPoint, creditNoteHook CreditNoteHook) {
+	switch hookPoint {
+	case boil.AfterSelectHook:
+		creditNoteAfterSelectMu.Lock()
+		creditNoteAfterSelectHooks = append(creditNoteAfterSelectHooks, creditNoteHook)
+		creditNoteAfterSelectMu.Unlock()
+	case boil.BeforeInsertHook:
+		creditNoteBeforeInsertMu.Lock()
+		creditNoteBeforeInsertHooks = append(creditNoteBeforeInsertHooks, creditNoteHook)
+		creditNoteBeforeInsertMu.Unlock()
+	case boil.AfterInsertHook:
+		creditNoteAfterInsertMu.Lock()
+		creditNoteAfterInsertHooks = append(creditNoteAfterInsertHooks, creditNoteHook)
+		creditNoteAfterInsertMu.Unlock()
+	case boil.BeforeUpdateHook:
+		creditNoteBeforeUpdateMu.Lock()
+		creditNoteBeforeUpdateHooks = append(creditNoteBeforeUpdateHooks, creditNoteHook)
+		creditNoteBeforeUpdateMu.Unlock()
+	case boil.AfterUpdateHook:
+		creditNoteAfterUpdateMu.Lock()
+		creditNoteAfterUpdateHooks = append(creditNoteAfterUpdateHooks, creditNoteHook)
+		creditNoteAfterUpdateMu.Unlock()
+	case boil.BeforeDeleteHook:
+		creditNoteBeforeDeleteMu.Lock()
+		creditNoteBeforeDeleteHooks = append(creditNoteBeforeDeleteHooks, creditNoteHook)
+		creditNoteBeforeDeleteMu.Unlock()
+	case boil.AfterDeleteHook:
+		creditNoteAfterDeleteMu.Lock()
+		creditNoteAfterDeleteHooks = append(creditNoteAfterDeleteHooks, creditNoteHook)
+		creditNoteAfterDeleteMu.Unlock()
+	case boil.BeforeUpsertHook:
+		creditNoteBeforeUpsertMu.Lock()
+		creditNoteBeforeUpsertHooks = append(creditNoteBeforeUpsertHooks, creditNoteHook)
+		creditNoteBeforeUpsertMu.Unlock()
+	case boil.AfterUpsertHook:
+		creditNoteAfterUpsertMu.Lock()
+		creditNoteAfterUpsertHooks = append(creditNoteAfterUpsertHooks, creditNoteHook)
+		creditNoteAfterUpsertMu.Unlock()
+	}
+}
+
+// One returns a single creditNote record from the query.
+func (q creditNoteQuery) One(ctx context.Context, exec boil.ContextExecutor) (*CreditNote, error) {
+	o := &CreditNote{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for credit_notes")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all CreditNote records from the query.
+func (q creditNoteQuery) All(ctx context.Context, exec boil.ContextExecutor) (CreditNoteSlice, error) {
+	var o []*CreditNote
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to CreditNote slice")
+	}
+
+	if len(creditNoteAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all CreditNote records in the query.
+func (q creditNoteQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count credit_notes rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q creditNoteQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if credit_notes exists")
+	}
+
+	return count > 0, nil
+}
+
+// Invoice pointed to by the foreign key.
+func (o *CreditNote) Invoice(mods ...qm.QueryMod) invoiceQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("`id` = ?", o.InvoiceID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	return Invoices(queryMods...)
+}
+
+// LoadInvoice allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (creditNoteL) LoadInvoice(ctx context.Context, e boil.ContextExecutor, singular bool, maybeCreditNote interface{}, mods queries.Applicator) error {
+	var slice []*CreditNote
+	var object *CreditNote
+
+	if singular {
+		var ok bool
+		object, ok = maybeCreditNote.(*CreditNote)
+		if !ok {
+			object = new(CreditNote)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeCreditNote)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeCreditNote))
+			}
+		}
+	} else {
+		s, ok := maybeCreditNote.(*[]*CreditNote)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeCreditNote)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeCreditNote))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &creditNoteR{}
+		}
+		args[object.InvoiceID] = struct{}{}
+
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &creditNoteR{}
+			}
+
+			args[obj.InvoiceID] = struct{}{}
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`invoices`),
+		qm.WhereIn(`invoices.id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load Invoice")
+	}
+
+	var resultSlice []*Invoice
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice Invoice")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for invoices")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for invoices")
+	}
+
+	if len(invoiceAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.Invoice = foreign
+		if foreign.R == nil {
+			foreign.R = &invoiceR{}
+		}
+		foreign.R.CreditNotes = append(foreign.R.CreditNotes, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.InvoiceID == foreign.ID {
+				local.R.Invoice = foreign
+				if foreign.R == nil {
+					foreign.R = &invoiceR{}
+				}
+				foreign.R.CreditNotes = append(foreign.R.CreditNotes, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetInvoice of the creditNote to the related item.
+// Sets o.R.Invoice to related.
+// Adds o to related.R.CreditNotes.
+func (o *CreditNote) SetInvoice(ctx context.Context, exec boil.ContextExecutor, insert bool, related *Invoice) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE `credit_notes` SET %s WHERE %s",
+		strmangle.SetParamNames("`", "`", 0, []string{"invoice_id"}),
+		strmangle.WhereClause("`", "`", 0, creditNotePrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.InvoiceID = related.ID
+	if o.R == nil {
+		o.R = &creditNoteR{
+			Invoice: related,
+		}
+	} else {
+		o.R.Invoice = related
+	}
+
+	if related.R == nil {
+		related.R = &invoiceR{
+			CreditNotes: CreditNoteSlice{o},
+		}
+	} else {
+		related.R.CreditNotes = append(related.R.CreditNotes, o)
+	}
+
+	return nil
+}
+
+// CreditNotes retrieves all the records using an executor.
+func CreditNotes(mods ...qm.QueryMod) creditNoteQuery {
+	mods = append(mods, qm.From("`credit_notes`"))
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"`credit_notes`.*"})
+	}
+
+	return creditNoteQuery{q}
+}
+
+// FindCreditNote retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindCreditNote(ctx context.Context, exec boil.ContextExecutor, iD int64, selectCols ...string) (*CreditNote, error) {
+	creditNoteObj := &CreditNote{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from `credit_notes` where `id`=?", sel,
+	)
+
+	q := queries.Raw(query, iD)
+
+	err := q.Bind(ctx, exec, creditNoteObj)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from credit_notes")
+	}
+
+	if err = creditNoteObj.doAfterSelectHooks(ctx, exec); err != nil {
+		return creditNoteObj, err
+	}
+
+	return creditNoteObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *CreditNote) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no credit_notes provided for insertion")
+	}
+
+	var err error
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(creditNoteColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	creditNoteInsertCacheMut.RLock()
+	cache, cached := creditNoteInsertCache[key]
+	creditNoteInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			creditNoteAllColumns,
+			creditNoteColumnsWithDefault,
+			creditNoteColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(creditNoteType, creditNoteMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(creditNoteType, creditNoteMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO `credit_notes` (`%s`) %%sVALUES (%s)%%s", strings.Join(wl, "`,`"), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO `credit_notes` () VALUES ()%s%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			cache.retQuery = fmt.Sprintf("SELECT `%s` FROM `credit_notes` WHERE %s", strings.Join(returnColumns, "`,`"), strmangle.WhereClause("`", "`", 0, creditNotePrimaryKeyColumns))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	result, err := exec.ExecContext(ctx, cache.query, vals...)
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into credit_notes")
+	}
+
+	var lastID int64
+	var identifierCols []interface{}
+
+	if len(cache.retMapping) == 0 {
+		goto CacheNoHooks
+	}
+
+	lastID, err = result.LastInsertId()
+	if err != nil {
+		return ErrSyncFail
+	}
+
+	o.ID = int64(lastID)
+	if lastID != 0 && len(cache.retMapping) == 1 && cache.retMapping[0] == creditNoteMapping["id"] {
+		goto CacheNoHooks
+	}
+
+	identifierCols = []interface{}{
+		o.ID,
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.retQuery)
+		fmt.Fprintln(writer, identifierCols...)
+	}
+	err = exec.QueryRowContext(ctx, cache.retQuery, identifierCols...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to populate default values for credit_notes")
+	}
+
+CacheNoHooks:
+	if !cached {
+		creditNoteInsertCacheMut.Lock()
+		creditNoteInsertCache[key] = cache
+		creditNoteInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// Update uses an executor to update the CreditNote.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *CreditNote) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	creditNoteUpdateCacheMut.RLock()
+	cache, cached := creditNoteUpdateCache[key]
+	creditNoteUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			creditNoteAllColumns,
+			creditNotePrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("models: unable to update credit_notes, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE `credit_notes` SET %s WHERE %s",
+			strmangle.SetParamNames("`", "`", 0, wl),
+			strmangle.WhereClause("`", "`", 0, creditNotePrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(creditNoteType, creditNoteMapping, append(wl, creditNotePrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update credit_notes row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by update for credit_notes")
+	}
+
+	if !cached {
+		creditNoteUpdateCacheMut.Lock()
+		creditNoteUpdateCache[key] = cache
+		creditNoteUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q creditNoteQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all for credit_notes")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected for credit_notes")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o CreditNoteSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), creditNotePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE `credit_notes` SET %s WHERE %s",
+		strmangle.SetParamNames("`", "`", 0, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, creditNotePrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all in creditNote slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected all in update all creditNote")
+	}
+	return rowsAff, nil
+}
+
+var mySQLCreditNoteUniqueColumns = []string{
+	"id",
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *CreditNote) Upsert(ctx context.Context, exec boil.ContextExecutor, updateColumns, insertColumns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no credit_notes provided for upsert")
+	}
+
+	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(creditNoteColumnsWithDefault, o)
+	nzUniques := queries.NonZeroDefaultSet(mySQLCreditNoteUniqueColumns, o)
+
+	if len(nzUniques) == 0 {
+		return errors.New("cannot upsert with a table that cannot conflict on a unique column")
+	}
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzUniques {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	creditNoteUpsertCacheMut.RLock()
+	cache, cached := creditNoteUpsertCache[key]
+	creditNoteUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, _ := insertColumns.InsertColumnSet(
+			creditNoteAllColumns,
+			creditNoteColumnsWithDefault,
+			creditNoteColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		update := updateColumns.UpdateColumnSet(
+			creditNoteAllColumns,
+			creditNotePrimaryKeyColumns,
+		)
+
+		if !updateColumns.IsNone() && len(update) == 0 {
+			return errors.New("models: unable to upsert credit_notes, could not build update column list")
+		}
+
+		ret := strmangle.SetComplement(creditNoteAllColumns, strmangle.SetIntersect(insert, update))
+
+		cache.query = buildUpsertQueryMySQL(dialect, "`credit_notes`", update, insert)
+		cache.retQuery = fmt.Sprintf(
+			"SELECT %s FROM `credit_notes` WHERE %s",
+			strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, ret), ","),
+			strmangle.WhereClause("`", "`", 0, nzUniques),
+		)
+
+		cache.valueMapping, err = queries.BindMapping(creditNoteType, creditNoteMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(creditNoteType, creditNoteMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	result, err := exec.ExecContext(ctx, cache.query, vals...)
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert for credit_notes")
+	}
+
+	var lastID int64
+	var uniqueMap []uint64
+	var nzUniqueCols []interface{}
+
+	if len(cache.retMapping) == 0 {
+		goto CacheNoHooks
+	}
+
+	lastID, err = result.LastInsertId()
+	if err != nil {
+		return ErrSyncFail
+	}
+
+	o.ID = int64(lastID)
+	if lastID != 0 && len(cache.retMapping) == 1 && cache.retMapping[0] == creditNoteMapping["id"] {
+		goto CacheNoHooks
+	}
+
+	uniqueMap, err = queries.BindMapping(creditNoteType, creditNoteMapping, nzUniques)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to retrieve unique values for credit_notes")
+	}
+	nzUniqueCols = queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), uniqueMap)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.retQuery)
+		fmt.Fprintln(writer, nzUniqueCols...)
+	}
+	err = exec.QueryRowContext(ctx, cache.retQuery, nzUniqueCols...).Scan(returns...)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to populate default values for credit_notes")
+	}
+
+CacheNoHooks:
+	if !cached {
+		creditNoteUpsertCacheMut.Lock()
+		creditNoteUpsertCache[key] = cache
+		creditNoteUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(ctx, exec)
+}
+
+// Delete deletes a single CreditNote record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *CreditNote) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("models: no CreditNote provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), creditNotePrimaryKeyMapping)
+	sql := "DELETE FROM `credit_notes` WHERE `id`=?"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete from credit_notes")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by delete for credit_notes")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q creditNoteQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("models: no creditNoteQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from credit_notes")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for credit_notes")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o CreditNoteSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(creditNoteBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), creditNotePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM `credit_notes` WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, creditNotePrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from creditNote slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for credit_notes")
+	}
+
+	if len(creditNoteAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *CreditNote) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindCreditNote(ctx, exec, o.ID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *CreditNoteSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := CreditNoteSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), creditNotePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT `credit_notes`.* FROM `credit_notes` WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, creditNotePrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in CreditNoteSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// CreditNoteExists checks if the CreditNote row exists.
+func CreditNoteExists(ctx context.Context, exec boil.ContextExecutor, iD int64) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from `credit_notes` where `id`=? limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, iD)
+	}
+	row := exec.QueryRowContext(ctx, sql, iD)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if credit_notes exists")
+	}
+
+	return exists, nil
+}
+
+// Exists checks if the CreditNote row exists.
+func (o *CreditNote) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	return CreditNoteExists(ctx, exec, o.ID)
+}
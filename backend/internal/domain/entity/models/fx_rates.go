@@ -0,0 +1,1005 @@
+// Code generated by SQLBoiler 4.16.2 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/sqlboiler/v4/types"
+	"github.com/volatiletech/strmangle"
+)
+
+// FXRate is an object representing the database table.
+type FXRate struct {
+	ID           int64         `boil:"id" json:"id" toml:"id" yaml:"id"`
+	Currency     string        `boil:"currency" json:"currency" toml:"currency" yaml:"currency"`
+	BaseCurrency string        `boil:"base_currency" json:"base_currency" toml:"base_currency" yaml:"base_currency"`
+	RateDate     time.Time     `boil:"rate_date" json:"rate_date" toml:"rate_date" yaml:"rate_date"`
+	Rate         types.Decimal `boil:"rate" json:"rate" toml:"rate" yaml:"rate"`
+
+	R *fxRateR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L fxRateL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var FXRateColumns = struct {
+	ID           string
+	Currency     string
+	BaseCurrency string
+	RateDate     string
+	Rate         string
+}{
+	ID:           "id",
+	Currency:     "currency",
+	BaseCurrency: "base_currency",
+	RateDate:     "rate_date",
+	Rate:         "rate",
+}
+
+var FXRateTableColumns = struct {
+	ID           string
+	Currency     string
+	BaseCurrency string
+	RateDate     string
+	Rate         string
+}{
+	ID:           "fx_rates.id",
+	Currency:     "fx_rates.currency",
+	BaseCurrency: "fx_rates.base_currency",
+	RateDate:     "fx_rates.rate_date",
+	Rate:         "fx_rates.rate",
+}
+
+// Generated where
+
+var FXRateWhere = struct {
+	ID           whereHelperint64
+	Currency     whereHelperstring
+	BaseCurrency whereHelperstring
+	RateDate     whereHelpertime_Time
+	Rate         whereHelpertypes_Decimal
+}{
+	ID:           whereHelperint64{field: "`fx_rates`.`id`"},
+	Currency:     whereHelperstring{field: "`fx_rates`.`currency`"},
+	BaseCurrency: whereHelperstring{field: "`fx_rates`.`base_currency`"},
+	RateDate:     whereHelpertime_Time{field: "`fx_rates`.`rate_date`"},
+	Rate:         whereHelpertypes_Decimal{field: "`fx_rates`.`rate`"},
+}
+
+// FXRateRels is where relationship names are stored.
+var FXRateRels = struct {
+}{}
+
+// fxRateR is where relationships are stored.
+type fxRateR struct {
+}
+
+// NewStruct creates a new relationship struct
+func (*fxRateR) NewStruct() *fxRateR {
+	return &fxRateR{}
+}
+
+// fxRateL is where Load methods for each relationship are stored.
+type fxRateL struct{}
+
+var (
+	fxRateAllColumns            = []string{"id", "currency", "base_currency", "rate_date", "rate"}
+	fxRateColumnsWithoutDefault = []string{"currency", "base_currency", "rate_date", "rate"}
+	fxRateColumnsWithDefault    = []string{"id"}
+	fxRatePrimaryKeyColumns     = []string{"id"}
+	fxRateGeneratedColumns      = []string{}
+)
+
+type (
+	// FXRateSlice is an alias for a slice of pointers to FXRate.
+	// This should almost always be used instead of []FXRate.
+	FXRateSlice []*FXRate
+	// FXRateHook is the signature for custom FXRate hook methods
+	FXRateHook func(context.Context, boil.ContextExecutor, *FXRate) error
+
+	fxRateQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	fxRateType                 = reflect.TypeOf(&FXRate{})
+	fxRateMapping              = queries.MakeStructMapping(fxRateType)
+	fxRatePrimaryKeyMapping, _ = queries.BindMapping(fxRateType, fxRateMapping, fxRatePrimaryKeyColumns)
+	fxRateInsertCacheMut       sync.RWMutex
+	fxRateInsertCache          = make(map[string]insertCache)
+	fxRateUpdateCacheMut       sync.RWMutex
+	fxRateUpdateCache          = make(map[string]updateCache)
+	fxRateUpsertCacheMut       sync.RWMutex
+	fxRateUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var fxRateAfterSelectMu sync.Mutex
+var fxRateAfterSelectHooks []FXRateHook
+
+var fxRateBeforeInsertMu sync.Mutex
+var fxRateBeforeInsertHooks []FXRateHook
+var fxRateAfterInsertMu sync.Mutex
+var fxRateAfterInsertHooks []FXRateHook
+
+var fxRateBeforeUpdateMu sync.Mutex
+var fxRateBeforeUpdateHooks []FXRateHook
+var fxRateAfterUpdateMu sync.Mutex
+var fxRateAfterUpdateHooks []FXRateHook
+
+var fxRateBeforeDeleteMu sync.Mutex
+var fxRateBeforeDeleteHooks []FXRateHook
+var fxRateAfterDeleteMu sync.Mutex
+var fxRateAfterDeleteHooks []FXRateHook
+
+var fxRateBeforeUpsertMu sync.Mutex
+var fxRateBeforeUpsertHooks []FXRateHook
+var fxRateAfterUpsertMu sync.Mutex
+var fxRateAfterUpsertHooks []FXRateHook
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *FXRate) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range fxRateAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *FXRate) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range fxRateBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *FXRate) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range fxRateAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *FXRate) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range fxRateBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *FXRate) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range fxRateAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *FXRate) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range fxRateBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *FXRate) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range fxRateAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *FXRate) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range fxRateBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *FXRate) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range fxRateAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddFXRateHook registers your hook function for all future operations.
+func AddFXRateHook(hookPoint boil.HookPoint, fxRateHook FXRateHook) {
+	switch hookPoint {
+	case boil.AfterSelectHook:
+		fxRateAfterSelectMu.Lock()
+		fxRateAfterSelectHooks = append(fxRateAfterSelectHooks, fxRateHook)
+		fxRateAfterSelectMu.Unlock()
+	case boil.BeforeInsertHook:
+		fxRateBeforeInsertMu.Lock()
+		fxRateBeforeInsertHooks = append(fxRateBeforeInsertHooks, fxRateHook)
+		fxRateBeforeInsertMu.Unlock()
+	case boil.AfterInsertHook:
+		fxRateAfterInsertMu.Lock()
+		fxRateAfterInsertHooks = append(fxRateAfterInsertHooks, fxRateHook)
+		fxRateAfterInsertMu.Unlock()
+	case boil.BeforeUpdateHook:
+		fxRateBeforeUpdateMu.Lock()
+		fxRateBeforeUpdateHooks = append(fxRateBeforeUpdateHooks, fxRateHook)
+		fxRateBeforeUpdateMu.Unlock()
+	case boil.AfterUpdateHook:
+		fxRateAfterUpdateMu.Lock()
+		fxRateAfterUpdateHooks = append(fxRateAfterUpdateHooks, fxRateHook)
+		fxRateAfterUpdateMu.Unlock()
+	case boil.BeforeDeleteHook:
+		fxRateBeforeDeleteMu.Lock()
+		fxRateBeforeDeleteHooks = append(fxRateBeforeDeleteHooks, fxRateHook)
+		fxRateBeforeDeleteMu.Unlock()
+	case boil.AfterDeleteHook:
+		fxRateAfterDeleteMu.Lock()
+		fxRateAfterDeleteHooks = append(fxRateAfterDeleteHooks, fxRateHook)
+		fxRateAfterDeleteMu.Unlock()
+	case boil.BeforeUpsertHook:
+		fxRateBeforeUpsertMu.Lock()
+		fxRateBeforeUpsertHooks = append(fxRateBeforeUpsertHooks, fxRateHook)
+		fxRateBeforeUpsertMu.Unlock()
+	case boil.AfterUpsertHook:
+		fxRateAfterUpsertMu.Lock()
+		fxRateAfterUpsertHooks = append(fxRateAfterUpsertHooks, fxRateHook)
+		fxRateAfterUpsertMu.Unlock()
+	}
+}
+
+// One returns a single fxRate record from the query.
+func (q fxRateQuery) One(ctx context.Context, exec boil.ContextExecutor) (*FXRate, error) {
+	o := &FXRate{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for fx_rates")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all FXRate records from the query.
+func (q fxRateQuery) All(ctx context.Context, exec boil.ContextExecutor) (FXRateSlice, error) {
+	var o []*FXRate
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to FXRate slice")
+	}
+
+	if len(fxRateAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all FXRate records in the query.
+func (q fxRateQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count fx_rates rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q fxRateQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if fx_rates exists")
+	}
+
+	return count > 0, nil
+}
+
+// FXRates retrieves all the records using an executor.
+func FXRates(mods ...qm.QueryMod) fxRateQuery {
+	mods = append(mods, qm.From("`fx_rates`"))
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"`fx_rates`.*"})
+	}
+
+	return fxRateQuery{q}
+}
+
+// FindFXRate retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindFXRate(ctx context.Context, exec boil.ContextExecutor, iD int64, selectCols ...string) (*FXRate, error) {
+	fxRateObj := &FXRate{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from `fx_rates` where `id`=?", sel,
+	)
+
+	q := queries.Raw(query, iD)
+
+	err := q.Bind(ctx, exec, fxRateObj)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from fx_rates")
+	}
+
+	if err = fxRateObj.doAfterSelectHooks(ctx, exec); err != nil {
+		return fxRateObj, err
+	}
+
+	return fxRateObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *FXRate) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no fx_rates provided for insertion")
+	}
+
+	var err error
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(fxRateColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	fxRateInsertCacheMut.RLock()
+	cache, cached := fxRateInsertCache[key]
+	fxRateInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			fxRateAllColumns,
+			fxRateColumnsWithDefault,
+			fxRateColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(fxRateType, fxRateMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(fxRateType, fxRateMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO `fx_rates` (`%s`) %%sVALUES (%s)%%s", strings.Join(wl, "`,`"), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO `fx_rates` () VALUES ()%s%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			cache.retQuery = fmt.Sprintf("SELECT `%s` FROM `fx_rates` WHERE %s", strings.Join(returnColumns, "`,`"), strmangle.WhereClause("`", "`", 0, fxRatePrimaryKeyColumns))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	result, err := exec.ExecContext(ctx, cache.query, vals...)
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into fx_rates")
+	}
+
+	var lastID int64
+	var identifierCols []interface{}
+
+	if len(cache.retMapping) == 0 {
+		goto CacheNoHooks
+	}
+
+	lastID, err = result.LastInsertId()
+	if err != nil {
+		return ErrSyncFail
+	}
+
+	o.ID = int64(lastID)
+	if lastID != 0 && len(cache.retMapping) == 1 && cache.retMapping[0] == fxRateMapping["id"] {
+		goto CacheNoHooks
+	}
+
+	identifierCols = []interface{}{
+		o.ID,
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.retQuery)
+		fmt.Fprintln(writer, identifierCols...)
+	}
+	err = exec.QueryRowContext(ctx, cache.retQuery, identifierCols...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to populate default values for fx_rates")
+	}
+
+CacheNoHooks:
+	if !cached {
+		fxRateInsertCacheMut.Lock()
+		fxRateInsertCache[key] = cache
+		fxRateInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// Update uses an executor to update the FXRate.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *FXRate) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	fxRateUpdateCacheMut.RLock()
+	cache, cached := fxRateUpdateCache[key]
+	fxRateUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			fxRateAllColumns,
+			fxRatePrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("models: unable to update fx_rates, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE `fx_rates` SET %s WHERE %s",
+			strmangle.SetParamNames("`", "`", 0, wl),
+			strmangle.WhereClause("`", "`", 0, fxRatePrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(fxRateType, fxRateMapping, append(wl, fxRatePrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update fx_rates row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by update for fx_rates")
+	}
+
+	if !cached {
+		fxRateUpdateCacheMut.Lock()
+		fxRateUpdateCache[key] = cache
+		fxRateUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q fxRateQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all for fx_rates")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected for fx_rates")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o FXRateSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), fxRatePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE `fx_rates` SET %s WHERE %s",
+		strmangle.SetParamNames("`", "`", 0, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, fxRatePrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all in fxRate slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected all in update all fxRate")
+	}
+	return rowsAff, nil
+}
+
+var mySQLFXRateUniqueColumns = []string{
+	"id",
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *FXRate) Upsert(ctx context.Context, exec boil.ContextExecutor, updateColumns, insertColumns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no fx_rates provided for upsert")
+	}
+
+	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(fxRateColumnsWithDefault, o)
+	nzUniques := queries.NonZeroDefaultSet(mySQLFXRateUniqueColumns, o)
+
+	if len(nzUniques) == 0 {
+		return errors.New("cannot upsert with a table that cannot conflict on a unique column")
+	}
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzUniques {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	fxRateUpsertCacheMut.RLock()
+	cache, cached := fxRateUpsertCache[key]
+	fxRateUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, _ := insertColumns.InsertColumnSet(
+			fxRateAllColumns,
+			fxRateColumnsWithDefault,
+			fxRateColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		update := updateColumns.UpdateColumnSet(
+			fxRateAllColumns,
+			fxRatePrimaryKeyColumns,
+		)
+
+		if !updateColumns.IsNone() && len(update) == 0 {
+			return errors.New("models: unable to upsert fx_rates, could not build update column list")
+		}
+
+		ret := strmangle.SetComplement(fxRateAllColumns, strmangle.SetIntersect(insert, update))
+
+		cache.query = buildUpsertQueryMySQL(dialect, "`fx_rates`", update, insert)
+		cache.retQuery = fmt.Sprintf(
+			"SELECT %s FROM `fx_rates` WHERE %s",
+			strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, ret), ","),
+			strmangle.WhereClause("`", "`", 0, nzUniques),
+		)
+
+		cache.valueMapping, err = queries.BindMapping(fxRateType, fxRateMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(fxRateType, fxRateMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	result, err := exec.ExecContext(ctx, cache.query, vals...)
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert for fx_rates")
+	}
+
+	var lastID int64
+	var uniqueMap []uint64
+	var nzUniqueCols []interface{}
+
+	if len(cache.retMapping) == 0 {
+		goto CacheNoHooks
+	}
+
+	lastID, err = result.LastInsertId()
+	if err != nil {
+		return ErrSyncFail
+	}
+
+	o.ID = int64(lastID)
+	if lastID != 0 && len(cache.retMapping) == 1 && cache.retMapping[0] == fxRateMapping["id"] {
+		goto CacheNoHooks
+	}
+
+	uniqueMap, err = queries.BindMapping(fxRateType, fxRateMapping, nzUniques)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to retrieve unique values for fx_rates")
+	}
+	nzUniqueCols = queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), uniqueMap)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.retQuery)
+		fmt.Fprintln(writer, nzUniqueCols...)
+	}
+	err = exec.QueryRowContext(ctx, cache.retQuery, nzUniqueCols...).Scan(returns...)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to populate default values for fx_rates")
+	}
+
+CacheNoHooks:
+	if !cached {
+		fxRateUpsertCacheMut.Lock()
+		fxRateUpsertCache[key] = cache
+		fxRateUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(ctx, exec)
+}
+
+// Delete deletes a single FXRate record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *FXRate) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("models: no FXRate provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), fxRatePrimaryKeyMapping)
+	sql := "DELETE FROM `fx_rates` WHERE `id`=?"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete from fx_rates")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by delete for fx_rates")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q fxRateQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("models: no fxRateQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from fx_rates")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for fx_rates")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o FXRateSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(fxRateBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), fxRatePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM `fx_rates` WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, fxRatePrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from fxRate slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for fx_rates")
+	}
+
+	if len(fxRateAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *FXRate) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindFXRate(ctx, exec, o.ID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *FXRateSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := FXRateSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), fxRatePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT `fx_rates`.* FROM `fx_rates` WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, fxRatePrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in FXRateSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// FXRateExists checks if the FXRate row exists.
+func FXRateExists(ctx context.Context, exec boil.ContextExecutor, iD int64) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from `fx_rates` where `id`=? limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, iD)
+	}
+	row := exec.QueryRowContext(ctx, sql, iD)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if fx_rates exists")
+	}
+
+	return exists, nil
+}
+
+// Exists checks if the FXRate row exists.
+func (o *FXRate) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	return FXRateExists(ctx, exec, o.ID)
+}
@@ -0,0 +1,1200 @@
+// Code generated by SQLBoiler 4.16.2 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/strmangle"
+)
+
+// InvoiceAttachment is an object representing the database table.
+type InvoiceAttachment struct {
+	ID          int64  `boil:"id" json:"id" toml:"id" yaml:"id"`
+	InvoiceID   int64  `boil:"invoice_id" json:"invoice_id" toml:"invoice_id" yaml:"invoice_id"`
+	FileName    string `boil:"file_name" json:"file_name" toml:"file_name" yaml:"file_name"`
+	ContentType string `boil:"content_type" json:"content_type" toml:"content_type" yaml:"content_type"`
+	Size        int64  `boil:"size" json:"size" toml:"size" yaml:"size"`
+	Sha256      string `boil:"sha256" json:"sha256" toml:"sha256" yaml:"sha256"`
+
+	R *invoiceAttachmentR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L invoiceAttachmentL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var InvoiceAttachmentColumns = struct {
+	ID          string
+	InvoiceID   string
+	FileName    string
+	ContentType string
+	Size        string
+	Sha256      string
+}{
+	ID:          "id",
+	InvoiceID:   "invoice_id",
+	FileName:    "file_name",
+	ContentType: "content_type",
+	Size:        "size",
+	Sha256:      "sha256",
+}
+
+var InvoiceAttachmentTableColumns = struct {
+	ID          string
+	InvoiceID   string
+	FileName    string
+	ContentType string
+	Size        string
+	Sha256      string
+}{
+	ID:          "invoice_attachments.id",
+	InvoiceID:   "invoice_attachments.invoice_id",
+	FileName:    "invoice_attachments.file_name",
+	ContentType: "invoice_attachments.content_type",
+	Size:        "invoice_attachments.size",
+	Sha256:      "invoice_attachments.sha256",
+}
+
+// Generated where
+
+var InvoiceAttachmentWhere = struct {
+	ID          whereHelperint64
+	InvoiceID   whereHelperint64
+	FileName    whereHelperstring
+	ContentType whereHelperstring
+	Size        whereHelperint64
+	Sha256      whereHelperstring
+}{
+	ID:          whereHelperint64{field: "`invoice_attachments`.`id`"},
+	InvoiceID:   whereHelperint64{field: "`invoice_attachments`.`invoice_id`"},
+	FileName:    whereHelperstring{field: "`invoice_attachments`.`file_name`"},
+	ContentType: whereHelperstring{field: "`invoice_attachments`.`content_type`"},
+	Size:        whereHelperint64{field: "`invoice_attachments`.`size`"},
+	Sha256:      whereHelperstring{field: "`invoice_attachments`.`sha256`"},
+}
+
+// InvoiceAttachmentRels is where relationship names are stored.
+var InvoiceAttachmentRels = struct {
+	Invoice string
+}{
+	Invoice: "Invoice",
+}
+
+// invoiceAttachmentR is where relationships are stored.
+type invoiceAttachmentR struct {
+	Invoice *Invoice `boil:"Invoice" json:"Invoice" toml:"Invoice" yaml:"Invoice"`
+}
+
+// NewStruct creates a new relationship struct
+func (*invoiceAttachmentR) NewStruct() *invoiceAttachmentR {
+	return &invoiceAttachmentR{}
+}
+
+func (r *invoiceAttachmentR) GetInvoice() *Invoice {
+	if r == nil {
+		return nil
+	}
+	return r.Invoice
+}
+
+// invoiceAttachmentL is where Load methods for each relationship are stored.
+type invoiceAttachmentL struct{}
+
+var (
+	invoiceAttachmentAllColumns            = []string{"id", "invoice_id", "file_name", "content_type", "size", "sha256"}
+	invoiceAttachmentColumnsWithoutDefault = []string{"invoice_id", "file_name", "content_type", "size", "sha256"}
+	invoiceAttachmentColumnsWithDefault    = []string{"id"}
+	invoiceAttachmentPrimaryKeyColumns     = []string{"id"}
+	invoiceAttachmentGeneratedColumns      = []string{}
+)
+
+type (
+	// InvoiceAttachmentSlice is an alias for a slice of pointers to InvoiceAttachment.
+	// This should almost always be used instead of []InvoiceAttachment.
+	InvoiceAttachmentSlice []*InvoiceAttachment
+	// InvoiceAttachmentHook is the signature for custom InvoiceAttachment hook methods
+	InvoiceAttachmentHook func(context.Context, boil.ContextExecutor, *InvoiceAttachment) error
+
+	invoiceAttachmentQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	invoiceAttachmentType                 = reflect.TypeOf(&InvoiceAttachment{})
+	invoiceAttachmentMapping              = queries.MakeStructMapping(invoiceAttachmentType)
+	invoiceAttachmentPrimaryKeyMapping, _ = queries.BindMapping(invoiceAttachmentType, invoiceAttachmentMapping, invoiceAttachmentPrimaryKeyColumns)
+	invoiceAttachmentInsertCacheMut       sync.RWMutex
+	invoiceAttachmentInsertCache          = make(map[string]insertCache)
+	invoiceAttachmentUpdateCacheMut       sync.RWMutex
+	invoiceAttachmentUpdateCache          = make(map[string]updateCache)
+	invoiceAttachmentUpsertCacheMut       sync.RWMutex
+	invoiceAttachmentUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var invoiceAttachmentAfterSelectMu sync.Mutex
+var invoiceAttachmentAfterSelectHooks []InvoiceAttachmentHook
+
+var invoiceAttachmentBeforeInsertMu sync.Mutex
+var invoiceAttachmentBeforeInsertHooks []InvoiceAttachmentHook
+var invoiceAttachmentAfterInsertMu sync.Mutex
+var invoiceAttachmentAfterInsertHooks []InvoiceAttachmentHook
+
+var invoiceAttachmentBeforeUpdateMu sync.Mutex
+var invoiceAttachmentBeforeUpdateHooks []InvoiceAttachmentHook
+var invoiceAttachmentAfterUpdateMu sync.Mutex
+var invoiceAttachmentAfterUpdateHooks []InvoiceAttachmentHook
+
+var invoiceAttachmentBeforeDeleteMu sync.Mutex
+var invoiceAttachmentBeforeDeleteHooks []InvoiceAttachmentHook
+var invoiceAttachmentAfterDeleteMu sync.Mutex
+var invoiceAttachmentAfterDeleteHooks []InvoiceAttachmentHook
+
+var invoiceAttachmentBeforeUpsertMu sync.Mutex
+var invoiceAttachmentBeforeUpsertHooks []InvoiceAttachmentHook
+var invoiceAttachmentAfterUpsertMu sync.Mutex
+var invoiceAttachmentAfterUpsertHooks []InvoiceAttachmentHook
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *InvoiceAttachment) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range invoiceAttachmentAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *InvoiceAttachment) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range invoiceAttachmentBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *InvoiceAttachment) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range invoiceAttachmentAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *InvoiceAttachment) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range invoiceAttachmentBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *InvoiceAttachment) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range invoiceAttachmentAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *InvoiceAttachment) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range invoiceAttachmentBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *InvoiceAttachment) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range invoiceAttachmentAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *InvoiceAttachment) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range invoiceAttachmentBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *InvoiceAttachment) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range invoiceAttachmentAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddInvoiceAttachmentHook registers your hook function for all future operations.
+func AddInvoiceAttachmentHook(hookPoint boil.HookPoint, invoiceAttachmentHook InvoiceAttachmentHook) {
+	switch hookPoint {
+	case boil.AfterSelectHook:
+		invoiceAttachmentAfterSelectMu.Lock()
+		invoiceAttachmentAfterSelectHooks = append(invoiceAttachmentAfterSelectHooks, invoiceAttachmentHook)
+		invoiceAttachmentAfterSelectMu.Unlock()
+	case boil.BeforeInsertHook:
+		invoiceAttachmentBeforeInsertMu.Lock()
+		invoiceAttachmentBeforeInsertHooks = append(invoiceAttachmentBeforeInsertHooks, invoiceAttachmentHook)
+		invoiceAttachmentBeforeInsertMu.Unlock()
+	case boil.AfterInsertHook:
+		invoiceAttachmentAfterInsertMu.Lock()
+		invoiceAttachmentAfterInsertHooks = append(invoiceAttachmentAfterInsertHooks, invoiceAttachmentHook)
+		invoiceAttachmentAfterInsertMu.Unlock()
+	case boil.BeforeUpdateHook:
+		invoiceAttachmentBeforeUpdateMu.Lock()
+		invoiceAttachmentBeforeUpdateHooks = append(invoiceAttachmentBeforeUpdateHooks, invoiceAttachmentHook)
+		invoiceAttachmentBeforeUpdateMu.Unlock()
+	case boil.AfterUpdateHook:
+		invoiceAttachmentAfterUpdateMu.Lock()
+		invoiceAttachmentAfterUpdateHooks = append(invoiceAttachmentAfterUpdateHooks, invoiceAttachmentHook)
+		invoiceAttachmentAfterUpdateMu.Unlock()
+	case boil.BeforeDeleteHook:
+		invoiceAttachmentBeforeDeleteMu.Lock()
+		invoiceAttachmentBeforeDeleteHooks = append(invoiceAttachmentBeforeDeleteHooks, invoiceAttachmentHook)
+		invoiceAttachmentBeforeDeleteMu.Unlock()
+	case boil.AfterDeleteHook:
+		invoiceAttachmentAfterDeleteMu.Lock()
+		invoiceAttachmentAfterDeleteHooks = append(invoiceAttachmentAfterDeleteHooks, invoiceAttachmentHook)
+		invoiceAttachmentAfterDeleteMu.Unlock()
+	case boil.BeforeUpsertHook:
+		invoiceAttachmentBeforeUpsertMu.Lock()
+		invoiceAttachmentBeforeUpsertHooks = append(invoiceAttachmentBeforeUpsertHooks, invoiceAttachmentHook)
+		invoiceAttachmentBeforeUpsertMu.Unlock()
+	case boil.AfterUpsertHook:
+		invoiceAttachmentAfterUpsertMu.Lock()
+		invoiceAttachmentAfterUpsertHooks = append(invoiceAttachmentAfterUpsertHooks, invoiceAttachmentHook)
+		invoiceAttachmentAfterUpsertMu.Unlock()
+	}
+}
+
+// One returns a single invoiceAttachment record from the query.
+func (q invoiceAttachmentQuery) One(ctx context.Context, exec boil.ContextExecutor) (*InvoiceAttachment, error) {
+	o := &InvoiceAttachment{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for invoice_attachments")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all InvoiceAttachment records from the query.
+func (q invoiceAttachmentQuery) All(ctx context.Context, exec boil.ContextExecutor) (InvoiceAttachmentSlice, error) {
+	var o []*InvoiceAttachment
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to InvoiceAttachment slice")
+	}
+
+	if len(invoiceAttachmentAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all InvoiceAttachment records in the query.
+func (q invoiceAttachmentQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count invoice_attachments rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q invoiceAttachmentQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if invoice_attachments exists")
+	}
+
+	return count > 0, nil
+}
+
+// Invoice pointed to by the foreign key.
+func (o *InvoiceAttachment) Invoice(mods ...qm.QueryMod) invoiceQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("`id` = ?", o.InvoiceID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	return Invoices(queryMods...)
+}
+
+// LoadInvoice allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (invoiceAttachmentL) LoadInvoice(ctx context.Context, e boil.ContextExecutor, singular bool, maybeInvoiceAttachment interface{}, mods queries.Applicator) error {
+	var slice []*InvoiceAttachment
+	var object *InvoiceAttachment
+
+	if singular {
+		var ok bool
+		object, ok = maybeInvoiceAttachment.(*InvoiceAttachment)
+		if !ok {
+			object = new(InvoiceAttachment)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeInvoiceAttachment)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeInvoiceAttachment))
+			}
+		}
+	} else {
+		s, ok := maybeInvoiceAttachment.(*[]*InvoiceAttachment)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeInvoiceAttachment)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeInvoiceAttachment))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &invoiceAttachmentR{}
+		}
+		args[object.InvoiceID] = struct{}{}
+
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &invoiceAttachmentR{}
+			}
+
+			args[obj.InvoiceID] = struct{}{}
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`invoices`),
+		qm.WhereIn(`invoices.id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load Invoice")
+	}
+
+	var resultSlice []*Invoice
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice Invoice")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for invoices")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for invoices")
+	}
+
+	if len(invoiceAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.Invoice = foreign
+		if foreign.R == nil {
+			foreign.R = &invoiceR{}
+		}
+		foreign.R.InvoiceAttachments = append(foreign.R.InvoiceAttachments, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.InvoiceID == foreign.ID {
+				local.R.Invoice = foreign
+				if foreign.R == nil {
+					foreign.R = &invoiceR{}
+				}
+				foreign.R.InvoiceAttachments = append(foreign.R.InvoiceAttachments, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetInvoice of the invoiceAttachment to the related item.
+// Sets o.R.Invoice to related.
+// Adds o to related.R.InvoiceAttachments.
+func (o *InvoiceAttachment) SetInvoice(ctx context.Context, exec boil.ContextExecutor, insert bool, related *Invoice) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE `invoice_attachments` SET %s WHERE %s",
+		strmangle.SetParamNames("`", "`", 0, []string{"invoice_id"}),
+		strmangle.WhereClause("`", "`", 0, invoiceAttachmentPrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.InvoiceID = related.ID
+	if o.R == nil {
+		o.R = &invoiceAttachmentR{
+			Invoice: related,
+		}
+	} else {
+		o.R.Invoice = related
+	}
+
+	if related.R == nil {
+		related.R = &invoiceR{
+			InvoiceAttachments: InvoiceAttachmentSlice{o},
+		}
+	} else {
+		related.R.InvoiceAttachments = append(related.R.InvoiceAttachments, o)
+	}
+
+	return nil
+}
+
+// InvoiceAttachments retrieves all the records using an executor.
+func InvoiceAttachments(mods ...qm.QueryMod) invoiceAttachmentQuery {
+	mods = append(mods, qm.From("`invoice_attachments`"))
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"`invoice_attachments`.*"})
+	}
+
+	return invoiceAttachmentQuery{q}
+}
+
+// FindInvoiceAttachment retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindInvoiceAttachment(ctx context.Context, exec boil.ContextExecutor, iD int64, selectCols ...string) (*InvoiceAttachment, error) {
+	invoiceAttachmentObj := &InvoiceAttachment{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from `invoice_attachments` where `id`=?", sel,
+	)
+
+	q := queries.Raw(query, iD)
+
+	err := q.Bind(ctx, exec, invoiceAttachmentObj)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from invoice_attachments")
+	}
+
+	if err = invoiceAttachmentObj.doAfterSelectHooks(ctx, exec); err != nil {
+		return invoiceAttachmentObj, err
+	}
+
+	return invoiceAttachmentObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *InvoiceAttachment) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no invoice_attachments provided for insertion")
+	}
+
+	var err error
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(invoiceAttachmentColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	invoiceAttachmentInsertCacheMut.RLock()
+	cache, cached := invoiceAttachmentInsertCache[key]
+	invoiceAttachmentInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			invoiceAttachmentAllColumns,
+			invoiceAttachmentColumnsWithDefault,
+			invoiceAttachmentColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(invoiceAttachmentType, invoiceAttachmentMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(invoiceAttachmentType, invoiceAttachmentMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO `invoice_attachments` (`%s`) %%sVALUES (%s)%%s", strings.Join(wl, "`,`"), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO `invoice_attachments` () VALUES ()%s%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			cache.retQuery = fmt.Sprintf("SELECT `%s` FROM `invoice_attachments` WHERE %s", strings.Join(returnColumns, "`,`"), strmangle.WhereClause("`", "`", 0, invoiceAttachmentPrimaryKeyColumns))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	result, err := exec.ExecContext(ctx, cache.query, vals...)
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into invoice_attachments")
+	}
+
+	var lastID int64
+	var identifierCols []interface{}
+
+	if len(cache.retMapping) == 0 {
+		goto CacheNoHooks
+	}
+
+	lastID, err = result.LastInsertId()
+	if err != nil {
+		return ErrSyncFail
+	}
+
+	o.ID = int64(lastID)
+	if lastID != 0 && len(cache.retMapping) == 1 && cache.retMapping[0] == invoiceAttachmentMapping["id"] {
+		goto CacheNoHooks
+	}
+
+	identifierCols = []interface{}{
+		o.ID,
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.retQuery)
+		fmt.Fprintln(writer, identifierCols...)
+	}
+	err = exec.QueryRowContext(ctx, cache.retQuery, identifierCols...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to populate default values for invoice_attachments")
+	}
+
+CacheNoHooks:
+	if !cached {
+		invoiceAttachmentInsertCacheMut.Lock()
+		invoiceAttachmentInsertCache[key] = cache
+		invoiceAttachmentInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// Update uses an executor to update the InvoiceAttachment.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *InvoiceAttachment) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	invoiceAttachmentUpdateCacheMut.RLock()
+	cache, cached := invoiceAttachmentUpdateCache[key]
+	invoiceAttachmentUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			invoiceAttachmentAllColumns,
+			invoiceAttachmentPrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("models: unable to update invoice_attachments, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE `invoice_attachments` SET %s WHERE %s",
+			strmangle.SetParamNames("`", "`", 0, wl),
+			strmangle.WhereClause("`", "`", 0, invoiceAttachmentPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(invoiceAttachmentType, invoiceAttachmentMapping, append(wl, invoiceAttachmentPrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update invoice_attachments row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by update for invoice_attachments")
+	}
+
+	if !cached {
+		invoiceAttachmentUpdateCacheMut.Lock()
+		invoiceAttachmentUpdateCache[key] = cache
+		invoiceAttachmentUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q invoiceAttachmentQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all for invoice_attachments")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected for invoice_attachments")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o InvoiceAttachmentSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), invoiceAttachmentPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE `invoice_attachments` SET %s WHERE %s",
+		strmangle.SetParamNames("`", "`", 0, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, invoiceAttachmentPrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all in invoiceAttachment slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected all in update all invoiceAttachment")
+	}
+	return rowsAff, nil
+}
+
+var mySQLInvoiceAttachmentUniqueColumns = []string{
+	"id",
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *InvoiceAttachment) Upsert(ctx context.Context, exec boil.ContextExecutor, updateColumns, insertColumns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no invoice_attachments provided for upsert")
+	}
+
+	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(invoiceAttachmentColumnsWithDefault, o)
+	nzUniques := queries.NonZeroDefaultSet(mySQLInvoiceAttachmentUniqueColumns, o)
+
+	if len(nzUniques) == 0 {
+		return errors.New("cannot upsert with a table that cannot conflict on a unique column")
+	}
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzUniques {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	invoiceAttachmentUpsertCacheMut.RLock()
+	cache, cached := invoiceAttachmentUpsertCache[key]
+	invoiceAttachmentUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, _ := insertColumns.InsertColumnSet(
+			invoiceAttachmentAllColumns,
+			invoiceAttachmentColumnsWithDefault,
+			invoiceAttachmentColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		update := updateColumns.UpdateColumnSet(
+			invoiceAttachmentAllColumns,
+			invoiceAttachmentPrimaryKeyColumns,
+		)
+
+		if !updateColumns.IsNone() && len(update) == 0 {
+			return errors.New("models: unable to upsert invoice_attachments, could not build update column list")
+		}
+
+		ret := strmangle.SetComplement(invoiceAttachmentAllColumns, strmangle.SetIntersect(insert, update))
+
+		cache.query = buildUpsertQueryMySQL(dialect, "`invoice_attachments`", update, insert)
+		cache.retQuery = fmt.Sprintf(
+			"SELECT %s FROM `invoice_attachments` WHERE %s",
+			strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, ret), ","),
+			strmangle.WhereClause("`", "`", 0, nzUniques),
+		)
+
+		cache.valueMapping, err = queries.BindMapping(invoiceAttachmentType, invoiceAttachmentMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(invoiceAttachmentType, invoiceAttachmentMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	result, err := exec.ExecContext(ctx, cache.query, vals...)
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert for invoice_attachments")
+	}
+
+	var lastID int64
+	var uniqueMap []uint64
+	var nzUniqueCols []interface{}
+
+	if len(cache.retMapping) == 0 {
+		goto CacheNoHooks
+	}
+
+	lastID, err = result.LastInsertId()
+	if err != nil {
+		return ErrSyncFail
+	}
+
+	o.ID = int64(lastID)
+	if lastID != 0 && len(cache.retMapping) == 1 && cache.retMapping[0] == invoiceAttachmentMapping["id"] {
+		goto CacheNoHooks
+	}
+
+	uniqueMap, err = queries.BindMapping(invoiceAttachmentType, invoiceAttachmentMapping, nzUniques)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to retrieve unique values for invoice_attachments")
+	}
+	nzUniqueCols = queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), uniqueMap)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.retQuery)
+		fmt.Fprintln(writer, nzUniqueCols...)
+	}
+	err = exec.QueryRowContext(ctx, cache.retQuery, nzUniqueCols...).Scan(returns...)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to populate default values for invoice_attachments")
+	}
+
+CacheNoHooks:
+	if !cached {
+		invoiceAttachmentUpsertCacheMut.Lock()
+		invoiceAttachmentUpsertCache[key] = cache
+		invoiceAttachmentUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(ctx, exec)
+}
+
+// Delete deletes a single InvoiceAttachment record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *InvoiceAttachment) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("models: no InvoiceAttachment provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), invoiceAttachmentPrimaryKeyMapping)
+	sql := "DELETE FROM `invoice_attachments` WHERE `id`=?"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete from invoice_attachments")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by delete for invoice_attachments")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q invoiceAttachmentQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("models: no invoiceAttachmentQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from invoice_attachments")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for invoice_attachments")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o InvoiceAttachmentSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(invoiceAttachmentBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), invoiceAttachmentPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM `invoice_attachments` WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, invoiceAttachmentPrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from invoiceAttachment slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for invoice_attachments")
+	}
+
+	if len(invoiceAttachmentAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *InvoiceAttachment) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindInvoiceAttachment(ctx, exec, o.ID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *InvoiceAttachmentSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := InvoiceAttachmentSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), invoiceAttachmentPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT `invoice_attachments`.* FROM `invoice_attachments` WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, invoiceAttachmentPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in InvoiceAttachmentSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// InvoiceAttachmentExists checks if the InvoiceAttachment row exists.
+func InvoiceAttachmentExists(ctx context.Context, exec boil.ContextExecutor, iD int64) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from `invoice_attachments` where `id`=? limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, iD)
+	}
+	row := exec.QueryRowContext(ctx, sql, iD)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if invoice_attachments exists")
+	}
+
+	return exists, nil
+}
+
+// Exists checks if the InvoiceAttachment row exists.
+func (o *InvoiceAttachment) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	return InvoiceAttachmentExists(ctx, exec, o.ID)
+}
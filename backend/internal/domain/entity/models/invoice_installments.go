@@ -0,0 +1,1217 @@
+// Code generated by SQLBoiler 4.16.2 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/sqlboiler/v4/types"
+	"github.com/volatiletech/strmangle"
+)
+
+// InvoiceInstallment is an object representing the database table.
+type InvoiceInstallment struct {
+	ID            int64         `boil:"id" json:"id" toml:"id" yaml:"id"`
+	InvoiceID     int64         `boil:"invoice_id" json:"invoice_id" toml:"invoice_id" yaml:"invoice_id"`
+	InstallmentNo int           `boil:"installment_no" json:"installment_no" toml:"installment_no" yaml:"installment_no"`
+	DueDate       time.Time     `boil:"due_date" json:"due_date" toml:"due_date" yaml:"due_date"`
+	Amount        types.Decimal `boil:"amount" json:"amount" toml:"amount" yaml:"amount"`
+
+	R *invoiceInstallmentR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L invoiceInstallmentL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var InvoiceInstallmentColumns = struct {
+	ID            string
+	InvoiceID     string
+	InstallmentNo string
+	DueDate       string
+	Amount        string
+}{
+	ID:            "id",
+	InvoiceID:     "invoice_id",
+	InstallmentNo: "installment_no",
+	DueDate:       "due_date",
+	Amount:        "amount",
+}
+
+var InvoiceInstallmentTableColumns = struct {
+	ID            string
+	InvoiceID     string
+	InstallmentNo string
+	DueDate       string
+	Amount        string
+}{
+	ID:            "invoice_installments.id",
+	InvoiceID:     "invoice_installments.invoice_id",
+	InstallmentNo: "invoice_installments.installment_no",
+	DueDate:       "invoice_installments.due_date",
+	Amount:        "invoice_installments.amount",
+}
+
+// Generated where
+
+type whereHelperint struct{ field string }
+
+func (w whereHelperint) EQ(x int) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
+func (w whereHelperint) NEQ(x int) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
+func (w whereHelperint) LT(x int) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.LT, x) }
+func (w whereHelperint) LTE(x int) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LTE, x) }
+func (w whereHelperint) GT(x int) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
+func (w whereHelperint) GTE(x int) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
+func (w whereHelperint) IN(slice []int) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
+}
+func (w whereHelperint) NIN(slice []int) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
+}
+
+var InvoiceInstallmentWhere = struct {
+	ID            whereHelperint64
+	InvoiceID     whereHelperint64
+	InstallmentNo whereHelperint
+	DueDate       whereHelpertime_Time
+	Amount        whereHelpertypes_Decimal
+}{
+	ID:            whereHelperint64{field: "`invoice_installments`.`id`"},
+	InvoiceID:     whereHelperint64{field: "`invoice_installments`.`invoice_id`"},
+	InstallmentNo: whereHelperint{field: "`invoice_installments`.`installment_no`"},
+	DueDate:       whereHelpertime_Time{field: "`invoice_installments`.`due_date`"},
+	Amount:        whereHelpertypes_Decimal{field: "`invoice_installments`.`amount`"},
+}
+
+// InvoiceInstallmentRels is where relationship names are stored.
+var InvoiceInstallmentRels = struct {
+	Invoice string
+}{
+	Invoice: "Invoice",
+}
+
+// invoiceInstallmentR is where relationships are stored.
+type invoiceInstallmentR struct {
+	Invoice *Invoice `boil:"Invoice" json:"Invoice" toml:"Invoice" yaml:"Invoice"`
+}
+
+// NewStruct creates a new relationship struct
+func (*invoiceInstallmentR) NewStruct() *invoiceInstallmentR {
+	return &invoiceInstallmentR{}
+}
+
+func (r *invoiceInstallmentR) GetInvoice() *Invoice {
+	if r == nil {
+		return nil
+	}
+	return r.Invoice
+}
+
+// invoiceInstallmentL is where Load methods for each relationship are stored.
+type invoiceInstallmentL struct{}
+
+var (
+	invoiceInstallmentAllColumns            = []string{"id", "invoice_id", "installment_no", "due_date", "amount"}
+	invoiceInstallmentColumnsWithoutDefault = []string{"invoice_id", "installment_no", "due_date", "amount"}
+	invoiceInstallmentColumnsWithDefault    = []string{"id"}
+	invoiceInstallmentPrimaryKeyColumns     = []string{"id"}
+	invoiceInstallmentGeneratedColumns      = []string{}
+)
+
+type (
+	// InvoiceInstallmentSlice is an alias for a slice of pointers to InvoiceInstallment.
+	// This should almost always be used instead of []InvoiceInstallment.
+	InvoiceInstallmentSlice []*InvoiceInstallment
+	// InvoiceInstallmentHook is the signature for custom InvoiceInstallment hook methods
+	InvoiceInstallmentHook func(context.Context, boil.ContextExecutor, *InvoiceInstallment) error
+
+	invoiceInstallmentQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	invoiceInstallmentType                 = reflect.TypeOf(&InvoiceInstallment{})
+	invoiceInstallmentMapping              = queries.MakeStructMapping(invoiceInstallmentType)
+	invoiceInstallmentPrimaryKeyMapping, _ = queries.BindMapping(invoiceInstallmentType, invoiceInstallmentMapping, invoiceInstallmentPrimaryKeyColumns)
+	invoiceInstallmentInsertCacheMut       sync.RWMutex
+	invoiceInstallmentInsertCache          = make(map[string]insertCache)
+	invoiceInstallmentUpdateCacheMut       sync.RWMutex
+	invoiceInstallmentUpdateCache          = make(map[string]updateCache)
+	invoiceInstallmentUpsertCacheMut       sync.RWMutex
+	invoiceInstallmentUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var invoiceInstallmentAfterSelectMu sync.Mutex
+var invoiceInstallmentAfterSelectHooks []InvoiceInstallmentHook
+
+var invoiceInstallmentBeforeInsertMu sync.Mutex
+var invoiceInstallmentBeforeInsertHooks []InvoiceInstallmentHook
+var invoiceInstallmentAfterInsertMu sync.Mutex
+var invoiceInstallmentAfterInsertHooks []InvoiceInstallmentHook
+
+var invoiceInstallmentBeforeUpdateMu sync.Mutex
+var invoiceInstallmentBeforeUpdateHooks []InvoiceInstallmentHook
+var invoiceInstallmentAfterUpdateMu sync.Mutex
+var invoiceInstallmentAfterUpdateHooks []InvoiceInstallmentHook
+
+var invoiceInstallmentBeforeDeleteMu sync.Mutex
+var invoiceInstallmentBeforeDeleteHooks []InvoiceInstallmentHook
+var invoiceInstallmentAfterDeleteMu sync.Mutex
+var invoiceInstallmentAfterDeleteHooks []InvoiceInstallmentHook
+
+var invoiceInstallmentBeforeUpsertMu sync.Mutex
+var invoiceInstallmentBeforeUpsertHooks []InvoiceInstallmentHook
+var invoiceInstallmentAfterUpsertMu sync.Mutex
+var invoiceInstallmentAfterUpsertHooks []InvoiceInstallmentHook
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *InvoiceInstallment) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range invoiceInstallmentAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *InvoiceInstallment) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range invoiceInstallmentBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *InvoiceInstallment) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range invoiceInstallmentAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *InvoiceInstallment) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range invoiceInstallmentBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *InvoiceInstallment) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range invoiceInstallmentAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *InvoiceInstallment) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range invoiceInstallmentBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *InvoiceInstallment) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range invoiceInstallmentAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *InvoiceInstallment) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range invoiceInstallmentBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *InvoiceInstallment) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range invoiceInstallmentAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddInvoiceInstallmentHook registers your hook function for all future operations.
+func AddInvoiceInstallmentHook(hookPoint boil.HookPoint, invoiceInstallmentHook InvoiceInstallmentHook) {
+	switch hookPoint {
+	case boil.AfterSelectHook:
+		invoiceInstallmentAfterSelectMu.Lock()
+		invoiceInstallmentAfterSelectHooks = append(invoiceInstallmentAfterSelectHooks, invoiceInstallmentHook)
+		invoiceInstallmentAfterSelectMu.Unlock()
+	case boil.BeforeInsertHook:
+		invoiceInstallmentBeforeInsertMu.Lock()
+		invoiceInstallmentBeforeInsertHooks = append(invoiceInstallmentBeforeInsertHooks, invoiceInstallmentHook)
+		invoiceInstallmentBeforeInsertMu.Unlock()
+	case boil.AfterInsertHook:
+		invoiceInstallmentAfterInsertMu.Lock()
+		invoiceInstallmentAfterInsertHooks = append(invoiceInstallmentAfterInsertHooks, invoiceInstallmentHook)
+		invoiceInstallmentAfterInsertMu.Unlock()
+	case boil.BeforeUpdateHook:
+		invoiceInstallmentBeforeUpdateMu.Lock()
+		invoiceInstallmentBeforeUpdateHooks = append(invoiceInstallmentBeforeUpdateHooks, invoiceInstallmentHook)
+		invoiceInstallmentBeforeUpdateMu.Unlock()
+	case boil.AfterUpdateHook:
+		invoiceInstallmentAfterUpdateMu.Lock()
+		invoiceInstallmentAfterUpdateHooks = append(invoiceInstallmentAfterUpdateHooks, invoiceInstallmentHook)
+		invoiceInstallmentAfterUpdateMu.Unlock()
+	case boil.BeforeDeleteHook:
+		invoiceInstallmentBeforeDeleteMu.Lock()
+		invoiceInstallmentBeforeDeleteHooks = append(invoiceInstallmentBeforeDeleteHooks, invoiceInstallmentHook)
+		invoiceInstallmentBeforeDeleteMu.Unlock()
+	case boil.AfterDeleteHook:
+		invoiceInstallmentAfterDeleteMu.Lock()
+		invoiceInstallmentAfterDeleteHooks = append(invoiceInstallmentAfterDeleteHooks, invoiceInstallmentHook)
+		invoiceInstallmentAfterDeleteMu.Unlock()
+	case boil.BeforeUpsertHook:
+		invoiceInstallmentBeforeUpsertMu.Lock()
+		invoiceInstallmentBeforeUpsertHooks = append(invoiceInstallmentBeforeUpsertHooks, invoiceInstallmentHook)
+		invoiceInstallmentBeforeUpsertMu.Unlock()
+	case boil.AfterUpsertHook:
+		invoiceInstallmentAfterUpsertMu.Lock()
+		invoiceInstallmentAfterUpsertHooks = append(invoiceInstallmentAfterUpsertHooks, invoiceInstallmentHook)
+		invoiceInstallmentAfterUpsertMu.Unlock()
+	}
+}
+
+// One returns a single invoiceInstallment record from the query.
+func (q invoiceInstallmentQuery) One(ctx context.Context, exec boil.ContextExecutor) (*InvoiceInstallment, error) {
+	o := &InvoiceInstallment{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for invoice_installments")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all InvoiceInstallment records from the query.
+func (q invoiceInstallmentQuery) All(ctx context.Context, exec boil.ContextExecutor) (InvoiceInstallmentSlice, error) {
+	var o []*InvoiceInstallment
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to InvoiceInstallment slice")
+	}
+
+	if len(invoiceInstallmentAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all InvoiceInstallment records in the query.
+func (q invoiceInstallmentQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count invoice_installments rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q invoiceInstallmentQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if invoice_installments exists")
+	}
+
+	return count > 0, nil
+}
+
+// Invoice pointed to by the foreign key.
+func (o *InvoiceInstallment) Invoice(mods ...qm.QueryMod) invoiceQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("`id` = ?", o.InvoiceID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	return Invoices(queryMods...)
+}
+
+// LoadInvoice allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (invoiceInstallmentL) LoadInvoice(ctx context.Context, e boil.ContextExecutor, singular bool, maybeInvoiceInstallment interface{}, mods queries.Applicator) error {
+	var slice []*InvoiceInstallment
+	var object *InvoiceInstallment
+
+	if singular {
+		var ok bool
+		object, ok = maybeInvoiceInstallment.(*InvoiceInstallment)
+		if !ok {
+			object = new(InvoiceInstallment)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeInvoiceInstallment)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeInvoiceInstallment))
+			}
+		}
+	} else {
+		s, ok := maybeInvoiceInstallment.(*[]*InvoiceInstallment)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeInvoiceInstallment)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeInvoiceInstallment))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &invoiceInstallmentR{}
+		}
+		args[object.InvoiceID] = struct{}{}
+
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &invoiceInstallmentR{}
+			}
+
+			args[obj.InvoiceID] = struct{}{}
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`invoices`),
+		qm.WhereIn(`invoices.id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load Invoice")
+	}
+
+	var resultSlice []*Invoice
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice Invoice")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for invoices")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for invoices")
+	}
+
+	if len(invoiceAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.Invoice = foreign
+		if foreign.R == nil {
+			foreign.R = &invoiceR{}
+		}
+		foreign.R.InvoiceInstallments = append(foreign.R.InvoiceInstallments, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.InvoiceID == foreign.ID {
+				local.R.Invoice = foreign
+				if foreign.R == nil {
+					foreign.R = &invoiceR{}
+				}
+				foreign.R.InvoiceInstallments = append(foreign.R.InvoiceInstallments, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetInvoice of the invoiceInstallment to the related item.
+// Sets o.R.Invoice to related.
+// Adds o to related.R.InvoiceInstallments.
+func (o *InvoiceInstallment) SetInvoice(ctx context.Context, exec boil.ContextExecutor, insert bool, related *Invoice) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE `invoice_installments` SET %s WHERE %s",
+		strmangle.SetParamNames("`", "`", 0, []string{"invoice_id"}),
+		strmangle.WhereClause("`", "`", 0, invoiceInstallmentPrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.InvoiceID = related.ID
+	if o.R == nil {
+		o.R = &invoiceInstallmentR{
+			Invoice: related,
+		}
+	} else {
+		o.R.Invoice = related
+	}
+
+	if related.R == nil {
+		related.R = &invoiceR{
+			InvoiceInstallments: InvoiceInstallmentSlice{o},
+		}
+	} else {
+		related.R.InvoiceInstallments = append(related.R.InvoiceInstallments, o)
+	}
+
+	return nil
+}
+
+// InvoiceInstallments retrieves all the records using an executor.
+func InvoiceInstallments(mods ...qm.QueryMod) invoiceInstallmentQuery {
+	mods = append(mods, qm.From("`invoice_installments`"))
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"`invoice_installments`.*"})
+	}
+
+	return invoiceInstallmentQuery{q}
+}
+
+// FindInvoiceInstallment retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindInvoiceInstallment(ctx context.Context, exec boil.ContextExecutor, iD int64, selectCols ...string) (*InvoiceInstallment, error) {
+	invoiceInstallmentObj := &InvoiceInstallment{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from `invoice_installments` where `id`=?", sel,
+	)
+
+	q := queries.Raw(query, iD)
+
+	err := q.Bind(ctx, exec, invoiceInstallmentObj)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from invoice_installments")
+	}
+
+	if err = invoiceInstallmentObj.doAfterSelectHooks(ctx, exec); err != nil {
+		return invoiceInstallmentObj, err
+	}
+
+	return invoiceInstallmentObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *InvoiceInstallment) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no invoice_installments provided for insertion")
+	}
+
+	var err error
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(invoiceInstallmentColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	invoiceInstallmentInsertCacheMut.RLock()
+	cache, cached := invoiceInstallmentInsertCache[key]
+	invoiceInstallmentInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			invoiceInstallmentAllColumns,
+			invoiceInstallmentColumnsWithDefault,
+			invoiceInstallmentColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(invoiceInstallmentType, invoiceInstallmentMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(invoiceInstallmentType, invoiceInstallmentMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO `invoice_installments` (`%s`) %%sVALUES (%s)%%s", strings.Join(wl, "`,`"), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO `invoice_installments` () VALUES ()%s%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			cache.retQuery = fmt.Sprintf("SELECT `%s` FROM `invoice_installments` WHERE %s", strings.Join(returnColumns, "`,`"), strmangle.WhereClause("`", "`", 0, invoiceInstallmentPrimaryKeyColumns))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	result, err := exec.ExecContext(ctx, cache.query, vals...)
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into invoice_installments")
+	}
+
+	var lastID int64
+	var identifierCols []interface{}
+
+	if len(cache.retMapping) == 0 {
+		goto CacheNoHooks
+	}
+
+	lastID, err = result.LastInsertId()
+	if err != nil {
+		return ErrSyncFail
+	}
+
+	o.ID = int64(lastID)
+	if lastID != 0 && len(cache.retMapping) == 1 && cache.retMapping[0] == invoiceInstallmentMapping["id"] {
+		goto CacheNoHooks
+	}
+
+	identifierCols = []interface{}{
+		o.ID,
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.retQuery)
+		fmt.Fprintln(writer, identifierCols...)
+	}
+	err = exec.QueryRowContext(ctx, cache.retQuery, identifierCols...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to populate default values for invoice_installments")
+	}
+
+CacheNoHooks:
+	if !cached {
+		invoiceInstallmentInsertCacheMut.Lock()
+		invoiceInstallmentInsertCache[key] = cache
+		invoiceInstallmentInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// Update uses an executor to update the InvoiceInstallment.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *InvoiceInstallment) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	invoiceInstallmentUpdateCacheMut.RLock()
+	cache, cached := invoiceInstallmentUpdateCache[key]
+	invoiceInstallmentUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			invoiceInstallmentAllColumns,
+			invoiceInstallmentPrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("models: unable to update invoice_installments, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE `invoice_installments` SET %s WHERE %s",
+			strmangle.SetParamNames("`", "`", 0, wl),
+			strmangle.WhereClause("`", "`", 0, invoiceInstallmentPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(invoiceInstallmentType, invoiceInstallmentMapping, append(wl, invoiceInstallmentPrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update invoice_installments row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by update for invoice_installments")
+	}
+
+	if !cached {
+		invoiceInstallmentUpdateCacheMut.Lock()
+		invoiceInstallmentUpdateCache[key] = cache
+		invoiceInstallmentUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q invoiceInstallmentQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all for invoice_installments")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected for invoice_installments")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o InvoiceInstallmentSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), invoiceInstallmentPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE `invoice_installments` SET %s WHERE %s",
+		strmangle.SetParamNames("`", "`", 0, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, invoiceInstallmentPrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all in invoiceInstallment slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected all in update all invoiceInstallment")
+	}
+	return rowsAff, nil
+}
+
+var mySQLInvoiceInstallmentUniqueColumns = []string{
+	"id",
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *InvoiceInstallment) Upsert(ctx context.Context, exec boil.ContextExecutor, updateColumns, insertColumns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no invoice_installments provided for upsert")
+	}
+
+	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(invoiceInstallmentColumnsWithDefault, o)
+	nzUniques := queries.NonZeroDefaultSet(mySQLInvoiceInstallmentUniqueColumns, o)
+
+	if len(nzUniques) == 0 {
+		return errors.New("cannot upsert with a table that cannot conflict on a unique column")
+	}
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzUniques {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	invoiceInstallmentUpsertCacheMut.RLock()
+	cache, cached := invoiceInstallmentUpsertCache[key]
+	invoiceInstallmentUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, _ := insertColumns.InsertColumnSet(
+			invoiceInstallmentAllColumns,
+			invoiceInstallmentColumnsWithDefault,
+			invoiceInstallmentColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		update := updateColumns.UpdateColumnSet(
+			invoiceInstallmentAllColumns,
+			invoiceInstallmentPrimaryKeyColumns,
+		)
+
+		if !updateColumns.IsNone() && len(update) == 0 {
+			return errors.New("models: unable to upsert invoice_installments, could not build update column list")
+		}
+
+		ret := strmangle.SetComplement(invoiceInstallmentAllColumns, strmangle.SetIntersect(insert, update))
+
+		cache.query = buildUpsertQueryMySQL(dialect, "`invoice_installments`", update, insert)
+		cache.retQuery = fmt.Sprintf(
+			"SELECT %s FROM `invoice_installments` WHERE %s",
+			strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, ret), ","),
+			strmangle.WhereClause("`", "`", 0, nzUniques),
+		)
+
+		cache.valueMapping, err = queries.BindMapping(invoiceInstallmentType, invoiceInstallmentMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(invoiceInstallmentType, invoiceInstallmentMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	result, err := exec.ExecContext(ctx, cache.query, vals...)
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert for invoice_installments")
+	}
+
+	var lastID int64
+	var uniqueMap []uint64
+	var nzUniqueCols []interface{}
+
+	if len(cache.retMapping) == 0 {
+		goto CacheNoHooks
+	}
+
+	lastID, err = result.LastInsertId()
+	if err != nil {
+		return ErrSyncFail
+	}
+
+	o.ID = int64(lastID)
+	if lastID != 0 && len(cache.retMapping) == 1 && cache.retMapping[0] == invoiceInstallmentMapping["id"] {
+		goto CacheNoHooks
+	}
+
+	uniqueMap, err = queries.BindMapping(invoiceInstallmentType, invoiceInstallmentMapping, nzUniques)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to retrieve unique values for invoice_installments")
+	}
+	nzUniqueCols = queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), uniqueMap)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.retQuery)
+		fmt.Fprintln(writer, nzUniqueCols...)
+	}
+	err = exec.QueryRowContext(ctx, cache.retQuery, nzUniqueCols...).Scan(returns...)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to populate default values for invoice_installments")
+	}
+
+CacheNoHooks:
+	if !cached {
+		invoiceInstallmentUpsertCacheMut.Lock()
+		invoiceInstallmentUpsertCache[key] = cache
+		invoiceInstallmentUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(ctx, exec)
+}
+
+// Delete deletes a single InvoiceInstallment record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *InvoiceInstallment) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("models: no InvoiceInstallment provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), invoiceInstallmentPrimaryKeyMapping)
+	sql := "DELETE FROM `invoice_installments` WHERE `id`=?"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete from invoice_installments")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by delete for invoice_installments")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q invoiceInstallmentQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("models: no invoiceInstallmentQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from invoice_installments")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for invoice_installments")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o InvoiceInstallmentSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(invoiceInstallmentBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), invoiceInstallmentPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM `invoice_installments` WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, invoiceInstallmentPrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from invoiceInstallment slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for invoice_installments")
+	}
+
+	if len(invoiceInstallmentAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *InvoiceInstallment) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindInvoiceInstallment(ctx, exec, o.ID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *InvoiceInstallmentSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := InvoiceInstallmentSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), invoiceInstallmentPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT `invoice_installments`.* FROM `invoice_installments` WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, invoiceInstallmentPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in InvoiceInstallmentSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// InvoiceInstallmentExists checks if the InvoiceInstallment row exists.
+func InvoiceInstallmentExists(ctx context.Context, exec boil.ContextExecutor, iD int64) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from `invoice_installments` where `id`=? limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, iD)
+	}
+	row := exec.QueryRowContext(ctx, sql, iD)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if invoice_installments exists")
+	}
+
+	return exists, nil
+}
+
+// Exists checks if the InvoiceInstallment row exists.
+func (o *InvoiceInstallment) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	return InvoiceInstallmentExists(ctx, exec, o.ID)
+}
@@ -0,0 +1,1037 @@
+// Code generated by SQLBoiler 4.16.2 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/strmangle"
+)
+
+// InvoiceIssuer is an object representing the database table.
+type InvoiceIssuer struct {
+	ID                 int64     `boil:"id" json:"id" toml:"id" yaml:"id"`
+	RegistrationNumber string    `boil:"registration_number" json:"registration_number" toml:"registration_number" yaml:"registration_number"`
+	Name               string    `boil:"name" json:"name" toml:"name" yaml:"name"`
+	RegisteredOn       time.Time `boil:"registered_on" json:"registered_on" toml:"registered_on" yaml:"registered_on"`
+	RevokedOn          null.Time `boil:"revoked_on" json:"revoked_on,omitempty" toml:"revoked_on" yaml:"revoked_on,omitempty"`
+	ExpiredOn          null.Time `boil:"expired_on" json:"expired_on,omitempty" toml:"expired_on" yaml:"expired_on,omitempty"`
+
+	R *invoiceIssuerR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L invoiceIssuerL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var InvoiceIssuerColumns = struct {
+	ID                 string
+	RegistrationNumber string
+	Name               string
+	RegisteredOn       string
+	RevokedOn          string
+	ExpiredOn          string
+}{
+	ID:                 "id",
+	RegistrationNumber: "registration_number",
+	Name:               "name",
+	RegisteredOn:       "registered_on",
+	RevokedOn:          "revoked_on",
+	ExpiredOn:          "expired_on",
+}
+
+var InvoiceIssuerTableColumns = struct {
+	ID                 string
+	RegistrationNumber string
+	Name               string
+	RegisteredOn       string
+	RevokedOn          string
+	ExpiredOn          string
+}{
+	ID:                 "invoice_issuers.id",
+	RegistrationNumber: "invoice_issuers.registration_number",
+	Name:               "invoice_issuers.name",
+	RegisteredOn:       "invoice_issuers.registered_on",
+	RevokedOn:          "invoice_issuers.revoked_on",
+	ExpiredOn:          "invoice_issuers.expired_on",
+}
+
+// Generated where
+
+type whereHelpernull_Time struct{ field string }
+
+func (w whereHelpernull_Time) EQ(x null.Time) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, false, x)
+}
+func (w whereHelpernull_Time) NEQ(x null.Time) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, true, x)
+}
+func (w whereHelpernull_Time) LT(x null.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelpernull_Time) LTE(x null.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelpernull_Time) GT(x null.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
+}
+func (w whereHelpernull_Time) GTE(x null.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+
+func (w whereHelpernull_Time) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
+func (w whereHelpernull_Time) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
+
+var InvoiceIssuerWhere = struct {
+	ID                 whereHelperint64
+	RegistrationNumber whereHelperstring
+	Name               whereHelperstring
+	RegisteredOn       whereHelpertime_Time
+	RevokedOn          whereHelpernull_Time
+	ExpiredOn          whereHelpernull_Time
+}{
+	ID:                 whereHelperint64{field: "`invoice_issuers`.`id`"},
+	RegistrationNumber: whereHelperstring{field: "`invoice_issuers`.`registration_number`"},
+	Name:               whereHelperstring{field: "`invoice_issuers`.`name`"},
+	RegisteredOn:       whereHelpertime_Time{field: "`invoice_issuers`.`registered_on`"},
+	RevokedOn:          whereHelpernull_Time{field: "`invoice_issuers`.`revoked_on`"},
+	ExpiredOn:          whereHelpernull_Time{field: "`invoice_issuers`.`expired_on`"},
+}
+
+// InvoiceIssuerRels is where relationship names are stored.
+var InvoiceIssuerRels = struct {
+}{}
+
+// invoiceIssuerR is where relationships are stored.
+type invoiceIssuerR struct {
+}
+
+// NewStruct creates a new relationship struct
+func (*invoiceIssuerR) NewStruct() *invoiceIssuerR {
+	return &invoiceIssuerR{}
+}
+
+// invoiceIssuerL is where Load methods for each relationship are stored.
+type invoiceIssuerL struct{}
+
+var (
+	invoiceIssuerAllColumns            = []string{"id", "registration_number", "name", "registered_on", "revoked_on", "expired_on"}
+	invoiceIssuerColumnsWithoutDefault = []string{"registration_number", "name", "registered_on", "revoked_on", "expired_on"}
+	invoiceIssuerColumnsWithDefault    = []string{"id"}
+	invoiceIssuerPrimaryKeyColumns     = []string{"id"}
+	invoiceIssuerGeneratedColumns      = []string{}
+)
+
+type (
+	// InvoiceIssuerSlice is an alias for a slice of pointers to InvoiceIssuer.
+	// This should almost always be used instead of []InvoiceIssuer.
+	InvoiceIssuerSlice []*InvoiceIssuer
+	// InvoiceIssuerHook is the signature for custom InvoiceIssuer hook methods
+	InvoiceIssuerHook func(context.Context, boil.ContextExecutor, *InvoiceIssuer) error
+
+	invoiceIssuerQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	invoiceIssuerType                 = reflect.TypeOf(&InvoiceIssuer{})
+	invoiceIssuerMapping              = queries.MakeStructMapping(invoiceIssuerType)
+	invoiceIssuerPrimaryKeyMapping, _ = queries.BindMapping(invoiceIssuerType, invoiceIssuerMapping, invoiceIssuerPrimaryKeyColumns)
+	invoiceIssuerInsertCacheMut       sync.RWMutex
+	invoiceIssuerInsertCache          = make(map[string]insertCache)
+	invoiceIssuerUpdateCacheMut       sync.RWMutex
+	invoiceIssuerUpdateCache          = make(map[string]updateCache)
+	invoiceIssuerUpsertCacheMut       sync.RWMutex
+	invoiceIssuerUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var invoiceIssuerAfterSelectMu sync.Mutex
+var invoiceIssuerAfterSelectHooks []InvoiceIssuerHook
+
+var invoiceIssuerBeforeInsertMu sync.Mutex
+var invoiceIssuerBeforeInsertHooks []InvoiceIssuerHook
+var invoiceIssuerAfterInsertMu sync.Mutex
+var invoiceIssuerAfterInsertHooks []InvoiceIssuerHook
+
+var invoiceIssuerBeforeUpdateMu sync.Mutex
+var invoiceIssuerBeforeUpdateHooks []InvoiceIssuerHook
+var invoiceIssuerAfterUpdateMu sync.Mutex
+var invoiceIssuerAfterUpdateHooks []InvoiceIssuerHook
+
+var invoiceIssuerBeforeDeleteMu sync.Mutex
+var invoiceIssuerBeforeDeleteHooks []InvoiceIssuerHook
+var invoiceIssuerAfterDeleteMu sync.Mutex
+var invoiceIssuerAfterDeleteHooks []InvoiceIssuerHook
+
+var invoiceIssuerBeforeUpsertMu sync.Mutex
+var invoiceIssuerBeforeUpsertHooks []InvoiceIssuerHook
+var invoiceIssuerAfterUpsertMu sync.Mutex
+var invoiceIssuerAfterUpsertHooks []InvoiceIssuerHook
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *InvoiceIssuer) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range invoiceIssuerAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *InvoiceIssuer) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range invoiceIssuerBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *InvoiceIssuer) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range invoiceIssuerAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *InvoiceIssuer) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range invoiceIssuerBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *InvoiceIssuer) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range invoiceIssuerAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *InvoiceIssuer) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range invoiceIssuerBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *InvoiceIssuer) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range invoiceIssuerAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *InvoiceIssuer) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range invoiceIssuerBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *InvoiceIssuer) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range invoiceIssuerAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddInvoiceIssuerHook registers your hook function for all future operations.
+func AddInvoiceIssuerHook(hookPoint boil.HookPoint, invoiceIssuerHook InvoiceIssuerHook) {
+	switch hookPoint {
+	case boil.AfterSelectHook:
+		invoiceIssuerAfterSelectMu.Lock()
+		invoiceIssuerAfterSelectHooks = append(invoiceIssuerAfterSelectHooks, invoiceIssuerHook)
+		invoiceIssuerAfterSelectMu.Unlock()
+	case boil.BeforeInsertHook:
+		invoiceIssuerBeforeInsertMu.Lock()
+		invoiceIssuerBeforeInsertHooks = append(invoiceIssuerBeforeInsertHooks, invoiceIssuerHook)
+		invoiceIssuerBeforeInsertMu.Unlock()
+	case boil.AfterInsertHook:
+		invoiceIssuerAfterInsertMu.Lock()
+		invoiceIssuerAfterInsertHooks = append(invoiceIssuerAfterInsertHooks, invoiceIssuerHook)
+		invoiceIssuerAfterInsertMu.Unlock()
+	case boil.BeforeUpdateHook:
+		invoiceIssuerBeforeUpdateMu.Lock()
+		invoiceIssuerBeforeUpdateHooks = append(invoiceIssuerBeforeUpdateHooks, invoiceIssuerHook)
+		invoiceIssuerBeforeUpdateMu.Unlock()
+	case boil.AfterUpdateHook:
+		invoiceIssuerAfterUpdateMu.Lock()
+		invoiceIssuerAfterUpdateHooks = append(invoiceIssuerAfterUpdateHooks, invoiceIssuerHook)
+		invoiceIssuerAfterUpdateMu.Unlock()
+	case boil.BeforeDeleteHook:
+		invoiceIssuerBeforeDeleteMu.Lock()
+		invoiceIssuerBeforeDeleteHooks = append(invoiceIssuerBeforeDeleteHooks, invoiceIssuerHook)
+		invoiceIssuerBeforeDeleteMu.Unlock()
+	case boil.AfterDeleteHook:
+		invoiceIssuerAfterDeleteMu.Lock()
+		invoiceIssuerAfterDeleteHooks = append(invoiceIssuerAfterDeleteHooks, invoiceIssuerHook)
+		invoiceIssuerAfterDeleteMu.Unlock()
+	case boil.BeforeUpsertHook:
+		invoiceIssuerBeforeUpsertMu.Lock()
+		invoiceIssuerBeforeUpsertHooks = append(invoiceIssuerBeforeUpsertHooks, invoiceIssuerHook)
+		invoiceIssuerBeforeUpsertMu.Unlock()
+	case boil.AfterUpsertHook:
+		invoiceIssuerAfterUpsertMu.Lock()
+		invoiceIssuerAfterUpsertHooks = append(invoiceIssuerAfterUpsertHooks, invoiceIssuerHook)
+		invoiceIssuerAfterUpsertMu.Unlock()
+	}
+}
+
+// One returns a single invoiceIssuer record from the query.
+func (q invoiceIssuerQuery) One(ctx context.Context, exec boil.ContextExecutor) (*InvoiceIssuer, error) {
+	o := &InvoiceIssuer{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for invoice_issuers")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all InvoiceIssuer records from the query.
+func (q invoiceIssuerQuery) All(ctx context.Context, exec boil.ContextExecutor) (InvoiceIssuerSlice, error) {
+	var o []*InvoiceIssuer
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to InvoiceIssuer slice")
+	}
+
+	if len(invoiceIssuerAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all InvoiceIssuer records in the query.
+func (q invoiceIssuerQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count invoice_issuers rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q invoiceIssuerQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if invoice_issuers exists")
+	}
+
+	return count > 0, nil
+}
+
+// InvoiceIssuers retrieves all the records using an executor.
+func InvoiceIssuers(mods ...qm.QueryMod) invoiceIssuerQuery {
+	mods = append(mods, qm.From("`invoice_issuers`"))
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"`invoice_issuers`.*"})
+	}
+
+	return invoiceIssuerQuery{q}
+}
+
+// FindInvoiceIssuer retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindInvoiceIssuer(ctx context.Context, exec boil.ContextExecutor, iD int64, selectCols ...string) (*InvoiceIssuer, error) {
+	invoiceIssuerObj := &InvoiceIssuer{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from `invoice_issuers` where `id`=?", sel,
+	)
+
+	q := queries.Raw(query, iD)
+
+	err := q.Bind(ctx, exec, invoiceIssuerObj)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from invoice_issuers")
+	}
+
+	if err = invoiceIssuerObj.doAfterSelectHooks(ctx, exec); err != nil {
+		return invoiceIssuerObj, err
+	}
+
+	return invoiceIssuerObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *InvoiceIssuer) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no invoice_issuers provided for insertion")
+	}
+
+	var err error
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(invoiceIssuerColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	invoiceIssuerInsertCacheMut.RLock()
+	cache, cached := invoiceIssuerInsertCache[key]
+	invoiceIssuerInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			invoiceIssuerAllColumns,
+			invoiceIssuerColumnsWithDefault,
+			invoiceIssuerColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(invoiceIssuerType, invoiceIssuerMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(invoiceIssuerType, invoiceIssuerMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO `invoice_issuers` (`%s`) %%sVALUES (%s)%%s", strings.Join(wl, "`,`"), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO `invoice_issuers` () VALUES ()%s%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			cache.retQuery = fmt.Sprintf("SELECT `%s` FROM `invoice_issuers` WHERE %s", strings.Join(returnColumns, "`,`"), strmangle.WhereClause("`", "`", 0, invoiceIssuerPrimaryKeyColumns))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	result, err := exec.ExecContext(ctx, cache.query, vals...)
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into invoice_issuers")
+	}
+
+	var lastID int64
+	var identifierCols []interface{}
+
+	if len(cache.retMapping) == 0 {
+		goto CacheNoHooks
+	}
+
+	lastID, err = result.LastInsertId()
+	if err != nil {
+		return ErrSyncFail
+	}
+
+	o.ID = int64(lastID)
+	if lastID != 0 && len(cache.retMapping) == 1 && cache.retMapping[0] == invoiceIssuerMapping["id"] {
+		goto CacheNoHooks
+	}
+
+	identifierCols = []interface{}{
+		o.ID,
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.retQuery)
+		fmt.Fprintln(writer, identifierCols...)
+	}
+	err = exec.QueryRowContext(ctx, cache.retQuery, identifierCols...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to populate default values for invoice_issuers")
+	}
+
+CacheNoHooks:
+	if !cached {
+		invoiceIssuerInsertCacheMut.Lock()
+		invoiceIssuerInsertCache[key] = cache
+		invoiceIssuerInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// Update uses an executor to update the InvoiceIssuer.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *InvoiceIssuer) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	invoiceIssuerUpdateCacheMut.RLock()
+	cache, cached := invoiceIssuerUpdateCache[key]
+	invoiceIssuerUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			invoiceIssuerAllColumns,
+			invoiceIssuerPrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("models: unable to update invoice_issuers, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE `invoice_issuers` SET %s WHERE %s",
+			strmangle.SetParamNames("`", "`", 0, wl),
+			strmangle.WhereClause("`", "`", 0, invoiceIssuerPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(invoiceIssuerType, invoiceIssuerMapping, append(wl, invoiceIssuerPrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update invoice_issuers row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by update for invoice_issuers")
+	}
+
+	if !cached {
+		invoiceIssuerUpdateCacheMut.Lock()
+		invoiceIssuerUpdateCache[key] = cache
+		invoiceIssuerUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q invoiceIssuerQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all for invoice_issuers")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected for invoice_issuers")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o InvoiceIssuerSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), invoiceIssuerPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE `invoice_issuers` SET %s WHERE %s",
+		strmangle.SetParamNames("`", "`", 0, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, invoiceIssuerPrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all in invoiceIssuer slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected all in update all invoiceIssuer")
+	}
+	return rowsAff, nil
+}
+
+var mySQLInvoiceIssuerUniqueColumns = []string{
+	"id",
+	"registration_number",
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *InvoiceIssuer) Upsert(ctx context.Context, exec boil.ContextExecutor, updateColumns, insertColumns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no invoice_issuers provided for upsert")
+	}
+
+	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(invoiceIssuerColumnsWithDefault, o)
+	nzUniques := queries.NonZeroDefaultSet(mySQLInvoiceIssuerUniqueColumns, o)
+
+	if len(nzUniques) == 0 {
+		return errors.New("cannot upsert with a table that cannot conflict on a unique column")
+	}
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzUniques {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	invoiceIssuerUpsertCacheMut.RLock()
+	cache, cached := invoiceIssuerUpsertCache[key]
+	invoiceIssuerUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, _ := insertColumns.InsertColumnSet(
+			invoiceIssuerAllColumns,
+			invoiceIssuerColumnsWithDefault,
+			invoiceIssuerColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		update := updateColumns.UpdateColumnSet(
+			invoiceIssuerAllColumns,
+			invoiceIssuerPrimaryKeyColumns,
+		)
+
+		if !updateColumns.IsNone() && len(update) == 0 {
+			return errors.New("models: unable to upsert invoice_issuers, could not build update column list")
+		}
+
+		ret := strmangle.SetComplement(invoiceIssuerAllColumns, strmangle.SetIntersect(insert, update))
+
+		cache.query = buildUpsertQueryMySQL(dialect, "`invoice_issuers`", update, insert)
+		cache.retQuery = fmt.Sprintf(
+			"SELECT %s FROM `invoice_issuers` WHERE %s",
+			strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, ret), ","),
+			strmangle.WhereClause("`", "`", 0, nzUniques),
+		)
+
+		cache.valueMapping, err = queries.BindMapping(invoiceIssuerType, invoiceIssuerMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(invoiceIssuerType, invoiceIssuerMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	result, err := exec.ExecContext(ctx, cache.query, vals...)
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert for invoice_issuers")
+	}
+
+	var lastID int64
+	var uniqueMap []uint64
+	var nzUniqueCols []interface{}
+
+	if len(cache.retMapping) == 0 {
+		goto CacheNoHooks
+	}
+
+	lastID, err = result.LastInsertId()
+	if err != nil {
+		return ErrSyncFail
+	}
+
+	o.ID = int64(lastID)
+	if lastID != 0 && len(cache.retMapping) == 1 && cache.retMapping[0] == invoiceIssuerMapping["id"] {
+		goto CacheNoHooks
+	}
+
+	uniqueMap, err = queries.BindMapping(invoiceIssuerType, invoiceIssuerMapping, nzUniques)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to retrieve unique values for invoice_issuers")
+	}
+	nzUniqueCols = queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), uniqueMap)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.retQuery)
+		fmt.Fprintln(writer, nzUniqueCols...)
+	}
+	err = exec.QueryRowContext(ctx, cache.retQuery, nzUniqueCols...).Scan(returns...)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to populate default values for invoice_issuers")
+	}
+
+CacheNoHooks:
+	if !cached {
+		invoiceIssuerUpsertCacheMut.Lock()
+		invoiceIssuerUpsertCache[key] = cache
+		invoiceIssuerUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(ctx, exec)
+}
+
+// Delete deletes a single InvoiceIssuer record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *InvoiceIssuer) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("models: no InvoiceIssuer provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), invoiceIssuerPrimaryKeyMapping)
+	sql := "DELETE FROM `invoice_issuers` WHERE `id`=?"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete from invoice_issuers")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by delete for invoice_issuers")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q invoiceIssuerQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("models: no invoiceIssuerQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from invoice_issuers")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for invoice_issuers")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o InvoiceIssuerSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(invoiceIssuerBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), invoiceIssuerPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM `invoice_issuers` WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, invoiceIssuerPrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from invoiceIssuer slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for invoice_issuers")
+	}
+
+	if len(invoiceIssuerAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *InvoiceIssuer) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindInvoiceIssuer(ctx, exec, o.ID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *InvoiceIssuerSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := InvoiceIssuerSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), invoiceIssuerPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT `invoice_issuers`.* FROM `invoice_issuers` WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, invoiceIssuerPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in InvoiceIssuerSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// InvoiceIssuerExists checks if the InvoiceIssuer row exists.
+func InvoiceIssuerExists(ctx context.Context, exec boil.ContextExecutor, iD int64) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from `invoice_issuers` where `id`=? limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, iD)
+	}
+	row := exec.QueryRowContext(ctx, sql, iD)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if invoice_issuers exists")
+	}
+
+	return exists, nil
+}
+
+// Exists checks if the InvoiceIssuer row exists.
+func (o *InvoiceIssuer) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	return InvoiceIssuerExists(ctx, exec, o.ID)
+}
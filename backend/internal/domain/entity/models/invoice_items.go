@@ -0,0 +1,1215 @@
+// Code generated by SQLBoiler 4.16.2 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/sqlboiler/v4/types"
+	"github.com/volatiletech/strmangle"
+)
+
+// InvoiceItem is an object representing the database table.
+type InvoiceItem struct {
+	ID          int64         `boil:"id" json:"id" toml:"id" yaml:"id"`
+	InvoiceID   int64         `boil:"invoice_id" json:"invoice_id" toml:"invoice_id" yaml:"invoice_id"`
+	LineNo      int           `boil:"line_no" json:"line_no" toml:"line_no" yaml:"line_no"`
+	Description string        `boil:"description" json:"description" toml:"description" yaml:"description"`
+	Quantity    types.Decimal `boil:"quantity" json:"quantity" toml:"quantity" yaml:"quantity"`
+	UnitPrice   types.Decimal `boil:"unit_price" json:"unit_price" toml:"unit_price" yaml:"unit_price"`
+	Amount      types.Decimal `boil:"amount" json:"amount" toml:"amount" yaml:"amount"`
+	TaxCategory string        `boil:"tax_category" json:"tax_category" toml:"tax_category" yaml:"tax_category"`
+
+	R *invoiceItemR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L invoiceItemL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var InvoiceItemColumns = struct {
+	ID          string
+	InvoiceID   string
+	LineNo      string
+	Description string
+	Quantity    string
+	UnitPrice   string
+	Amount      string
+	TaxCategory string
+}{
+	ID:          "id",
+	InvoiceID:   "invoice_id",
+	LineNo:      "line_no",
+	Description: "description",
+	Quantity:    "quantity",
+	UnitPrice:   "unit_price",
+	Amount:      "amount",
+	TaxCategory: "tax_category",
+}
+
+var InvoiceItemTableColumns = struct {
+	ID          string
+	InvoiceID   string
+	LineNo      string
+	Description string
+	Quantity    string
+	UnitPrice   string
+	Amount      string
+	TaxCategory string
+}{
+	ID:          "invoice_items.id",
+	InvoiceID:   "invoice_items.invoice_id",
+	LineNo:      "invoice_items.line_no",
+	Description: "invoice_items.description",
+	Quantity:    "invoice_items.quantity",
+	UnitPrice:   "invoice_items.unit_price",
+	Amount:      "invoice_items.amount",
+	TaxCategory: "invoice_items.tax_category",
+}
+
+// Generated where
+
+var InvoiceItemWhere = struct {
+	ID          whereHelperint64
+	InvoiceID   whereHelperint64
+	LineNo      whereHelperint
+	Description whereHelperstring
+	Quantity    whereHelpertypes_Decimal
+	UnitPrice   whereHelpertypes_Decimal
+	Amount      whereHelpertypes_Decimal
+	TaxCategory whereHelperstring
+}{
+	ID:          whereHelperint64{field: "`invoice_items`.`id`"},
+	InvoiceID:   whereHelperint64{field: "`invoice_items`.`invoice_id`"},
+	LineNo:      whereHelperint{field: "`invoice_items`.`line_no`"},
+	Description: whereHelperstring{field: "`invoice_items`.`description`"},
+	Quantity:    whereHelpertypes_Decimal{field: "`invoice_items`.`quantity`"},
+	UnitPrice:   whereHelpertypes_Decimal{field: "`invoice_items`.`unit_price`"},
+	Amount:      whereHelpertypes_Decimal{field: "`invoice_items`.`amount`"},
+	TaxCategory: whereHelperstring{field: "`invoice_items`.`tax_category`"},
+}
+
+// InvoiceItemRels is where relationship names are stored.
+var InvoiceItemRels = struct {
+	Invoice string
+}{
+	Invoice: "Invoice",
+}
+
+// invoiceItemR is where relationships are stored.
+type invoiceItemR struct {
+	Invoice *Invoice `boil:"Invoice" json:"Invoice" toml:"Invoice" yaml:"Invoice"`
+}
+
+// NewStruct creates a new relationship struct
+func (*invoiceItemR) NewStruct() *invoiceItemR {
+	return &invoiceItemR{}
+}
+
+func (r *invoiceItemR) GetInvoice() *Invoice {
+	if r == nil {
+		return nil
+	}
+	return r.Invoice
+}
+
+// invoiceItemL is where Load methods for each relationship are stored.
+type invoiceItemL struct{}
+
+var (
+	invoiceItemAllColumns            = []string{"id", "invoice_id", "line_no", "description", "quantity", "unit_price", "amount", "tax_category"}
+	invoiceItemColumnsWithoutDefault = []string{"invoice_id", "line_no", "description", "quantity", "unit_price", "amount", "tax_category"}
+	invoiceItemColumnsWithDefault    = []string{"id"}
+	invoiceItemPrimaryKeyColumns     = []string{"id"}
+	invoiceItemGeneratedColumns      = []string{}
+)
+
+type (
+	// InvoiceItemSlice is an alias for a slice of pointers to InvoiceItem.
+	// This should almost always be used instead of []InvoiceItem.
+	InvoiceItemSlice []*InvoiceItem
+	// InvoiceItemHook is the signature for custom InvoiceItem hook methods
+	InvoiceItemHook func(context.Context, boil.ContextExecutor, *InvoiceItem) error
+
+	invoiceItemQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	invoiceItemType                 = reflect.TypeOf(&InvoiceItem{})
+	invoiceItemMapping              = queries.MakeStructMapping(invoiceItemType)
+	invoiceItemPrimaryKeyMapping, _ = queries.BindMapping(invoiceItemType, invoiceItemMapping, invoiceItemPrimaryKeyColumns)
+	invoiceItemInsertCacheMut       sync.RWMutex
+	invoiceItemInsertCache          = make(map[string]insertCache)
+	invoiceItemUpdateCacheMut       sync.RWMutex
+	invoiceItemUpdateCache          = make(map[string]updateCache)
+	invoiceItemUpsertCacheMut       sync.RWMutex
+	invoiceItemUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var invoiceItemAfterSelectMu sync.Mutex
+var invoiceItemAfterSelectHooks []InvoiceItemHook
+
+var invoiceItemBeforeInsertMu sync.Mutex
+var invoiceItemBeforeInsertHooks []InvoiceItemHook
+var invoiceItemAfterInsertMu sync.Mutex
+var invoiceItemAfterInsertHooks []InvoiceItemHook
+
+var invoiceItemBeforeUpdateMu sync.Mutex
+var invoiceItemBeforeUpdateHooks []InvoiceItemHook
+var invoiceItemAfterUpdateMu sync.Mutex
+var invoiceItemAfterUpdateHooks []InvoiceItemHook
+
+var invoiceItemBeforeDeleteMu sync.Mutex
+var invoiceItemBeforeDeleteHooks []InvoiceItemHook
+var invoiceItemAfterDeleteMu sync.Mutex
+var invoiceItemAfterDeleteHooks []InvoiceItemHook
+
+var invoiceItemBeforeUpsertMu sync.Mutex
+var invoiceItemBeforeUpsertHooks []InvoiceItemHook
+var invoiceItemAfterUpsertMu sync.Mutex
+var invoiceItemAfterUpsertHooks []InvoiceItemHook
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *InvoiceItem) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range invoiceItemAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *InvoiceItem) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range invoiceItemBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *InvoiceItem) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range invoiceItemAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *InvoiceItem) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range invoiceItemBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *InvoiceItem) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range invoiceItemAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *InvoiceItem) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range invoiceItemBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *InvoiceItem) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range invoiceItemAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *InvoiceItem) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range invoiceItemBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *InvoiceItem) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range invoiceItemAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddInvoiceItemHook registers your hook function for all future operations.
+func AddInvoiceItemHook(hookPoint boil.HookPoint, invoiceItemHook InvoiceItemHook) {
+	switch hookPoint {
+	case boil.AfterSelectHook:
+		invoiceItemAfterSelectMu.Lock()
+		invoiceItemAfterSelectHooks = append(invoiceItemAfterSelectHooks, invoiceItemHook)
+		invoiceItemAfterSelectMu.Unlock()
+	case boil.BeforeInsertHook:
+		invoiceItemBeforeInsertMu.Lock()
+		invoiceItemBeforeInsertHooks = append(invoiceItemBeforeInsertHooks, invoiceItemHook)
+		invoiceItemBeforeInsertMu.Unlock()
+	case boil.AfterInsertHook:
+		invoiceItemAfterInsertMu.Lock()
+		invoiceItemAfterInsertHooks = append(invoiceItemAfterInsertHooks, invoiceItemHook)
+		invoiceItemAfterInsertMu.Unlock()
+	case boil.BeforeUpdateHook:
+		invoiceItemBeforeUpdateMu.Lock()
+		invoiceItemBeforeUpdateHooks = append(invoiceItemBeforeUpdateHooks, invoiceItemHook)
+		invoiceItemBeforeUpdateMu.Unlock()
+	case boil.AfterUpdateHook:
+		invoiceItemAfterUpdateMu.Lock()
+		invoiceItemAfterUpdateHooks = append(invoiceItemAfterUpdateHooks, invoiceItemHook)
+		invoiceItemAfterUpdateMu.Unlock()
+	case boil.BeforeDeleteHook:
+		invoiceItemBeforeDeleteMu.Lock()
+		invoiceItemBeforeDeleteHooks = append(invoiceItemBeforeDeleteHooks, invoiceItemHook)
+		invoiceItemBeforeDeleteMu.Unlock()
+	case boil.AfterDeleteHook:
+		invoiceItemAfterDeleteMu.Lock()
+		invoiceItemAfterDeleteHooks = append(invoiceItemAfterDeleteHooks, invoiceItemHook)
+		invoiceItemAfterDeleteMu.Unlock()
+	case boil.BeforeUpsertHook:
+		invoiceItemBeforeUpsertMu.Lock()
+		invoiceItemBeforeUpsertHooks = append(invoiceItemBeforeUpsertHooks, invoiceItemHook)
+		invoiceItemBeforeUpsertMu.Unlock()
+	case boil.AfterUpsertHook:
+		invoiceItemAfterUpsertMu.Lock()
+		invoiceItemAfterUpsertHooks = append(invoiceItemAfterUpsertHooks, invoiceItemHook)
+		invoiceItemAfterUpsertMu.Unlock()
+	}
+}
+
+// One returns a single invoiceItem record from the query.
+func (q invoiceItemQuery) One(ctx context.Context, exec boil.ContextExecutor) (*InvoiceItem, error) {
+	o := &InvoiceItem{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for invoice_items")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all InvoiceItem records from the query.
+func (q invoiceItemQuery) All(ctx context.Context, exec boil.ContextExecutor) (InvoiceItemSlice, error) {
+	var o []*InvoiceItem
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to InvoiceItem slice")
+	}
+
+	if len(invoiceItemAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all InvoiceItem records in the query.
+func (q invoiceItemQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count invoice_items rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q invoiceItemQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if invoice_items exists")
+	}
+
+	return count > 0, nil
+}
+
+// Invoice pointed to by the foreign key.
+func (o *InvoiceItem) Invoice(mods ...qm.QueryMod) invoiceQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("`id` = ?", o.InvoiceID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	return Invoices(queryMods...)
+}
+
+// LoadInvoice allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (invoiceItemL) LoadInvoice(ctx context.Context, e boil.ContextExecutor, singular bool, maybeInvoiceItem interface{}, mods queries.Applicator) error {
+	var slice []*InvoiceItem
+	var object *InvoiceItem
+
+	if singular {
+		var ok bool
+		object, ok = maybeInvoiceItem.(*InvoiceItem)
+		if !ok {
+			object = new(InvoiceItem)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeInvoiceItem)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeInvoiceItem))
+			}
+		}
+	} else {
+		s, ok := maybeInvoiceItem.(*[]*InvoiceItem)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeInvoiceItem)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeInvoiceItem))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &invoiceItemR{}
+		}
+		args[object.InvoiceID] = struct{}{}
+
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &invoiceItemR{}
+			}
+
+			args[obj.InvoiceID] = struct{}{}
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`invoices`),
+		qm.WhereIn(`invoices.id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load Invoice")
+	}
+
+	var resultSlice []*Invoice
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice Invoice")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for invoices")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for invoices")
+	}
+
+	if len(invoiceAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.Invoice = foreign
+		if foreign.R == nil {
+			foreign.R = &invoiceR{}
+		}
+		foreign.R.InvoiceItems = append(foreign.R.InvoiceItems, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.InvoiceID == foreign.ID {
+				local.R.Invoice = foreign
+				if foreign.R == nil {
+					foreign.R = &invoiceR{}
+				}
+				foreign.R.InvoiceItems = append(foreign.R.InvoiceItems, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetInvoice of the invoiceItem to the related item.
+// Sets o.R.Invoice to related.
+// Adds o to related.R.InvoiceItems.
+func (o *InvoiceItem) SetInvoice(ctx context.Context, exec boil.ContextExecutor, insert bool, related *Invoice) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE `invoice_items` SET %s WHERE %s",
+		strmangle.SetParamNames("`", "`", 0, []string{"invoice_id"}),
+		strmangle.WhereClause("`", "`", 0, invoiceItemPrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.InvoiceID = related.ID
+	if o.R == nil {
+		o.R = &invoiceItemR{
+			Invoice: related,
+		}
+	} else {
+		o.R.Invoice = related
+	}
+
+	if related.R == nil {
+		related.R = &invoiceR{
+			InvoiceItems: InvoiceItemSlice{o},
+		}
+	} else {
+		related.R.InvoiceItems = append(related.R.InvoiceItems, o)
+	}
+
+	return nil
+}
+
+// InvoiceItems retrieves all the records using an executor.
+func InvoiceItems(mods ...qm.QueryMod) invoiceItemQuery {
+	mods = append(mods, qm.From("`invoice_items`"))
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"`invoice_items`.*"})
+	}
+
+	return invoiceItemQuery{q}
+}
+
+// FindInvoiceItem retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindInvoiceItem(ctx context.Context, exec boil.ContextExecutor, iD int64, selectCols ...string) (*InvoiceItem, error) {
+	invoiceItemObj := &InvoiceItem{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from `invoice_items` where `id`=?", sel,
+	)
+
+	q := queries.Raw(query, iD)
+
+	err := q.Bind(ctx, exec, invoiceItemObj)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from invoice_items")
+	}
+
+	if err = invoiceItemObj.doAfterSelectHooks(ctx, exec); err != nil {
+		return invoiceItemObj, err
+	}
+
+	return invoiceItemObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *InvoiceItem) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no invoice_items provided for insertion")
+	}
+
+	var err error
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(invoiceItemColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	invoiceItemInsertCacheMut.RLock()
+	cache, cached := invoiceItemInsertCache[key]
+	invoiceItemInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			invoiceItemAllColumns,
+			invoiceItemColumnsWithDefault,
+			invoiceItemColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(invoiceItemType, invoiceItemMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(invoiceItemType, invoiceItemMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO `invoice_items` (`%s`) %%sVALUES (%s)%%s", strings.Join(wl, "`,`"), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO `invoice_items` () VALUES ()%s%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			cache.retQuery = fmt.Sprintf("SELECT `%s` FROM `invoice_items` WHERE %s", strings.Join(returnColumns, "`,`"), strmangle.WhereClause("`", "`", 0, invoiceItemPrimaryKeyColumns))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	result, err := exec.ExecContext(ctx, cache.query, vals...)
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into invoice_items")
+	}
+
+	var lastID int64
+	var identifierCols []interface{}
+
+	if len(cache.retMapping) == 0 {
+		goto CacheNoHooks
+	}
+
+	lastID, err = result.LastInsertId()
+	if err != nil {
+		return ErrSyncFail
+	}
+
+	o.ID = int64(lastID)
+	if lastID != 0 && len(cache.retMapping) == 1 && cache.retMapping[0] == invoiceItemMapping["id"] {
+		goto CacheNoHooks
+	}
+
+	identifierCols = []interface{}{
+		o.ID,
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.retQuery)
+		fmt.Fprintln(writer, identifierCols...)
+	}
+	err = exec.QueryRowContext(ctx, cache.retQuery, identifierCols...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to populate default values for invoice_items")
+	}
+
+CacheNoHooks:
+	if !cached {
+		invoiceItemInsertCacheMut.Lock()
+		invoiceItemInsertCache[key] = cache
+		invoiceItemInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// Update uses an executor to update the InvoiceItem.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *InvoiceItem) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	invoiceItemUpdateCacheMut.RLock()
+	cache, cached := invoiceItemUpdateCache[key]
+	invoiceItemUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			invoiceItemAllColumns,
+			invoiceItemPrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("models: unable to update invoice_items, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE `invoice_items` SET %s WHERE %s",
+			strmangle.SetParamNames("`", "`", 0, wl),
+			strmangle.WhereClause("`", "`", 0, invoiceItemPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(invoiceItemType, invoiceItemMapping, append(wl, invoiceItemPrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update invoice_items row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by update for invoice_items")
+	}
+
+	if !cached {
+		invoiceItemUpdateCacheMut.Lock()
+		invoiceItemUpdateCache[key] = cache
+		invoiceItemUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q invoiceItemQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all for invoice_items")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected for invoice_items")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o InvoiceItemSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), invoiceItemPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE `invoice_items` SET %s WHERE %s",
+		strmangle.SetParamNames("`", "`", 0, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, invoiceItemPrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all in invoiceItem slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected all in update all invoiceItem")
+	}
+	return rowsAff, nil
+}
+
+var mySQLInvoiceItemUniqueColumns = []string{
+	"id",
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *InvoiceItem) Upsert(ctx context.Context, exec boil.ContextExecutor, updateColumns, insertColumns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no invoice_items provided for upsert")
+	}
+
+	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(invoiceItemColumnsWithDefault, o)
+	nzUniques := queries.NonZeroDefaultSet(mySQLInvoiceItemUniqueColumns, o)
+
+	if len(nzUniques) == 0 {
+		return errors.New("cannot upsert with a table that cannot conflict on a unique column")
+	}
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzUniques {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	invoiceItemUpsertCacheMut.RLock()
+	cache, cached := invoiceItemUpsertCache[key]
+	invoiceItemUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, _ := insertColumns.InsertColumnSet(
+			invoiceItemAllColumns,
+			invoiceItemColumnsWithDefault,
+			invoiceItemColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		update := updateColumns.UpdateColumnSet(
+			invoiceItemAllColumns,
+			invoiceItemPrimaryKeyColumns,
+		)
+
+		if !updateColumns.IsNone() && len(update) == 0 {
+			return errors.New("models: unable to upsert invoice_items, could not build update column list")
+		}
+
+		ret := strmangle.SetComplement(invoiceItemAllColumns, strmangle.SetIntersect(insert, update))
+
+		cache.query = buildUpsertQueryMySQL(dialect, "`invoice_items`", update, insert)
+		cache.retQuery = fmt.Sprintf(
+			"SELECT %s FROM `invoice_items` WHERE %s",
+			strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, ret), ","),
+			strmangle.WhereClause("`", "`", 0, nzUniques),
+		)
+
+		cache.valueMapping, err = queries.BindMapping(invoiceItemType, invoiceItemMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(invoiceItemType, invoiceItemMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	result, err := exec.ExecContext(ctx, cache.query, vals...)
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert for invoice_items")
+	}
+
+	var lastID int64
+	var uniqueMap []uint64
+	var nzUniqueCols []interface{}
+
+	if len(cache.retMapping) == 0 {
+		goto CacheNoHooks
+	}
+
+	lastID, err = result.LastInsertId()
+	if err != nil {
+		return ErrSyncFail
+	}
+
+	o.ID = int64(lastID)
+	if lastID != 0 && len(cache.retMapping) == 1 && cache.retMapping[0] == invoiceItemMapping["id"] {
+		goto CacheNoHooks
+	}
+
+	uniqueMap, err = queries.BindMapping(invoiceItemType, invoiceItemMapping, nzUniques)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to retrieve unique values for invoice_items")
+	}
+	nzUniqueCols = queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), uniqueMap)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.retQuery)
+		fmt.Fprintln(writer, nzUniqueCols...)
+	}
+	err = exec.QueryRowContext(ctx, cache.retQuery, nzUniqueCols...).Scan(returns...)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to populate default values for invoice_items")
+	}
+
+CacheNoHooks:
+	if !cached {
+		invoiceItemUpsertCacheMut.Lock()
+		invoiceItemUpsertCache[key] = cache
+		invoiceItemUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(ctx, exec)
+}
+
+// Delete deletes a single InvoiceItem record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *InvoiceItem) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("models: no InvoiceItem provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), invoiceItemPrimaryKeyMapping)
+	sql := "DELETE FROM `invoice_items` WHERE `id`=?"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete from invoice_items")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by delete for invoice_items")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q invoiceItemQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("models: no invoiceItemQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from invoice_items")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for invoice_items")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o InvoiceItemSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(invoiceItemBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), invoiceItemPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM `invoice_items` WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, invoiceItemPrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from invoiceItem slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for invoice_items")
+	}
+
+	if len(invoiceItemAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *InvoiceItem) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindInvoiceItem(ctx, exec, o.ID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *InvoiceItemSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := InvoiceItemSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), invoiceItemPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT `invoice_items`.* FROM `invoice_items` WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, invoiceItemPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in InvoiceItemSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// InvoiceItemExists checks if the InvoiceItem row exists.
+func InvoiceItemExists(ctx context.Context, exec boil.ContextExecutor, iD int64) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from `invoice_items` where `id`=? limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, iD)
+	}
+	row := exec.QueryRowContext(ctx, sql, iD)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if invoice_items exists")
+	}
+
+	return exists, nil
+}
+
+// Exists checks if the InvoiceItem row exists.
+func (o *InvoiceItem) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	return InvoiceItemExists(ctx, exec, o.ID)
+}
@@ -0,0 +1,1194 @@
+// Code generated by SQLBoiler 4.16.2 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/sqlboiler/v4/types"
+	"github.com/volatiletech/strmangle"
+)
+
+// InvoicePayment is an object representing the database table.
+type InvoicePayment struct {
+	ID          int64         `boil:"id" json:"id" toml:"id" yaml:"id"`
+	InvoiceID   int64         `boil:"invoice_id" json:"invoice_id" toml:"invoice_id" yaml:"invoice_id"`
+	PaymentDate time.Time     `boil:"payment_date" json:"payment_date" toml:"payment_date" yaml:"payment_date"`
+	Amount      types.Decimal `boil:"amount" json:"amount" toml:"amount" yaml:"amount"`
+	Reference   string        `boil:"reference" json:"reference" toml:"reference" yaml:"reference"`
+
+	R *invoicePaymentR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L invoicePaymentL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var InvoicePaymentColumns = struct {
+	ID          string
+	InvoiceID   string
+	PaymentDate string
+	Amount      string
+	Reference   string
+}{
+	ID:          "id",
+	InvoiceID:   "invoice_id",
+	PaymentDate: "payment_date",
+	Amount:      "amount",
+	Reference:   "reference",
+}
+
+var InvoicePaymentTableColumns = struct {
+	ID          string
+	InvoiceID   string
+	PaymentDate string
+	Amount      string
+	Reference   string
+}{
+	ID:          "invoice_payments.id",
+	InvoiceID:   "invoice_payments.invoice_id",
+	PaymentDate: "invoice_payments.payment_date",
+	Amount:      "invoice_payments.amount",
+	Reference:   "invoice_payments.reference",
+}
+
+// Generated where
+
+var InvoicePaymentWhere = struct {
+	ID          whereHelperint64
+	InvoiceID   whereHelperint64
+	PaymentDate whereHelpertime_Time
+	Amount      whereHelpertypes_Decimal
+	Reference   whereHelperstring
+}{
+	ID:          whereHelperint64{field: "`invoice_payments`.`id`"},
+	InvoiceID:   whereHelperint64{field: "`invoice_payments`.`invoice_id`"},
+	PaymentDate: whereHelpertime_Time{field: "`invoice_payments`.`payment_date`"},
+	Amount:      whereHelpertypes_Decimal{field: "`invoice_payments`.`amount`"},
+	Reference:   whereHelperstring{field: "`invoice_payments`.`reference`"},
+}
+
+// InvoicePaymentRels is where relationship names are stored.
+var InvoicePaymentRels = struct {
+	Invoice string
+}{
+	Invoice: "Invoice",
+}
+
+// invoicePaymentR is where relationships are stored.
+type invoicePaymentR struct {
+	Invoice *Invoice `boil:"Invoice" json:"Invoice" toml:"Invoice" yaml:"Invoice"`
+}
+
+// NewStruct creates a new relationship struct
+func (*invoicePaymentR) NewStruct() *invoicePaymentR {
+	return &invoicePaymentR{}
+}
+
+func (r *invoicePaymentR) GetInvoice() *Invoice {
+	if r == nil {
+		return nil
+	}
+	return r.Invoice
+}
+
+// invoicePaymentL is where Load methods for each relationship are stored.
+type invoicePaymentL struct{}
+
+var (
+	invoicePaymentAllColumns            = []string{"id", "invoice_id", "payment_date", "amount", "reference"}
+	invoicePaymentColumnsWithoutDefault = []string{"invoice_id", "payment_date", "amount", "reference"}
+	invoicePaymentColumnsWithDefault    = []string{"id"}
+	invoicePaymentPrimaryKeyColumns     = []string{"id"}
+	invoicePaymentGeneratedColumns      = []string{}
+)
+
+type (
+	// InvoicePaymentSlice is an alias for a slice of pointers to InvoicePayment.
+	// This should almost always be used instead of []InvoicePayment.
+	InvoicePaymentSlice []*InvoicePayment
+	// InvoicePaymentHook is the signature for custom InvoicePayment hook methods
+	InvoicePaymentHook func(context.Context, boil.ContextExecutor, *InvoicePayment) error
+
+	invoicePaymentQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	invoicePaymentType                 = reflect.TypeOf(&InvoicePayment{})
+	invoicePaymentMapping              = queries.MakeStructMapping(invoicePaymentType)
+	invoicePaymentPrimaryKeyMapping, _ = queries.BindMapping(invoicePaymentType, invoicePaymentMapping, invoicePaymentPrimaryKeyColumns)
+	invoicePaymentInsertCacheMut       sync.RWMutex
+	invoicePaymentInsertCache          = make(map[string]insertCache)
+	invoicePaymentUpdateCacheMut       sync.RWMutex
+	invoicePaymentUpdateCache          = make(map[string]updateCache)
+	invoicePaymentUpsertCacheMut       sync.RWMutex
+	invoicePaymentUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var invoicePaymentAfterSelectMu sync.Mutex
+var invoicePaymentAfterSelectHooks []InvoicePaymentHook
+
+var invoicePaymentBeforeInsertMu sync.Mutex
+var invoicePaymentBeforeInsertHooks []InvoicePaymentHook
+var invoicePaymentAfterInsertMu sync.Mutex
+var invoicePaymentAfterInsertHooks []InvoicePaymentHook
+
+var invoicePaymentBeforeUpdateMu sync.Mutex
+var invoicePaymentBeforeUpdateHooks []InvoicePaymentHook
+var invoicePaymentAfterUpdateMu sync.Mutex
+var invoicePaymentAfterUpdateHooks []InvoicePaymentHook
+
+var invoicePaymentBeforeDeleteMu sync.Mutex
+var invoicePaymentBeforeDeleteHooks []InvoicePaymentHook
+var invoicePaymentAfterDeleteMu sync.Mutex
+var invoicePaymentAfterDeleteHooks []InvoicePaymentHook
+
+var invoicePaymentBeforeUpsertMu sync.Mutex
+var invoicePaymentBeforeUpsertHooks []InvoicePaymentHook
+var invoicePaymentAfterUpsertMu sync.Mutex
+var invoicePaymentAfterUpsertHooks []InvoicePaymentHook
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *InvoicePayment) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range invoicePaymentAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *InvoicePayment) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range invoicePaymentBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *InvoicePayment) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range invoicePaymentAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *InvoicePayment) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range invoicePaymentBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *InvoicePayment) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range invoicePaymentAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *InvoicePayment) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range invoicePaymentBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *InvoicePayment) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range invoicePaymentAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *InvoicePayment) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range invoicePaymentBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *InvoicePayment) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range invoicePaymentAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddInvoicePaymentHook registers your hook function for all future operations.
+func AddInvoicePaymentHook(hookPoint boil.HookPoint, invoicePaymentHook InvoicePaymentHook) {
+	switch hookPoint {
+	case boil.AfterSelectHook:
+		invoicePaymentAfterSelectMu.Lock()
+		invoicePaymentAfterSelectHooks = append(invoicePaymentAfterSelectHooks, invoicePaymentHook)
+		invoicePaymentAfterSelectMu.Unlock()
+	case boil.BeforeInsertHook:
+		invoicePaymentBeforeInsertMu.Lock()
+		invoicePaymentBeforeInsertHooks = append(invoicePaymentBeforeInsertHooks, invoicePaymentHook)
+		invoicePaymentBeforeInsertMu.Unlock()
+	case boil.AfterInsertHook:
+		invoicePaymentAfterInsertMu.Lock()
+		invoicePaymentAfterInsertHooks = append(invoicePaymentAfterInsertHooks, invoicePaymentHook)
+		invoicePaymentAfterInsertMu.Unlock()
+	case boil.BeforeUpdateHook:
+		invoicePaymentBeforeUpdateMu.Lock()
+		invoicePaymentBeforeUpdateHooks = append(invoicePaymentBeforeUpdateHooks, invoicePaymentHook)
+		invoicePaymentBeforeUpdateMu.Unlock()
+	case boil.AfterUpdateHook:
+		invoicePaymentAfterUpdateMu.Lock()
+		invoicePaymentAfterUpdateHooks = append(invoicePaymentAfterUpdateHooks, invoicePaymentHook)
+		invoicePaymentAfterUpdateMu.Unlock()
+	case boil.BeforeDeleteHook:
+		invoicePaymentBeforeDeleteMu.Lock()
+		invoicePaymentBeforeDeleteHooks = append(invoicePaymentBeforeDeleteHooks, invoicePaymentHook)
+		invoicePaymentBeforeDeleteMu.Unlock()
+	case boil.AfterDeleteHook:
+		invoicePaymentAfterDeleteMu.Lock()
+		invoicePaymentAfterDeleteHooks = append(invoicePaymentAfterDeleteHooks, invoicePaymentHook)
+		invoicePaymentAfterDeleteMu.Unlock()
+	case boil.BeforeUpsertHook:
+		invoicePaymentBeforeUpsertMu.Lock()
+		invoicePaymentBeforeUpsertHooks = append(invoicePaymentBeforeUpsertHooks, invoicePaymentHook)
+		invoicePaymentBeforeUpsertMu.Unlock()
+	case boil.AfterUpsertHook:
+		invoicePaymentAfterUpsertMu.Lock()
+		invoicePaymentAfterUpsertHooks = append(invoicePaymentAfterUpsertHooks, invoicePaymentHook)
+		invoicePaymentAfterUpsertMu.Unlock()
+	}
+}
+
+// One returns a single invoicePayment record from the query.
+func (q invoicePaymentQuery) One(ctx context.Context, exec boil.ContextExecutor) (*InvoicePayment, error) {
+	o := &InvoicePayment{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for invoice_payments")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all InvoicePayment records from the query.
+func (q invoicePaymentQuery) All(ctx context.Context, exec boil.ContextExecutor) (InvoicePaymentSlice, error) {
+	var o []*InvoicePayment
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to InvoicePayment slice")
+	}
+
+	if len(invoicePaymentAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all InvoicePayment records in the query.
+func (q invoicePaymentQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count invoice_payments rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q invoicePaymentQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if invoice_payments exists")
+	}
+
+	return count > 0, nil
+}
+
+// Invoice pointed to by the foreign key.
+func (o *InvoicePayment) Invoice(mods ...qm.QueryMod) invoiceQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("`id` = ?", o.InvoiceID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	return Invoices(queryMods...)
+}
+
+// LoadInvoice allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (invoicePaymentL) LoadInvoice(ctx context.Context, e boil.ContextExecutor, singular bool, maybeInvoicePayment interface{}, mods queries.Applicator) error {
+	var slice []*InvoicePayment
+	var object *InvoicePayment
+
+	if singular {
+		var ok bool
+		object, ok = maybeInvoicePayment.(*InvoicePayment)
+		if !ok {
+			object = new(InvoicePayment)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeInvoicePayment)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeInvoicePayment))
+			}
+		}
+	} else {
+		s, ok := maybeInvoicePayment.(*[]*InvoicePayment)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeInvoicePayment)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeInvoicePayment))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &invoicePaymentR{}
+		}
+		args[object.InvoiceID] = struct{}{}
+
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &invoicePaymentR{}
+			}
+
+			args[obj.InvoiceID] = struct{}{}
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`invoices`),
+		qm.WhereIn(`invoices.id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load Invoice")
+	}
+
+	var resultSlice []*Invoice
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice Invoice")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for invoices")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for invoices")
+	}
+
+	if len(invoiceAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.Invoice = foreign
+		if foreign.R == nil {
+			foreign.R = &invoiceR{}
+		}
+		foreign.R.InvoicePayments = append(foreign.R.InvoicePayments, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.InvoiceID == foreign.ID {
+				local.R.Invoice = foreign
+				if foreign.R == nil {
+					foreign.R = &invoiceR{}
+				}
+				foreign.R.InvoicePayments = append(foreign.R.InvoicePayments, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetInvoice of the invoicePayment to the related item.
+// Sets o.R.Invoice to related.
+// Adds o to related.R.InvoicePayments.
+func (o *InvoicePayment) SetInvoice(ctx context.Context, exec boil.ContextExecutor, insert bool, related *Invoice) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE `invoice_payments` SET %s WHERE %s",
+		strmangle.SetParamNames("`", "`", 0, []string{"invoice_id"}),
+		strmangle.WhereClause("`", "`", 0, invoicePaymentPrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.InvoiceID = related.ID
+	if o.R == nil {
+		o.R = &invoicePaymentR{
+			Invoice: related,
+		}
+	} else {
+		o.R.Invoice = related
+	}
+
+	if related.R == nil {
+		related.R = &invoiceR{
+			InvoicePayments: InvoicePaymentSlice{o},
+		}
+	} else {
+		related.R.InvoicePayments = append(related.R.InvoicePayments, o)
+	}
+
+	return nil
+}
+
+// InvoicePayments retrieves all the records using an executor.
+func InvoicePayments(mods ...qm.QueryMod) invoicePaymentQuery {
+	mods = append(mods, qm.From("`invoice_payments`"))
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"`invoice_payments`.*"})
+	}
+
+	return invoicePaymentQuery{q}
+}
+
+// FindInvoicePayment retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindInvoicePayment(ctx context.Context, exec boil.ContextExecutor, iD int64, selectCols ...string) (*InvoicePayment, error) {
+	invoicePaymentObj := &InvoicePayment{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from `invoice_payments` where `id`=?", sel,
+	)
+
+	q := queries.Raw(query, iD)
+
+	err := q.Bind(ctx, exec, invoicePaymentObj)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from invoice_payments")
+	}
+
+	if err = invoicePaymentObj.doAfterSelectHooks(ctx, exec); err != nil {
+		return invoicePaymentObj, err
+	}
+
+	return invoicePaymentObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *InvoicePayment) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no invoice_payments provided for insertion")
+	}
+
+	var err error
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(invoicePaymentColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	invoicePaymentInsertCacheMut.RLock()
+	cache, cached := invoicePaymentInsertCache[key]
+	invoicePaymentInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			invoicePaymentAllColumns,
+			invoicePaymentColumnsWithDefault,
+			invoicePaymentColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(invoicePaymentType, invoicePaymentMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(invoicePaymentType, invoicePaymentMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO `invoice_payments` (`%s`) %%sVALUES (%s)%%s", strings.Join(wl, "`,`"), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO `invoice_payments` () VALUES ()%s%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			cache.retQuery = fmt.Sprintf("SELECT `%s` FROM `invoice_payments` WHERE %s", strings.Join(returnColumns, "`,`"), strmangle.WhereClause("`", "`", 0, invoicePaymentPrimaryKeyColumns))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	result, err := exec.ExecContext(ctx, cache.query, vals...)
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into invoice_payments")
+	}
+
+	var lastID int64
+	var identifierCols []interface{}
+
+	if len(cache.retMapping) == 0 {
+		goto CacheNoHooks
+	}
+
+	lastID, err = result.LastInsertId()
+	if err != nil {
+		return ErrSyncFail
+	}
+
+	o.ID = int64(lastID)
+	if lastID != 0 && len(cache.retMapping) == 1 && cache.retMapping[0] == invoicePaymentMapping["id"] {
+		goto CacheNoHooks
+	}
+
+	identifierCols = []interface{}{
+		o.ID,
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.retQuery)
+		fmt.Fprintln(writer, identifierCols...)
+	}
+	err = exec.QueryRowContext(ctx, cache.retQuery, identifierCols...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to populate default values for invoice_payments")
+	}
+
+CacheNoHooks:
+	if !cached {
+		invoicePaymentInsertCacheMut.Lock()
+		invoicePaymentInsertCache[key] = cache
+		invoicePaymentInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// Update uses an executor to update the InvoicePayment.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *InvoicePayment) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	invoicePaymentUpdateCacheMut.RLock()
+	cache, cached := invoicePaymentUpdateCache[key]
+	invoicePaymentUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			invoicePaymentAllColumns,
+			invoicePaymentPrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("models: unable to update invoice_payments, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE `invoice_payments` SET %s WHERE %s",
+			strmangle.SetParamNames("`", "`", 0, wl),
+			strmangle.WhereClause("`", "`", 0, invoicePaymentPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(invoicePaymentType, invoicePaymentMapping, append(wl, invoicePaymentPrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update invoice_payments row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by update for invoice_payments")
+	}
+
+	if !cached {
+		invoicePaymentUpdateCacheMut.Lock()
+		invoicePaymentUpdateCache[key] = cache
+		invoicePaymentUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q invoicePaymentQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all for invoice_payments")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected for invoice_payments")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o InvoicePaymentSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), invoicePaymentPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE `invoice_payments` SET %s WHERE %s",
+		strmangle.SetParamNames("`", "`", 0, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, invoicePaymentPrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all in invoicePayment slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected all in update all invoicePayment")
+	}
+	return rowsAff, nil
+}
+
+var mySQLInvoicePaymentUniqueColumns = []string{
+	"id",
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *InvoicePayment) Upsert(ctx context.Context, exec boil.ContextExecutor, updateColumns, insertColumns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no invoice_payments provided for upsert")
+	}
+
+	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(invoicePaymentColumnsWithDefault, o)
+	nzUniques := queries.NonZeroDefaultSet(mySQLInvoicePaymentUniqueColumns, o)
+
+	if len(nzUniques) == 0 {
+		return errors.New("cannot upsert with a table that cannot conflict on a unique column")
+	}
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzUniques {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	invoicePaymentUpsertCacheMut.RLock()
+	cache, cached := invoicePaymentUpsertCache[key]
+	invoicePaymentUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, _ := insertColumns.InsertColumnSet(
+			invoicePaymentAllColumns,
+			invoicePaymentColumnsWithDefault,
+			invoicePaymentColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		update := updateColumns.UpdateColumnSet(
+			invoicePaymentAllColumns,
+			invoicePaymentPrimaryKeyColumns,
+		)
+
+		if !updateColumns.IsNone() && len(update) == 0 {
+			return errors.New("models: unable to upsert invoice_payments, could not build update column list")
+		}
+
+		ret := strmangle.SetComplement(invoicePaymentAllColumns, strmangle.SetIntersect(insert, update))
+
+		cache.query = buildUpsertQueryMySQL(dialect, "`invoice_payments`", update, insert)
+		cache.retQuery = fmt.Sprintf(
+			"SELECT %s FROM `invoice_payments` WHERE %s",
+			strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, ret), ","),
+			strmangle.WhereClause("`", "`", 0, nzUniques),
+		)
+
+		cache.valueMapping, err = queries.BindMapping(invoicePaymentType, invoicePaymentMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(invoicePaymentType, invoicePaymentMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	result, err := exec.ExecContext(ctx, cache.query, vals...)
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert for invoice_payments")
+	}
+
+	var lastID int64
+	var uniqueMap []uint64
+	var nzUniqueCols []interface{}
+
+	if len(cache.retMapping) == 0 {
+		goto CacheNoHooks
+	}
+
+	lastID, err = result.LastInsertId()
+	if err != nil {
+		return ErrSyncFail
+	}
+
+	o.ID = int64(lastID)
+	if lastID != 0 && len(cache.retMapping) == 1 && cache.retMapping[0] == invoicePaymentMapping["id"] {
+		goto CacheNoHooks
+	}
+
+	uniqueMap, err = queries.BindMapping(invoicePaymentType, invoicePaymentMapping, nzUniques)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to retrieve unique values for invoice_payments")
+	}
+	nzUniqueCols = queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), uniqueMap)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.retQuery)
+		fmt.Fprintln(writer, nzUniqueCols...)
+	}
+	err = exec.QueryRowContext(ctx, cache.retQuery, nzUniqueCols...).Scan(returns...)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to populate default values for invoice_payments")
+	}
+
+CacheNoHooks:
+	if !cached {
+		invoicePaymentUpsertCacheMut.Lock()
+		invoicePaymentUpsertCache[key] = cache
+		invoicePaymentUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(ctx, exec)
+}
+
+// Delete deletes a single InvoicePayment record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *InvoicePayment) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("models: no InvoicePayment provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), invoicePaymentPrimaryKeyMapping)
+	sql := "DELETE FROM `invoice_payments` WHERE `id`=?"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete from invoice_payments")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by delete for invoice_payments")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q invoicePaymentQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("models: no invoicePaymentQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from invoice_payments")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for invoice_payments")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o InvoicePaymentSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(invoicePaymentBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), invoicePaymentPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM `invoice_payments` WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, invoicePaymentPrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from invoicePayment slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for invoice_payments")
+	}
+
+	if len(invoicePaymentAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *InvoicePayment) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindInvoicePayment(ctx, exec, o.ID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *InvoicePaymentSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := InvoicePaymentSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), invoicePaymentPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT `invoice_payments`.* FROM `invoice_payments` WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, invoicePaymentPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in InvoicePaymentSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// InvoicePaymentExists checks if the InvoicePayment row exists.
+func InvoicePaymentExists(ctx context.Context, exec boil.ContextExecutor, iD int64) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from `invoice_payments` where `id`=? limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, iD)
+	}
+	row := exec.QueryRowContext(ctx, sql, iD)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if invoice_payments exists")
+	}
+
+	return exists, nil
+}
+
+// Exists checks if the InvoicePayment row exists.
+func (o *InvoicePayment) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	return InvoicePaymentExists(ctx, exec, o.ID)
+}
@@ -0,0 +1,1201 @@
+// Code generated by SQLBoiler 4.16.2 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/sqlboiler/v4/types"
+	"github.com/volatiletech/strmangle"
+)
+
+// InvoiceTaxSubtotal is an object representing the database table.
+type InvoiceTaxSubtotal struct {
+	ID          int64         `boil:"id" json:"id" toml:"id" yaml:"id"`
+	InvoiceID   int64         `boil:"invoice_id" json:"invoice_id" toml:"invoice_id" yaml:"invoice_id"`
+	TaxCategory string        `boil:"tax_category" json:"tax_category" toml:"tax_category" yaml:"tax_category"`
+	Rate        int           `boil:"rate" json:"rate" toml:"rate" yaml:"rate"`
+	Amount      types.Decimal `boil:"amount" json:"amount" toml:"amount" yaml:"amount"`
+	TaxAmount   types.Decimal `boil:"tax_amount" json:"tax_amount" toml:"tax_amount" yaml:"tax_amount"`
+
+	R *invoiceTaxSubtotalR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L invoiceTaxSubtotalL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var InvoiceTaxSubtotalColumns = struct {
+	ID          string
+	InvoiceID   string
+	TaxCategory string
+	Rate        string
+	Amount      string
+	TaxAmount   string
+}{
+	ID:          "id",
+	InvoiceID:   "invoice_id",
+	TaxCategory: "tax_category",
+	Rate:        "rate",
+	Amount:      "amount",
+	TaxAmount:   "tax_amount",
+}
+
+var InvoiceTaxSubtotalTableColumns = struct {
+	ID          string
+	InvoiceID   string
+	TaxCategory string
+	Rate        string
+	Amount      string
+	TaxAmount   string
+}{
+	ID:          "invoice_tax_subtotals.id",
+	InvoiceID:   "invoice_tax_subtotals.invoice_id",
+	TaxCategory: "invoice_tax_subtotals.tax_category",
+	Rate:        "invoice_tax_subtotals.rate",
+	Amount:      "invoice_tax_subtotals.amount",
+	TaxAmount:   "invoice_tax_subtotals.tax_amount",
+}
+
+// Generated where
+
+var InvoiceTaxSubtotalWhere = struct {
+	ID          whereHelperint64
+	InvoiceID   whereHelperint64
+	TaxCategory whereHelperstring
+	Rate        whereHelperint
+	Amount      whereHelpertypes_Decimal
+	TaxAmount   whereHelpertypes_Decimal
+}{
+	ID:          whereHelperint64{field: "`invoice_tax_subtotals`.`id`"},
+	InvoiceID:   whereHelperint64{field: "`invoice_tax_subtotals`.`invoice_id`"},
+	TaxCategory: whereHelperstring{field: "`invoice_tax_subtotals`.`tax_category`"},
+	Rate:        whereHelperint{field: "`invoice_tax_subtotals`.`rate`"},
+	Amount:      whereHelpertypes_Decimal{field: "`invoice_tax_subtotals`.`amount`"},
+	TaxAmount:   whereHelpertypes_Decimal{field: "`invoice_tax_subtotals`.`tax_amount`"},
+}
+
+// InvoiceTaxSubtotalRels is where relationship names are stored.
+var InvoiceTaxSubtotalRels = struct {
+	Invoice string
+}{
+	Invoice: "Invoice",
+}
+
+// invoiceTaxSubtotalR is where relationships are stored.
+type invoiceTaxSubtotalR struct {
+	Invoice *Invoice `boil:"Invoice" json:"Invoice" toml:"Invoice" yaml:"Invoice"`
+}
+
+// NewStruct creates a new relationship struct
+func (*invoiceTaxSubtotalR) NewStruct() *invoiceTaxSubtotalR {
+	return &invoiceTaxSubtotalR{}
+}
+
+func (r *invoiceTaxSubtotalR) GetInvoice() *Invoice {
+	if r == nil {
+		return nil
+	}
+	return r.Invoice
+}
+
+// invoiceTaxSubtotalL is where Load methods for each relationship are stored.
+type invoiceTaxSubtotalL struct{}
+
+var (
+	invoiceTaxSubtotalAllColumns            = []string{"id", "invoice_id", "tax_category", "rate", "amount", "tax_amount"}
+	invoiceTaxSubtotalColumnsWithoutDefault = []string{"invoice_id", "tax_category", "rate", "amount", "tax_amount"}
+	invoiceTaxSubtotalColumnsWithDefault    = []string{"id"}
+	invoiceTaxSubtotalPrimaryKeyColumns     = []string{"id"}
+	invoiceTaxSubtotalGeneratedColumns      = []string{}
+)
+
+type (
+	// InvoiceTaxSubtotalSlice is an alias for a slice of pointers to InvoiceTaxSubtotal.
+	// This should almost always be used instead of []InvoiceTaxSubtotal.
+	InvoiceTaxSubtotalSlice []*InvoiceTaxSubtotal
+	// InvoiceTaxSubtotalHook is the signature for custom InvoiceTaxSubtotal hook methods
+	InvoiceTaxSubtotalHook func(context.Context, boil.ContextExecutor, *InvoiceTaxSubtotal) error
+
+	invoiceTaxSubtotalQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	invoiceTaxSubtotalType                 = reflect.TypeOf(&InvoiceTaxSubtotal{})
+	invoiceTaxSubtotalMapping              = queries.MakeStructMapping(invoiceTaxSubtotalType)
+	invoiceTaxSubtotalPrimaryKeyMapping, _ = queries.BindMapping(invoiceTaxSubtotalType, invoiceTaxSubtotalMapping, invoiceTaxSubtotalPrimaryKeyColumns)
+	invoiceTaxSubtotalInsertCacheMut       sync.RWMutex
+	invoiceTaxSubtotalInsertCache          = make(map[string]insertCache)
+	invoiceTaxSubtotalUpdateCacheMut       sync.RWMutex
+	invoiceTaxSubtotalUpdateCache          = make(map[string]updateCache)
+	invoiceTaxSubtotalUpsertCacheMut       sync.RWMutex
+	invoiceTaxSubtotalUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var invoiceTaxSubtotalAfterSelectMu sync.Mutex
+var invoiceTaxSubtotalAfterSelectHooks []InvoiceTaxSubtotalHook
+
+var invoiceTaxSubtotalBeforeInsertMu sync.Mutex
+var invoiceTaxSubtotalBeforeInsertHooks []InvoiceTaxSubtotalHook
+var invoiceTaxSubtotalAfterInsertMu sync.Mutex
+var invoiceTaxSubtotalAfterInsertHooks []InvoiceTaxSubtotalHook
+
+var invoiceTaxSubtotalBeforeUpdateMu sync.Mutex
+var invoiceTaxSubtotalBeforeUpdateHooks []InvoiceTaxSubtotalHook
+var invoiceTaxSubtotalAfterUpdateMu sync.Mutex
+var invoiceTaxSubtotalAfterUpdateHooks []InvoiceTaxSubtotalHook
+
+var invoiceTaxSubtotalBeforeDeleteMu sync.Mutex
+var invoiceTaxSubtotalBeforeDeleteHooks []InvoiceTaxSubtotalHook
+var invoiceTaxSubtotalAfterDeleteMu sync.Mutex
+var invoiceTaxSubtotalAfterDeleteHooks []InvoiceTaxSubtotalHook
+
+var invoiceTaxSubtotalBeforeUpsertMu sync.Mutex
+var invoiceTaxSubtotalBeforeUpsertHooks []InvoiceTaxSubtotalHook
+var invoiceTaxSubtotalAfterUpsertMu sync.Mutex
+var invoiceTaxSubtotalAfterUpsertHooks []InvoiceTaxSubtotalHook
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *InvoiceTaxSubtotal) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range invoiceTaxSubtotalAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *InvoiceTaxSubtotal) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range invoiceTaxSubtotalBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *InvoiceTaxSubtotal) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range invoiceTaxSubtotalAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *InvoiceTaxSubtotal) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range invoiceTaxSubtotalBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *InvoiceTaxSubtotal) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range invoiceTaxSubtotalAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *InvoiceTaxSubtotal) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range invoiceTaxSubtotalBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *InvoiceTaxSubtotal) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range invoiceTaxSubtotalAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *InvoiceTaxSubtotal) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range invoiceTaxSubtotalBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *InvoiceTaxSubtotal) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range invoiceTaxSubtotalAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddInvoiceTaxSubtotalHook registers your hook function for all future operations.
+func AddInvoiceTaxSubtotalHook(hookPoint boil.HookPoint, invoiceTaxSubtotalHook InvoiceTaxSubtotalHook) {
+	switch hookPoint {
+	case boil.AfterSelectHook:
+		invoiceTaxSubtotalAfterSelectMu.Lock()
+		invoiceTaxSubtotalAfterSelectHooks = append(invoiceTaxSubtotalAfterSelectHooks, invoiceTaxSubtotalHook)
+		invoiceTaxSubtotalAfterSelectMu.Unlock()
+	case boil.BeforeInsertHook:
+		invoiceTaxSubtotalBeforeInsertMu.Lock()
+		invoiceTaxSubtotalBeforeInsertHooks = append(invoiceTaxSubtotalBeforeInsertHooks, invoiceTaxSubtotalHook)
+		invoiceTaxSubtotalBeforeInsertMu.Unlock()
+	case boil.AfterInsertHook:
+		invoiceTaxSubtotalAfterInsertMu.Lock()
+		invoiceTaxSubtotalAfterInsertHooks = append(invoiceTaxSubtotalAfterInsertHooks, invoiceTaxSubtotalHook)
+		invoiceTaxSubtotalAfterInsertMu.Unlock()
+	case boil.BeforeUpdateHook:
+		invoiceTaxSubtotalBeforeUpdateMu.Lock()
+		invoiceTaxSubtotalBeforeUpdateHooks = append(invoiceTaxSubtotalBeforeUpdateHooks, invoiceTaxSubtotalHook)
+		invoiceTaxSubtotalBeforeUpdateMu.Unlock()
+	case boil.AfterUpdateHook:
+		invoiceTaxSubtotalAfterUpdateMu.Lock()
+		invoiceTaxSubtotalAfterUpdateHooks = append(invoiceTaxSubtotalAfterUpdateHooks, invoiceTaxSubtotalHook)
+		invoiceTaxSubtotalAfterUpdateMu.Unlock()
+	case boil.BeforeDeleteHook:
+		invoiceTaxSubtotalBeforeDeleteMu.Lock()
+		invoiceTaxSubtotalBeforeDeleteHooks = append(invoiceTaxSubtotalBeforeDeleteHooks, invoiceTaxSubtotalHook)
+		invoiceTaxSubtotalBeforeDeleteMu.Unlock()
+	case boil.AfterDeleteHook:
+		invoiceTaxSubtotalAfterDeleteMu.Lock()
+		invoiceTaxSubtotalAfterDeleteHooks = append(invoiceTaxSubtotalAfterDeleteHooks, invoiceTaxSubtotalHook)
+		invoiceTaxSubtotalAfterDeleteMu.Unlock()
+	case boil.BeforeUpsertHook:
+		invoiceTaxSubtotalBeforeUpsertMu.Lock()
+		invoiceTaxSubtotalBeforeUpsertHooks = append(invoiceTaxSubtotalBeforeUpsertHooks, invoiceTaxSubtotalHook)
+		invoiceTaxSubtotalBeforeUpsertMu.Unlock()
+	case boil.AfterUpsertHook:
+		invoiceTaxSubtotalAfterUpsertMu.Lock()
+		invoiceTaxSubtotalAfterUpsertHooks = append(invoiceTaxSubtotalAfterUpsertHooks, invoiceTaxSubtotalHook)
+		invoiceTaxSubtotalAfterUpsertMu.Unlock()
+	}
+}
+
+// One returns a single invoiceTaxSubtotal record from the query.
+func (q invoiceTaxSubtotalQuery) One(ctx context.Context, exec boil.ContextExecutor) (*InvoiceTaxSubtotal, error) {
+	o := &InvoiceTaxSubtotal{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for invoice_tax_subtotals")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all InvoiceTaxSubtotal records from the query.
+func (q invoiceTaxSubtotalQuery) All(ctx context.Context, exec boil.ContextExecutor) (InvoiceTaxSubtotalSlice, error) {
+	var o []*InvoiceTaxSubtotal
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to InvoiceTaxSubtotal slice")
+	}
+
+	if len(invoiceTaxSubtotalAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all InvoiceTaxSubtotal records in the query.
+func (q invoiceTaxSubtotalQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count invoice_tax_subtotals rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q invoiceTaxSubtotalQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if invoice_tax_subtotals exists")
+	}
+
+	return count > 0, nil
+}
+
+// Invoice pointed to by the foreign key.
+func (o *InvoiceTaxSubtotal) Invoice(mods ...qm.QueryMod) invoiceQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("`id` = ?", o.InvoiceID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	return Invoices(queryMods...)
+}
+
+// LoadInvoice allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (invoiceTaxSubtotalL) LoadInvoice(ctx context.Context, e boil.ContextExecutor, singular bool, maybeInvoiceTaxSubtotal interface{}, mods queries.Applicator) error {
+	var slice []*InvoiceTaxSubtotal
+	var object *InvoiceTaxSubtotal
+
+	if singular {
+		var ok bool
+		object, ok = maybeInvoiceTaxSubtotal.(*InvoiceTaxSubtotal)
+		if !ok {
+			object = new(InvoiceTaxSubtotal)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeInvoiceTaxSubtotal)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeInvoiceTaxSubtotal))
+			}
+		}
+	} else {
+		s, ok := maybeInvoiceTaxSubtotal.(*[]*InvoiceTaxSubtotal)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeInvoiceTaxSubtotal)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeInvoiceTaxSubtotal))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &invoiceTaxSubtotalR{}
+		}
+		args[object.InvoiceID] = struct{}{}
+
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &invoiceTaxSubtotalR{}
+			}
+
+			args[obj.InvoiceID] = struct{}{}
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`invoices`),
+		qm.WhereIn(`invoices.id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load Invoice")
+	}
+
+	var resultSlice []*Invoice
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice Invoice")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for invoices")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for invoices")
+	}
+
+	if len(invoiceAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.Invoice = foreign
+		if foreign.R == nil {
+			foreign.R = &invoiceR{}
+		}
+		foreign.R.InvoiceTaxSubtotals = append(foreign.R.InvoiceTaxSubtotals, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.InvoiceID == foreign.ID {
+				local.R.Invoice = foreign
+				if foreign.R == nil {
+					foreign.R = &invoiceR{}
+				}
+				foreign.R.InvoiceTaxSubtotals = append(foreign.R.InvoiceTaxSubtotals, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetInvoice of the invoiceTaxSubtotal to the related item.
+// Sets o.R.Invoice to related.
+// Adds o to related.R.InvoiceTaxSubtotals.
+func (o *InvoiceTaxSubtotal) SetInvoice(ctx context.Context, exec boil.ContextExecutor, insert bool, related *Invoice) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE `invoice_tax_subtotals` SET %s WHERE %s",
+		strmangle.SetParamNames("`", "`", 0, []string{"invoice_id"}),
+		strmangle.WhereClause("`", "`", 0, invoiceTaxSubtotalPrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.InvoiceID = related.ID
+	if o.R == nil {
+		o.R = &invoiceTaxSubtotalR{
+			Invoice: related,
+		}
+	} else {
+		o.R.Invoice = related
+	}
+
+	if related.R == nil {
+		related.R = &invoiceR{
+			InvoiceTaxSubtotals: InvoiceTaxSubtotalSlice{o},
+		}
+	} else {
+		related.R.InvoiceTaxSubtotals = append(related.R.InvoiceTaxSubtotals, o)
+	}
+
+	return nil
+}
+
+// InvoiceTaxSubtotals retrieves all the records using an executor.
+func InvoiceTaxSubtotals(mods ...qm.QueryMod) invoiceTaxSubtotalQuery {
+	mods = append(mods, qm.From("`invoice_tax_subtotals`"))
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"`invoice_tax_subtotals`.*"})
+	}
+
+	return invoiceTaxSubtotalQuery{q}
+}
+
+// FindInvoiceTaxSubtotal retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindInvoiceTaxSubtotal(ctx context.Context, exec boil.ContextExecutor, iD int64, selectCols ...string) (*InvoiceTaxSubtotal, error) {
+	invoiceTaxSubtotalObj := &InvoiceTaxSubtotal{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from `invoice_tax_subtotals` where `id`=?", sel,
+	)
+
+	q := queries.Raw(query, iD)
+
+	err := q.Bind(ctx, exec, invoiceTaxSubtotalObj)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from invoice_tax_subtotals")
+	}
+
+	if err = invoiceTaxSubtotalObj.doAfterSelectHooks(ctx, exec); err != nil {
+		return invoiceTaxSubtotalObj, err
+	}
+
+	return invoiceTaxSubtotalObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *InvoiceTaxSubtotal) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no invoice_tax_subtotals provided for insertion")
+	}
+
+	var err error
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(invoiceTaxSubtotalColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	invoiceTaxSubtotalInsertCacheMut.RLock()
+	cache, cached := invoiceTaxSubtotalInsertCache[key]
+	invoiceTaxSubtotalInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			invoiceTaxSubtotalAllColumns,
+			invoiceTaxSubtotalColumnsWithDefault,
+			invoiceTaxSubtotalColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(invoiceTaxSubtotalType, invoiceTaxSubtotalMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(invoiceTaxSubtotalType, invoiceTaxSubtotalMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO `invoice_tax_subtotals` (`%s`) %%sVALUES (%s)%%s", strings.Join(wl, "`,`"), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO `invoice_tax_subtotals` () VALUES ()%s%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			cache.retQuery = fmt.Sprintf("SELECT `%s` FROM `invoice_tax_subtotals` WHERE %s", strings.Join(returnColumns, "`,`"), strmangle.WhereClause("`", "`", 0, invoiceTaxSubtotalPrimaryKeyColumns))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	result, err := exec.ExecContext(ctx, cache.query, vals...)
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into invoice_tax_subtotals")
+	}
+
+	var lastID int64
+	var identifierCols []interface{}
+
+	if len(cache.retMapping) == 0 {
+		goto CacheNoHooks
+	}
+
+	lastID, err = result.LastInsertId()
+	if err != nil {
+		return ErrSyncFail
+	}
+
+	o.ID = int64(lastID)
+	if lastID != 0 && len(cache.retMapping) == 1 && cache.retMapping[0] == invoiceTaxSubtotalMapping["id"] {
+		goto CacheNoHooks
+	}
+
+	identifierCols = []interface{}{
+		o.ID,
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.retQuery)
+		fmt.Fprintln(writer, identifierCols...)
+	}
+	err = exec.QueryRowContext(ctx, cache.retQuery, identifierCols...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to populate default values for invoice_tax_subtotals")
+	}
+
+CacheNoHooks:
+	if !cached {
+		invoiceTaxSubtotalInsertCacheMut.Lock()
+		invoiceTaxSubtotalInsertCache[key] = cache
+		invoiceTaxSubtotalInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// Update uses an executor to update the InvoiceTaxSubtotal.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *InvoiceTaxSubtotal) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	invoiceTaxSubtotalUpdateCacheMut.RLock()
+	cache, cached := invoiceTaxSubtotalUpdateCache[key]
+	invoiceTaxSubtotalUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			invoiceTaxSubtotalAllColumns,
+			invoiceTaxSubtotalPrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("models: unable to update invoice_tax_subtotals, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE `invoice_tax_subtotals` SET %s WHERE %s",
+			strmangle.SetParamNames("`", "`", 0, wl),
+			strmangle.WhereClause("`", "`", 0, invoiceTaxSubtotalPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(invoiceTaxSubtotalType, invoiceTaxSubtotalMapping, append(wl, invoiceTaxSubtotalPrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update invoice_tax_subtotals row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by update for invoice_tax_subtotals")
+	}
+
+	if !cached {
+		invoiceTaxSubtotalUpdateCacheMut.Lock()
+		invoiceTaxSubtotalUpdateCache[key] = cache
+		invoiceTaxSubtotalUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q invoiceTaxSubtotalQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all for invoice_tax_subtotals")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected for invoice_tax_subtotals")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o InvoiceTaxSubtotalSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), invoiceTaxSubtotalPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE `invoice_tax_subtotals` SET %s WHERE %s",
+		strmangle.SetParamNames("`", "`", 0, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, invoiceTaxSubtotalPrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all in invoiceTaxSubtotal slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected all in update all invoiceTaxSubtotal")
+	}
+	return rowsAff, nil
+}
+
+var mySQLInvoiceTaxSubtotalUniqueColumns = []string{
+	"id",
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *InvoiceTaxSubtotal) Upsert(ctx context.Context, exec boil.ContextExecutor, updateColumns, insertColumns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no invoice_tax_subtotals provided for upsert")
+	}
+
+	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(invoiceTaxSubtotalColumnsWithDefault, o)
+	nzUniques := queries.NonZeroDefaultSet(mySQLInvoiceTaxSubtotalUniqueColumns, o)
+
+	if len(nzUniques) == 0 {
+		return errors.New("cannot upsert with a table that cannot conflict on a unique column")
+	}
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzUniques {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	invoiceTaxSubtotalUpsertCacheMut.RLock()
+	cache, cached := invoiceTaxSubtotalUpsertCache[key]
+	invoiceTaxSubtotalUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, _ := insertColumns.InsertColumnSet(
+			invoiceTaxSubtotalAllColumns,
+			invoiceTaxSubtotalColumnsWithDefault,
+			invoiceTaxSubtotalColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		update := updateColumns.UpdateColumnSet(
+			invoiceTaxSubtotalAllColumns,
+			invoiceTaxSubtotalPrimaryKeyColumns,
+		)
+
+		if !updateColumns.IsNone() && len(update) == 0 {
+			return errors.New("models: unable to upsert invoice_tax_subtotals, could not build update column list")
+		}
+
+		ret := strmangle.SetComplement(invoiceTaxSubtotalAllColumns, strmangle.SetIntersect(insert, update))
+
+		cache.query = buildUpsertQueryMySQL(dialect, "`invoice_tax_subtotals`", update, insert)
+		cache.retQuery = fmt.Sprintf(
+			"SELECT %s FROM `invoice_tax_subtotals` WHERE %s",
+			strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, ret), ","),
+			strmangle.WhereClause("`", "`", 0, nzUniques),
+		)
+
+		cache.valueMapping, err = queries.BindMapping(invoiceTaxSubtotalType, invoiceTaxSubtotalMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(invoiceTaxSubtotalType, invoiceTaxSubtotalMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	result, err := exec.ExecContext(ctx, cache.query, vals...)
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert for invoice_tax_subtotals")
+	}
+
+	var lastID int64
+	var uniqueMap []uint64
+	var nzUniqueCols []interface{}
+
+	if len(cache.retMapping) == 0 {
+		goto CacheNoHooks
+	}
+
+	lastID, err = result.LastInsertId()
+	if err != nil {
+		return ErrSyncFail
+	}
+
+	o.ID = int64(lastID)
+	if lastID != 0 && len(cache.retMapping) == 1 && cache.retMapping[0] == invoiceTaxSubtotalMapping["id"] {
+		goto CacheNoHooks
+	}
+
+	uniqueMap, err = queries.BindMapping(invoiceTaxSubtotalType, invoiceTaxSubtotalMapping, nzUniques)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to retrieve unique values for invoice_tax_subtotals")
+	}
+	nzUniqueCols = queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), uniqueMap)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.retQuery)
+		fmt.Fprintln(writer, nzUniqueCols...)
+	}
+	err = exec.QueryRowContext(ctx, cache.retQuery, nzUniqueCols...).Scan(returns...)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to populate default values for invoice_tax_subtotals")
+	}
+
+CacheNoHooks:
+	if !cached {
+		invoiceTaxSubtotalUpsertCacheMut.Lock()
+		invoiceTaxSubtotalUpsertCache[key] = cache
+		invoiceTaxSubtotalUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(ctx, exec)
+}
+
+// Delete deletes a single InvoiceTaxSubtotal record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *InvoiceTaxSubtotal) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("models: no InvoiceTaxSubtotal provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), invoiceTaxSubtotalPrimaryKeyMapping)
+	sql := "DELETE FROM `invoice_tax_subtotals` WHERE `id`=?"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete from invoice_tax_subtotals")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by delete for invoice_tax_subtotals")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q invoiceTaxSubtotalQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("models: no invoiceTaxSubtotalQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from invoice_tax_subtotals")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for invoice_tax_subtotals")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o InvoiceTaxSubtotalSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(invoiceTaxSubtotalBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), invoiceTaxSubtotalPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM `invoice_tax_subtotals` WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, invoiceTaxSubtotalPrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from invoiceTaxSubtotal slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for invoice_tax_subtotals")
+	}
+
+	if len(invoiceTaxSubtotalAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *InvoiceTaxSubtotal) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindInvoiceTaxSubtotal(ctx, exec, o.ID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *InvoiceTaxSubtotalSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := InvoiceTaxSubtotalSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), invoiceTaxSubtotalPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT `invoice_tax_subtotals`.* FROM `invoice_tax_subtotals` WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, invoiceTaxSubtotalPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in InvoiceTaxSubtotalSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// InvoiceTaxSubtotalExists checks if the InvoiceTaxSubtotal row exists.
+func InvoiceTaxSubtotalExists(ctx context.Context, exec boil.ContextExecutor, iD int64) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from `invoice_tax_subtotals` where `id`=? limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, iD)
+	}
+	row := exec.QueryRowContext(ctx, sql, iD)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if invoice_tax_subtotals exists")
+	}
+
+	return exists, nil
+}
+
+// Exists checks if the InvoiceTaxSubtotal row exists.
+func (o *InvoiceTaxSubtotal) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	return InvoiceTaxSubtotalExists(ctx, exec, o.ID)
+}
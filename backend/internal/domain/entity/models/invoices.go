@@ -34,6 +34,9 @@ type Invoice struct {
 	TaxAmount     types.Decimal `boil:"tax_amount" json:"tax_amount" toml:"tax_amount" yaml:"tax_amount"`
 	TotalAmount   types.Decimal `boil:"total_amount" json:"total_amount" toml:"total_amount" yaml:"total_amount"`
 	Status        string        `boil:"status" json:"status" toml:"status" yaml:"status"`
+	Version       int64         `boil:"version" json:"version" toml:"version" yaml:"version"`
+	Currency      string        `boil:"currency" json:"currency" toml:"currency" yaml:"currency"`
+	FXRate        types.Decimal `boil:"fx_rate" json:"fx_rate" toml:"fx_rate" yaml:"fx_rate"`
 
 	R *invoiceR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L invoiceL  `boil:"-" json:"-" toml:"-" yaml:"-"`
@@ -50,6 +53,9 @@ var InvoiceColumns = struct {
 	TaxAmount     string
 	TotalAmount   string
 	Status        string
+	Version       string
+	Currency      string
+	FXRate        string
 }{
 	ID:            "id",
 	CompanyID:     "company_id",
@@ -61,6 +67,9 @@ var InvoiceColumns = struct {
 	TaxAmount:     "tax_amount",
 	TotalAmount:   "total_amount",
 	Status:        "status",
+	Version:       "version",
+	Currency:      "currency",
+	FXRate:        "fx_rate",
 }
 
 var InvoiceTableColumns = struct {
@@ -74,6 +83,9 @@ var InvoiceTableColumns = struct {
 	TaxAmount     string
 	TotalAmount   string
 	Status        string
+	Version       string
+	Currency      string
+	FXRate        string
 }{
 	ID:            "invoices.id",
 	CompanyID:     "invoices.company_id",
@@ -85,52 +97,13 @@ var InvoiceTableColumns = struct {
 	TaxAmount:     "invoices.tax_amount",
 	TotalAmount:   "invoices.total_amount",
 	Status:        "invoices.status",
+	Version:       "invoices.version",
+	Currency:      "invoices.currency",
+	FXRate:        "invoices.fx_rate",
 }
 
 // Generated where
 
-type whereHelpertime_Time struct{ field string }
-
-func (w whereHelpertime_Time) EQ(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.EQ, x)
-}
-func (w whereHelpertime_Time) NEQ(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.NEQ, x)
-}
-func (w whereHelpertime_Time) LT(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LT, x)
-}
-func (w whereHelpertime_Time) LTE(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LTE, x)
-}
-func (w whereHelpertime_Time) GT(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GT, x)
-}
-func (w whereHelpertime_Time) GTE(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GTE, x)
-}
-
-type whereHelpertypes_Decimal struct{ field string }
-
-func (w whereHelpertypes_Decimal) EQ(x types.Decimal) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.EQ, x)
-}
-func (w whereHelpertypes_Decimal) NEQ(x types.Decimal) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.NEQ, x)
-}
-func (w whereHelpertypes_Decimal) LT(x types.Decimal) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LT, x)
-}
-func (w whereHelpertypes_Decimal) LTE(x types.Decimal) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LTE, x)
-}
-func (w whereHelpertypes_Decimal) GT(x types.Decimal) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GT, x)
-}
-func (w whereHelpertypes_Decimal) GTE(x types.Decimal) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GTE, x)
-}
-
 var InvoiceWhere = struct {
 	ID            whereHelperint64
 	CompanyID     whereHelperint64
@@ -142,6 +115,9 @@ var InvoiceWhere = struct {
 	TaxAmount     whereHelpertypes_Decimal
 	TotalAmount   whereHelpertypes_Decimal
 	Status        whereHelperstring
+	Version       whereHelperint64
+	Currency      whereHelperstring
+	FXRate        whereHelpertypes_Decimal
 }{
 	ID:            whereHelperint64{field: "`invoices`.`id`"},
 	CompanyID:     whereHelperint64{field: "`invoices`.`company_id`"},
@@ -153,21 +129,42 @@ var InvoiceWhere = struct {
 	TaxAmount:     whereHelpertypes_Decimal{field: "`invoices`.`tax_amount`"},
 	TotalAmount:   whereHelpertypes_Decimal{field: "`invoices`.`total_amount`"},
 	Status:        whereHelperstring{field: "`invoices`.`status`"},
+	Version:       whereHelperint64{field: "`invoices`.`version`"},
+	Currency:      whereHelperstring{field: "`invoices`.`currency`"},
+	FXRate:        whereHelpertypes_Decimal{field: "`invoices`.`fx_rate`"},
 }
 
 // InvoiceRels is where relationship names are stored.
 var InvoiceRels = struct {
-	Company string
-	Client  string
+	Company             string
+	Client              string
+	CreditNotes         string
+	InvoiceAttachments  string
+	InvoiceInstallments string
+	InvoiceItems        string
+	InvoicePayments     string
+	InvoiceTaxSubtotals string
 }{
-	Company: "Company",
-	Client:  "Client",
+	Company:             "Company",
+	Client:              "Client",
+	CreditNotes:         "CreditNotes",
+	InvoiceAttachments:  "InvoiceAttachments",
+	InvoiceInstallments: "InvoiceInstallments",
+	InvoiceItems:        "InvoiceItems",
+	InvoicePayments:     "InvoicePayments",
+	InvoiceTaxSubtotals: "InvoiceTaxSubtotals",
 }
 
 // invoiceR is where relationships are stored.
 type invoiceR struct {
-	Company *Company `boil:"Company" json:"Company" toml:"Company" yaml:"Company"`
-	Client  *Client  `boil:"Client" json:"Client" toml:"Client" yaml:"Client"`
+	Company             *Company                `boil:"Company" json:"Company" toml:"Company" yaml:"Company"`
+	Client              *Client                 `boil:"Client" json:"Client" toml:"Client" yaml:"Client"`
+	CreditNotes         CreditNoteSlice         `boil:"CreditNotes" json:"CreditNotes" toml:"CreditNotes" yaml:"CreditNotes"`
+	InvoiceAttachments  InvoiceAttachmentSlice  `boil:"InvoiceAttachments" json:"InvoiceAttachments" toml:"InvoiceAttachments" yaml:"InvoiceAttachments"`
+	InvoiceInstallments InvoiceInstallmentSlice `boil:"InvoiceInstallments" json:"InvoiceInstallments" toml:"InvoiceInstallments" yaml:"InvoiceInstallments"`
+	InvoiceItems        InvoiceItemSlice        `boil:"InvoiceItems" json:"InvoiceItems" toml:"InvoiceItems" yaml:"InvoiceItems"`
+	InvoicePayments     InvoicePaymentSlice     `boil:"InvoicePayments" json:"InvoicePayments" toml:"InvoicePayments" yaml:"InvoicePayments"`
+	InvoiceTaxSubtotals InvoiceTaxSubtotalSlice `boil:"InvoiceTaxSubtotals" json:"InvoiceTaxSubtotals" toml:"InvoiceTaxSubtotals" yaml:"InvoiceTaxSubtotals"`
 }
 
 // NewStruct creates a new relationship struct
@@ -189,13 +186,55 @@ func (r *invoiceR) GetClient() *Client {
 	return r.Client
 }
 
+func (r *invoiceR) GetCreditNotes() CreditNoteSlice {
+	if r == nil {
+		return nil
+	}
+	return r.CreditNotes
+}
+
+func (r *invoiceR) GetInvoiceAttachments() InvoiceAttachmentSlice {
+	if r == nil {
+		return nil
+	}
+	return r.InvoiceAttachments
+}
+
+func (r *invoiceR) GetInvoiceInstallments() InvoiceInstallmentSlice {
+	if r == nil {
+		return nil
+	}
+	return r.InvoiceInstallments
+}
+
+func (r *invoiceR) GetInvoiceItems() InvoiceItemSlice {
+	if r == nil {
+		return nil
+	}
+	return r.InvoiceItems
+}
+
+func (r *invoiceR) GetInvoicePayments() InvoicePaymentSlice {
+	if r == nil {
+		return nil
+	}
+	return r.InvoicePayments
+}
+
+func (r *invoiceR) GetInvoiceTaxSubtotals() InvoiceTaxSubtotalSlice {
+	if r == nil {
+		return nil
+	}
+	return r.InvoiceTaxSubtotals
+}
+
 // invoiceL is where Load methods for each relationship are stored.
 type invoiceL struct{}
 
 var (
-	invoiceAllColumns            = []string{"id", "company_id", "client_id", "issue_date", "due_date", "payment_amount", "fee_amount", "tax_amount", "total_amount", "status"}
+	invoiceAllColumns            = []string{"id", "company_id", "client_id", "issue_date", "due_date", "payment_amount", "fee_amount", "tax_amount", "total_amount", "status", "version", "currency", "fx_rate"}
 	invoiceColumnsWithoutDefault = []string{"company_id", "client_id", "issue_date", "due_date", "payment_amount", "fee_amount", "tax_amount", "total_amount", "status"}
-	invoiceColumnsWithDefault    = []string{"id"}
+	invoiceColumnsWithDefault    = []string{"id", "version", "currency", "fx_rate"}
 	invoicePrimaryKeyColumns     = []string{"id"}
 	invoiceGeneratedColumns      = []string{}
 )
@@ -527,6 +566,90 @@ func (o *Invoice) Client(mods ...qm.QueryMod) clientQuery {
 	return Clients(queryMods...)
 }
 
+// CreditNotes retrieves all the credit_note's CreditNotes with an executor.
+func (o *Invoice) CreditNotes(mods ...qm.QueryMod) creditNoteQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("`credit_notes`.`invoice_id`=?", o.ID),
+	)
+
+	return CreditNotes(queryMods...)
+}
+
+// InvoiceAttachments retrieves all the invoice_attachment's InvoiceAttachments with an executor.
+func (o *Invoice) InvoiceAttachments(mods ...qm.QueryMod) invoiceAttachmentQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("`invoice_attachments`.`invoice_id`=?", o.ID),
+	)
+
+	return InvoiceAttachments(queryMods...)
+}
+
+// InvoiceInstallments retrieves all the invoice_installment's InvoiceInstallments with an executor.
+func (o *Invoice) InvoiceInstallments(mods ...qm.QueryMod) invoiceInstallmentQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("`invoice_installments`.`invoice_id`=?", o.ID),
+	)
+
+	return InvoiceInstallments(queryMods...)
+}
+
+// InvoiceItems retrieves all the invoice_item's InvoiceItems with an executor.
+func (o *Invoice) InvoiceItems(mods ...qm.QueryMod) invoiceItemQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("`invoice_items`.`invoice_id`=?", o.ID),
+	)
+
+	return InvoiceItems(queryMods...)
+}
+
+// InvoicePayments retrieves all the invoice_payment's InvoicePayments with an executor.
+func (o *Invoice) InvoicePayments(mods ...qm.QueryMod) invoicePaymentQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("`invoice_payments`.`invoice_id`=?", o.ID),
+	)
+
+	return InvoicePayments(queryMods...)
+}
+
+// InvoiceTaxSubtotals retrieves all the invoice_tax_subtotal's InvoiceTaxSubtotals with an executor.
+func (o *Invoice) InvoiceTaxSubtotals(mods ...qm.QueryMod) invoiceTaxSubtotalQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("`invoice_tax_subtotals`.`invoice_id`=?", o.ID),
+	)
+
+	return InvoiceTaxSubtotals(queryMods...)
+}
+
 // LoadCompany allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for an N-1 relationship.
 func (invoiceL) LoadCompany(ctx context.Context, e boil.ContextExecutor, singular bool, maybeInvoice interface{}, mods queries.Applicator) error {
@@ -767,97 +890,1093 @@ func (invoiceL) LoadClient(ctx context.Context, e boil.ContextExecutor, singular
 	return nil
 }
 
-// SetCompany of the invoice to the related item.
-// Sets o.R.Company to related.
-// Adds o to related.R.Invoices.
-func (o *Invoice) SetCompany(ctx context.Context, exec boil.ContextExecutor, insert bool, related *Company) error {
-	var err error
-	if insert {
-		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
-			return errors.Wrap(err, "failed to insert into foreign table")
+// LoadCreditNotes allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (invoiceL) LoadCreditNotes(ctx context.Context, e boil.ContextExecutor, singular bool, maybeInvoice interface{}, mods queries.Applicator) error {
+	var slice []*Invoice
+	var object *Invoice
+
+	if singular {
+		var ok bool
+		object, ok = maybeInvoice.(*Invoice)
+		if !ok {
+			object = new(Invoice)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeInvoice)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeInvoice))
+			}
+		}
+	} else {
+		s, ok := maybeInvoice.(*[]*Invoice)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeInvoice)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeInvoice))
+			}
 		}
 	}
 
-	updateQuery := fmt.Sprintf(
-		"UPDATE `invoices` SET %s WHERE %s",
-		strmangle.SetParamNames("`", "`", 0, []string{"company_id"}),
-		strmangle.WhereClause("`", "`", 0, invoicePrimaryKeyColumns),
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &invoiceR{}
+		}
+		args[object.ID] = struct{}{}
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &invoiceR{}
+			}
+			args[obj.ID] = struct{}{}
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`credit_notes`),
+		qm.WhereIn(`credit_notes.invoice_id in ?`, argsSlice...),
 	)
-	values := []interface{}{related.ID, o.ID}
+	if mods != nil {
+		mods.Apply(query)
+	}
 
-	if boil.IsDebug(ctx) {
-		writer := boil.DebugWriterFrom(ctx)
-		fmt.Fprintln(writer, updateQuery)
-		fmt.Fprintln(writer, values)
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load credit_notes")
 	}
-	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
-		return errors.Wrap(err, "failed to update local table")
+
+	var resultSlice []*CreditNote
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice credit_notes")
 	}
 
-	o.CompanyID = related.ID
-	if o.R == nil {
-		o.R = &invoiceR{
-			Company: related,
-		}
-	} else {
-		o.R.Company = related
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on credit_notes")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for credit_notes")
 	}
 
-	if related.R == nil {
-		related.R = &companyR{
-			Invoices: InvoiceSlice{o},
+	if len(creditNoteAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
 		}
-	} else {
-		related.R.Invoices = append(related.R.Invoices, o)
+	}
+	if singular {
+		object.R.CreditNotes = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &creditNoteR{}
+			}
+			foreign.R.Invoice = object
+		}
+		return nil
 	}
 
-	return nil
-}
-
-// SetClient of the invoice to the related item.
-// Sets o.R.Client to related.
-// Adds o to related.R.Invoices.
-func (o *Invoice) SetClient(ctx context.Context, exec boil.ContextExecutor, insert bool, related *Client) error {
-	var err error
-	if insert {
-		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
-			return errors.Wrap(err, "failed to insert into foreign table")
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.InvoiceID {
+				local.R.CreditNotes = append(local.R.CreditNotes, foreign)
+				if foreign.R == nil {
+					foreign.R = &creditNoteR{}
+				}
+				foreign.R.Invoice = local
+				break
+			}
 		}
 	}
 
-	updateQuery := fmt.Sprintf(
-		"UPDATE `invoices` SET %s WHERE %s",
-		strmangle.SetParamNames("`", "`", 0, []string{"client_id"}),
-		strmangle.WhereClause("`", "`", 0, invoicePrimaryKeyColumns),
-	)
-	values := []interface{}{related.ID, o.ID}
+	return nil
+}
 
-	if boil.IsDebug(ctx) {
-		writer := boil.DebugWriterFrom(ctx)
-		fmt.Fprintln(writer, updateQuery)
-		fmt.Fprintln(writer, values)
-	}
-	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
-		return errors.Wrap(err, "failed to update local table")
-	}
+// LoadInvoiceAttachments allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (invoiceL) LoadInvoiceAttachments(ctx context.Context, e boil.ContextExecutor, singular bool, maybeInvoice interface{}, mods queries.Applicator) error {
+	var slice []*Invoice
+	var object *Invoice
 
-	o.ClientID = related.ID
-	if o.R == nil {
-		o.R = &invoiceR{
-			Client: related,
+	if singular {
+		var ok bool
+		object, ok = maybeInvoice.(*Invoice)
+		if !ok {
+			object = new(Invoice)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeInvoice)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeInvoice))
+			}
 		}
 	} else {
-		o.R.Client = related
+		s, ok := maybeInvoice.(*[]*Invoice)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeInvoice)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeInvoice))
+			}
+		}
 	}
 
-	if related.R == nil {
-		related.R = &clientR{
-			Invoices: InvoiceSlice{o},
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &invoiceR{}
 		}
+		args[object.ID] = struct{}{}
 	} else {
-		related.R.Invoices = append(related.R.Invoices, o)
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &invoiceR{}
+			}
+			args[obj.ID] = struct{}{}
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
 	}
 
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`invoice_attachments`),
+		qm.WhereIn(`invoice_attachments.invoice_id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load invoice_attachments")
+	}
+
+	var resultSlice []*InvoiceAttachment
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice invoice_attachments")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on invoice_attachments")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for invoice_attachments")
+	}
+
+	if len(invoiceAttachmentAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.InvoiceAttachments = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &invoiceAttachmentR{}
+			}
+			foreign.R.Invoice = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.InvoiceID {
+				local.R.InvoiceAttachments = append(local.R.InvoiceAttachments, foreign)
+				if foreign.R == nil {
+					foreign.R = &invoiceAttachmentR{}
+				}
+				foreign.R.Invoice = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadInvoiceInstallments allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (invoiceL) LoadInvoiceInstallments(ctx context.Context, e boil.ContextExecutor, singular bool, maybeInvoice interface{}, mods queries.Applicator) error {
+	var slice []*Invoice
+	var object *Invoice
+
+	if singular {
+		var ok bool
+		object, ok = maybeInvoice.(*Invoice)
+		if !ok {
+			object = new(Invoice)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeInvoice)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeInvoice))
+			}
+		}
+	} else {
+		s, ok := maybeInvoice.(*[]*Invoice)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeInvoice)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeInvoice))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &invoiceR{}
+		}
+		args[object.ID] = struct{}{}
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &invoiceR{}
+			}
+			args[obj.ID] = struct{}{}
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`invoice_installments`),
+		qm.WhereIn(`invoice_installments.invoice_id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load invoice_installments")
+	}
+
+	var resultSlice []*InvoiceInstallment
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice invoice_installments")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on invoice_installments")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for invoice_installments")
+	}
+
+	if len(invoiceInstallmentAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.InvoiceInstallments = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &invoiceInstallmentR{}
+			}
+			foreign.R.Invoice = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.InvoiceID {
+				local.R.InvoiceInstallments = append(local.R.InvoiceInstallments, foreign)
+				if foreign.R == nil {
+					foreign.R = &invoiceInstallmentR{}
+				}
+				foreign.R.Invoice = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadInvoiceItems allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (invoiceL) LoadInvoiceItems(ctx context.Context, e boil.ContextExecutor, singular bool, maybeInvoice interface{}, mods queries.Applicator) error {
+	var slice []*Invoice
+	var object *Invoice
+
+	if singular {
+		var ok bool
+		object, ok = maybeInvoice.(*Invoice)
+		if !ok {
+			object = new(Invoice)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeInvoice)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeInvoice))
+			}
+		}
+	} else {
+		s, ok := maybeInvoice.(*[]*Invoice)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeInvoice)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeInvoice))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &invoiceR{}
+		}
+		args[object.ID] = struct{}{}
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &invoiceR{}
+			}
+			args[obj.ID] = struct{}{}
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`invoice_items`),
+		qm.WhereIn(`invoice_items.invoice_id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load invoice_items")
+	}
+
+	var resultSlice []*InvoiceItem
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice invoice_items")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on invoice_items")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for invoice_items")
+	}
+
+	if len(invoiceItemAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.InvoiceItems = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &invoiceItemR{}
+			}
+			foreign.R.Invoice = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.InvoiceID {
+				local.R.InvoiceItems = append(local.R.InvoiceItems, foreign)
+				if foreign.R == nil {
+					foreign.R = &invoiceItemR{}
+				}
+				foreign.R.Invoice = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadInvoicePayments allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (invoiceL) LoadInvoicePayments(ctx context.Context, e boil.ContextExecutor, singular bool, maybeInvoice interface{}, mods queries.Applicator) error {
+	var slice []*Invoice
+	var object *Invoice
+
+	if singular {
+		var ok bool
+		object, ok = maybeInvoice.(*Invoice)
+		if !ok {
+			object = new(Invoice)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeInvoice)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeInvoice))
+			}
+		}
+	} else {
+		s, ok := maybeInvoice.(*[]*Invoice)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeInvoice)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeInvoice))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &invoiceR{}
+		}
+		args[object.ID] = struct{}{}
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &invoiceR{}
+			}
+			args[obj.ID] = struct{}{}
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`invoice_payments`),
+		qm.WhereIn(`invoice_payments.invoice_id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load invoice_payments")
+	}
+
+	var resultSlice []*InvoicePayment
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice invoice_payments")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on invoice_payments")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for invoice_payments")
+	}
+
+	if len(invoicePaymentAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.InvoicePayments = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &invoicePaymentR{}
+			}
+			foreign.R.Invoice = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.InvoiceID {
+				local.R.InvoicePayments = append(local.R.InvoicePayments, foreign)
+				if foreign.R == nil {
+					foreign.R = &invoicePaymentR{}
+				}
+				foreign.R.Invoice = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadInvoiceTaxSubtotals allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (invoiceL) LoadInvoiceTaxSubtotals(ctx context.Context, e boil.ContextExecutor, singular bool, maybeInvoice interface{}, mods queries.Applicator) error {
+	var slice []*Invoice
+	var object *Invoice
+
+	if singular {
+		var ok bool
+		object, ok = maybeInvoice.(*Invoice)
+		if !ok {
+			object = new(Invoice)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeInvoice)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeInvoice))
+			}
+		}
+	} else {
+		s, ok := maybeInvoice.(*[]*Invoice)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeInvoice)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeInvoice))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &invoiceR{}
+		}
+		args[object.ID] = struct{}{}
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &invoiceR{}
+			}
+			args[obj.ID] = struct{}{}
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`invoice_tax_subtotals`),
+		qm.WhereIn(`invoice_tax_subtotals.invoice_id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load invoice_tax_subtotals")
+	}
+
+	var resultSlice []*InvoiceTaxSubtotal
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice invoice_tax_subtotals")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on invoice_tax_subtotals")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for invoice_tax_subtotals")
+	}
+
+	if len(invoiceTaxSubtotalAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.InvoiceTaxSubtotals = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &invoiceTaxSubtotalR{}
+			}
+			foreign.R.Invoice = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.InvoiceID {
+				local.R.InvoiceTaxSubtotals = append(local.R.InvoiceTaxSubtotals, foreign)
+				if foreign.R == nil {
+					foreign.R = &invoiceTaxSubtotalR{}
+				}
+				foreign.R.Invoice = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetCompany of the invoice to the related item.
+// Sets o.R.Company to related.
+// Adds o to related.R.Invoices.
+func (o *Invoice) SetCompany(ctx context.Context, exec boil.ContextExecutor, insert bool, related *Company) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE `invoices` SET %s WHERE %s",
+		strmangle.SetParamNames("`", "`", 0, []string{"company_id"}),
+		strmangle.WhereClause("`", "`", 0, invoicePrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.CompanyID = related.ID
+	if o.R == nil {
+		o.R = &invoiceR{
+			Company: related,
+		}
+	} else {
+		o.R.Company = related
+	}
+
+	if related.R == nil {
+		related.R = &companyR{
+			Invoices: InvoiceSlice{o},
+		}
+	} else {
+		related.R.Invoices = append(related.R.Invoices, o)
+	}
+
+	return nil
+}
+
+// SetClient of the invoice to the related item.
+// Sets o.R.Client to related.
+// Adds o to related.R.Invoices.
+func (o *Invoice) SetClient(ctx context.Context, exec boil.ContextExecutor, insert bool, related *Client) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE `invoices` SET %s WHERE %s",
+		strmangle.SetParamNames("`", "`", 0, []string{"client_id"}),
+		strmangle.WhereClause("`", "`", 0, invoicePrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.ClientID = related.ID
+	if o.R == nil {
+		o.R = &invoiceR{
+			Client: related,
+		}
+	} else {
+		o.R.Client = related
+	}
+
+	if related.R == nil {
+		related.R = &clientR{
+			Invoices: InvoiceSlice{o},
+		}
+	} else {
+		related.R.Invoices = append(related.R.Invoices, o)
+	}
+
+	return nil
+}
+
+// AddCreditNotes adds the given related objects to the existing relationships
+// of the invoice, optionally inserting them as new records.
+// Appends related to o.R.CreditNotes.
+// Sets related.R.Invoice appropriately.
+func (o *Invoice) AddCreditNotes(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*CreditNote) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.InvoiceID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE `credit_notes` SET %s WHERE %s",
+				strmangle.SetParamNames("`", "`", 0, []string{"invoice_id"}),
+				strmangle.WhereClause("`", "`", 0, creditNotePrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.InvoiceID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &invoiceR{
+			CreditNotes: related,
+		}
+	} else {
+		o.R.CreditNotes = append(o.R.CreditNotes, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &creditNoteR{
+				Invoice: o,
+			}
+		} else {
+			rel.R.Invoice = o
+		}
+	}
+	return nil
+}
+
+// AddInvoiceAttachments adds the given related objects to the existing relationships
+// of the invoice, optionally inserting them as new records.
+// Appends related to o.R.InvoiceAttachments.
+// Sets related.R.Invoice appropriately.
+func (o *Invoice) AddInvoiceAttachments(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*InvoiceAttachment) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.InvoiceID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE `invoice_attachments` SET %s WHERE %s",
+				strmangle.SetParamNames("`", "`", 0, []string{"invoice_id"}),
+				strmangle.WhereClause("`", "`", 0, invoiceAttachmentPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.InvoiceID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &invoiceR{
+			InvoiceAttachments: related,
+		}
+	} else {
+		o.R.InvoiceAttachments = append(o.R.InvoiceAttachments, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &invoiceAttachmentR{
+				Invoice: o,
+			}
+		} else {
+			rel.R.Invoice = o
+		}
+	}
+	return nil
+}
+
+// AddInvoiceInstallments adds the given related objects to the existing relationships
+// of the invoice, optionally inserting them as new records.
+// Appends related to o.R.InvoiceInstallments.
+// Sets related.R.Invoice appropriately.
+func (o *Invoice) AddInvoiceInstallments(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*InvoiceInstallment) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.InvoiceID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE `invoice_installments` SET %s WHERE %s",
+				strmangle.SetParamNames("`", "`", 0, []string{"invoice_id"}),
+				strmangle.WhereClause("`", "`", 0, invoiceInstallmentPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.InvoiceID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &invoiceR{
+			InvoiceInstallments: related,
+		}
+	} else {
+		o.R.InvoiceInstallments = append(o.R.InvoiceInstallments, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &invoiceInstallmentR{
+				Invoice: o,
+			}
+		} else {
+			rel.R.Invoice = o
+		}
+	}
+	return nil
+}
+
+// AddInvoiceItems adds the given related objects to the existing relationships
+// of the invoice, optionally inserting them as new records.
+// Appends related to o.R.InvoiceItems.
+// Sets related.R.Invoice appropriately.
+func (o *Invoice) AddInvoiceItems(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*InvoiceItem) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.InvoiceID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE `invoice_items` SET %s WHERE %s",
+				strmangle.SetParamNames("`", "`", 0, []string{"invoice_id"}),
+				strmangle.WhereClause("`", "`", 0, invoiceItemPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.InvoiceID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &invoiceR{
+			InvoiceItems: related,
+		}
+	} else {
+		o.R.InvoiceItems = append(o.R.InvoiceItems, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &invoiceItemR{
+				Invoice: o,
+			}
+		} else {
+			rel.R.Invoice = o
+		}
+	}
+	return nil
+}
+
+// AddInvoicePayments adds the given related objects to the existing relationships
+// of the invoice, optionally inserting them as new records.
+// Appends related to o.R.InvoicePayments.
+// Sets related.R.Invoice appropriately.
+func (o *Invoice) AddInvoicePayments(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*InvoicePayment) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.InvoiceID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE `invoice_payments` SET %s WHERE %s",
+				strmangle.SetParamNames("`", "`", 0, []string{"invoice_id"}),
+				strmangle.WhereClause("`", "`", 0, invoicePaymentPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.InvoiceID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &invoiceR{
+			InvoicePayments: related,
+		}
+	} else {
+		o.R.InvoicePayments = append(o.R.InvoicePayments, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &invoicePaymentR{
+				Invoice: o,
+			}
+		} else {
+			rel.R.Invoice = o
+		}
+	}
+	return nil
+}
+
+// AddInvoiceTaxSubtotals adds the given related objects to the existing relationships
+// of the invoice, optionally inserting them as new records.
+// Appends related to o.R.InvoiceTaxSubtotals.
+// Sets related.R.Invoice appropriately.
+func (o *Invoice) AddInvoiceTaxSubtotals(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*InvoiceTaxSubtotal) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.InvoiceID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE `invoice_tax_subtotals` SET %s WHERE %s",
+				strmangle.SetParamNames("`", "`", 0, []string{"invoice_id"}),
+				strmangle.WhereClause("`", "`", 0, invoiceTaxSubtotalPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.InvoiceID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &invoiceR{
+			InvoiceTaxSubtotals: related,
+		}
+	} else {
+		o.R.InvoiceTaxSubtotals = append(o.R.InvoiceTaxSubtotals, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &invoiceTaxSubtotalR{
+				Invoice: o,
+			}
+		} else {
+			rel.R.Invoice = o
+		}
+	}
 	return nil
 }
 
@@ -0,0 +1,1460 @@
+// Code generated by SQLBoiler 4.16.2 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/sqlboiler/v4/types"
+	"github.com/volatiletech/strmangle"
+)
+
+// RecurringInvoice is an object representing the database table.
+type RecurringInvoice struct {
+	ID             int64         `boil:"id" json:"id" toml:"id" yaml:"id"`
+	CompanyID      int64         `boil:"company_id" json:"company_id" toml:"company_id" yaml:"company_id"`
+	ClientID       int64         `boil:"client_id" json:"client_id" toml:"client_id" yaml:"client_id"`
+	Frequency      string        `boil:"frequency" json:"frequency" toml:"frequency" yaml:"frequency"`
+	Every          int           `boil:"every" json:"every" toml:"every" yaml:"every"`
+	DayOfMonth     int           `boil:"day_of_month" json:"day_of_month" toml:"day_of_month" yaml:"day_of_month"`
+	StartDate      time.Time     `boil:"start_date" json:"start_date" toml:"start_date" yaml:"start_date"`
+	EndDate        null.Time     `boil:"end_date" json:"end_date,omitempty" toml:"end_date" yaml:"end_date,omitempty"`
+	MaxOccurrences int           `boil:"max_occurrences" json:"max_occurrences" toml:"max_occurrences" yaml:"max_occurrences"`
+	PaymentAmount  types.Decimal `boil:"payment_amount" json:"payment_amount" toml:"payment_amount" yaml:"payment_amount"`
+	Currency       string        `boil:"currency" json:"currency" toml:"currency" yaml:"currency"`
+	DueDays        int           `boil:"due_days" json:"due_days" toml:"due_days" yaml:"due_days"`
+	Status         string        `boil:"status" json:"status" toml:"status" yaml:"status"`
+	NextDate       null.Time     `boil:"next_date" json:"next_date,omitempty" toml:"next_date" yaml:"next_date,omitempty"`
+	Version        int64         `boil:"version" json:"version" toml:"version" yaml:"version"`
+	SkippedCount   int           `boil:"skipped_count" json:"skipped_count" toml:"skipped_count" yaml:"skipped_count"`
+
+	R *recurringInvoiceR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L recurringInvoiceL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var RecurringInvoiceColumns = struct {
+	ID             string
+	CompanyID      string
+	ClientID       string
+	Frequency      string
+	Every          string
+	DayOfMonth     string
+	StartDate      string
+	EndDate        string
+	MaxOccurrences string
+	PaymentAmount  string
+	Currency       string
+	DueDays        string
+	Status         string
+	NextDate       string
+	Version        string
+	SkippedCount   string
+}{
+	ID:             "id",
+	CompanyID:      "company_id",
+	ClientID:       "client_id",
+	Frequency:      "frequency",
+	Every:          "every",
+	DayOfMonth:     "day_of_month",
+	StartDate:      "start_date",
+	EndDate:        "end_date",
+	MaxOccurrences: "max_occurrences",
+	PaymentAmount:  "payment_amount",
+	Currency:       "currency",
+	DueDays:        "due_days",
+	Status:         "status",
+	NextDate:       "next_date",
+	Version:        "version",
+	SkippedCount:   "skipped_count",
+}
+
+var RecurringInvoiceTableColumns = struct {
+	ID             string
+	CompanyID      string
+	ClientID       string
+	Frequency      string
+	Every          string
+	DayOfMonth     string
+	StartDate      string
+	EndDate        string
+	MaxOccurrences string
+	PaymentAmount  string
+	Currency       string
+	DueDays        string
+	Status         string
+	NextDate       string
+	Version        string
+	SkippedCount   string
+}{
+	ID:             "recurring_invoices.id",
+	CompanyID:      "recurring_invoices.company_id",
+	ClientID:       "recurring_invoices.client_id",
+	Frequency:      "recurring_invoices.frequency",
+	Every:          "recurring_invoices.every",
+	DayOfMonth:     "recurring_invoices.day_of_month",
+	StartDate:      "recurring_invoices.start_date",
+	EndDate:        "recurring_invoices.end_date",
+	MaxOccurrences: "recurring_invoices.max_occurrences",
+	PaymentAmount:  "recurring_invoices.payment_amount",
+	Currency:       "recurring_invoices.currency",
+	DueDays:        "recurring_invoices.due_days",
+	Status:         "recurring_invoices.status",
+	NextDate:       "recurring_invoices.next_date",
+	Version:        "recurring_invoices.version",
+	SkippedCount:   "recurring_invoices.skipped_count",
+}
+
+// Generated where
+
+var RecurringInvoiceWhere = struct {
+	ID             whereHelperint64
+	CompanyID      whereHelperint64
+	ClientID       whereHelperint64
+	Frequency      whereHelperstring
+	Every          whereHelperint
+	DayOfMonth     whereHelperint
+	StartDate      whereHelpertime_Time
+	EndDate        whereHelpernull_Time
+	MaxOccurrences whereHelperint
+	PaymentAmount  whereHelpertypes_Decimal
+	Currency       whereHelperstring
+	DueDays        whereHelperint
+	Status         whereHelperstring
+	NextDate       whereHelpernull_Time
+	Version        whereHelperint64
+	SkippedCount   whereHelperint
+}{
+	ID:             whereHelperint64{field: "`recurring_invoices`.`id`"},
+	CompanyID:      whereHelperint64{field: "`recurring_invoices`.`company_id`"},
+	ClientID:       whereHelperint64{field: "`recurring_invoices`.`client_id`"},
+	Frequency:      whereHelperstring{field: "`recurring_invoices`.`frequency`"},
+	Every:          whereHelperint{field: "`recurring_invoices`.`every`"},
+	DayOfMonth:     whereHelperint{field: "`recurring_invoices`.`day_of_month`"},
+	StartDate:      whereHelpertime_Time{field: "`recurring_invoices`.`start_date`"},
+	EndDate:        whereHelpernull_Time{field: "`recurring_invoices`.`end_date`"},
+	MaxOccurrences: whereHelperint{field: "`recurring_invoices`.`max_occurrences`"},
+	PaymentAmount:  whereHelpertypes_Decimal{field: "`recurring_invoices`.`payment_amount`"},
+	Currency:       whereHelperstring{field: "`recurring_invoices`.`currency`"},
+	DueDays:        whereHelperint{field: "`recurring_invoices`.`due_days`"},
+	Status:         whereHelperstring{field: "`recurring_invoices`.`status`"},
+	NextDate:       whereHelpernull_Time{field: "`recurring_invoices`.`next_date`"},
+	Version:        whereHelperint64{field: "`recurring_invoices`.`version`"},
+	SkippedCount:   whereHelperint{field: "`recurring_invoices`.`skipped_count`"},
+}
+
+// RecurringInvoiceRels is where relationship names are stored.
+var RecurringInvoiceRels = struct {
+	Company string
+	Client  string
+}{
+	Company: "Company",
+	Client:  "Client",
+}
+
+// recurringInvoiceR is where relationships are stored.
+type recurringInvoiceR struct {
+	Company *Company `boil:"Company" json:"Company" toml:"Company" yaml:"Company"`
+	Client  *Client  `boil:"Client" json:"Client" toml:"Client" yaml:"Client"`
+}
+
+// NewStruct creates a new relationship struct
+func (*recurringInvoiceR) NewStruct() *recurringInvoiceR {
+	return &recurringInvoiceR{}
+}
+
+func (r *recurringInvoiceR) GetCompany() *Company {
+	if r == nil {
+		return nil
+	}
+	return r.Company
+}
+
+func (r *recurringInvoiceR) GetClient() *Client {
+	if r == nil {
+		return nil
+	}
+	return r.Client
+}
+
+// recurringInvoiceL is where Load methods for each relationship are stored.
+type recurringInvoiceL struct{}
+
+var (
+	recurringInvoiceAllColumns            = []string{"id", "company_id", "client_id", "frequency", "every", "day_of_month", "start_date", "end_date", "max_occurrences", "payment_amount", "currency", "due_days", "status", "next_date", "version", "skipped_count"}
+	recurringInvoiceColumnsWithoutDefault = []string{"company_id", "client_id", "frequency", "start_date", "end_date", "payment_amount", "currency", "due_days", "next_date"}
+	recurringInvoiceColumnsWithDefault    = []string{"id", "every", "day_of_month", "max_occurrences", "status", "version", "skipped_count"}
+	recurringInvoicePrimaryKeyColumns     = []string{"id"}
+	recurringInvoiceGeneratedColumns      = []string{}
+)
+
+type (
+	// RecurringInvoiceSlice is an alias for a slice of pointers to RecurringInvoice.
+	// This should almost always be used instead of []RecurringInvoice.
+	RecurringInvoiceSlice []*RecurringInvoice
+	// RecurringInvoiceHook is the signature for custom RecurringInvoice hook methods
+	RecurringInvoiceHook func(context.Context, boil.ContextExecutor, *RecurringInvoice) error
+
+	recurringInvoiceQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	recurringInvoiceType                 = reflect.TypeOf(&RecurringInvoice{})
+	recurringInvoiceMapping              = queries.MakeStructMapping(recurringInvoiceType)
+	recurringInvoicePrimaryKeyMapping, _ = queries.BindMapping(recurringInvoiceType, recurringInvoiceMapping, recurringInvoicePrimaryKeyColumns)
+	recurringInvoiceInsertCacheMut       sync.RWMutex
+	recurringInvoiceInsertCache          = make(map[string]insertCache)
+	recurringInvoiceUpdateCacheMut       sync.RWMutex
+	recurringInvoiceUpdateCache          = make(map[string]updateCache)
+	recurringInvoiceUpsertCacheMut       sync.RWMutex
+	recurringInvoiceUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var recurringInvoiceAfterSelectMu sync.Mutex
+var recurringInvoiceAfterSelectHooks []RecurringInvoiceHook
+
+var recurringInvoiceBeforeInsertMu sync.Mutex
+var recurringInvoiceBeforeInsertHooks []RecurringInvoiceHook
+var recurringInvoiceAfterInsertMu sync.Mutex
+var recurringInvoiceAfterInsertHooks []RecurringInvoiceHook
+
+var recurringInvoiceBeforeUpdateMu sync.Mutex
+var recurringInvoiceBeforeUpdateHooks []RecurringInvoiceHook
+var recurringInvoiceAfterUpdateMu sync.Mutex
+var recurringInvoiceAfterUpdateHooks []RecurringInvoiceHook
+
+var recurringInvoiceBeforeDeleteMu sync.Mutex
+var recurringInvoiceBeforeDeleteHooks []RecurringInvoiceHook
+var recurringInvoiceAfterDeleteMu sync.Mutex
+var recurringInvoiceAfterDeleteHooks []RecurringInvoiceHook
+
+var recurringInvoiceBeforeUpsertMu sync.Mutex
+var recurringInvoiceBeforeUpsertHooks []RecurringInvoiceHook
+var recurringInvoiceAfterUpsertMu sync.Mutex
+var recurringInvoiceAfterUpsertHooks []RecurringInvoiceHook
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *RecurringInvoice) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range recurringInvoiceAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *RecurringInvoice) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range recurringInvoiceBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *RecurringInvoice) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range recurringInvoiceAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *RecurringInvoice) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range recurringInvoiceBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *RecurringInvoice) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range recurringInvoiceAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *RecurringInvoice) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range recurringInvoiceBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *RecurringInvoice) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range recurringInvoiceAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *RecurringInvoice) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range recurringInvoiceBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *RecurringInvoice) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range recurringInvoiceAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddRecurringInvoiceHook registers your hook function for all future operations.
+func AddRecurringInvoiceHook(hookPoint boil.HookPoint, recurringInvoiceHook RecurringInvoiceHook) {
+	switch hookPoint {
+	case boil.AfterSelectHook:
+		recurringInvoiceAfterSelectMu.Lock()
+		recurringInvoiceAfterSelectHooks = append(recurringInvoiceAfterSelectHooks, recurringInvoiceHook)
+		recurringInvoiceAfterSelectMu.Unlock()
+	case boil.BeforeInsertHook:
+		recurringInvoiceBeforeInsertMu.Lock()
+		recurringInvoiceBeforeInsertHooks = append(recurringInvoiceBeforeInsertHooks, recurringInvoiceHook)
+		recurringInvoiceBeforeInsertMu.Unlock()
+	case boil.AfterInsertHook:
+		recurringInvoiceAfterInsertMu.Lock()
+		recurringInvoiceAfterInsertHooks = append(recurringInvoiceAfterInsertHooks, recurringInvoiceHook)
+		recurringInvoiceAfterInsertMu.Unlock()
+	case boil.BeforeUpdateHook:
+		recurringInvoiceBeforeUpdateMu.Lock()
+		recurringInvoiceBeforeUpdateHooks = append(recurringInvoiceBeforeUpdateHooks, recurringInvoiceHook)
+		recurringInvoiceBeforeUpdateMu.Unlock()
+	case boil.AfterUpdateHook:
+		recurringInvoiceAfterUpdateMu.Lock()
+		recurringInvoiceAfterUpdateHooks = append(recurringInvoiceAfterUpdateHooks, recurringInvoiceHook)
+		recurringInvoiceAfterUpdateMu.Unlock()
+	case boil.BeforeDeleteHook:
+		recurringInvoiceBeforeDeleteMu.Lock()
+		recurringInvoiceBeforeDeleteHooks = append(recurringInvoiceBeforeDeleteHooks, recurringInvoiceHook)
+		recurringInvoiceBeforeDeleteMu.Unlock()
+	case boil.AfterDeleteHook:
+		recurringInvoiceAfterDeleteMu.Lock()
+		recurringInvoiceAfterDeleteHooks = append(recurringInvoiceAfterDeleteHooks, recurringInvoiceHook)
+		recurringInvoiceAfterDeleteMu.Unlock()
+	case boil.BeforeUpsertHook:
+		recurringInvoiceBeforeUpsertMu.Lock()
+		recurringInvoiceBeforeUpsertHooks = append(recurringInvoiceBeforeUpsertHooks, recurringInvoiceHook)
+		recurringInvoiceBeforeUpsertMu.Unlock()
+	case boil.AfterUpsertHook:
+		recurringInvoiceAfterUpsertMu.Lock()
+		recurringInvoiceAfterUpsertHooks = append(recurringInvoiceAfterUpsertHooks, recurringInvoiceHook)
+		recurringInvoiceAfterUpsertMu.Unlock()
+	}
+}
+
+// One returns a single recurringInvoice record from the query.
+func (q recurringInvoiceQuery) One(ctx context.Context, exec boil.ContextExecutor) (*RecurringInvoice, error) {
+	o := &RecurringInvoice{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for recurring_invoices")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all RecurringInvoice records from the query.
+func (q recurringInvoiceQuery) All(ctx context.Context, exec boil.ContextExecutor) (RecurringInvoiceSlice, error) {
+	var o []*RecurringInvoice
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to RecurringInvoice slice")
+	}
+
+	if len(recurringInvoiceAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all RecurringInvoice records in the query.
+func (q recurringInvoiceQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count recurring_invoices rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q recurringInvoiceQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if recurring_invoices exists")
+	}
+
+	return count > 0, nil
+}
+
+// Company pointed to by the foreign key.
+func (o *RecurringInvoice) Company(mods ...qm.QueryMod) companyQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("`id` = ?", o.CompanyID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	return Companies(queryMods...)
+}
+
+// Client pointed to by the foreign key.
+func (o *RecurringInvoice) Client(mods ...qm.QueryMod) clientQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("`id` = ?", o.ClientID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	return Clients(queryMods...)
+}
+
+// LoadCompany allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (recurringInvoiceL) LoadCompany(ctx context.Context, e boil.ContextExecutor, singular bool, maybeRecurringInvoice interface{}, mods queries.Applicator) error {
+	var slice []*RecurringInvoice
+	var object *RecurringInvoice
+
+	if singular {
+		var ok bool
+		object, ok = maybeRecurringInvoice.(*RecurringInvoice)
+		if !ok {
+			object = new(RecurringInvoice)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeRecurringInvoice)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeRecurringInvoice))
+			}
+		}
+	} else {
+		s, ok := maybeRecurringInvoice.(*[]*RecurringInvoice)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeRecurringInvoice)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeRecurringInvoice))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &recurringInvoiceR{}
+		}
+		args[object.CompanyID] = struct{}{}
+
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &recurringInvoiceR{}
+			}
+
+			args[obj.CompanyID] = struct{}{}
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`companies`),
+		qm.WhereIn(`companies.id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load Company")
+	}
+
+	var resultSlice []*Company
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice Company")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for companies")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for companies")
+	}
+
+	if len(companyAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.Company = foreign
+		if foreign.R == nil {
+			foreign.R = &companyR{}
+		}
+		foreign.R.RecurringInvoices = append(foreign.R.RecurringInvoices, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.CompanyID == foreign.ID {
+				local.R.Company = foreign
+				if foreign.R == nil {
+					foreign.R = &companyR{}
+				}
+				foreign.R.RecurringInvoices = append(foreign.R.RecurringInvoices, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadClient allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (recurringInvoiceL) LoadClient(ctx context.Context, e boil.ContextExecutor, singular bool, maybeRecurringInvoice interface{}, mods queries.Applicator) error {
+	var slice []*RecurringInvoice
+	var object *RecurringInvoice
+
+	if singular {
+		var ok bool
+		object, ok = maybeRecurringInvoice.(*RecurringInvoice)
+		if !ok {
+			object = new(RecurringInvoice)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeRecurringInvoice)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeRecurringInvoice))
+			}
+		}
+	} else {
+		s, ok := maybeRecurringInvoice.(*[]*RecurringInvoice)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeRecurringInvoice)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeRecurringInvoice))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &recurringInvoiceR{}
+		}
+		args[object.ClientID] = struct{}{}
+
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &recurringInvoiceR{}
+			}
+
+			args[obj.ClientID] = struct{}{}
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`clients`),
+		qm.WhereIn(`clients.id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load Client")
+	}
+
+	var resultSlice []*Client
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice Client")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for clients")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for clients")
+	}
+
+	if len(clientAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.Client = foreign
+		if foreign.R == nil {
+			foreign.R = &clientR{}
+		}
+		foreign.R.RecurringInvoices = append(foreign.R.RecurringInvoices, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.ClientID == foreign.ID {
+				local.R.Client = foreign
+				if foreign.R == nil {
+					foreign.R = &clientR{}
+				}
+				foreign.R.RecurringInvoices = append(foreign.R.RecurringInvoices, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetCompany of the recurringInvoice to the related item.
+// Sets o.R.Company to related.
+// Adds o to related.R.RecurringInvoices.
+func (o *RecurringInvoice) SetCompany(ctx context.Context, exec boil.ContextExecutor, insert bool, related *Company) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE `recurring_invoices` SET %s WHERE %s",
+		strmangle.SetParamNames("`", "`", 0, []string{"company_id"}),
+		strmangle.WhereClause("`", "`", 0, recurringInvoicePrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.CompanyID = related.ID
+	if o.R == nil {
+		o.R = &recurringInvoiceR{
+			Company: related,
+		}
+	} else {
+		o.R.Company = related
+	}
+
+	if related.R == nil {
+		related.R = &companyR{
+			RecurringInvoices: RecurringInvoiceSlice{o},
+		}
+	} else {
+		related.R.RecurringInvoices = append(related.R.RecurringInvoices, o)
+	}
+
+	return nil
+}
+
+// SetClient of the recurringInvoice to the related item.
+// Sets o.R.Client to related.
+// Adds o to related.R.RecurringInvoices.
+func (o *RecurringInvoice) SetClient(ctx context.Context, exec boil.ContextExecutor, insert bool, related *Client) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE `recurring_invoices` SET %s WHERE %s",
+		strmangle.SetParamNames("`", "`", 0, []string{"client_id"}),
+		strmangle.WhereClause("`", "`", 0, recurringInvoicePrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.ClientID = related.ID
+	if o.R == nil {
+		o.R = &recurringInvoiceR{
+			Client: related,
+		}
+	} else {
+		o.R.Client = related
+	}
+
+	if related.R == nil {
+		related.R = &clientR{
+			RecurringInvoices: RecurringInvoiceSlice{o},
+		}
+	} else {
+		related.R.RecurringInvoices = append(related.R.RecurringInvoices, o)
+	}
+
+	return nil
+}
+
+// RecurringInvoices retrieves all the records using an executor.
+func RecurringInvoices(mods ...qm.QueryMod) recurringInvoiceQuery {
+	mods = append(mods, qm.From("`recurring_invoices`"))
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"`recurring_invoices`.*"})
+	}
+
+	return recurringInvoiceQuery{q}
+}
+
+// FindRecurringInvoice retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindRecurringInvoice(ctx context.Context, exec boil.ContextExecutor, iD int64, selectCols ...string) (*RecurringInvoice, error) {
+	recurringInvoiceObj := &RecurringInvoice{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from `recurring_invoices` where `id`=?", sel,
+	)
+
+	q := queries.Raw(query, iD)
+
+	err := q.Bind(ctx, exec, recurringInvoiceObj)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from recurring_invoices")
+	}
+
+	if err = recurringInvoiceObj.doAfterSelectHooks(ctx, exec); err != nil {
+		return recurringInvoiceObj, err
+	}
+
+	return recurringInvoiceObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *RecurringInvoice) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no recurring_invoices provided for insertion")
+	}
+
+	var err error
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(recurringInvoiceColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	recurringInvoiceInsertCacheMut.RLock()
+	cache, cached := recurringInvoiceInsertCache[key]
+	recurringInvoiceInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			recurringInvoiceAllColumns,
+			recurringInvoiceColumnsWithDefault,
+			recurringInvoiceColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(recurringInvoiceType, recurringInvoiceMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(recurringInvoiceType, recurringInvoiceMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO `recurring_invoices` (`%s`) %%sVALUES (%s)%%s", strings.Join(wl, "`,`"), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO `recurring_invoices` () VALUES ()%s%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			cache.retQuery = fmt.Sprintf("SELECT `%s` FROM `recurring_invoices` WHERE %s", strings.Join(returnColumns, "`,`"), strmangle.WhereClause("`", "`", 0, recurringInvoicePrimaryKeyColumns))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	result, err := exec.ExecContext(ctx, cache.query, vals...)
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into recurring_invoices")
+	}
+
+	var lastID int64
+	var identifierCols []interface{}
+
+	if len(cache.retMapping) == 0 {
+		goto CacheNoHooks
+	}
+
+	lastID, err = result.LastInsertId()
+	if err != nil {
+		return ErrSyncFail
+	}
+
+	o.ID = int64(lastID)
+	if lastID != 0 && len(cache.retMapping) == 1 && cache.retMapping[0] == recurringInvoiceMapping["id"] {
+		goto CacheNoHooks
+	}
+
+	identifierCols = []interface{}{
+		o.ID,
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.retQuery)
+		fmt.Fprintln(writer, identifierCols...)
+	}
+	err = exec.QueryRowContext(ctx, cache.retQuery, identifierCols...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to populate default values for recurring_invoices")
+	}
+
+CacheNoHooks:
+	if !cached {
+		recurringInvoiceInsertCacheMut.Lock()
+		recurringInvoiceInsertCache[key] = cache
+		recurringInvoiceInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// Update uses an executor to update the RecurringInvoice.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *RecurringInvoice) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	recurringInvoiceUpdateCacheMut.RLock()
+	cache, cached := recurringInvoiceUpdateCache[key]
+	recurringInvoiceUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			recurringInvoiceAllColumns,
+			recurringInvoicePrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("models: unable to update recurring_invoices, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE `recurring_invoices` SET %s WHERE %s",
+			strmangle.SetParamNames("`", "`", 0, wl),
+			strmangle.WhereClause("`", "`", 0, recurringInvoicePrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(recurringInvoiceType, recurringInvoiceMapping, append(wl, recurringInvoicePrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update recurring_invoices row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by update for recurring_invoices")
+	}
+
+	if !cached {
+		recurringInvoiceUpdateCacheMut.Lock()
+		recurringInvoiceUpdateCache[key] = cache
+		recurringInvoiceUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q recurringInvoiceQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all for recurring_invoices")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected for recurring_invoices")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o RecurringInvoiceSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), recurringInvoicePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE `recurring_invoices` SET %s WHERE %s",
+		strmangle.SetParamNames("`", "`", 0, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, recurringInvoicePrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all in recurringInvoice slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected all in update all recurringInvoice")
+	}
+	return rowsAff, nil
+}
+
+var mySQLRecurringInvoiceUniqueColumns = []string{
+	"id",
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *RecurringInvoice) Upsert(ctx context.Context, exec boil.ContextExecutor, updateColumns, insertColumns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no recurring_invoices provided for upsert")
+	}
+
+	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(recurringInvoiceColumnsWithDefault, o)
+	nzUniques := queries.NonZeroDefaultSet(mySQLRecurringInvoiceUniqueColumns, o)
+
+	if len(nzUniques) == 0 {
+		return errors.New("cannot upsert with a table that cannot conflict on a unique column")
+	}
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzUniques {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	recurringInvoiceUpsertCacheMut.RLock()
+	cache, cached := recurringInvoiceUpsertCache[key]
+	recurringInvoiceUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, _ := insertColumns.InsertColumnSet(
+			recurringInvoiceAllColumns,
+			recurringInvoiceColumnsWithDefault,
+			recurringInvoiceColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		update := updateColumns.UpdateColumnSet(
+			recurringInvoiceAllColumns,
+			recurringInvoicePrimaryKeyColumns,
+		)
+
+		if !updateColumns.IsNone() && len(update) == 0 {
+			return errors.New("models: unable to upsert recurring_invoices, could not build update column list")
+		}
+
+		ret := strmangle.SetComplement(recurringInvoiceAllColumns, strmangle.SetIntersect(insert, update))
+
+		cache.query = buildUpsertQueryMySQL(dialect, "`recurring_invoices`", update, insert)
+		cache.retQuery = fmt.Sprintf(
+			"SELECT %s FROM `recurring_invoices` WHERE %s",
+			strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, ret), ","),
+			strmangle.WhereClause("`", "`", 0, nzUniques),
+		)
+
+		cache.valueMapping, err = queries.BindMapping(recurringInvoiceType, recurringInvoiceMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(recurringInvoiceType, recurringInvoiceMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	result, err := exec.ExecContext(ctx, cache.query, vals...)
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert for recurring_invoices")
+	}
+
+	var lastID int64
+	var uniqueMap []uint64
+	var nzUniqueCols []interface{}
+
+	if len(cache.retMapping) == 0 {
+		goto CacheNoHooks
+	}
+
+	lastID, err = result.LastInsertId()
+	if err != nil {
+		return ErrSyncFail
+	}
+
+	o.ID = int64(lastID)
+	if lastID != 0 && len(cache.retMapping) == 1 && cache.retMapping[0] == recurringInvoiceMapping["id"] {
+		goto CacheNoHooks
+	}
+
+	uniqueMap, err = queries.BindMapping(recurringInvoiceType, recurringInvoiceMapping, nzUniques)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to retrieve unique values for recurring_invoices")
+	}
+	nzUniqueCols = queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), uniqueMap)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.retQuery)
+		fmt.Fprintln(writer, nzUniqueCols...)
+	}
+	err = exec.QueryRowContext(ctx, cache.retQuery, nzUniqueCols...).Scan(returns...)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to populate default values for recurring_invoices")
+	}
+
+CacheNoHooks:
+	if !cached {
+		recurringInvoiceUpsertCacheMut.Lock()
+		recurringInvoiceUpsertCache[key] = cache
+		recurringInvoiceUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(ctx, exec)
+}
+
+// Delete deletes a single RecurringInvoice record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *RecurringInvoice) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("models: no RecurringInvoice provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), recurringInvoicePrimaryKeyMapping)
+	sql := "DELETE FROM `recurring_invoices` WHERE `id`=?"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete from recurring_invoices")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by delete for recurring_invoices")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q recurringInvoiceQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("models: no recurringInvoiceQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from recurring_invoices")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for recurring_invoices")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o RecurringInvoiceSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(recurringInvoiceBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), recurringInvoicePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM `recurring_invoices` WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, recurringInvoicePrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from recurringInvoice slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for recurring_invoices")
+	}
+
+	if len(recurringInvoiceAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *RecurringInvoice) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindRecurringInvoice(ctx, exec, o.ID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *RecurringInvoiceSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := RecurringInvoiceSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), recurringInvoicePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT `recurring_invoices`.* FROM `recurring_invoices` WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, recurringInvoicePrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in RecurringInvoiceSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// RecurringInvoiceExists checks if the RecurringInvoice row exists.
+func RecurringInvoiceExists(ctx context.Context, exec boil.ContextExecutor, iD int64) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from `recurring_invoices` where `id`=? limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, iD)
+	}
+	row := exec.QueryRowContext(ctx, sql, iD)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if recurring_invoices exists")
+	}
+
+	return exists, nil
+}
+
+// Exists checks if the RecurringInvoice row exists.
+func (o *RecurringInvoice) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	return RecurringInvoiceExists(ctx, exec, o.ID)
+}
@@ -0,0 +1,48 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/ericlagergren/decimal"
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+	"github.com/volatiletech/sqlboiler/v4/types"
+)
+
+// The statuses of the invoices derived from their payments
+const (
+	// InvoiceStatusPaid is the status of the invoices which were paid, the others are outstanding
+	InvoiceStatusPaid = "paid"
+	// InvoiceStatusPartiallyPaid is the status of the invoices with payments which don't settle them yet
+	InvoiceStatusPartiallyPaid = "partially_paid"
+)
+
+// Payment represents a payment recorded against an invoice, in its currency. It may pay part of it
+type Payment struct {
+	ID          int64     `json:"id"`
+	InvoiceID   int64     `json:"invoice_id"`
+	PaymentDate time.Time `json:"payment_date"`
+	Amount      float64   `json:"amount"`
+	// Reference identifies the payment, e.g. the id of the bank transfer, optional
+	Reference string `json:"reference"`
+}
+
+// PaidAmount returns the total of the payments of an invoice, loaded with it
+func PaidAmount(invoice *models.Invoice) types.Decimal {
+	paid := new(decimal.Big).SetMantScale(0, 2) // 0.00 as the stored amounts
+	for _, payment := range invoice.R.GetInvoicePayments() {
+		paid.Add(paid, payment.Amount.Big)
+	}
+	return types.NewDecimal(paid)
+}
+
+// PaymentStatus returns the status of an invoice derived from its payments and credit notes, loaded with it:
+// paid once nothing is outstanding, partially paid before. An invoice without payments keeps its status
+func PaymentStatus(invoice *models.Invoice) string {
+	if len(invoice.R.GetInvoicePayments()) == 0 {
+		return invoice.Status
+	}
+	if OutstandingAmount(invoice).Big.Sign() <= 0 {
+		return InvoiceStatusPaid
+	}
+	return InvoiceStatusPartiallyPaid
+}
@@ -0,0 +1,127 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+	"github.com/volatiletech/sqlboiler/v4/types"
+)
+
+// The frequencies of the recurring invoices
+const (
+	// RecurringFrequencyMonthly bills on the day of month of the template, the last day of the shorter months
+	RecurringFrequencyMonthly = "monthly"
+	// RecurringFrequencyMonthEnd bills on the last day of the month
+	RecurringFrequencyMonthEnd = "month_end"
+	// RecurringFrequencyWeekly bills on the weekday of the start date
+	RecurringFrequencyWeekly = "weekly"
+)
+
+// RecurringFrequencies are the valid frequencies of the recurring invoices
+var RecurringFrequencies = []string{RecurringFrequencyMonthly, RecurringFrequencyMonthEnd, RecurringFrequencyWeekly}
+
+// The statuses of the recurring invoices
+const (
+	// RecurringStatusActive is the status of a recurring invoice whose occurrences are billed
+	RecurringStatusActive = "active"
+	// RecurringStatusPaused is the status of a recurring invoice whose occurrences are skipped until it is resumed
+	RecurringStatusPaused = "paused"
+)
+
+// RecurringStatuses are the valid statuses of the recurring invoices
+var RecurringStatuses = []string{RecurringStatusActive, RecurringStatusPaused}
+
+// InvoiceStatusUnprocessed is the status of the invoices generated from the recurring invoices
+const InvoiceStatusUnprocessed = "unprocessed"
+
+// RecurringInvoice represents a template of the invoices a client is billed at regular dates, every Every months
+// (monthly, month_end) or weeks (weekly) from the start date. The schedule ends after the end date or after
+// MaxOccurrences invoices, if set, the occurrences skipped while paused not counting. Each occurrence is the issue
+// date of an invoice due DueDays later
+type RecurringInvoice struct {
+	ID             int64      `json:"id"`
+	CompanyID      int64      `json:"company_id"`
+	ClientID       int64      `json:"client_id"`
+	Frequency      string     `json:"frequency"`
+	Every          int        `json:"every"`
+	DayOfMonth     int        `json:"day_of_month"`
+	StartDate      time.Time  `json:"start_date"`
+	EndDate        *time.Time `json:"end_date,omitempty"`
+	MaxOccurrences int        `json:"max_occurrences"`
+	// PaymentAmount and Currency are the ones of every invoice, the currency the base one of the company if empty
+	PaymentAmount float64 `json:"payment_amount"`
+	Currency      string  `json:"currency,omitempty"`
+	DueDays       int     `json:"due_days"`
+	Status        string  `json:"-"`
+	Version       int64   `json:"-"`
+}
+
+// RecurringInvoiceStatus is the body pausing or resuming a recurring invoice
+type RecurringInvoiceStatus struct {
+	Status string `json:"status"`
+}
+
+// Occurrence is an upcoming invoice of a recurring invoice, previewed with its amounts
+type Occurrence struct {
+	IssueDate     time.Time     `json:"issue_date"`
+	DueDate       time.Time     `json:"due_date"`
+	PaymentAmount types.Decimal `json:"payment_amount"`
+	FeeAmount     types.Decimal `json:"fee_amount"`
+	TaxAmount     types.Decimal `json:"tax_amount"`
+	TotalAmount   types.Decimal `json:"total_amount"`
+	Currency      string        `json:"currency"`
+}
+
+// NthOccurrence returns the n-th date (from 0) of the schedule of a recurring invoice, ignoring its end
+func NthOccurrence(recurring *models.RecurringInvoice, n int) time.Time {
+	start := recurring.StartDate
+	if recurring.Frequency == RecurringFrequencyWeekly {
+		return start.AddDate(0, 0, 7*recurring.Every*n)
+	}
+	// The months are counted from the first one with an occurrence on or after the start date
+	months := recurring.Every * n
+	if billingDay(recurring, start.Year(), start.Month()).Before(start) {
+		months++
+	}
+	return billingDay(recurring, start.Year(), start.Month()+time.Month(months))
+}
+
+// NextOccurrence returns the first date of the schedule of a recurring invoice on or after from,
+// false if the schedule ends before
+func NextOccurrence(recurring *models.RecurringInvoice, from time.Time) (time.Time, bool) {
+	n, ok := NextOccurrenceIndex(recurring, from)
+	if !ok {
+		return time.Time{}, false
+	}
+	return NthOccurrence(recurring, n), true
+}
+
+// NextOccurrenceIndex returns the n of NthOccurrence of the first date of the schedule of a recurring invoice
+// on or after from, false if the schedule ends before
+func NextOccurrenceIndex(recurring *models.RecurringInvoice, from time.Time) (int, bool) {
+	for n := 0; InSchedule(recurring, n); n++ {
+		if !NthOccurrence(recurring, n).Before(from) {
+			return n, true
+		}
+	}
+	return 0, false
+}
+
+// InSchedule reports whether the n-th date (from 0) of the schedule of a recurring invoice is before its end:
+// within its maximum number of occurrences, less the ones it skipped, and on or before its end date
+func InSchedule(recurring *models.RecurringInvoice, n int) bool {
+	if recurring.MaxOccurrences != 0 && n-recurring.SkippedCount >= recurring.MaxOccurrences {
+		return false
+	}
+	return !recurring.EndDate.Valid || !NthOccurrence(recurring, n).After(recurring.EndDate.Time)
+}
+
+// billingDay returns the day of a month a monthly recurring invoice is billed
+func billingDay(recurring *models.RecurringInvoice, year int, month time.Month) time.Time {
+	// Day 0 of the next month is the last day of this one, time.Date normalizing the months after December
+	last := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC)
+	if recurring.Frequency == RecurringFrequencyMonthEnd || recurring.DayOfMonth >= last.Day() {
+		return last
+	}
+	return time.Date(last.Year(), last.Month(), recurring.DayOfMonth, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,280 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/ericlagergren/decimal"
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+	"github.com/volatiletech/sqlboiler/v4/types"
+)
+
+// The aging buckets, by days past the due date
+const (
+	AgingCurrent   = iota // not due yet, or due on the day
+	AgingDays1To30        // 1 to 30 days late
+	AgingDays31To60
+	AgingDays61To90
+	AgingOver90
+	// AgingBuckets is the number of buckets
+	AgingBuckets
+)
+
+// AgingBucketNames are the names of the buckets, as written in the CSV header
+var AgingBucketNames = [AgingBuckets]string{"current", "1-30", "31-60", "61-90", "90+"}
+
+// AgingCutoffs returns the earliest due date of each bucket but the last one, as of a date:
+// an invoice is in the first bucket whose cutoff is on or before its due date, in the last one otherwise
+func AgingCutoffs(asOf time.Time) [AgingBuckets - 1]time.Time {
+	asOf = time.Date(asOf.Year(), asOf.Month(), asOf.Day(), 0, 0, 0, 0, time.UTC)
+	return [AgingBuckets - 1]time.Time{asOf, asOf.AddDate(0, 0, -30), asOf.AddDate(0, 0, -60), asOf.AddDate(0, 0, -90)}
+}
+
+// AgingBucketOf returns the bucket of an invoice due on dueDate, as of a date
+func AgingBucketOf(asOf time.Time, dueDate time.Time) int {
+	dueDate = time.Date(dueDate.Year(), dueDate.Month(), dueDate.Day(), 0, 0, 0, 0, time.UTC)
+	for bucket, cutoff := range AgingCutoffs(asOf) {
+		if !dueDate.Before(cutoff) {
+			return bucket
+		}
+	}
+	return AgingOver90
+}
+
+// AgingAmount is the number and the total amount of outstanding invoices
+type AgingAmount struct {
+	Count  int64         `json:"count"`
+	Amount types.Decimal `json:"amount"`
+}
+
+// NewAgingAmount returns an empty amount, 0.00
+func NewAgingAmount() AgingAmount {
+	return AgingAmount{Amount: types.NewDecimal(decimal.New(0, 2))}
+}
+
+// Add adds invoices to the amount
+func (a *AgingAmount) Add(count int64, amount types.Decimal) {
+	a.Count += count
+	addDecimal(&a.Amount, amount)
+}
+
+// addDecimal adds an amount to a sum, a NULL amount counting as 0
+func addDecimal(sum *types.Decimal, amount types.Decimal) {
+	if amount.Big != nil {
+		*sum = types.NewDecimal(new(decimal.Big).Add(sum.Big, amount.Big))
+	}
+}
+
+// AgingBreakdown is the outstanding invoices of every bucket, and their total, in base currency
+type AgingBreakdown struct {
+	Current    AgingAmount `json:"current"`
+	Days1To30  AgingAmount `json:"days_1_30"`
+	Days31To60 AgingAmount `json:"days_31_60"`
+	Days61To90 AgingAmount `json:"days_61_90"`
+	Over90     AgingAmount `json:"days_over_90"`
+	Total      AgingAmount `json:"total"`
+	// Currencies are the totals in the currencies of the invoices, before conversion
+	Currencies []*OriginalAmount `json:"currencies"`
+}
+
+// NewAgingBreakdown returns a breakdown without invoices
+func NewAgingBreakdown() AgingBreakdown {
+	return AgingBreakdown{
+		Current: NewAgingAmount(), Days1To30: NewAgingAmount(), Days31To60: NewAgingAmount(),
+		Days61To90: NewAgingAmount(), Over90: NewAgingAmount(), Total: NewAgingAmount(),
+		Currencies: []*OriginalAmount{},
+	}
+}
+
+// Bucket returns the amount of a bucket
+func (b *AgingBreakdown) Bucket(bucket int) *AgingAmount {
+	switch bucket {
+	case AgingCurrent:
+		return &b.Current
+	case AgingDays1To30:
+		return &b.Days1To30
+	case AgingDays31To60:
+		return &b.Days31To60
+	case AgingDays61To90:
+		return &b.Days61To90
+	default:
+		return &b.Over90
+	}
+}
+
+// Add adds invoices to a bucket and to the total
+func (b *AgingBreakdown) Add(bucket int, count int64, amount types.Decimal) {
+	b.Bucket(bucket).Add(count, amount)
+	b.Total.Add(count, amount)
+}
+
+// AddOriginal adds invoices to the total of their currency
+func (b *AgingBreakdown) AddOriginal(currency string, count int64, amount types.Decimal) {
+	b.Currencies = addOriginal(b.Currencies, currency, count, amount)
+}
+
+// ClientAging is the aging of the outstanding invoices of a client
+type ClientAging struct {
+	ClientID   int64  `json:"client_id"`
+	ClientName string `json:"client_name"`
+	AgingBreakdown
+}
+
+// AgingReport buckets the outstanding invoices by how late they are, as of a date
+type AgingReport struct {
+	AsOf string `json:"as_of"`
+	// BaseCurrency is the currency of the amounts, the base currency of the companies of the invoices
+	BaseCurrency string         `json:"base_currency"`
+	Totals       AgingBreakdown `json:"totals"`
+	Clients      []*ClientAging `json:"clients"`
+}
+
+// The granularities of the cash forecast, the length of its periods
+const (
+	GranularityDay   = "day"
+	GranularityWeek  = "week"
+	GranularityMonth = "month"
+)
+
+// Granularities are the valid granularities of the cash forecast
+var Granularities = []string{GranularityDay, GranularityWeek, GranularityMonth}
+
+// PeriodStart returns the first day of the period containing a date: the day itself, the Monday of its week,
+// or the first day of its month
+func PeriodStart(granularity string, date time.Time) time.Time {
+	date = time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	switch granularity {
+	case GranularityWeek:
+		return date.AddDate(0, 0, -(int(date.Weekday())+6)%7)
+	case GranularityMonth:
+		return date.AddDate(0, 0, 1-date.Day())
+	default:
+		return date
+	}
+}
+
+// NextPeriod returns the first day of the period following the one starting on start
+func NextPeriod(granularity string, start time.Time) time.Time {
+	switch granularity {
+	case GranularityWeek:
+		return start.AddDate(0, 0, 7)
+	case GranularityMonth:
+		return start.AddDate(0, 1, 0)
+	default:
+		return start.AddDate(0, 0, 1)
+	}
+}
+
+// NextBusinessDay returns the first weekday after a date. Public holidays are not taken into account
+func NextBusinessDay(date time.Time) time.Time {
+	date = time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, 1)
+	for date.Weekday() == time.Saturday || date.Weekday() == time.Sunday {
+		date = date.AddDate(0, 0, 1)
+	}
+	return date
+}
+
+// CashFlow is the number of invoices to pay and their amounts in base currency, the fee and the tax being separated
+type CashFlow struct {
+	Count         int64         `json:"count"`
+	PaymentAmount types.Decimal `json:"payment_amount"`
+	FeeAmount     types.Decimal `json:"fee_amount"`
+	TaxAmount     types.Decimal `json:"tax_amount"`
+	// TotalAmount is what is left to pay, less the partial payments already recorded
+	TotalAmount types.Decimal `json:"total_amount"`
+	// Currencies are the total amounts in the currencies of the invoices, before conversion
+	Currencies []*OriginalAmount `json:"currencies"`
+}
+
+// NewCashFlow returns a cash flow without invoices, of 0.00
+func NewCashFlow() CashFlow {
+	zero := func() types.Decimal { return types.NewDecimal(decimal.New(0, 2)) }
+	return CashFlow{PaymentAmount: zero(), FeeAmount: zero(), TaxAmount: zero(), TotalAmount: zero(), Currencies: []*OriginalAmount{}}
+}
+
+// Add adds the invoices of another cash flow
+func (f *CashFlow) Add(other CashFlow) {
+	f.Count += other.Count
+	addDecimal(&f.PaymentAmount, other.PaymentAmount)
+	addDecimal(&f.FeeAmount, other.FeeAmount)
+	addDecimal(&f.TaxAmount, other.TaxAmount)
+	addDecimal(&f.TotalAmount, other.TotalAmount)
+	for _, original := range other.Currencies {
+		f.Currencies = addOriginal(f.Currencies, original.Currency, original.Count, original.Amount)
+	}
+}
+
+// DueCashFlow is the cash flow of the open invoices due on a date
+type DueCashFlow struct {
+	DueDate time.Time
+	CashFlow
+}
+
+// CashForecastPeriod is the cash flow of the invoices due in a period, from Start to End included
+type CashForecastPeriod struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+	CashFlow
+	// RemainingBalance is the available balance minus the payouts up to the end of the period, null unless compared
+	RemainingBalance types.NullDecimal `json:"remaining_balance"`
+}
+
+// CashForecast is the upcoming payouts of the open invoices, by period of their due date
+type CashForecast struct {
+	From        string `json:"from"`
+	To          string `json:"to"`
+	Granularity string `json:"granularity"`
+	// CompanyID is the company of the invoices, 0 for every company
+	CompanyID int64 `json:"company_id"`
+	// BaseCurrency is the currency of the amounts, the base currency of the companies of the invoices
+	BaseCurrency string `json:"base_currency"`
+	// ProjectOverdue tells whether the overdue invoices are moved to the next business day
+	ProjectOverdue bool     `json:"project_overdue"`
+	Totals         CashFlow `json:"totals"`
+	// AvailableBalance is the balance the payouts are compared to, null unless compared
+	AvailableBalance types.NullDecimal `json:"available_balance"`
+	// ShortfallFrom is the start of the first period the payouts exceed the available balance, if they do
+	ShortfallFrom string                `json:"shortfall_from,omitempty"`
+	Periods       []*CashForecastPeriod `json:"periods"`
+}
+
+// IssuerCheck is an invoice with the registration number of its client, and the imported issuer of that number
+type IssuerCheck struct {
+	InvoiceID   int64
+	CompanyID   int64
+	ClientID    int64
+	ClientName  string
+	IssueDate   time.Time
+	Currency    string
+	TaxAmount   types.Decimal
+	TotalAmount types.Decimal
+	// RegistrationNumber is the registration number of the client, empty if it has none
+	RegistrationNumber string
+	// Issuer is the imported issuer of the registration number, nil if there is none
+	Issuer *models.InvoiceIssuer
+}
+
+// FlaggedInvoice is an invoice whose client was not a registered qualified invoice issuer on the issue date
+type FlaggedInvoice struct {
+	InvoiceID          int64  `json:"invoice_id"`
+	CompanyID          int64  `json:"company_id"`
+	ClientID           int64  `json:"client_id"`
+	ClientName         string `json:"client_name"`
+	RegistrationNumber string `json:"registration_number"`
+	IssueDate          string `json:"issue_date"`
+	Currency           string `json:"currency"`
+	// TaxAmount is the consumption tax the invoice includes, which can't be deducted in full
+	TaxAmount   types.Decimal `json:"tax_amount"`
+	TotalAmount types.Decimal `json:"total_amount"`
+	// Status tells why the client was not registered, one of the Issuer statuses but IssuerRegistered
+	Status string `json:"status"`
+}
+
+// IssuerReport flags the invoices issued in a period, from From to To included, whose client was not a registered
+// qualified invoice issuer on the issue date according to the imported issuers
+type IssuerReport struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	// CompanyID is the company of the invoices, 0 for every company
+	CompanyID int64             `json:"company_id"`
+	Invoices  []*FlaggedInvoice `json:"invoices"`
+}
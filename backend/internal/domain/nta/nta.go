@@ -0,0 +1,148 @@
+// Package nta reads the qualified invoice issuers published by the National Tax Agency (国税庁適格請求書発行事業者
+// 公表サイト) in its downloadable CSV files: the full data, split by prefecture for the corporations, and the daily
+// differences. The files have no header; each line is the state of an issuer after a change, with the columns of the
+// Web-API: sequenceNumber, registratedNumber, process, correct, kind, country, latest, registrationDate, updateDate,
+// disposalDate, expireDate, the addresses, kana, name and the other names
+package nta
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/niko-cb/uct/internal/domain/entity"
+	"github.com/niko-cb/uct/internal/domain/tax"
+)
+
+// ErrInvalidRecord is returned for a line which is not an issuer of the dataset
+var ErrInvalidRecord = errors.New("invalid record of the qualified invoice issuers")
+
+// The columns read, by position
+const (
+	columnNumber       = 1
+	columnProcess      = 2
+	columnLatest       = 6
+	columnRegistration = 7
+	columnDisposal     = 9
+	columnExpire       = 10
+	columnName         = 18
+	// minColumns is the number of columns up to the name, the later ones being ignored
+	minColumns = columnName + 1
+)
+
+// processDeleted is the process (事業者処理区分) of the issuers removed from the published ones; the others are
+// 01 registered, 02 changed, 03 expired and 04 revoked
+const processDeleted = "99"
+
+// latest is the latest flag (最新履歴) of the lines giving the current state of an issuer, the others being history
+const latest = "1"
+
+// dateFormat is the format of the dates of the dataset
+const dateFormat = "2006-01-02"
+
+// byteOrderMark may start the files of the Unicode version of the dataset
+const byteOrderMark = "\ufeff"
+
+// Reader reads the issuers of a CSV file of the dataset. Only the Unicode (UTF-8) version is supported:
+// the lines of the Shift-JIS version are rejected as invalid
+type Reader struct {
+	csv *csv.Reader
+}
+
+// NewReader returns a reader of the issuers of a CSV file
+func NewReader(r io.Reader) *Reader {
+	buffered := bufio.NewReader(r)
+	if start, err := buffered.Peek(len(byteOrderMark)); err == nil && string(start) == byteOrderMark {
+		_, _ = buffered.Discard(len(byteOrderMark))
+	}
+	reader := csv.NewReader(buffered)
+	reader.FieldsPerRecord = -1
+	reader.ReuseRecord = true
+	return &Reader{csv: reader}
+}
+
+// Read returns the next issuer, skipping the history lines, or io.EOF at the end of the file.
+// A deleted issuer has its registration number only, and Deleted set
+func (r *Reader) Read() (*entity.InvoiceIssuer, error) {
+	for {
+		record, err := r.csv.Read()
+		if err != nil {
+			return nil, err
+		}
+		line, _ := r.csv.FieldPos(0)
+		if len(record) < minColumns {
+			return nil, fmt.Errorf("%w: line %d: %d columns, expected at least %d", ErrInvalidRecord, line, len(record), minColumns)
+		}
+		if record[columnLatest] != latest {
+			continue
+		}
+
+		issuer, err := parseIssuer(record)
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %s", ErrInvalidRecord, line, err)
+		}
+		return issuer, nil
+	}
+}
+
+// All returns the issuers of the file, then the error which stopped the reading if any, io.EOF excluded
+func (r *Reader) All() iter.Seq2[*entity.InvoiceIssuer, error] {
+	return func(yield func(*entity.InvoiceIssuer, error) bool) {
+		for {
+			issuer, err := r.Read()
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			if !yield(issuer, err) || err != nil {
+				return
+			}
+		}
+	}
+}
+
+// parseIssuer converts the latest line of an issuer
+func parseIssuer(record []string) (*entity.InvoiceIssuer, error) {
+	number, err := tax.ParseRegistrationNumber(record[columnNumber])
+	if err != nil {
+		return nil, err
+	}
+	if record[columnProcess] == processDeleted {
+		return &entity.InvoiceIssuer{RegistrationNumber: number, Deleted: true}, nil
+	}
+
+	issuer := &entity.InvoiceIssuer{RegistrationNumber: number, Name: strings.TrimSpace(record[columnName])}
+	if !utf8.ValidString(issuer.Name) {
+		return nil, errors.New("the name is not UTF-8, expected the Unicode version of the dataset")
+	}
+	if issuer.RegisteredOn, err = parseDate(record[columnRegistration], "registration date"); err != nil {
+		return nil, err
+	}
+	if issuer.RegisteredOn.IsZero() {
+		return nil, errors.New("no registration date")
+	}
+	if issuer.RevokedOn, err = parseDate(record[columnDisposal], "disposal date"); err != nil {
+		return nil, err
+	}
+	if issuer.ExpiredOn, err = parseDate(record[columnExpire], "expire date"); err != nil {
+		return nil, err
+	}
+	return issuer, nil
+}
+
+// parseDate parses an optional date, zero if empty
+func parseDate(s, name string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	date, err := time.Parse(dateFormat, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid %s %q, expected YYYY-MM-DD", name, s)
+	}
+	return date, nil
+}
@@ -0,0 +1,82 @@
+package nta_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/niko-cb/uct/internal/domain/entity"
+	"github.com/niko-cb/uct/internal/domain/nta"
+)
+
+func date(s string) time.Time {
+	d, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// TestReader reads the latest state of the issuers of a sample file, with a byte order mark and quoted fields
+func TestReader(t *testing.T) {
+	f, err := os.Open(filepath.Join("testdata", "issuers.csv"))
+	require.NoError(t, err)
+	defer f.Close()
+
+	var issuers []*entity.InvoiceIssuer
+	for issuer, err := range nta.NewReader(f).All() {
+		require.NoError(t, err)
+		issuers = append(issuers, issuer)
+	}
+
+	assert.Equal(t, []*entity.InvoiceIssuer{
+		{RegistrationNumber: "T2010401000001", Name: "株式会社サンプル商事", RegisteredOn: date("2023-10-01")},
+		// The history line of the former name is skipped
+		{RegistrationNumber: "T2120001000002", Name: "新名物産株式会社", RegisteredOn: date("2023-10-01")},
+		{RegistrationNumber: "T4010001000003", Name: "株式会社失効サンプル", RegisteredOn: date("2023-10-01"), ExpiredOn: date("2024-06-30")},
+		{RegistrationNumber: "T1011001000004", Name: "取消サンプル合同会社", RegisteredOn: date("2023-10-01"), RevokedOn: date("2024-03-31")},
+		{RegistrationNumber: "T8200001000005", Deleted: true},
+		{RegistrationNumber: "T5300001000006", Name: "山田 太郎", RegisteredOn: date("2024-01-01")},
+	}, issuers)
+}
+
+// TestReader_Invalid tests the lines which are not issuers stop the reading
+func TestReader_Invalid(t *testing.T) {
+	valid := []string{"1", "T2010401000001", "01", "0", "2", "1", "1", "2023-10-01", "2023-10-01", "", "",
+		"", "", "", "", "", "", "", "株式会社サンプル商事"}
+	tests := []struct {
+		name   string
+		column int
+		value  string
+	}{
+		{"check digit", 1, "T1010401000001"},
+		{"registration date", 7, "2023/10/01"},
+		{"no registration date", 7, ""},
+		{"expire date", 10, "R6.6.30"},
+		{"Shift-JIS name", 18, "\x8a\x94\x8e\xae\x89\xef\x8e\xd0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			record := append([]string(nil), valid...)
+			record[tt.column] = tt.value
+			content := strings.Join(valid, ",") + "\n" + strings.Join(record, ",") + "\n"
+
+			reader := nta.NewReader(strings.NewReader(content))
+			_, err := reader.Read()
+			require.NoError(t, err)
+			_, err = reader.Read()
+			assert.ErrorIs(t, err, nta.ErrInvalidRecord)
+			assert.ErrorContains(t, err, "line 2")
+		})
+	}
+
+	t.Run("columns", func(t *testing.T) {
+		_, err := nta.NewReader(strings.NewReader("date,currency,base_currency,rate\n")).Read()
+		assert.ErrorIs(t, err, nta.ErrInvalidRecord)
+	})
+}
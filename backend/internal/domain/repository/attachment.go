@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+)
+
+// AttachmentRepository is an interface for interacting with the attachment gateway.
+// It stores the metadata of the attachments, their content is in the BlobStore
+type AttachmentRepository interface {
+	// CreateAttachment saves the attachment unless the invoice already has one with the same SHA-256, and fills it
+	// with the stored row either way. It returns whether the attachment was created
+	CreateAttachment(ctx context.Context, attachment *models.InvoiceAttachment) (bool, error)
+	GetAttachment(ctx context.Context, id int64) (*models.InvoiceAttachment, error)
+	// GetAttachmentsByInvoice returns the attachments of an invoice ordered by id
+	GetAttachmentsByInvoice(ctx context.Context, invoiceID int64) ([]*models.InvoiceAttachment, error)
+}
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+	"io"
+)
+
+// BlobStore is an interface for storing the content of files, e.g. the attachments of the invoices, by key.
+// The content of a key is never changed once stored
+type BlobStore interface {
+	Put(ctx context.Context, key string, contentType string, content []byte) error
+	// Get returns the content of the key, ErrNotFound if there is none. The caller closes it
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Exists(ctx context.Context, key string) (bool, error)
+}
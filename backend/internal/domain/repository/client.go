@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+)
+
+// ClientRepository is an interface for interacting with the client gateway, clients and their bank accounts
+type ClientRepository interface {
+	// CreateClient saves a client and sets its id and version
+	CreateClient(ctx context.Context, client *models.Client) error
+	// CreateBankAccount saves a bank account of a client and sets its id and version
+	CreateBankAccount(ctx context.Context, account *models.BankAccount) error
+	// GetClient returns a client, or ErrNotFound
+	GetClient(ctx context.Context, id int64) (*models.Client, error)
+	// FindClient returns the client of a company with a registration number, else the one with the name and
+	// without registration number (the lowest id of them), or ErrNotFound
+	FindClient(ctx context.Context, companyID int64, registrationNumber string, name string) (*models.Client, error)
+	// UpdateClientRegistrationNumber sets the registration number of a client if it is still at version, or returns
+	// ErrVersionConflict (ErrNotFound without client)
+	UpdateClientRegistrationNumber(ctx context.Context, id int64, version int64, registrationNumber string) error
+}
@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/types"
+)
+
+// CompanyRepository is an interface for interacting with the company gateway
+type CompanyRepository interface {
+	// CreateCompany saves a company and sets its id
+	CreateCompany(ctx context.Context, company *models.Company) error
+	// GetCompany returns a company, or ErrNotFound
+	GetCompany(ctx context.Context, id int64) (*models.Company, error)
+	// UpdateAvailableBalance sets the balance available to pay the invoices of a company (NULL to unset it), or returns ErrNotFound
+	UpdateAvailableBalance(ctx context.Context, id int64, balance types.NullDecimal) error
+	// UpdateTaxRounding sets how the consumption tax of the invoices of a company is rounded, or returns ErrNotFound
+	UpdateTaxRounding(ctx context.Context, id int64, rounding string) error
+	// UpdateRegistrationNumber sets the registration number of a company as qualified invoice issuer (NULL to unset it),
+	// or returns ErrNotFound
+	UpdateRegistrationNumber(ctx context.Context, id int64, registrationNumber null.String) error
+}
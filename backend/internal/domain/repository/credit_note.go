@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+)
+
+// CreditNoteRepository is an interface for interacting with the credit note gateway.
+// The credit notes are also loaded with their invoice by the InvoiceRepository, in invoice.R.CreditNotes
+type CreditNoteRepository interface {
+	CreateCreditNote(ctx context.Context, creditNote *models.CreditNote) error
+	GetCreditNote(ctx context.Context, id int64) (*models.CreditNote, error)
+	// GetCreditNotesByInvoice returns the credit notes of an invoice ordered by id, none if there is no such invoice
+	GetCreditNotesByInvoice(ctx context.Context, invoiceID int64) ([]*models.CreditNote, error)
+	// GetCreditNotesByDateRange returns the credit notes issued between from and to, ordered by id
+	GetCreditNotesByDateRange(ctx context.Context, from time.Time, to time.Time, page Page) ([]*models.CreditNote, error)
+
+	// UpdateCreditNoteStatus sets the status of the credit note if its stored version is still version, and increments it.
+	// It returns ErrVersionConflict if the credit note was changed in the meantime, and ErrNotFound if there is none
+	UpdateCreditNoteStatus(ctx context.Context, id int64, version int64, status string) error
+}
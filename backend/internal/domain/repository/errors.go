@@ -0,0 +1,12 @@
+package repository
+
+import "errors"
+
+var (
+	// ErrNotFound is returned when the requested row does not exist
+	ErrNotFound = errors.New("not found")
+
+	// ErrVersionConflict is returned when a row was changed by someone else since it was read:
+	// its version no longer matches the expected one
+	ErrVersionConflict = errors.New("version conflict")
+)
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+)
+
+// FXRateRepository is an interface for the daily exchange rates
+type FXRateRepository interface {
+	// UpsertFXRates saves rates in a single statement, replacing the rate of the same currencies on the same day.
+	// The rates must be unique by currencies and day
+	UpsertFXRates(ctx context.Context, rates []*models.FXRate) error
+	// GetFXRate returns the latest rate of a currency in a base currency on or before a day, or ErrNotFound
+	GetFXRate(ctx context.Context, currency, baseCurrency string, on time.Time) (*models.FXRate, error)
+}
@@ -2,14 +2,28 @@ package repository
 
 import (
 	"context"
-	"database/sql"
 	"time"
 
 	"github.com/niko-cb/uct/internal/domain/entity/models"
 )
 
-// InvoiceRepository is an interface for interacting with the invoice gateway
+// InvoiceRepository is an interface for interacting with the invoice gateway.
+// The items of an invoice are saved and loaded with it, in invoice.R.InvoiceItems numbered by their order,
+// and so are its installments, in invoice.R.InvoiceInstallments numbered by their order
 type InvoiceRepository interface {
-	CreateInvoice(ctx context.Context, tx *sql.Tx, invoice *models.Invoice) error
-	GetInvoicesByDateRange(ctx context.Context, from time.Time, to time.Time) ([]*models.Invoice, error)
+	CreateInvoice(ctx context.Context, invoice *models.Invoice) error
+	GetInvoice(ctx context.Context, id int64) (*models.Invoice, error)
+	GetInvoicesByDateRange(ctx context.Context, from time.Time, to time.Time, page Page) ([]*models.Invoice, error)
+	// GetInvoicesByInstallmentDateRange returns the invoices with an installment due between from and to, ordered by id
+	GetInvoicesByInstallmentDateRange(ctx context.Context, from time.Time, to time.Time, page Page) ([]*models.Invoice, error)
+
+	// UpdateInvoice saves the invoice and replaces its items if its stored version is still invoice.Version, and increments it.
+	// It returns ErrVersionConflict if the invoice was changed in the meantime, and ErrNotFound if it was deleted
+	UpdateInvoice(ctx context.Context, invoice *models.Invoice) error
+
+	// UpdateInvoiceStatus sets the status of the invoice only, with the same version check and errors as UpdateInvoice
+	UpdateInvoiceStatus(ctx context.Context, id int64, version int64, status string) error
+
+	// DeleteInvoice deletes the invoice if its stored version is still version, with the same errors as UpdateInvoice
+	DeleteInvoice(ctx context.Context, id int64, version int64) error
 }
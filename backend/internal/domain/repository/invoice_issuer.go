@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+)
+
+// InvoiceIssuerRepository is an interface for the qualified invoice issuers published by the National Tax Agency
+type InvoiceIssuerRepository interface {
+	// UpsertInvoiceIssuers saves issuers in a single statement, replacing the ones of the same registration number.
+	// The issuers must be unique by registration number
+	UpsertInvoiceIssuers(ctx context.Context, issuers []*models.InvoiceIssuer) error
+	// DeleteInvoiceIssuers deletes the issuers of registration numbers, the ones not saved being ignored
+	DeleteInvoiceIssuers(ctx context.Context, registrationNumbers []string) error
+	// GetInvoiceIssuer returns the issuer of a registration number, or ErrNotFound
+	GetInvoiceIssuer(ctx context.Context, registrationNumber string) (*models.InvoiceIssuer, error)
+}
@@ -0,0 +1,8 @@
+package repository
+
+// Page selects a page of a list ordered by id (keyset pagination):
+// the rows after AfterID, at most Limit of them. The zero Page selects everything
+type Page struct {
+	AfterID int64
+	Limit   int
+}
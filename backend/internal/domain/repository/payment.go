@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+)
+
+// PaymentRepository is an interface for interacting with the payment gateway.
+// The payments are loaded with their invoice by the InvoiceRepository, in invoice.R.InvoicePayments ordered by id
+type PaymentRepository interface {
+	CreatePayment(ctx context.Context, payment *models.InvoicePayment) error
+}
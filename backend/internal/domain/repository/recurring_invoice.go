@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+	"github.com/volatiletech/null/v8"
+)
+
+// RecurringInvoiceRepository is an interface for interacting with the recurring invoice gateway
+type RecurringInvoiceRepository interface {
+	CreateRecurringInvoice(ctx context.Context, recurring *models.RecurringInvoice) error
+	GetRecurringInvoice(ctx context.Context, id int64) (*models.RecurringInvoice, error)
+	// GetRecurringInvoicesByClient returns the recurring invoices of a client ordered by id
+	GetRecurringInvoicesByClient(ctx context.Context, clientID int64) ([]*models.RecurringInvoice, error)
+	// GetDueRecurringInvoices returns the active recurring invoices whose next occurrence is on or before asOf, ordered by id
+	GetDueRecurringInvoices(ctx context.Context, asOf time.Time) ([]*models.RecurringInvoice, error)
+
+	// UpdateRecurringInvoiceSchedule sets the status, the next occurrence and the number of skipped occurrences of the
+	// recurring invoice if its stored version is still version, and increments it. It returns ErrVersionConflict if the recurring invoice was changed
+	// in the meantime, and ErrNotFound if there is none
+	UpdateRecurringInvoiceSchedule(ctx context.Context, id int64, version int64, status string, nextDate null.Time, skipped int) error
+}
@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/niko-cb/uct/internal/domain/entity"
+)
+
+// ReportRepository is an interface for the reports, which are aggregated by the database
+type ReportRepository interface {
+	// GetAging buckets the invoices outstanding on asOf (issued on or before it and not paid) of the companies
+	// of a base currency by days past their due date, see entity.AgingCutoffs. The amounts are converted to
+	// the base currency at the rates of the invoices, and also summed by currency.
+	// It returns the clients having such invoices, ordered by id
+	GetAging(ctx context.Context, asOf time.Time, baseCurrency string) ([]*entity.ClientAging, error)
+	// GetCashFlows sums the open (not paid) invoices selected by a filter by due date, ordered by date.
+	// The amounts are converted to the base currency like GetAging does
+	GetCashFlows(ctx context.Context, filter CashFlowFilter) ([]*entity.DueCashFlow, error)
+	// GetUnregisteredIssuerInvoices returns the invoices selected by a filter whose client was not a registered
+	// issuer on the issue date according to the imported issuers: the client has no registration number, no issuer
+	// has its number, or the issuer was registered after the issue date, revoked or expired on or before it.
+	// They are ordered by issue date and id
+	GetUnregisteredIssuerInvoices(ctx context.Context, filter IssuerFilter) ([]*entity.IssuerCheck, error)
+}
+
+// CashFlowFilter selects the open invoices of the cash forecast
+type CashFlowFilter struct {
+	// CompanyID restricts the invoices to a company, every company if 0
+	CompanyID int64
+	// BaseCurrency restricts the invoices to the companies of a base currency
+	BaseCurrency string
+	// From and To bound the due dates, both included
+	From, To time.Time
+	// OverdueBefore also selects the invoices due before it, even before From, unless it is zero
+	OverdueBefore time.Time
+}
+
+// IssuerFilter selects the invoices of the issuer report
+type IssuerFilter struct {
+	// CompanyID restricts the invoices to a company, every company if 0
+	CompanyID int64
+	// From and To bound the issue dates, both included
+	From, To time.Time
+}
@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+)
+
+// SeedIDs are the largest ids of the tables the seeder fills, 0 for an empty table
+type SeedIDs struct {
+	Companies    int64
+	Users        int64
+	Clients      int64
+	BankAccounts int64
+	Invoices     int64
+}
+
+// SeedRepository is an interface for inserting generated rows in bulk. The rows come with their ids,
+// and each call inserts all of them in a single statement, so the caller decides the batch size
+type SeedRepository interface {
+	// MaxIDs returns the largest id of every table, so generated rows can be numbered after the existing ones
+	MaxIDs(ctx context.Context) (SeedIDs, error)
+	InsertCompanies(ctx context.Context, companies []*models.Company) error
+	InsertUsers(ctx context.Context, users []*models.User) error
+	InsertClients(ctx context.Context, clients []*models.Client) error
+	InsertBankAccounts(ctx context.Context, accounts []*models.BankAccount) error
+	InsertInvoices(ctx context.Context, invoices []*models.Invoice) error
+}
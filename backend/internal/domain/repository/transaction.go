@@ -1,7 +1,42 @@
 package repository
 
-import "context"
+import (
+	"context"
+	"database/sql"
+)
 
+// TxOptions configures a transaction
+type TxOptions struct {
+	// Isolation is the isolation level, the database default is used if zero
+	Isolation sql.IsolationLevel
+	// ReadOnly marks the transaction as read-only
+	ReadOnly bool
+}
+
+// TxOption sets an option of a transaction
+type TxOption func(*TxOptions)
+
+// WithIsolation sets the isolation level of the transaction
+func WithIsolation(level sql.IsolationLevel) TxOption {
+	return func(o *TxOptions) {
+		o.Isolation = level
+	}
+}
+
+// ReadOnly marks the transaction as read-only
+func ReadOnly() TxOption {
+	return func(o *TxOptions) {
+		o.ReadOnly = true
+	}
+}
+
+// Transaction runs functions atomically.
+// Repositories called with the context passed to f take part in the transaction transparently
 type Transaction interface {
-	DoInTx(ctx context.Context, f func(ctx context.Context) error) error
+	// DoInTx runs f in a transaction, committed if f returns nil and rolled back otherwise.
+	// When ctx already carries a transaction, f runs in a savepoint of it instead: an error only
+	// rolls back what f did, and the options are ignored as they can't change mid-transaction.
+	// f may be called several times if the transaction is aborted by a deadlock, so it must not
+	// have side effects outside of the database
+	DoInTx(ctx context.Context, f func(ctx context.Context) error, opts ...TxOption) error
 }
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+)
+
+// UserRepository is an interface for interacting with the user gateway
+type UserRepository interface {
+	// CreateUser saves a user and sets its id. The password must already be hashed
+	CreateUser(ctx context.Context, user *models.User) error
+	// GetUser returns a user, or ErrNotFound
+	GetUser(ctx context.Context, id int64) (*models.User, error)
+	// GetUserByEmail returns the user with an email, or ErrNotFound
+	GetUserByEmail(ctx context.Context, email string) (*models.User, error)
+	// UpdateUserPassword replaces the (hashed) password of a user, or returns ErrNotFound
+	UpdateUserPassword(ctx context.Context, id int64, password string) error
+}
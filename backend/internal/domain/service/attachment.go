@@ -0,0 +1,62 @@
+package service
+
+import (
+	"context"
+
+	"github.com/niko-cb/uct/internal/domain/entity"
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+	"github.com/niko-cb/uct/internal/domain/repository"
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/trace"
+)
+
+type AttachmentService interface {
+	EntityToModel(attachment *entity.Attachment) *models.InvoiceAttachment
+	CreateAttachment(ctx context.Context, attachment *models.InvoiceAttachment) (bool, error)
+	GetAttachment(ctx context.Context, id int64) (*models.InvoiceAttachment, error)
+	GetAttachmentsByInvoice(ctx context.Context, invoiceID int64) ([]*models.InvoiceAttachment, error)
+}
+
+type attachmentService struct {
+	repo repository.AttachmentRepository
+}
+
+func NewAttachmentService(repo repository.AttachmentRepository) AttachmentService {
+	return &attachmentService{
+		repo: repo,
+	}
+}
+
+// EntityToModel converts an attachment entity to an attachment model, with the size and the hash of its content
+func (s *attachmentService) EntityToModel(attachment *entity.Attachment) *models.InvoiceAttachment {
+	return &models.InvoiceAttachment{
+		InvoiceID:   attachment.InvoiceID,
+		FileName:    attachment.FileName,
+		ContentType: attachment.ContentType,
+		Size:        int64(len(attachment.Content)),
+		Sha256:      entity.ContentHash(attachment.Content),
+	}
+}
+
+// CreateAttachment saves the metadata of an attachment to the database, unless the invoice already has the same file
+func (s *attachmentService) CreateAttachment(ctx context.Context, attachment *models.InvoiceAttachment) (_ bool, err error) {
+	ctx, span := trace.Start(ctx, "AttachmentService.CreateAttachment")
+	defer trace.End(span, &err)
+
+	return s.repo.CreateAttachment(ctx, attachment)
+}
+
+// GetAttachment retrieves the metadata of an attachment from the database by id
+func (s *attachmentService) GetAttachment(ctx context.Context, id int64) (_ *models.InvoiceAttachment, err error) {
+	ctx, span := trace.Start(ctx, "AttachmentService.GetAttachment")
+	defer trace.End(span, &err)
+
+	return s.repo.GetAttachment(ctx, id)
+}
+
+// GetAttachmentsByInvoice retrieves the metadata of the attachments of an invoice
+func (s *attachmentService) GetAttachmentsByInvoice(ctx context.Context, invoiceID int64) (_ []*models.InvoiceAttachment, err error) {
+	ctx, span := trace.Start(ctx, "AttachmentService.GetAttachmentsByInvoice")
+	defer trace.End(span, &err)
+
+	return s.repo.GetAttachmentsByInvoice(ctx, invoiceID)
+}
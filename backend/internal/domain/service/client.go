@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+
+	"github.com/niko-cb/uct/internal/domain/entity"
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+	"github.com/niko-cb/uct/internal/domain/repository"
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/trace"
+)
+
+type ClientService interface {
+	EntityToModel(client *entity.Client) *models.Client
+	BankAccountEntityToModel(account *entity.BankAccount) *models.BankAccount
+	CreateClient(ctx context.Context, client *models.Client) error
+	CreateBankAccount(ctx context.Context, account *models.BankAccount) error
+	GetClient(ctx context.Context, id int64) (*models.Client, error)
+	FindClient(ctx context.Context, companyID int64, registrationNumber string, name string) (*models.Client, error)
+	UpdateClientRegistrationNumber(ctx context.Context, id int64, version int64, registrationNumber string) error
+}
+
+type clientService struct {
+	repo repository.ClientRepository
+}
+
+func NewClientService(repo repository.ClientRepository) ClientService {
+	return &clientService{
+		repo: repo,
+	}
+}
+
+// EntityToModel converts a client entity to a client model, empty optional fields being NULL
+func (s *clientService) EntityToModel(client *entity.Client) *models.Client {
+	return &models.Client{
+		ID:                 client.ID,
+		CompanyID:          client.CompanyID,
+		Name:               client.Name,
+		Phone:              nullString(client.Phone),
+		Address:            nullString(client.Address),
+		RegistrationNumber: nullString(client.RegistrationNumber),
+		Version:            client.Version,
+	}
+}
+
+// BankAccountEntityToModel converts a bank account entity to a bank account model
+func (s *clientService) BankAccountEntityToModel(account *entity.BankAccount) *models.BankAccount {
+	return &models.BankAccount{
+		ID:        account.ID,
+		ClientID:  account.ClientID,
+		BankName:  account.BankName,
+		Branch:    account.Branch,
+		AccountNo: account.AccountNo,
+		Holder:    account.Holder,
+		Version:   account.Version,
+	}
+}
+
+// CreateClient saves a client to the database
+func (s *clientService) CreateClient(ctx context.Context, client *models.Client) (err error) {
+	ctx, span := trace.Start(ctx, "ClientService.CreateClient")
+	defer trace.End(span, &err)
+
+	return s.repo.CreateClient(ctx, client)
+}
+
+// CreateBankAccount saves a bank account of a client to the database
+func (s *clientService) CreateBankAccount(ctx context.Context, account *models.BankAccount) (err error) {
+	ctx, span := trace.Start(ctx, "ClientService.CreateBankAccount")
+	defer trace.End(span, &err)
+
+	return s.repo.CreateBankAccount(ctx, account)
+}
+
+// GetClient retrieves a client from the database, or repository.ErrNotFound
+func (s *clientService) GetClient(ctx context.Context, id int64) (_ *models.Client, err error) {
+	ctx, span := trace.Start(ctx, "ClientService.GetClient")
+	defer trace.End(span, &err)
+
+	return s.repo.GetClient(ctx, id)
+}
+
+// FindClient retrieves the client of a company with a registration number, else the one with the name and
+// without registration number, or repository.ErrNotFound
+func (s *clientService) FindClient(ctx context.Context, companyID int64, registrationNumber string, name string) (_ *models.Client, err error) {
+	ctx, span := trace.Start(ctx, "ClientService.FindClient")
+	defer trace.End(span, &err)
+
+	return s.repo.FindClient(ctx, companyID, registrationNumber, name)
+}
+
+// UpdateClientRegistrationNumber sets the registration number of a client still at version
+func (s *clientService) UpdateClientRegistrationNumber(ctx context.Context, id int64, version int64, registrationNumber string) (err error) {
+	ctx, span := trace.Start(ctx, "ClientService.UpdateClientRegistrationNumber")
+	defer trace.End(span, &err)
+
+	return s.repo.UpdateClientRegistrationNumber(ctx, id, version, registrationNumber)
+}
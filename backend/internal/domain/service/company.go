@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+
+	"github.com/niko-cb/uct/internal/domain/entity"
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+	"github.com/niko-cb/uct/internal/domain/repository"
+	"github.com/niko-cb/uct/internal/domain/tax"
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/trace"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/types"
+)
+
+type CompanyService interface {
+	EntityToModel(company *entity.Company) *models.Company
+	CreateCompany(ctx context.Context, company *models.Company) error
+	GetCompany(ctx context.Context, id int64) (*models.Company, error)
+	UpdateAvailableBalance(ctx context.Context, id int64, balance types.NullDecimal) error
+	UpdateTaxRounding(ctx context.Context, id int64, rounding tax.Rounding) error
+	UpdateRegistrationNumber(ctx context.Context, id int64, registrationNumber string) error
+}
+
+type companyService struct {
+	repo repository.CompanyRepository
+}
+
+func NewCompanyService(repo repository.CompanyRepository) CompanyService {
+	return &companyService{
+		repo: repo,
+	}
+}
+
+// EntityToModel converts a company entity to a company model, empty optional fields being NULL
+func (s *companyService) EntityToModel(company *entity.Company) *models.Company {
+	return &models.Company{
+		ID:                 company.ID,
+		Name:               company.Name,
+		OwnerName:          company.OwnerName,
+		Phone:              nullString(company.Phone),
+		Address:            nullString(company.Address),
+		BaseCurrency:       company.BaseCurrency,
+		RegistrationNumber: nullString(company.RegistrationNumber),
+	}
+}
+
+// CreateCompany saves a company to the database
+func (s *companyService) CreateCompany(ctx context.Context, company *models.Company) (err error) {
+	ctx, span := trace.Start(ctx, "CompanyService.CreateCompany")
+	defer trace.End(span, &err)
+
+	return s.repo.CreateCompany(ctx, company)
+}
+
+// GetCompany retrieves a company from the database by id
+func (s *companyService) GetCompany(ctx context.Context, id int64) (_ *models.Company, err error) {
+	ctx, span := trace.Start(ctx, "CompanyService.GetCompany")
+	defer trace.End(span, &err)
+
+	return s.repo.GetCompany(ctx, id)
+}
+
+// UpdateAvailableBalance sets the balance available to pay the invoices of a company
+func (s *companyService) UpdateAvailableBalance(ctx context.Context, id int64, balance types.NullDecimal) (err error) {
+	ctx, span := trace.Start(ctx, "CompanyService.UpdateAvailableBalance")
+	defer trace.End(span, &err)
+
+	return s.repo.UpdateAvailableBalance(ctx, id, balance)
+}
+
+// UpdateTaxRounding sets how the consumption tax of the invoices of a company is rounded
+func (s *companyService) UpdateTaxRounding(ctx context.Context, id int64, rounding tax.Rounding) (err error) {
+	ctx, span := trace.Start(ctx, "CompanyService.UpdateTaxRounding")
+	defer trace.End(span, &err)
+
+	return s.repo.UpdateTaxRounding(ctx, id, string(rounding))
+}
+
+// UpdateRegistrationNumber sets the registration number of a company as qualified invoice issuer, empty meaning none
+func (s *companyService) UpdateRegistrationNumber(ctx context.Context, id int64, registrationNumber string) (err error) {
+	ctx, span := trace.Start(ctx, "CompanyService.UpdateRegistrationNumber")
+	defer trace.End(span, &err)
+
+	return s.repo.UpdateRegistrationNumber(ctx, id, nullString(registrationNumber))
+}
+
+// nullString converts an optional string, empty meaning NULL
+func nullString(s string) null.String {
+	return null.NewString(s, s != "")
+}
@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/niko-cb/uct/internal/conversion"
+	"github.com/niko-cb/uct/internal/domain/entity"
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+	"github.com/niko-cb/uct/internal/domain/repository"
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/trace"
+	"github.com/volatiletech/sqlboiler/v4/types"
+)
+
+type CreditNoteService interface {
+	EntityToModel(creditNote *entity.CreditNote) (*models.CreditNote, error)
+	CreateCreditNote(ctx context.Context, creditNote *models.CreditNote) error
+	GetCreditNote(ctx context.Context, id int64) (*models.CreditNote, error)
+	GetCreditNotesByInvoice(ctx context.Context, invoiceID int64) ([]*models.CreditNote, error)
+	GetCreditNotesByDateRange(ctx context.Context, from time.Time, to time.Time, page repository.Page) ([]*models.CreditNote, error)
+	UpdateCreditNoteStatus(ctx context.Context, id int64, version int64, status string) error
+}
+
+type creditNoteService struct {
+	repo repository.CreditNoteRepository
+}
+
+func NewCreditNoteService(repo repository.CreditNoteRepository) CreditNoteService {
+	return &creditNoteService{
+		repo: repo,
+	}
+}
+
+// EntityToModel converts a credit note entity to a credit note model
+func (s *creditNoteService) EntityToModel(creditNote *entity.CreditNote) (*models.CreditNote, error) {
+	amounts := []float64{creditNote.PaymentAmount, creditNote.FeeAmount, creditNote.TaxAmount, creditNote.TotalAmount}
+	decimals := make([]types.Decimal, len(amounts))
+	for i, amount := range amounts {
+		d, err := conversion.ConvertToDecimal(amount)
+		if err != nil {
+			return nil, err
+		}
+		decimals[i] = d
+	}
+	return &models.CreditNote{
+		ID:            creditNote.ID,
+		InvoiceID:     creditNote.InvoiceID,
+		IssueDate:     creditNote.IssueDate,
+		Reason:        creditNote.Reason,
+		PaymentAmount: decimals[0],
+		FeeAmount:     decimals[1],
+		TaxAmount:     decimals[2],
+		TotalAmount:   decimals[3],
+		Status:        creditNote.Status,
+		Version:       creditNote.Version,
+	}, nil
+}
+
+// CreateCreditNote saves a credit note to the database
+func (s *creditNoteService) CreateCreditNote(ctx context.Context, creditNote *models.CreditNote) (err error) {
+	ctx, span := trace.Start(ctx, "CreditNoteService.CreateCreditNote")
+	defer trace.End(span, &err)
+
+	return s.repo.CreateCreditNote(ctx, creditNote)
+}
+
+// GetCreditNote retrieves a credit note from the database by id
+func (s *creditNoteService) GetCreditNote(ctx context.Context, id int64) (_ *models.CreditNote, err error) {
+	ctx, span := trace.Start(ctx, "CreditNoteService.GetCreditNote")
+	defer trace.End(span, &err)
+
+	return s.repo.GetCreditNote(ctx, id)
+}
+
+// GetCreditNotesByInvoice retrieves the credit notes of an invoice
+func (s *creditNoteService) GetCreditNotesByInvoice(ctx context.Context, invoiceID int64) (_ []*models.CreditNote, err error) {
+	ctx, span := trace.Start(ctx, "CreditNoteService.GetCreditNotesByInvoice")
+	defer trace.End(span, &err)
+
+	return s.repo.GetCreditNotesByInvoice(ctx, invoiceID)
+}
+
+// GetCreditNotesByDateRange retrieves the credit notes issued in a date range
+func (s *creditNoteService) GetCreditNotesByDateRange(ctx context.Context, from time.Time, to time.Time, page repository.Page) (_ []*models.CreditNote, err error) {
+	ctx, span := trace.Start(ctx, "CreditNoteService.GetCreditNotesByDateRange")
+	defer trace.End(span, &err)
+
+	return s.repo.GetCreditNotesByDateRange(ctx, from, to, page)
+}
+
+// UpdateCreditNoteStatus sets the status of a credit note, provided nobody else changed it since version
+func (s *creditNoteService) UpdateCreditNoteStatus(ctx context.Context, id int64, version int64, status string) (err error) {
+	ctx, span := trace.Start(ctx, "CreditNoteService.UpdateCreditNoteStatus")
+	defer trace.End(span, &err)
+
+	return s.repo.UpdateCreditNoteStatus(ctx, id, version, status)
+}
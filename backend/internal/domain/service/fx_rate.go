@@ -0,0 +1,53 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/niko-cb/uct/internal/domain/entity"
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+	"github.com/niko-cb/uct/internal/domain/repository"
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/trace"
+)
+
+type FXRateService interface {
+	EntityToModel(rate *entity.FXRate) *models.FXRate
+	UpsertFXRates(ctx context.Context, rates []*models.FXRate) error
+	GetFXRate(ctx context.Context, currency, baseCurrency string, on time.Time) (*models.FXRate, error)
+}
+
+type fxRateService struct {
+	repo repository.FXRateRepository
+}
+
+func NewFXRateService(repo repository.FXRateRepository) FXRateService {
+	return &fxRateService{
+		repo: repo,
+	}
+}
+
+// EntityToModel converts an exchange rate entity to a model
+func (s *fxRateService) EntityToModel(rate *entity.FXRate) *models.FXRate {
+	return &models.FXRate{
+		Currency:     rate.Currency,
+		BaseCurrency: rate.BaseCurrency,
+		RateDate:     rate.Date,
+		Rate:         rate.Rate,
+	}
+}
+
+// UpsertFXRates saves exchange rates, replacing the ones of the same currencies and day
+func (s *fxRateService) UpsertFXRates(ctx context.Context, rates []*models.FXRate) (err error) {
+	ctx, span := trace.Start(ctx, "FXRateService.UpsertFXRates")
+	defer trace.End(span, &err)
+
+	return s.repo.UpsertFXRates(ctx, rates)
+}
+
+// GetFXRate retrieves the latest rate of a currency in a base currency on or before a day
+func (s *fxRateService) GetFXRate(ctx context.Context, currency, baseCurrency string, on time.Time) (_ *models.FXRate, err error) {
+	ctx, span := trace.Start(ctx, "FXRateService.GetFXRate")
+	defer trace.End(span, &err)
+
+	return s.repo.GetFXRate(ctx, currency, baseCurrency, on)
+}
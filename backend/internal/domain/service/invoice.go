@@ -2,11 +2,11 @@ package service
 
 import (
 	"context"
-	"database/sql"
 	"fmt"
 	"github.com/niko-cb/uct/internal/conversion"
 	"github.com/niko-cb/uct/internal/domain/repository"
 	"github.com/niko-cb/uct/internal/infrastructure/monitor/log"
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/trace"
 	"time"
 
 	"github.com/niko-cb/uct/internal/domain/entity"
@@ -15,8 +15,13 @@ import (
 
 type InvoiceService interface {
 	EntityToModel(ctx context.Context, invoice *entity.Invoice) (*models.Invoice, error)
-	CreateInvoice(ctx context.Context, tx *sql.Tx, invoice *models.Invoice) error
-	GetInvoicesByDateRange(ctx context.Context, from time.Time, to time.Time) ([]*models.Invoice, error)
+	CreateInvoice(ctx context.Context, invoice *models.Invoice) error
+	GetInvoice(ctx context.Context, id int64) (*models.Invoice, error)
+	GetInvoicesByDateRange(ctx context.Context, from time.Time, to time.Time, page repository.Page) ([]*models.Invoice, error)
+	GetInvoicesByInstallmentDateRange(ctx context.Context, from time.Time, to time.Time, page repository.Page) ([]*models.Invoice, error)
+	UpdateInvoice(ctx context.Context, invoice *models.Invoice) error
+	UpdateInvoiceStatus(ctx context.Context, id int64, version int64, status string) error
+	DeleteInvoice(ctx context.Context, id int64, version int64) error
 }
 
 type invoiceService struct {
@@ -66,16 +71,134 @@ func (s *invoiceService) EntityToModel(ctx context.Context, invoice *entity.Invo
 		TaxAmount:     ta,
 		TotalAmount:   toa,
 		Status:        invoice.Status,
+		Version:       invoice.Version,
+		Currency:      invoice.Currency,
+		FXRate:        invoice.FXRate,
+	}
+	if len(invoice.Items) > 0 || len(invoice.TaxSubtotals) > 0 || len(invoice.Installments) > 0 {
+		invoiceM.R = invoiceM.R.NewStruct()
+	}
+	for _, item := range invoice.Items {
+		itemM, err := itemToModel(item)
+		if err != nil {
+			log.Error(ctx, fmt.Errorf("error converting invoice item: %v", err))
+			return nil, err
+		}
+		invoiceM.R.InvoiceItems = append(invoiceM.R.InvoiceItems, itemM)
+	}
+	for _, subtotal := range invoice.TaxSubtotals {
+		subtotalM, err := taxSubtotalToModel(subtotal)
+		if err != nil {
+			log.Error(ctx, fmt.Errorf("error converting invoice tax subtotal: %v", err))
+			return nil, err
+		}
+		invoiceM.R.InvoiceTaxSubtotals = append(invoiceM.R.InvoiceTaxSubtotals, subtotalM)
+	}
+	for _, installment := range invoice.Installments {
+		amount, err := conversion.ConvertToDecimal(installment.Amount)
+		if err != nil {
+			log.Error(ctx, fmt.Errorf("error converting invoice installment: %v", err))
+			return nil, err
+		}
+		invoiceM.R.InvoiceInstallments = append(invoiceM.R.InvoiceInstallments,
+			&models.InvoiceInstallment{DueDate: installment.DueDate, Amount: amount})
 	}
 	return invoiceM, nil
 }
 
+// taxSubtotalToModel converts a tax subtotal of an invoice to a model
+func taxSubtotalToModel(subtotal *entity.InvoiceTaxSubtotal) (*models.InvoiceTaxSubtotal, error) {
+	amount, err := conversion.ConvertToDecimal(subtotal.Amount)
+	if err != nil {
+		return nil, err
+	}
+	taxAmount, err := conversion.ConvertToDecimal(subtotal.TaxAmount)
+	if err != nil {
+		return nil, err
+	}
+	return &models.InvoiceTaxSubtotal{
+		TaxCategory: subtotal.TaxCategory,
+		Rate:        subtotal.Rate,
+		Amount:      amount,
+		TaxAmount:   taxAmount,
+	}, nil
+}
+
+// itemToModel converts an invoice item entity to a model, numbered when the invoice is saved
+func itemToModel(item *entity.InvoiceItem) (*models.InvoiceItem, error) {
+	quantity, err := conversion.ConvertToDecimal(item.Quantity)
+	if err != nil {
+		return nil, err
+	}
+	unitPrice, err := conversion.ConvertToDecimal(item.UnitPrice)
+	if err != nil {
+		return nil, err
+	}
+	amount, err := conversion.ConvertToDecimal(item.Amount)
+	if err != nil {
+		return nil, err
+	}
+	return &models.InvoiceItem{
+		Description: item.Description,
+		Quantity:    quantity,
+		UnitPrice:   unitPrice,
+		Amount:      amount,
+		TaxCategory: item.TaxCategory,
+	}, nil
+}
+
 // CreateInvoice saves invoices to the database
-func (s *invoiceService) CreateInvoice(ctx context.Context, tx *sql.Tx, invoice *models.Invoice) error {
-	return s.repo.CreateInvoice(ctx, tx, invoice)
+func (s *invoiceService) CreateInvoice(ctx context.Context, invoice *models.Invoice) (err error) {
+	ctx, span := trace.Start(ctx, "InvoiceService.CreateInvoice")
+	defer trace.End(span, &err)
+
+	return s.repo.CreateInvoice(ctx, invoice)
+}
+
+// GetInvoice retrieves an invoice from the database by id
+func (s *invoiceService) GetInvoice(ctx context.Context, id int64) (_ *models.Invoice, err error) {
+	ctx, span := trace.Start(ctx, "InvoiceService.GetInvoice")
+	defer trace.End(span, &err)
+
+	return s.repo.GetInvoice(ctx, id)
 }
 
 // GetInvoicesByDateRange retrieves invoices from the database by date range
-func (s *invoiceService) GetInvoicesByDateRange(ctx context.Context, from time.Time, to time.Time) ([]*models.Invoice, error) {
-	return s.repo.GetInvoicesByDateRange(ctx, from, to)
+func (s *invoiceService) GetInvoicesByDateRange(ctx context.Context, from time.Time, to time.Time, page repository.Page) (_ []*models.Invoice, err error) {
+	ctx, span := trace.Start(ctx, "InvoiceService.GetInvoicesByDateRange")
+	defer trace.End(span, &err)
+
+	return s.repo.GetInvoicesByDateRange(ctx, from, to, page)
+}
+
+// GetInvoicesByInstallmentDateRange retrieves the invoices with an installment due between from and to
+func (s *invoiceService) GetInvoicesByInstallmentDateRange(ctx context.Context, from time.Time, to time.Time, page repository.Page) (_ []*models.Invoice, err error) {
+	ctx, span := trace.Start(ctx, "InvoiceService.GetInvoicesByInstallmentDateRange")
+	defer trace.End(span, &err)
+
+	return s.repo.GetInvoicesByInstallmentDateRange(ctx, from, to, page)
+}
+
+// UpdateInvoice saves the changes of an invoice, provided nobody else changed it since invoice.Version
+func (s *invoiceService) UpdateInvoice(ctx context.Context, invoice *models.Invoice) (err error) {
+	ctx, span := trace.Start(ctx, "InvoiceService.UpdateInvoice")
+	defer trace.End(span, &err)
+
+	return s.repo.UpdateInvoice(ctx, invoice)
+}
+
+// UpdateInvoiceStatus sets the status of an invoice, provided nobody else changed it since version
+func (s *invoiceService) UpdateInvoiceStatus(ctx context.Context, id int64, version int64, status string) (err error) {
+	ctx, span := trace.Start(ctx, "InvoiceService.UpdateInvoiceStatus")
+	defer trace.End(span, &err)
+
+	return s.repo.UpdateInvoiceStatus(ctx, id, version, status)
+}
+
+// DeleteInvoice deletes an invoice, provided nobody else changed it since version
+func (s *invoiceService) DeleteInvoice(ctx context.Context, id int64, version int64) (err error) {
+	ctx, span := trace.Start(ctx, "InvoiceService.DeleteInvoice")
+	defer trace.End(span, &err)
+
+	return s.repo.DeleteInvoice(ctx, id, version)
 }
@@ -0,0 +1,63 @@
+package service
+
+import (
+	"context"
+
+	"github.com/niko-cb/uct/internal/domain/entity"
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+	"github.com/niko-cb/uct/internal/domain/repository"
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/trace"
+	"github.com/volatiletech/null/v8"
+)
+
+type InvoiceIssuerService interface {
+	EntityToModel(issuer *entity.InvoiceIssuer) *models.InvoiceIssuer
+	UpsertInvoiceIssuers(ctx context.Context, issuers []*models.InvoiceIssuer) error
+	DeleteInvoiceIssuers(ctx context.Context, registrationNumbers []string) error
+	GetInvoiceIssuer(ctx context.Context, registrationNumber string) (*models.InvoiceIssuer, error)
+}
+
+type invoiceIssuerService struct {
+	repo repository.InvoiceIssuerRepository
+}
+
+func NewInvoiceIssuerService(repo repository.InvoiceIssuerRepository) InvoiceIssuerService {
+	return &invoiceIssuerService{
+		repo: repo,
+	}
+}
+
+// EntityToModel converts an issuer entity to a model, zero dates being NULL
+func (s *invoiceIssuerService) EntityToModel(issuer *entity.InvoiceIssuer) *models.InvoiceIssuer {
+	return &models.InvoiceIssuer{
+		RegistrationNumber: issuer.RegistrationNumber,
+		Name:               issuer.Name,
+		RegisteredOn:       issuer.RegisteredOn,
+		RevokedOn:          null.NewTime(issuer.RevokedOn, !issuer.RevokedOn.IsZero()),
+		ExpiredOn:          null.NewTime(issuer.ExpiredOn, !issuer.ExpiredOn.IsZero()),
+	}
+}
+
+// UpsertInvoiceIssuers saves issuers, replacing the ones of the same registration number
+func (s *invoiceIssuerService) UpsertInvoiceIssuers(ctx context.Context, issuers []*models.InvoiceIssuer) (err error) {
+	ctx, span := trace.Start(ctx, "InvoiceIssuerService.UpsertInvoiceIssuers")
+	defer trace.End(span, &err)
+
+	return s.repo.UpsertInvoiceIssuers(ctx, issuers)
+}
+
+// DeleteInvoiceIssuers deletes the issuers of registration numbers
+func (s *invoiceIssuerService) DeleteInvoiceIssuers(ctx context.Context, registrationNumbers []string) (err error) {
+	ctx, span := trace.Start(ctx, "InvoiceIssuerService.DeleteInvoiceIssuers")
+	defer trace.End(span, &err)
+
+	return s.repo.DeleteInvoiceIssuers(ctx, registrationNumbers)
+}
+
+// GetInvoiceIssuer retrieves the issuer of a registration number
+func (s *invoiceIssuerService) GetInvoiceIssuer(ctx context.Context, registrationNumber string) (_ *models.InvoiceIssuer, err error) {
+	ctx, span := trace.Start(ctx, "InvoiceIssuerService.GetInvoiceIssuer")
+	defer trace.End(span, &err)
+
+	return s.repo.GetInvoiceIssuer(ctx, registrationNumber)
+}
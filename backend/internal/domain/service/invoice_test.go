@@ -2,7 +2,6 @@ package service_test
 
 import (
 	"context"
-	"database/sql"
 	"errors"
 	"testing"
 	"time"
@@ -10,6 +9,7 @@ import (
 	"github.com/niko-cb/uct/internal/conversion"
 	"github.com/niko-cb/uct/internal/domain/entity"
 	"github.com/niko-cb/uct/internal/domain/entity/models"
+	"github.com/niko-cb/uct/internal/domain/repository"
 	"github.com/niko-cb/uct/internal/domain/service"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -20,13 +20,13 @@ type MockInvoiceRepository struct {
 	mock.Mock
 }
 
-func (m *MockInvoiceRepository) CreateInvoice(ctx context.Context, tx *sql.Tx, invoice *models.Invoice) error {
-	args := m.Called(ctx, tx, invoice)
+func (m *MockInvoiceRepository) CreateInvoice(ctx context.Context, invoice *models.Invoice) error {
+	args := m.Called(ctx, invoice)
 	return args.Error(0)
 }
 
-func (m *MockInvoiceRepository) GetInvoicesByDateRange(ctx context.Context, from time.Time, to time.Time) ([]*models.Invoice, error) {
-	args := m.Called(ctx, from, to)
+func (m *MockInvoiceRepository) GetInvoicesByDateRange(ctx context.Context, from time.Time, to time.Time, page repository.Page) ([]*models.Invoice, error) {
+	args := m.Called(ctx, from, to, page)
 
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -35,6 +35,41 @@ func (m *MockInvoiceRepository) GetInvoicesByDateRange(ctx context.Context, from
 	return args.Get(0).([]*models.Invoice), args.Error(1)
 }
 
+func (m *MockInvoiceRepository) GetInvoicesByInstallmentDateRange(ctx context.Context, from time.Time, to time.Time, page repository.Page) ([]*models.Invoice, error) {
+	args := m.Called(ctx, from, to, page)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]*models.Invoice), args.Error(1)
+}
+
+func (m *MockInvoiceRepository) GetInvoice(ctx context.Context, id int64) (*models.Invoice, error) {
+	args := m.Called(ctx, id)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*models.Invoice), args.Error(1)
+}
+
+func (m *MockInvoiceRepository) UpdateInvoice(ctx context.Context, invoice *models.Invoice) error {
+	args := m.Called(ctx, invoice)
+	return args.Error(0)
+}
+
+func (m *MockInvoiceRepository) UpdateInvoiceStatus(ctx context.Context, id int64, version int64, status string) error {
+	args := m.Called(ctx, id, version, status)
+	return args.Error(0)
+}
+
+func (m *MockInvoiceRepository) DeleteInvoice(ctx context.Context, id int64, version int64) error {
+	args := m.Called(ctx, id, version)
+	return args.Error(0)
+}
+
 // Test for EntityToModel
 func TestEntityToModel(t *testing.T) {
 	ctx := context.Background()
@@ -94,12 +129,12 @@ func TestCreateInvoice_Success(t *testing.T) {
 	}
 
 	// Set mock expectation
-	mockRepo.On("CreateInvoice", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("CreateInvoice", mock.Anything, mock.Anything).Return(nil)
 
 	pa, _ := conversion.ConvertToDecimal(invoice.PaymentAmount)
 
 	// Call
-	err := invoiceService.CreateInvoice(ctx, nil, &models.Invoice{
+	err := invoiceService.CreateInvoice(ctx, &models.Invoice{
 		CompanyID:     invoice.CompanyID,
 		ClientID:      invoice.ClientID,
 		PaymentAmount: pa,
@@ -130,12 +165,12 @@ func TestCreateInvoice_Error(t *testing.T) {
 	}
 
 	// Set mock expectation to return an error
-	mockRepo.On("CreateInvoice", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("database error"))
+	mockRepo.On("CreateInvoice", mock.Anything, mock.Anything).Return(errors.New("database error"))
 
 	pa, _ := conversion.ConvertToDecimal(10000.0)
 
 	// Call
-	err := invoiceService.CreateInvoice(ctx, nil, &models.Invoice{
+	err := invoiceService.CreateInvoice(ctx, &models.Invoice{
 		CompanyID:     invoice.CompanyID,
 		ClientID:      invoice.ClientID,
 		PaymentAmount: pa,
@@ -174,13 +209,13 @@ func TestGetInvoicesByDateRange_Success(t *testing.T) {
 	}
 
 	// Set mock expectation
-	mockRepo.On("GetInvoicesByDateRange", mock.Anything, mock.Anything, mock.Anything).Return(expectedInvoices, nil)
+	mockRepo.On("GetInvoicesByDateRange", mock.Anything, mock.Anything, mock.Anything, repository.Page{}).Return(expectedInvoices, nil)
 
 	// Create the service instance
 	invoiceService := service.NewInvoiceService(mockRepo)
 
 	// Call
-	result, err := invoiceService.GetInvoicesByDateRange(ctx, time.Now().Add(-30*24*time.Hour), time.Now())
+	result, err := invoiceService.GetInvoicesByDateRange(ctx, time.Now().Add(-30*24*time.Hour), time.Now(), repository.Page{})
 
 	// Assert no error and correct result
 	assert.NoError(t, err)
@@ -200,13 +235,13 @@ func TestGetInvoicesByDateRange_Error(t *testing.T) {
 	expectedError := errors.New("database error")
 
 	// Setup mock to return nil and an error
-	mockRepo.On("GetInvoicesByDateRange", mock.Anything, mock.Anything, mock.Anything).Return(nil, expectedError)
+	mockRepo.On("GetInvoicesByDateRange", mock.Anything, mock.Anything, mock.Anything, repository.Page{}).Return(nil, expectedError)
 
 	// Create the service instance
 	invoiceService := service.NewInvoiceService(mockRepo)
 
 	// Call
-	result, err := invoiceService.GetInvoicesByDateRange(ctx, time.Now().Add(-30*24*time.Hour), time.Now())
+	result, err := invoiceService.GetInvoicesByDateRange(ctx, time.Now().Add(-30*24*time.Hour), time.Now(), repository.Page{})
 
 	// Assert that an error is returned
 	assert.Error(t, err)
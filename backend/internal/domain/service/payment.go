@@ -0,0 +1,49 @@
+package service
+
+import (
+	"context"
+
+	"github.com/niko-cb/uct/internal/conversion"
+	"github.com/niko-cb/uct/internal/domain/entity"
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+	"github.com/niko-cb/uct/internal/domain/repository"
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/trace"
+)
+
+type PaymentService interface {
+	EntityToModel(payment *entity.Payment) (*models.InvoicePayment, error)
+	CreatePayment(ctx context.Context, payment *models.InvoicePayment) error
+}
+
+type paymentService struct {
+	repo repository.PaymentRepository
+}
+
+func NewPaymentService(repo repository.PaymentRepository) PaymentService {
+	return &paymentService{
+		repo: repo,
+	}
+}
+
+// EntityToModel converts a payment entity to a payment model
+func (s *paymentService) EntityToModel(payment *entity.Payment) (*models.InvoicePayment, error) {
+	amount, err := conversion.ConvertToDecimal(payment.Amount)
+	if err != nil {
+		return nil, err
+	}
+	return &models.InvoicePayment{
+		ID:          payment.ID,
+		InvoiceID:   payment.InvoiceID,
+		PaymentDate: payment.PaymentDate,
+		Amount:      amount,
+		Reference:   payment.Reference,
+	}, nil
+}
+
+// CreatePayment saves a payment to the database
+func (s *paymentService) CreatePayment(ctx context.Context, payment *models.InvoicePayment) (err error) {
+	ctx, span := trace.Start(ctx, "PaymentService.CreatePayment")
+	defer trace.End(span, &err)
+
+	return s.repo.CreatePayment(ctx, payment)
+}
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/niko-cb/uct/internal/conversion"
+	"github.com/niko-cb/uct/internal/domain/entity"
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+	"github.com/niko-cb/uct/internal/domain/repository"
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/trace"
+	"github.com/volatiletech/null/v8"
+)
+
+type RecurringInvoiceService interface {
+	EntityToModel(recurring *entity.RecurringInvoice) (*models.RecurringInvoice, error)
+	CreateRecurringInvoice(ctx context.Context, recurring *models.RecurringInvoice) error
+	GetRecurringInvoice(ctx context.Context, id int64) (*models.RecurringInvoice, error)
+	GetRecurringInvoicesByClient(ctx context.Context, clientID int64) ([]*models.RecurringInvoice, error)
+	GetDueRecurringInvoices(ctx context.Context, asOf time.Time) ([]*models.RecurringInvoice, error)
+	UpdateRecurringInvoiceSchedule(ctx context.Context, id int64, version int64, status string, nextDate null.Time, skipped int) error
+}
+
+type recurringInvoiceService struct {
+	repo repository.RecurringInvoiceRepository
+}
+
+func NewRecurringInvoiceService(repo repository.RecurringInvoiceRepository) RecurringInvoiceService {
+	return &recurringInvoiceService{
+		repo: repo,
+	}
+}
+
+// EntityToModel converts a recurring invoice entity to a recurring invoice model
+func (s *recurringInvoiceService) EntityToModel(recurring *entity.RecurringInvoice) (*models.RecurringInvoice, error) {
+	paymentAmount, err := conversion.ConvertToDecimal(recurring.PaymentAmount)
+	if err != nil {
+		return nil, err
+	}
+	var endDate null.Time
+	if recurring.EndDate != nil {
+		endDate = null.TimeFrom(*recurring.EndDate)
+	}
+	return &models.RecurringInvoice{
+		ID:             recurring.ID,
+		CompanyID:      recurring.CompanyID,
+		ClientID:       recurring.ClientID,
+		Frequency:      recurring.Frequency,
+		Every:          recurring.Every,
+		DayOfMonth:     recurring.DayOfMonth,
+		StartDate:      recurring.StartDate,
+		EndDate:        endDate,
+		MaxOccurrences: recurring.MaxOccurrences,
+		PaymentAmount:  paymentAmount,
+		Currency:       recurring.Currency,
+		DueDays:        recurring.DueDays,
+		Status:         recurring.Status,
+		Version:        recurring.Version,
+	}, nil
+}
+
+// CreateRecurringInvoice saves a recurring invoice to the database
+func (s *recurringInvoiceService) CreateRecurringInvoice(ctx context.Context, recurring *models.RecurringInvoice) (err error) {
+	ctx, span := trace.Start(ctx, "RecurringInvoiceService.CreateRecurringInvoice")
+	defer trace.End(span, &err)
+
+	return s.repo.CreateRecurringInvoice(ctx, recurring)
+}
+
+// GetRecurringInvoice retrieves a recurring invoice from the database by id
+func (s *recurringInvoiceService) GetRecurringInvoice(ctx context.Context, id int64) (_ *models.RecurringInvoice, err error) {
+	ctx, span := trace.Start(ctx, "RecurringInvoiceService.GetRecurringInvoice")
+	defer trace.End(span, &err)
+
+	return s.repo.GetRecurringInvoice(ctx, id)
+}
+
+// GetRecurringInvoicesByClient retrieves the recurring invoices of a client
+func (s *recurringInvoiceService) GetRecurringInvoicesByClient(ctx context.Context, clientID int64) (_ []*models.RecurringInvoice, err error) {
+	ctx, span := trace.Start(ctx, "RecurringInvoiceService.GetRecurringInvoicesByClient")
+	defer trace.End(span, &err)
+
+	return s.repo.GetRecurringInvoicesByClient(ctx, clientID)
+}
+
+// GetDueRecurringInvoices retrieves the active recurring invoices with an occurrence due on or before asOf
+func (s *recurringInvoiceService) GetDueRecurringInvoices(ctx context.Context, asOf time.Time) (_ []*models.RecurringInvoice, err error) {
+	ctx, span := trace.Start(ctx, "RecurringInvoiceService.GetDueRecurringInvoices")
+	defer trace.End(span, &err)
+
+	return s.repo.GetDueRecurringInvoices(ctx, asOf)
+}
+
+// UpdateRecurringInvoiceSchedule sets the status, the next occurrence and the skipped occurrences of a recurring invoice,
+// provided nobody else changed it since version
+func (s *recurringInvoiceService) UpdateRecurringInvoiceSchedule(ctx context.Context, id int64, version int64, status string, nextDate null.Time, skipped int) (err error) {
+	ctx, span := trace.Start(ctx, "RecurringInvoiceService.UpdateRecurringInvoiceSchedule")
+	defer trace.End(span, &err)
+
+	return s.repo.UpdateRecurringInvoiceSchedule(ctx, id, version, status, nextDate, skipped)
+}
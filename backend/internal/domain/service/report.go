@@ -0,0 +1,50 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/niko-cb/uct/internal/domain/entity"
+	"github.com/niko-cb/uct/internal/domain/repository"
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/trace"
+)
+
+type ReportService interface {
+	GetAging(ctx context.Context, asOf time.Time, baseCurrency string) ([]*entity.ClientAging, error)
+	GetCashFlows(ctx context.Context, filter repository.CashFlowFilter) ([]*entity.DueCashFlow, error)
+	GetUnregisteredIssuerInvoices(ctx context.Context, filter repository.IssuerFilter) ([]*entity.IssuerCheck, error)
+}
+
+type reportService struct {
+	repo repository.ReportRepository
+}
+
+func NewReportService(repo repository.ReportRepository) ReportService {
+	return &reportService{
+		repo: repo,
+	}
+}
+
+// GetAging retrieves the aging of the outstanding invoices of every client, aggregated by the database
+func (s *reportService) GetAging(ctx context.Context, asOf time.Time, baseCurrency string) (_ []*entity.ClientAging, err error) {
+	ctx, span := trace.Start(ctx, "ReportService.GetAging")
+	defer trace.End(span, &err)
+
+	return s.repo.GetAging(ctx, asOf, baseCurrency)
+}
+
+// GetCashFlows retrieves the open invoices by due date, aggregated by the database
+func (s *reportService) GetCashFlows(ctx context.Context, filter repository.CashFlowFilter) (_ []*entity.DueCashFlow, err error) {
+	ctx, span := trace.Start(ctx, "ReportService.GetCashFlows")
+	defer trace.End(span, &err)
+
+	return s.repo.GetCashFlows(ctx, filter)
+}
+
+// GetUnregisteredIssuerInvoices retrieves the invoices whose client was not a registered issuer on the issue date
+func (s *reportService) GetUnregisteredIssuerInvoices(ctx context.Context, filter repository.IssuerFilter) (_ []*entity.IssuerCheck, err error) {
+	ctx, span := trace.Start(ctx, "ReportService.GetUnregisteredIssuerInvoices")
+	defer trace.End(span, &err)
+
+	return s.repo.GetUnregisteredIssuerInvoices(ctx, filter)
+}
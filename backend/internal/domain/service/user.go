@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+
+	"github.com/niko-cb/uct/internal/domain/entity"
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+	"github.com/niko-cb/uct/internal/domain/repository"
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/trace"
+)
+
+type UserService interface {
+	EntityToModel(user *entity.User) *models.User
+	CreateUser(ctx context.Context, user *models.User) error
+	GetUser(ctx context.Context, id int64) (*models.User, error)
+	GetUserByEmail(ctx context.Context, email string) (*models.User, error)
+	UpdateUserPassword(ctx context.Context, id int64, password string) error
+}
+
+type userService struct {
+	repo repository.UserRepository
+}
+
+func NewUserService(repo repository.UserRepository) UserService {
+	return &userService{
+		repo: repo,
+	}
+}
+
+// EntityToModel converts a user entity to a user model
+func (s *userService) EntityToModel(user *entity.User) *models.User {
+	return &models.User{
+		ID:        user.ID,
+		CompanyID: user.CompanyID,
+		Name:      user.Name,
+		Email:     user.Email,
+		Password:  user.Password,
+	}
+}
+
+// CreateUser saves a user to the database
+func (s *userService) CreateUser(ctx context.Context, user *models.User) (err error) {
+	ctx, span := trace.Start(ctx, "UserService.CreateUser")
+	defer trace.End(span, &err)
+
+	return s.repo.CreateUser(ctx, user)
+}
+
+// GetUser retrieves a user from the database by id
+func (s *userService) GetUser(ctx context.Context, id int64) (_ *models.User, err error) {
+	ctx, span := trace.Start(ctx, "UserService.GetUser")
+	defer trace.End(span, &err)
+
+	return s.repo.GetUser(ctx, id)
+}
+
+// GetUserByEmail retrieves a user from the database by email
+func (s *userService) GetUserByEmail(ctx context.Context, email string) (_ *models.User, err error) {
+	ctx, span := trace.Start(ctx, "UserService.GetUserByEmail")
+	defer trace.End(span, &err)
+
+	return s.repo.GetUserByEmail(ctx, email)
+}
+
+// UpdateUserPassword replaces the hashed password of a user
+func (s *userService) UpdateUserPassword(ctx context.Context, id int64, password string) (err error) {
+	ctx, span := trace.Start(ctx, "UserService.UpdateUserPassword")
+	defer trace.End(span, &err)
+
+	return s.repo.UpdateUserPassword(ctx, id, password)
+}
@@ -0,0 +1,44 @@
+package tax
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidRegistrationNumber is returned for a registration number which is not T followed by 13 digits,
+// the first of them the check digit of the others
+var ErrInvalidRegistrationNumber = errors.New("invalid registration number, expected T and 13 digits with a valid check digit")
+
+// ParseRegistrationNumber validates the registration number of a qualified invoice issuer (適格請求書発行事業者
+// 登録番号) and returns it upper-cased, without surrounding spaces. The 13 digits are the corporate number of
+// the corporations, or a number assigned to the individuals in the same format: the first digit checks the others
+func ParseRegistrationNumber(s string) (string, error) {
+	number := strings.ToUpper(strings.TrimSpace(s))
+	if len(number) != 14 || number[0] != 'T' {
+		return "", fmt.Errorf("%w: %q", ErrInvalidRegistrationNumber, s)
+	}
+	for _, c := range number[1:] {
+		if c < '0' || c > '9' {
+			return "", fmt.Errorf("%w: %q", ErrInvalidRegistrationNumber, s)
+		}
+	}
+	if int(number[1]-'0') != CheckDigit(number[2:]) {
+		return "", fmt.Errorf("%w: %q, the check digit should be %d", ErrInvalidRegistrationNumber, s, CheckDigit(number[2:]))
+	}
+	return number, nil
+}
+
+// CheckDigit returns the check digit of the 12 digits of a corporate number: 9 minus the remainder by 9 of
+// the sum of the digits, the ones in an even position from the right counting twice
+func CheckDigit(digits string) int {
+	sum := 0
+	for i := 0; i < len(digits); i++ {
+		weight := 1
+		if (len(digits)-i)%2 == 0 {
+			weight = 2
+		}
+		sum += int(digits[i]-'0') * weight
+	}
+	return 9 - sum%9
+}
@@ -0,0 +1,149 @@
+// Package tax computes the consumption tax of invoices under the qualified invoice system:
+// the amounts are summed by tax rate and the tax of each rate is rounded once, to the yen
+// (or to the minor unit of the currency of the invoice, like the cent)
+package tax
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ericlagergren/decimal"
+
+	"github.com/niko-cb/uct/internal/domain/entity"
+)
+
+// Rounding is how the fraction of yen (or cent) of a tax amount is rounded, configured by company
+type Rounding string
+
+const (
+	// RoundingFloor drops the fraction (切り捨て), the most common choice and the default
+	RoundingFloor Rounding = "floor"
+	// RoundingRound rounds half away from zero (四捨五入)
+	RoundingRound Rounding = "round"
+	// RoundingCeil rounds any fraction up (切り上げ)
+	RoundingCeil Rounding = "ceil"
+)
+
+// DefaultRounding is the rounding of the companies which didn't configure one
+const DefaultRounding = RoundingFloor
+
+// Roundings are the valid roundings
+var Roundings = []Rounding{RoundingFloor, RoundingRound, RoundingCeil}
+
+var (
+	// ErrInvalidRounding is returned for a rounding which is not one of Roundings
+	ErrInvalidRounding = errors.New("invalid tax rounding, expected floor, round or ceil")
+	// ErrUnknownCategory is returned for a line whose tax category has no rate
+	ErrUnknownCategory = errors.New("unknown tax category")
+)
+
+// Rates are the consumption tax rates of the tax categories, in percent
+var Rates = map[string]int64{
+	entity.TaxCategoryStandard: 10,
+	entity.TaxCategoryReduced:  8,
+}
+
+// ParseRounding validates a rounding
+func ParseRounding(s string) (Rounding, error) {
+	for _, rounding := range Roundings {
+		if string(rounding) == s {
+			return rounding, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %q", ErrInvalidRounding, s)
+}
+
+// Line is an amount subject to the rate of its tax category
+type Line struct {
+	Category string
+	Amount   *decimal.Big
+}
+
+// Subtotal is the sum of the lines of a tax category, and the tax of that sum
+type Subtotal struct {
+	Category string
+	Rate     int64
+	Amount   *decimal.Big
+	Tax      *decimal.Big
+}
+
+// Breakdown is the tax of a set of lines: a subtotal for each category with lines, ordered like
+// entity.TaxCategories, and the total tax
+type Breakdown struct {
+	Subtotals []Subtotal
+	Tax       *decimal.Big
+}
+
+// Included computes the tax included in lines whose amounts are tax included: amount * rate / (100 + rate),
+// rounded to digits decimals (0 for yen, 2 for dollars)
+func Included(lines []Line, rounding Rounding, digits int) (Breakdown, error) {
+	return compute(lines, rounding, digits, true)
+}
+
+// Excluded computes the tax to add to lines whose amounts are tax excluded: amount * rate / 100,
+// rounded to digits decimals
+func Excluded(lines []Line, rounding Rounding, digits int) (Breakdown, error) {
+	return compute(lines, rounding, digits, false)
+}
+
+// compute sums the lines by category, then rounds the tax of each sum once. Rounding is symmetric:
+// a negative amount (a refund) gets the opposite tax of the same positive amount
+func compute(lines []Line, rounding Rounding, digits int, included bool) (Breakdown, error) {
+	if _, err := ParseRounding(string(rounding)); err != nil {
+		return Breakdown{}, err
+	}
+
+	sums := map[string]*decimal.Big{}
+	for _, line := range lines {
+		if _, ok := Rates[line.Category]; !ok {
+			return Breakdown{}, fmt.Errorf("%w: %q", ErrUnknownCategory, line.Category)
+		}
+		if sums[line.Category] == nil {
+			sums[line.Category] = newBig()
+		}
+		sums[line.Category].Add(sums[line.Category], line.Amount)
+	}
+
+	breakdown := Breakdown{Tax: newBig()}
+	for _, category := range entity.TaxCategories {
+		sum, ok := sums[category]
+		if !ok {
+			continue
+		}
+		rate := Rates[category]
+		tax := newBig().Mul(sum, decimal.New(rate, 0))
+		divisor := decimal.New(100, 0)
+		if included {
+			divisor = decimal.New(100+rate, 0)
+		}
+		tax.Quo(tax, divisor)
+		tax = round(tax, rounding, digits)
+
+		breakdown.Subtotals = append(breakdown.Subtotals, Subtotal{Category: category, Rate: rate, Amount: sum, Tax: tax})
+		breakdown.Tax.Add(breakdown.Tax, tax)
+	}
+	return breakdown, nil
+}
+
+// round rounds an amount to digits decimals, the minor unit of its currency
+func round(amount *decimal.Big, rounding Rounding, digits int) *decimal.Big {
+	// In minor units (cents), so they can be rounded to an integer
+	minor := newBig().Copy(amount)
+	minor.SetScale(minor.Scale() - digits)
+	switch rounding {
+	case RoundingRound:
+		minor.Context.RoundingMode = decimal.ToNearestAway
+	case RoundingCeil:
+		minor.Context.RoundingMode = decimal.AwayFromZero
+	default:
+		minor.Context.RoundingMode = decimal.ToZero
+	}
+	// Minor units fit in an int64, which also normalises the exponent of a carry (1.0E+2) and -0
+	units, _ := minor.Quantize(0).Int64()
+	return newBig().SetMantScale(units, digits)
+}
+
+// newBig returns a zero precise enough for the divisions of amounts, which never end
+func newBig() *decimal.Big {
+	return decimal.WithContext(decimal.Context128)
+}
@@ -0,0 +1,160 @@
+package tax_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ericlagergren/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/niko-cb/uct/internal/domain/tax"
+)
+
+func line(category, amount string) tax.Line {
+	d, ok := new(decimal.Big).SetString(amount)
+	if !ok {
+		panic(amount)
+	}
+	return tax.Line{Category: category, Amount: d}
+}
+
+// subtotals formats a breakdown as "category rate% amount: tax" strings
+func subtotals(breakdown tax.Breakdown) []string {
+	var s []string
+	for _, subtotal := range breakdown.Subtotals {
+		s = append(s, fmt.Sprintf("%s %d%% %s: %s", subtotal.Category, subtotal.Rate, subtotal.Amount, subtotal.Tax))
+	}
+	return s
+}
+
+// TestIncluded tests the tax included in tax-inclusive amounts, rounded once per rate
+func TestIncluded(t *testing.T) {
+	tests := []struct {
+		name      string
+		lines     []tax.Line
+		rounding  tax.Rounding
+		subtotals []string
+		tax       string
+	}{
+		{"exact", []tax.Line{line("standard", "1100")}, tax.RoundingFloor, []string{"standard 10% 1100: 100"}, "100"},
+		{"standard floor", []tax.Line{line("standard", "1000")}, tax.RoundingFloor, []string{"standard 10% 1000: 90"}, "90"},
+		{"standard round", []tax.Line{line("standard", "1000")}, tax.RoundingRound, []string{"standard 10% 1000: 91"}, "91"},
+		{"standard ceil", []tax.Line{line("standard", "1000")}, tax.RoundingCeil, []string{"standard 10% 1000: 91"}, "91"},
+		{"reduced floor", []tax.Line{line("reduced", "1000")}, tax.RoundingFloor, []string{"reduced 8% 1000: 74"}, "74"},
+		{"reduced round", []tax.Line{line("reduced", "1000")}, tax.RoundingRound, []string{"reduced 8% 1000: 74"}, "74"},
+		{"reduced ceil", []tax.Line{line("reduced", "1000")}, tax.RoundingCeil, []string{"reduced 8% 1000: 75"}, "75"},
+		{
+			// Rounding each line would give 9 * 3 = 27
+			"rounded once per rate, not per line",
+			[]tax.Line{line("standard", "105"), line("standard", "105"), line("standard", "105")},
+			tax.RoundingFloor, []string{"standard 10% 315: 28"}, "28",
+		},
+		{
+			"both rates, ordered by category",
+			[]tax.Line{line("reduced", "1080"), line("standard", "5500"), line("reduced", "540.00")},
+			tax.RoundingFloor, []string{"standard 10% 5500: 500", "reduced 8% 1620.00: 120"}, "620",
+		},
+		{"cents", []tax.Line{line("standard", "1099.99")}, tax.RoundingCeil, []string{"standard 10% 1099.99: 100"}, "100"},
+		{"refund", []tax.Line{line("standard", "-1000")}, tax.RoundingFloor, []string{"standard 10% -1000: -90"}, "-90"},
+		{"refund ceil", []tax.Line{line("standard", "-1000")}, tax.RoundingCeil, []string{"standard 10% -1000: -91"}, "-91"},
+		{"no lines", nil, tax.RoundingFloor, nil, "0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			breakdown, err := tax.Included(tt.lines, tt.rounding, 0)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.subtotals, subtotals(breakdown))
+			assert.Equal(t, tt.tax, breakdown.Tax.String())
+		})
+	}
+}
+
+// TestExcluded tests the tax added to tax-exclusive amounts, like the fee of an invoice
+func TestExcluded(t *testing.T) {
+	tests := []struct {
+		name     string
+		amount   string
+		rounding tax.Rounding
+		digits   int
+		tax      string
+	}{
+		{"exact", "400", tax.RoundingFloor, 0, "40"},
+		{"fraction floor", "400.5", tax.RoundingFloor, 0, "40"},
+		{"fraction round", "400.5", tax.RoundingRound, 0, "40"},
+		{"fraction ceil", "400.5", tax.RoundingCeil, 0, "41"},
+		{"half floor", "1005", tax.RoundingFloor, 0, "100"},
+		{"half round", "1005", tax.RoundingRound, 0, "101"},
+		{"half ceil", "1005", tax.RoundingCeil, 0, "101"},
+		{"just under half", "1004.99", tax.RoundingRound, 0, "100"},
+		{"below a yen", "5", tax.RoundingFloor, 0, "0"},
+		{"below a yen ceil", "0.01", tax.RoundingCeil, 0, "1"},
+		{"zero", "0", tax.RoundingCeil, 0, "0"},
+		{"negative zero", "-5", tax.RoundingFloor, 0, "0"},
+		{"negative half", "-1005", tax.RoundingRound, 0, "-101"},
+		{"large", "9999999999999.99", tax.RoundingFloor, 0, "999999999999"},
+		{"cents floor", "400.55", tax.RoundingFloor, 2, "40.05"},
+		{"cents round", "400.55", tax.RoundingRound, 2, "40.06"},
+		{"cents ceil", "400.51", tax.RoundingCeil, 2, "40.06"},
+		{"cents carry", "999.99", tax.RoundingCeil, 2, "100.00"},
+		{"cents negative", "-400.55", tax.RoundingRound, 2, "-40.06"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			breakdown, err := tax.Excluded([]tax.Line{line("standard", tt.amount)}, tt.rounding, tt.digits)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.tax, breakdown.Tax.String())
+		})
+	}
+}
+
+// TestErrors tests unknown categories and roundings are rejected
+func TestErrors(t *testing.T) {
+	_, err := tax.Included([]tax.Line{line("zero", "100")}, tax.RoundingFloor, 0)
+	assert.ErrorIs(t, err, tax.ErrUnknownCategory)
+
+	_, err = tax.Excluded([]tax.Line{line("standard", "100")}, "truncate", 0)
+	assert.ErrorIs(t, err, tax.ErrInvalidRounding)
+
+	for _, s := range []string{"floor", "round", "ceil"} {
+		rounding, err := tax.ParseRounding(s)
+		assert.NoError(t, err)
+		assert.Equal(t, s, string(rounding))
+	}
+	_, err = tax.ParseRounding("")
+	assert.ErrorIs(t, err, tax.ErrInvalidRounding)
+}
+
+// TestParseRegistrationNumber tests the registration numbers are validated with their check digit
+func TestParseRegistrationNumber(t *testing.T) {
+	tests := []struct {
+		name   string
+		number string
+		want   string
+	}{
+		{"corporation", "T7000012050002", "T7000012050002"},
+		{"other corporation", "T1180301018771", "T1180301018771"},
+		{"check digit 9", "T9234567890123", "T9234567890123"},
+		{"lower case and spaces", " t7123456789012 ", "T7123456789012"},
+		{"wrong check digit", "T1234567890123", ""},
+		{"without T", "7000012050002", ""},
+		{"12 digits", "T700001205000", ""},
+		{"14 digits", "T70000120500020", ""},
+		{"not a digit", "T70000120500O2", ""},
+		{"hyphens", "T7-0000-1205-0002", ""},
+		{"empty", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			number, err := tax.ParseRegistrationNumber(tt.number)
+			if tt.want == "" {
+				assert.ErrorIs(t, err, tax.ErrInvalidRegistrationNumber)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, number)
+		})
+	}
+}
@@ -0,0 +1,406 @@
+package ubl
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/ericlagergren/decimal"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/types"
+
+	"github.com/niko-cb/uct/internal/domain/entity"
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+)
+
+// ErrUnsupportedDocument is returned for a valid document which can't be an invoice of this application, or for an
+// invoice which can't be written as a valid document
+var ErrUnsupportedDocument = errors.New("unsupported UBL invoice")
+
+const (
+	// maxNameLength is the length of the names of the clients and of the descriptions of the items
+	maxNameLength = 255
+	// maxPhoneLength is the length of the phones of the clients, longer telephones are not imported
+	maxPhoneLength = 20
+	// quantityDigits are the decimals of the quantities of the items
+	quantityDigits = 3
+)
+
+// categoryCodes are the tax categories of the items of the tax category codes of the consumption tax
+var categoryCodes = map[string]string{
+	TaxCategoryStandard: entity.TaxCategoryStandard,
+	TaxCategoryReduced:  entity.TaxCategoryReduced,
+}
+
+// Imported is an invoice read from a document, and the client who issued it
+type Imported struct {
+	// Invoice has the dates, the currency and the lines of the document as items, their prices tax included
+	Invoice *entity.Invoice
+	// Seller is the client issuing the document, without id
+	Seller *entity.Client
+	// TaxSubtotals are the tax subtotals of the document, tax included like the ones calculated for the invoices
+	TaxSubtotals []*entity.InvoiceTaxSubtotal
+}
+
+// Import validates a document and converts it to an invoice of its seller, or returns ErrUnsupportedDocument.
+// The lines are tax excluded in the document, and tax included in the invoices: the tax of each category
+// is split between its lines by net amount, so the items of a category add up to its taxable amount plus its tax.
+// An item whose amount can't be divided by its quantity to the cent is imported with a quantity of 1
+func Import(doc *Invoice) (*Imported, error) {
+	if err := Validate(doc); err != nil {
+		return nil, err
+	}
+	digits, ok := entity.CurrencyDigits[doc.DocumentCurrencyCode]
+	if !ok {
+		return nil, unsupported("currency %q", doc.DocumentCurrencyCode)
+	}
+	if doc.InvoiceTypeCode != InvoiceTypeCommercial {
+		return nil, unsupported("invoice type code %q, only commercial invoices (%s) are imported", doc.InvoiceTypeCode, InvoiceTypeCommercial)
+	}
+	if doc.DueDate == "" {
+		return nil, unsupported("an invoice without due date")
+	}
+	totals := doc.LegalMonetaryTotal
+	for _, adjustment := range []struct {
+		name   string
+		amount *Amount
+	}{
+		{"document level allowances", totals.AllowanceTotalAmount},
+		{"document level charges", totals.ChargeTotalAmount},
+		{"prepaid amounts", totals.PrepaidAmount},
+		{"rounding amounts", totals.PayableRoundingAmount},
+	} {
+		if adjustment.amount == nil {
+			continue
+		}
+		if minor, _ := minorUnits(adjustment.amount.Value, digits); minor != 0 {
+			return nil, unsupported("%s", adjustment.name)
+		}
+	}
+
+	seller, err := importSeller(doc.AccountingSupplierParty.Party)
+	if err != nil {
+		return nil, err
+	}
+	invoice := &entity.Invoice{
+		Currency: doc.DocumentCurrencyCode,
+		Status:   entity.InvoiceStatusUnprocessed,
+	}
+	// The dates were validated
+	invoice.IssueDate, _ = time.Parse(dateFormat, strings.TrimSpace(doc.IssueDate))
+	invoice.DueDate, _ = time.Parse(dateFormat, strings.TrimSpace(doc.DueDate))
+	payable, _ := minorUnits(totals.PayableAmount.Value, digits)
+	invoice.PaymentAmount = minorToFloat(payable, digits)
+
+	// The lines of each category, and their net amounts
+	nets := make([]int64, len(doc.InvoiceLines))
+	byCategory := map[string][]int{}
+	for i, line := range doc.InvoiceLines {
+		code := line.Item.ClassifiedTaxCategory.ID
+		if _, ok := categoryCodes[code]; !ok {
+			return nil, unsupported("invoice line %d tax category %q, only the consumption tax rates %s (10%%) and %s (8%%) are",
+				i+1, code, TaxCategoryStandard, TaxCategoryReduced)
+		}
+		if quantity, _ := parseDecimal(line.InvoicedQuantity.Value); quantity.Sign() <= 0 {
+			return nil, unsupported("invoice line %d quantity %s, the quantities must be positive", i+1, line.InvoicedQuantity.Value)
+		}
+		if utf8.RuneCountInString(strings.TrimSpace(line.Item.Name)) > maxNameLength {
+			return nil, unsupported("invoice line %d item name longer than %d characters", i+1, maxNameLength)
+		}
+		nets[i], _ = minorUnits(line.LineExtensionAmount.Value, digits)
+		byCategory[code] = append(byCategory[code], i)
+	}
+
+	// The tax of each category, split between its lines
+	invoice.Items = make([]*entity.InvoiceItem, len(doc.InvoiceLines))
+	var subtotals []*entity.InvoiceTaxSubtotal
+	for _, subtotal := range taxTotalOf(doc).TaxSubtotals {
+		code := subtotal.TaxCategory.ID
+		category, ok := categoryCodes[code]
+		if !ok {
+			return nil, unsupported("tax category %q, only the consumption tax rates %s (10%%) and %s (8%%) are",
+				code, TaxCategoryStandard, TaxCategoryReduced)
+		}
+		taxable, _ := minorUnits(subtotal.TaxableAmount.Value, digits)
+		tax, _ := minorUnits(subtotal.TaxAmount.Value, digits)
+		subtotals = append(subtotals, &entity.InvoiceTaxSubtotal{
+			TaxCategory: category,
+			Rate:        int(taxRates[code]),
+			Amount:      minorToFloat(taxable+tax, digits),
+			TaxAmount:   minorToFloat(tax, digits),
+		})
+
+		lines := byCategory[code]
+		weights := make([]int64, len(lines))
+		for j, i := range lines {
+			weights[j] = nets[i]
+		}
+		for j, share := range allocate(tax, weights) {
+			line := doc.InvoiceLines[lines[j]]
+			quantity, _ := parseDecimal(line.InvoicedQuantity.Value)
+			invoice.Items[lines[j]] = importItem(strings.TrimSpace(line.Item.Name), quantity, nets[lines[j]]+share, digits, category)
+		}
+	}
+
+	return &Imported{Invoice: invoice, Seller: seller, TaxSubtotals: orderSubtotals(subtotals)}, nil
+}
+
+// importSeller returns the client of the seller of a document
+func importSeller(party Party) (*entity.Client, error) {
+	client := &entity.Client{
+		Name:               legalName(party),
+		RegistrationNumber: RegistrationNumber(party),
+	}
+	if utf8.RuneCountInString(client.Name) > maxNameLength {
+		return nil, unsupported("seller name longer than %d characters", maxNameLength)
+	}
+	if address := party.PostalAddress; address != nil {
+		var parts []string
+		for _, part := range []string{address.PostalZone, address.CountrySubentity, address.CityName, address.StreetName,
+			address.AdditionalStreetName, address.AddressLine} {
+			if part = strings.TrimSpace(part); part != "" {
+				parts = append(parts, part)
+			}
+		}
+		client.Address = strings.Join(parts, " ")
+	}
+	if party.Contact != nil && utf8.RuneCountInString(strings.TrimSpace(party.Contact.Telephone)) <= maxPhoneLength {
+		client.Phone = strings.TrimSpace(party.Contact.Telephone)
+	}
+	return client, nil
+}
+
+// importItem returns the item of a line whose amount tax included is amount, in minor units
+func importItem(name string, quantity *decimal.Big, amount int64, digits int, category string) *entity.InvoiceItem {
+	total := minorToFloat(amount, digits)
+	item := &entity.InvoiceItem{Description: name, Quantity: 1, UnitPrice: total, TaxCategory: category}
+
+	// The unit price to the cent, if the items calculate the same amount from it
+	if newBig().Copy(quantity).Reduce().Scale() > quantityDigits {
+		return item
+	}
+	q, _ := quantity.Float64()
+	unitPrice := math.Round(total/q*100) / 100
+	if math.Round(q*unitPrice*100) == math.Round(total*100) {
+		item.Quantity, item.UnitPrice = q, unitPrice
+	}
+	return item
+}
+
+// Export converts an invoice, with its items and its tax subtotals, to the document its client issued to its company.
+// The fee of the invoice is not part of it. The items are tax included, and the lines tax excluded: the tax of
+// each category is split between its items by amount. It returns ErrUnsupportedDocument for an invoice without
+// items, or with amounts more precise than its currency
+func Export(invoice *models.Invoice, company *models.Company, client *models.Client) (*Invoice, error) {
+	items := invoice.R.GetInvoiceItems()
+	if len(items) == 0 {
+		return nil, unsupported("an invoice without items")
+	}
+	digits, ok := entity.CurrencyDigits[invoice.Currency]
+	if !ok {
+		return nil, unsupported("currency %q", invoice.Currency)
+	}
+
+	doc := &Invoice{
+		CustomizationID:         CustomizationID,
+		ProfileID:               ProfileID,
+		ID:                      strconv.FormatInt(invoice.ID, 10),
+		IssueDate:               invoice.IssueDate.Format(dateFormat),
+		DueDate:                 invoice.DueDate.Format(dateFormat),
+		InvoiceTypeCode:         InvoiceTypeCommercial,
+		DocumentCurrencyCode:    invoice.Currency,
+		AccountingSupplierParty: PartyContainer{Party: exportParty(client.Name, client.Address, client.Phone, client.RegistrationNumber.String)},
+		AccountingCustomerParty: PartyContainer{Party: exportParty(company.Name, company.Address, company.Phone, company.RegistrationNumber.String)},
+	}
+	amount := func(minor int64) Amount {
+		return Amount{Value: formatMinor(minor, digits), CurrencyID: invoice.Currency}
+	}
+
+	amounts := make([]int64, len(items))
+	byCategory := map[string][]int{}
+	for i, item := range items {
+		var err error
+		if amounts[i], err = exportMinor(item.Amount, digits, "item amount"); err != nil {
+			return nil, err
+		}
+		byCategory[item.TaxCategory] = append(byCategory[item.TaxCategory], i)
+	}
+
+	// The tax of each category, split between its items
+	nets := make([]int64, len(items))
+	taxTotal := TaxTotal{}
+	var tax, lineTotal int64
+	for _, subtotal := range invoice.R.GetInvoiceTaxSubtotals() {
+		code := codeOf(subtotal.TaxCategory)
+		categoryTotal, err := exportMinor(subtotal.Amount, digits, "tax subtotal amount")
+		if err != nil {
+			return nil, err
+		}
+		categoryTax, err := exportMinor(subtotal.TaxAmount, digits, "tax subtotal tax amount")
+		if err != nil {
+			return nil, err
+		}
+		taxTotal.TaxSubtotals = append(taxTotal.TaxSubtotals, TaxSubtotal{
+			TaxableAmount: amount(categoryTotal - categoryTax),
+			TaxAmount:     amount(categoryTax),
+			TaxCategory:   TaxCategory{ID: code, Percent: strconv.Itoa(subtotal.Rate), TaxSchemeID: TaxSchemeVAT},
+		})
+		tax += categoryTax
+
+		lines := byCategory[subtotal.TaxCategory]
+		weights := make([]int64, len(lines))
+		for j, i := range lines {
+			weights[j] = amounts[i]
+		}
+		for j, share := range allocate(categoryTax, weights) {
+			nets[lines[j]] = amounts[lines[j]] - share
+		}
+	}
+	taxTotal.TaxAmount = amount(tax)
+	doc.TaxTotals = []TaxTotal{taxTotal}
+
+	for i, item := range items {
+		code := codeOf(item.TaxCategory)
+		rate := taxRates[code]
+		quantity := item.Quantity.Big.String()
+		doc.InvoiceLines = append(doc.InvoiceLines, InvoiceLine{
+			ID:                  strconv.Itoa(i + 1),
+			InvoicedQuantity:    Quantity{Value: quantity, UnitCode: UnitCodePiece},
+			LineExtensionAmount: amount(nets[i]),
+			Item: Item{
+				Name:                  item.Description,
+				ClassifiedTaxCategory: TaxCategory{ID: code, Percent: strconv.FormatInt(rate, 10), TaxSchemeID: TaxSchemeVAT},
+			},
+			Price: exportPrice(nets[i], item.Quantity.Big, digits, invoice.Currency),
+		})
+		lineTotal += nets[i]
+	}
+
+	payable, err := exportMinor(invoice.PaymentAmount, digits, "payment amount")
+	if err != nil {
+		return nil, err
+	}
+	doc.LegalMonetaryTotal = MonetaryTotal{
+		LineExtensionAmount: amount(lineTotal),
+		TaxExclusiveAmount:  amount(lineTotal),
+		TaxInclusiveAmount:  amount(payable),
+		PayableAmount:       amount(payable),
+	}
+	return doc, nil
+}
+
+// exportParty returns the party of a client or of a company, in Japan
+func exportParty(name string, address, phone null.String, registrationNumber string) Party {
+	party := Party{Name: name, PartyLegalEntity: &LegalEntity{RegistrationName: name}}
+	if strings.TrimSpace(address.String) != "" {
+		party.PostalAddress = &Address{StreetName: address.String, CountryCode: CountryJP}
+	}
+	if registrationNumber != "" {
+		party.PartyTaxSchemes = []PartyTaxScheme{{CompanyID: registrationNumber, TaxSchemeID: TaxSchemeVAT}}
+	}
+	if phone.String != "" {
+		party.Contact = &Contact{Telephone: phone.String}
+	}
+	return party
+}
+
+// exportPrice returns the net price of a line: its net amount divided by its quantity if it is exact to the
+// minor unit, else its net amount for its quantity as base quantity
+func exportPrice(net int64, quantity *decimal.Big, digits int, currency string) Price {
+	price := newBig().Quo(decimal.New(net, digits), quantity)
+	if minor, exact := roundMinor(price, digits); exact {
+		return Price{PriceAmount: Amount{Value: formatMinor(minor, digits), CurrencyID: currency}}
+	}
+	return Price{
+		PriceAmount:  Amount{Value: formatMinor(net, digits), CurrencyID: currency},
+		BaseQuantity: &Quantity{Value: quantity.String(), UnitCode: UnitCodePiece},
+	}
+}
+
+// exportMinor converts a stored amount to minor units, or returns ErrUnsupportedDocument
+func exportMinor(amount types.Decimal, digits int, name string) (int64, error) {
+	minor, err := minorUnits(amount.Big.String(), digits)
+	if err != nil {
+		return 0, unsupported("%s %s: %v", name, amount.Big.String(), err)
+	}
+	return minor, nil
+}
+
+// codeOf returns the tax category code of a tax category of the items
+func codeOf(category string) string {
+	for code, c := range categoryCodes {
+		if c == category {
+			return code
+		}
+	}
+	return category
+}
+
+// taxTotalOf returns the tax total of a validated document, in its currency
+func taxTotalOf(doc *Invoice) *TaxTotal {
+	v := &validator{currency: doc.DocumentCurrencyCode}
+	return v.taxTotal(doc.TaxTotals)
+}
+
+// orderSubtotals orders tax subtotals like entity.TaxCategories, as they are calculated
+func orderSubtotals(subtotals []*entity.InvoiceTaxSubtotal) []*entity.InvoiceTaxSubtotal {
+	ordered := make([]*entity.InvoiceTaxSubtotal, 0, len(subtotals))
+	for _, category := range entity.TaxCategories {
+		for _, subtotal := range subtotals {
+			if subtotal.TaxCategory == category {
+				ordered = append(ordered, subtotal)
+			}
+		}
+	}
+	return ordered
+}
+
+// allocate splits a positive amount between parts by weight, in minor units: each part gets its share rounded down,
+// and the units left go one by one to the parts with the largest remainders, the first ones on ties
+func allocate(amount int64, weights []int64) []int64 {
+	shares := make([]int64, len(weights))
+	if len(weights) == 0 {
+		return shares
+	}
+	total := big.NewInt(0)
+	for _, weight := range weights {
+		total.Add(total, big.NewInt(weight))
+	}
+	if total.Sign() == 0 {
+		shares[0] = amount
+		return shares
+	}
+
+	remainders := make([]*big.Int, len(weights))
+	left := amount
+	for i, weight := range weights {
+		share, remainder := new(big.Int).QuoRem(new(big.Int).Mul(big.NewInt(amount), big.NewInt(weight)), total, new(big.Int))
+		shares[i], remainders[i] = share.Int64(), remainder
+		left -= shares[i]
+	}
+	for ; left > 0; left-- {
+		largest := 0
+		for i := range remainders {
+			if remainders[i].Cmp(remainders[largest]) > 0 {
+				largest = i
+			}
+		}
+		shares[largest]++
+		remainders[largest] = big.NewInt(-1)
+	}
+	return shares
+}
+
+// minorToFloat converts an amount in minor units to the float64 of the entities
+func minorToFloat(minor int64, digits int) float64 {
+	return float64(minor) / math.Pow10(digits)
+}
+
+func unsupported(format string, args ...interface{}) error {
+	return fmt.Errorf("%w: %s", ErrUnsupportedDocument, fmt.Sprintf(format, args...))
+}
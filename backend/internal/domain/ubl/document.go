@@ -0,0 +1,246 @@
+// Package ubl reads and writes electronic invoices as UBL 2.1 documents, following JP PINT, the Japanese
+// specification of Peppol: the invoices a company (the buyer) receives from its clients (the sellers)
+package ubl
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+)
+
+// The identifiers of the documents written
+const (
+	// CustomizationID identifies the JP PINT specification of the invoices
+	CustomizationID = "urn:peppol:pint:billing-1@jp-1"
+	// ProfileID is the Peppol business process of the invoices
+	ProfileID = "urn:peppol:bis:billing"
+	// InvoiceTypeCommercial is the type code of the commercial invoices, the only ones imported
+	InvoiceTypeCommercial = "380"
+	// TaxSchemeVAT identifies the consumption tax in the tax schemes
+	TaxSchemeVAT = "VAT"
+	// CountryJP is the country code of the addresses of the exported parties
+	CountryJP = "JP"
+	// UnitCodePiece is the unit of the quantities of the exported lines (one item)
+	UnitCodePiece = "H87"
+)
+
+// The tax category codes of the consumption tax rates in JP PINT
+const (
+	// TaxCategoryStandard is the standard rate, 10%
+	TaxCategoryStandard = "S"
+	// TaxCategoryReduced is the reduced rate, 8%
+	TaxCategoryReduced = "AA"
+)
+
+// The namespaces of the elements of the invoices
+const (
+	nsInvoice = "urn:oasis:names:specification:ubl:schema:xsd:Invoice-2"
+	nsCAC     = "urn:oasis:names:specification:ubl:schema:xsd:CommonAggregateComponents-2"
+	nsCBC     = "urn:oasis:names:specification:ubl:schema:xsd:CommonBasicComponents-2"
+)
+
+// prefixes are the prefixes of the namespaces in the names of the fields, the invoice itself having none
+var prefixes = map[string]string{
+	nsInvoice: "",
+	nsCAC:     "cac:",
+	nsCBC:     "cbc:",
+}
+
+// ErrMalformedDocument is returned for a document which is not a UBL invoice
+var ErrMalformedDocument = errors.New("malformed UBL invoice")
+
+// Invoice is a UBL invoice, with the elements of JP PINT this application reads and writes, in the order of the schema
+type Invoice struct {
+	XMLName  xml.Name `xml:"Invoice"`
+	Xmlns    string   `xml:"xmlns,attr,omitempty"`
+	XmlnsCAC string   `xml:"xmlns:cac,attr,omitempty"`
+	XmlnsCBC string   `xml:"xmlns:cbc,attr,omitempty"`
+
+	CustomizationID         string         `xml:"cbc:CustomizationID"`
+	ProfileID               string         `xml:"cbc:ProfileID,omitempty"`
+	ID                      string         `xml:"cbc:ID"`
+	IssueDate               string         `xml:"cbc:IssueDate"`
+	DueDate                 string         `xml:"cbc:DueDate,omitempty"`
+	InvoiceTypeCode         string         `xml:"cbc:InvoiceTypeCode"`
+	Note                    string         `xml:"cbc:Note,omitempty"`
+	DocumentCurrencyCode    string         `xml:"cbc:DocumentCurrencyCode"`
+	AccountingSupplierParty PartyContainer `xml:"cac:AccountingSupplierParty"`
+	AccountingCustomerParty PartyContainer `xml:"cac:AccountingCustomerParty"`
+	PaymentTerms            string         `xml:"cac:PaymentTerms>cbc:Note,omitempty"`
+	TaxTotals               []TaxTotal     `xml:"cac:TaxTotal"`
+	LegalMonetaryTotal      MonetaryTotal  `xml:"cac:LegalMonetaryTotal"`
+	InvoiceLines            []InvoiceLine  `xml:"cac:InvoiceLine"`
+}
+
+// PartyContainer holds the party of the seller or of the buyer
+type PartyContainer struct {
+	Party Party `xml:"cac:Party"`
+}
+
+// Party is the seller or the buyer of an invoice
+type Party struct {
+	Name             string           `xml:"cac:PartyName>cbc:Name,omitempty"`
+	PostalAddress    *Address         `xml:"cac:PostalAddress"`
+	PartyTaxSchemes  []PartyTaxScheme `xml:"cac:PartyTaxScheme"`
+	PartyLegalEntity *LegalEntity     `xml:"cac:PartyLegalEntity"`
+	Contact          *Contact         `xml:"cac:Contact"`
+}
+
+// Address is the postal address of a party
+type Address struct {
+	StreetName           string `xml:"cbc:StreetName,omitempty"`
+	AdditionalStreetName string `xml:"cbc:AdditionalStreetName,omitempty"`
+	CityName             string `xml:"cbc:CityName,omitempty"`
+	PostalZone           string `xml:"cbc:PostalZone,omitempty"`
+	CountrySubentity     string `xml:"cbc:CountrySubentity,omitempty"`
+	AddressLine          string `xml:"cac:AddressLine>cbc:Line,omitempty"`
+	CountryCode          string `xml:"cac:Country>cbc:IdentificationCode"`
+}
+
+// PartyTaxScheme is a tax identifier of a party: the registration number as qualified invoice issuer for VAT
+type PartyTaxScheme struct {
+	CompanyID   string `xml:"cbc:CompanyID"`
+	TaxSchemeID string `xml:"cac:TaxScheme>cbc:ID"`
+}
+
+// LegalEntity is the registered name of a party
+type LegalEntity struct {
+	RegistrationName string `xml:"cbc:RegistrationName"`
+}
+
+// Contact is how to reach a party
+type Contact struct {
+	Telephone string `xml:"cbc:Telephone,omitempty"`
+}
+
+// TaxTotal is the consumption tax of an invoice, by tax category
+type TaxTotal struct {
+	TaxAmount    Amount        `xml:"cbc:TaxAmount"`
+	TaxSubtotals []TaxSubtotal `xml:"cac:TaxSubtotal"`
+}
+
+// TaxSubtotal is the total of the lines of a tax category, tax excluded, and its tax
+type TaxSubtotal struct {
+	TaxableAmount Amount      `xml:"cbc:TaxableAmount"`
+	TaxAmount     Amount      `xml:"cbc:TaxAmount"`
+	TaxCategory   TaxCategory `xml:"cac:TaxCategory"`
+}
+
+// TaxCategory is a tax category code with its rate in percent
+type TaxCategory struct {
+	ID          string `xml:"cbc:ID"`
+	Percent     string `xml:"cbc:Percent,omitempty"`
+	TaxSchemeID string `xml:"cac:TaxScheme>cbc:ID"`
+}
+
+// MonetaryTotal is the totals of an invoice
+type MonetaryTotal struct {
+	LineExtensionAmount   Amount  `xml:"cbc:LineExtensionAmount"`
+	TaxExclusiveAmount    Amount  `xml:"cbc:TaxExclusiveAmount"`
+	TaxInclusiveAmount    Amount  `xml:"cbc:TaxInclusiveAmount"`
+	AllowanceTotalAmount  *Amount `xml:"cbc:AllowanceTotalAmount"`
+	ChargeTotalAmount     *Amount `xml:"cbc:ChargeTotalAmount"`
+	PrepaidAmount         *Amount `xml:"cbc:PrepaidAmount"`
+	PayableRoundingAmount *Amount `xml:"cbc:PayableRoundingAmount"`
+	PayableAmount         Amount  `xml:"cbc:PayableAmount"`
+}
+
+// InvoiceLine is a line of an invoice, its amounts tax excluded
+type InvoiceLine struct {
+	ID                  string   `xml:"cbc:ID"`
+	InvoicedQuantity    Quantity `xml:"cbc:InvoicedQuantity"`
+	LineExtensionAmount Amount   `xml:"cbc:LineExtensionAmount"`
+	Item                Item     `xml:"cac:Item"`
+	Price               Price    `xml:"cac:Price"`
+}
+
+// Item is what a line invoices
+type Item struct {
+	Name                  string      `xml:"cbc:Name"`
+	ClassifiedTaxCategory TaxCategory `xml:"cac:ClassifiedTaxCategory"`
+}
+
+// Price is the net price of an item, for its base quantity (1 if empty)
+type Price struct {
+	PriceAmount  Amount    `xml:"cbc:PriceAmount"`
+	BaseQuantity *Quantity `xml:"cbc:BaseQuantity"`
+}
+
+// Amount is an amount in a currency, e.g. 1000 in JPY
+type Amount struct {
+	Value      string `xml:",chardata"`
+	CurrencyID string `xml:"currencyID,attr"`
+}
+
+// Quantity is a quantity in a unit, a UN/ECE recommendation 20 code
+type Quantity struct {
+	Value    string `xml:",chardata"`
+	UnitCode string `xml:"unitCode,attr,omitempty"`
+}
+
+// Parse reads a UBL invoice, whatever the prefixes of its namespaces, or returns ErrMalformedDocument.
+// The elements and attributes of other namespaces (like the extensions) are ignored
+func Parse(data []byte) (*Invoice, error) {
+	var invoice Invoice
+	decoder := xml.NewTokenDecoder(&prefixer{decoder: xml.NewDecoder(bytes.NewReader(data))})
+	if err := decoder.Decode(&invoice); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrMalformedDocument, err)
+	}
+	return &invoice, nil
+}
+
+// Marshal writes an invoice as an XML document, with the prefixes cac and cbc
+func Marshal(invoice *Invoice) ([]byte, error) {
+	invoice.Xmlns, invoice.XmlnsCAC, invoice.XmlnsCBC = nsInvoice, nsCAC, nsCBC
+
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	encoder := xml.NewEncoder(&buf)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(invoice); err != nil {
+		return nil, err
+	}
+	buf.WriteByte('\n')
+	return buf.Bytes(), nil
+}
+
+// prefixer names the elements of the documents read by their usual prefix rather than their namespace,
+// so the same fields read and write them (encoding/xml can't write prefixes)
+type prefixer struct {
+	decoder *xml.Decoder
+}
+
+func (p *prefixer) Token() (xml.Token, error) {
+	token, err := p.decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch t := token.(type) {
+	case xml.StartElement:
+		t.Name = prefixed(t.Name)
+		attrs := t.Attr[:0]
+		for _, attr := range t.Attr {
+			// The namespaces are already resolved, and the attributes of UBL have none
+			if attr.Name.Space == "" && attr.Name.Local != "xmlns" {
+				attrs = append(attrs, attr)
+			}
+		}
+		t.Attr = attrs
+		return xml.CopyToken(t), nil
+	case xml.EndElement:
+		t.Name = prefixed(t.Name)
+		return t, nil
+	}
+	return xml.CopyToken(token), nil
+}
+
+// prefixed returns the name of an element with the prefix of its namespace, or a name matching no field
+// for the other namespaces
+func prefixed(name xml.Name) xml.Name {
+	prefix, ok := prefixes[name.Space]
+	if !ok {
+		prefix = "other:"
+	}
+	return xml.Name{Local: prefix + name.Local}
+}
@@ -0,0 +1,396 @@
+package ubl
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"math/big"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ericlagergren/decimal"
+
+	"github.com/niko-cb/uct/internal/domain/entity"
+	"github.com/niko-cb/uct/internal/domain/tax"
+)
+
+// ErrInvalidDocument is wrapped by the ValidationError of a document breaking business rules
+var ErrInvalidDocument = errors.New("invalid UBL invoice")
+
+// dateFormat is the format of the dates of the documents
+const dateFormat = "2006-01-02"
+
+// taxRates are the rates of the tax category codes of the consumption tax, in percent
+var taxRates = map[string]int64{
+	TaxCategoryStandard: 10,
+	TaxCategoryReduced:  8,
+}
+
+// Violation is a business rule broken by a document: a rule of EN 16931 (BR-xx), of Peppol, or one of the rules
+// of the Japanese consumption tax checked here: JP-01 the registration number, JP-02 the rates, JP-03 the tax rounding
+type Violation struct {
+	Rule    string
+	Message string
+}
+
+// ValidationError lists the business rules a document breaks
+type ValidationError struct {
+	Violations []Violation
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		messages[i] = v.Rule + " " + v.Message
+	}
+	return fmt.Sprintf("%s: %s", ErrInvalidDocument, strings.Join(messages, "; "))
+}
+
+func (e *ValidationError) Unwrap() error {
+	return ErrInvalidDocument
+}
+
+// Validate checks a document against the core business rules of EN 16931 and JP PINT which apply to the
+// elements it has, and returns a ValidationError listing the broken ones
+func Validate(invoice *Invoice) error {
+	v := &validator{currency: invoice.DocumentCurrencyCode, digits: 2}
+	if digits, ok := entity.CurrencyDigits[invoice.DocumentCurrencyCode]; ok {
+		v.digits = digits
+	}
+
+	v.require(invoice.CustomizationID, "BR-01", "an invoice shall have a specification identifier")
+	v.require(invoice.ID, "BR-02", "an invoice shall have an invoice number")
+	if v.require(invoice.IssueDate, "BR-03", "an invoice shall have an issue date") {
+		v.date(invoice.IssueDate, "BR-03", "issue date")
+	}
+	v.require(invoice.InvoiceTypeCode, "BR-04", "an invoice shall have an invoice type code")
+	v.require(invoice.DocumentCurrencyCode, "BR-05", "an invoice shall have a currency code")
+	if invoice.DueDate != "" {
+		v.date(invoice.DueDate, "BR-CO-25", "due date")
+	}
+
+	seller, buyer := invoice.AccountingSupplierParty.Party, invoice.AccountingCustomerParty.Party
+	v.require(legalName(seller), "BR-06", "an invoice shall contain the seller name")
+	v.require(legalName(buyer), "BR-07", "an invoice shall contain the buyer name")
+	if v.check(seller.PostalAddress != nil, "BR-08", "an invoice shall contain the seller postal address") {
+		v.require(seller.PostalAddress.CountryCode, "BR-09", "the seller postal address shall contain a country code")
+	}
+	if v.check(buyer.PostalAddress != nil, "BR-10", "an invoice shall contain the buyer postal address") {
+		v.require(buyer.PostalAddress.CountryCode, "BR-11", "the buyer postal address shall contain a country code")
+	}
+	if number := RegistrationNumber(seller); number != "" {
+		_, err := tax.ParseRegistrationNumber(number)
+		v.check(err == nil, "JP-01",
+			fmt.Sprintf("the seller registration number %q shall be T followed by 13 digits, the first one checking the others", number))
+	}
+
+	totals := invoice.LegalMonetaryTotal
+	lineTotal, hasLineTotal := v.amount(totals.LineExtensionAmount, "BR-12", "sum of invoice line net amount")
+	taxExclusive, hasTaxExclusive := v.amount(totals.TaxExclusiveAmount, "BR-13", "invoice total amount without VAT")
+	taxInclusive, hasTaxInclusive := v.amount(totals.TaxInclusiveAmount, "BR-14", "invoice total amount with VAT")
+	payable, hasPayable := v.amount(totals.PayableAmount, "BR-15", "amount due for payment")
+	allowances := v.optionalAmount(totals.AllowanceTotalAmount, "sum of allowances on document level")
+	charges := v.optionalAmount(totals.ChargeTotalAmount, "sum of charges on document level")
+	prepaid := v.optionalAmount(totals.PrepaidAmount, "paid amount")
+	rounding := v.optionalAmount(totals.PayableRoundingAmount, "rounding amount")
+
+	// The lines, and their net amounts by tax category
+	v.check(len(invoice.InvoiceLines) > 0, "BR-16", "an invoice shall have at least one invoice line")
+	lines := big.NewInt(0)
+	linesComplete := true
+	categories := map[string]*big.Int{}
+	for i, line := range invoice.InvoiceLines {
+		net, ok := v.line(i, line)
+		if !ok {
+			linesComplete = false
+			continue
+		}
+		lines.Add(lines, big.NewInt(net))
+		category := line.Item.ClassifiedTaxCategory
+		key := category.ID + "/" + normalizePercent(category.Percent)
+		if categories[key] == nil {
+			categories[key] = big.NewInt(0)
+		}
+		categories[key].Add(categories[key], big.NewInt(net))
+	}
+	if linesComplete && hasLineTotal {
+		v.check(lines.Cmp(big.NewInt(lineTotal)) == 0, "BR-CO-10",
+			fmt.Sprintf("sum of invoice line net amount %s shall be the sum of the line net amounts %s",
+				v.format(lineTotal), v.format(lines.Int64())))
+	}
+	if hasLineTotal && hasTaxExclusive {
+		v.check(taxExclusive == lineTotal-allowances+charges, "BR-CO-13",
+			"invoice total amount without VAT shall be the sum of the line net amounts minus the allowances plus the charges")
+	}
+
+	// The tax, in the currency of the document (another total may be in the tax currency)
+	taxTotal := v.taxTotal(invoice.TaxTotals)
+	if v.check(taxTotal != nil, "BR-CO-18", "an invoice shall have at least one VAT breakdown") {
+		tax, hasTax := v.amount(taxTotal.TaxAmount, "BR-CO-14", "invoice total VAT amount")
+		subtotals := big.NewInt(0)
+		subtotalsComplete := true
+		seen := map[string]bool{}
+		for i, subtotal := range taxTotal.TaxSubtotals {
+			taxable, subtotalTax, key, ok := v.subtotal(i, subtotal)
+			if !ok {
+				subtotalsComplete = false
+				continue
+			}
+			subtotals.Add(subtotals, big.NewInt(subtotalTax))
+			if v.check(!seen[key], "BR-CO-18", fmt.Sprintf("tax category %s is broken down twice", key)) {
+				seen[key] = true
+				sum := categories[key]
+				if sum == nil {
+					sum = big.NewInt(0)
+				}
+				if linesComplete {
+					v.check(sum.Cmp(big.NewInt(taxable)) == 0, "BR-S-08",
+						fmt.Sprintf("the taxable amount %s of tax category %s shall be the sum of the net amounts of its lines %s",
+							v.format(taxable), key, v.format(sum.Int64())))
+				}
+			}
+		}
+		if linesComplete {
+			for _, key := range slices.Sorted(maps.Keys(categories)) {
+				v.check(seen[key], "BR-CO-18", fmt.Sprintf("tax category %s of lines has no VAT breakdown", key))
+			}
+		}
+		if hasTax && subtotalsComplete {
+			v.check(subtotals.Cmp(big.NewInt(tax)) == 0, "BR-CO-14",
+				"invoice total VAT amount shall be the sum of the VAT category tax amounts")
+		}
+		if hasTax && hasTaxExclusive && hasTaxInclusive {
+			v.check(taxInclusive == taxExclusive+tax, "BR-CO-15",
+				"invoice total amount with VAT shall be the invoice total amount without VAT plus the invoice total VAT amount")
+		}
+	}
+	if hasTaxInclusive && hasPayable {
+		v.check(payable == taxInclusive-prepaid+rounding, "BR-CO-16",
+			"amount due for payment shall be the invoice total amount with VAT minus the paid amount plus the rounding amount")
+	}
+	if hasPayable {
+		v.check(payable <= 0 || invoice.DueDate != "" || invoice.PaymentTerms != "", "BR-CO-25",
+			"an invoice with a positive amount due shall have a payment due date or payment terms")
+	}
+
+	if len(v.violations) > 0 {
+		return &ValidationError{Violations: v.violations}
+	}
+	return nil
+}
+
+// RegistrationNumber returns the VAT identifier of a party, its registration number as qualified invoice issuer
+func RegistrationNumber(party Party) string {
+	for _, scheme := range party.PartyTaxSchemes {
+		if scheme.TaxSchemeID == TaxSchemeVAT {
+			return strings.TrimSpace(scheme.CompanyID)
+		}
+	}
+	return ""
+}
+
+// legalName returns the registered name of a party, else its trading name
+func legalName(party Party) string {
+	if party.PartyLegalEntity != nil && strings.TrimSpace(party.PartyLegalEntity.RegistrationName) != "" {
+		return strings.TrimSpace(party.PartyLegalEntity.RegistrationName)
+	}
+	return strings.TrimSpace(party.Name)
+}
+
+// validator collects the violations of a document, its amounts being in minor units of its currency
+type validator struct {
+	currency   string
+	digits     int
+	violations []Violation
+}
+
+// check records a violation unless ok, and returns ok
+func (v *validator) check(ok bool, rule, message string) bool {
+	if !ok {
+		v.violations = append(v.violations, Violation{Rule: rule, Message: message})
+	}
+	return ok
+}
+
+// require records a violation if a value is blank, and reports whether it is not
+func (v *validator) require(value string, rule, message string) bool {
+	return v.check(strings.TrimSpace(value) != "", rule, message)
+}
+
+// date checks a date is formatted YYYY-MM-DD
+func (v *validator) date(value string, rule, name string) bool {
+	_, err := time.Parse(dateFormat, strings.TrimSpace(value))
+	return v.check(err == nil, rule, fmt.Sprintf("%s %q shall be a date (YYYY-MM-DD)", name, value))
+}
+
+// amount returns an amount in minor units, and whether it is valid: present (else rule is broken),
+// in the currency of the document and without more decimals than its minor unit
+func (v *validator) amount(amount Amount, rule, name string) (int64, bool) {
+	if !v.require(amount.Value, rule, fmt.Sprintf("an invoice shall have the %s", name)) {
+		return 0, false
+	}
+	if !v.check(amount.CurrencyID == v.currency, "PEPPOL-EN16931-R051",
+		fmt.Sprintf("the currency of the %s %q shall be the invoice currency %q", name, amount.CurrencyID, v.currency)) {
+		return 0, false
+	}
+	minor, err := minorUnits(amount.Value, v.digits)
+	if !v.check(err == nil, "BR-DEC", fmt.Sprintf("%s %q: %v", name, amount.Value, err)) {
+		return 0, false
+	}
+	return minor, true
+}
+
+// optionalAmount returns an optional amount in minor units, 0 without it
+func (v *validator) optionalAmount(amount *Amount, name string) int64 {
+	if amount == nil {
+		return 0
+	}
+	minor, _ := v.amount(*amount, "BR-DEC", name)
+	return minor
+}
+
+// line checks the i-th line of a document, and returns its net amount if it is valid
+func (v *validator) line(i int, line InvoiceLine) (int64, bool) {
+	where := fmt.Sprintf("invoice line %d", i+1)
+	ok := v.require(line.ID, "BR-21", where+" shall have an invoice line identifier")
+	ok = v.require(line.InvoicedQuantity.Value, "BR-22", where+" shall have an invoiced quantity") && ok
+	ok = v.require(line.InvoicedQuantity.UnitCode, "BR-23", where+" shall have an invoiced quantity unit of measure code") && ok
+	net, netOK := v.amount(line.LineExtensionAmount, "BR-24", where+" net amount")
+	ok = netOK && ok
+	ok = v.require(line.Item.Name, "BR-25", where+" shall contain the item name") && ok
+	ok = v.require(line.Price.PriceAmount.Value, "BR-26", where+" shall contain the item net price") && ok
+	ok = v.require(line.Item.ClassifiedTaxCategory.ID, "BR-CO-04", where+" shall be categorized with an invoiced item VAT category code") && ok
+	if !ok {
+		return 0, false
+	}
+
+	quantity, quantityOK := parseDecimal(line.InvoicedQuantity.Value)
+	ok = v.check(quantityOK, "BR-22", fmt.Sprintf("%s quantity %q shall be a number", where, line.InvoicedQuantity.Value))
+	price, priceOK := parseDecimal(line.Price.PriceAmount.Value)
+	if v.check(priceOK, "BR-26", fmt.Sprintf("%s price %q shall be a number", where, line.Price.PriceAmount.Value)) {
+		ok = v.check(price.Sign() >= 0, "BR-27", where+" item net price shall not be negative") && ok
+	} else {
+		ok = false
+	}
+	base := decimal.New(1, 0)
+	if line.Price.BaseQuantity != nil {
+		var baseOK bool
+		base, baseOK = parseDecimal(line.Price.BaseQuantity.Value)
+		ok = v.check(baseOK && base.Sign() > 0, "BR-DEC", where+" item price base quantity shall be a positive number") && ok
+	}
+	ok = v.category(where, line.Item.ClassifiedTaxCategory) && ok
+	if !ok {
+		return 0, false
+	}
+
+	// quantity * price / base quantity, to the minor unit
+	expected := newBig().Mul(quantity, price)
+	expected.Quo(expected, base)
+	expectedMinor, exact := roundMinor(expected, v.digits)
+	v.check(exact && expectedMinor == net, "PEPPOL-EN16931-R120",
+		fmt.Sprintf("%s net amount %s shall be the invoiced quantity times the item net price, %s", where,
+			v.format(net), expected.Quantize(v.digits).String()))
+	return net, true
+}
+
+// subtotal checks the i-th tax subtotal of a document, and returns its taxable amount, its tax and its category
+// (code/rate) if it is valid
+func (v *validator) subtotal(i int, subtotal TaxSubtotal) (int64, int64, string, bool) {
+	where := fmt.Sprintf("VAT breakdown %d", i+1)
+	taxable, taxableOK := v.amount(subtotal.TaxableAmount, "BR-45", where+" taxable amount")
+	tax, taxOK := v.amount(subtotal.TaxAmount, "BR-46", where+" tax amount")
+	categoryOK := v.require(subtotal.TaxCategory.ID, "BR-47", where+" shall be defined through a VAT category code") &&
+		v.category(where, subtotal.TaxCategory)
+	if !taxableOK || !taxOK || !categoryOK {
+		return 0, 0, "", false
+	}
+
+	// The tax of each rate is rounded once, to the minor unit of the currency
+	rate, known := taxRates[subtotal.TaxCategory.ID]
+	if known {
+		exact := newBig().Mul(decimal.New(taxable, v.digits), decimal.New(rate, 2))
+		difference := newBig().Sub(exact, decimal.New(tax, v.digits))
+		v.check(difference.CmpAbs(decimal.New(1, v.digits)) < 0, "JP-03",
+			fmt.Sprintf("%s tax amount %s shall be its taxable amount %s times %d%%, rounded once", where,
+				v.format(tax), v.format(taxable), rate))
+	}
+	return taxable, tax, subtotal.TaxCategory.ID + "/" + normalizePercent(subtotal.TaxCategory.Percent), true
+}
+
+// category checks the rate of a tax category is its rate in Japan, for the categories of the consumption tax
+func (v *validator) category(where string, category TaxCategory) bool {
+	rate, known := taxRates[category.ID]
+	if !known {
+		return true
+	}
+	percent, ok := parseDecimal(category.Percent)
+	return v.check(ok && percent.Cmp(decimal.New(rate, 0)) == 0, "JP-02",
+		fmt.Sprintf("%s tax category %s shall have the rate %d%%, not %q", where, category.ID, rate, category.Percent))
+}
+
+// taxTotal returns the tax total in the currency of the document, nil without one
+func (v *validator) taxTotal(totals []TaxTotal) *TaxTotal {
+	for i := range totals {
+		if totals[i].TaxAmount.CurrencyID == v.currency {
+			return &totals[i]
+		}
+	}
+	return nil
+}
+
+// format formats an amount in minor units
+func (v *validator) format(minor int64) string {
+	return formatMinor(minor, v.digits)
+}
+
+// minorUnits converts an amount to an integer in minor units of a currency with digits decimals
+func minorUnits(value string, digits int) (int64, error) {
+	amount, ok := parseDecimal(value)
+	if !ok {
+		return 0, errors.New("not a number")
+	}
+	minor, exact := roundMinor(amount, digits)
+	if !exact {
+		return 0, fmt.Errorf("more than %d decimals", digits)
+	}
+	return minor, nil
+}
+
+// roundMinor rounds an amount to minor units, half away from zero, and reports whether it was exact
+func roundMinor(amount *decimal.Big, digits int) (int64, bool) {
+	minor := newBig().Copy(amount)
+	minor.SetScale(minor.Scale() - digits)
+	exact := minor.IsInt()
+	minor.Context.RoundingMode = decimal.ToNearestAway
+	units, ok := minor.Quantize(0).Int64()
+	return units, exact && ok
+}
+
+// formatMinor formats an amount in minor units with the decimals of its currency
+func formatMinor(minor int64, digits int) string {
+	return decimal.New(minor, digits).String()
+}
+
+// parseDecimal parses a finite decimal number
+func parseDecimal(value string) (*decimal.Big, bool) {
+	d, ok := newBig().SetString(strings.TrimSpace(value))
+	if !ok || !d.IsFinite() {
+		return nil, false
+	}
+	return d, true
+}
+
+// normalizePercent formats a rate without its trailing zeros, so 10 and 10.00 are the same category
+func normalizePercent(percent string) string {
+	d, ok := parseDecimal(percent)
+	if !ok {
+		return strings.TrimSpace(percent)
+	}
+	return d.Reduce().String()
+}
+
+// newBig returns a zero precise enough for the divisions of prices
+func newBig() *decimal.Big {
+	return decimal.WithContext(decimal.Context128)
+}
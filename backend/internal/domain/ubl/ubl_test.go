@@ -0,0 +1,271 @@
+package ubl_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ericlagergren/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/types"
+
+	"github.com/niko-cb/uct/internal/domain/entity"
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+	"github.com/niko-cb/uct/internal/domain/ubl"
+)
+
+func sample(t *testing.T, name string) []byte {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	require.NoError(t, err)
+	return data
+}
+
+func parse(t *testing.T, data []byte) *ubl.Invoice {
+	t.Helper()
+	doc, err := ubl.Parse(data)
+	require.NoError(t, err)
+	return doc
+}
+
+// rules returns the rules a document breaks
+func rules(err error) []string {
+	var validation *ubl.ValidationError
+	if !errors.As(err, &validation) {
+		return nil
+	}
+	var r []string
+	for _, violation := range validation.Violations {
+		r = append(r, violation.Rule)
+	}
+	return r
+}
+
+func TestParse(t *testing.T) {
+	doc := parse(t, sample(t, "invoice.xml"))
+	assert.Equal(t, ubl.CustomizationID, doc.CustomizationID)
+	assert.Equal(t, "INV-2024-0042", doc.ID)
+	assert.Equal(t, "Yamada Trading Co., Ltd.", doc.AccountingSupplierParty.Party.PartyLegalEntity.RegistrationName)
+	assert.Equal(t, "T7123456789012", ubl.RegistrationNumber(doc.AccountingSupplierParty.Party))
+	require.Len(t, doc.InvoiceLines, 3)
+	assert.Equal(t, ubl.Quantity{Value: "3", UnitCode: "KGM"}, doc.InvoiceLines[1].InvoicedQuantity)
+	assert.Equal(t, ubl.Amount{Value: "115077", CurrencyID: "JPY"}, doc.LegalMonetaryTotal.PayableAmount)
+
+	t.Run("other prefixes", func(t *testing.T) {
+		data := string(sample(t, "invoice.xml"))
+		data = strings.ReplaceAll(data, "cbc:", "b:")
+		data = strings.ReplaceAll(data, "xmlns:cbc", "xmlns:b")
+		data = strings.ReplaceAll(data, "<Invoice ", "<inv:Invoice xmlns:inv=\"urn:oasis:names:specification:ubl:schema:xsd:Invoice-2\" ")
+		data = strings.ReplaceAll(data, "</Invoice>", "</inv:Invoice>")
+		assert.Equal(t, doc, parse(t, []byte(data)))
+	})
+	t.Run("not an invoice", func(t *testing.T) {
+		_, err := ubl.Parse([]byte("<Invoice><cbc:ID>"))
+		assert.ErrorIs(t, err, ubl.ErrMalformedDocument)
+		_, err = ubl.Parse([]byte(`<CreditNote xmlns="urn:oasis:names:specification:ubl:schema:xsd:CreditNote-2"/>`))
+		assert.ErrorIs(t, err, ubl.ErrMalformedDocument)
+	})
+}
+
+func TestValidate(t *testing.T) {
+	assert.NoError(t, ubl.Validate(parse(t, sample(t, "invoice.xml"))))
+
+	err := ubl.Validate(parse(t, sample(t, "invalid_invoice.xml")))
+	assert.ErrorIs(t, err, ubl.ErrInvalidDocument)
+	assert.Equal(t, []string{"BR-06", "JP-01", "BR-CO-16"}, rules(err))
+
+	tests := []struct {
+		name   string
+		modify func(doc *ubl.Invoice)
+		rules  []string
+	}{
+		{"line net amount", func(doc *ubl.Invoice) {
+			doc.InvoiceLines[0].LineExtensionAmount.Value = "100001"
+		}, []string{"PEPPOL-EN16931-R120", "BR-CO-10", "BR-S-08"}},
+		{"tax of a category", func(doc *ubl.Invoice) {
+			doc.TaxTotals[0].TaxSubtotals[1].TaxAmount.Value = "400"
+			doc.TaxTotals[0].TaxAmount.Value = "10400"
+			doc.LegalMonetaryTotal.TaxInclusiveAmount.Value = "115101"
+			doc.LegalMonetaryTotal.PayableAmount.Value = "115101"
+		}, []string{"JP-03"}},
+		{"check digit of the registration number", func(doc *ubl.Invoice) {
+			doc.AccountingSupplierParty.Party.PartyTaxSchemes[0].CompanyID = "T1234567890123"
+		}, []string{"JP-01"}},
+		{"rate", func(doc *ubl.Invoice) {
+			doc.InvoiceLines[2].Item.ClassifiedTaxCategory.Percent = "5"
+		}, []string{"JP-02"}},
+		{"decimals of the currency", func(doc *ubl.Invoice) {
+			doc.InvoiceLines[2].LineExtensionAmount.Value = "999.5"
+		}, []string{"BR-DEC"}},
+		{"currency of an amount", func(doc *ubl.Invoice) {
+			doc.InvoiceLines[2].LineExtensionAmount.CurrencyID = "USD"
+		}, []string{"PEPPOL-EN16931-R051"}},
+		{"no lines", func(doc *ubl.Invoice) {
+			doc.InvoiceLines = nil
+		}, []string{"BR-16", "BR-CO-10", "BR-S-08", "BR-S-08"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := parse(t, sample(t, "invoice.xml"))
+			tt.modify(doc)
+			err := ubl.Validate(doc)
+			assert.ErrorIs(t, err, ubl.ErrInvalidDocument)
+			assert.Equal(t, tt.rules, rules(err))
+		})
+	}
+}
+
+func TestImport(t *testing.T) {
+	imported, err := ubl.Import(parse(t, sample(t, "invoice.xml")))
+	require.NoError(t, err)
+
+	assert.Equal(t, &entity.Client{
+		Name:               "Yamada Trading Co., Ltd.",
+		Address:            "100-0005 Tokyo Chiyoda-ku 1-2-3 Marunouchi",
+		Phone:              "03-1234-5678",
+		RegistrationNumber: "T7123456789012",
+	}, imported.Seller)
+	assert.Equal(t, &entity.Invoice{
+		IssueDate:     time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC),
+		DueDate:       time.Date(2024, 4, 30, 0, 0, 0, 0, time.UTC),
+		PaymentAmount: 115077,
+		Status:        entity.InvoiceStatusUnprocessed,
+		Currency:      "JPY",
+		Items: []*entity.InvoiceItem{
+			{Description: "Consulting", Quantity: 2, UnitPrice: 55000, TaxCategory: entity.TaxCategoryStandard},
+			// 3998 / 3 is not exact to the cent
+			{Description: "Coffee beans", Quantity: 1, UnitPrice: 3998, TaxCategory: entity.TaxCategoryReduced},
+			{Description: "Snacks", Quantity: 1, UnitPrice: 1079, TaxCategory: entity.TaxCategoryReduced},
+		},
+	}, imported.Invoice)
+	assert.Equal(t, []*entity.InvoiceTaxSubtotal{
+		{TaxCategory: entity.TaxCategoryStandard, Rate: 10, Amount: 110000, TaxAmount: 10000},
+		{TaxCategory: entity.TaxCategoryReduced, Rate: 8, Amount: 5077, TaxAmount: 376},
+	}, imported.TaxSubtotals)
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := ubl.Import(parse(t, sample(t, "invalid_invoice.xml")))
+		assert.ErrorIs(t, err, ubl.ErrInvalidDocument)
+	})
+	t.Run("unsupported", func(t *testing.T) {
+		tests := []struct {
+			name   string
+			modify func(doc *ubl.Invoice)
+		}{
+			{"credit note type", func(doc *ubl.Invoice) { doc.InvoiceTypeCode = "381" }},
+			{"payment terms without due date", func(doc *ubl.Invoice) {
+				doc.DueDate = ""
+				doc.PaymentTerms = "30 days end of month"
+			}},
+			{"prepaid", func(doc *ubl.Invoice) {
+				doc.LegalMonetaryTotal.PrepaidAmount = &ubl.Amount{Value: "1077", CurrencyID: "JPY"}
+				doc.LegalMonetaryTotal.PayableAmount.Value = "114000"
+			}},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				doc := parse(t, sample(t, "invoice.xml"))
+				tt.modify(doc)
+				_, err := ubl.Import(doc)
+				assert.ErrorIs(t, err, ubl.ErrUnsupportedDocument)
+			})
+		}
+	})
+}
+
+func decimalOf(f float64) types.Decimal {
+	return types.NewDecimal(new(decimal.Big).SetFloat64(f))
+}
+
+// stored returns an imported invoice as stored, with its items and its tax subtotals
+func stored(id int64, imported *ubl.Imported) *models.Invoice {
+	invoice := &models.Invoice{
+		ID:            id,
+		IssueDate:     imported.Invoice.IssueDate,
+		DueDate:       imported.Invoice.DueDate,
+		PaymentAmount: decimalOf(imported.Invoice.PaymentAmount),
+		Currency:      imported.Invoice.Currency,
+	}
+	invoice.R = invoice.R.NewStruct()
+	for i, item := range imported.Invoice.Items {
+		invoice.R.InvoiceItems = append(invoice.R.InvoiceItems, &models.InvoiceItem{
+			LineNo:      i + 1,
+			Description: item.Description,
+			Quantity:    decimalOf(item.Quantity),
+			UnitPrice:   decimalOf(item.UnitPrice),
+			Amount:      decimalOf(item.Quantity * item.UnitPrice),
+			TaxCategory: item.TaxCategory,
+		})
+	}
+	for _, subtotal := range imported.TaxSubtotals {
+		invoice.R.InvoiceTaxSubtotals = append(invoice.R.InvoiceTaxSubtotals, &models.InvoiceTaxSubtotal{
+			TaxCategory: subtotal.TaxCategory,
+			Rate:        subtotal.Rate,
+			Amount:      decimalOf(subtotal.Amount),
+			TaxAmount:   decimalOf(subtotal.TaxAmount),
+		})
+	}
+	return invoice
+}
+
+func TestExport(t *testing.T) {
+	imported, err := ubl.Import(parse(t, sample(t, "invoice.xml")))
+	require.NoError(t, err)
+	company := &models.Company{Name: "Sample Company", Address: null.StringFrom("4-5-6 Umeda Osaka")}
+	client := &models.Client{
+		Name:               imported.Seller.Name,
+		Address:            null.StringFrom(imported.Seller.Address),
+		Phone:              null.StringFrom(imported.Seller.Phone),
+		RegistrationNumber: null.StringFrom(imported.Seller.RegistrationNumber),
+	}
+
+	doc, err := ubl.Export(stored(42, imported), company, client)
+	require.NoError(t, err)
+	require.NoError(t, ubl.Validate(doc))
+	assert.Equal(t, "42", doc.ID)
+	assert.Equal(t, "T7123456789012", ubl.RegistrationNumber(doc.AccountingSupplierParty.Party))
+	// The tax included in the items is taken out of their net amounts again
+	var nets []string
+	for _, line := range doc.InvoiceLines {
+		nets = append(nets, line.LineExtensionAmount.Value)
+	}
+	assert.Equal(t, []string{"100000", "3702", "999"}, nets)
+	assert.Equal(t, "10376", doc.TaxTotals[0].TaxAmount.Value)
+	assert.Equal(t, "104701", doc.LegalMonetaryTotal.TaxExclusiveAmount.Value)
+	assert.Equal(t, "115077", doc.LegalMonetaryTotal.PayableAmount.Value)
+
+	t.Run("round trip", func(t *testing.T) {
+		data, err := ubl.Marshal(doc)
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(string(data), `<?xml version="1.0" encoding="UTF-8"?>`))
+		assert.Contains(t, string(data), "<cbc:PayableAmount currencyID=\"JPY\">115077</cbc:PayableAmount>")
+
+		again, err := ubl.Import(parse(t, data))
+		require.NoError(t, err)
+		assert.Equal(t, imported.Invoice, again.Invoice)
+		assert.Equal(t, imported.TaxSubtotals, again.TaxSubtotals)
+	})
+	t.Run("price for a base quantity", func(t *testing.T) {
+		invoice := stored(43, imported)
+		invoice.R.InvoiceItems[2].Quantity = decimalOf(2)
+		invoice.R.InvoiceItems[2].UnitPrice = decimalOf(539.5)
+		doc, err := ubl.Export(invoice, company, client)
+		require.NoError(t, err)
+		require.NoError(t, ubl.Validate(doc))
+		assert.Equal(t, ubl.Price{
+			PriceAmount:  ubl.Amount{Value: "999", CurrencyID: "JPY"},
+			BaseQuantity: &ubl.Quantity{Value: "2", UnitCode: ubl.UnitCodePiece},
+		}, doc.InvoiceLines[2].Price)
+	})
+	t.Run("no items", func(t *testing.T) {
+		invoice := stored(44, imported)
+		invoice.R.InvoiceItems = nil
+		_, err := ubl.Export(invoice, company, client)
+		assert.ErrorIs(t, err, ubl.ErrUnsupportedDocument)
+	})
+}
@@ -0,0 +1,84 @@
+package gateway
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/log"
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/trace"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+	"github.com/niko-cb/uct/internal/domain/repository"
+	"github.com/niko-cb/uct/internal/infrastructure/persistent/mysql"
+)
+
+var _ repository.AttachmentRepository = &attachmentGateway{}
+
+type attachmentGateway struct {
+	client *mysql.MySQLClient
+}
+
+func NewAttachmentGateway(client *mysql.MySQLClient) repository.AttachmentRepository {
+	return &attachmentGateway{
+		client: client,
+	}
+}
+
+func (g *attachmentGateway) CreateAttachment(ctx context.Context, attachment *models.InvoiceAttachment) (_ bool, err error) {
+	ctx, span := trace.Start(ctx, "AttachmentGateway.CreateAttachment")
+	defer trace.End(span, &err)
+
+	// The no-op update leaves the row of the same file alone, and affects no row then
+	exec := g.client.Executor(ctx)
+	result, err := exec.ExecContext(ctx,
+		`INSERT INTO invoice_attachments (invoice_id, file_name, content_type, size, sha256) VALUES (?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE id = id`,
+		attachment.InvoiceID, attachment.FileName, attachment.ContentType, attachment.Size, attachment.Sha256)
+	if err != nil {
+		log.Error(ctx, fmt.Errorf("failed to insert attachment into database: %+v", err))
+		return false, err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	stored, err := models.InvoiceAttachments(
+		models.InvoiceAttachmentWhere.InvoiceID.EQ(attachment.InvoiceID),
+		models.InvoiceAttachmentWhere.Sha256.EQ(attachment.Sha256),
+	).One(ctx, exec)
+	if err != nil {
+		return false, err
+	}
+	*attachment = *stored
+
+	return rows == 1, nil
+}
+
+func (g *attachmentGateway) GetAttachment(ctx context.Context, id int64) (_ *models.InvoiceAttachment, err error) {
+	ctx, span := trace.Start(ctx, "AttachmentGateway.GetAttachment")
+	defer trace.End(span, &err)
+
+	attachment, err := models.FindInvoiceAttachment(ctx, g.client.Reader(ctx), id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repository.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return attachment, nil
+}
+
+func (g *attachmentGateway) GetAttachmentsByInvoice(ctx context.Context, invoiceID int64) (_ []*models.InvoiceAttachment, err error) {
+	ctx, span := trace.Start(ctx, "AttachmentGateway.GetAttachmentsByInvoice")
+	defer trace.End(span, &err)
+
+	return models.InvoiceAttachments(
+		models.InvoiceAttachmentWhere.InvoiceID.EQ(invoiceID),
+		qm.OrderBy(models.InvoiceAttachmentColumns.ID),
+	).All(ctx, g.client.Reader(ctx))
+}
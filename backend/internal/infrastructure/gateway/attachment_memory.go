@@ -0,0 +1,99 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/log"
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/trace"
+
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+	"github.com/niko-cb/uct/internal/domain/repository"
+	"github.com/niko-cb/uct/internal/infrastructure/persistent/memory"
+)
+
+var _ repository.AttachmentRepository = &attachmentMemoryGateway{}
+
+// attachmentMemoryGateway stores the metadata of the attachments in the in-memory store, mimicking the MySQL schema
+type attachmentMemoryGateway struct {
+	store *memory.Store
+}
+
+func NewAttachmentMemoryGateway(store *memory.Store) repository.AttachmentRepository {
+	return &attachmentMemoryGateway{
+		store: store,
+	}
+}
+
+func (g *attachmentMemoryGateway) CreateAttachment(ctx context.Context, attachment *models.InvoiceAttachment) (_ bool, err error) {
+	ctx, span := trace.Start(ctx, "AttachmentMemoryGateway.CreateAttachment")
+	defer trace.End(span, &err)
+
+	var created bool
+	err = g.store.Write(ctx, func(t *memory.Tables) error {
+		if _, ok := t.Invoices[attachment.InvoiceID]; !ok {
+			return fmt.Errorf("%w: invoice_attachments.invoice_id %d", memory.ErrForeignKey, attachment.InvoiceID)
+		}
+		for _, stored := range t.Attachments {
+			if stored.InvoiceID == attachment.InvoiceID && stored.Sha256 == attachment.Sha256 {
+				*attachment = stored
+				return nil
+			}
+		}
+
+		attachment.ID = g.store.ID(memory.TableAttachments, 0)
+		attachment.R = nil
+		t.Attachments[attachment.ID] = *attachment
+		created = true
+		return nil
+	})
+	if err != nil {
+		log.Error(ctx, fmt.Errorf("failed to insert attachment into database: %+v", err))
+		return false, err
+	}
+
+	return created, nil
+}
+
+func (g *attachmentMemoryGateway) GetAttachment(ctx context.Context, id int64) (_ *models.InvoiceAttachment, err error) {
+	ctx, span := trace.Start(ctx, "AttachmentMemoryGateway.GetAttachment")
+	defer trace.End(span, &err)
+
+	var attachment models.InvoiceAttachment
+	err = g.store.Read(ctx, func(t *memory.Tables) error {
+		stored, ok := t.Attachments[id]
+		if !ok {
+			return repository.ErrNotFound
+		}
+		attachment = stored
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &attachment, nil
+}
+
+func (g *attachmentMemoryGateway) GetAttachmentsByInvoice(ctx context.Context, invoiceID int64) (_ []*models.InvoiceAttachment, err error) {
+	ctx, span := trace.Start(ctx, "AttachmentMemoryGateway.GetAttachmentsByInvoice")
+	defer trace.End(span, &err)
+
+	var attachments []*models.InvoiceAttachment
+	err = g.store.Read(ctx, func(t *memory.Tables) error {
+		for _, stored := range t.Attachments {
+			if stored.InvoiceID == invoiceID {
+				attachment := stored
+				attachments = append(attachments, &attachment)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(attachments, func(i, j int) bool { return attachments[i].ID < attachments[j].ID })
+	return attachments, nil
+}
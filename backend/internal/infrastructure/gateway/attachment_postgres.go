@@ -0,0 +1,86 @@
+package gateway
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/log"
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/trace"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+	"github.com/niko-cb/uct/internal/domain/repository"
+	"github.com/niko-cb/uct/internal/infrastructure/persistent/postgres"
+)
+
+var _ repository.AttachmentRepository = &attachmentPostgresGateway{}
+
+// attachmentPostgresGateway stores the metadata of the attachments in Postgres
+type attachmentPostgresGateway struct {
+	client *postgres.PostgresClient
+}
+
+func NewAttachmentPostgresGateway(client *postgres.PostgresClient) repository.AttachmentRepository {
+	return &attachmentPostgresGateway{
+		client: client,
+	}
+}
+
+func (g *attachmentPostgresGateway) CreateAttachment(ctx context.Context, attachment *models.InvoiceAttachment) (_ bool, err error) {
+	ctx, span := trace.Start(ctx, "AttachmentPostgresGateway.CreateAttachment")
+	defer trace.End(span, &err)
+
+	exec := g.client.Executor(ctx)
+	result, err := exec.ExecContext(ctx,
+		`INSERT INTO invoice_attachments (invoice_id, file_name, content_type, size, sha256) VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (invoice_id, sha256) DO NOTHING`,
+		attachment.InvoiceID, attachment.FileName, attachment.ContentType, attachment.Size, attachment.Sha256)
+	if err != nil {
+		log.Error(ctx, fmt.Errorf("failed to insert attachment into database: %+v", err))
+		return false, err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	err = queries.Raw(`SELECT * FROM invoice_attachments WHERE invoice_id = $1 AND sha256 = $2`,
+		attachment.InvoiceID, attachment.Sha256).Bind(ctx, exec, attachment)
+	if err != nil {
+		return false, err
+	}
+
+	return rows == 1, nil
+}
+
+func (g *attachmentPostgresGateway) GetAttachment(ctx context.Context, id int64) (_ *models.InvoiceAttachment, err error) {
+	ctx, span := trace.Start(ctx, "AttachmentPostgresGateway.GetAttachment")
+	defer trace.End(span, &err)
+
+	attachment := &models.InvoiceAttachment{}
+	err = queries.Raw(`SELECT * FROM invoice_attachments WHERE id = $1`, id).Bind(ctx, g.client.Reader(ctx), attachment)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repository.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return attachment, nil
+}
+
+func (g *attachmentPostgresGateway) GetAttachmentsByInvoice(ctx context.Context, invoiceID int64) (_ []*models.InvoiceAttachment, err error) {
+	ctx, span := trace.Start(ctx, "AttachmentPostgresGateway.GetAttachmentsByInvoice")
+	defer trace.End(span, &err)
+
+	var attachments []*models.InvoiceAttachment
+	err = queries.Raw(`SELECT * FROM invoice_attachments WHERE invoice_id = $1 ORDER BY id`, invoiceID).
+		Bind(ctx, g.client.Reader(ctx), &attachments)
+	if err != nil {
+		return nil, err
+	}
+
+	return attachments, nil
+}
@@ -0,0 +1,73 @@
+package gateway_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+	"github.com/niko-cb/uct/internal/domain/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestAttachmentRepository checks the attachments are saved once per file and invoice, listed by invoice,
+// and deleted with it
+func TestAttachmentRepository(t *testing.T) {
+	for _, b := range backends(t) {
+		t.Run(b.name, func(t *testing.T) {
+			ctx := context.Background()
+			companyID, clientID := b.parents(t)
+			invoice, other := newInvoice(companyID, clientID, date("2024-07-31"), 10000), newInvoice(companyID, clientID, date("2024-07-31"), 20000)
+			require.NoError(t, b.invoices.CreateInvoice(ctx, invoice))
+			require.NoError(t, b.invoices.CreateInvoice(ctx, other))
+			newAttachment := func(invoiceID int64, fileName, hash string) *models.InvoiceAttachment {
+				return &models.InvoiceAttachment{
+					InvoiceID: invoiceID, FileName: fileName, ContentType: "application/pdf", Size: 1024, Sha256: strings.Repeat(hash, 64),
+				}
+			}
+
+			pdf, scan := newAttachment(invoice.ID, "supplier.pdf", "a"), newAttachment(invoice.ID, "scan.pdf", "b")
+			for _, attachment := range []*models.InvoiceAttachment{pdf, scan} {
+				created, err := b.attachments.CreateAttachment(ctx, attachment)
+				require.NoError(t, err)
+				assert.True(t, created)
+				require.NotZero(t, attachment.ID)
+			}
+			_, err := b.attachments.CreateAttachment(ctx, newAttachment(other.ID+1, "supplier.pdf", "a"))
+			assert.Error(t, err, "the invoice of an attachment must exist")
+
+			// The same file is attached once to an invoice, under its first name, but may be attached to another one
+			again := newAttachment(invoice.ID, "copy.pdf", "a")
+			created, err := b.attachments.CreateAttachment(ctx, again)
+			require.NoError(t, err)
+			assert.False(t, created)
+			assert.Equal(t, pdf.ID, again.ID)
+			assert.Equal(t, "supplier.pdf", again.FileName)
+			shared := newAttachment(other.ID, "supplier.pdf", "a")
+			created, err = b.attachments.CreateAttachment(ctx, shared)
+			require.NoError(t, err)
+			assert.True(t, created)
+			assert.NotEqual(t, pdf.ID, shared.ID)
+
+			loaded, err := b.attachments.GetAttachment(ctx, scan.ID)
+			require.NoError(t, err)
+			assert.Equal(t, *scan, *loaded)
+			_, err = b.attachments.GetAttachment(ctx, shared.ID+1)
+			assert.ErrorIs(t, err, repository.ErrNotFound)
+
+			listed, err := b.attachments.GetAttachmentsByInvoice(ctx, invoice.ID)
+			require.NoError(t, err)
+			require.Len(t, listed, 2)
+			assert.Equal(t, []int64{pdf.ID, scan.ID}, []int64{listed[0].ID, listed[1].ID})
+
+			require.NoError(t, b.invoices.DeleteInvoice(ctx, invoice.ID, 1))
+			listed, err = b.attachments.GetAttachmentsByInvoice(ctx, invoice.ID)
+			require.NoError(t, err)
+			assert.Empty(t, listed)
+			listed, err = b.attachments.GetAttachmentsByInvoice(ctx, other.ID)
+			require.NoError(t, err)
+			assert.Len(t, listed, 1)
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package gateway
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/log"
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/trace"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+	"github.com/niko-cb/uct/internal/domain/repository"
+	"github.com/niko-cb/uct/internal/infrastructure/persistent/mysql"
+)
+
+var _ repository.ClientRepository = &clientGateway{}
+
+type clientGateway struct {
+	client *mysql.MySQLClient
+}
+
+func NewClientGateway(client *mysql.MySQLClient) repository.ClientRepository {
+	return &clientGateway{
+		client: client,
+	}
+}
+
+func (g *clientGateway) CreateClient(ctx context.Context, client *models.Client) (err error) {
+	ctx, span := trace.Start(ctx, "ClientGateway.CreateClient")
+	defer trace.End(span, &err)
+
+	err = client.Insert(ctx, g.client.Executor(ctx), boil.Infer())
+	if err != nil {
+		log.Error(ctx, fmt.Errorf("failed to insert client into database: %+v", err))
+		return err
+	}
+
+	return nil
+}
+
+func (g *clientGateway) CreateBankAccount(ctx context.Context, account *models.BankAccount) (err error) {
+	ctx, span := trace.Start(ctx, "ClientGateway.CreateBankAccount")
+	defer trace.End(span, &err)
+
+	err = account.Insert(ctx, g.client.Executor(ctx), boil.Infer())
+	if err != nil {
+		log.Error(ctx, fmt.Errorf("failed to insert bank account into database: %+v", err))
+		return err
+	}
+
+	return nil
+}
+
+func (g *clientGateway) GetClient(ctx context.Context, id int64) (_ *models.Client, err error) {
+	ctx, span := trace.Start(ctx, "ClientGateway.GetClient")
+	defer trace.End(span, &err)
+
+	client, err := models.FindClient(ctx, g.client.Reader(ctx), id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repository.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return client, nil
+}
+
+func (g *clientGateway) FindClient(ctx context.Context, companyID int64, registrationNumber string, name string) (_ *models.Client, err error) {
+	ctx, span := trace.Start(ctx, "ClientGateway.FindClient")
+	defer trace.End(span, &err)
+
+	// The client with the registration number first, then the ones with the name
+	client, err := models.Clients(
+		models.ClientWhere.CompanyID.EQ(companyID),
+		qm.Expr(
+			qm.Where("registration_number = ?", registrationNumber),
+			qm.Or2(qm.Expr(models.ClientWhere.RegistrationNumber.IsNull(), models.ClientWhere.Name.EQ(name))),
+		),
+		qm.OrderBy("registration_number IS NULL, id"),
+	).One(ctx, g.client.Reader(ctx))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repository.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return client, nil
+}
+
+func (g *clientGateway) UpdateClientRegistrationNumber(ctx context.Context, id int64, version int64, registrationNumber string) (err error) {
+	ctx, span := trace.Start(ctx, "ClientGateway.UpdateClientRegistrationNumber")
+	defer trace.End(span, &err)
+
+	exec := g.client.Executor(ctx)
+	rows, err := models.Clients(
+		models.ClientWhere.ID.EQ(id),
+		models.ClientWhere.Version.EQ(version),
+	).UpdateAll(ctx, exec, models.M{
+		models.ClientColumns.RegistrationNumber: null.NewString(registrationNumber, registrationNumber != ""),
+		models.ClientColumns.Version:            version + 1,
+	})
+	if err != nil {
+		log.Error(ctx, fmt.Errorf("failed to update client registration number: %+v", err))
+		return err
+	}
+	if rows == 0 {
+		exists, err := models.ClientExists(ctx, exec, id)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return repository.ErrNotFound
+		}
+		return repository.ErrVersionConflict
+	}
+
+	return nil
+}
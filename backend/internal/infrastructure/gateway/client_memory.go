@@ -0,0 +1,167 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/log"
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/trace"
+	"github.com/volatiletech/null/v8"
+
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+	"github.com/niko-cb/uct/internal/domain/repository"
+	"github.com/niko-cb/uct/internal/infrastructure/persistent/memory"
+)
+
+var _ repository.ClientRepository = &clientMemoryGateway{}
+
+// clientMemoryGateway stores clients and their bank accounts in the in-memory store
+type clientMemoryGateway struct {
+	store *memory.Store
+}
+
+func NewClientMemoryGateway(store *memory.Store) repository.ClientRepository {
+	return &clientMemoryGateway{
+		store: store,
+	}
+}
+
+func (g *clientMemoryGateway) CreateClient(ctx context.Context, client *models.Client) (err error) {
+	ctx, span := trace.Start(ctx, "ClientMemoryGateway.CreateClient")
+	defer trace.End(span, &err)
+
+	err = g.store.Write(ctx, func(t *memory.Tables) error {
+		if _, ok := t.Companies[client.CompanyID]; !ok {
+			return fmt.Errorf("%w: clients.company_id %d", memory.ErrForeignKey, client.CompanyID)
+		}
+		if _, ok := t.Clients[client.ID]; ok {
+			return fmt.Errorf("%w: clients.id %d", memory.ErrDuplicateKey, client.ID)
+		}
+
+		client.ID = g.store.ID(memory.TableClients, client.ID)
+		client.Version = 1
+		stored := *client
+		stored.R = nil
+		t.Clients[client.ID] = stored
+		return nil
+	})
+	if err != nil {
+		log.Error(ctx, fmt.Errorf("failed to insert client into database: %+v", err))
+		return err
+	}
+
+	return nil
+}
+
+func (g *clientMemoryGateway) CreateBankAccount(ctx context.Context, account *models.BankAccount) (err error) {
+	ctx, span := trace.Start(ctx, "ClientMemoryGateway.CreateBankAccount")
+	defer trace.End(span, &err)
+
+	err = g.store.Write(ctx, func(t *memory.Tables) error {
+		if _, ok := t.Clients[account.ClientID]; !ok {
+			return fmt.Errorf("%w: bank_accounts.client_id %d", memory.ErrForeignKey, account.ClientID)
+		}
+		if _, ok := t.BankAccounts[account.ID]; ok {
+			return fmt.Errorf("%w: bank_accounts.id %d", memory.ErrDuplicateKey, account.ID)
+		}
+
+		account.ID = g.store.ID(memory.TableBankAccounts, account.ID)
+		account.Version = 1
+		stored := *account
+		stored.R = nil
+		t.BankAccounts[account.ID] = stored
+		return nil
+	})
+	if err != nil {
+		log.Error(ctx, fmt.Errorf("failed to insert bank account into database: %+v", err))
+		return err
+	}
+
+	return nil
+}
+
+func (g *clientMemoryGateway) GetClient(ctx context.Context, id int64) (_ *models.Client, err error) {
+	ctx, span := trace.Start(ctx, "ClientMemoryGateway.GetClient")
+	defer trace.End(span, &err)
+
+	var client models.Client
+	err = g.store.Read(ctx, func(t *memory.Tables) error {
+		stored, ok := t.Clients[id]
+		if !ok {
+			return repository.ErrNotFound
+		}
+		client = stored
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &client, nil
+}
+
+func (g *clientMemoryGateway) FindClient(ctx context.Context, companyID int64, registrationNumber string, name string) (_ *models.Client, err error) {
+	ctx, span := trace.Start(ctx, "ClientMemoryGateway.FindClient")
+	defer trace.End(span, &err)
+
+	var found *models.Client
+	err = g.store.Read(ctx, func(t *memory.Tables) error {
+		// The client with the registration number first, then the ones with the name
+		rank := func(client models.Client) int {
+			switch {
+			case client.CompanyID != companyID:
+				return -1
+			case registrationNumber != "" && client.RegistrationNumber.String == registrationNumber:
+				return 0
+			case !client.RegistrationNumber.Valid && client.Name == name:
+				return 1
+			default:
+				return -1
+			}
+		}
+		for _, stored := range t.Clients {
+			r := rank(stored)
+			if r < 0 {
+				continue
+			}
+			if found == nil || r < rank(*found) || (r == rank(*found) && stored.ID < found.ID) {
+				client := stored
+				found = &client
+			}
+		}
+		if found == nil {
+			return repository.ErrNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return found, nil
+}
+
+func (g *clientMemoryGateway) UpdateClientRegistrationNumber(ctx context.Context, id int64, version int64, registrationNumber string) (err error) {
+	ctx, span := trace.Start(ctx, "ClientMemoryGateway.UpdateClientRegistrationNumber")
+	defer trace.End(span, &err)
+
+	err = g.store.Write(ctx, func(t *memory.Tables) error {
+		stored, ok := t.Clients[id]
+		if !ok {
+			return repository.ErrNotFound
+		}
+		if stored.Version != version {
+			return repository.ErrVersionConflict
+		}
+		stored.RegistrationNumber = null.NewString(registrationNumber, registrationNumber != "")
+		stored.Version++
+		t.Clients[id] = stored
+		return nil
+	})
+	if err != nil {
+		log.Error(ctx, fmt.Errorf("failed to update client registration number: %+v", err))
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,125 @@
+package gateway
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/log"
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/trace"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+	"github.com/niko-cb/uct/internal/domain/repository"
+	"github.com/niko-cb/uct/internal/infrastructure/persistent/postgres"
+)
+
+var _ repository.ClientRepository = &clientPostgresGateway{}
+
+// clientPostgresGateway stores clients in Postgres, with queries written by hand like invoicePostgresGateway
+type clientPostgresGateway struct {
+	client *postgres.PostgresClient
+}
+
+func NewClientPostgresGateway(client *postgres.PostgresClient) repository.ClientRepository {
+	return &clientPostgresGateway{
+		client: client,
+	}
+}
+
+func (g *clientPostgresGateway) CreateClient(ctx context.Context, client *models.Client) (err error) {
+	ctx, span := trace.Start(ctx, "ClientPostgresGateway.CreateClient")
+	defer trace.End(span, &err)
+
+	err = g.client.Executor(ctx).QueryRowContext(ctx,
+		`INSERT INTO clients (company_id, name, phone, address, registration_number) VALUES ($1, $2, $3, $4, $5) RETURNING id, version`,
+		client.CompanyID, client.Name, client.Phone, client.Address, client.RegistrationNumber).Scan(&client.ID, &client.Version)
+	if err != nil {
+		log.Error(ctx, fmt.Errorf("failed to insert client into database: %+v", err))
+		return err
+	}
+
+	return nil
+}
+
+func (g *clientPostgresGateway) CreateBankAccount(ctx context.Context, account *models.BankAccount) (err error) {
+	ctx, span := trace.Start(ctx, "ClientPostgresGateway.CreateBankAccount")
+	defer trace.End(span, &err)
+
+	err = g.client.Executor(ctx).QueryRowContext(ctx,
+		`INSERT INTO bank_accounts (client_id, bank_name, branch, account_no, holder) VALUES ($1, $2, $3, $4, $5) RETURNING id, version`,
+		account.ClientID, account.BankName, account.Branch, account.AccountNo, account.Holder).Scan(&account.ID, &account.Version)
+	if err != nil {
+		log.Error(ctx, fmt.Errorf("failed to insert bank account into database: %+v", err))
+		return err
+	}
+
+	return nil
+}
+
+func (g *clientPostgresGateway) GetClient(ctx context.Context, id int64) (_ *models.Client, err error) {
+	ctx, span := trace.Start(ctx, "ClientPostgresGateway.GetClient")
+	defer trace.End(span, &err)
+
+	client := &models.Client{}
+	err = queries.Raw(`SELECT * FROM clients WHERE id = $1`, id).Bind(ctx, g.client.Reader(ctx), client)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repository.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return client, nil
+}
+
+func (g *clientPostgresGateway) FindClient(ctx context.Context, companyID int64, registrationNumber string, name string) (_ *models.Client, err error) {
+	ctx, span := trace.Start(ctx, "ClientPostgresGateway.FindClient")
+	defer trace.End(span, &err)
+
+	// The client with the registration number first, then the ones with the name
+	client := &models.Client{}
+	err = queries.Raw(`SELECT * FROM clients WHERE company_id = $1
+		AND (registration_number = $2 OR (registration_number IS NULL AND name = $3))
+		ORDER BY registration_number IS NULL, id LIMIT 1`,
+		companyID, registrationNumber, name).Bind(ctx, g.client.Reader(ctx), client)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repository.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return client, nil
+}
+
+func (g *clientPostgresGateway) UpdateClientRegistrationNumber(ctx context.Context, id int64, version int64, registrationNumber string) (err error) {
+	ctx, span := trace.Start(ctx, "ClientPostgresGateway.UpdateClientRegistrationNumber")
+	defer trace.End(span, &err)
+
+	exec := g.client.Executor(ctx)
+	result, err := exec.ExecContext(ctx, `UPDATE clients SET registration_number = $1, version = version + 1 WHERE id = $2 AND version = $3`,
+		null.NewString(registrationNumber, registrationNumber != ""), id, version)
+	if err != nil {
+		log.Error(ctx, fmt.Errorf("failed to update client registration number: %+v", err))
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		var exists bool
+		if err := exec.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM clients WHERE id = $1)`, id).Scan(&exists); err != nil {
+			return err
+		}
+		if !exists {
+			return repository.ErrNotFound
+		}
+		return repository.ErrVersionConflict
+	}
+
+	return nil
+}
@@ -0,0 +1,109 @@
+package gateway
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/log"
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/trace"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/types"
+
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+	"github.com/niko-cb/uct/internal/domain/repository"
+	"github.com/niko-cb/uct/internal/infrastructure/persistent/mysql"
+)
+
+var _ repository.CompanyRepository = &companyGateway{}
+
+type companyGateway struct {
+	client *mysql.MySQLClient
+}
+
+func NewCompanyGateway(client *mysql.MySQLClient) repository.CompanyRepository {
+	return &companyGateway{
+		client: client,
+	}
+}
+
+func (g *companyGateway) CreateCompany(ctx context.Context, company *models.Company) (err error) {
+	ctx, span := trace.Start(ctx, "CompanyGateway.CreateCompany")
+	defer trace.End(span, &err)
+
+	err = company.Insert(ctx, g.client.Executor(ctx), boil.Infer())
+	if err != nil {
+		log.Error(ctx, fmt.Errorf("failed to insert company into database: %+v", err))
+		return err
+	}
+
+	return nil
+}
+
+func (g *companyGateway) GetCompany(ctx context.Context, id int64) (_ *models.Company, err error) {
+	ctx, span := trace.Start(ctx, "CompanyGateway.GetCompany")
+	defer trace.End(span, &err)
+
+	company, err := models.FindCompany(ctx, g.client.Reader(ctx), id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repository.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return company, nil
+}
+
+func (g *companyGateway) UpdateAvailableBalance(ctx context.Context, id int64, balance types.NullDecimal) (err error) {
+	ctx, span := trace.Start(ctx, "CompanyGateway.UpdateAvailableBalance")
+	defer trace.End(span, &err)
+
+	rows, err := models.Companies(models.CompanyWhere.ID.EQ(id)).
+		UpdateAll(ctx, g.client.Executor(ctx), models.M{models.CompanyColumns.AvailableBalance: balance})
+	if err != nil {
+		log.Error(ctx, fmt.Errorf("failed to update company available balance: %+v", err))
+		return err
+	}
+	if rows == 0 {
+		return repository.ErrNotFound
+	}
+
+	return nil
+}
+
+func (g *companyGateway) UpdateTaxRounding(ctx context.Context, id int64, rounding string) (err error) {
+	ctx, span := trace.Start(ctx, "CompanyGateway.UpdateTaxRounding")
+	defer trace.End(span, &err)
+
+	rows, err := models.Companies(models.CompanyWhere.ID.EQ(id)).
+		UpdateAll(ctx, g.client.Executor(ctx), models.M{models.CompanyColumns.TaxRounding: rounding})
+	if err != nil {
+		log.Error(ctx, fmt.Errorf("failed to update company tax rounding: %+v", err))
+		return err
+	}
+	if rows == 0 {
+		return repository.ErrNotFound
+	}
+
+	return nil
+}
+
+func (g *companyGateway) UpdateRegistrationNumber(ctx context.Context, id int64, registrationNumber null.String) (err error) {
+	ctx, span := trace.Start(ctx, "CompanyGateway.UpdateRegistrationNumber")
+	defer trace.End(span, &err)
+
+	rows, err := models.Companies(models.CompanyWhere.ID.EQ(id)).
+		UpdateAll(ctx, g.client.Executor(ctx), models.M{models.CompanyColumns.RegistrationNumber: registrationNumber})
+	if err != nil {
+		log.Error(ctx, fmt.Errorf("failed to update company registration number: %+v", err))
+		return err
+	}
+	if rows == 0 {
+		return repository.ErrNotFound
+	}
+
+	return nil
+}
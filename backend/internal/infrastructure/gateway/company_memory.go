@@ -0,0 +1,148 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/log"
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/trace"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/types"
+
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+	"github.com/niko-cb/uct/internal/domain/repository"
+	"github.com/niko-cb/uct/internal/infrastructure/persistent/memory"
+)
+
+var _ repository.CompanyRepository = &companyMemoryGateway{}
+
+// companyMemoryGateway stores companies in the in-memory store
+type companyMemoryGateway struct {
+	store *memory.Store
+}
+
+func NewCompanyMemoryGateway(store *memory.Store) repository.CompanyRepository {
+	return &companyMemoryGateway{
+		store: store,
+	}
+}
+
+func (g *companyMemoryGateway) CreateCompany(ctx context.Context, company *models.Company) (err error) {
+	ctx, span := trace.Start(ctx, "CompanyMemoryGateway.CreateCompany")
+	defer trace.End(span, &err)
+
+	err = g.store.Write(ctx, func(t *memory.Tables) error {
+		if _, ok := t.Companies[company.ID]; ok {
+			return fmt.Errorf("%w: companies.id %d", memory.ErrDuplicateKey, company.ID)
+		}
+
+		company.ID = g.store.ID(memory.TableCompanies, company.ID)
+		companyDefaults(company)
+		stored := *company
+		stored.R = nil
+		t.Companies[company.ID] = stored
+		return nil
+	})
+	if err != nil {
+		log.Error(ctx, fmt.Errorf("failed to insert company into database: %+v", err))
+		return err
+	}
+
+	return nil
+}
+
+func (g *companyMemoryGateway) GetCompany(ctx context.Context, id int64) (_ *models.Company, err error) {
+	ctx, span := trace.Start(ctx, "CompanyMemoryGateway.GetCompany")
+	defer trace.End(span, &err)
+
+	var company models.Company
+	err = g.store.Read(ctx, func(t *memory.Tables) error {
+		stored, ok := t.Companies[id]
+		if !ok {
+			return repository.ErrNotFound
+		}
+		company = stored
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &company, nil
+}
+
+func (g *companyMemoryGateway) UpdateAvailableBalance(ctx context.Context, id int64, balance types.NullDecimal) (err error) {
+	ctx, span := trace.Start(ctx, "CompanyMemoryGateway.UpdateAvailableBalance")
+	defer trace.End(span, &err)
+
+	err = g.store.Write(ctx, func(t *memory.Tables) error {
+		company, ok := t.Companies[id]
+		if !ok {
+			return repository.ErrNotFound
+		}
+		company.AvailableBalance = balance
+		t.Companies[id] = company
+		return nil
+	})
+	if err != nil {
+		log.Error(ctx, fmt.Errorf("failed to update company available balance: %+v", err))
+		return err
+	}
+
+	return nil
+}
+
+func (g *companyMemoryGateway) UpdateTaxRounding(ctx context.Context, id int64, rounding string) (err error) {
+	ctx, span := trace.Start(ctx, "CompanyMemoryGateway.UpdateTaxRounding")
+	defer trace.End(span, &err)
+
+	err = g.store.Write(ctx, func(t *memory.Tables) error {
+		company, ok := t.Companies[id]
+		if !ok {
+			return repository.ErrNotFound
+		}
+		company.TaxRounding = rounding
+		t.Companies[id] = company
+		return nil
+	})
+	if err != nil {
+		log.Error(ctx, fmt.Errorf("failed to update company tax rounding: %+v", err))
+		return err
+	}
+
+	return nil
+}
+
+func (g *companyMemoryGateway) UpdateRegistrationNumber(ctx context.Context, id int64, registrationNumber null.String) (err error) {
+	ctx, span := trace.Start(ctx, "CompanyMemoryGateway.UpdateRegistrationNumber")
+	defer trace.End(span, &err)
+
+	err = g.store.Write(ctx, func(t *memory.Tables) error {
+		company, ok := t.Companies[id]
+		if !ok {
+			return repository.ErrNotFound
+		}
+		company.RegistrationNumber = registrationNumber
+		t.Companies[id] = company
+		return nil
+	})
+	if err != nil {
+		log.Error(ctx, fmt.Errorf("failed to update company registration number: %+v", err))
+		return err
+	}
+
+	return nil
+}
+
+// companyDefaults sets the column defaults of the empty fields of a company, as MySQL does on insert
+func companyDefaults(company *models.Company) {
+	if company.OwnerName == "" {
+		company.OwnerName = "Unknown"
+	}
+	if company.TaxRounding == "" {
+		company.TaxRounding = "floor"
+	}
+	if company.BaseCurrency == "" {
+		company.BaseCurrency = "JPY"
+	}
+}
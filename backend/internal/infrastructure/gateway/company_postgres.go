@@ -0,0 +1,140 @@
+package gateway
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/log"
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/trace"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/types"
+
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+	"github.com/niko-cb/uct/internal/domain/repository"
+	"github.com/niko-cb/uct/internal/infrastructure/persistent/postgres"
+)
+
+var _ repository.CompanyRepository = &companyPostgresGateway{}
+
+// companyPostgresGateway stores companies in Postgres, with queries written by hand like invoicePostgresGateway
+type companyPostgresGateway struct {
+	client *postgres.PostgresClient
+}
+
+func NewCompanyPostgresGateway(client *postgres.PostgresClient) repository.CompanyRepository {
+	return &companyPostgresGateway{
+		client: client,
+	}
+}
+
+func (g *companyPostgresGateway) CreateCompany(ctx context.Context, company *models.Company) (err error) {
+	ctx, span := trace.Start(ctx, "CompanyPostgresGateway.CreateCompany")
+	defer trace.End(span, &err)
+
+	// An empty owner name, tax rounding or base currency gets the column default, like boil.Infer() does with MySQL
+	columns := []string{"name", "phone", "address", "registration_number"}
+	args := []interface{}{company.Name, company.Phone, company.Address, company.RegistrationNumber}
+	if company.OwnerName != "" {
+		columns, args = append(columns, "owner_name"), append(args, company.OwnerName)
+	}
+	if company.TaxRounding != "" {
+		columns, args = append(columns, "tax_rounding"), append(args, company.TaxRounding)
+	}
+	if company.BaseCurrency != "" {
+		columns, args = append(columns, "base_currency"), append(args, company.BaseCurrency)
+	}
+	placeholders := make([]string, len(columns))
+	for i := range columns {
+		placeholders[i] = fmt.Sprintf("$%d", i+1)
+	}
+	query := fmt.Sprintf(`INSERT INTO companies (%s) VALUES (%s) RETURNING id, owner_name, tax_rounding, base_currency`,
+		strings.Join(columns, ", "), strings.Join(placeholders, ", "))
+
+	err = g.client.Executor(ctx).QueryRowContext(ctx, query, args...).Scan(&company.ID, &company.OwnerName, &company.TaxRounding, &company.BaseCurrency)
+	if err != nil {
+		log.Error(ctx, fmt.Errorf("failed to insert company into database: %+v", err))
+		return err
+	}
+
+	return nil
+}
+
+func (g *companyPostgresGateway) GetCompany(ctx context.Context, id int64) (_ *models.Company, err error) {
+	ctx, span := trace.Start(ctx, "CompanyPostgresGateway.GetCompany")
+	defer trace.End(span, &err)
+
+	company := &models.Company{}
+	err = queries.Raw(`SELECT * FROM companies WHERE id = $1`, id).Bind(ctx, g.client.Reader(ctx), company)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repository.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return company, nil
+}
+
+func (g *companyPostgresGateway) UpdateAvailableBalance(ctx context.Context, id int64, balance types.NullDecimal) (err error) {
+	ctx, span := trace.Start(ctx, "CompanyPostgresGateway.UpdateAvailableBalance")
+	defer trace.End(span, &err)
+
+	result, err := g.client.Executor(ctx).ExecContext(ctx, `UPDATE companies SET available_balance = $1 WHERE id = $2`, balance, id)
+	if err != nil {
+		log.Error(ctx, fmt.Errorf("failed to update company available balance: %+v", err))
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return repository.ErrNotFound
+	}
+
+	return nil
+}
+
+func (g *companyPostgresGateway) UpdateTaxRounding(ctx context.Context, id int64, rounding string) (err error) {
+	ctx, span := trace.Start(ctx, "CompanyPostgresGateway.UpdateTaxRounding")
+	defer trace.End(span, &err)
+
+	result, err := g.client.Executor(ctx).ExecContext(ctx, `UPDATE companies SET tax_rounding = $1 WHERE id = $2`, rounding, id)
+	if err != nil {
+		log.Error(ctx, fmt.Errorf("failed to update company tax rounding: %+v", err))
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return repository.ErrNotFound
+	}
+
+	return nil
+}
+
+func (g *companyPostgresGateway) UpdateRegistrationNumber(ctx context.Context, id int64, registrationNumber null.String) (err error) {
+	ctx, span := trace.Start(ctx, "CompanyPostgresGateway.UpdateRegistrationNumber")
+	defer trace.End(span, &err)
+
+	result, err := g.client.Executor(ctx).ExecContext(ctx, `UPDATE companies SET registration_number = $1 WHERE id = $2`, registrationNumber, id)
+	if err != nil {
+		log.Error(ctx, fmt.Errorf("failed to update company registration number: %+v", err))
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return repository.ErrNotFound
+	}
+
+	return nil
+}
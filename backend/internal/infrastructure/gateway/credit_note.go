@@ -0,0 +1,126 @@
+package gateway
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/log"
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/trace"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+
+	"github.com/niko-cb/uct/internal/domain/entity"
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+	"github.com/niko-cb/uct/internal/domain/repository"
+	"github.com/niko-cb/uct/internal/infrastructure/persistent/mysql"
+)
+
+var _ repository.CreditNoteRepository = &creditNoteGateway{}
+
+type creditNoteGateway struct {
+	client *mysql.MySQLClient
+}
+
+func NewCreditNoteGateway(client *mysql.MySQLClient) repository.CreditNoteRepository {
+	return &creditNoteGateway{
+		client: client,
+	}
+}
+
+func (g *creditNoteGateway) CreateCreditNote(ctx context.Context, creditNote *models.CreditNote) (err error) {
+	ctx, span := trace.Start(ctx, "CreditNoteGateway.CreateCreditNote")
+	defer trace.End(span, &err)
+
+	creditNoteDefaults(creditNote)
+	err = creditNote.Insert(ctx, g.client.Executor(ctx), boil.Infer())
+	if err != nil {
+		log.Error(ctx, fmt.Errorf("failed to insert credit note into database: %+v", err))
+		return err
+	}
+
+	return nil
+}
+
+func (g *creditNoteGateway) GetCreditNote(ctx context.Context, id int64) (_ *models.CreditNote, err error) {
+	ctx, span := trace.Start(ctx, "CreditNoteGateway.GetCreditNote")
+	defer trace.End(span, &err)
+
+	creditNote, err := models.FindCreditNote(ctx, g.client.Reader(ctx), id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repository.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return creditNote, nil
+}
+
+func (g *creditNoteGateway) GetCreditNotesByInvoice(ctx context.Context, invoiceID int64) (_ []*models.CreditNote, err error) {
+	ctx, span := trace.Start(ctx, "CreditNoteGateway.GetCreditNotesByInvoice")
+	defer trace.End(span, &err)
+
+	return models.CreditNotes(
+		models.CreditNoteWhere.InvoiceID.EQ(invoiceID),
+		qm.OrderBy(models.CreditNoteColumns.ID),
+	).All(ctx, g.client.Reader(ctx))
+}
+
+func (g *creditNoteGateway) GetCreditNotesByDateRange(ctx context.Context, from, to time.Time, page repository.Page) (_ []*models.CreditNote, err error) {
+	ctx, span := trace.Start(ctx, "CreditNoteGateway.GetCreditNotesByDateRange")
+	defer trace.End(span, &err)
+
+	mods := []qm.QueryMod{
+		models.CreditNoteWhere.IssueDate.GTE(from),
+		models.CreditNoteWhere.IssueDate.LTE(to),
+		qm.OrderBy(models.CreditNoteColumns.ID),
+	}
+	if page.AfterID > 0 {
+		mods = append(mods, models.CreditNoteWhere.ID.GT(page.AfterID))
+	}
+	if page.Limit > 0 {
+		mods = append(mods, qm.Limit(page.Limit))
+	}
+	return models.CreditNotes(mods...).All(ctx, g.client.Reader(ctx))
+}
+
+func (g *creditNoteGateway) UpdateCreditNoteStatus(ctx context.Context, id int64, version int64, status string) (err error) {
+	ctx, span := trace.Start(ctx, "CreditNoteGateway.UpdateCreditNoteStatus")
+	defer trace.End(span, &err)
+
+	// The version check and the update are a single statement, like the invoices
+	exec := g.client.Executor(ctx)
+	rows, err := models.CreditNotes(
+		models.CreditNoteWhere.ID.EQ(id),
+		models.CreditNoteWhere.Version.EQ(version),
+	).UpdateAll(ctx, exec, models.M{
+		models.CreditNoteColumns.Status:  status,
+		models.CreditNoteColumns.Version: version + 1,
+	})
+	if err != nil {
+		log.Error(ctx, fmt.Errorf("failed to update credit note: %+v", err))
+		return err
+	}
+	if rows == 0 {
+		exists, err := models.CreditNoteExists(ctx, exec, id)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return repository.ErrNotFound
+		}
+		return repository.ErrVersionConflict
+	}
+
+	return nil
+}
+
+// creditNoteDefaults sets the column default of the status of a credit note when it is empty, a new credit note
+func creditNoteDefaults(creditNote *models.CreditNote) {
+	if creditNote.Status == "" {
+		creditNote.Status = entity.CreditNoteStatusIssued
+	}
+}
@@ -0,0 +1,158 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/log"
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/trace"
+
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+	"github.com/niko-cb/uct/internal/domain/repository"
+	"github.com/niko-cb/uct/internal/infrastructure/persistent/memory"
+)
+
+var _ repository.CreditNoteRepository = &creditNoteMemoryGateway{}
+
+// creditNoteMemoryGateway stores the credit notes in the in-memory store, mimicking the MySQL schema
+type creditNoteMemoryGateway struct {
+	store *memory.Store
+}
+
+func NewCreditNoteMemoryGateway(store *memory.Store) repository.CreditNoteRepository {
+	return &creditNoteMemoryGateway{
+		store: store,
+	}
+}
+
+func (g *creditNoteMemoryGateway) CreateCreditNote(ctx context.Context, creditNote *models.CreditNote) (err error) {
+	ctx, span := trace.Start(ctx, "CreditNoteMemoryGateway.CreateCreditNote")
+	defer trace.End(span, &err)
+
+	err = g.store.Write(ctx, func(t *memory.Tables) error {
+		if _, ok := t.Invoices[creditNote.InvoiceID]; !ok {
+			return fmt.Errorf("%w: credit_notes.invoice_id %d", memory.ErrForeignKey, creditNote.InvoiceID)
+		}
+		if _, ok := t.CreditNotes[creditNote.ID]; ok {
+			return fmt.Errorf("%w: credit_notes.id %d", memory.ErrDuplicateKey, creditNote.ID)
+		}
+
+		creditNote.ID = g.store.ID(memory.TableCreditNotes, creditNote.ID)
+		creditNote.Version = 1
+		creditNoteDefaults(creditNote)
+		t.CreditNotes[creditNote.ID] = storedCreditNote(creditNote)
+		return nil
+	})
+	if err != nil {
+		log.Error(ctx, fmt.Errorf("failed to insert credit note into database: %+v", err))
+		return err
+	}
+
+	return nil
+}
+
+func (g *creditNoteMemoryGateway) GetCreditNote(ctx context.Context, id int64) (_ *models.CreditNote, err error) {
+	ctx, span := trace.Start(ctx, "CreditNoteMemoryGateway.GetCreditNote")
+	defer trace.End(span, &err)
+
+	var creditNote *models.CreditNote
+	err = g.store.Read(ctx, func(t *memory.Tables) error {
+		stored, ok := t.CreditNotes[id]
+		if !ok {
+			return repository.ErrNotFound
+		}
+		creditNote = copyCreditNote(stored)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return creditNote, nil
+}
+
+func (g *creditNoteMemoryGateway) GetCreditNotesByInvoice(ctx context.Context, invoiceID int64) (_ []*models.CreditNote, err error) {
+	ctx, span := trace.Start(ctx, "CreditNoteMemoryGateway.GetCreditNotesByInvoice")
+	defer trace.End(span, &err)
+
+	return g.find(ctx, func(creditNote models.CreditNote) bool { return creditNote.InvoiceID == invoiceID }, repository.Page{})
+}
+
+func (g *creditNoteMemoryGateway) GetCreditNotesByDateRange(ctx context.Context, from, to time.Time, page repository.Page) (_ []*models.CreditNote, err error) {
+	ctx, span := trace.Start(ctx, "CreditNoteMemoryGateway.GetCreditNotesByDateRange")
+	defer trace.End(span, &err)
+
+	return g.find(ctx, func(creditNote models.CreditNote) bool {
+		return !creditNote.IssueDate.Before(from) && !creditNote.IssueDate.After(to)
+	}, page)
+}
+
+func (g *creditNoteMemoryGateway) UpdateCreditNoteStatus(ctx context.Context, id int64, version int64, status string) (err error) {
+	ctx, span := trace.Start(ctx, "CreditNoteMemoryGateway.UpdateCreditNoteStatus")
+	defer trace.End(span, &err)
+
+	err = g.store.Write(ctx, func(t *memory.Tables) error {
+		stored, ok := t.CreditNotes[id]
+		if !ok {
+			return repository.ErrNotFound
+		}
+		if stored.Version != version {
+			return repository.ErrVersionConflict
+		}
+		stored.Status = status
+		stored.Version++
+		t.CreditNotes[id] = stored
+		return nil
+	})
+	if err != nil {
+		log.Error(ctx, fmt.Errorf("failed to update credit note: %+v", err))
+		return err
+	}
+
+	return nil
+}
+
+// find returns a page of the credit notes matching a condition, ordered by id
+func (g *creditNoteMemoryGateway) find(ctx context.Context, match func(models.CreditNote) bool, page repository.Page) ([]*models.CreditNote, error) {
+	var creditNotes []*models.CreditNote
+	err := g.store.Read(ctx, func(t *memory.Tables) error {
+		for _, creditNote := range t.CreditNotes {
+			if creditNote.ID > page.AfterID && match(creditNote) {
+				creditNotes = append(creditNotes, copyCreditNote(creditNote))
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(creditNotes, func(i, j int) bool { return creditNotes[i].ID < creditNotes[j].ID })
+	if page.Limit > 0 && len(creditNotes) > page.Limit {
+		creditNotes = creditNotes[:page.Limit]
+	}
+	return creditNotes, nil
+}
+
+// storedCreditNote converts the credit note the way MySQL stores it: a date without time and amounts rounded to 2 decimals
+func storedCreditNote(creditNote *models.CreditNote) models.CreditNote {
+	stored := *copyCreditNote(*creditNote)
+	stored.IssueDate = toDate(stored.IssueDate)
+	stored.PaymentAmount = toDecimal152(stored.PaymentAmount)
+	stored.FeeAmount = toDecimal152(stored.FeeAmount)
+	stored.TaxAmount = toDecimal152(stored.TaxAmount)
+	stored.TotalAmount = toDecimal152(stored.TotalAmount)
+	return stored
+}
+
+// copyCreditNote copies a credit note, including its amounts which are pointers
+func copyCreditNote(creditNote models.CreditNote) *models.CreditNote {
+	creditNote.PaymentAmount = copyDecimal(creditNote.PaymentAmount)
+	creditNote.FeeAmount = copyDecimal(creditNote.FeeAmount)
+	creditNote.TaxAmount = copyDecimal(creditNote.TaxAmount)
+	creditNote.TotalAmount = copyDecimal(creditNote.TotalAmount)
+	creditNote.R = nil
+	return &creditNote
+}
@@ -0,0 +1,129 @@
+package gateway
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/log"
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/trace"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+	"github.com/niko-cb/uct/internal/domain/repository"
+	"github.com/niko-cb/uct/internal/infrastructure/persistent/postgres"
+)
+
+var _ repository.CreditNoteRepository = &creditNotePostgresGateway{}
+
+// creditNotePostgresGateway stores the credit notes in Postgres, with queries written by hand like the invoices
+type creditNotePostgresGateway struct {
+	client *postgres.PostgresClient
+}
+
+func NewCreditNotePostgresGateway(client *postgres.PostgresClient) repository.CreditNoteRepository {
+	return &creditNotePostgresGateway{
+		client: client,
+	}
+}
+
+func (g *creditNotePostgresGateway) CreateCreditNote(ctx context.Context, creditNote *models.CreditNote) (err error) {
+	ctx, span := trace.Start(ctx, "CreditNotePostgresGateway.CreateCreditNote")
+	defer trace.End(span, &err)
+
+	creditNoteDefaults(creditNote)
+	err = g.client.Executor(ctx).QueryRowContext(ctx,
+		`INSERT INTO credit_notes (invoice_id, issue_date, reason, payment_amount, fee_amount, tax_amount, total_amount, status)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id, version`,
+		creditNote.InvoiceID, creditNote.IssueDate, creditNote.Reason, creditNote.PaymentAmount, creditNote.FeeAmount,
+		creditNote.TaxAmount, creditNote.TotalAmount, creditNote.Status,
+	).Scan(&creditNote.ID, &creditNote.Version)
+	if err != nil {
+		log.Error(ctx, fmt.Errorf("failed to insert credit note into database: %+v", err))
+		return err
+	}
+
+	return nil
+}
+
+func (g *creditNotePostgresGateway) GetCreditNote(ctx context.Context, id int64) (_ *models.CreditNote, err error) {
+	ctx, span := trace.Start(ctx, "CreditNotePostgresGateway.GetCreditNote")
+	defer trace.End(span, &err)
+
+	creditNote := &models.CreditNote{}
+	err = queries.Raw(`SELECT * FROM credit_notes WHERE id = $1`, id).Bind(ctx, g.client.Reader(ctx), creditNote)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repository.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return creditNote, nil
+}
+
+func (g *creditNotePostgresGateway) GetCreditNotesByInvoice(ctx context.Context, invoiceID int64) (_ []*models.CreditNote, err error) {
+	ctx, span := trace.Start(ctx, "CreditNotePostgresGateway.GetCreditNotesByInvoice")
+	defer trace.End(span, &err)
+
+	var creditNotes []*models.CreditNote
+	err = queries.Raw(`SELECT * FROM credit_notes WHERE invoice_id = $1 ORDER BY id`, invoiceID).
+		Bind(ctx, g.client.Reader(ctx), &creditNotes)
+	if err != nil {
+		return nil, err
+	}
+
+	return creditNotes, nil
+}
+
+func (g *creditNotePostgresGateway) GetCreditNotesByDateRange(ctx context.Context, from, to time.Time, page repository.Page) (_ []*models.CreditNote, err error) {
+	ctx, span := trace.Start(ctx, "CreditNotePostgresGateway.GetCreditNotesByDateRange")
+	defer trace.End(span, &err)
+
+	query := `SELECT * FROM credit_notes WHERE issue_date >= $1 AND issue_date <= $2 AND id > $3 ORDER BY id`
+	args := []interface{}{from, to, page.AfterID}
+	if page.Limit > 0 {
+		query += ` LIMIT $4`
+		args = append(args, page.Limit)
+	}
+
+	var creditNotes []*models.CreditNote
+	err = queries.Raw(query, args...).Bind(ctx, g.client.Reader(ctx), &creditNotes)
+	if err != nil {
+		return nil, err
+	}
+
+	return creditNotes, nil
+}
+
+func (g *creditNotePostgresGateway) UpdateCreditNoteStatus(ctx context.Context, id int64, version int64, status string) (err error) {
+	ctx, span := trace.Start(ctx, "CreditNotePostgresGateway.UpdateCreditNoteStatus")
+	defer trace.End(span, &err)
+
+	exec := g.client.Executor(ctx)
+	result, err := exec.ExecContext(ctx,
+		`UPDATE credit_notes SET status = $1, version = version + 1 WHERE id = $2 AND version = $3`, status, id, version)
+	if err != nil {
+		log.Error(ctx, fmt.Errorf("failed to update credit note: %+v", err))
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		var exists bool
+		err := exec.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM credit_notes WHERE id = $1)`, id).Scan(&exists)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return repository.ErrNotFound
+		}
+		return repository.ErrVersionConflict
+	}
+
+	return nil
+}
@@ -0,0 +1,93 @@
+package gateway_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/niko-cb/uct/internal/conversion"
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+	"github.com/niko-cb/uct/internal/domain/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/sqlboiler/v4/types"
+)
+
+// TestCreditNoteRepository checks the credit notes are listed, loaded with their invoice and kept when it is updated,
+// and their status changes at their version only
+func TestCreditNoteRepository(t *testing.T) {
+	for _, b := range backends(t) {
+		t.Run(b.name, func(t *testing.T) {
+			ctx := context.Background()
+			companyID, clientID := b.parents(t)
+			invoice := newInvoice(companyID, clientID, date("2024-07-31"), 10000)
+			require.NoError(t, b.invoices.CreateInvoice(ctx, invoice))
+
+			var ids []int64
+			for _, issued := range []string{"2024-07-05", "2024-07-10", "2024-08-01"} {
+				creditNote := &models.CreditNote{
+					InvoiceID: invoice.ID, IssueDate: date(issued), Reason: "overbilled",
+					PaymentAmount: amount(1000.004), FeeAmount: amount(40), TaxAmount: amount(4), TotalAmount: amount(1044),
+				}
+				require.NoError(t, b.creditNotes.CreateCreditNote(ctx, creditNote))
+				require.NotZero(t, creditNote.ID)
+				assert.Equal(t, int64(1), creditNote.Version)
+				assert.Equal(t, "issued", creditNote.Status)
+				ids = append(ids, creditNote.ID)
+			}
+
+			stored, err := b.creditNotes.GetCreditNote(ctx, ids[0])
+			require.NoError(t, err)
+			assert.Equal(t, "1000.00", stored.PaymentAmount.String())
+			assert.Equal(t, "2024-07-05", stored.IssueDate.Format("2006-01-02"))
+			_, err = b.creditNotes.GetCreditNote(ctx, ids[2]+1)
+			assert.ErrorIs(t, err, repository.ErrNotFound)
+
+			byInvoice, err := b.creditNotes.GetCreditNotesByInvoice(ctx, invoice.ID)
+			require.NoError(t, err)
+			assert.Equal(t, ids, creditNoteIDs(byInvoice))
+			byInvoice, err = b.creditNotes.GetCreditNotesByInvoice(ctx, invoice.ID+1)
+			require.NoError(t, err)
+			assert.Empty(t, byInvoice)
+
+			july, err := b.creditNotes.GetCreditNotesByDateRange(ctx, date("2024-07-01"), date("2024-07-31"), repository.Page{})
+			require.NoError(t, err)
+			assert.Equal(t, ids[:2], creditNoteIDs(july))
+			page, err := b.creditNotes.GetCreditNotesByDateRange(ctx, date("2024-07-01"), date("2024-08-31"), repository.Page{Limit: 1, AfterID: ids[0]})
+			require.NoError(t, err)
+			assert.Equal(t, ids[1:2], creditNoteIDs(page))
+
+			require.NoError(t, b.creditNotes.UpdateCreditNoteStatus(ctx, ids[1], 1, "void"))
+			assert.ErrorIs(t, b.creditNotes.UpdateCreditNoteStatus(ctx, ids[1], 1, "applied"), repository.ErrVersionConflict)
+			assert.ErrorIs(t, b.creditNotes.UpdateCreditNoteStatus(ctx, ids[2]+1, 1, "void"), repository.ErrNotFound)
+			voided, err := b.creditNotes.GetCreditNote(ctx, ids[1])
+			require.NoError(t, err)
+			assert.Equal(t, "void", voided.Status)
+			assert.Equal(t, int64(2), voided.Version)
+
+			// The credit notes are loaded with the invoice, and not replaced like its items
+			loaded, err := b.invoices.GetInvoice(ctx, invoice.ID)
+			require.NoError(t, err)
+			require.NoError(t, b.invoices.UpdateInvoice(ctx, loaded))
+			loaded, err = b.invoices.GetInvoice(ctx, invoice.ID)
+			require.NoError(t, err)
+			assert.Equal(t, ids, creditNoteIDs(loaded.R.GetCreditNotes()))
+
+			require.NoError(t, b.invoices.DeleteInvoice(ctx, invoice.ID, loaded.Version))
+			_, err = b.creditNotes.GetCreditNote(ctx, ids[0])
+			assert.ErrorIs(t, err, repository.ErrNotFound, "the credit notes are deleted with their invoice")
+		})
+	}
+}
+
+func amount(f float64) (d types.Decimal) {
+	d, _ = conversion.ConvertToDecimal(f)
+	return d
+}
+
+func creditNoteIDs(creditNotes []*models.CreditNote) []int64 {
+	ids := make([]int64, len(creditNotes))
+	for i, creditNote := range creditNotes {
+		ids[i] = creditNote.ID
+	}
+	return ids
+}
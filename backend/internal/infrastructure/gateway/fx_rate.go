@@ -0,0 +1,76 @@
+package gateway
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/log"
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/trace"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+	"github.com/niko-cb/uct/internal/domain/repository"
+	"github.com/niko-cb/uct/internal/infrastructure/persistent/mysql"
+)
+
+var _ repository.FXRateRepository = &fxRateGateway{}
+
+// fxRateColumns are the columns inserted by UpsertFXRates, the id being generated
+var fxRateColumns = []string{"currency", "base_currency", "rate_date", "rate"}
+
+type fxRateGateway struct {
+	client *mysql.MySQLClient
+}
+
+func NewFXRateGateway(client *mysql.MySQLClient) repository.FXRateRepository {
+	return &fxRateGateway{
+		client: client,
+	}
+}
+
+func (g *fxRateGateway) UpsertFXRates(ctx context.Context, rates []*models.FXRate) (err error) {
+	ctx, span := trace.Start(ctx, "FXRateGateway.UpsertFXRates")
+	defer trace.End(span, &err)
+
+	if len(rates) == 0 {
+		return nil
+	}
+	query, args := insertQuery("fx_rates", fxRateColumns, fxRateRows(rates), false)
+	_, err = g.client.Executor(ctx).ExecContext(ctx, query+" ON DUPLICATE KEY UPDATE rate = VALUES(rate)", args...)
+	if err != nil {
+		log.Error(ctx, fmt.Errorf("failed to upsert fx rates into database: %+v", err))
+		return err
+	}
+
+	return nil
+}
+
+func (g *fxRateGateway) GetFXRate(ctx context.Context, currency, baseCurrency string, on time.Time) (_ *models.FXRate, err error) {
+	ctx, span := trace.Start(ctx, "FXRateGateway.GetFXRate")
+	defer trace.End(span, &err)
+
+	rate, err := models.FXRates(
+		models.FXRateWhere.Currency.EQ(currency),
+		models.FXRateWhere.BaseCurrency.EQ(baseCurrency),
+		models.FXRateWhere.RateDate.LTE(on),
+		qm.OrderBy(models.FXRateColumns.RateDate+" DESC"),
+	).One(ctx, g.client.Reader(ctx))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repository.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return rate, nil
+}
+
+// fxRateRows are the values of fxRateColumns of every rate
+func fxRateRows(rates []*models.FXRate) [][]interface{} {
+	return seedRows(rates, func(r *models.FXRate) []interface{} {
+		return []interface{}{r.Currency, r.BaseCurrency, r.RateDate, r.Rate}
+	})
+}
@@ -0,0 +1,95 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/log"
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/trace"
+
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+	"github.com/niko-cb/uct/internal/domain/repository"
+	"github.com/niko-cb/uct/internal/infrastructure/persistent/memory"
+)
+
+var _ repository.FXRateRepository = &fxRateMemoryGateway{}
+
+// fxRateMemoryGateway stores the exchange rates in the in-memory store
+type fxRateMemoryGateway struct {
+	store *memory.Store
+}
+
+func NewFXRateMemoryGateway(store *memory.Store) repository.FXRateRepository {
+	return &fxRateMemoryGateway{
+		store: store,
+	}
+}
+
+func (g *fxRateMemoryGateway) UpsertFXRates(ctx context.Context, rates []*models.FXRate) (err error) {
+	ctx, span := trace.Start(ctx, "FXRateMemoryGateway.UpsertFXRates")
+	defer trace.End(span, &err)
+
+	err = g.store.Write(ctx, func(t *memory.Tables) error {
+		// The rates by currencies and day, like the unique key
+		existing := map[string]int64{}
+		for id, rate := range t.FXRates {
+			existing[fxRateKey(&rate)] = id
+		}
+		for _, rate := range rates {
+			stored := models.FXRate{
+				Currency:     rate.Currency,
+				BaseCurrency: rate.BaseCurrency,
+				RateDate:     toDate(rate.RateDate),
+				Rate:         roundDecimal(copyDecimal(rate.Rate), 8),
+			}
+			id, ok := existing[fxRateKey(&stored)]
+			if !ok {
+				id = g.store.ID(memory.TableFXRates, 0)
+				existing[fxRateKey(&stored)] = id
+			}
+			stored.ID = id
+			t.FXRates[id] = stored
+		}
+		return nil
+	})
+	if err != nil {
+		log.Error(ctx, fmt.Errorf("failed to upsert fx rates into database: %+v", err))
+		return err
+	}
+
+	return nil
+}
+
+func (g *fxRateMemoryGateway) GetFXRate(ctx context.Context, currency, baseCurrency string, on time.Time) (_ *models.FXRate, err error) {
+	ctx, span := trace.Start(ctx, "FXRateMemoryGateway.GetFXRate")
+	defer trace.End(span, &err)
+
+	var latest *models.FXRate
+	err = g.store.Read(ctx, func(t *memory.Tables) error {
+		for _, rate := range t.FXRates {
+			if rate.Currency != currency || rate.BaseCurrency != baseCurrency || rate.RateDate.After(on) {
+				continue
+			}
+			if latest == nil || rate.RateDate.After(latest.RateDate) {
+				found := rate
+				found.Rate = copyDecimal(rate.Rate)
+				latest = &found
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if latest == nil {
+		return nil, repository.ErrNotFound
+	}
+
+	return latest, nil
+}
+
+// fxRateKey identifies the rate of a currency in a base currency on a day
+func fxRateKey(rate *models.FXRate) string {
+	return rate.Currency + "/" + rate.BaseCurrency + "/" + rate.RateDate.Format("2006-01-02")
+}
@@ -0,0 +1,64 @@
+package gateway
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/log"
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/trace"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+	"github.com/niko-cb/uct/internal/domain/repository"
+	"github.com/niko-cb/uct/internal/infrastructure/persistent/postgres"
+)
+
+var _ repository.FXRateRepository = &fxRatePostgresGateway{}
+
+// fxRatePostgresGateway stores the exchange rates in Postgres
+type fxRatePostgresGateway struct {
+	client *postgres.PostgresClient
+}
+
+func NewFXRatePostgresGateway(client *postgres.PostgresClient) repository.FXRateRepository {
+	return &fxRatePostgresGateway{
+		client: client,
+	}
+}
+
+func (g *fxRatePostgresGateway) UpsertFXRates(ctx context.Context, rates []*models.FXRate) (err error) {
+	ctx, span := trace.Start(ctx, "FXRatePostgresGateway.UpsertFXRates")
+	defer trace.End(span, &err)
+
+	if len(rates) == 0 {
+		return nil
+	}
+	query, args := insertQuery("fx_rates", fxRateColumns, fxRateRows(rates), true)
+	query += " ON CONFLICT (currency, base_currency, rate_date) DO UPDATE SET rate = EXCLUDED.rate"
+	if _, err = g.client.Executor(ctx).ExecContext(ctx, query, args...); err != nil {
+		log.Error(ctx, fmt.Errorf("failed to upsert fx rates into database: %+v", err))
+		return err
+	}
+
+	return nil
+}
+
+func (g *fxRatePostgresGateway) GetFXRate(ctx context.Context, currency, baseCurrency string, on time.Time) (_ *models.FXRate, err error) {
+	ctx, span := trace.Start(ctx, "FXRatePostgresGateway.GetFXRate")
+	defer trace.End(span, &err)
+
+	rate := &models.FXRate{}
+	err = queries.Raw(`SELECT * FROM fx_rates WHERE currency = $1 AND base_currency = $2 AND rate_date <= $3
+		ORDER BY rate_date DESC LIMIT 1`, currency, baseCurrency, on).Bind(ctx, g.client.Reader(ctx), rate)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repository.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return rate, nil
+}
@@ -0,0 +1,55 @@
+package gateway_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ericlagergren/decimal"
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+	"github.com/niko-cb/uct/internal/domain/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/sqlboiler/v4/types"
+)
+
+// TestFXRateRepository checks the rates are replaced by day, and the latest rate on or before a day is found
+func TestFXRateRepository(t *testing.T) {
+	for _, b := range backends(t) {
+		t.Run(b.name, func(t *testing.T) {
+			ctx := context.Background()
+			rate := func(currency, day string, rate int64) *models.FXRate {
+				return &models.FXRate{Currency: currency, BaseCurrency: "JPY", RateDate: date(day), Rate: types.NewDecimal(decimal.New(rate, 4))}
+			}
+
+			require.NoError(t, b.fxRates.UpsertFXRates(ctx, []*models.FXRate{
+				rate("USD", "2024-07-01", 1612345),
+				rate("USD", "2024-07-05", 1605000),
+				rate("EUR", "2024-07-03", 1735000),
+			}))
+			// A correction of a day, and a new day
+			require.NoError(t, b.fxRates.UpsertFXRates(ctx, []*models.FXRate{
+				rate("USD", "2024-07-01", 1610000),
+				rate("USD", "2024-07-02", 1611000),
+			}))
+
+			for day, expected := range map[string]string{
+				"2024-07-01": "161.00000000",
+				"2024-07-02": "161.10000000",
+				"2024-07-04": "161.10000000",
+				"2024-07-31": "160.50000000",
+			} {
+				found, err := b.fxRates.GetFXRate(ctx, "USD", "JPY", date(day))
+				require.NoError(t, err, day)
+				assert.Equal(t, expected, found.Rate.String(), day)
+			}
+			found, err := b.fxRates.GetFXRate(ctx, "USD", "JPY", date("2024-07-04"))
+			require.NoError(t, err)
+			assert.Equal(t, "2024-07-02", found.RateDate.Format("2006-01-02"))
+
+			_, err = b.fxRates.GetFXRate(ctx, "USD", "JPY", date("2024-06-30"))
+			assert.ErrorIs(t, err, repository.ErrNotFound)
+			_, err = b.fxRates.GetFXRate(ctx, "JPY", "USD", date("2024-07-31"))
+			assert.ErrorIs(t, err, repository.ErrNotFound, "rates are not inverted")
+		})
+	}
+}
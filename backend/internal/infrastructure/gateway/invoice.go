@@ -3,12 +3,18 @@ package gateway
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"time"
+
+	"github.com/ericlagergren/decimal"
 	"github.com/niko-cb/uct/internal/infrastructure/monitor/log"
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/trace"
 	"github.com/volatiletech/sqlboiler/v4/boil"
 	"github.com/volatiletech/sqlboiler/v4/queries/qm"
-	"time"
+	"github.com/volatiletech/sqlboiler/v4/types"
 
+	"github.com/niko-cb/uct/internal/domain/entity"
 	"github.com/niko-cb/uct/internal/domain/entity/models"
 	"github.com/niko-cb/uct/internal/domain/repository"
 	"github.com/niko-cb/uct/internal/infrastructure/persistent/mysql"
@@ -26,31 +32,276 @@ func NewInvoiceGateway(client *mysql.MySQLClient) repository.InvoiceRepository {
 	}
 }
 
-func (g *invoiceGateway) CreateInvoice(ctx context.Context, tx *sql.Tx, invoice *models.Invoice) error {
-	// Connect to the database
-	g.client.Connect()
+func (g *invoiceGateway) CreateInvoice(ctx context.Context, invoice *models.Invoice) (err error) {
+	ctx, span := trace.Start(ctx, "InvoiceGateway.CreateInvoice")
+	defer trace.End(span, &err)
+
+	invoiceDefaults(invoice)
 
 	// Insert the invoice
-	err := invoice.Insert(ctx, tx, boil.Infer())
+	err = invoice.Insert(ctx, g.client.Executor(ctx), boil.Infer())
 	if err != nil {
 		log.Error(ctx, fmt.Errorf(fmt.Sprintf("failed to insert invoice into database: %+v", err)))
 		return err
 	}
 
+	err = g.saveDetails(ctx, g.client.Executor(ctx), invoice)
+	if err != nil {
+		log.Error(ctx, fmt.Errorf("failed to insert invoice details into database: %+v", err))
+		return err
+	}
+
 	return nil
 }
 
-func (g *invoiceGateway) GetInvoicesByDateRange(ctx context.Context, from, to time.Time) ([]*models.Invoice, error) {
-	// Ensure the database connection is established
-	g.client.Connect()
+func (g *invoiceGateway) GetInvoicesByDateRange(ctx context.Context, from, to time.Time, page repository.Page) (_ []*models.Invoice, err error) {
+	ctx, span := trace.Start(ctx, "InvoiceGateway.GetInvoicesByDateRange")
+	defer trace.End(span, &err)
 
 	// Retrieve invoices where the DueDate is between the provided 'from' and 'to' dates
-	invoices, err := models.Invoices(
-		qm.Where("due_date >= ? AND due_date <= ?", from, to),
-	).All(ctx, g.client.DB)
+	mods := []qm.QueryMod{
+		models.InvoiceWhere.DueDate.GTE(from),
+		models.InvoiceWhere.DueDate.LTE(to),
+		qm.OrderBy(models.InvoiceColumns.ID),
+	}
+	if page.AfterID > 0 {
+		mods = append(mods, models.InvoiceWhere.ID.GT(page.AfterID))
+	}
+	if page.Limit > 0 {
+		mods = append(mods, qm.Limit(page.Limit))
+	}
+	invoices, err := models.Invoices(mods...).All(ctx, g.client.Reader(ctx))
 	if err != nil {
 		return nil, err
 	}
+	if err := g.loadDetails(ctx, invoices); err != nil {
+		return nil, err
+	}
 
 	return invoices, nil
 }
+
+func (g *invoiceGateway) GetInvoicesByInstallmentDateRange(ctx context.Context, from, to time.Time, page repository.Page) (_ []*models.Invoice, err error) {
+	ctx, span := trace.Start(ctx, "InvoiceGateway.GetInvoicesByInstallmentDateRange")
+	defer trace.End(span, &err)
+
+	// An invoice is listed once, however many of its installments are due in the range
+	mods := []qm.QueryMod{
+		qm.Where("EXISTS (SELECT 1 FROM invoice_installments ii WHERE ii.invoice_id = invoices.id AND ii.due_date >= ? AND ii.due_date <= ?)",
+			from, to),
+		qm.OrderBy(models.InvoiceColumns.ID),
+	}
+	if page.AfterID > 0 {
+		mods = append(mods, models.InvoiceWhere.ID.GT(page.AfterID))
+	}
+	if page.Limit > 0 {
+		mods = append(mods, qm.Limit(page.Limit))
+	}
+	invoices, err := models.Invoices(mods...).All(ctx, g.client.Reader(ctx))
+	if err != nil {
+		return nil, err
+	}
+	if err := g.loadDetails(ctx, invoices); err != nil {
+		return nil, err
+	}
+
+	return invoices, nil
+}
+
+func (g *invoiceGateway) GetInvoice(ctx context.Context, id int64) (_ *models.Invoice, err error) {
+	ctx, span := trace.Start(ctx, "InvoiceGateway.GetInvoice")
+	defer trace.End(span, &err)
+
+	invoice, err := models.FindInvoice(ctx, g.client.Reader(ctx), id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repository.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := g.loadDetails(ctx, []*models.Invoice{invoice}); err != nil {
+		return nil, err
+	}
+
+	return invoice, nil
+}
+
+func (g *invoiceGateway) UpdateInvoice(ctx context.Context, invoice *models.Invoice) (err error) {
+	ctx, span := trace.Start(ctx, "InvoiceGateway.UpdateInvoice")
+	defer trace.End(span, &err)
+
+	invoiceDefaults(invoice)
+
+	// The version check and the update are a single statement, so a concurrent update can't slip in between
+	exec := g.client.Executor(ctx)
+	rows, err := models.Invoices(
+		models.InvoiceWhere.ID.EQ(invoice.ID),
+		models.InvoiceWhere.Version.EQ(invoice.Version),
+	).UpdateAll(ctx, exec, models.M{
+		models.InvoiceColumns.CompanyID:     invoice.CompanyID,
+		models.InvoiceColumns.ClientID:      invoice.ClientID,
+		models.InvoiceColumns.IssueDate:     invoice.IssueDate,
+		models.InvoiceColumns.DueDate:       invoice.DueDate,
+		models.InvoiceColumns.PaymentAmount: invoice.PaymentAmount,
+		models.InvoiceColumns.FeeAmount:     invoice.FeeAmount,
+		models.InvoiceColumns.TaxAmount:     invoice.TaxAmount,
+		models.InvoiceColumns.TotalAmount:   invoice.TotalAmount,
+		models.InvoiceColumns.Status:        invoice.Status,
+		models.InvoiceColumns.Currency:      invoice.Currency,
+		models.InvoiceColumns.FXRate:        invoice.FXRate,
+		models.InvoiceColumns.Version:       invoice.Version + 1,
+	})
+	if err != nil {
+		log.Error(ctx, fmt.Errorf("failed to update invoice: %+v", err))
+		return err
+	}
+	if rows == 0 {
+		return g.missingInvoice(ctx, exec, invoice.ID)
+	}
+
+	err = g.saveDetails(ctx, exec, invoice)
+	if err != nil {
+		log.Error(ctx, fmt.Errorf("failed to update invoice details: %+v", err))
+		return err
+	}
+
+	invoice.Version++
+	return nil
+}
+
+func (g *invoiceGateway) UpdateInvoiceStatus(ctx context.Context, id int64, version int64, status string) (err error) {
+	ctx, span := trace.Start(ctx, "InvoiceGateway.UpdateInvoiceStatus")
+	defer trace.End(span, &err)
+
+	exec := g.client.Executor(ctx)
+	rows, err := models.Invoices(
+		models.InvoiceWhere.ID.EQ(id),
+		models.InvoiceWhere.Version.EQ(version),
+	).UpdateAll(ctx, exec, models.M{
+		models.InvoiceColumns.Status:  status,
+		models.InvoiceColumns.Version: version + 1,
+	})
+	if err != nil {
+		log.Error(ctx, fmt.Errorf("failed to update invoice status: %+v", err))
+		return err
+	}
+	if rows == 0 {
+		return g.missingInvoice(ctx, exec, id)
+	}
+
+	return nil
+}
+
+func (g *invoiceGateway) DeleteInvoice(ctx context.Context, id int64, version int64) (err error) {
+	ctx, span := trace.Start(ctx, "InvoiceGateway.DeleteInvoice")
+	defer trace.End(span, &err)
+
+	exec := g.client.Executor(ctx)
+	rows, err := models.Invoices(
+		models.InvoiceWhere.ID.EQ(id),
+		models.InvoiceWhere.Version.EQ(version),
+	).DeleteAll(ctx, exec)
+	if err != nil {
+		log.Error(ctx, fmt.Errorf("failed to delete invoice: %+v", err))
+		return err
+	}
+	if rows == 0 {
+		return g.missingInvoice(ctx, exec, id)
+	}
+
+	return nil
+}
+
+// saveDetails replaces the items, the tax subtotals and the installments of an invoice by the ones it holds
+func (g *invoiceGateway) saveDetails(ctx context.Context, exec boil.ContextExecutor, invoice *models.Invoice) error {
+	_, err := models.InvoiceItems(models.InvoiceItemWhere.InvoiceID.EQ(invoice.ID)).DeleteAll(ctx, exec)
+	if err != nil {
+		return err
+	}
+	_, err = models.InvoiceTaxSubtotals(models.InvoiceTaxSubtotalWhere.InvoiceID.EQ(invoice.ID)).DeleteAll(ctx, exec)
+	if err != nil {
+		return err
+	}
+	_, err = models.InvoiceInstallments(models.InvoiceInstallmentWhere.InvoiceID.EQ(invoice.ID)).DeleteAll(ctx, exec)
+	if err != nil {
+		return err
+	}
+	err = insertRows(ctx, exec, models.TableNames.InvoiceItems, invoiceItemColumns, invoiceItemRows(invoice), false)
+	if err != nil {
+		return err
+	}
+	err = insertRows(ctx, exec, models.TableNames.InvoiceTaxSubtotals, taxSubtotalColumns, taxSubtotalRows(invoice), false)
+	if err != nil {
+		return err
+	}
+	return insertRows(ctx, exec, models.TableNames.InvoiceInstallments, installmentColumns, installmentRows(invoice), false)
+}
+
+// loadDetails loads the items, the tax subtotals, the installments, the credit notes and the payments of the invoices,
+// a query for each
+func (g *invoiceGateway) loadDetails(ctx context.Context, invoices []*models.Invoice) error {
+	if len(invoices) == 0 {
+		return nil
+	}
+	ids := invoiceIDs(invoices)
+	items, err := models.InvoiceItems(
+		models.InvoiceItemWhere.InvoiceID.IN(ids),
+		qm.OrderBy(models.InvoiceItemColumns.InvoiceID+", "+models.InvoiceItemColumns.LineNo),
+	).All(ctx, g.client.Reader(ctx))
+	if err != nil {
+		return err
+	}
+	subtotals, err := models.InvoiceTaxSubtotals(
+		models.InvoiceTaxSubtotalWhere.InvoiceID.IN(ids),
+		qm.OrderBy(models.InvoiceTaxSubtotalColumns.InvoiceID+", "+models.InvoiceTaxSubtotalColumns.ID),
+	).All(ctx, g.client.Reader(ctx))
+	if err != nil {
+		return err
+	}
+	installments, err := models.InvoiceInstallments(
+		models.InvoiceInstallmentWhere.InvoiceID.IN(ids),
+		qm.OrderBy(models.InvoiceInstallmentColumns.InvoiceID+", "+models.InvoiceInstallmentColumns.InstallmentNo),
+	).All(ctx, g.client.Reader(ctx))
+	if err != nil {
+		return err
+	}
+	creditNotes, err := models.CreditNotes(
+		models.CreditNoteWhere.InvoiceID.IN(ids),
+		qm.OrderBy(models.CreditNoteColumns.InvoiceID+", "+models.CreditNoteColumns.ID),
+	).All(ctx, g.client.Reader(ctx))
+	if err != nil {
+		return err
+	}
+	payments, err := models.InvoicePayments(
+		models.InvoicePaymentWhere.InvoiceID.IN(ids),
+		qm.OrderBy(models.InvoicePaymentColumns.InvoiceID+", "+models.InvoicePaymentColumns.ID),
+	).All(ctx, g.client.Reader(ctx))
+	if err != nil {
+		return err
+	}
+	attachInvoiceDetails(invoices, items, subtotals, installments, creditNotes, payments)
+	return nil
+}
+
+// missingInvoice tells why no row matched an id and a version: the invoice is gone or its version changed
+func (g *invoiceGateway) missingInvoice(ctx context.Context, exec boil.ContextExecutor, id int64) error {
+	exists, err := models.InvoiceExists(ctx, exec, id)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return repository.ErrNotFound
+	}
+	return repository.ErrVersionConflict
+}
+
+// invoiceDefaults sets the column defaults of the currency and the rate of an invoice when they are empty,
+// the invoices of the base currency
+func invoiceDefaults(invoice *models.Invoice) {
+	if invoice.Currency == "" {
+		invoice.Currency = entity.DefaultCurrency
+	}
+	if invoice.FXRate.Big == nil {
+		invoice.FXRate = types.NewDecimal(decimal.New(1, 0))
+	}
+}
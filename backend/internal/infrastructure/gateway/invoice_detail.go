@@ -0,0 +1,121 @@
+package gateway
+
+import (
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+)
+
+// The details of an invoice are its items, its tax subtotals and its installments, saved and loaded with it,
+// and its credit notes and payments, only loaded with it
+
+// invoiceItemColumns are the columns inserted for the items of an invoice, the id is generated
+var invoiceItemColumns = []string{
+	models.InvoiceItemColumns.InvoiceID,
+	models.InvoiceItemColumns.LineNo,
+	models.InvoiceItemColumns.Description,
+	models.InvoiceItemColumns.Quantity,
+	models.InvoiceItemColumns.UnitPrice,
+	models.InvoiceItemColumns.Amount,
+	models.InvoiceItemColumns.TaxCategory,
+}
+
+// taxSubtotalColumns are the columns inserted for the tax subtotals of an invoice, the id is generated
+var taxSubtotalColumns = []string{
+	models.InvoiceTaxSubtotalColumns.InvoiceID,
+	models.InvoiceTaxSubtotalColumns.TaxCategory,
+	models.InvoiceTaxSubtotalColumns.Rate,
+	models.InvoiceTaxSubtotalColumns.Amount,
+	models.InvoiceTaxSubtotalColumns.TaxAmount,
+}
+
+// installmentColumns are the columns inserted for the installments of an invoice, the id is generated
+var installmentColumns = []string{
+	models.InvoiceInstallmentColumns.InvoiceID,
+	models.InvoiceInstallmentColumns.InstallmentNo,
+	models.InvoiceInstallmentColumns.DueDate,
+	models.InvoiceInstallmentColumns.Amount,
+}
+
+// invoiceItemRows numbers the items of an invoice from 1, in their order, and returns their values to insert
+func invoiceItemRows(invoice *models.Invoice) [][]interface{} {
+	items := invoice.R.GetInvoiceItems()
+	rows := make([][]interface{}, len(items))
+	for i, item := range items {
+		item.InvoiceID = invoice.ID
+		item.LineNo = i + 1
+		rows[i] = []interface{}{item.InvoiceID, item.LineNo, item.Description, item.Quantity, item.UnitPrice, item.Amount, item.TaxCategory}
+	}
+	return rows
+}
+
+// taxSubtotalRows returns the values to insert for the tax subtotals of an invoice
+func taxSubtotalRows(invoice *models.Invoice) [][]interface{} {
+	subtotals := invoice.R.GetInvoiceTaxSubtotals()
+	rows := make([][]interface{}, len(subtotals))
+	for i, subtotal := range subtotals {
+		subtotal.InvoiceID = invoice.ID
+		rows[i] = []interface{}{subtotal.InvoiceID, subtotal.TaxCategory, subtotal.Rate, subtotal.Amount, subtotal.TaxAmount}
+	}
+	return rows
+}
+
+// installmentRows numbers the installments of an invoice from 1, in their order, and returns their values to insert
+func installmentRows(invoice *models.Invoice) [][]interface{} {
+	installments := invoice.R.GetInvoiceInstallments()
+	rows := make([][]interface{}, len(installments))
+	for i, installment := range installments {
+		installment.InvoiceID = invoice.ID
+		installment.InstallmentNo = i + 1
+		rows[i] = []interface{}{installment.InvoiceID, installment.InstallmentNo, installment.DueDate, installment.Amount}
+	}
+	return rows
+}
+
+// attachInvoiceDetails sets the items, ordered by line, the tax subtotals, the installments, ordered by number,
+// the credit notes and the payments to the invoices they belong to
+func attachInvoiceDetails(invoices []*models.Invoice, items models.InvoiceItemSlice, subtotals models.InvoiceTaxSubtotalSlice,
+	installments models.InvoiceInstallmentSlice, creditNotes models.CreditNoteSlice, payments models.InvoicePaymentSlice) {
+	byID := make(map[int64]*models.Invoice, len(invoices))
+	for _, invoice := range invoices {
+		invoice.R = invoice.R.NewStruct()
+		invoice.R.InvoiceItems = models.InvoiceItemSlice{}
+		invoice.R.InvoiceTaxSubtotals = models.InvoiceTaxSubtotalSlice{}
+		invoice.R.InvoiceInstallments = models.InvoiceInstallmentSlice{}
+		invoice.R.CreditNotes = models.CreditNoteSlice{}
+		invoice.R.InvoicePayments = models.InvoicePaymentSlice{}
+		byID[invoice.ID] = invoice
+	}
+	for _, item := range items {
+		if invoice, ok := byID[item.InvoiceID]; ok {
+			invoice.R.InvoiceItems = append(invoice.R.InvoiceItems, item)
+		}
+	}
+	for _, subtotal := range subtotals {
+		if invoice, ok := byID[subtotal.InvoiceID]; ok {
+			invoice.R.InvoiceTaxSubtotals = append(invoice.R.InvoiceTaxSubtotals, subtotal)
+		}
+	}
+	for _, installment := range installments {
+		if invoice, ok := byID[installment.InvoiceID]; ok {
+			invoice.R.InvoiceInstallments = append(invoice.R.InvoiceInstallments, installment)
+		}
+	}
+	for _, creditNote := range creditNotes {
+		if invoice, ok := byID[creditNote.InvoiceID]; ok {
+			invoice.R.CreditNotes = append(invoice.R.CreditNotes, creditNote)
+		}
+	}
+	for _, payment := range payments {
+		if invoice, ok := byID[payment.InvoiceID]; ok {
+			invoice.R.InvoicePayments = append(invoice.R.InvoicePayments, payment)
+		}
+	}
+}
+
+// invoiceIDs returns the ids of the invoices
+func invoiceIDs(invoices []*models.Invoice) []int64 {
+	ids := make([]int64, len(invoices))
+	for i, invoice := range invoices {
+		ids[i] = invoice.ID
+	}
+	return ids
+}
@@ -0,0 +1,88 @@
+package gateway
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/log"
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/trace"
+
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+	"github.com/niko-cb/uct/internal/domain/repository"
+	"github.com/niko-cb/uct/internal/infrastructure/persistent/mysql"
+)
+
+var _ repository.InvoiceIssuerRepository = &invoiceIssuerGateway{}
+
+// invoiceIssuerColumns are the columns inserted by UpsertInvoiceIssuers, the id being generated
+var invoiceIssuerColumns = []string{"registration_number", "name", "registered_on", "revoked_on", "expired_on"}
+
+type invoiceIssuerGateway struct {
+	client *mysql.MySQLClient
+}
+
+func NewInvoiceIssuerGateway(client *mysql.MySQLClient) repository.InvoiceIssuerRepository {
+	return &invoiceIssuerGateway{
+		client: client,
+	}
+}
+
+func (g *invoiceIssuerGateway) UpsertInvoiceIssuers(ctx context.Context, issuers []*models.InvoiceIssuer) (err error) {
+	ctx, span := trace.Start(ctx, "InvoiceIssuerGateway.UpsertInvoiceIssuers")
+	defer trace.End(span, &err)
+
+	if len(issuers) == 0 {
+		return nil
+	}
+	query, args := insertQuery("invoice_issuers", invoiceIssuerColumns, invoiceIssuerRows(issuers), false)
+	query += ` ON DUPLICATE KEY UPDATE name = VALUES(name), registered_on = VALUES(registered_on),
+		revoked_on = VALUES(revoked_on), expired_on = VALUES(expired_on)`
+	if _, err = g.client.Executor(ctx).ExecContext(ctx, query, args...); err != nil {
+		log.Error(ctx, fmt.Errorf("failed to upsert invoice issuers into database: %+v", err))
+		return err
+	}
+
+	return nil
+}
+
+func (g *invoiceIssuerGateway) DeleteInvoiceIssuers(ctx context.Context, registrationNumbers []string) (err error) {
+	ctx, span := trace.Start(ctx, "InvoiceIssuerGateway.DeleteInvoiceIssuers")
+	defer trace.End(span, &err)
+
+	if len(registrationNumbers) == 0 {
+		return nil
+	}
+	_, err = models.InvoiceIssuers(models.InvoiceIssuerWhere.RegistrationNumber.IN(registrationNumbers)).
+		DeleteAll(ctx, g.client.Executor(ctx))
+	if err != nil {
+		log.Error(ctx, fmt.Errorf("failed to delete invoice issuers from database: %+v", err))
+		return err
+	}
+
+	return nil
+}
+
+func (g *invoiceIssuerGateway) GetInvoiceIssuer(ctx context.Context, registrationNumber string) (_ *models.InvoiceIssuer, err error) {
+	ctx, span := trace.Start(ctx, "InvoiceIssuerGateway.GetInvoiceIssuer")
+	defer trace.End(span, &err)
+
+	issuer, err := models.InvoiceIssuers(models.InvoiceIssuerWhere.RegistrationNumber.EQ(registrationNumber)).
+		One(ctx, g.client.Reader(ctx))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repository.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return issuer, nil
+}
+
+// invoiceIssuerRows are the values of invoiceIssuerColumns of every issuer
+func invoiceIssuerRows(issuers []*models.InvoiceIssuer) [][]interface{} {
+	return seedRows(issuers, func(i *models.InvoiceIssuer) []interface{} {
+		return []interface{}{i.RegistrationNumber, i.Name, i.RegisteredOn, i.RevokedOn, i.ExpiredOn}
+	})
+}
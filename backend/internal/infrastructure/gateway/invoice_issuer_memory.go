@@ -0,0 +1,104 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/log"
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/trace"
+
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+	"github.com/niko-cb/uct/internal/domain/repository"
+	"github.com/niko-cb/uct/internal/infrastructure/persistent/memory"
+)
+
+var _ repository.InvoiceIssuerRepository = &invoiceIssuerMemoryGateway{}
+
+// invoiceIssuerMemoryGateway stores the qualified invoice issuers in the in-memory store
+type invoiceIssuerMemoryGateway struct {
+	store *memory.Store
+}
+
+func NewInvoiceIssuerMemoryGateway(store *memory.Store) repository.InvoiceIssuerRepository {
+	return &invoiceIssuerMemoryGateway{
+		store: store,
+	}
+}
+
+func (g *invoiceIssuerMemoryGateway) UpsertInvoiceIssuers(ctx context.Context, issuers []*models.InvoiceIssuer) (err error) {
+	ctx, span := trace.Start(ctx, "InvoiceIssuerMemoryGateway.UpsertInvoiceIssuers")
+	defer trace.End(span, &err)
+
+	err = g.store.Write(ctx, func(t *memory.Tables) error {
+		// The issuers by registration number, like the unique key
+		existing := map[string]int64{}
+		for id, issuer := range t.Issuers {
+			existing[issuer.RegistrationNumber] = id
+		}
+		for _, issuer := range issuers {
+			stored := models.InvoiceIssuer{
+				RegistrationNumber: issuer.RegistrationNumber,
+				Name:               issuer.Name,
+				RegisteredOn:       toDate(issuer.RegisteredOn),
+				RevokedOn:          toNullDate(issuer.RevokedOn),
+				ExpiredOn:          toNullDate(issuer.ExpiredOn),
+			}
+			id, ok := existing[stored.RegistrationNumber]
+			if !ok {
+				id = g.store.ID(memory.TableIssuers, 0)
+				existing[stored.RegistrationNumber] = id
+			}
+			stored.ID = id
+			t.Issuers[id] = stored
+		}
+		return nil
+	})
+	if err != nil {
+		log.Error(ctx, fmt.Errorf("failed to upsert invoice issuers into database: %+v", err))
+		return err
+	}
+
+	return nil
+}
+
+func (g *invoiceIssuerMemoryGateway) DeleteInvoiceIssuers(ctx context.Context, registrationNumbers []string) (err error) {
+	ctx, span := trace.Start(ctx, "InvoiceIssuerMemoryGateway.DeleteInvoiceIssuers")
+	defer trace.End(span, &err)
+
+	err = g.store.Write(ctx, func(t *memory.Tables) error {
+		for id, issuer := range t.Issuers {
+			if slices.Contains(registrationNumbers, issuer.RegistrationNumber) {
+				delete(t.Issuers, id)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.Error(ctx, fmt.Errorf("failed to delete invoice issuers from database: %+v", err))
+		return err
+	}
+
+	return nil
+}
+
+func (g *invoiceIssuerMemoryGateway) GetInvoiceIssuer(ctx context.Context, registrationNumber string) (_ *models.InvoiceIssuer, err error) {
+	ctx, span := trace.Start(ctx, "InvoiceIssuerMemoryGateway.GetInvoiceIssuer")
+	defer trace.End(span, &err)
+
+	var found *models.InvoiceIssuer
+	err = g.store.Read(ctx, func(t *memory.Tables) error {
+		for _, issuer := range t.Issuers {
+			if issuer.RegistrationNumber == registrationNumber {
+				found = &issuer
+				return nil
+			}
+		}
+		return repository.ErrNotFound
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return found, nil
+}
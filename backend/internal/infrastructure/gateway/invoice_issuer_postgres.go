@@ -0,0 +1,82 @@
+package gateway
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/log"
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/trace"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+	"github.com/niko-cb/uct/internal/domain/repository"
+	"github.com/niko-cb/uct/internal/infrastructure/persistent/postgres"
+)
+
+var _ repository.InvoiceIssuerRepository = &invoiceIssuerPostgresGateway{}
+
+// invoiceIssuerPostgresGateway stores the qualified invoice issuers in Postgres
+type invoiceIssuerPostgresGateway struct {
+	client *postgres.PostgresClient
+}
+
+func NewInvoiceIssuerPostgresGateway(client *postgres.PostgresClient) repository.InvoiceIssuerRepository {
+	return &invoiceIssuerPostgresGateway{
+		client: client,
+	}
+}
+
+func (g *invoiceIssuerPostgresGateway) UpsertInvoiceIssuers(ctx context.Context, issuers []*models.InvoiceIssuer) (err error) {
+	ctx, span := trace.Start(ctx, "InvoiceIssuerPostgresGateway.UpsertInvoiceIssuers")
+	defer trace.End(span, &err)
+
+	if len(issuers) == 0 {
+		return nil
+	}
+	query, args := insertQuery("invoice_issuers", invoiceIssuerColumns, invoiceIssuerRows(issuers), true)
+	query += ` ON CONFLICT (registration_number) DO UPDATE SET name = EXCLUDED.name, registered_on = EXCLUDED.registered_on,
+		revoked_on = EXCLUDED.revoked_on, expired_on = EXCLUDED.expired_on`
+	if _, err = g.client.Executor(ctx).ExecContext(ctx, query, args...); err != nil {
+		log.Error(ctx, fmt.Errorf("failed to upsert invoice issuers into database: %+v", err))
+		return err
+	}
+
+	return nil
+}
+
+func (g *invoiceIssuerPostgresGateway) DeleteInvoiceIssuers(ctx context.Context, registrationNumbers []string) (err error) {
+	ctx, span := trace.Start(ctx, "InvoiceIssuerPostgresGateway.DeleteInvoiceIssuers")
+	defer trace.End(span, &err)
+
+	if len(registrationNumbers) == 0 {
+		return nil
+	}
+	_, err = g.client.Executor(ctx).ExecContext(ctx, `DELETE FROM invoice_issuers WHERE registration_number = ANY($1)`,
+		pq.Array(registrationNumbers))
+	if err != nil {
+		log.Error(ctx, fmt.Errorf("failed to delete invoice issuers from database: %+v", err))
+		return err
+	}
+
+	return nil
+}
+
+func (g *invoiceIssuerPostgresGateway) GetInvoiceIssuer(ctx context.Context, registrationNumber string) (_ *models.InvoiceIssuer, err error) {
+	ctx, span := trace.Start(ctx, "InvoiceIssuerPostgresGateway.GetInvoiceIssuer")
+	defer trace.End(span, &err)
+
+	issuer := &models.InvoiceIssuer{}
+	err = queries.Raw(`SELECT * FROM invoice_issuers WHERE registration_number = $1`, registrationNumber).
+		Bind(ctx, g.client.Reader(ctx), issuer)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repository.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return issuer, nil
+}
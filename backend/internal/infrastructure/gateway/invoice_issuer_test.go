@@ -0,0 +1,121 @@
+package gateway_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+	"github.com/niko-cb/uct/internal/domain/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/null/v8"
+)
+
+// TestInvoiceIssuerRepository checks the issuers are replaced by registration number, and deleted
+func TestInvoiceIssuerRepository(t *testing.T) {
+	for _, b := range backends(t) {
+		t.Run(b.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			require.NoError(t, b.issuers.UpsertInvoiceIssuers(ctx, []*models.InvoiceIssuer{
+				{RegistrationNumber: "T2010401000001", Name: "株式会社サンプル商事", RegisteredOn: date("2023-10-01")},
+				{RegistrationNumber: "T4010001000003", Name: "expired", RegisteredOn: date("2023-10-01")},
+				{RegistrationNumber: "T8200001000005", Name: "deleted", RegisteredOn: date("2023-10-01")},
+			}))
+			// A later revocation, and a new issuer
+			require.NoError(t, b.issuers.UpsertInvoiceIssuers(ctx, []*models.InvoiceIssuer{
+				{RegistrationNumber: "T2010401000001", Name: "株式会社サンプル商事", RegisteredOn: date("2023-10-01"),
+					RevokedOn: null.TimeFrom(date("2024-09-30"))},
+				{RegistrationNumber: "T5300001000006", Name: "山田 太郎", RegisteredOn: date("2024-01-01")},
+			}))
+			require.NoError(t, b.issuers.DeleteInvoiceIssuers(ctx, []string{"T8200001000005", "T7123456789012"}))
+			require.NoError(t, b.issuers.UpsertInvoiceIssuers(ctx, nil))
+			require.NoError(t, b.issuers.DeleteInvoiceIssuers(ctx, nil))
+
+			found, err := b.issuers.GetInvoiceIssuer(ctx, "T2010401000001")
+			require.NoError(t, err)
+			assert.Equal(t, "株式会社サンプル商事", found.Name)
+			assert.Equal(t, "2023-10-01", found.RegisteredOn.Format("2006-01-02"))
+			require.True(t, found.RevokedOn.Valid)
+			assert.Equal(t, "2024-09-30", found.RevokedOn.Time.Format("2006-01-02"))
+			assert.False(t, found.ExpiredOn.Valid)
+
+			_, err = b.issuers.GetInvoiceIssuer(ctx, "T5300001000006")
+			require.NoError(t, err)
+			_, err = b.issuers.GetInvoiceIssuer(ctx, "T8200001000005")
+			assert.ErrorIs(t, err, repository.ErrNotFound)
+		})
+	}
+}
+
+// TestReportRepository_UnregisteredIssuers checks which invoices are flagged, by the registration of their client
+// on the issue date
+func TestReportRepository_UnregisteredIssuers(t *testing.T) {
+	for _, b := range backends(t) {
+		t.Run(b.name, func(t *testing.T) {
+			ctx := context.Background()
+			companyID, unknownClient := b.parents(t)
+			otherCompany, otherClient := b.parents(t)
+
+			require.NoError(t, b.issuers.UpsertInvoiceIssuers(ctx, []*models.InvoiceIssuer{
+				{RegistrationNumber: "T2010401000001", Name: "revoked", RegisteredOn: date("2023-10-01"),
+					RevokedOn: null.TimeFrom(date("2024-06-30"))},
+				{RegistrationNumber: "T4010001000003", Name: "expired", RegisteredOn: date("2023-10-01"),
+					ExpiredOn: null.TimeFrom(date("2024-06-30"))},
+				{RegistrationNumber: "T5300001000006", Name: "registered", RegisteredOn: date("2024-04-01")},
+			}))
+			client := func(number string) int64 {
+				c := &models.Client{CompanyID: companyID, Name: number, RegistrationNumber: null.StringFrom(number)}
+				require.NoError(t, b.clients.CreateClient(ctx, c))
+				return c.ID
+			}
+			revoked, expired, registered, unregistered :=
+				client("T2010401000001"), client("T4010001000003"), client("T5300001000006"), client("T7123456789012")
+
+			create := func(companyID, clientID int64, issue string) {
+				invoice := newInvoice(companyID, clientID, date(issue).AddDate(0, 0, 30), 10000)
+				require.NoError(t, b.invoices.CreateInvoice(ctx, invoice))
+			}
+			create(companyID, revoked, "2024-06-29")      // registered
+			create(companyID, revoked, "2024-06-30")      // revoked on the day
+			create(companyID, expired, "2024-07-01")      // expired
+			create(companyID, registered, "2024-03-31")   // not yet registered
+			create(companyID, registered, "2024-04-01")   // registered on the day
+			create(companyID, unregistered, "2024-05-01") // never imported
+			create(companyID, unknownClient, "2024-05-02")
+			create(companyID, unknownClient, "2023-12-31") // before the report
+			create(otherCompany, otherClient, "2024-05-03")
+
+			checks, err := b.reports.GetUnregisteredIssuerInvoices(ctx, repository.IssuerFilter{
+				CompanyID: companyID, From: date("2024-01-01"), To: date("2024-12-31"),
+			})
+			require.NoError(t, err)
+			var flagged []string
+			for _, check := range checks {
+				status := "no issuer"
+				if check.Issuer != nil {
+					status = check.Issuer.Name
+				}
+				flagged = append(flagged, check.IssueDate.Format("2006-01-02")+" "+check.RegistrationNumber+" "+status)
+			}
+			assert.Equal(t, []string{
+				"2024-03-31 T5300001000006 registered",
+				"2024-05-01 T7123456789012 no issuer",
+				"2024-05-02  no issuer",
+				"2024-06-30 T2010401000001 revoked",
+				"2024-07-01 T4010001000003 expired",
+			}, flagged)
+			require.NotEmpty(t, checks)
+			assert.Equal(t, "10440.00", checks[0].TotalAmount.String())
+			assert.Equal(t, "T5300001000006", checks[0].ClientName)
+
+			// Every company
+			checks, err = b.reports.GetUnregisteredIssuerInvoices(ctx, repository.IssuerFilter{
+				From: date("2024-05-03"), To: date("2024-05-03"),
+			})
+			require.NoError(t, err)
+			require.Len(t, checks, 1)
+			assert.Equal(t, otherCompany, checks[0].CompanyID)
+		})
+	}
+}
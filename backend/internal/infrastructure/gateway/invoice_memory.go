@@ -0,0 +1,433 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/ericlagergren/decimal"
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/log"
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/trace"
+	"github.com/volatiletech/sqlboiler/v4/types"
+
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+	"github.com/niko-cb/uct/internal/domain/repository"
+	"github.com/niko-cb/uct/internal/infrastructure/persistent/memory"
+)
+
+var _ repository.InvoiceRepository = &invoiceMemoryGateway{}
+
+// invoiceMemoryGateway stores invoices in the in-memory store, mimicking the MySQL schema:
+// auto-increment ids, foreign keys, DATE columns and DECIMAL(15,2) amounts
+type invoiceMemoryGateway struct {
+	store *memory.Store
+}
+
+func NewInvoiceMemoryGateway(store *memory.Store) repository.InvoiceRepository {
+	return &invoiceMemoryGateway{
+		store: store,
+	}
+}
+
+func (g *invoiceMemoryGateway) CreateInvoice(ctx context.Context, invoice *models.Invoice) (err error) {
+	ctx, span := trace.Start(ctx, "InvoiceMemoryGateway.CreateInvoice")
+	defer trace.End(span, &err)
+
+	err = g.store.Write(ctx, func(t *memory.Tables) error {
+		if _, ok := t.Companies[invoice.CompanyID]; !ok {
+			return fmt.Errorf("%w: invoices.company_id %d", memory.ErrForeignKey, invoice.CompanyID)
+		}
+		if _, ok := t.Clients[invoice.ClientID]; !ok {
+			return fmt.Errorf("%w: invoices.client_id %d", memory.ErrForeignKey, invoice.ClientID)
+		}
+		if _, ok := t.Invoices[invoice.ID]; ok {
+			return fmt.Errorf("%w: invoices.id %d", memory.ErrDuplicateKey, invoice.ID)
+		}
+
+		invoice.ID = g.store.ID(memory.TableInvoices, invoice.ID)
+		invoice.Version = 1
+		t.Invoices[invoice.ID] = storedInvoice(invoice)
+		g.saveDetails(t, invoice)
+		return nil
+	})
+	if err != nil {
+		log.Error(ctx, fmt.Errorf(fmt.Sprintf("failed to insert invoice into database: %+v", err)))
+		return err
+	}
+
+	return nil
+}
+
+func (g *invoiceMemoryGateway) GetInvoicesByDateRange(ctx context.Context, from, to time.Time, page repository.Page) (_ []*models.Invoice, err error) {
+	ctx, span := trace.Start(ctx, "InvoiceMemoryGateway.GetInvoicesByDateRange")
+	defer trace.End(span, &err)
+
+	var invoices []*models.Invoice
+	err = g.store.Read(ctx, func(t *memory.Tables) error {
+		for _, invoice := range t.Invoices {
+			if !invoice.DueDate.Before(from) && !invoice.DueDate.After(to) && invoice.ID > page.AfterID {
+				invoices = append(invoices, copyInvoice(invoice))
+			}
+		}
+		loadInvoiceDetails(t, invoices)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Rows are ordered by primary key, like the SQL gateways do
+	sort.Slice(invoices, func(i, j int) bool { return invoices[i].ID < invoices[j].ID })
+	if page.Limit > 0 && len(invoices) > page.Limit {
+		invoices = invoices[:page.Limit]
+	}
+	return invoices, nil
+}
+
+func (g *invoiceMemoryGateway) GetInvoicesByInstallmentDateRange(ctx context.Context, from, to time.Time, page repository.Page) (_ []*models.Invoice, err error) {
+	ctx, span := trace.Start(ctx, "InvoiceMemoryGateway.GetInvoicesByInstallmentDateRange")
+	defer trace.End(span, &err)
+
+	var invoices []*models.Invoice
+	err = g.store.Read(ctx, func(t *memory.Tables) error {
+		due := map[int64]bool{}
+		for _, installment := range t.Installments {
+			if !installment.DueDate.Before(from) && !installment.DueDate.After(to) {
+				due[installment.InvoiceID] = true
+			}
+		}
+		for id := range due {
+			if id > page.AfterID {
+				invoices = append(invoices, copyInvoice(t.Invoices[id]))
+			}
+		}
+		loadInvoiceDetails(t, invoices)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(invoices, func(i, j int) bool { return invoices[i].ID < invoices[j].ID })
+	if page.Limit > 0 && len(invoices) > page.Limit {
+		invoices = invoices[:page.Limit]
+	}
+	return invoices, nil
+}
+
+func (g *invoiceMemoryGateway) GetInvoice(ctx context.Context, id int64) (_ *models.Invoice, err error) {
+	ctx, span := trace.Start(ctx, "InvoiceMemoryGateway.GetInvoice")
+	defer trace.End(span, &err)
+
+	var invoice *models.Invoice
+	err = g.store.Read(ctx, func(t *memory.Tables) error {
+		stored, ok := t.Invoices[id]
+		if !ok {
+			return repository.ErrNotFound
+		}
+		invoice = copyInvoice(stored)
+		loadInvoiceDetails(t, []*models.Invoice{invoice})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return invoice, nil
+}
+
+func (g *invoiceMemoryGateway) UpdateInvoice(ctx context.Context, invoice *models.Invoice) (err error) {
+	ctx, span := trace.Start(ctx, "InvoiceMemoryGateway.UpdateInvoice")
+	defer trace.End(span, &err)
+
+	err = g.store.Write(ctx, func(t *memory.Tables) error {
+		if err := checkInvoiceVersion(t, invoice.ID, invoice.Version); err != nil {
+			return err
+		}
+		if _, ok := t.Companies[invoice.CompanyID]; !ok {
+			return fmt.Errorf("%w: invoices.company_id %d", memory.ErrForeignKey, invoice.CompanyID)
+		}
+		if _, ok := t.Clients[invoice.ClientID]; !ok {
+			return fmt.Errorf("%w: invoices.client_id %d", memory.ErrForeignKey, invoice.ClientID)
+		}
+
+		stored := storedInvoice(invoice)
+		stored.Version++
+		t.Invoices[invoice.ID] = stored
+		g.saveDetails(t, invoice)
+		return nil
+	})
+	if err != nil {
+		log.Error(ctx, fmt.Errorf("failed to update invoice: %+v", err))
+		return err
+	}
+
+	invoice.Version++
+	return nil
+}
+
+func (g *invoiceMemoryGateway) UpdateInvoiceStatus(ctx context.Context, id int64, version int64, status string) (err error) {
+	ctx, span := trace.Start(ctx, "InvoiceMemoryGateway.UpdateInvoiceStatus")
+	defer trace.End(span, &err)
+
+	err = g.store.Write(ctx, func(t *memory.Tables) error {
+		if err := checkInvoiceVersion(t, id, version); err != nil {
+			return err
+		}
+		stored := t.Invoices[id]
+		stored.Status = status
+		stored.Version++
+		t.Invoices[id] = stored
+		return nil
+	})
+	if err != nil {
+		log.Error(ctx, fmt.Errorf("failed to update invoice status: %+v", err))
+		return err
+	}
+
+	return nil
+}
+
+func (g *invoiceMemoryGateway) DeleteInvoice(ctx context.Context, id int64, version int64) (err error) {
+	ctx, span := trace.Start(ctx, "InvoiceMemoryGateway.DeleteInvoice")
+	defer trace.End(span, &err)
+
+	err = g.store.Write(ctx, func(t *memory.Tables) error {
+		if err := checkInvoiceVersion(t, id, version); err != nil {
+			return err
+		}
+		delete(t.Invoices, id)
+		deleteInvoiceDetails(t, id)
+		for creditNoteID, creditNote := range t.CreditNotes {
+			if creditNote.InvoiceID == id {
+				delete(t.CreditNotes, creditNoteID)
+			}
+		}
+		for paymentID, payment := range t.Payments {
+			if payment.InvoiceID == id {
+				delete(t.Payments, paymentID)
+			}
+		}
+		for attachmentID, attachment := range t.Attachments {
+			if attachment.InvoiceID == id {
+				delete(t.Attachments, attachmentID)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.Error(ctx, fmt.Errorf("failed to delete invoice: %+v", err))
+		return err
+	}
+
+	return nil
+}
+
+// saveDetails replaces the items, the tax subtotals and the installments of the invoice by the ones it holds
+func (g *invoiceMemoryGateway) saveDetails(t *memory.Tables, invoice *models.Invoice) {
+	deleteInvoiceDetails(t, invoice.ID)
+	invoiceItemRows(invoice) // numbers the lines
+	taxSubtotalRows(invoice) // sets the invoice id
+	installmentRows(invoice) // numbers the installments
+	for _, item := range invoice.R.GetInvoiceItems() {
+		item.ID = g.store.ID(memory.TableInvoiceItems, 0)
+		t.InvoiceItems[item.ID] = storedInvoiceItem(item)
+	}
+	for _, subtotal := range invoice.R.GetInvoiceTaxSubtotals() {
+		subtotal.ID = g.store.ID(memory.TableTaxSubtotals, 0)
+		t.TaxSubtotals[subtotal.ID] = storedTaxSubtotal(subtotal)
+	}
+	for _, installment := range invoice.R.GetInvoiceInstallments() {
+		installment.ID = g.store.ID(memory.TableInstallments, 0)
+		t.Installments[installment.ID] = storedInstallment(installment)
+	}
+}
+
+// deleteInvoiceDetails deletes the items, the tax subtotals and the installments of an invoice, as the foreign keys cascade
+func deleteInvoiceDetails(t *memory.Tables, invoiceID int64) {
+	for id, item := range t.InvoiceItems {
+		if item.InvoiceID == invoiceID {
+			delete(t.InvoiceItems, id)
+		}
+	}
+	for id, subtotal := range t.TaxSubtotals {
+		if subtotal.InvoiceID == invoiceID {
+			delete(t.TaxSubtotals, id)
+		}
+	}
+	for id, installment := range t.Installments {
+		if installment.InvoiceID == invoiceID {
+			delete(t.Installments, id)
+		}
+	}
+}
+
+// loadInvoiceDetails sets their items, ordered by line, their tax subtotals, their installments, ordered by number,
+// their credit notes and their payments to the invoices
+func loadInvoiceDetails(t *memory.Tables, invoices []*models.Invoice) {
+	ids := make(map[int64]bool, len(invoices))
+	for _, invoice := range invoices {
+		ids[invoice.ID] = true
+	}
+	var items models.InvoiceItemSlice
+	for _, item := range t.InvoiceItems {
+		if ids[item.InvoiceID] {
+			items = append(items, copyInvoiceItem(item))
+		}
+	}
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].InvoiceID != items[j].InvoiceID {
+			return items[i].InvoiceID < items[j].InvoiceID
+		}
+		return items[i].LineNo < items[j].LineNo
+	})
+	var subtotals models.InvoiceTaxSubtotalSlice
+	for _, subtotal := range t.TaxSubtotals {
+		if ids[subtotal.InvoiceID] {
+			subtotals = append(subtotals, copyTaxSubtotal(subtotal))
+		}
+	}
+	sort.Slice(subtotals, func(i, j int) bool { return subtotals[i].ID < subtotals[j].ID })
+	var installments models.InvoiceInstallmentSlice
+	for _, installment := range t.Installments {
+		if ids[installment.InvoiceID] {
+			installments = append(installments, copyInstallment(installment))
+		}
+	}
+	sort.Slice(installments, func(i, j int) bool {
+		if installments[i].InvoiceID != installments[j].InvoiceID {
+			return installments[i].InvoiceID < installments[j].InvoiceID
+		}
+		return installments[i].InstallmentNo < installments[j].InstallmentNo
+	})
+	var creditNotes models.CreditNoteSlice
+	for _, creditNote := range t.CreditNotes {
+		if ids[creditNote.InvoiceID] {
+			creditNotes = append(creditNotes, copyCreditNote(creditNote))
+		}
+	}
+	sort.Slice(creditNotes, func(i, j int) bool { return creditNotes[i].ID < creditNotes[j].ID })
+	var payments models.InvoicePaymentSlice
+	for _, payment := range t.Payments {
+		if ids[payment.InvoiceID] {
+			payments = append(payments, copyPayment(payment))
+		}
+	}
+	sort.Slice(payments, func(i, j int) bool { return payments[i].ID < payments[j].ID })
+	attachInvoiceDetails(invoices, items, subtotals, installments, creditNotes, payments)
+}
+
+// storedInvoiceItem converts the item the way MySQL stores it: a DECIMAL(15,3) quantity and DECIMAL(15,2) prices
+func storedInvoiceItem(item *models.InvoiceItem) models.InvoiceItem {
+	stored := *copyInvoiceItem(*item)
+	stored.Quantity = roundDecimal(stored.Quantity, 3)
+	stored.UnitPrice = toDecimal152(stored.UnitPrice)
+	stored.Amount = toDecimal152(stored.Amount)
+	return stored
+}
+
+// copyInvoiceItem copies an item, including its amounts which are pointers
+func copyInvoiceItem(item models.InvoiceItem) *models.InvoiceItem {
+	item.Quantity = copyDecimal(item.Quantity)
+	item.UnitPrice = copyDecimal(item.UnitPrice)
+	item.Amount = copyDecimal(item.Amount)
+	item.R = nil
+	return &item
+}
+
+// storedTaxSubtotal converts the tax subtotal the way MySQL stores it, with DECIMAL(15,2) amounts
+func storedTaxSubtotal(subtotal *models.InvoiceTaxSubtotal) models.InvoiceTaxSubtotal {
+	stored := *copyTaxSubtotal(*subtotal)
+	stored.Amount = toDecimal152(stored.Amount)
+	stored.TaxAmount = toDecimal152(stored.TaxAmount)
+	return stored
+}
+
+// copyTaxSubtotal copies a tax subtotal, including its amounts which are pointers
+func copyTaxSubtotal(subtotal models.InvoiceTaxSubtotal) *models.InvoiceTaxSubtotal {
+	subtotal.Amount = copyDecimal(subtotal.Amount)
+	subtotal.TaxAmount = copyDecimal(subtotal.TaxAmount)
+	subtotal.R = nil
+	return &subtotal
+}
+
+// storedInstallment converts the installment the way MySQL stores it: a date without time and a DECIMAL(15,2) amount
+func storedInstallment(installment *models.InvoiceInstallment) models.InvoiceInstallment {
+	stored := *copyInstallment(*installment)
+	stored.DueDate = toDate(stored.DueDate)
+	stored.Amount = toDecimal152(stored.Amount)
+	return stored
+}
+
+// copyInstallment copies an installment, including its amount which is a pointer
+func copyInstallment(installment models.InvoiceInstallment) *models.InvoiceInstallment {
+	installment.Amount = copyDecimal(installment.Amount)
+	installment.R = nil
+	return &installment
+}
+
+// checkInvoiceVersion returns ErrNotFound or ErrVersionConflict unless the invoice exists with the given version
+func checkInvoiceVersion(t *memory.Tables, id int64, version int64) error {
+	stored, ok := t.Invoices[id]
+	if !ok {
+		return repository.ErrNotFound
+	}
+	if stored.Version != version {
+		return repository.ErrVersionConflict
+	}
+	return nil
+}
+
+// storedInvoice converts the invoice the way MySQL stores it: dates without time, amounts rounded to 2 decimals
+// and the rate to 8. The defaults of the currency and the rate are set on the invoice, as MySQL returns them
+func storedInvoice(invoice *models.Invoice) models.Invoice {
+	invoiceDefaults(invoice)
+	stored := *copyInvoice(*invoice)
+	stored.IssueDate = toDate(stored.IssueDate)
+	stored.DueDate = toDate(stored.DueDate)
+	stored.PaymentAmount = toDecimal152(stored.PaymentAmount)
+	stored.FeeAmount = toDecimal152(stored.FeeAmount)
+	stored.TaxAmount = toDecimal152(stored.TaxAmount)
+	stored.TotalAmount = toDecimal152(stored.TotalAmount)
+	stored.FXRate = roundDecimal(stored.FXRate, 8)
+	return stored
+}
+
+// copyInvoice copies an invoice, including its amounts which are pointers
+func copyInvoice(invoice models.Invoice) *models.Invoice {
+	invoice.PaymentAmount = copyDecimal(invoice.PaymentAmount)
+	invoice.FeeAmount = copyDecimal(invoice.FeeAmount)
+	invoice.TaxAmount = copyDecimal(invoice.TaxAmount)
+	invoice.TotalAmount = copyDecimal(invoice.TotalAmount)
+	invoice.FXRate = copyDecimal(invoice.FXRate)
+	invoice.R = nil
+	return &invoice
+}
+
+func copyDecimal(d types.Decimal) types.Decimal {
+	if d.Big == nil {
+		return d
+	}
+	return types.NewDecimal(new(decimal.Big).Copy(d.Big))
+}
+
+// toDate truncates t to a DATE, as returned by the MySQL driver with parseTime
+func toDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// toDecimal152 rounds d to 2 decimals, half away from zero like MySQL does for DECIMAL(15,2)
+func toDecimal152(d types.Decimal) types.Decimal {
+	return roundDecimal(d, 2)
+}
+
+// roundDecimal rounds d to scale decimals, half away from zero like MySQL does for DECIMAL columns
+func roundDecimal(d types.Decimal, scale int) types.Decimal {
+	if d.Big == nil {
+		return d
+	}
+	rounded := new(decimal.Big).Copy(d.Big)
+	rounded.Context.RoundingMode = decimal.ToNearestAway
+	return types.NewDecimal(rounded.Quantize(scale))
+}
@@ -0,0 +1,290 @@
+package gateway
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/log"
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/trace"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+	"github.com/niko-cb/uct/internal/domain/repository"
+	"github.com/niko-cb/uct/internal/infrastructure/persistent/postgres"
+)
+
+var _ repository.InvoiceRepository = &invoicePostgresGateway{}
+
+// invoicePostgresGateway stores invoices in Postgres. The sqlboiler models are generated for MySQL,
+// so queries are written by hand and only bound to the models
+type invoicePostgresGateway struct {
+	client *postgres.PostgresClient
+}
+
+func NewInvoicePostgresGateway(client *postgres.PostgresClient) repository.InvoiceRepository {
+	return &invoicePostgresGateway{
+		client: client,
+	}
+}
+
+func (g *invoicePostgresGateway) CreateInvoice(ctx context.Context, invoice *models.Invoice) (err error) {
+	ctx, span := trace.Start(ctx, "InvoicePostgresGateway.CreateInvoice")
+	defer trace.End(span, &err)
+
+	invoiceDefaults(invoice)
+
+	// The id is only set explicitly if given, like boil.Infer() does with MySQL
+	query := `INSERT INTO invoices (company_id, client_id, issue_date, due_date, payment_amount, fee_amount, tax_amount, total_amount, status,
+		currency, fx_rate) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11) RETURNING id, version`
+	args := []interface{}{invoice.CompanyID, invoice.ClientID, invoice.IssueDate, invoice.DueDate,
+		invoice.PaymentAmount, invoice.FeeAmount, invoice.TaxAmount, invoice.TotalAmount, invoice.Status, invoice.Currency, invoice.FXRate}
+	if invoice.ID != 0 {
+		query = `INSERT INTO invoices (id, company_id, client_id, issue_date, due_date, payment_amount, fee_amount, tax_amount, total_amount, status,
+		currency, fx_rate) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12) RETURNING id, version`
+		args = append([]interface{}{invoice.ID}, args...)
+	}
+
+	err = g.client.Executor(ctx).QueryRowContext(ctx, query, args...).Scan(&invoice.ID, &invoice.Version)
+	if err != nil {
+		log.Error(ctx, fmt.Errorf(fmt.Sprintf("failed to insert invoice into database: %+v", err)))
+		return err
+	}
+
+	err = g.saveDetails(ctx, g.client.Executor(ctx), invoice)
+	if err != nil {
+		log.Error(ctx, fmt.Errorf("failed to insert invoice details into database: %+v", err))
+		return err
+	}
+
+	return nil
+}
+
+func (g *invoicePostgresGateway) GetInvoicesByDateRange(ctx context.Context, from, to time.Time, page repository.Page) (_ []*models.Invoice, err error) {
+	ctx, span := trace.Start(ctx, "InvoicePostgresGateway.GetInvoicesByDateRange")
+	defer trace.End(span, &err)
+
+	query := `SELECT * FROM invoices WHERE due_date >= $1 AND due_date <= $2 AND id > $3 ORDER BY id`
+	args := []interface{}{from, to, page.AfterID}
+	if page.Limit > 0 {
+		query += ` LIMIT $4`
+		args = append(args, page.Limit)
+	}
+
+	var invoices []*models.Invoice
+	err = queries.Raw(query, args...).Bind(ctx, g.client.Reader(ctx), &invoices)
+	if err != nil {
+		return nil, err
+	}
+	if err := g.loadDetails(ctx, invoices); err != nil {
+		return nil, err
+	}
+
+	return invoices, nil
+}
+
+func (g *invoicePostgresGateway) GetInvoicesByInstallmentDateRange(ctx context.Context, from, to time.Time, page repository.Page) (_ []*models.Invoice, err error) {
+	ctx, span := trace.Start(ctx, "InvoicePostgresGateway.GetInvoicesByInstallmentDateRange")
+	defer trace.End(span, &err)
+
+	query := `SELECT * FROM invoices WHERE EXISTS (SELECT 1 FROM invoice_installments ii WHERE ii.invoice_id = invoices.id
+		AND ii.due_date >= $1 AND ii.due_date <= $2) AND id > $3 ORDER BY id`
+	args := []interface{}{from, to, page.AfterID}
+	if page.Limit > 0 {
+		query += ` LIMIT $4`
+		args = append(args, page.Limit)
+	}
+
+	var invoices []*models.Invoice
+	err = queries.Raw(query, args...).Bind(ctx, g.client.Reader(ctx), &invoices)
+	if err != nil {
+		return nil, err
+	}
+	if err := g.loadDetails(ctx, invoices); err != nil {
+		return nil, err
+	}
+
+	return invoices, nil
+}
+
+func (g *invoicePostgresGateway) GetInvoice(ctx context.Context, id int64) (_ *models.Invoice, err error) {
+	ctx, span := trace.Start(ctx, "InvoicePostgresGateway.GetInvoice")
+	defer trace.End(span, &err)
+
+	invoice := &models.Invoice{}
+	err = queries.Raw(`SELECT * FROM invoices WHERE id = $1`, id).Bind(ctx, g.client.Reader(ctx), invoice)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repository.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := g.loadDetails(ctx, []*models.Invoice{invoice}); err != nil {
+		return nil, err
+	}
+
+	return invoice, nil
+}
+
+func (g *invoicePostgresGateway) UpdateInvoice(ctx context.Context, invoice *models.Invoice) (err error) {
+	ctx, span := trace.Start(ctx, "InvoicePostgresGateway.UpdateInvoice")
+	defer trace.End(span, &err)
+
+	invoiceDefaults(invoice)
+
+	// The version check and the update are a single statement, so a concurrent update can't slip in between
+	exec := g.client.Executor(ctx)
+	result, err := exec.ExecContext(ctx,
+		`UPDATE invoices SET company_id = $1, client_id = $2, issue_date = $3, due_date = $4, payment_amount = $5,
+		fee_amount = $6, tax_amount = $7, total_amount = $8, status = $9, currency = $10, fx_rate = $11, version = version + 1
+		WHERE id = $12 AND version = $13`,
+		invoice.CompanyID, invoice.ClientID, invoice.IssueDate, invoice.DueDate, invoice.PaymentAmount,
+		invoice.FeeAmount, invoice.TaxAmount, invoice.TotalAmount, invoice.Status, invoice.Currency, invoice.FXRate,
+		invoice.ID, invoice.Version)
+	if err != nil {
+		log.Error(ctx, fmt.Errorf("failed to update invoice: %+v", err))
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return g.missingInvoice(ctx, exec, invoice.ID)
+	}
+
+	err = g.saveDetails(ctx, exec, invoice)
+	if err != nil {
+		log.Error(ctx, fmt.Errorf("failed to update invoice details: %+v", err))
+		return err
+	}
+
+	invoice.Version++
+	return nil
+}
+
+func (g *invoicePostgresGateway) UpdateInvoiceStatus(ctx context.Context, id int64, version int64, status string) (err error) {
+	ctx, span := trace.Start(ctx, "InvoicePostgresGateway.UpdateInvoiceStatus")
+	defer trace.End(span, &err)
+
+	exec := g.client.Executor(ctx)
+	result, err := exec.ExecContext(ctx, `UPDATE invoices SET status = $1, version = version + 1 WHERE id = $2 AND version = $3`,
+		status, id, version)
+	if err != nil {
+		log.Error(ctx, fmt.Errorf("failed to update invoice status: %+v", err))
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return g.missingInvoice(ctx, exec, id)
+	}
+
+	return nil
+}
+
+func (g *invoicePostgresGateway) DeleteInvoice(ctx context.Context, id int64, version int64) (err error) {
+	ctx, span := trace.Start(ctx, "InvoicePostgresGateway.DeleteInvoice")
+	defer trace.End(span, &err)
+
+	exec := g.client.Executor(ctx)
+	result, err := exec.ExecContext(ctx, `DELETE FROM invoices WHERE id = $1 AND version = $2`, id, version)
+	if err != nil {
+		log.Error(ctx, fmt.Errorf("failed to delete invoice: %+v", err))
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return g.missingInvoice(ctx, exec, id)
+	}
+
+	return nil
+}
+
+// saveDetails replaces the items, the tax subtotals and the installments of an invoice by the ones it holds
+func (g *invoicePostgresGateway) saveDetails(ctx context.Context, exec boil.ContextExecutor, invoice *models.Invoice) error {
+	_, err := exec.ExecContext(ctx, `DELETE FROM invoice_items WHERE invoice_id = $1`, invoice.ID)
+	if err != nil {
+		return err
+	}
+	_, err = exec.ExecContext(ctx, `DELETE FROM invoice_tax_subtotals WHERE invoice_id = $1`, invoice.ID)
+	if err != nil {
+		return err
+	}
+	_, err = exec.ExecContext(ctx, `DELETE FROM invoice_installments WHERE invoice_id = $1`, invoice.ID)
+	if err != nil {
+		return err
+	}
+	err = insertRows(ctx, exec, models.TableNames.InvoiceItems, invoiceItemColumns, invoiceItemRows(invoice), true)
+	if err != nil {
+		return err
+	}
+	err = insertRows(ctx, exec, models.TableNames.InvoiceTaxSubtotals, taxSubtotalColumns, taxSubtotalRows(invoice), true)
+	if err != nil {
+		return err
+	}
+	return insertRows(ctx, exec, models.TableNames.InvoiceInstallments, installmentColumns, installmentRows(invoice), true)
+}
+
+// loadDetails loads the items, the tax subtotals, the installments, the credit notes and the payments of the invoices,
+// a query for each
+func (g *invoicePostgresGateway) loadDetails(ctx context.Context, invoices []*models.Invoice) error {
+	if len(invoices) == 0 {
+		return nil
+	}
+	ids := pq.Array(invoiceIDs(invoices))
+	var items models.InvoiceItemSlice
+	err := queries.Raw(`SELECT * FROM invoice_items WHERE invoice_id = ANY($1) ORDER BY invoice_id, line_no`, ids).
+		Bind(ctx, g.client.Reader(ctx), &items)
+	if err != nil {
+		return err
+	}
+	var subtotals models.InvoiceTaxSubtotalSlice
+	err = queries.Raw(`SELECT * FROM invoice_tax_subtotals WHERE invoice_id = ANY($1) ORDER BY invoice_id, id`, ids).
+		Bind(ctx, g.client.Reader(ctx), &subtotals)
+	if err != nil {
+		return err
+	}
+	var installments models.InvoiceInstallmentSlice
+	err = queries.Raw(`SELECT * FROM invoice_installments WHERE invoice_id = ANY($1) ORDER BY invoice_id, installment_no`, ids).
+		Bind(ctx, g.client.Reader(ctx), &installments)
+	if err != nil {
+		return err
+	}
+	var creditNotes models.CreditNoteSlice
+	err = queries.Raw(`SELECT * FROM credit_notes WHERE invoice_id = ANY($1) ORDER BY invoice_id, id`, ids).
+		Bind(ctx, g.client.Reader(ctx), &creditNotes)
+	if err != nil {
+		return err
+	}
+	var payments models.InvoicePaymentSlice
+	err = queries.Raw(`SELECT * FROM invoice_payments WHERE invoice_id = ANY($1) ORDER BY invoice_id, id`, ids).
+		Bind(ctx, g.client.Reader(ctx), &payments)
+	if err != nil {
+		return err
+	}
+	attachInvoiceDetails(invoices, items, subtotals, installments, creditNotes, payments)
+	return nil
+}
+
+// missingInvoice tells why no row matched an id and a version: the invoice is gone or its version changed
+func (g *invoicePostgresGateway) missingInvoice(ctx context.Context, exec boil.ContextExecutor, id int64) error {
+	var exists bool
+	err := exec.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM invoices WHERE id = $1)`, id).Scan(&exists)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return repository.ErrNotFound
+	}
+	return repository.ErrVersionConflict
+}
@@ -0,0 +1,430 @@
+package gateway_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/niko-cb/uct/internal/conversion"
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+	"github.com/niko-cb/uct/internal/domain/repository"
+	"github.com/niko-cb/uct/internal/infrastructure/gateway"
+	"github.com/niko-cb/uct/internal/infrastructure/persistent/memory"
+	"github.com/niko-cb/uct/internal/infrastructure/persistent/migration"
+	"github.com/niko-cb/uct/internal/infrastructure/persistent/mysql"
+	"github.com/niko-cb/uct/internal/infrastructure/persistent/postgres"
+	"github.com/niko-cb/uct/internal/infrastructure/persistent/sqldb"
+	"github.com/niko-cb/uct/internal/infrastructure/web/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/sqlboiler/v4/types"
+)
+
+// backend is a storage the repositories are tested against
+type backend struct {
+	name        string
+	invoices    repository.InvoiceRepository
+	companies   repository.CompanyRepository
+	users       repository.UserRepository
+	clients     repository.ClientRepository
+	seeds       repository.SeedRepository
+	reports     repository.ReportRepository
+	fxRates     repository.FXRateRepository
+	creditNotes repository.CreditNoteRepository
+	payments    repository.PaymentRepository
+	recurring   repository.RecurringInvoiceRepository
+	attachments repository.AttachmentRepository
+	issuers     repository.InvoiceIssuerRepository
+	transaction repository.Transaction
+	// parents inserts a company and a client, returning their ids
+	parents func(t *testing.T) (companyID, clientID int64)
+}
+
+// backends returns the storages to test: the memory store always, MySQL and Postgres when
+// TEST_MYSQL_HOST and TEST_POSTGRES_HOST point to a database whose data the tests may wipe
+// (TEST_*_PORT, TEST_*_USER, TEST_*_PASS and TEST_*_NAME complete the settings)
+func backends(t *testing.T) []backend {
+	store := memory.NewStore()
+	backends := []backend{{
+		name:        config.DriverMemory,
+		invoices:    gateway.NewInvoiceMemoryGateway(store),
+		companies:   gateway.NewCompanyMemoryGateway(store),
+		users:       gateway.NewUserMemoryGateway(store),
+		clients:     gateway.NewClientMemoryGateway(store),
+		seeds:       gateway.NewSeedMemoryGateway(store),
+		reports:     gateway.NewReportMemoryGateway(store),
+		fxRates:     gateway.NewFXRateMemoryGateway(store),
+		creditNotes: gateway.NewCreditNoteMemoryGateway(store),
+		payments:    gateway.NewPaymentMemoryGateway(store),
+		recurring:   gateway.NewRecurringInvoiceMemoryGateway(store),
+		attachments: gateway.NewAttachmentMemoryGateway(store),
+		issuers:     gateway.NewInvoiceIssuerMemoryGateway(store),
+		transaction: memory.NewTransaction(store),
+		parents: func(t *testing.T) (int64, int64) {
+			companyID, clientID := store.ID(memory.TableCompanies, 0), store.ID(memory.TableClients, 0)
+			require.NoError(t, store.Write(context.Background(), func(tables *memory.Tables) error {
+				tables.Companies[companyID] = models.Company{ID: companyID, Name: "company", BaseCurrency: "JPY"}
+				tables.Clients[clientID] = models.Client{ID: clientID, CompanyID: companyID, Name: "client"}
+				return nil
+			}))
+			return companyID, clientID
+		},
+	}}
+
+	if cfg, ok := testConfig(config.DriverMySQL, "root"); ok {
+		client, cleanup, err := mysql.NewMySQLClient(cfg)
+		require.NoError(t, err)
+		t.Cleanup(cleanup)
+		migrator, err := mysql.NewMigrator(client)
+		require.NoError(t, err)
+		resetDatabase(t, client.Pool, migrator)
+
+		backends = append(backends, backend{
+			name:        config.DriverMySQL,
+			invoices:    gateway.NewInvoiceGateway(client),
+			companies:   gateway.NewCompanyGateway(client),
+			users:       gateway.NewUserGateway(client),
+			clients:     gateway.NewClientGateway(client),
+			seeds:       gateway.NewSeedGateway(client),
+			reports:     gateway.NewReportGateway(client),
+			fxRates:     gateway.NewFXRateGateway(client),
+			creditNotes: gateway.NewCreditNoteGateway(client),
+			payments:    gateway.NewPaymentGateway(client),
+			recurring:   gateway.NewRecurringInvoiceGateway(client),
+			attachments: gateway.NewAttachmentGateway(client),
+			issuers:     gateway.NewInvoiceIssuerGateway(client),
+			transaction: sqldb.NewTransaction(client.Pool),
+			parents: func(t *testing.T) (int64, int64) {
+				res, err := client.Exec("INSERT INTO companies (name) VALUES ('company')")
+				require.NoError(t, err)
+				companyID, _ := res.LastInsertId()
+				res, err = client.Exec("INSERT INTO clients (company_id, name) VALUES (?, 'client')", companyID)
+				require.NoError(t, err)
+				clientID, _ := res.LastInsertId()
+				return companyID, clientID
+			},
+		})
+	}
+
+	if cfg, ok := testConfig(config.DriverPostgres, "postgres"); ok {
+		client, cleanup, err := postgres.NewPostgresClient(cfg)
+		require.NoError(t, err)
+		t.Cleanup(cleanup)
+		migrator, err := postgres.NewMigrator(client)
+		require.NoError(t, err)
+		resetDatabase(t, client.Pool, migrator)
+
+		backends = append(backends, backend{
+			name:        config.DriverPostgres,
+			invoices:    gateway.NewInvoicePostgresGateway(client),
+			companies:   gateway.NewCompanyPostgresGateway(client),
+			users:       gateway.NewUserPostgresGateway(client),
+			clients:     gateway.NewClientPostgresGateway(client),
+			seeds:       gateway.NewSeedPostgresGateway(client),
+			reports:     gateway.NewReportPostgresGateway(client),
+			fxRates:     gateway.NewFXRatePostgresGateway(client),
+			creditNotes: gateway.NewCreditNotePostgresGateway(client),
+			payments:    gateway.NewPaymentPostgresGateway(client),
+			recurring:   gateway.NewRecurringInvoicePostgresGateway(client),
+			attachments: gateway.NewAttachmentPostgresGateway(client),
+			issuers:     gateway.NewInvoiceIssuerPostgresGateway(client),
+			transaction: sqldb.NewTransaction(client.Pool),
+			parents: func(t *testing.T) (companyID int64, clientID int64) {
+				require.NoError(t, client.QueryRow("INSERT INTO companies (name) VALUES ('company') RETURNING id").Scan(&companyID))
+				require.NoError(t, client.QueryRow("INSERT INTO clients (company_id, name) VALUES ($1, 'client') RETURNING id", companyID).Scan(&clientID))
+				return companyID, clientID
+			},
+		})
+	}
+
+	return backends
+}
+
+// testConfig returns the settings of the test database of driver, if configured
+func testConfig(driver, defaultUser string) (*config.Config, bool) {
+	prefix := "TEST_" + map[string]string{config.DriverMySQL: "MYSQL", config.DriverPostgres: "POSTGRES"}[driver] + "_"
+	host := os.Getenv(prefix + "HOST")
+	if host == "" {
+		return nil, false
+	}
+	getenv := func(key, fallback string) string {
+		if v := os.Getenv(prefix + key); v != "" {
+			return v
+		}
+		return fallback
+	}
+	return &config.Config{
+		DbDriver:          driver,
+		DbHost:            host,
+		DbPort:            getenv("PORT", ""),
+		DbUser:            getenv("USER", defaultUser),
+		DbPass:            getenv("PASS", ""),
+		DbName:            getenv("NAME", "uct_test"),
+		DbSSLMode:         "disable",
+		DbConnectAttempts: 1,
+		DbTxRetries:       3,
+	}, true
+}
+
+// resetDatabase brings the schema up to date and deletes every row
+func resetDatabase(t *testing.T, pool *sqldb.Pool, migrator *migration.Migrator) {
+	ctx := context.Background()
+	_, err := migrator.Up(ctx)
+	require.NoError(t, err)
+	for _, table := range []string{"fx_rates", "credit_notes", "invoice_tax_subtotals", "invoice_items", "invoices", "bank_accounts", "clients", "users", "companies"} {
+		_, err := pool.ExecContext(ctx, "DELETE FROM "+table)
+		require.NoError(t, err)
+	}
+}
+
+func newInvoice(companyID, clientID int64, dueDate time.Time, amount float64) *models.Invoice {
+	decimal := func(f float64) (d types.Decimal) {
+		d, _ = conversion.ConvertToDecimal(f)
+		return d
+	}
+	return &models.Invoice{
+		CompanyID:     companyID,
+		ClientID:      clientID,
+		IssueDate:     dueDate.AddDate(0, 0, -30),
+		DueDate:       dueDate,
+		PaymentAmount: decimal(amount),
+		FeeAmount:     decimal(amount * 0.04),
+		TaxAmount:     decimal(amount * 0.004),
+		TotalAmount:   decimal(amount * 1.044),
+		Status:        "unprocessed",
+	}
+}
+
+func date(s string) time.Time {
+	d, _ := time.Parse("2006-01-02", s)
+	return d
+}
+
+// TestInvoiceRepository_CreateAndList checks the insert and the inclusive date range
+func TestInvoiceRepository_CreateAndList(t *testing.T) {
+	for _, b := range backends(t) {
+		t.Run(b.name, func(t *testing.T) {
+			ctx := context.Background()
+			companyID, clientID := b.parents(t)
+
+			var ids []int64
+			for _, due := range []string{"2024-01-31", "2024-02-01", "2024-02-29", "2024-03-01"} {
+				invoice := newInvoice(companyID, clientID, date(due), 10000.125)
+				require.NoError(t, b.invoices.CreateInvoice(ctx, invoice))
+				require.NotZero(t, invoice.ID)
+				ids = append(ids, invoice.ID)
+			}
+
+			invoices, err := b.invoices.GetInvoicesByDateRange(ctx, date("2024-02-01"), date("2024-02-29"), repository.Page{})
+			require.NoError(t, err)
+			require.Len(t, invoices, 2)
+			assert.Equal(t, ids[1], invoices[0].ID)
+			assert.Equal(t, ids[2], invoices[1].ID)
+			assert.True(t, invoices[0].DueDate.Equal(date("2024-02-01")))
+			assert.Equal(t, "10000.13", invoices[0].PaymentAmount.String())
+		})
+	}
+}
+
+// TestInvoiceRepository_Items checks the items are numbered, saved and loaded with their invoice with the tax subtotals,
+// and both are replaced on update
+func TestInvoiceRepository_Items(t *testing.T) {
+	for _, b := range backends(t) {
+		t.Run(b.name, func(t *testing.T) {
+			ctx := context.Background()
+			companyID, clientID := b.parents(t)
+			due := date("2032-01-01")
+			item := func(description string, quantity, unitPrice float64, category string) *models.InvoiceItem {
+				q, _ := conversion.ConvertToDecimal(quantity)
+				p, _ := conversion.ConvertToDecimal(unitPrice)
+				a, _ := conversion.ConvertToDecimal(quantity * unitPrice)
+				return &models.InvoiceItem{Description: description, Quantity: q, UnitPrice: p, Amount: a, TaxCategory: category}
+			}
+
+			subtotal := func(category string, rate int, amount, taxAmount float64) *models.InvoiceTaxSubtotal {
+				a, _ := conversion.ConvertToDecimal(amount)
+				ta, _ := conversion.ConvertToDecimal(taxAmount)
+				return &models.InvoiceTaxSubtotal{TaxCategory: category, Rate: rate, Amount: a, TaxAmount: ta}
+			}
+
+			invoice := newInvoice(companyID, clientID, due, 1375)
+			invoice.R = invoice.R.NewStruct()
+			invoice.R.InvoiceItems = models.InvoiceItemSlice{
+				item("consulting", 1.5, 550, "standard"),
+				item("lunch boxes", 0.5, 1100, "reduced"),
+			}
+			invoice.R.InvoiceTaxSubtotals = models.InvoiceTaxSubtotalSlice{
+				subtotal("standard", 10, 825, 75),
+				subtotal("reduced", 8, 550, 40),
+			}
+			require.NoError(t, b.invoices.CreateInvoice(ctx, invoice))
+			require.NoError(t, b.invoices.CreateInvoice(ctx, newInvoice(companyID, clientID, due, 100)))
+
+			invoices, err := b.invoices.GetInvoicesByDateRange(ctx, due, due, repository.Page{})
+			require.NoError(t, err)
+			require.Len(t, invoices, 2)
+			items := invoices[0].R.GetInvoiceItems()
+			require.Len(t, items, 2)
+			assert.Equal(t, []int{1, 2}, []int{items[0].LineNo, items[1].LineNo})
+			assert.Equal(t, invoice.ID, items[1].InvoiceID)
+			assert.Equal(t, "lunch boxes", items[1].Description)
+			assert.Equal(t, "0.500", items[1].Quantity.String())
+			assert.Equal(t, "1100.00", items[1].UnitPrice.String())
+			assert.Equal(t, "550.00", items[1].Amount.String())
+			assert.Equal(t, "reduced", items[1].TaxCategory)
+			assert.NotNil(t, invoices[1].R.GetInvoiceItems())
+			assert.Empty(t, invoices[1].R.GetInvoiceItems())
+			subtotals := invoices[0].R.GetInvoiceTaxSubtotals()
+			require.Len(t, subtotals, 2)
+			assert.Equal(t, []string{"standard", "825.00", "75.00"},
+				[]string{subtotals[0].TaxCategory, subtotals[0].Amount.String(), subtotals[0].TaxAmount.String()})
+			assert.Equal(t, 8, subtotals[1].Rate)
+			assert.Empty(t, invoices[1].R.GetInvoiceTaxSubtotals())
+
+			stored, err := b.invoices.GetInvoice(ctx, invoice.ID)
+			require.NoError(t, err)
+			stored.R.InvoiceItems = models.InvoiceItemSlice{item("audit", 2, 300, "standard")}
+			stored.R.InvoiceTaxSubtotals = models.InvoiceTaxSubtotalSlice{subtotal("standard", 10, 600, 54)}
+			require.NoError(t, b.invoices.UpdateInvoice(ctx, stored))
+			stored, err = b.invoices.GetInvoice(ctx, invoice.ID)
+			require.NoError(t, err)
+			require.Len(t, stored.R.GetInvoiceItems(), 1)
+			require.Len(t, stored.R.GetInvoiceTaxSubtotals(), 1)
+			assert.Equal(t, "54.00", stored.R.InvoiceTaxSubtotals[0].TaxAmount.String())
+			assert.Equal(t, "audit", stored.R.InvoiceItems[0].Description)
+			assert.Equal(t, 1, stored.R.InvoiceItems[0].LineNo)
+
+			require.NoError(t, b.invoices.DeleteInvoice(ctx, invoice.ID, stored.Version))
+		})
+	}
+}
+
+// TestInvoiceRepository_ForeignKey checks invoices can't reference a missing client
+func TestInvoiceRepository_ForeignKey(t *testing.T) {
+	for _, b := range backends(t) {
+		t.Run(b.name, func(t *testing.T) {
+			companyID, clientID := b.parents(t)
+
+			err := b.invoices.CreateInvoice(context.Background(), newInvoice(companyID, clientID+1000, date("2024-02-01"), 100))
+
+			assert.Error(t, err)
+		})
+	}
+}
+
+// TestInvoiceRepository_Transaction checks rollbacks and savepoints behave the same on every storage
+func TestInvoiceRepository_Transaction(t *testing.T) {
+	for _, b := range backends(t) {
+		t.Run(b.name, func(t *testing.T) {
+			ctx := context.Background()
+			companyID, clientID := b.parents(t)
+			due := date("2030-06-01")
+
+			err := b.transaction.DoInTx(ctx, func(ctx context.Context) error {
+				require.NoError(t, b.invoices.CreateInvoice(ctx, newInvoice(companyID, clientID, due, 100)))
+				return errors.New("rollback")
+			})
+			require.Error(t, err)
+
+			err = b.transaction.DoInTx(ctx, func(ctx context.Context) error {
+				if err := b.invoices.CreateInvoice(ctx, newInvoice(companyID, clientID, due, 200)); err != nil {
+					return err
+				}
+				nested := b.transaction.DoInTx(ctx, func(ctx context.Context) error {
+					require.NoError(t, b.invoices.CreateInvoice(ctx, newInvoice(companyID, clientID, due, 300)))
+					return errors.New("rollback to savepoint")
+				})
+				require.Error(t, nested)
+
+				// The transaction sees its own writes
+				invoices, err := b.invoices.GetInvoicesByDateRange(ctx, due, due, repository.Page{})
+				require.NoError(t, err)
+				assert.Len(t, invoices, 1)
+				return nil
+			})
+			require.NoError(t, err)
+
+			invoices, err := b.invoices.GetInvoicesByDateRange(ctx, due, due, repository.Page{})
+			require.NoError(t, err)
+			require.Len(t, invoices, 1)
+			assert.Equal(t, "200.00", invoices[0].PaymentAmount.String())
+		})
+	}
+}
+
+// TestInvoiceRepository_Versions checks updates and deletes only apply to the expected version
+func TestInvoiceRepository_Versions(t *testing.T) {
+	for _, b := range backends(t) {
+		t.Run(b.name, func(t *testing.T) {
+			ctx := context.Background()
+			companyID, clientID := b.parents(t)
+
+			invoice := newInvoice(companyID, clientID, date("2024-02-01"), 100)
+			require.NoError(t, b.invoices.CreateInvoice(ctx, invoice))
+			assert.Equal(t, int64(1), invoice.Version)
+
+			// Two clients read version 1, the first one to save wins
+			first, err := b.invoices.GetInvoice(ctx, invoice.ID)
+			require.NoError(t, err)
+			second, err := b.invoices.GetInvoice(ctx, invoice.ID)
+			require.NoError(t, err)
+
+			first.Status = "paid"
+			require.NoError(t, b.invoices.UpdateInvoice(ctx, first))
+			assert.Equal(t, int64(2), first.Version)
+
+			second.Status = "cancelled"
+			assert.ErrorIs(t, b.invoices.UpdateInvoice(ctx, second), repository.ErrVersionConflict)
+			assert.ErrorIs(t, b.invoices.DeleteInvoice(ctx, invoice.ID, 1), repository.ErrVersionConflict)
+
+			stored, err := b.invoices.GetInvoice(ctx, invoice.ID)
+			require.NoError(t, err)
+			assert.Equal(t, "paid", stored.Status)
+			assert.Equal(t, int64(2), stored.Version)
+
+			require.NoError(t, b.invoices.DeleteInvoice(ctx, invoice.ID, 2))
+			_, err = b.invoices.GetInvoice(ctx, invoice.ID)
+			assert.ErrorIs(t, err, repository.ErrNotFound)
+			assert.ErrorIs(t, b.invoices.UpdateInvoice(ctx, stored), repository.ErrNotFound)
+			assert.ErrorIs(t, b.invoices.DeleteInvoice(ctx, invoice.ID, 2), repository.ErrNotFound)
+		})
+	}
+}
+
+// TestInvoiceRepository_Page checks the keyset pagination
+func TestInvoiceRepository_Page(t *testing.T) {
+	for _, b := range backends(t) {
+		t.Run(b.name, func(t *testing.T) {
+			ctx := context.Background()
+			companyID, clientID := b.parents(t)
+			due := date("2031-01-01")
+
+			var ids []int64
+			for i := 0; i < 5; i++ {
+				invoice := newInvoice(companyID, clientID, due, 100)
+				require.NoError(t, b.invoices.CreateInvoice(ctx, invoice))
+				ids = append(ids, invoice.ID)
+			}
+
+			var pages [][]int64
+			page := repository.Page{Limit: 2}
+			for {
+				invoices, err := b.invoices.GetInvoicesByDateRange(ctx, due, due, page)
+				require.NoError(t, err)
+				if len(invoices) == 0 {
+					break
+				}
+				var pageIDs []int64
+				for _, invoice := range invoices {
+					pageIDs = append(pageIDs, invoice.ID)
+				}
+				pages = append(pages, pageIDs)
+				page.AfterID = pageIDs[len(pageIDs)-1]
+			}
+
+			assert.Equal(t, [][]int64{ids[0:2], ids[2:4], ids[4:5]}, pages)
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/log"
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/trace"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+	"github.com/niko-cb/uct/internal/domain/repository"
+	"github.com/niko-cb/uct/internal/infrastructure/persistent/mysql"
+)
+
+var _ repository.PaymentRepository = &paymentGateway{}
+
+type paymentGateway struct {
+	client *mysql.MySQLClient
+}
+
+func NewPaymentGateway(client *mysql.MySQLClient) repository.PaymentRepository {
+	return &paymentGateway{
+		client: client,
+	}
+}
+
+func (g *paymentGateway) CreatePayment(ctx context.Context, payment *models.InvoicePayment) (err error) {
+	ctx, span := trace.Start(ctx, "PaymentGateway.CreatePayment")
+	defer trace.End(span, &err)
+
+	err = payment.Insert(ctx, g.client.Executor(ctx), boil.Infer())
+	if err != nil {
+		log.Error(ctx, fmt.Errorf("failed to insert payment into database: %+v", err))
+		return err
+	}
+
+	return nil
+}
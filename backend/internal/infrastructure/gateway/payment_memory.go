@@ -0,0 +1,65 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/log"
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/trace"
+
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+	"github.com/niko-cb/uct/internal/domain/repository"
+	"github.com/niko-cb/uct/internal/infrastructure/persistent/memory"
+)
+
+var _ repository.PaymentRepository = &paymentMemoryGateway{}
+
+// paymentMemoryGateway stores the payments in the in-memory store, mimicking the MySQL schema
+type paymentMemoryGateway struct {
+	store *memory.Store
+}
+
+func NewPaymentMemoryGateway(store *memory.Store) repository.PaymentRepository {
+	return &paymentMemoryGateway{
+		store: store,
+	}
+}
+
+func (g *paymentMemoryGateway) CreatePayment(ctx context.Context, payment *models.InvoicePayment) (err error) {
+	ctx, span := trace.Start(ctx, "PaymentMemoryGateway.CreatePayment")
+	defer trace.End(span, &err)
+
+	err = g.store.Write(ctx, func(t *memory.Tables) error {
+		if _, ok := t.Invoices[payment.InvoiceID]; !ok {
+			return fmt.Errorf("%w: invoice_payments.invoice_id %d", memory.ErrForeignKey, payment.InvoiceID)
+		}
+		if _, ok := t.Payments[payment.ID]; ok {
+			return fmt.Errorf("%w: invoice_payments.id %d", memory.ErrDuplicateKey, payment.ID)
+		}
+
+		payment.ID = g.store.ID(memory.TablePayments, payment.ID)
+		t.Payments[payment.ID] = storedPayment(payment)
+		return nil
+	})
+	if err != nil {
+		log.Error(ctx, fmt.Errorf("failed to insert payment into database: %+v", err))
+		return err
+	}
+
+	return nil
+}
+
+// storedPayment converts the payment the way MySQL stores it: a date without time and a DECIMAL(15,2) amount
+func storedPayment(payment *models.InvoicePayment) models.InvoicePayment {
+	stored := *copyPayment(*payment)
+	stored.PaymentDate = toDate(stored.PaymentDate)
+	stored.Amount = toDecimal152(stored.Amount)
+	return stored
+}
+
+// copyPayment copies a payment, including its amount which is a pointer
+func copyPayment(payment models.InvoicePayment) *models.InvoicePayment {
+	payment.Amount = copyDecimal(payment.Amount)
+	payment.R = nil
+	return &payment
+}
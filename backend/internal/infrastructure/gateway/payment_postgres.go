@@ -0,0 +1,42 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/log"
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/trace"
+
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+	"github.com/niko-cb/uct/internal/domain/repository"
+	"github.com/niko-cb/uct/internal/infrastructure/persistent/postgres"
+)
+
+var _ repository.PaymentRepository = &paymentPostgresGateway{}
+
+// paymentPostgresGateway stores the payments in Postgres, with queries written by hand like the invoices
+type paymentPostgresGateway struct {
+	client *postgres.PostgresClient
+}
+
+func NewPaymentPostgresGateway(client *postgres.PostgresClient) repository.PaymentRepository {
+	return &paymentPostgresGateway{
+		client: client,
+	}
+}
+
+func (g *paymentPostgresGateway) CreatePayment(ctx context.Context, payment *models.InvoicePayment) (err error) {
+	ctx, span := trace.Start(ctx, "PaymentPostgresGateway.CreatePayment")
+	defer trace.End(span, &err)
+
+	err = g.client.Executor(ctx).QueryRowContext(ctx,
+		`INSERT INTO invoice_payments (invoice_id, payment_date, amount, reference) VALUES ($1, $2, $3, $4) RETURNING id`,
+		payment.InvoiceID, payment.PaymentDate, payment.Amount, payment.Reference,
+	).Scan(&payment.ID)
+	if err != nil {
+		log.Error(ctx, fmt.Errorf("failed to insert payment into database: %+v", err))
+		return err
+	}
+
+	return nil
+}
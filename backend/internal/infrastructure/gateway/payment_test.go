@@ -0,0 +1,113 @@
+package gateway_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+	"github.com/niko-cb/uct/internal/domain/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestInstallmentRepository checks the installments are saved and replaced with their invoice, and select it by their
+// due dates
+func TestInstallmentRepository(t *testing.T) {
+	for _, b := range backends(t) {
+		t.Run(b.name, func(t *testing.T) {
+			ctx := context.Background()
+			companyID, clientID := b.parents(t)
+			invoice := newInvoice(companyID, clientID, date("2024-07-31"), 10000)
+			invoice.R = invoice.R.NewStruct()
+			invoice.R.InvoiceInstallments = models.InvoiceInstallmentSlice{
+				{DueDate: date("2024-07-15"), Amount: amount(5000)},
+				{DueDate: date("2024-07-31"), Amount: amount(5440)},
+			}
+			require.NoError(t, b.invoices.CreateInvoice(ctx, invoice))
+
+			listed, err := b.invoices.GetInvoicesByInstallmentDateRange(ctx, date("2024-07-15"), date("2024-07-20"), repository.Page{})
+			require.NoError(t, err)
+			require.Len(t, listed, 1)
+			installments := listed[0].R.GetInvoiceInstallments()
+			require.Len(t, installments, 2)
+			assert.Equal(t, []int{1, 2}, []int{installments[0].InstallmentNo, installments[1].InstallmentNo})
+			assert.Equal(t, "2024-07-15", installments[0].DueDate.Format("2006-01-02"))
+			assert.Equal(t, "5440.00", installments[1].Amount.String())
+			listed, err = b.invoices.GetInvoicesByInstallmentDateRange(ctx, date("2024-07-16"), date("2024-07-30"), repository.Page{})
+			require.NoError(t, err)
+			assert.Empty(t, listed)
+
+			// The installments are replaced when the invoice is updated
+			loaded, err := b.invoices.GetInvoice(ctx, invoice.ID)
+			require.NoError(t, err)
+			loaded.R.InvoiceInstallments = loaded.R.InvoiceInstallments[1:]
+			require.NoError(t, b.invoices.UpdateInvoice(ctx, loaded))
+			loaded, err = b.invoices.GetInvoice(ctx, invoice.ID)
+			require.NoError(t, err)
+			require.Len(t, loaded.R.GetInvoiceInstallments(), 1)
+			assert.Equal(t, 1, loaded.R.GetInvoiceInstallments()[0].InstallmentNo)
+			listed, err = b.invoices.GetInvoicesByInstallmentDateRange(ctx, date("2024-07-15"), date("2024-07-20"), repository.Page{})
+			require.NoError(t, err)
+			assert.Empty(t, listed)
+
+			require.NoError(t, b.invoices.DeleteInvoice(ctx, invoice.ID, loaded.Version))
+			listed, err = b.invoices.GetInvoicesByInstallmentDateRange(ctx, date("2024-07-31"), date("2024-07-31"), repository.Page{})
+			require.NoError(t, err)
+			assert.Empty(t, listed, "the installments are deleted with their invoice")
+		})
+	}
+}
+
+// TestPaymentRepository checks the payments are loaded with their invoice, kept when it is updated, subtracted by
+// the reports and deleted with it
+func TestPaymentRepository(t *testing.T) {
+	for _, b := range backends(t) {
+		t.Run(b.name, func(t *testing.T) {
+			ctx := context.Background()
+			companyID, clientID := b.parents(t)
+			invoice := newInvoice(companyID, clientID, date("2024-07-31"), 10000)
+			require.NoError(t, b.invoices.CreateInvoice(ctx, invoice))
+
+			for _, paid := range []float64{3000, 2000.004} {
+				payment := &models.InvoicePayment{InvoiceID: invoice.ID, PaymentDate: date("2024-07-15"), Amount: amount(paid), Reference: "transfer"}
+				require.NoError(t, b.payments.CreatePayment(ctx, payment))
+				require.NotZero(t, payment.ID)
+			}
+			assert.Error(t, b.payments.CreatePayment(ctx, &models.InvoicePayment{
+				InvoiceID: invoice.ID + 1, PaymentDate: date("2024-07-15"), Amount: amount(1),
+			}), "the invoice of a payment must exist")
+
+			require.NoError(t, b.invoices.UpdateInvoiceStatus(ctx, invoice.ID, 1, "partially_paid"))
+			assert.ErrorIs(t, b.invoices.UpdateInvoiceStatus(ctx, invoice.ID, 1, "paid"), repository.ErrVersionConflict)
+			assert.ErrorIs(t, b.invoices.UpdateInvoiceStatus(ctx, invoice.ID+1, 1, "paid"), repository.ErrNotFound)
+
+			// The payments are kept when the invoice is updated
+			loaded, err := b.invoices.GetInvoice(ctx, invoice.ID)
+			require.NoError(t, err)
+			assert.Equal(t, "partially_paid", loaded.Status)
+			assert.Equal(t, int64(2), loaded.Version)
+			require.NoError(t, b.invoices.UpdateInvoice(ctx, loaded))
+			loaded, err = b.invoices.GetInvoice(ctx, invoice.ID)
+			require.NoError(t, err)
+			require.Len(t, loaded.R.GetInvoicePayments(), 2)
+			assert.Equal(t, "2000.00", loaded.R.GetInvoicePayments()[1].Amount.String())
+
+			// 10440.00 - 5000.00
+			clients, err := b.reports.GetAging(ctx, date("2024-07-31"), "JPY")
+			require.NoError(t, err)
+			require.Len(t, clients, 1)
+			assert.Equal(t, "5440.00", clients[0].Total.Amount.String())
+			filter := repository.CashFlowFilter{CompanyID: companyID, BaseCurrency: "JPY", From: date("2024-07-31"), To: date("2024-07-31")}
+			flows, err := b.reports.GetCashFlows(ctx, filter)
+			require.NoError(t, err)
+			require.Len(t, flows, 1)
+			assert.Equal(t, "10000.00", flows[0].PaymentAmount.String())
+			assert.Equal(t, "5440.00", flows[0].TotalAmount.String())
+
+			require.NoError(t, b.invoices.DeleteInvoice(ctx, invoice.ID, loaded.Version))
+			clients, err = b.reports.GetAging(ctx, date("2024-07-31"), "JPY")
+			require.NoError(t, err)
+			assert.Empty(t, clients, "the invoice is deleted with its payments")
+		})
+	}
+}
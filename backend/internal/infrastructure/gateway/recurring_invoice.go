@@ -0,0 +1,125 @@
+package gateway
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/log"
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/trace"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+
+	"github.com/niko-cb/uct/internal/domain/entity"
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+	"github.com/niko-cb/uct/internal/domain/repository"
+	"github.com/niko-cb/uct/internal/infrastructure/persistent/mysql"
+)
+
+var _ repository.RecurringInvoiceRepository = &recurringInvoiceGateway{}
+
+type recurringInvoiceGateway struct {
+	client *mysql.MySQLClient
+}
+
+func NewRecurringInvoiceGateway(client *mysql.MySQLClient) repository.RecurringInvoiceRepository {
+	return &recurringInvoiceGateway{
+		client: client,
+	}
+}
+
+func (g *recurringInvoiceGateway) CreateRecurringInvoice(ctx context.Context, recurring *models.RecurringInvoice) (err error) {
+	ctx, span := trace.Start(ctx, "RecurringInvoiceGateway.CreateRecurringInvoice")
+	defer trace.End(span, &err)
+
+	recurringInvoiceDefaults(recurring)
+	err = recurring.Insert(ctx, g.client.Executor(ctx), boil.Infer())
+	if err != nil {
+		log.Error(ctx, fmt.Errorf("failed to insert recurring invoice into database: %+v", err))
+		return err
+	}
+
+	return nil
+}
+
+func (g *recurringInvoiceGateway) GetRecurringInvoice(ctx context.Context, id int64) (_ *models.RecurringInvoice, err error) {
+	ctx, span := trace.Start(ctx, "RecurringInvoiceGateway.GetRecurringInvoice")
+	defer trace.End(span, &err)
+
+	recurring, err := models.FindRecurringInvoice(ctx, g.client.Reader(ctx), id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repository.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return recurring, nil
+}
+
+func (g *recurringInvoiceGateway) GetRecurringInvoicesByClient(ctx context.Context, clientID int64) (_ []*models.RecurringInvoice, err error) {
+	ctx, span := trace.Start(ctx, "RecurringInvoiceGateway.GetRecurringInvoicesByClient")
+	defer trace.End(span, &err)
+
+	return models.RecurringInvoices(
+		models.RecurringInvoiceWhere.ClientID.EQ(clientID),
+		qm.OrderBy(models.RecurringInvoiceColumns.ID),
+	).All(ctx, g.client.Reader(ctx))
+}
+
+func (g *recurringInvoiceGateway) GetDueRecurringInvoices(ctx context.Context, asOf time.Time) (_ []*models.RecurringInvoice, err error) {
+	ctx, span := trace.Start(ctx, "RecurringInvoiceGateway.GetDueRecurringInvoices")
+	defer trace.End(span, &err)
+
+	return models.RecurringInvoices(
+		models.RecurringInvoiceWhere.Status.EQ(entity.RecurringStatusActive),
+		models.RecurringInvoiceWhere.NextDate.LTE(null.TimeFrom(asOf)),
+		qm.OrderBy(models.RecurringInvoiceColumns.ID),
+	).All(ctx, g.client.Reader(ctx))
+}
+
+func (g *recurringInvoiceGateway) UpdateRecurringInvoiceSchedule(ctx context.Context, id int64, version int64, status string, nextDate null.Time, skipped int) (err error) {
+	ctx, span := trace.Start(ctx, "RecurringInvoiceGateway.UpdateRecurringInvoiceSchedule")
+	defer trace.End(span, &err)
+
+	// The version check and the update are a single statement, like the invoices
+	exec := g.client.Executor(ctx)
+	rows, err := models.RecurringInvoices(
+		models.RecurringInvoiceWhere.ID.EQ(id),
+		models.RecurringInvoiceWhere.Version.EQ(version),
+	).UpdateAll(ctx, exec, models.M{
+		models.RecurringInvoiceColumns.Status:       status,
+		models.RecurringInvoiceColumns.NextDate:     nextDate,
+		models.RecurringInvoiceColumns.SkippedCount: skipped,
+		models.RecurringInvoiceColumns.Version:      version + 1,
+	})
+	if err != nil {
+		log.Error(ctx, fmt.Errorf("failed to update recurring invoice: %+v", err))
+		return err
+	}
+	if rows == 0 {
+		exists, err := models.RecurringInvoiceExists(ctx, exec, id)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return repository.ErrNotFound
+		}
+		return repository.ErrVersionConflict
+	}
+
+	return nil
+}
+
+// recurringInvoiceDefaults sets the column defaults of a new recurring invoice left empty: active, every period
+func recurringInvoiceDefaults(recurring *models.RecurringInvoice) {
+	if recurring.Status == "" {
+		recurring.Status = entity.RecurringStatusActive
+	}
+	if recurring.Every == 0 {
+		recurring.Every = 1
+	}
+}
@@ -0,0 +1,167 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/log"
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/trace"
+	"github.com/volatiletech/null/v8"
+
+	"github.com/niko-cb/uct/internal/domain/entity"
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+	"github.com/niko-cb/uct/internal/domain/repository"
+	"github.com/niko-cb/uct/internal/infrastructure/persistent/memory"
+)
+
+var _ repository.RecurringInvoiceRepository = &recurringInvoiceMemoryGateway{}
+
+// recurringInvoiceMemoryGateway stores the recurring invoices in the in-memory store, mimicking the MySQL schema
+type recurringInvoiceMemoryGateway struct {
+	store *memory.Store
+}
+
+func NewRecurringInvoiceMemoryGateway(store *memory.Store) repository.RecurringInvoiceRepository {
+	return &recurringInvoiceMemoryGateway{
+		store: store,
+	}
+}
+
+func (g *recurringInvoiceMemoryGateway) CreateRecurringInvoice(ctx context.Context, recurring *models.RecurringInvoice) (err error) {
+	ctx, span := trace.Start(ctx, "RecurringInvoiceMemoryGateway.CreateRecurringInvoice")
+	defer trace.End(span, &err)
+
+	err = g.store.Write(ctx, func(t *memory.Tables) error {
+		if _, ok := t.Companies[recurring.CompanyID]; !ok {
+			return fmt.Errorf("%w: recurring_invoices.company_id %d", memory.ErrForeignKey, recurring.CompanyID)
+		}
+		if _, ok := t.Clients[recurring.ClientID]; !ok {
+			return fmt.Errorf("%w: recurring_invoices.client_id %d", memory.ErrForeignKey, recurring.ClientID)
+		}
+		if _, ok := t.Recurring[recurring.ID]; ok {
+			return fmt.Errorf("%w: recurring_invoices.id %d", memory.ErrDuplicateKey, recurring.ID)
+		}
+
+		recurring.ID = g.store.ID(memory.TableRecurring, recurring.ID)
+		recurring.Version = 1
+		recurringInvoiceDefaults(recurring)
+		t.Recurring[recurring.ID] = storedRecurringInvoice(recurring)
+		return nil
+	})
+	if err != nil {
+		log.Error(ctx, fmt.Errorf("failed to insert recurring invoice into database: %+v", err))
+		return err
+	}
+
+	return nil
+}
+
+func (g *recurringInvoiceMemoryGateway) GetRecurringInvoice(ctx context.Context, id int64) (_ *models.RecurringInvoice, err error) {
+	ctx, span := trace.Start(ctx, "RecurringInvoiceMemoryGateway.GetRecurringInvoice")
+	defer trace.End(span, &err)
+
+	var recurring *models.RecurringInvoice
+	err = g.store.Read(ctx, func(t *memory.Tables) error {
+		stored, ok := t.Recurring[id]
+		if !ok {
+			return repository.ErrNotFound
+		}
+		recurring = copyRecurringInvoice(stored)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return recurring, nil
+}
+
+func (g *recurringInvoiceMemoryGateway) GetRecurringInvoicesByClient(ctx context.Context, clientID int64) (_ []*models.RecurringInvoice, err error) {
+	ctx, span := trace.Start(ctx, "RecurringInvoiceMemoryGateway.GetRecurringInvoicesByClient")
+	defer trace.End(span, &err)
+
+	return g.find(ctx, func(recurring models.RecurringInvoice) bool { return recurring.ClientID == clientID })
+}
+
+func (g *recurringInvoiceMemoryGateway) GetDueRecurringInvoices(ctx context.Context, asOf time.Time) (_ []*models.RecurringInvoice, err error) {
+	ctx, span := trace.Start(ctx, "RecurringInvoiceMemoryGateway.GetDueRecurringInvoices")
+	defer trace.End(span, &err)
+
+	return g.find(ctx, func(recurring models.RecurringInvoice) bool {
+		return recurring.Status == entity.RecurringStatusActive && recurring.NextDate.Valid && !recurring.NextDate.Time.After(asOf)
+	})
+}
+
+func (g *recurringInvoiceMemoryGateway) UpdateRecurringInvoiceSchedule(ctx context.Context, id int64, version int64, status string, nextDate null.Time, skipped int) (err error) {
+	ctx, span := trace.Start(ctx, "RecurringInvoiceMemoryGateway.UpdateRecurringInvoiceSchedule")
+	defer trace.End(span, &err)
+
+	err = g.store.Write(ctx, func(t *memory.Tables) error {
+		stored, ok := t.Recurring[id]
+		if !ok {
+			return repository.ErrNotFound
+		}
+		if stored.Version != version {
+			return repository.ErrVersionConflict
+		}
+		stored.Status = status
+		stored.NextDate = toNullDate(nextDate)
+		stored.SkippedCount = skipped
+		stored.Version++
+		t.Recurring[id] = stored
+		return nil
+	})
+	if err != nil {
+		log.Error(ctx, fmt.Errorf("failed to update recurring invoice: %+v", err))
+		return err
+	}
+
+	return nil
+}
+
+// find returns the recurring invoices matching a condition, ordered by id
+func (g *recurringInvoiceMemoryGateway) find(ctx context.Context, match func(models.RecurringInvoice) bool) ([]*models.RecurringInvoice, error) {
+	var recurring []*models.RecurringInvoice
+	err := g.store.Read(ctx, func(t *memory.Tables) error {
+		for _, stored := range t.Recurring {
+			if match(stored) {
+				recurring = append(recurring, copyRecurringInvoice(stored))
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(recurring, func(i, j int) bool { return recurring[i].ID < recurring[j].ID })
+	return recurring, nil
+}
+
+// storedRecurringInvoice converts the recurring invoice the way MySQL stores it: dates without time and
+// a DECIMAL(15,2) payment amount
+func storedRecurringInvoice(recurring *models.RecurringInvoice) models.RecurringInvoice {
+	stored := *copyRecurringInvoice(*recurring)
+	stored.StartDate = toDate(stored.StartDate)
+	stored.EndDate = toNullDate(stored.EndDate)
+	stored.NextDate = toNullDate(stored.NextDate)
+	stored.PaymentAmount = toDecimal152(stored.PaymentAmount)
+	return stored
+}
+
+// copyRecurringInvoice copies a recurring invoice, including its payment amount which is a pointer
+func copyRecurringInvoice(recurring models.RecurringInvoice) *models.RecurringInvoice {
+	recurring.PaymentAmount = copyDecimal(recurring.PaymentAmount)
+	recurring.R = nil
+	return &recurring
+}
+
+// toNullDate truncates a nullable time to a DATE, like toDate
+func toNullDate(t null.Time) null.Time {
+	if !t.Valid {
+		return t
+	}
+	return null.TimeFrom(toDate(t.Time))
+}
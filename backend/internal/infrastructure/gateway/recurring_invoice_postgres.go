@@ -0,0 +1,129 @@
+package gateway
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/log"
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/trace"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+
+	"github.com/niko-cb/uct/internal/domain/entity"
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+	"github.com/niko-cb/uct/internal/domain/repository"
+	"github.com/niko-cb/uct/internal/infrastructure/persistent/postgres"
+)
+
+var _ repository.RecurringInvoiceRepository = &recurringInvoicePostgresGateway{}
+
+// recurringInvoicePostgresGateway stores the recurring invoices in Postgres, with queries written by hand like the invoices
+type recurringInvoicePostgresGateway struct {
+	client *postgres.PostgresClient
+}
+
+func NewRecurringInvoicePostgresGateway(client *postgres.PostgresClient) repository.RecurringInvoiceRepository {
+	return &recurringInvoicePostgresGateway{
+		client: client,
+	}
+}
+
+func (g *recurringInvoicePostgresGateway) CreateRecurringInvoice(ctx context.Context, recurring *models.RecurringInvoice) (err error) {
+	ctx, span := trace.Start(ctx, "RecurringInvoicePostgresGateway.CreateRecurringInvoice")
+	defer trace.End(span, &err)
+
+	recurringInvoiceDefaults(recurring)
+	err = g.client.Executor(ctx).QueryRowContext(ctx,
+		`INSERT INTO recurring_invoices (company_id, client_id, frequency, every, day_of_month, start_date, end_date,
+			max_occurrences, payment_amount, currency, due_days, status, next_date)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13) RETURNING id, version`,
+		recurring.CompanyID, recurring.ClientID, recurring.Frequency, recurring.Every, recurring.DayOfMonth,
+		recurring.StartDate, recurring.EndDate, recurring.MaxOccurrences, recurring.PaymentAmount, recurring.Currency,
+		recurring.DueDays, recurring.Status, recurring.NextDate,
+	).Scan(&recurring.ID, &recurring.Version)
+	if err != nil {
+		log.Error(ctx, fmt.Errorf("failed to insert recurring invoice into database: %+v", err))
+		return err
+	}
+
+	return nil
+}
+
+func (g *recurringInvoicePostgresGateway) GetRecurringInvoice(ctx context.Context, id int64) (_ *models.RecurringInvoice, err error) {
+	ctx, span := trace.Start(ctx, "RecurringInvoicePostgresGateway.GetRecurringInvoice")
+	defer trace.End(span, &err)
+
+	recurring := &models.RecurringInvoice{}
+	err = queries.Raw(`SELECT * FROM recurring_invoices WHERE id = $1`, id).Bind(ctx, g.client.Reader(ctx), recurring)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repository.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return recurring, nil
+}
+
+func (g *recurringInvoicePostgresGateway) GetRecurringInvoicesByClient(ctx context.Context, clientID int64) (_ []*models.RecurringInvoice, err error) {
+	ctx, span := trace.Start(ctx, "RecurringInvoicePostgresGateway.GetRecurringInvoicesByClient")
+	defer trace.End(span, &err)
+
+	var recurring []*models.RecurringInvoice
+	err = queries.Raw(`SELECT * FROM recurring_invoices WHERE client_id = $1 ORDER BY id`, clientID).
+		Bind(ctx, g.client.Reader(ctx), &recurring)
+	if err != nil {
+		return nil, err
+	}
+
+	return recurring, nil
+}
+
+func (g *recurringInvoicePostgresGateway) GetDueRecurringInvoices(ctx context.Context, asOf time.Time) (_ []*models.RecurringInvoice, err error) {
+	ctx, span := trace.Start(ctx, "RecurringInvoicePostgresGateway.GetDueRecurringInvoices")
+	defer trace.End(span, &err)
+
+	var recurring []*models.RecurringInvoice
+	err = queries.Raw(`SELECT * FROM recurring_invoices WHERE status = $1 AND next_date <= $2 ORDER BY id`,
+		entity.RecurringStatusActive, asOf).Bind(ctx, g.client.Reader(ctx), &recurring)
+	if err != nil {
+		return nil, err
+	}
+
+	return recurring, nil
+}
+
+func (g *recurringInvoicePostgresGateway) UpdateRecurringInvoiceSchedule(ctx context.Context, id int64, version int64, status string, nextDate null.Time, skipped int) (err error) {
+	ctx, span := trace.Start(ctx, "RecurringInvoicePostgresGateway.UpdateRecurringInvoiceSchedule")
+	defer trace.End(span, &err)
+
+	exec := g.client.Executor(ctx)
+	result, err := exec.ExecContext(ctx,
+		`UPDATE recurring_invoices SET status = $1, next_date = $2, skipped_count = $3, version = version + 1
+		WHERE id = $4 AND version = $5`,
+		status, nextDate, skipped, id, version)
+	if err != nil {
+		log.Error(ctx, fmt.Errorf("failed to update recurring invoice: %+v", err))
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		var exists bool
+		err := exec.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM recurring_invoices WHERE id = $1)`, id).Scan(&exists)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return repository.ErrNotFound
+		}
+		return repository.ErrVersionConflict
+	}
+
+	return nil
+}
@@ -0,0 +1,74 @@
+package gateway_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+	"github.com/niko-cb/uct/internal/domain/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/null/v8"
+)
+
+// TestRecurringInvoiceRepository checks the recurring invoices are saved with their schedule, listed by client and
+// by due date, and their schedule moved at the version read
+func TestRecurringInvoiceRepository(t *testing.T) {
+	for _, b := range backends(t) {
+		t.Run(b.name, func(t *testing.T) {
+			ctx := context.Background()
+			companyID, clientID := b.parents(t)
+			newRecurring := func(nextDate string) *models.RecurringInvoice {
+				return &models.RecurringInvoice{
+					CompanyID: companyID, ClientID: clientID, Frequency: "monthly", Every: 1, DayOfMonth: 31,
+					StartDate: date("2024-01-31"), EndDate: null.TimeFrom(date("2024-12-31")), PaymentAmount: amount(110000.004),
+					Currency: "JPY", DueDays: 30, Status: "active", NextDate: null.TimeFrom(date(nextDate)),
+				}
+			}
+
+			rent, saas := newRecurring("2024-02-29"), newRecurring("2024-03-31")
+			for _, recurring := range []*models.RecurringInvoice{rent, saas} {
+				require.NoError(t, b.recurring.CreateRecurringInvoice(ctx, recurring))
+				require.NotZero(t, recurring.ID)
+			}
+			assert.Error(t, b.recurring.CreateRecurringInvoice(ctx, &models.RecurringInvoice{
+				CompanyID: companyID, ClientID: clientID + 1, Frequency: "weekly", Every: 1, StartDate: date("2024-01-01"),
+				PaymentAmount: amount(1), Currency: "JPY", Status: "active",
+			}), "the client of a recurring invoice must exist")
+
+			loaded, err := b.recurring.GetRecurringInvoice(ctx, rent.ID)
+			require.NoError(t, err)
+			assert.Equal(t, "110000.00", loaded.PaymentAmount.String())
+			assert.Equal(t, "2024-12-31", loaded.EndDate.Time.Format("2006-01-02"))
+			assert.Equal(t, "2024-02-29", loaded.NextDate.Time.Format("2006-01-02"))
+			assert.Equal(t, int64(1), loaded.Version)
+			_, err = b.recurring.GetRecurringInvoice(ctx, saas.ID+1)
+			assert.ErrorIs(t, err, repository.ErrNotFound)
+
+			listed, err := b.recurring.GetRecurringInvoicesByClient(ctx, clientID)
+			require.NoError(t, err)
+			require.Len(t, listed, 2)
+			assert.Equal(t, []int64{rent.ID, saas.ID}, []int64{listed[0].ID, listed[1].ID})
+
+			due, err := b.recurring.GetDueRecurringInvoices(ctx, date("2024-02-29"))
+			require.NoError(t, err)
+			require.Len(t, due, 1)
+			assert.Equal(t, rent.ID, due[0].ID)
+
+			// Paused, or without next occurrence, a recurring invoice is never due
+			require.NoError(t, b.recurring.UpdateRecurringInvoiceSchedule(ctx, rent.ID, 1, "paused", null.TimeFrom(date("2024-02-29")), 0))
+			require.NoError(t, b.recurring.UpdateRecurringInvoiceSchedule(ctx, saas.ID, 1, "active", null.Time{}, 2))
+			assert.ErrorIs(t, b.recurring.UpdateRecurringInvoiceSchedule(ctx, rent.ID, 1, "active", null.Time{}, 0), repository.ErrVersionConflict)
+			assert.ErrorIs(t, b.recurring.UpdateRecurringInvoiceSchedule(ctx, saas.ID+1, 1, "active", null.Time{}, 0), repository.ErrNotFound)
+			due, err = b.recurring.GetDueRecurringInvoices(ctx, date("2025-01-01"))
+			require.NoError(t, err)
+			assert.Empty(t, due)
+
+			loaded, err = b.recurring.GetRecurringInvoice(ctx, saas.ID)
+			require.NoError(t, err)
+			assert.False(t, loaded.NextDate.Valid)
+			assert.Equal(t, 2, loaded.SkippedCount)
+			assert.Equal(t, int64(2), loaded.Version)
+		})
+	}
+}
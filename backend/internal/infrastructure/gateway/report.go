@@ -0,0 +1,239 @@
+package gateway
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/trace"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/types"
+
+	"github.com/niko-cb/uct/internal/domain/entity"
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+	"github.com/niko-cb/uct/internal/domain/repository"
+	"github.com/niko-cb/uct/internal/infrastructure/persistent/mysql"
+)
+
+var _ repository.ReportRepository = &reportGateway{}
+
+// agingQuery counts and sums the outstanding invoices by client, bucket and currency. The buckets are
+// compared to cutoff dates rather than computed with date functions, which differ between databases.
+// The amount of each invoice, less its credit notes and its payments until the date, is converted to the base currency
+// and rounded to its minor unit before the sum. Invoices fully credited or paid are not outstanding anymore: the status
+// of the invoices with payments is derived from all of them, so their amount as of the date decides instead
+const agingQuery = `SELECT i.client_id, c.name,
+	CASE WHEN i.due_date >= ? THEN 0 WHEN i.due_date >= ? THEN 1 WHEN i.due_date >= ? THEN 2 WHEN i.due_date >= ? THEN 3 ELSE 4 END AS bucket,
+	i.currency, COUNT(*), SUM(ROUND((i.total_amount - COALESCE(cn.total_amount, 0) - COALESCE(p.amount, 0)) * i.fx_rate, ?)),
+	SUM(i.total_amount - COALESCE(cn.total_amount, 0) - COALESCE(p.amount, 0))
+	FROM invoices i
+	JOIN clients c ON c.id = i.client_id
+	JOIN companies co ON co.id = i.company_id
+	LEFT JOIN ` + creditsQuery + ` cn ON cn.invoice_id = i.id
+	LEFT JOIN ` + paymentsUntilQuery + ` p ON p.invoice_id = i.id
+	WHERE (i.status <> ? OR EXISTS (SELECT 1 FROM invoice_payments ip WHERE ip.invoice_id = i.id))
+	AND i.issue_date <= ? AND co.base_currency = ?
+	AND i.total_amount - COALESCE(cn.total_amount, 0) - COALESCE(p.amount, 0) > 0
+	GROUP BY i.client_id, c.name, bucket, i.currency
+	ORDER BY i.client_id, bucket, i.currency`
+
+// cashFlowQuery sums the open invoices by due date and currency, less their credit notes and converted like
+// in agingQuery. The total is also less the payments, what is left to pay, while the payment, fee and tax amounts
+// are the ones invoiced. The second range selects the overdue invoices, and is empty when they are not (see overdueBefore)
+const cashFlowQuery = `SELECT i.due_date, i.currency, COUNT(*),
+	SUM(ROUND((i.payment_amount - COALESCE(cn.payment_amount, 0)) * i.fx_rate, ?)),
+	SUM(ROUND((i.fee_amount - COALESCE(cn.fee_amount, 0)) * i.fx_rate, ?)),
+	SUM(ROUND((i.tax_amount - COALESCE(cn.tax_amount, 0)) * i.fx_rate, ?)),
+	SUM(ROUND((i.total_amount - COALESCE(cn.total_amount, 0) - COALESCE(p.amount, 0)) * i.fx_rate, ?)),
+	SUM(i.total_amount - COALESCE(cn.total_amount, 0) - COALESCE(p.amount, 0))
+	FROM invoices i
+	JOIN companies co ON co.id = i.company_id
+	LEFT JOIN ` + creditsQuery + ` cn ON cn.invoice_id = i.id
+	LEFT JOIN ` + paymentsQuery + ` p ON p.invoice_id = i.id
+	WHERE i.status <> ? AND co.base_currency = ? AND (? = 0 OR i.company_id = ?) AND i.due_date <= ? AND (i.due_date >= ? OR i.due_date < ?)
+	AND i.total_amount - COALESCE(cn.total_amount, 0) - COALESCE(p.amount, 0) > 0
+	GROUP BY i.due_date, i.currency
+	ORDER BY i.due_date, i.currency`
+
+// unregisteredIssuerQuery selects the invoices issued in a range whose client was not a registered issuer on the
+// issue date, with the issuer of its registration number if any. A NULL date of the issuer compares to nothing
+const unregisteredIssuerQuery = `SELECT i.id, i.company_id, i.client_id, c.name, i.issue_date, i.currency, i.tax_amount, i.total_amount,
+	c.registration_number, r.id, r.name, r.registered_on, r.revoked_on, r.expired_on
+	FROM invoices i
+	JOIN clients c ON c.id = i.client_id
+	LEFT JOIN invoice_issuers r ON r.registration_number = c.registration_number
+	WHERE (? = 0 OR i.company_id = ?) AND i.issue_date >= ? AND i.issue_date <= ?
+	AND (r.id IS NULL OR r.registered_on > i.issue_date OR r.revoked_on <= i.issue_date OR r.expired_on <= i.issue_date)
+	ORDER BY i.issue_date, i.id`
+
+// creditsQuery sums the credit notes of each invoice which are not void, the void status being its only argument
+const creditsQuery = `(SELECT invoice_id, SUM(payment_amount) AS payment_amount, SUM(fee_amount) AS fee_amount,
+	SUM(tax_amount) AS tax_amount, SUM(total_amount) AS total_amount
+	FROM credit_notes WHERE status <> ? GROUP BY invoice_id)`
+
+// paymentsQuery sums the payments of each invoice, without argument
+const paymentsQuery = `(SELECT invoice_id, SUM(amount) AS amount FROM invoice_payments GROUP BY invoice_id)`
+
+// paymentsUntilQuery sums the payments of each invoice until a date, its only argument
+const paymentsUntilQuery = `(SELECT invoice_id, SUM(amount) AS amount FROM invoice_payments WHERE payment_date <= ? GROUP BY invoice_id)`
+
+type reportGateway struct {
+	client *mysql.MySQLClient
+}
+
+func NewReportGateway(client *mysql.MySQLClient) repository.ReportRepository {
+	return &reportGateway{
+		client: client,
+	}
+}
+
+func (g *reportGateway) GetAging(ctx context.Context, asOf time.Time, baseCurrency string) (_ []*entity.ClientAging, err error) {
+	ctx, span := trace.Start(ctx, "ReportGateway.GetAging")
+	defer trace.End(span, &err)
+
+	rows, err := g.client.Reader(ctx).QueryContext(ctx, agingQuery, agingArgs(asOf, baseCurrency)...)
+	if err != nil {
+		return nil, err
+	}
+	return scanAging(rows)
+}
+
+func (g *reportGateway) GetCashFlows(ctx context.Context, filter repository.CashFlowFilter) (_ []*entity.DueCashFlow, err error) {
+	ctx, span := trace.Start(ctx, "ReportGateway.GetCashFlows")
+	defer trace.End(span, &err)
+
+	rows, err := g.client.Reader(ctx).QueryContext(ctx, cashFlowQuery, cashFlowArgs(filter)...)
+	if err != nil {
+		return nil, err
+	}
+	return scanCashFlows(rows)
+}
+
+func (g *reportGateway) GetUnregisteredIssuerInvoices(ctx context.Context, filter repository.IssuerFilter) (_ []*entity.IssuerCheck, err error) {
+	ctx, span := trace.Start(ctx, "ReportGateway.GetUnregisteredIssuerInvoices")
+	defer trace.End(span, &err)
+
+	rows, err := g.client.Reader(ctx).QueryContext(ctx, unregisteredIssuerQuery, issuerArgs(filter)...)
+	if err != nil {
+		return nil, err
+	}
+	return scanIssuerChecks(rows)
+}
+
+// agingArgs are the arguments of the aging query: the cutoffs of the buckets, the digits of the base currency,
+// the void status of the credit notes, the date of the last payments, the paid status, the date and the base currency
+func agingArgs(asOf time.Time, baseCurrency string) []interface{} {
+	var args []interface{}
+	for _, cutoff := range entity.AgingCutoffs(asOf) {
+		args = append(args, cutoff)
+	}
+	return append(args, entity.CurrencyDigits[baseCurrency], entity.CreditNoteStatusVoid, asOf, entity.InvoiceStatusPaid,
+		asOf, baseCurrency)
+}
+
+// cashFlowArgs are the arguments of the cash flow query, in the order of the MySQL placeholders
+func cashFlowArgs(filter repository.CashFlowFilter) []interface{} {
+	digits := entity.CurrencyDigits[filter.BaseCurrency]
+	return []interface{}{digits, digits, digits, digits, entity.CreditNoteStatusVoid, entity.InvoiceStatusPaid, filter.BaseCurrency,
+		filter.CompanyID, filter.CompanyID, filter.To, filter.From, overdueBefore(filter)}
+}
+
+// issuerArgs are the arguments of the unregistered issuer query, in the order of the MySQL placeholders
+func issuerArgs(filter repository.IssuerFilter) []interface{} {
+	return []interface{}{filter.CompanyID, filter.CompanyID, filter.From, filter.To}
+}
+
+// scanAging reads the rows of the aging query, ordered by client, into the aging of each client
+func scanAging(rows *sql.Rows) ([]*entity.ClientAging, error) {
+	defer rows.Close()
+
+	var clients []*entity.ClientAging
+	for rows.Next() {
+		var (
+			clientID   int64
+			clientName string
+			bucket     int
+			currency   string
+			count      int64
+			amount     types.Decimal
+			original   types.Decimal
+		)
+		if err := rows.Scan(&clientID, &clientName, &bucket, &currency, &count, &amount, &original); err != nil {
+			return nil, err
+		}
+		if len(clients) == 0 || clients[len(clients)-1].ClientID != clientID {
+			clients = append(clients, &entity.ClientAging{ClientID: clientID, ClientName: clientName, AgingBreakdown: entity.NewAgingBreakdown()})
+		}
+		clients[len(clients)-1].Add(bucket, count, amount)
+		clients[len(clients)-1].AddOriginal(currency, count, original)
+	}
+	return clients, rows.Err()
+}
+
+// noOverdue is before every due date, the smallest date MySQL supports: the zero time.Time is not one
+var noOverdue = time.Date(1000, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// overdueBefore is the end of the range of the overdue invoices, which is empty unless they are selected
+func overdueBefore(filter repository.CashFlowFilter) time.Time {
+	if filter.OverdueBefore.IsZero() {
+		return noOverdue
+	}
+	return filter.OverdueBefore
+}
+
+// scanCashFlows reads the rows of the cash flow query, ordered by due date, into the cash flow of each date
+func scanCashFlows(rows *sql.Rows) ([]*entity.DueCashFlow, error) {
+	defer rows.Close()
+
+	var flows []*entity.DueCashFlow
+	for rows.Next() {
+		var (
+			dueDate  time.Time
+			currency string
+			sums     entity.CashFlow
+			original types.Decimal
+		)
+		err := rows.Scan(&dueDate, &currency, &sums.Count, &sums.PaymentAmount, &sums.FeeAmount, &sums.TaxAmount, &sums.TotalAmount, &original)
+		if err != nil {
+			return nil, err
+		}
+		sums.Currencies = []*entity.OriginalAmount{{Currency: currency, Count: sums.Count, Amount: original}}
+		if len(flows) == 0 || !flows[len(flows)-1].DueDate.Equal(dueDate) {
+			flows = append(flows, &entity.DueCashFlow{DueDate: dueDate, CashFlow: entity.NewCashFlow()})
+		}
+		// Added to 0.00 so the amounts have 2 decimals, whatever the scale of the sums of the database
+		flows[len(flows)-1].Add(sums)
+	}
+	return flows, rows.Err()
+}
+
+// scanIssuerChecks reads the rows of the unregistered issuer query
+func scanIssuerChecks(rows *sql.Rows) ([]*entity.IssuerCheck, error) {
+	defer rows.Close()
+
+	var checks []*entity.IssuerCheck
+	for rows.Next() {
+		var (
+			check              entity.IssuerCheck
+			registrationNumber null.String
+			issuerID           null.Int64
+			issuerName         null.String
+			registeredOn       null.Time
+			issuer             models.InvoiceIssuer
+		)
+		err := rows.Scan(&check.InvoiceID, &check.CompanyID, &check.ClientID, &check.ClientName, &check.IssueDate,
+			&check.Currency, &check.TaxAmount, &check.TotalAmount, &registrationNumber,
+			&issuerID, &issuerName, &registeredOn, &issuer.RevokedOn, &issuer.ExpiredOn)
+		if err != nil {
+			return nil, err
+		}
+		check.RegistrationNumber = registrationNumber.String
+		if issuerID.Valid {
+			issuer.ID, issuer.RegistrationNumber, issuer.Name, issuer.RegisteredOn =
+				issuerID.Int64, registrationNumber.String, issuerName.String, registeredOn.Time
+			check.Issuer = &issuer
+		}
+		checks = append(checks, &check)
+	}
+	return checks, rows.Err()
+}
@@ -0,0 +1,235 @@
+package gateway
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/ericlagergren/decimal"
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/trace"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/types"
+
+	"github.com/niko-cb/uct/internal/domain/entity"
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+	"github.com/niko-cb/uct/internal/domain/repository"
+	"github.com/niko-cb/uct/internal/infrastructure/persistent/memory"
+)
+
+var _ repository.ReportRepository = &reportMemoryGateway{}
+
+// reportMemoryGateway aggregates the reports over the in-memory store, the way the SQL queries do
+type reportMemoryGateway struct {
+	store *memory.Store
+}
+
+func NewReportMemoryGateway(store *memory.Store) repository.ReportRepository {
+	return &reportMemoryGateway{
+		store: store,
+	}
+}
+
+func (g *reportMemoryGateway) GetAging(ctx context.Context, asOf time.Time, baseCurrency string) (_ []*entity.ClientAging, err error) {
+	ctx, span := trace.Start(ctx, "ReportMemoryGateway.GetAging")
+	defer trace.End(span, &err)
+
+	byClient := map[int64]*entity.ClientAging{}
+	err = g.store.Read(ctx, func(t *memory.Tables) error {
+		credits, paid, paidUntil := creditSums(t), paymentSums(t, time.Time{}), paymentSums(t, asOf)
+		for _, invoice := range t.Invoices {
+			paidStatus := invoice.Status == entity.InvoiceStatusPaid && paid[invoice.ID] == nil
+			invoice = lessPayments(lessCredits(invoice, credits[invoice.ID]), paidUntil[invoice.ID])
+			if paidStatus || invoice.IssueDate.After(asOf) ||
+				t.Companies[invoice.CompanyID].BaseCurrency != baseCurrency || invoice.TotalAmount.Sign() <= 0 {
+				continue
+			}
+			client, ok := byClient[invoice.ClientID]
+			if !ok {
+				client = &entity.ClientAging{
+					ClientID:       invoice.ClientID,
+					ClientName:     t.Clients[invoice.ClientID].Name,
+					AgingBreakdown: entity.NewAgingBreakdown(),
+				}
+				byClient[invoice.ClientID] = client
+			}
+			client.Add(entity.AgingBucketOf(asOf, invoice.DueDate), 1, toBaseCurrency(invoice, invoice.TotalAmount, baseCurrency))
+			client.AddOriginal(invoice.Currency, 1, invoice.TotalAmount)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	clients := make([]*entity.ClientAging, 0, len(byClient))
+	for _, client := range byClient {
+		clients = append(clients, client)
+	}
+	sort.Slice(clients, func(i, j int) bool { return clients[i].ClientID < clients[j].ClientID })
+	return clients, nil
+}
+
+func (g *reportMemoryGateway) GetCashFlows(ctx context.Context, filter repository.CashFlowFilter) (_ []*entity.DueCashFlow, err error) {
+	ctx, span := trace.Start(ctx, "ReportMemoryGateway.GetCashFlows")
+	defer trace.End(span, &err)
+
+	before := overdueBefore(filter)
+	byDate := map[string]*entity.DueCashFlow{}
+	err = g.store.Read(ctx, func(t *memory.Tables) error {
+		credits, paid := creditSums(t), paymentSums(t, time.Time{})
+		for _, invoice := range t.Invoices {
+			invoice = lessPayments(lessCredits(invoice, credits[invoice.ID]), paid[invoice.ID])
+			if invoice.Status == entity.InvoiceStatusPaid || t.Companies[invoice.CompanyID].BaseCurrency != filter.BaseCurrency ||
+				invoice.TotalAmount.Sign() <= 0 ||
+				(filter.CompanyID != 0 && invoice.CompanyID != filter.CompanyID) ||
+				invoice.DueDate.After(filter.To) || (invoice.DueDate.Before(filter.From) && !invoice.DueDate.Before(before)) {
+				continue
+			}
+			day := invoice.DueDate.Format("2006-01-02")
+			flow, ok := byDate[day]
+			if !ok {
+				flow = &entity.DueCashFlow{DueDate: invoice.DueDate, CashFlow: entity.NewCashFlow()}
+				byDate[day] = flow
+			}
+			base := func(amount types.Decimal) types.Decimal { return toBaseCurrency(invoice, amount, filter.BaseCurrency) }
+			flow.Add(entity.CashFlow{
+				Count: 1, PaymentAmount: base(invoice.PaymentAmount), FeeAmount: base(invoice.FeeAmount),
+				TaxAmount: base(invoice.TaxAmount), TotalAmount: base(invoice.TotalAmount),
+				Currencies: []*entity.OriginalAmount{{Currency: invoice.Currency, Count: 1, Amount: invoice.TotalAmount}},
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	flows := make([]*entity.DueCashFlow, 0, len(byDate))
+	for _, flow := range byDate {
+		flows = append(flows, flow)
+	}
+	sort.Slice(flows, func(i, j int) bool { return flows[i].DueDate.Before(flows[j].DueDate) })
+	return flows, nil
+}
+
+func (g *reportMemoryGateway) GetUnregisteredIssuerInvoices(ctx context.Context, filter repository.IssuerFilter) (_ []*entity.IssuerCheck, err error) {
+	ctx, span := trace.Start(ctx, "ReportMemoryGateway.GetUnregisteredIssuerInvoices")
+	defer trace.End(span, &err)
+
+	var checks []*entity.IssuerCheck
+	err = g.store.Read(ctx, func(t *memory.Tables) error {
+		issuers := map[string]models.InvoiceIssuer{}
+		for _, issuer := range t.Issuers {
+			issuers[issuer.RegistrationNumber] = issuer
+		}
+		for _, invoice := range t.Invoices {
+			if (filter.CompanyID != 0 && invoice.CompanyID != filter.CompanyID) ||
+				invoice.IssueDate.Before(filter.From) || invoice.IssueDate.After(filter.To) {
+				continue
+			}
+			client := t.Clients[invoice.ClientID]
+			check := &entity.IssuerCheck{
+				InvoiceID: invoice.ID, CompanyID: invoice.CompanyID, ClientID: invoice.ClientID, ClientName: client.Name,
+				IssueDate: invoice.IssueDate, Currency: invoice.Currency,
+				TaxAmount: copyDecimal(invoice.TaxAmount), TotalAmount: copyDecimal(invoice.TotalAmount),
+				RegistrationNumber: client.RegistrationNumber.String,
+			}
+			if issuer, ok := issuers[client.RegistrationNumber.String]; ok && client.RegistrationNumber.Valid {
+				if !issuer.RegisteredOn.After(invoice.IssueDate) && !onOrBefore(issuer.RevokedOn, invoice.IssueDate) &&
+					!onOrBefore(issuer.ExpiredOn, invoice.IssueDate) {
+					continue
+				}
+				check.Issuer = &issuer
+			}
+			checks = append(checks, check)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(checks, func(i, j int) bool {
+		if !checks[i].IssueDate.Equal(checks[j].IssueDate) {
+			return checks[i].IssueDate.Before(checks[j].IssueDate)
+		}
+		return checks[i].InvoiceID < checks[j].InvoiceID
+	})
+	return checks, nil
+}
+
+// onOrBefore reports whether a nullable date is set and on or before a date, like a comparison of SQL
+func onOrBefore(date null.Time, t time.Time) bool {
+	return date.Valid && !date.Time.After(t)
+}
+
+// toBaseCurrency converts an amount of an invoice at its rate, rounded to the minor unit of the base currency
+// like the SQL queries do
+func toBaseCurrency(invoice models.Invoice, amount types.Decimal, baseCurrency string) types.Decimal {
+	converted := decimal.WithContext(decimal.Context128).Mul(amount.Big, invoice.FXRate.Big)
+	return roundDecimal(types.NewDecimal(converted), entity.CurrencyDigits[baseCurrency])
+}
+
+// creditSums sums the amounts of the credit notes of each invoice which are not void, like creditsQuery
+func creditSums(t *memory.Tables) map[int64]*models.CreditNote {
+	sums := map[int64]*models.CreditNote{}
+	for _, creditNote := range t.CreditNotes {
+		if creditNote.Status == entity.CreditNoteStatusVoid {
+			continue
+		}
+		sum, ok := sums[creditNote.InvoiceID]
+		if !ok {
+			sums[creditNote.InvoiceID] = copyCreditNote(creditNote)
+			continue
+		}
+		for _, amount := range []struct{ sum, credit *types.Decimal }{
+			{&sum.PaymentAmount, &creditNote.PaymentAmount}, {&sum.FeeAmount, &creditNote.FeeAmount},
+			{&sum.TaxAmount, &creditNote.TaxAmount}, {&sum.TotalAmount, &creditNote.TotalAmount},
+		} {
+			amount.sum.Add(amount.sum.Big, amount.credit.Big)
+		}
+	}
+	return sums
+}
+
+// lessCredits returns an invoice with the amounts of its credits subtracted, the amounts the reports count
+func lessCredits(invoice models.Invoice, credits *models.CreditNote) models.Invoice {
+	if credits == nil {
+		return invoice
+	}
+	sub := func(amount, credit types.Decimal) types.Decimal {
+		return types.NewDecimal(new(decimal.Big).Sub(amount.Big, credit.Big))
+	}
+	invoice.PaymentAmount = sub(invoice.PaymentAmount, credits.PaymentAmount)
+	invoice.FeeAmount = sub(invoice.FeeAmount, credits.FeeAmount)
+	invoice.TaxAmount = sub(invoice.TaxAmount, credits.TaxAmount)
+	invoice.TotalAmount = sub(invoice.TotalAmount, credits.TotalAmount)
+	return invoice
+}
+
+// paymentSums sums the payments of each invoice until a date, all of them with the zero time,
+// like paymentsUntilQuery and paymentsQuery
+func paymentSums(t *memory.Tables, until time.Time) map[int64]*decimal.Big {
+	sums := map[int64]*decimal.Big{}
+	for _, payment := range t.Payments {
+		if !until.IsZero() && payment.PaymentDate.After(until) {
+			continue
+		}
+		sum, ok := sums[payment.InvoiceID]
+		if !ok {
+			sum = new(decimal.Big)
+			sums[payment.InvoiceID] = sum
+		}
+		sum.Add(sum, payment.Amount.Big)
+	}
+	return sums
+}
+
+// lessPayments returns an invoice with its payments subtracted from its total amount, what is left to pay
+func lessPayments(invoice models.Invoice, paid *decimal.Big) models.Invoice {
+	if paid == nil {
+		return invoice
+	}
+	invoice.TotalAmount = types.NewDecimal(new(decimal.Big).Sub(invoice.TotalAmount.Big, paid))
+	return invoice
+}
@@ -0,0 +1,104 @@
+package gateway
+
+import (
+	"context"
+	"time"
+
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/trace"
+
+	"github.com/niko-cb/uct/internal/domain/entity"
+	"github.com/niko-cb/uct/internal/domain/repository"
+	"github.com/niko-cb/uct/internal/infrastructure/persistent/postgres"
+)
+
+var _ repository.ReportRepository = &reportPostgresGateway{}
+
+// agingPostgresQuery is agingQuery with numbered placeholders
+const agingPostgresQuery = `SELECT i.client_id, c.name,
+	CASE WHEN i.due_date >= $1 THEN 0 WHEN i.due_date >= $2 THEN 1 WHEN i.due_date >= $3 THEN 2 WHEN i.due_date >= $4 THEN 3 ELSE 4 END AS bucket,
+	i.currency, COUNT(*), SUM(ROUND((i.total_amount - COALESCE(cn.total_amount, 0) - COALESCE(p.amount, 0)) * i.fx_rate, $5::INT)),
+	SUM(i.total_amount - COALESCE(cn.total_amount, 0) - COALESCE(p.amount, 0))
+	FROM invoices i
+	JOIN clients c ON c.id = i.client_id
+	JOIN companies co ON co.id = i.company_id
+	LEFT JOIN (SELECT invoice_id, SUM(total_amount) AS total_amount
+		FROM credit_notes WHERE status <> $6 GROUP BY invoice_id) cn ON cn.invoice_id = i.id
+	LEFT JOIN (SELECT invoice_id, SUM(amount) AS amount
+		FROM invoice_payments WHERE payment_date <= $7 GROUP BY invoice_id) p ON p.invoice_id = i.id
+	WHERE (i.status <> $8 OR EXISTS (SELECT 1 FROM invoice_payments ip WHERE ip.invoice_id = i.id))
+	AND i.issue_date <= $9 AND co.base_currency = $10
+	AND i.total_amount - COALESCE(cn.total_amount, 0) - COALESCE(p.amount, 0) > 0
+	GROUP BY i.client_id, c.name, bucket, i.currency
+	ORDER BY i.client_id, bucket, i.currency`
+
+// cashFlowPostgresQuery is cashFlowQuery with placeholders numbered in the same order, so both take cashFlowArgs
+const cashFlowPostgresQuery = `SELECT i.due_date, i.currency, COUNT(*),
+	SUM(ROUND((i.payment_amount - COALESCE(cn.payment_amount, 0)) * i.fx_rate, $1::INT)),
+	SUM(ROUND((i.fee_amount - COALESCE(cn.fee_amount, 0)) * i.fx_rate, $2::INT)),
+	SUM(ROUND((i.tax_amount - COALESCE(cn.tax_amount, 0)) * i.fx_rate, $3::INT)),
+	SUM(ROUND((i.total_amount - COALESCE(cn.total_amount, 0) - COALESCE(p.amount, 0)) * i.fx_rate, $4::INT)),
+	SUM(i.total_amount - COALESCE(cn.total_amount, 0) - COALESCE(p.amount, 0))
+	FROM invoices i
+	JOIN companies co ON co.id = i.company_id
+	LEFT JOIN (SELECT invoice_id, SUM(payment_amount) AS payment_amount, SUM(fee_amount) AS fee_amount,
+		SUM(tax_amount) AS tax_amount, SUM(total_amount) AS total_amount
+		FROM credit_notes WHERE status <> $5 GROUP BY invoice_id) cn ON cn.invoice_id = i.id
+	LEFT JOIN ` + paymentsQuery + ` p ON p.invoice_id = i.id
+	WHERE i.status <> $6 AND co.base_currency = $7 AND ($8::BIGINT = 0 OR i.company_id = $9) AND i.due_date <= $10
+	AND (i.due_date >= $11 OR i.due_date < $12) AND i.total_amount - COALESCE(cn.total_amount, 0) - COALESCE(p.amount, 0) > 0
+	GROUP BY i.due_date, i.currency
+	ORDER BY i.due_date, i.currency`
+
+// unregisteredIssuerPostgresQuery is unregisteredIssuerQuery with numbered placeholders
+const unregisteredIssuerPostgresQuery = `SELECT i.id, i.company_id, i.client_id, c.name, i.issue_date, i.currency, i.tax_amount, i.total_amount,
+	c.registration_number, r.id, r.name, r.registered_on, r.revoked_on, r.expired_on
+	FROM invoices i
+	JOIN clients c ON c.id = i.client_id
+	LEFT JOIN invoice_issuers r ON r.registration_number = c.registration_number
+	WHERE ($1::BIGINT = 0 OR i.company_id = $2) AND i.issue_date >= $3 AND i.issue_date <= $4
+	AND (r.id IS NULL OR r.registered_on > i.issue_date OR r.revoked_on <= i.issue_date OR r.expired_on <= i.issue_date)
+	ORDER BY i.issue_date, i.id`
+
+// reportPostgresGateway aggregates the reports in Postgres
+type reportPostgresGateway struct {
+	client *postgres.PostgresClient
+}
+
+func NewReportPostgresGateway(client *postgres.PostgresClient) repository.ReportRepository {
+	return &reportPostgresGateway{
+		client: client,
+	}
+}
+
+func (g *reportPostgresGateway) GetAging(ctx context.Context, asOf time.Time, baseCurrency string) (_ []*entity.ClientAging, err error) {
+	ctx, span := trace.Start(ctx, "ReportPostgresGateway.GetAging")
+	defer trace.End(span, &err)
+
+	rows, err := g.client.Reader(ctx).QueryContext(ctx, agingPostgresQuery, agingArgs(asOf, baseCurrency)...)
+	if err != nil {
+		return nil, err
+	}
+	return scanAging(rows)
+}
+
+func (g *reportPostgresGateway) GetCashFlows(ctx context.Context, filter repository.CashFlowFilter) (_ []*entity.DueCashFlow, err error) {
+	ctx, span := trace.Start(ctx, "ReportPostgresGateway.GetCashFlows")
+	defer trace.End(span, &err)
+
+	rows, err := g.client.Reader(ctx).QueryContext(ctx, cashFlowPostgresQuery, cashFlowArgs(filter)...)
+	if err != nil {
+		return nil, err
+	}
+	return scanCashFlows(rows)
+}
+
+func (g *reportPostgresGateway) GetUnregisteredIssuerInvoices(ctx context.Context, filter repository.IssuerFilter) (_ []*entity.IssuerCheck, err error) {
+	ctx, span := trace.Start(ctx, "ReportPostgresGateway.GetUnregisteredIssuerInvoices")
+	defer trace.End(span, &err)
+
+	rows, err := g.client.Reader(ctx).QueryContext(ctx, unregisteredIssuerPostgresQuery, issuerArgs(filter)...)
+	if err != nil {
+		return nil, err
+	}
+	return scanIssuerChecks(rows)
+}
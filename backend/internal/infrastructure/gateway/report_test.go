@@ -0,0 +1,242 @@
+package gateway_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/ericlagergren/decimal"
+	"github.com/niko-cb/uct/internal/domain/entity"
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+	"github.com/niko-cb/uct/internal/domain/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/sqlboiler/v4/types"
+)
+
+// TestReportRepository_GetAging checks the bucket boundaries and which invoices are outstanding
+func TestReportRepository_GetAging(t *testing.T) {
+	for _, b := range backends(t) {
+		t.Run(b.name, func(t *testing.T) {
+			ctx := context.Background()
+			companyID, firstClient := b.parents(t)
+			_, secondClient := b.parents(t)
+
+			create := func(clientID int64, due string, status string) {
+				invoice := newInvoice(companyID, clientID, date(due), 10000)
+				invoice.Status = status
+				require.NoError(t, b.invoices.CreateInvoice(ctx, invoice))
+			}
+			// As of 2024-06-30
+			create(firstClient, "2024-07-10", "unprocessed") // current
+			create(firstClient, "2024-06-30", "processing")  // current, due on the day
+			create(firstClient, "2024-06-29", "unprocessed") // 1 day late
+			create(firstClient, "2024-05-31", "error")       // 30 days late
+			create(firstClient, "2024-05-30", "unprocessed") // 31 days late
+			create(firstClient, "2024-04-01", "unprocessed") // 90 days late
+			create(firstClient, "2024-03-31", "unprocessed") // 91 days late
+			create(firstClient, "2024-05-01", "paid")        // paid, not outstanding
+			create(firstClient, "2024-08-15", "unprocessed") // issued after the date of the report
+			create(secondClient, "2023-12-31", "unprocessed")
+
+			clients, err := b.reports.GetAging(ctx, date("2024-06-30"), "JPY")
+			require.NoError(t, err)
+			require.Len(t, clients, 2)
+
+			first := clients[0]
+			assert.Equal(t, firstClient, first.ClientID)
+			assert.Equal(t, "client", first.ClientName)
+			for bucket, count := range []int64{2, 2, 1, 1, 1} {
+				assert.Equal(t, count, first.Bucket(bucket).Count, entity.AgingBucketNames[bucket])
+			}
+			assert.Equal(t, int64(7), first.Total.Count)
+			assert.Equal(t, "20880.00", first.Current.Amount.String())
+			assert.Equal(t, "73080.00", first.Total.Amount.String())
+
+			assert.Equal(t, secondClient, clients[1].ClientID)
+			assert.Equal(t, int64(1), clients[1].Over90.Count)
+			assert.Equal(t, int64(0), clients[1].Current.Count)
+			assert.Equal(t, "0.00", clients[1].Current.Amount.String())
+		})
+	}
+}
+
+// TestReportRepository_GetCashFlows checks the open invoices are summed by due date, in the range or overdue
+func TestReportRepository_GetCashFlows(t *testing.T) {
+	for _, b := range backends(t) {
+		t.Run(b.name, func(t *testing.T) {
+			ctx := context.Background()
+			companyID, clientID := b.parents(t)
+			otherCompany, otherClient := b.parents(t)
+
+			create := func(companyID, clientID int64, due string, amount float64, status string) {
+				invoice := newInvoice(companyID, clientID, date(due), amount)
+				invoice.Status = status
+				require.NoError(t, b.invoices.CreateInvoice(ctx, invoice))
+			}
+			create(companyID, clientID, "2024-07-01", 10000, "unprocessed")
+			create(companyID, clientID, "2024-07-01", 20000, "processing")
+			create(companyID, clientID, "2024-07-31", 10000, "error")
+			create(companyID, clientID, "2024-07-15", 10000, "paid")        // paid, not open
+			create(companyID, clientID, "2024-06-20", 5000, "unprocessed")  // before the range
+			create(companyID, clientID, "2024-08-01", 10000, "unprocessed") // after the range
+			create(otherCompany, otherClient, "2024-07-01", 10000, "unprocessed")
+
+			filter := repository.CashFlowFilter{CompanyID: companyID, BaseCurrency: "JPY", From: date("2024-07-01"), To: date("2024-07-31")}
+			flows, err := b.reports.GetCashFlows(ctx, filter)
+			require.NoError(t, err)
+			require.Len(t, flows, 2)
+			assert.Equal(t, "2024-07-01", flows[0].DueDate.Format("2006-01-02"))
+			assert.Equal(t, int64(2), flows[0].Count)
+			assert.Equal(t, "30000.00", flows[0].PaymentAmount.String())
+			assert.Equal(t, "1200.00", flows[0].FeeAmount.String())
+			assert.Equal(t, "120.00", flows[0].TaxAmount.String())
+			assert.Equal(t, "31320.00", flows[0].TotalAmount.String())
+			assert.Equal(t, "2024-07-31", flows[1].DueDate.Format("2006-01-02"))
+
+			// The invoices overdue on 2024-07-10 are selected even before the range
+			filter.OverdueBefore = date("2024-07-10")
+			flows, err = b.reports.GetCashFlows(ctx, filter)
+			require.NoError(t, err)
+			require.Len(t, flows, 3)
+			assert.Equal(t, "2024-06-20", flows[0].DueDate.Format("2006-01-02"))
+			assert.Equal(t, "5220.00", flows[0].TotalAmount.String())
+
+			// Every company
+			flows, err = b.reports.GetCashFlows(ctx, repository.CashFlowFilter{BaseCurrency: "JPY", From: date("2024-07-01"), To: date("2024-07-01")})
+			require.NoError(t, err)
+			require.Len(t, flows, 1)
+			assert.GreaterOrEqual(t, flows[0].Count, int64(3))
+		})
+	}
+}
+
+// TestReportRepository_Currencies checks the amounts are converted to the base currency at the rate of each invoice,
+// rounded to the yen, and also summed in their own currency
+func TestReportRepository_Currencies(t *testing.T) {
+	for _, b := range backends(t) {
+		t.Run(b.name, func(t *testing.T) {
+			ctx := context.Background()
+			companyID, clientID := b.parents(t)
+
+			require.NoError(t, b.invoices.CreateInvoice(ctx, newInvoice(companyID, clientID, date("2024-07-01"), 10000)))
+			dollars := newInvoice(companyID, clientID, date("2024-07-01"), 100)
+			dollars.Currency = "USD"
+			dollars.FXRate = types.NewDecimal(decimal.New(15012345678, 8))
+			require.NoError(t, b.invoices.CreateInvoice(ctx, dollars))
+
+			originals := func(amounts []*entity.OriginalAmount) []string {
+				var s []string
+				for _, amount := range amounts {
+					s = append(s, fmt.Sprintf("%s %d %s", amount.Currency, amount.Count, amount.Amount))
+				}
+				return s
+			}
+
+			clients, err := b.reports.GetAging(ctx, date("2024-07-01"), "JPY")
+			require.NoError(t, err)
+			require.Len(t, clients, 1)
+			// 10440.00 + 104.40 * 150.12345678 (15672.89)
+			assert.Equal(t, "26113.00", clients[0].Total.Amount.String())
+			assert.Equal(t, []string{"JPY 1 10440.00", "USD 1 104.40"}, originals(clients[0].Currencies))
+
+			filter := repository.CashFlowFilter{BaseCurrency: "JPY", From: date("2024-07-01"), To: date("2024-07-01")}
+			flows, err := b.reports.GetCashFlows(ctx, filter)
+			require.NoError(t, err)
+			require.Len(t, flows, 1)
+			assert.Equal(t, int64(2), flows[0].Count)
+			// Each amount is converted and rounded on its own
+			assert.Equal(t, []string{"25012.00", "1000.00", "100.00", "26113.00"}, []string{flows[0].PaymentAmount.String(),
+				flows[0].FeeAmount.String(), flows[0].TaxAmount.String(), flows[0].TotalAmount.String()})
+			assert.Equal(t, []string{"JPY 1 10440.00", "USD 1 104.40"}, originals(flows[0].Currencies))
+
+			// The companies of another base currency
+			clients, err = b.reports.GetAging(ctx, date("2024-07-01"), "USD")
+			require.NoError(t, err)
+			assert.Empty(t, clients)
+			filter.BaseCurrency = "USD"
+			flows, err = b.reports.GetCashFlows(ctx, filter)
+			require.NoError(t, err)
+			assert.Empty(t, flows)
+		})
+	}
+}
+
+// TestReportRepository_CreditNotes checks the credit notes which are not void are subtracted from their invoice,
+// and the invoices fully credited are not outstanding anymore
+func TestReportRepository_CreditNotes(t *testing.T) {
+	for _, b := range backends(t) {
+		t.Run(b.name, func(t *testing.T) {
+			ctx := context.Background()
+			companyID, clientID := b.parents(t)
+
+			credit := func(invoiceID int64, payment, fee, tax float64, status string) {
+				creditNote := &models.CreditNote{
+					InvoiceID: invoiceID, IssueDate: date("2024-07-05"), Reason: "overbilled", PaymentAmount: amount(payment),
+					FeeAmount: amount(fee), TaxAmount: amount(tax), TotalAmount: amount(payment + fee + tax),
+				}
+				require.NoError(t, b.creditNotes.CreateCreditNote(ctx, creditNote))
+				if status != "issued" {
+					require.NoError(t, b.creditNotes.UpdateCreditNoteStatus(ctx, creditNote.ID, 1, status))
+				}
+			}
+			partly := newInvoice(companyID, clientID, date("2024-07-31"), 10000)
+			require.NoError(t, b.invoices.CreateInvoice(ctx, partly))
+			credit(partly.ID, 2500, 100, 10, "issued")
+			credit(partly.ID, 1000, 40, 4, "applied")
+			credit(partly.ID, 5000, 200, 20, "void")
+			fully := newInvoice(companyID, clientID, date("2024-07-31"), 5000)
+			require.NoError(t, b.invoices.CreateInvoice(ctx, fully))
+			credit(fully.ID, 5000, 200, 20, "issued")
+
+			clients, err := b.reports.GetAging(ctx, date("2024-07-31"), "JPY")
+			require.NoError(t, err)
+			require.Len(t, clients, 1)
+			// 10440.00 - 2610.00 - 1044.00
+			assert.Equal(t, int64(1), clients[0].Total.Count)
+			assert.Equal(t, "6786.00", clients[0].Total.Amount.String())
+
+			filter := repository.CashFlowFilter{CompanyID: companyID, BaseCurrency: "JPY", From: date("2024-07-31"), To: date("2024-07-31")}
+			flows, err := b.reports.GetCashFlows(ctx, filter)
+			require.NoError(t, err)
+			require.Len(t, flows, 1)
+			assert.Equal(t, int64(1), flows[0].Count)
+			assert.Equal(t, []string{"6500.00", "260.00", "26.00", "6786.00"}, []string{flows[0].PaymentAmount.String(),
+				flows[0].FeeAmount.String(), flows[0].TaxAmount.String(), flows[0].TotalAmount.String()})
+		})
+	}
+}
+
+// TestReportRepository_Payments checks the aging subtracts the payments made until its date, and counts an invoice
+// paid afterwards as outstanding then
+func TestReportRepository_Payments(t *testing.T) {
+	for _, b := range backends(t) {
+		t.Run(b.name, func(t *testing.T) {
+			ctx := context.Background()
+			companyID, clientID := b.parents(t)
+
+			invoice := newInvoice(companyID, clientID, date("2024-07-31"), 10000)
+			require.NoError(t, b.invoices.CreateInvoice(ctx, invoice))
+			for _, payment := range []*models.InvoicePayment{
+				{InvoiceID: invoice.ID, PaymentDate: date("2024-07-15"), Amount: amount(5000)},
+				{InvoiceID: invoice.ID, PaymentDate: date("2024-08-10"), Amount: amount(5440)},
+			} {
+				require.NoError(t, b.payments.CreatePayment(ctx, payment))
+			}
+			require.NoError(t, b.invoices.UpdateInvoiceStatus(ctx, invoice.ID, 1, "paid"))
+
+			aging := func(asOf string) []string {
+				clients, err := b.reports.GetAging(ctx, date(asOf), "JPY")
+				require.NoError(t, err)
+				var totals []string
+				for _, client := range clients {
+					totals = append(totals, client.Total.Amount.String())
+				}
+				return totals
+			}
+			assert.Equal(t, []string{"10440.00"}, aging("2024-07-14"))
+			assert.Equal(t, []string{"5440.00"}, aging("2024-07-31"))
+			assert.Empty(t, aging("2024-08-10"))
+		})
+	}
+}
@@ -0,0 +1,167 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/log"
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/trace"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+	"github.com/niko-cb/uct/internal/domain/repository"
+	"github.com/niko-cb/uct/internal/infrastructure/persistent/mysql"
+)
+
+var _ repository.SeedRepository = &seedGateway{}
+
+// maxIDsQuery reads the largest id of every seeded table, it is the same for MySQL and Postgres
+const maxIDsQuery = `SELECT
+	COALESCE((SELECT MAX(id) FROM companies), 0),
+	COALESCE((SELECT MAX(id) FROM users), 0),
+	COALESCE((SELECT MAX(id) FROM clients), 0),
+	COALESCE((SELECT MAX(id) FROM bank_accounts), 0),
+	COALESCE((SELECT MAX(id) FROM invoices), 0)`
+
+// The columns the seeder sets, the versions are left to their default
+var (
+	seedCompanyColumns     = []string{"id", "name", "owner_name", "phone", "address"}
+	seedUserColumns        = []string{"id", "company_id", "name", "email", "password"}
+	seedClientColumns      = []string{"id", "company_id", "name", "phone", "address"}
+	seedBankAccountColumns = []string{"id", "client_id", "bank_name", "branch", "account_no", "holder"}
+	seedInvoiceColumns     = []string{"id", "company_id", "client_id", "issue_date", "due_date",
+		"payment_amount", "fee_amount", "tax_amount", "total_amount", "status"}
+)
+
+type seedGateway struct {
+	client *mysql.MySQLClient
+}
+
+func NewSeedGateway(client *mysql.MySQLClient) repository.SeedRepository {
+	return &seedGateway{
+		client: client,
+	}
+}
+
+func (g *seedGateway) MaxIDs(ctx context.Context) (ids repository.SeedIDs, err error) {
+	ctx, span := trace.Start(ctx, "SeedGateway.MaxIDs")
+	defer trace.End(span, &err)
+
+	// Read from the primary: a lagging replica would hand out ids which are already taken
+	err = g.client.Executor(ctx).QueryRowContext(ctx, maxIDsQuery).
+		Scan(&ids.Companies, &ids.Users, &ids.Clients, &ids.BankAccounts, &ids.Invoices)
+	return ids, err
+}
+
+func (g *seedGateway) InsertCompanies(ctx context.Context, companies []*models.Company) (err error) {
+	ctx, span := trace.Start(ctx, "SeedGateway.InsertCompanies")
+	defer trace.End(span, &err)
+
+	return g.insert(ctx, models.TableNames.Companies, seedCompanyColumns, seedRows(companies, companyValues))
+}
+
+func (g *seedGateway) InsertUsers(ctx context.Context, users []*models.User) (err error) {
+	ctx, span := trace.Start(ctx, "SeedGateway.InsertUsers")
+	defer trace.End(span, &err)
+
+	return g.insert(ctx, models.TableNames.Users, seedUserColumns, seedRows(users, userValues))
+}
+
+func (g *seedGateway) InsertClients(ctx context.Context, clients []*models.Client) (err error) {
+	ctx, span := trace.Start(ctx, "SeedGateway.InsertClients")
+	defer trace.End(span, &err)
+
+	return g.insert(ctx, models.TableNames.Clients, seedClientColumns, seedRows(clients, clientValues))
+}
+
+func (g *seedGateway) InsertBankAccounts(ctx context.Context, accounts []*models.BankAccount) (err error) {
+	ctx, span := trace.Start(ctx, "SeedGateway.InsertBankAccounts")
+	defer trace.End(span, &err)
+
+	return g.insert(ctx, models.TableNames.BankAccounts, seedBankAccountColumns, seedRows(accounts, bankAccountValues))
+}
+
+func (g *seedGateway) InsertInvoices(ctx context.Context, invoices []*models.Invoice) (err error) {
+	ctx, span := trace.Start(ctx, "SeedGateway.InsertInvoices")
+	defer trace.End(span, &err)
+
+	return g.insert(ctx, models.TableNames.Invoices, seedInvoiceColumns, seedRows(invoices, invoiceValues))
+}
+
+func (g *seedGateway) insert(ctx context.Context, table string, columns []string, rows [][]interface{}) error {
+	// Explicit ids move AUTO_INCREMENT past them, so rows created afterwards don't collide
+	err := insertRows(ctx, g.client.Executor(ctx), table, columns, rows, false)
+	if err != nil {
+		log.Error(ctx, fmt.Errorf("failed to insert %d rows into %s: %+v", len(rows), table, err))
+		return err
+	}
+	return nil
+}
+
+// insertRows inserts rows with a single multi-row INSERT statement
+func insertRows(ctx context.Context, exec boil.ContextExecutor, table string, columns []string, rows [][]interface{}, numbered bool) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	query, args := insertQuery(table, columns, rows, numbered)
+	_, err := exec.ExecContext(ctx, query, args...)
+	return err
+}
+
+// insertQuery builds a multi-row INSERT statement and its arguments.
+// Postgres placeholders are numbered ($1, $2, ...), MySQL ones are not (?)
+func insertQuery(table string, columns []string, rows [][]interface{}, numbered bool) (string, []interface{}) {
+	var query strings.Builder
+	args := make([]interface{}, 0, len(rows)*len(columns))
+	fmt.Fprintf(&query, "INSERT INTO %s (%s) VALUES ", table, strings.Join(columns, ", "))
+	for i, row := range rows {
+		if i > 0 {
+			query.WriteString(", ")
+		}
+		query.WriteByte('(')
+		for j, value := range row {
+			if j > 0 {
+				query.WriteString(", ")
+			}
+			args = append(args, value)
+			if numbered {
+				fmt.Fprintf(&query, "$%d", len(args))
+			} else {
+				query.WriteByte('?')
+			}
+		}
+		query.WriteByte(')')
+	}
+	return query.String(), args
+}
+
+func seedRows[T any](rows []T, values func(T) []interface{}) [][]interface{} {
+	result := make([][]interface{}, len(rows))
+	for i, row := range rows {
+		result[i] = values(row)
+	}
+	return result
+}
+
+func companyValues(c *models.Company) []interface{} {
+	return []interface{}{c.ID, c.Name, c.OwnerName, c.Phone, c.Address}
+}
+
+func userValues(u *models.User) []interface{} {
+	return []interface{}{u.ID, u.CompanyID, u.Name, u.Email, u.Password}
+}
+
+func clientValues(c *models.Client) []interface{} {
+	return []interface{}{c.ID, c.CompanyID, c.Name, c.Phone, c.Address}
+}
+
+func bankAccountValues(a *models.BankAccount) []interface{} {
+	return []interface{}{a.ID, a.ClientID, a.BankName, a.Branch, a.AccountNo, a.Holder}
+}
+
+func invoiceValues(i *models.Invoice) []interface{} {
+	return []interface{}{i.ID, i.CompanyID, i.ClientID, i.IssueDate, i.DueDate,
+		i.PaymentAmount, i.FeeAmount, i.TaxAmount, i.TotalAmount, i.Status}
+}
@@ -0,0 +1,188 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/log"
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/trace"
+
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+	"github.com/niko-cb/uct/internal/domain/repository"
+	"github.com/niko-cb/uct/internal/infrastructure/persistent/memory"
+)
+
+var _ repository.SeedRepository = &seedMemoryGateway{}
+
+// seedMemoryGateway inserts generated rows in the in-memory store. Like a multi-row INSERT,
+// each call checks the constraints of every row before inserting any of them
+type seedMemoryGateway struct {
+	store *memory.Store
+}
+
+func NewSeedMemoryGateway(store *memory.Store) repository.SeedRepository {
+	return &seedMemoryGateway{
+		store: store,
+	}
+}
+
+func (g *seedMemoryGateway) MaxIDs(ctx context.Context) (ids repository.SeedIDs, err error) {
+	ctx, span := trace.Start(ctx, "SeedMemoryGateway.MaxIDs")
+	defer trace.End(span, &err)
+
+	err = g.store.Read(ctx, func(t *memory.Tables) error {
+		ids = repository.SeedIDs{
+			Companies:    maxID(t.Companies),
+			Users:        maxID(t.Users),
+			Clients:      maxID(t.Clients),
+			BankAccounts: maxID(t.BankAccounts),
+			Invoices:     maxID(t.Invoices),
+		}
+		return nil
+	})
+	return ids, err
+}
+
+func (g *seedMemoryGateway) InsertCompanies(ctx context.Context, companies []*models.Company) (err error) {
+	ctx, span := trace.Start(ctx, "SeedMemoryGateway.InsertCompanies")
+	defer trace.End(span, &err)
+
+	return g.write(ctx, memory.TableCompanies, len(companies), func(t *memory.Tables) error {
+		for _, company := range companies {
+			if _, ok := t.Companies[company.ID]; ok {
+				return fmt.Errorf("%w: companies.id %d", memory.ErrDuplicateKey, company.ID)
+			}
+		}
+		for _, company := range companies {
+			stored := *company
+			stored.ID = g.store.ID(memory.TableCompanies, company.ID)
+			companyDefaults(&stored)
+			stored.R = nil
+			t.Companies[stored.ID] = stored
+		}
+		return nil
+	})
+}
+
+func (g *seedMemoryGateway) InsertUsers(ctx context.Context, users []*models.User) (err error) {
+	ctx, span := trace.Start(ctx, "SeedMemoryGateway.InsertUsers")
+	defer trace.End(span, &err)
+
+	return g.write(ctx, memory.TableUsers, len(users), func(t *memory.Tables) error {
+		emails := map[string]bool{}
+		for _, existing := range t.Users {
+			emails[existing.Email] = true
+		}
+		for _, user := range users {
+			if _, ok := t.Companies[user.CompanyID]; !ok {
+				return fmt.Errorf("%w: users.company_id %d", memory.ErrForeignKey, user.CompanyID)
+			}
+			if _, ok := t.Users[user.ID]; ok {
+				return fmt.Errorf("%w: users.id %d", memory.ErrDuplicateKey, user.ID)
+			}
+			if emails[user.Email] {
+				return fmt.Errorf("%w: users.email %s", memory.ErrDuplicateKey, user.Email)
+			}
+			emails[user.Email] = true
+		}
+		for _, user := range users {
+			stored := *user
+			stored.ID = g.store.ID(memory.TableUsers, user.ID)
+			stored.R = nil
+			t.Users[stored.ID] = stored
+		}
+		return nil
+	})
+}
+
+func (g *seedMemoryGateway) InsertClients(ctx context.Context, clients []*models.Client) (err error) {
+	ctx, span := trace.Start(ctx, "SeedMemoryGateway.InsertClients")
+	defer trace.End(span, &err)
+
+	return g.write(ctx, memory.TableClients, len(clients), func(t *memory.Tables) error {
+		for _, client := range clients {
+			if _, ok := t.Companies[client.CompanyID]; !ok {
+				return fmt.Errorf("%w: clients.company_id %d", memory.ErrForeignKey, client.CompanyID)
+			}
+			if _, ok := t.Clients[client.ID]; ok {
+				return fmt.Errorf("%w: clients.id %d", memory.ErrDuplicateKey, client.ID)
+			}
+		}
+		for _, client := range clients {
+			stored := *client
+			stored.ID = g.store.ID(memory.TableClients, client.ID)
+			stored.Version = 1
+			stored.R = nil
+			t.Clients[stored.ID] = stored
+		}
+		return nil
+	})
+}
+
+func (g *seedMemoryGateway) InsertBankAccounts(ctx context.Context, accounts []*models.BankAccount) (err error) {
+	ctx, span := trace.Start(ctx, "SeedMemoryGateway.InsertBankAccounts")
+	defer trace.End(span, &err)
+
+	return g.write(ctx, memory.TableBankAccounts, len(accounts), func(t *memory.Tables) error {
+		for _, account := range accounts {
+			if _, ok := t.Clients[account.ClientID]; !ok {
+				return fmt.Errorf("%w: bank_accounts.client_id %d", memory.ErrForeignKey, account.ClientID)
+			}
+			if _, ok := t.BankAccounts[account.ID]; ok {
+				return fmt.Errorf("%w: bank_accounts.id %d", memory.ErrDuplicateKey, account.ID)
+			}
+		}
+		for _, account := range accounts {
+			stored := *account
+			stored.ID = g.store.ID(memory.TableBankAccounts, account.ID)
+			stored.Version = 1
+			stored.R = nil
+			t.BankAccounts[stored.ID] = stored
+		}
+		return nil
+	})
+}
+
+func (g *seedMemoryGateway) InsertInvoices(ctx context.Context, invoices []*models.Invoice) (err error) {
+	ctx, span := trace.Start(ctx, "SeedMemoryGateway.InsertInvoices")
+	defer trace.End(span, &err)
+
+	return g.write(ctx, memory.TableInvoices, len(invoices), func(t *memory.Tables) error {
+		for _, invoice := range invoices {
+			if _, ok := t.Companies[invoice.CompanyID]; !ok {
+				return fmt.Errorf("%w: invoices.company_id %d", memory.ErrForeignKey, invoice.CompanyID)
+			}
+			if _, ok := t.Clients[invoice.ClientID]; !ok {
+				return fmt.Errorf("%w: invoices.client_id %d", memory.ErrForeignKey, invoice.ClientID)
+			}
+			if _, ok := t.Invoices[invoice.ID]; ok {
+				return fmt.Errorf("%w: invoices.id %d", memory.ErrDuplicateKey, invoice.ID)
+			}
+		}
+		for _, invoice := range invoices {
+			stored := storedInvoice(invoice)
+			stored.ID = g.store.ID(memory.TableInvoices, invoice.ID)
+			stored.Version = 1
+			t.Invoices[stored.ID] = stored
+		}
+		return nil
+	})
+}
+
+func (g *seedMemoryGateway) write(ctx context.Context, table string, rows int, f func(t *memory.Tables) error) error {
+	if err := g.store.Write(ctx, f); err != nil {
+		log.Error(ctx, fmt.Errorf("failed to insert %d rows into %s: %+v", rows, table, err))
+		return err
+	}
+	return nil
+}
+
+func maxID[V any](rows map[int64]V) int64 {
+	var max int64
+	for id := range rows {
+		if id > max {
+			max = id
+		}
+	}
+	return max
+}
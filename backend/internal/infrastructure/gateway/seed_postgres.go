@@ -0,0 +1,90 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/log"
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/trace"
+
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+	"github.com/niko-cb/uct/internal/domain/repository"
+	"github.com/niko-cb/uct/internal/infrastructure/persistent/postgres"
+)
+
+var _ repository.SeedRepository = &seedPostgresGateway{}
+
+// seedPostgresGateway inserts generated rows in bulk in Postgres
+type seedPostgresGateway struct {
+	client *postgres.PostgresClient
+}
+
+func NewSeedPostgresGateway(client *postgres.PostgresClient) repository.SeedRepository {
+	return &seedPostgresGateway{
+		client: client,
+	}
+}
+
+func (g *seedPostgresGateway) MaxIDs(ctx context.Context) (ids repository.SeedIDs, err error) {
+	ctx, span := trace.Start(ctx, "SeedPostgresGateway.MaxIDs")
+	defer trace.End(span, &err)
+
+	// Read from the primary: a lagging replica would hand out ids which are already taken
+	err = g.client.Executor(ctx).QueryRowContext(ctx, maxIDsQuery).
+		Scan(&ids.Companies, &ids.Users, &ids.Clients, &ids.BankAccounts, &ids.Invoices)
+	return ids, err
+}
+
+func (g *seedPostgresGateway) InsertCompanies(ctx context.Context, companies []*models.Company) (err error) {
+	ctx, span := trace.Start(ctx, "SeedPostgresGateway.InsertCompanies")
+	defer trace.End(span, &err)
+
+	return g.insert(ctx, models.TableNames.Companies, seedCompanyColumns, seedRows(companies, companyValues))
+}
+
+func (g *seedPostgresGateway) InsertUsers(ctx context.Context, users []*models.User) (err error) {
+	ctx, span := trace.Start(ctx, "SeedPostgresGateway.InsertUsers")
+	defer trace.End(span, &err)
+
+	return g.insert(ctx, models.TableNames.Users, seedUserColumns, seedRows(users, userValues))
+}
+
+func (g *seedPostgresGateway) InsertClients(ctx context.Context, clients []*models.Client) (err error) {
+	ctx, span := trace.Start(ctx, "SeedPostgresGateway.InsertClients")
+	defer trace.End(span, &err)
+
+	return g.insert(ctx, models.TableNames.Clients, seedClientColumns, seedRows(clients, clientValues))
+}
+
+func (g *seedPostgresGateway) InsertBankAccounts(ctx context.Context, accounts []*models.BankAccount) (err error) {
+	ctx, span := trace.Start(ctx, "SeedPostgresGateway.InsertBankAccounts")
+	defer trace.End(span, &err)
+
+	return g.insert(ctx, models.TableNames.BankAccounts, seedBankAccountColumns, seedRows(accounts, bankAccountValues))
+}
+
+func (g *seedPostgresGateway) InsertInvoices(ctx context.Context, invoices []*models.Invoice) (err error) {
+	ctx, span := trace.Start(ctx, "SeedPostgresGateway.InsertInvoices")
+	defer trace.End(span, &err)
+
+	return g.insert(ctx, models.TableNames.Invoices, seedInvoiceColumns, seedRows(invoices, invoiceValues))
+}
+
+func (g *seedPostgresGateway) insert(ctx context.Context, table string, columns []string, rows [][]interface{}) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	exec := g.client.Executor(ctx)
+	err := insertRows(ctx, exec, table, columns, rows, true)
+	if err == nil {
+		// Unlike AUTO_INCREMENT, identity sequences ignore explicit ids: move them past the inserted rows
+		_, err = exec.ExecContext(ctx,
+			fmt.Sprintf(`SELECT setval(pg_get_serial_sequence('%s', 'id'), (SELECT MAX(id) FROM %s))`, table, table))
+	}
+	if err != nil {
+		log.Error(ctx, fmt.Errorf("failed to insert %d rows into %s: %+v", len(rows), table, err))
+		return err
+	}
+	return nil
+}
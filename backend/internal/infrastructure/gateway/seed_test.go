@@ -0,0 +1,83 @@
+package gateway_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/niko-cb/uct/internal/conversion"
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/types"
+)
+
+// TestSeedRepository checks the bulk inserts keep the given ids, and rows created afterwards get the next ones
+func TestSeedRepository(t *testing.T) {
+	for _, b := range backends(t) {
+		t.Run(b.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			ids, err := b.seeds.MaxIDs(ctx)
+			require.NoError(t, err)
+
+			companyID, clientID, invoiceID := ids.Companies+10, ids.Clients+10, ids.Invoices+10
+			require.NoError(t, b.seeds.InsertCompanies(ctx, []*models.Company{
+				{ID: companyID, Name: "株式会社さくら商事", OwnerName: "佐藤 太郎", Phone: null.StringFrom("03-1234-5678")},
+				{ID: companyID + 1, Name: "みなと物産合同会社", OwnerName: "鈴木 花子"},
+			}))
+			require.NoError(t, b.seeds.InsertUsers(ctx, []*models.User{
+				{ID: ids.Users + 10, CompanyID: companyID, Name: "佐藤 太郎", Email: b.name + "-seed1@example.com", Password: "hash"},
+				{ID: ids.Users + 11, CompanyID: companyID + 1, Name: "鈴木 花子", Email: b.name + "-seed2@example.com", Password: "hash"},
+			}))
+			require.NoError(t, b.seeds.InsertClients(ctx, []*models.Client{{ID: clientID, CompanyID: companyID, Name: "client"}}))
+			require.NoError(t, b.seeds.InsertBankAccounts(ctx, []*models.BankAccount{
+				{ID: ids.BankAccounts + 10, ClientID: clientID, BankName: "みずほ銀行", Branch: "本店", AccountNo: "1234567", Holder: "client"},
+			}))
+
+			issueDate := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+			amount := func(f float64) types.Decimal {
+				d, err := conversion.ConvertToDecimal(f)
+				require.NoError(t, err)
+				return d
+			}
+			var invoices []*models.Invoice
+			for i := int64(0); i < 3; i++ {
+				invoices = append(invoices, &models.Invoice{
+					ID: invoiceID + i, CompanyID: companyID, ClientID: clientID, IssueDate: issueDate, DueDate: issueDate.AddDate(0, 0, 30),
+					PaymentAmount: amount(10000), FeeAmount: amount(400), TaxAmount: amount(440), TotalAmount: amount(10840), Status: "paid",
+				})
+			}
+			require.NoError(t, b.seeds.InsertInvoices(ctx, invoices))
+
+			found, err := b.invoices.GetInvoice(ctx, invoiceID+2)
+			require.NoError(t, err)
+			assert.Equal(t, int64(1), found.Version)
+			assert.Equal(t, "10840.00", found.TotalAmount.String())
+
+			after, err := b.seeds.MaxIDs(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, companyID+1, after.Companies)
+			assert.Equal(t, invoiceID+2, after.Invoices)
+
+			// The sequences moved past the inserted ids
+			company := &models.Company{Name: "company"}
+			require.NoError(t, b.companies.CreateCompany(ctx, company))
+			assert.Greater(t, company.ID, companyID+1)
+			invoice := *invoices[0]
+			invoice.ID = 0
+			require.NoError(t, b.invoices.CreateInvoice(ctx, &invoice))
+			assert.Greater(t, invoice.ID, invoiceID+2)
+
+			// A missing parent fails the whole statement
+			orphan := *invoices[0]
+			orphan.ID, orphan.ClientID = invoiceID+100, clientID+100
+			other := *invoices[0]
+			other.ID = invoiceID + 101
+			assert.Error(t, b.seeds.InsertInvoices(ctx, []*models.Invoice{&other, &orphan}))
+			_, err = b.invoices.GetInvoice(ctx, invoiceID+101)
+			assert.Error(t, err)
+		})
+	}
+}
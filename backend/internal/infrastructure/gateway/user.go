@@ -0,0 +1,88 @@
+package gateway
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/log"
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/trace"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+	"github.com/niko-cb/uct/internal/domain/repository"
+	"github.com/niko-cb/uct/internal/infrastructure/persistent/mysql"
+)
+
+var _ repository.UserRepository = &userGateway{}
+
+type userGateway struct {
+	client *mysql.MySQLClient
+}
+
+func NewUserGateway(client *mysql.MySQLClient) repository.UserRepository {
+	return &userGateway{
+		client: client,
+	}
+}
+
+func (g *userGateway) CreateUser(ctx context.Context, user *models.User) (err error) {
+	ctx, span := trace.Start(ctx, "UserGateway.CreateUser")
+	defer trace.End(span, &err)
+
+	err = user.Insert(ctx, g.client.Executor(ctx), boil.Infer())
+	if err != nil {
+		log.Error(ctx, fmt.Errorf("failed to insert user into database: %+v", err))
+		return err
+	}
+
+	return nil
+}
+
+func (g *userGateway) GetUser(ctx context.Context, id int64) (_ *models.User, err error) {
+	ctx, span := trace.Start(ctx, "UserGateway.GetUser")
+	defer trace.End(span, &err)
+
+	user, err := models.FindUser(ctx, g.client.Reader(ctx), id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repository.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (g *userGateway) GetUserByEmail(ctx context.Context, email string) (_ *models.User, err error) {
+	ctx, span := trace.Start(ctx, "UserGateway.GetUserByEmail")
+	defer trace.End(span, &err)
+
+	user, err := models.Users(models.UserWhere.Email.EQ(email)).One(ctx, g.client.Reader(ctx))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repository.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (g *userGateway) UpdateUserPassword(ctx context.Context, id int64, password string) (err error) {
+	ctx, span := trace.Start(ctx, "UserGateway.UpdateUserPassword")
+	defer trace.End(span, &err)
+
+	rows, err := models.Users(models.UserWhere.ID.EQ(id)).
+		UpdateAll(ctx, g.client.Executor(ctx), models.M{models.UserColumns.Password: password})
+	if err != nil {
+		log.Error(ctx, fmt.Errorf("failed to update user password: %+v", err))
+		return err
+	}
+	if rows == 0 {
+		return repository.ErrNotFound
+	}
+
+	return nil
+}
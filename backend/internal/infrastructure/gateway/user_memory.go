@@ -0,0 +1,110 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/log"
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/trace"
+
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+	"github.com/niko-cb/uct/internal/domain/repository"
+	"github.com/niko-cb/uct/internal/infrastructure/persistent/memory"
+)
+
+var _ repository.UserRepository = &userMemoryGateway{}
+
+// userMemoryGateway stores users in the in-memory store, with the unique email of the MySQL schema
+type userMemoryGateway struct {
+	store *memory.Store
+}
+
+func NewUserMemoryGateway(store *memory.Store) repository.UserRepository {
+	return &userMemoryGateway{
+		store: store,
+	}
+}
+
+func (g *userMemoryGateway) CreateUser(ctx context.Context, user *models.User) (err error) {
+	ctx, span := trace.Start(ctx, "UserMemoryGateway.CreateUser")
+	defer trace.End(span, &err)
+
+	err = g.store.Write(ctx, func(t *memory.Tables) error {
+		if _, ok := t.Companies[user.CompanyID]; !ok {
+			return fmt.Errorf("%w: users.company_id %d", memory.ErrForeignKey, user.CompanyID)
+		}
+		if _, ok := t.Users[user.ID]; ok {
+			return fmt.Errorf("%w: users.id %d", memory.ErrDuplicateKey, user.ID)
+		}
+		for _, existing := range t.Users {
+			if existing.Email == user.Email {
+				return fmt.Errorf("%w: users.email %s", memory.ErrDuplicateKey, user.Email)
+			}
+		}
+
+		user.ID = g.store.ID(memory.TableUsers, user.ID)
+		stored := *user
+		stored.R = nil
+		t.Users[user.ID] = stored
+		return nil
+	})
+	if err != nil {
+		log.Error(ctx, fmt.Errorf("failed to insert user into database: %+v", err))
+		return err
+	}
+
+	return nil
+}
+
+func (g *userMemoryGateway) GetUser(ctx context.Context, id int64) (_ *models.User, err error) {
+	ctx, span := trace.Start(ctx, "UserMemoryGateway.GetUser")
+	defer trace.End(span, &err)
+
+	return g.findUser(ctx, func(user models.User) bool { return user.ID == id })
+}
+
+func (g *userMemoryGateway) GetUserByEmail(ctx context.Context, email string) (_ *models.User, err error) {
+	ctx, span := trace.Start(ctx, "UserMemoryGateway.GetUserByEmail")
+	defer trace.End(span, &err)
+
+	return g.findUser(ctx, func(user models.User) bool { return user.Email == email })
+}
+
+func (g *userMemoryGateway) findUser(ctx context.Context, match func(models.User) bool) (*models.User, error) {
+	var user *models.User
+	err := g.store.Read(ctx, func(t *memory.Tables) error {
+		for _, stored := range t.Users {
+			if match(stored) {
+				user = &stored
+				return nil
+			}
+		}
+		return repository.ErrNotFound
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (g *userMemoryGateway) UpdateUserPassword(ctx context.Context, id int64, password string) (err error) {
+	ctx, span := trace.Start(ctx, "UserMemoryGateway.UpdateUserPassword")
+	defer trace.End(span, &err)
+
+	err = g.store.Write(ctx, func(t *memory.Tables) error {
+		user, ok := t.Users[id]
+		if !ok {
+			return repository.ErrNotFound
+		}
+		user.Password = password
+		t.Users[id] = user
+		return nil
+	})
+	if err != nil {
+		log.Error(ctx, fmt.Errorf("failed to update user password: %+v", err))
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,91 @@
+package gateway
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/log"
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/trace"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+	"github.com/niko-cb/uct/internal/domain/repository"
+	"github.com/niko-cb/uct/internal/infrastructure/persistent/postgres"
+)
+
+var _ repository.UserRepository = &userPostgresGateway{}
+
+// userPostgresGateway stores users in Postgres, with queries written by hand like invoicePostgresGateway
+type userPostgresGateway struct {
+	client *postgres.PostgresClient
+}
+
+func NewUserPostgresGateway(client *postgres.PostgresClient) repository.UserRepository {
+	return &userPostgresGateway{
+		client: client,
+	}
+}
+
+func (g *userPostgresGateway) CreateUser(ctx context.Context, user *models.User) (err error) {
+	ctx, span := trace.Start(ctx, "UserPostgresGateway.CreateUser")
+	defer trace.End(span, &err)
+
+	err = g.client.Executor(ctx).QueryRowContext(ctx,
+		`INSERT INTO users (company_id, name, email, password) VALUES ($1, $2, $3, $4) RETURNING id`,
+		user.CompanyID, user.Name, user.Email, user.Password).Scan(&user.ID)
+	if err != nil {
+		log.Error(ctx, fmt.Errorf("failed to insert user into database: %+v", err))
+		return err
+	}
+
+	return nil
+}
+
+func (g *userPostgresGateway) GetUser(ctx context.Context, id int64) (_ *models.User, err error) {
+	ctx, span := trace.Start(ctx, "UserPostgresGateway.GetUser")
+	defer trace.End(span, &err)
+
+	return g.getUser(ctx, `SELECT * FROM users WHERE id = $1`, id)
+}
+
+func (g *userPostgresGateway) GetUserByEmail(ctx context.Context, email string) (_ *models.User, err error) {
+	ctx, span := trace.Start(ctx, "UserPostgresGateway.GetUserByEmail")
+	defer trace.End(span, &err)
+
+	return g.getUser(ctx, `SELECT * FROM users WHERE email = $1`, email)
+}
+
+func (g *userPostgresGateway) getUser(ctx context.Context, query string, arg interface{}) (*models.User, error) {
+	user := &models.User{}
+	err := queries.Raw(query, arg).Bind(ctx, g.client.Reader(ctx), user)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repository.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (g *userPostgresGateway) UpdateUserPassword(ctx context.Context, id int64, password string) (err error) {
+	ctx, span := trace.Start(ctx, "UserPostgresGateway.UpdateUserPassword")
+	defer trace.End(span, &err)
+
+	result, err := g.client.Executor(ctx).ExecContext(ctx, `UPDATE users SET password = $1 WHERE id = $2`, password, id)
+	if err != nil {
+		log.Error(ctx, fmt.Errorf("failed to update user password: %+v", err))
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return repository.ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,165 @@
+package gateway_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ericlagergren/decimal"
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+	"github.com/niko-cb/uct/internal/domain/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/types"
+)
+
+// TestCompanyRepository checks the insert, the default owner name, the lookup and the available balance
+func TestCompanyRepository(t *testing.T) {
+	for _, b := range backends(t) {
+		t.Run(b.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			company := &models.Company{Name: "company", Phone: null.StringFrom("03-0000-0000")}
+			require.NoError(t, b.companies.CreateCompany(ctx, company))
+			require.NotZero(t, company.ID)
+			assert.Equal(t, "Unknown", company.OwnerName)
+
+			found, err := b.companies.GetCompany(ctx, company.ID)
+			require.NoError(t, err)
+			assert.Equal(t, "company", found.Name)
+			assert.Equal(t, "03-0000-0000", found.Phone.String)
+			assert.False(t, found.Address.Valid)
+
+			_, err = b.companies.GetCompany(ctx, company.ID+1000)
+			assert.ErrorIs(t, err, repository.ErrNotFound)
+
+			assert.Nil(t, found.AvailableBalance.Big, "no balance by default")
+			require.NoError(t, b.companies.UpdateAvailableBalance(ctx, company.ID, types.NewNullDecimal(decimal.New(123456750, 2))))
+			found, err = b.companies.GetCompany(ctx, company.ID)
+			require.NoError(t, err)
+			assert.Equal(t, "1234567.50", found.AvailableBalance.String())
+			require.NoError(t, b.companies.UpdateAvailableBalance(ctx, company.ID, types.NullDecimal{}))
+			found, err = b.companies.GetCompany(ctx, company.ID)
+			require.NoError(t, err)
+			assert.Nil(t, found.AvailableBalance.Big)
+			assert.ErrorIs(t, b.companies.UpdateAvailableBalance(ctx, company.ID+1000, types.NullDecimal{}), repository.ErrNotFound)
+
+			assert.Equal(t, "floor", found.TaxRounding, "the default rounding")
+			require.NoError(t, b.companies.UpdateTaxRounding(ctx, company.ID, "ceil"))
+			found, err = b.companies.GetCompany(ctx, company.ID)
+			require.NoError(t, err)
+			assert.Equal(t, "ceil", found.TaxRounding)
+			assert.ErrorIs(t, b.companies.UpdateTaxRounding(ctx, company.ID+1000, "ceil"), repository.ErrNotFound)
+
+			assert.False(t, found.RegistrationNumber.Valid, "no registration number by default")
+			require.NoError(t, b.companies.UpdateRegistrationNumber(ctx, company.ID, null.StringFrom("T7123456789012")))
+			found, err = b.companies.GetCompany(ctx, company.ID)
+			require.NoError(t, err)
+			assert.Equal(t, null.StringFrom("T7123456789012"), found.RegistrationNumber)
+			require.NoError(t, b.companies.UpdateRegistrationNumber(ctx, company.ID, null.String{}))
+			found, err = b.companies.GetCompany(ctx, company.ID)
+			require.NoError(t, err)
+			assert.False(t, found.RegistrationNumber.Valid)
+			assert.ErrorIs(t, b.companies.UpdateRegistrationNumber(ctx, company.ID+1000, null.String{}), repository.ErrNotFound)
+
+			rounded := &models.Company{Name: "rounded", TaxRounding: "round", RegistrationNumber: null.StringFrom("T2010401000001")}
+			require.NoError(t, b.companies.CreateCompany(ctx, rounded))
+			found, err = b.companies.GetCompany(ctx, rounded.ID)
+			require.NoError(t, err)
+			assert.Equal(t, "round", found.TaxRounding)
+			assert.Equal(t, null.StringFrom("T2010401000001"), found.RegistrationNumber)
+		})
+	}
+}
+
+// TestUserRepository checks the unique email, the lookups and the password update
+func TestUserRepository(t *testing.T) {
+	for _, b := range backends(t) {
+		t.Run(b.name, func(t *testing.T) {
+			ctx := context.Background()
+			companyID, _ := b.parents(t)
+
+			user := &models.User{CompanyID: companyID, Name: "user", Email: b.name + "@example.com", Password: "hash"}
+			require.NoError(t, b.users.CreateUser(ctx, user))
+			require.NotZero(t, user.ID)
+
+			duplicate := &models.User{CompanyID: companyID, Name: "other", Email: user.Email, Password: "hash"}
+			assert.Error(t, b.users.CreateUser(ctx, duplicate))
+
+			require.NoError(t, b.users.UpdateUserPassword(ctx, user.ID, "new hash"))
+			found, err := b.users.GetUserByEmail(ctx, user.Email)
+			require.NoError(t, err)
+			assert.Equal(t, user.ID, found.ID)
+			assert.Equal(t, "new hash", found.Password)
+
+			found, err = b.users.GetUser(ctx, user.ID)
+			require.NoError(t, err)
+			assert.Equal(t, user.Email, found.Email)
+
+			_, err = b.users.GetUserByEmail(ctx, "nobody@example.com")
+			assert.ErrorIs(t, err, repository.ErrNotFound)
+			assert.ErrorIs(t, b.users.UpdateUserPassword(ctx, user.ID+1000, "hash"), repository.ErrNotFound)
+		})
+	}
+}
+
+// TestClientRepository checks clients and bank accounts start at version 1 and reference their parent
+func TestClientRepository(t *testing.T) {
+	for _, b := range backends(t) {
+		t.Run(b.name, func(t *testing.T) {
+			ctx := context.Background()
+			companyID, _ := b.parents(t)
+
+			client := &models.Client{CompanyID: companyID, Name: "client"}
+			require.NoError(t, b.clients.CreateClient(ctx, client))
+			require.NotZero(t, client.ID)
+			assert.Equal(t, int64(1), client.Version)
+
+			account := &models.BankAccount{ClientID: client.ID, BankName: "bank", Branch: "branch", AccountNo: "0000001", Holder: "client"}
+			require.NoError(t, b.clients.CreateBankAccount(ctx, account))
+			require.NotZero(t, account.ID)
+			assert.Equal(t, int64(1), account.Version)
+
+			account = &models.BankAccount{ClientID: client.ID + 1000, BankName: "bank", Branch: "branch", AccountNo: "0000002", Holder: "nobody"}
+			assert.Error(t, b.clients.CreateBankAccount(ctx, account))
+		})
+	}
+}
+
+// TestClientRepository_RegistrationNumber checks the clients are found by registration number, else by name
+// among the ones without registration number
+func TestClientRepository_RegistrationNumber(t *testing.T) {
+	for _, b := range backends(t) {
+		t.Run(b.name, func(t *testing.T) {
+			ctx := context.Background()
+			companyID, _ := b.parents(t)
+			otherCompanyID, _ := b.parents(t)
+
+			registered := &models.Client{CompanyID: companyID, Name: "Yamada Trading", RegistrationNumber: null.StringFrom("T7123456789012")}
+			require.NoError(t, b.clients.CreateClient(ctx, registered))
+			unregistered := &models.Client{CompanyID: companyID, Name: "Suzuki Foods"}
+			require.NoError(t, b.clients.CreateClient(ctx, unregistered))
+
+			found, err := b.clients.FindClient(ctx, companyID, "T7123456789012", "another name")
+			require.NoError(t, err)
+			assert.Equal(t, registered.ID, found.ID)
+			found, err = b.clients.FindClient(ctx, companyID, "T9999999999999", "Suzuki Foods")
+			require.NoError(t, err)
+			assert.Equal(t, unregistered.ID, found.ID)
+			_, err = b.clients.FindClient(ctx, companyID, "T9999999999999", "Yamada Trading")
+			assert.ErrorIs(t, err, repository.ErrNotFound, "the client with the name has another registration number")
+			_, err = b.clients.FindClient(ctx, otherCompanyID, "T7123456789012", "Yamada Trading")
+			assert.ErrorIs(t, err, repository.ErrNotFound, "a client of another company")
+
+			require.NoError(t, b.clients.UpdateClientRegistrationNumber(ctx, unregistered.ID, 1, "T9999999999999"))
+			updated, err := b.clients.GetClient(ctx, unregistered.ID)
+			require.NoError(t, err)
+			assert.Equal(t, null.StringFrom("T9999999999999"), updated.RegistrationNumber)
+			assert.Equal(t, int64(2), updated.Version)
+			assert.ErrorIs(t, b.clients.UpdateClientRegistrationNumber(ctx, unregistered.ID, 1, ""), repository.ErrVersionConflict)
+			assert.ErrorIs(t, b.clients.UpdateClientRegistrationNumber(ctx, unregistered.ID+1000, 1, ""), repository.ErrNotFound)
+			_, err = b.clients.GetClient(ctx, unregistered.ID+1000)
+			assert.ErrorIs(t, err, repository.ErrNotFound)
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	StatusOK          = "ok"
+	StatusUnavailable = "unavailable"
+)
+
+// ErrDraining is reported while the server is shutting down, so load balancers stop sending traffic
+var ErrDraining = errors.New("server is shutting down")
+
+// Check reports whether a dependency is usable. It must return quickly once ctx is done
+type Check func(ctx context.Context) error
+
+// Report is the result of running the readiness checks
+type Report struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks"`
+}
+
+// Checker runs the readiness checks registered by the different parts of the application
+type Checker struct {
+	timeout  time.Duration
+	mu       sync.RWMutex
+	checks   map[string]Check
+	draining atomic.Bool
+}
+
+// NewChecker creates a Checker that gives each check at most timeout to complete
+func NewChecker(timeout time.Duration) *Checker {
+	return &Checker{
+		timeout: timeout,
+		checks:  map[string]Check{},
+	}
+}
+
+// Register adds a named readiness check. Registering the same name again replaces the check
+func (h *Checker) Register(name string, check Check) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.checks[name] = check
+}
+
+// Drain makes the readiness fail from now on. It is called when a graceful shutdown starts
+func (h *Checker) Drain() {
+	h.draining.Store(true)
+}
+
+// Ready runs every check concurrently and reports whether the application can serve traffic
+func (h *Checker) Ready(ctx context.Context) Report {
+	h.mu.RLock()
+	names := make([]string, 0, len(h.checks))
+	for name := range h.checks {
+		names = append(names, name)
+	}
+	h.mu.RUnlock()
+	sort.Strings(names)
+
+	results := make([]error, len(names))
+	var wg sync.WaitGroup
+	for i, name := range names {
+		wg.Add(1)
+		go func(i int, name string) {
+			defer wg.Done()
+			results[i] = h.run(ctx, name)
+		}(i, name)
+	}
+	wg.Wait()
+
+	report := Report{Status: StatusOK, Checks: make(map[string]string, len(names)+1)}
+	if h.draining.Load() {
+		report.Status = StatusUnavailable
+		report.Checks["shutdown"] = ErrDraining.Error()
+	}
+	for i, name := range names {
+		if results[i] != nil {
+			report.Status = StatusUnavailable
+			report.Checks[name] = results[i].Error()
+			continue
+		}
+		report.Checks[name] = StatusOK
+	}
+	return report
+}
+
+// run executes a single check with the checker's timeout
+func (h *Checker) run(ctx context.Context, name string) error {
+	h.mu.RLock()
+	check := h.checks[name]
+	h.mu.RUnlock()
+
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- fmt.Errorf("check panicked: %v", r)
+			}
+		}()
+		done <- check(ctx)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return fmt.Errorf("check timed out after %s", h.timeout)
+	}
+}
@@ -0,0 +1,60 @@
+package health_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/health"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReady_AllChecksPass(t *testing.T) {
+	checker := health.NewChecker(time.Second)
+	checker.Register("database", func(ctx context.Context) error { return nil })
+
+	report := checker.Ready(context.Background())
+
+	assert.Equal(t, health.StatusOK, report.Status)
+	assert.Equal(t, map[string]string{"database": health.StatusOK}, report.Checks)
+}
+
+func TestReady_FailingCheck(t *testing.T) {
+	checker := health.NewChecker(time.Second)
+	checker.Register("database", func(ctx context.Context) error { return errors.New("connection refused") })
+	checker.Register("other", func(ctx context.Context) error { return nil })
+
+	report := checker.Ready(context.Background())
+
+	assert.Equal(t, health.StatusUnavailable, report.Status)
+	assert.Equal(t, "connection refused", report.Checks["database"])
+	assert.Equal(t, health.StatusOK, report.Checks["other"])
+}
+
+func TestReady_CheckTimeout(t *testing.T) {
+	checker := health.NewChecker(10 * time.Millisecond)
+	checker.Register("database", func(ctx context.Context) error {
+		// A check ignoring its context must not block the probe
+		time.Sleep(time.Second)
+		return nil
+	})
+
+	start := time.Now()
+	report := checker.Ready(context.Background())
+
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+	assert.Equal(t, health.StatusUnavailable, report.Status)
+	assert.Contains(t, report.Checks["database"], "timed out")
+}
+
+func TestReady_Draining(t *testing.T) {
+	checker := health.NewChecker(time.Second)
+	checker.Register("database", func(ctx context.Context) error { return nil })
+
+	checker.Drain()
+	report := checker.Ready(context.Background())
+
+	assert.Equal(t, health.StatusUnavailable, report.Status)
+	assert.Equal(t, health.ErrDraining.Error(), report.Checks["shutdown"])
+}
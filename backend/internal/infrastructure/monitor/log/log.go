@@ -2,11 +2,13 @@ package log
 
 import (
 	"context"
+	"io"
 	"os"
 	"time"
 
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/pkgerrors"
+	"go.opentelemetry.io/otel/trace"
 )
 
 var log *zerolog.Logger
@@ -20,20 +22,26 @@ func init() {
 	log = &l
 }
 
+// SetOutput redirects the logs, e.g. to stderr for the commands whose output goes to stdout
+func SetOutput(w io.Writer) {
+	l := log.Output(w)
+	log = &l
+}
+
 func Debug(ctx context.Context, msg string) {
-	log.Debug().
+	withTrace(ctx, log.Debug()).
 		Str("severity", "DEBUG").
 		Msg(msg)
 }
 
 func Info(ctx context.Context, msg string) {
-	log.Info().
+	withTrace(ctx, log.Info()).
 		Str("severity", "INFO").
 		Msg(msg)
 }
 
 func Warning(ctx context.Context, err error) {
-	log.Warn().
+	withTrace(ctx, log.Warn()).
 		Stack().
 		Err(err).
 		Str("severity", "WARNING").
@@ -41,7 +49,7 @@ func Warning(ctx context.Context, err error) {
 }
 
 func Error(ctx context.Context, err error) {
-	log.Error().
+	withTrace(ctx, log.Error()).
 		Stack().
 		Err(err).
 		Str("severity", "ERROR").
@@ -49,9 +57,21 @@ func Error(ctx context.Context, err error) {
 }
 
 func Fatal(ctx context.Context, err error) {
-	log.Fatal().
+	withTrace(ctx, log.Fatal()).
 		Stack().
 		Err(err).
 		Str("severity", "ALERT").
 		Msg(err.Error())
 }
+
+// withTrace adds the trace and span IDs of the current span (if any) so logs can be correlated with traces
+func withTrace(ctx context.Context, e *zerolog.Event) *zerolog.Event {
+	if ctx == nil {
+		return e
+	}
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return e
+	}
+	return e.Str("trace_id", sc.TraceID().String()).Str("span_id", sc.SpanID().String())
+}
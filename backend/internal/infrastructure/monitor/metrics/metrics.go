@@ -0,0 +1,213 @@
+package metrics
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "uct"
+
+// registry holds every metric exposed on /metrics.
+// We don't use the default registry so that imported libraries can't add metrics behind our back
+var registry = prometheus.NewRegistry() // nolint: gochecknoglobals
+
+var (
+	// HTTP RED metrics, labelled by the registered route rather than the raw URL to keep cardinality bounded
+	httpRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "requests_total",
+		Help:      "Number of HTTP requests by method, route and status code.",
+	}, []string{"method", "route", "status"})
+	httpErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "errors_total",
+		Help:      "Number of HTTP requests answered with a 5xx status code.",
+	}, []string{"method", "route"})
+	httpDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "HTTP request latency by method and route.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route"})
+	httpInFlight = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "requests_in_flight",
+		Help:      "Number of HTTP requests currently being served.",
+	})
+
+	// Database transaction metrics
+	txDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "db",
+		Name:      "transaction_duration_seconds",
+		Help:      "Duration of database transactions by result (commit or rollback).",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"result"})
+	txRollbacks = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "db",
+		Name:      "transaction_rollbacks_total",
+		Help:      "Number of database transactions that were rolled back.",
+	})
+	txRetries = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "db",
+		Name:      "transaction_retries_total",
+		Help:      "Number of database transactions retried after a deadlock or a lock wait timeout.",
+	})
+
+	dbReads = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "db",
+		Name:      "reads_total",
+		Help:      "Number of reads made outside a transaction by target (primary or replica).",
+	}, []string{"target"})
+	replicaUp = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "db",
+		Name:      "replica_up",
+		Help:      "Whether a read replica answered its last health check (1) or not (0).",
+	}, []string{"replica"})
+
+	// Business metrics
+	invoicesCreated = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "invoices",
+		Name:      "created_total",
+		Help:      "Number of invoices created by status.",
+	}, []string{"status"})
+	invoiceAmounts = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "invoices",
+		Name:      "amount_total",
+		Help:      "Sum of the amounts of created invoices by status and amount type (payment, fee, tax, total).",
+	}, []string{"status", "type"})
+
+	// Recurring invoice generator metrics, to alert when it stalls
+	recurringRuns = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "recurring",
+		Name:      "runs_total",
+		Help:      "Number of runs of the recurring invoice generator by result (success or error).",
+	}, []string{"result"})
+	recurringLastSuccess = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "recurring",
+		Name:      "last_success_timestamp_seconds",
+		Help:      "Unix time of the last successful run of the recurring invoice generator.",
+	})
+	recurringInvoices = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "recurring",
+		Name:      "invoices_total",
+		Help:      "Number of invoices of the recurring invoices by result (generated or failed).",
+	}, []string{"result"})
+)
+
+func init() {
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		httpRequests, httpErrors, httpDuration, httpInFlight,
+		txDuration, txRollbacks, txRetries, dbReads, replicaUp,
+		invoicesCreated, invoiceAmounts,
+		recurringRuns, recurringLastSuccess, recurringInvoices,
+	)
+}
+
+// Handler returns the HTTP handler serving the metrics in the Prometheus exposition format
+func Handler() http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{Registry: registry})
+}
+
+// InitRoute creates the series of a route up front so it is exported (with a zero value)
+// before it receives its first request
+func InitRoute(method, route string) {
+	httpErrors.WithLabelValues(method, route)
+	httpDuration.WithLabelValues(method, route)
+}
+
+// RequestStarted increments the in-flight gauge and returns a function recording the request once it's done
+func RequestStarted() func(method, route string, status int) {
+	start := time.Now()
+	httpInFlight.Inc()
+	return func(method, route string, status int) {
+		httpInFlight.Dec()
+		httpRequests.WithLabelValues(method, route, strconv.Itoa(status)).Inc()
+		httpDuration.WithLabelValues(method, route).Observe(time.Since(start).Seconds())
+		if status >= http.StatusInternalServerError {
+			httpErrors.WithLabelValues(method, route).Inc()
+		}
+	}
+}
+
+// RegisterDB exports the connection pool statistics (sql.DBStats) of db
+func RegisterDB(name string, db *sql.DB) error {
+	err := registry.Register(collectors.NewDBStatsCollector(db, name))
+	var are prometheus.AlreadyRegisteredError
+	if errors.As(err, &are) {
+		return nil
+	}
+	return err
+}
+
+// ObserveTransaction records the duration and the result of a database transaction
+func ObserveTransaction(start time.Time, committed bool) {
+	result := "commit"
+	if !committed {
+		result = "rollback"
+		txRollbacks.Inc()
+	}
+	txDuration.WithLabelValues(result).Observe(time.Since(start).Seconds())
+}
+
+// TransactionRetried records a transaction retried after a deadlock or a lock wait timeout
+func TransactionRetried() {
+	txRetries.Inc()
+}
+
+// DatabaseRead records a read made outside a transaction and where it was routed
+func DatabaseRead(target string) {
+	dbReads.WithLabelValues(target).Inc()
+}
+
+// ReplicaUp records the result of the health check of a read replica
+func ReplicaUp(replica string, up bool) {
+	value := 0.0
+	if up {
+		value = 1
+	}
+	replicaUp.WithLabelValues(replica).Set(value)
+}
+
+// InvoiceCreated records a newly created invoice and its amounts
+func InvoiceCreated(status string, payment, fee, tax, total float64) {
+	invoicesCreated.WithLabelValues(status).Inc()
+	invoiceAmounts.WithLabelValues(status, "payment").Add(payment)
+	invoiceAmounts.WithLabelValues(status, "fee").Add(fee)
+	invoiceAmounts.WithLabelValues(status, "tax").Add(tax)
+	invoiceAmounts.WithLabelValues(status, "total").Add(total)
+}
+
+// RecurringRun records a run of the recurring invoice generator: its invoices if it succeeded, else the error
+func RecurringRun(succeeded bool, generated, failed int) {
+	if !succeeded {
+		recurringRuns.WithLabelValues("error").Inc()
+		return
+	}
+	recurringRuns.WithLabelValues("success").Inc()
+	recurringLastSuccess.SetToCurrentTime()
+	recurringInvoices.WithLabelValues("generated").Add(float64(generated))
+	recurringInvoices.WithLabelValues("failed").Add(float64(failed))
+}
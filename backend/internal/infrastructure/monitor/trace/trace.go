@@ -0,0 +1,125 @@
+package trace
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.25.0"
+	oteltrace "go.opentelemetry.io/otel/trace"
+
+	"github.com/niko-cb/uct/internal/infrastructure/web/config"
+)
+
+const (
+	ExporterNone   = "none"
+	ExporterOTLP   = "otlp"
+	ExporterStdout = "stdout"
+	ExporterFile   = "file"
+
+	instrumentationName = "github.com/niko-cb/uct"
+)
+
+// ShutdownFunc flushes the remaining spans and releases the exporter
+type ShutdownFunc func(ctx context.Context) error
+
+// Init sets up the global tracer provider and the W3C trace context propagator.
+// The returned function must be called on shutdown so buffered spans are flushed
+func Init(ctx context.Context, cfg *config.Config) (ShutdownFunc, error) {
+	// Always propagate traceparent/baggage, even if we don't export anything ourselves,
+	// so upstream traces keep flowing through to the database comments
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	exporter, closer, err := newExporter(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	if exporter == nil {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(cfg.ServiceName),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create trace resource: %w", err)
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.TraceSampleRatio))),
+	)
+	otel.SetTracerProvider(tp)
+
+	return func(ctx context.Context) error {
+		err := tp.Shutdown(ctx)
+		if closer != nil {
+			if cerr := closer.Close(); cerr != nil && err == nil {
+				err = cerr
+			}
+		}
+		return err
+	}, nil
+}
+
+// newExporter creates the span exporter selected by TRACE_EXPORTER
+func newExporter(ctx context.Context, cfg *config.Config) (sdktrace.SpanExporter, io.Closer, error) {
+	switch cfg.TraceExporter {
+	case ExporterNone, "":
+		return nil, nil, nil
+	case ExporterOTLP:
+		// Endpoint, headers, etc. are read from the standard OTEL_EXPORTER_OTLP_* variables
+		exp, err := otlptracehttp.New(ctx)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create otlp exporter: %w", err)
+		}
+		return exp, nil, nil
+	case ExporterStdout:
+		exp, err := stdouttrace.New(stdouttrace.WithPrettyPrint())
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create stdout exporter: %w", err)
+		}
+		return exp, nil, nil
+	case ExporterFile:
+		f, err := os.OpenFile(cfg.TraceFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to open trace file: %w", err)
+		}
+		exp, err := stdouttrace.New(stdouttrace.WithWriter(f))
+		if err != nil {
+			_ = f.Close()
+			return nil, nil, fmt.Errorf("failed to create file exporter: %w", err)
+		}
+		return exp, f, nil
+	default:
+		return nil, nil, fmt.Errorf("unknown trace exporter: %s", cfg.TraceExporter)
+	}
+}
+
+// Start starts a new span as a child of the span stored in ctx (if any)
+func Start(ctx context.Context, name string) (context.Context, oteltrace.Span) {
+	return otel.Tracer(instrumentationName).Start(ctx, name)
+}
+
+// End records err on the span (if not nil) and ends it.
+// It is meant to be deferred with a pointer to the function's named error result
+func End(span oteltrace.Span, err *error) {
+	if err != nil && *err != nil {
+		span.RecordError(*err)
+		span.SetStatus(codes.Error, (*err).Error())
+	}
+	span.End()
+}
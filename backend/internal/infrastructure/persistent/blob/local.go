@@ -0,0 +1,100 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/niko-cb/uct/internal/domain/repository"
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/trace"
+)
+
+var _ repository.BlobStore = &LocalStore{}
+
+// LocalStore stores the blobs as files under a directory, a key being the relative path of its file
+type LocalStore struct {
+	dir string
+}
+
+// NewLocalStore returns a store of the blobs under dir, created if it doesn't exist
+func NewLocalStore(dir string) (*LocalStore, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create the blob directory: %w", err)
+	}
+	return &LocalStore{dir: dir}, nil
+}
+
+// path returns the file of key, refusing the keys which would escape the directory
+func (s *LocalStore) path(key string) (string, error) {
+	if !filepath.IsLocal(filepath.FromSlash(key)) {
+		return "", fmt.Errorf("invalid blob key %q", key)
+	}
+	return filepath.Join(s.dir, filepath.FromSlash(key)), nil
+}
+
+// Put writes the content to a temporary file renamed once complete, so a reader never sees a partial blob
+func (s *LocalStore) Put(ctx context.Context, key string, contentType string, content []byte) (err error) {
+	_, span := trace.Start(ctx, "LocalStore.Put")
+	defer trace.End(span, &err)
+
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	file, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name()) // fails once renamed
+	if _, err := file.Write(content); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), path)
+}
+
+func (s *LocalStore) Get(ctx context.Context, key string) (_ io.ReadCloser, err error) {
+	_, span := trace.Start(ctx, "LocalStore.Get")
+	defer trace.End(span, &err)
+
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, repository.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return file, nil
+}
+
+func (s *LocalStore) Exists(ctx context.Context, key string) (_ bool, err error) {
+	_, span := trace.Start(ctx, "LocalStore.Exists")
+	defer trace.End(span, &err)
+
+	path, err := s.path(key)
+	if err != nil {
+		return false, err
+	}
+	_, err = os.Stat(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
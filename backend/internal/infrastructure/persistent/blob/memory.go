@@ -0,0 +1,49 @@
+package blob
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"sync"
+
+	"github.com/niko-cb/uct/internal/domain/repository"
+)
+
+var _ repository.BlobStore = &MemoryStore{}
+
+// MemoryStore keeps the blobs in memory, for the memory driver and the tests
+type MemoryStore struct {
+	mu    sync.RWMutex
+	blobs map[string][]byte
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{blobs: map[string][]byte{}}
+}
+
+func (s *MemoryStore) Put(_ context.Context, key string, _ string, content []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.blobs[key] = bytes.Clone(content)
+	return nil
+}
+
+func (s *MemoryStore) Get(_ context.Context, key string) (io.ReadCloser, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	content, ok := s.blobs[key]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	return io.NopCloser(bytes.NewReader(content)), nil
+}
+
+func (s *MemoryStore) Exists(_ context.Context, key string) (bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	_, ok := s.blobs[key]
+	return ok, nil
+}
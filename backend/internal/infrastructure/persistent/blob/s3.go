@@ -0,0 +1,229 @@
+package blob
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/niko-cb/uct/internal/domain/repository"
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/trace"
+)
+
+var _ repository.BlobStore = &S3Store{}
+
+// defaultS3Timeout bounds a request to S3 when S3Options has no Timeout, so a stalled service doesn't hold the request
+// of the API, and its transaction, open
+const defaultS3Timeout = 30 * time.Second
+
+// S3Options are the settings of an S3Store
+type S3Options struct {
+	// Endpoint is the URL of the service, https://s3.<region>.amazonaws.com if empty
+	Endpoint        string
+	Region          string
+	Bucket          string
+	AccessKeyID     string
+	SecretAccessKey string
+	// PathStyle addresses the bucket in the path (endpoint/bucket/key) rather than in the host name (bucket.endpoint/key)
+	PathStyle bool
+	// HTTPClient sends the requests, a client whose requests time out after Timeout if nil
+	HTTPClient *http.Client
+	// Timeout bounds each request of the default HTTPClient, defaultS3Timeout if 0
+	Timeout time.Duration
+}
+
+// S3Store stores the blobs as the objects of a bucket of S3 or of an S3-compatible service such as MinIO.
+// The requests are signed with AWS Signature Version 4
+type S3Store struct {
+	options S3Options
+	base    *url.URL
+	now     func() time.Time
+}
+
+func NewS3Store(options S3Options) (*S3Store, error) {
+	if options.Region == "" {
+		options.Region = "us-east-1"
+	}
+	if options.Endpoint == "" {
+		options.Endpoint = "https://s3." + options.Region + ".amazonaws.com"
+	}
+	if options.Timeout == 0 {
+		options.Timeout = defaultS3Timeout
+	}
+	if options.HTTPClient == nil {
+		options.HTTPClient = &http.Client{Timeout: options.Timeout}
+	}
+	base, err := url.Parse(options.Endpoint)
+	if err != nil || base.Host == "" {
+		return nil, fmt.Errorf("invalid S3 endpoint %q", options.Endpoint)
+	}
+	if options.PathStyle {
+		base.Path = strings.TrimSuffix(base.Path, "/") + "/" + options.Bucket
+	} else {
+		base.Host = options.Bucket + "." + base.Host
+	}
+	return &S3Store{options: options, base: base, now: time.Now}, nil
+}
+
+func (s *S3Store) Put(ctx context.Context, key string, contentType string, content []byte) (err error) {
+	ctx, span := trace.Start(ctx, "S3Store.Put")
+	defer trace.End(span, &err)
+
+	resp, err := s.do(ctx, http.MethodPut, key, contentType, content)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return responseError(http.MethodPut, key, resp)
+	}
+	return nil
+}
+
+func (s *S3Store) Get(ctx context.Context, key string) (_ io.ReadCloser, err error) {
+	ctx, span := trace.Start(ctx, "S3Store.Get")
+	defer trace.End(span, &err)
+
+	resp, err := s.do(ctx, http.MethodGet, key, "", nil)
+	if err != nil {
+		return nil, err
+	}
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return resp.Body, nil
+	case http.StatusNotFound:
+		resp.Body.Close()
+		return nil, repository.ErrNotFound
+	default:
+		defer resp.Body.Close()
+		return nil, responseError(http.MethodGet, key, resp)
+	}
+}
+
+func (s *S3Store) Exists(ctx context.Context, key string) (_ bool, err error) {
+	ctx, span := trace.Start(ctx, "S3Store.Exists")
+	defer trace.End(span, &err)
+
+	resp, err := s.do(ctx, http.MethodHead, key, "", nil)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		return false, responseError(http.MethodHead, key, resp)
+	}
+}
+
+// do sends a signed request on the object key
+func (s *S3Store) do(ctx context.Context, method, key, contentType string, content []byte) (*http.Response, error) {
+	if key == "" || strings.HasPrefix(key, "/") {
+		return nil, fmt.Errorf("invalid blob key %q", key)
+	}
+	u := *s.base
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/" + key
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), bytes.NewReader(content))
+	if err != nil {
+		return nil, err
+	}
+	if content == nil {
+		req.Body, req.ContentLength = http.NoBody, 0
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	s.sign(req, payloadHash(content), s.now().UTC())
+	return s.options.HTTPClient.Do(req)
+}
+
+// responseError returns the error of an unexpected response, with the start of its body: S3 describes the errors in it
+func responseError(method, key string, resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	return fmt.Errorf("s3: %s %s: %s: %s", method, key, resp.Status, bytes.TrimSpace(body))
+}
+
+// payloadHash returns the hex SHA-256 of the body of a request
+func payloadHash(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// sign adds the AWS Signature Version 4 of the request in its Authorization header, covering its host and all its
+// headers set so far, see https://docs.aws.amazon.com/AmazonS3/latest/API/sig-v4-header-based-auth.html
+func (s *S3Store) sign(req *http.Request, payloadHash string, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	date := amzDate[:8]
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+	scope := date + "/" + s.options.Region + "/s3/aws4_request"
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(requestHash[:])
+
+	key := hmacSHA256([]byte("AWS4"+s.options.SecretAccessKey), date)
+	key = hmacSHA256(key, s.options.Region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", "AWS4-HMAC-SHA256 Credential="+s.options.AccessKeyID+"/"+scope+
+		", SignedHeaders="+signedHeaders+", Signature="+signature)
+}
+
+// canonicalQuery returns the query sorted by name, then by value, with the encoding SigV4 expects
+func canonicalQuery(query url.Values) string {
+	var params []string
+	for name, values := range query {
+		for _, value := range values {
+			params = append(params, uriEncode(name)+"="+uriEncode(value))
+		}
+	}
+	sort.Strings(params)
+	return strings.Join(params, "&")
+}
+
+// uriEncode percent-encodes everything but the unreserved characters, spaces as %20
+func uriEncode(s string) string {
+	return strings.ReplaceAll(url.QueryEscape(s), "+", "%20")
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,29 @@
+package blob
+
+import (
+	"fmt"
+
+	"github.com/niko-cb/uct/internal/domain/repository"
+	"github.com/niko-cb/uct/internal/infrastructure/web/config"
+)
+
+// NewStore returns the blob store selected by the configuration: a directory of the local filesystem,
+// or a bucket of an S3-compatible service
+func NewStore(cfg *config.Config) (repository.BlobStore, error) {
+	switch cfg.BlobStore {
+	case config.BlobStoreLocal:
+		return NewLocalStore(cfg.BlobDir)
+	case config.BlobStoreS3:
+		return NewS3Store(S3Options{
+			Endpoint:        cfg.S3Endpoint,
+			Region:          cfg.S3Region,
+			Bucket:          cfg.S3Bucket,
+			AccessKeyID:     cfg.S3AccessKeyID,
+			SecretAccessKey: cfg.S3SecretAccessKey,
+			PathStyle:       cfg.S3PathStyle,
+			Timeout:         cfg.S3Timeout,
+		})
+	default:
+		return nil, fmt.Errorf("unknown blob store: %s", cfg.BlobStore)
+	}
+}
@@ -0,0 +1,221 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+	"github.com/niko-cb/uct/internal/domain/repository"
+)
+
+var (
+	// ErrDuplicateKey is returned when a row violates a primary or unique key
+	ErrDuplicateKey = errors.New("duplicate key")
+	// ErrForeignKey is returned when a row references a row that does not exist
+	ErrForeignKey = errors.New("foreign key constraint fails")
+	// ErrReadOnly is returned when writing in a read-only transaction
+	ErrReadOnly = errors.New("cannot write in a read-only transaction")
+)
+
+const (
+	TableCompanies    = "companies"
+	TableUsers        = "users"
+	TableClients      = "clients"
+	TableBankAccounts = "bank_accounts"
+	TableInvoices     = "invoices"
+	TableInvoiceItems = "invoice_items"
+	TableTaxSubtotals = "invoice_tax_subtotals"
+	TableFXRates      = "fx_rates"
+	TableCreditNotes  = "credit_notes"
+	TableInstallments = "invoice_installments"
+	TablePayments     = "invoice_payments"
+	TableRecurring    = "recurring_invoices"
+	TableAttachments  = "invoice_attachments"
+	TableIssuers      = "invoice_issuers"
+)
+
+// Tables holds the rows of every table, keyed by primary key.
+// Rows are stored by value so callers can't modify them behind the store's back
+type Tables struct {
+	Companies    map[int64]models.Company
+	Users        map[int64]models.User
+	Clients      map[int64]models.Client
+	BankAccounts map[int64]models.BankAccount
+	Invoices     map[int64]models.Invoice
+	InvoiceItems map[int64]models.InvoiceItem
+	TaxSubtotals map[int64]models.InvoiceTaxSubtotal
+	FXRates      map[int64]models.FXRate
+	CreditNotes  map[int64]models.CreditNote
+	Installments map[int64]models.InvoiceInstallment
+	Payments     map[int64]models.InvoicePayment
+	Recurring    map[int64]models.RecurringInvoice
+	Attachments  map[int64]models.InvoiceAttachment
+	Issuers      map[int64]models.InvoiceIssuer
+}
+
+func newTables() *Tables {
+	return &Tables{
+		Companies:    map[int64]models.Company{},
+		Users:        map[int64]models.User{},
+		Clients:      map[int64]models.Client{},
+		BankAccounts: map[int64]models.BankAccount{},
+		Invoices:     map[int64]models.Invoice{},
+		InvoiceItems: map[int64]models.InvoiceItem{},
+		TaxSubtotals: map[int64]models.InvoiceTaxSubtotal{},
+		FXRates:      map[int64]models.FXRate{},
+		CreditNotes:  map[int64]models.CreditNote{},
+		Installments: map[int64]models.InvoiceInstallment{},
+		Payments:     map[int64]models.InvoicePayment{},
+		Recurring:    map[int64]models.RecurringInvoice{},
+		Attachments:  map[int64]models.InvoiceAttachment{},
+		Issuers:      map[int64]models.InvoiceIssuer{},
+	}
+}
+
+// clone copies the tables, used to snapshot them at the start of a transaction or a savepoint
+func (t *Tables) clone() *Tables {
+	return &Tables{
+		Companies:    cloneMap(t.Companies),
+		Users:        cloneMap(t.Users),
+		Clients:      cloneMap(t.Clients),
+		BankAccounts: cloneMap(t.BankAccounts),
+		Invoices:     cloneMap(t.Invoices),
+		InvoiceItems: cloneMap(t.InvoiceItems),
+		TaxSubtotals: cloneMap(t.TaxSubtotals),
+		FXRates:      cloneMap(t.FXRates),
+		CreditNotes:  cloneMap(t.CreditNotes),
+		Installments: cloneMap(t.Installments),
+		Payments:     cloneMap(t.Payments),
+		Recurring:    cloneMap(t.Recurring),
+		Attachments:  cloneMap(t.Attachments),
+		Issuers:      cloneMap(t.Issuers),
+	}
+}
+
+func cloneMap[V any](m map[int64]V) map[int64]V {
+	c := make(map[int64]V, len(m))
+	for k, v := range m {
+		c[k] = v
+	}
+	return c
+}
+
+// Store is an in-memory database with the same transaction semantics as the MySQL client:
+// changes made in DoInTx are only visible to others once committed, nested calls run in
+// savepoints, and reads outside a transaction see the last committed state.
+// Transactions are serialized, which is equivalent to the SERIALIZABLE isolation level
+type Store struct {
+	// mu guards tables and sequences
+	mu     sync.RWMutex
+	tables *Tables
+	// sequences holds the last id generated for each table. Like AUTO_INCREMENT,
+	// they are not rolled back with the transaction
+	sequences map[string]int64
+
+	// txMu is held by the transaction in progress, if any
+	txMu sync.Mutex
+}
+
+func NewStore() *Store {
+	return &Store{
+		tables:    newTables(),
+		sequences: map[string]int64{},
+	}
+}
+
+// ID returns id if set, or the next id of table otherwise, the way AUTO_INCREMENT does
+func (s *Store) ID(table string, id int64) int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if id == 0 {
+		s.sequences[table]++
+		return s.sequences[table]
+	}
+	if id > s.sequences[table] {
+		s.sequences[table] = id
+	}
+	return id
+}
+
+// txKey is the context key of the active transaction
+type txKey struct{}
+
+// txState is the transaction stored in the context
+type txState struct {
+	store    *Store
+	tables   *Tables
+	readOnly bool
+}
+
+// transaction returns the transaction of s carried by ctx, if any
+func (s *Store) transaction(ctx context.Context) (*txState, bool) {
+	state, ok := ctx.Value(txKey{}).(*txState)
+	if !ok || state.store != s {
+		return nil, false
+	}
+	return state, true
+}
+
+// Read calls f with the tables seen from ctx: the ones of its transaction if any,
+// the last committed ones otherwise. f must not modify the tables
+func (s *Store) Read(ctx context.Context, f func(t *Tables) error) error {
+	if state, ok := s.transaction(ctx); ok {
+		return f(state.tables)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return f(s.tables)
+}
+
+// Write calls f with the tables of the transaction of ctx. Outside a transaction,
+// f runs in its own one so a failing statement doesn't leave partial changes.
+// Inside one, f must check the constraints before modifying anything, like a statement would
+func (s *Store) Write(ctx context.Context, f func(t *Tables) error) error {
+	state, ok := s.transaction(ctx)
+	if !ok {
+		return s.DoInTx(ctx, func(ctx context.Context) error {
+			return s.Write(ctx, f)
+		})
+	}
+	if state.readOnly {
+		return ErrReadOnly
+	}
+	return f(state.tables)
+}
+
+// DoInTx runs f in a transaction, or in a savepoint if ctx already carries one.
+// The isolation level is ignored as transactions are serialized anyway
+func (s *Store) DoInTx(ctx context.Context, f func(context.Context) error, opts ...repository.TxOption) error {
+	if state, ok := s.transaction(ctx); ok {
+		savepoint := state.tables.clone()
+		if err := f(ctx); err != nil {
+			state.tables = savepoint
+			return err
+		}
+		return nil
+	}
+
+	var options repository.TxOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	s.txMu.Lock()
+	defer s.txMu.Unlock()
+
+	s.mu.RLock()
+	state := &txState{store: s, tables: s.tables.clone(), readOnly: options.ReadOnly}
+	s.mu.RUnlock()
+
+	if err := f(context.WithValue(ctx, txKey{}, state)); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.tables = state.tables
+	s.mu.Unlock()
+	return nil
+}
@@ -0,0 +1,109 @@
+package memory_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+	"github.com/niko-cb/uct/internal/domain/repository"
+	"github.com/niko-cb/uct/internal/infrastructure/persistent/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func insertCompany(ctx context.Context, s *memory.Store, name string) error {
+	return s.Write(ctx, func(t *memory.Tables) error {
+		id := s.ID(memory.TableCompanies, 0)
+		t.Companies[id] = models.Company{ID: id, Name: name}
+		return nil
+	})
+}
+
+func countCompanies(ctx context.Context, s *memory.Store) int {
+	var n int
+	_ = s.Read(ctx, func(t *memory.Tables) error {
+		n = len(t.Companies)
+		return nil
+	})
+	return n
+}
+
+// TestDoInTx_Isolation makes sure changes are only visible outside the transaction once committed
+func TestDoInTx_Isolation(t *testing.T) {
+	s := memory.NewStore()
+	ctx := context.Background()
+
+	err := s.DoInTx(ctx, func(txCtx context.Context) error {
+		require.NoError(t, insertCompany(txCtx, s, "a"))
+		assert.Equal(t, 1, countCompanies(txCtx, s))
+		assert.Equal(t, 0, countCompanies(ctx, s))
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, countCompanies(ctx, s))
+}
+
+// TestDoInTx_Rollback makes sure an error discards the changes but not the generated ids
+func TestDoInTx_Rollback(t *testing.T) {
+	s := memory.NewStore()
+	ctx := context.Background()
+	want := errors.New("boom")
+
+	err := s.DoInTx(ctx, func(ctx context.Context) error {
+		require.NoError(t, insertCompany(ctx, s, "a"))
+		return want
+	})
+
+	assert.Equal(t, want, err)
+	assert.Equal(t, 0, countCompanies(ctx, s))
+	// Like AUTO_INCREMENT, the id consumed by the rolled back insert is not reused
+	assert.Equal(t, int64(2), s.ID(memory.TableCompanies, 0))
+}
+
+// TestDoInTx_Savepoint makes sure nested calls only roll back their own changes
+func TestDoInTx_Savepoint(t *testing.T) {
+	s := memory.NewStore()
+	ctx := context.Background()
+
+	err := s.DoInTx(ctx, func(ctx context.Context) error {
+		require.NoError(t, insertCompany(ctx, s, "a"))
+
+		nested := s.DoInTx(ctx, func(ctx context.Context) error {
+			require.NoError(t, insertCompany(ctx, s, "b"))
+			return errors.New("nested failure")
+		})
+		assert.Error(t, nested)
+
+		return s.DoInTx(ctx, func(ctx context.Context) error {
+			return insertCompany(ctx, s, "c")
+		})
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, countCompanies(ctx, s))
+}
+
+// TestDoInTx_ReadOnly makes sure writes fail in a read-only transaction
+func TestDoInTx_ReadOnly(t *testing.T) {
+	s := memory.NewStore()
+	ctx := context.Background()
+
+	err := s.DoInTx(ctx, func(ctx context.Context) error {
+		return insertCompany(ctx, s, "a")
+	}, repository.ReadOnly())
+
+	assert.ErrorIs(t, err, memory.ErrReadOnly)
+	assert.Equal(t, 0, countCompanies(ctx, s))
+}
+
+// TestID makes sure explicit ids move the sequence forward
+func TestID(t *testing.T) {
+	s := memory.NewStore()
+
+	assert.Equal(t, int64(1), s.ID(memory.TableInvoices, 0))
+	assert.Equal(t, int64(10), s.ID(memory.TableInvoices, 10))
+	assert.Equal(t, int64(11), s.ID(memory.TableInvoices, 0))
+	assert.Equal(t, int64(1), s.ID(memory.TableClients, 0))
+}
@@ -0,0 +1,11 @@
+package memory
+
+import (
+	"github.com/niko-cb/uct/internal/domain/repository"
+)
+
+var _ repository.Transaction = &Store{}
+
+func NewTransaction(s *Store) repository.Transaction {
+	return s
+}
@@ -0,0 +1,6 @@
+package migration
+
+// After adding a migration, apply it to the local database (`go run ./cmd migrate up`)
+// and regenerate the sqlboiler models and the schema documentation from it:
+//
+//go:generate sh -c "cd ../../../.. && sqlboiler mysql && tbls doc --force"
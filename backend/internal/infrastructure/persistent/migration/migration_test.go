@@ -0,0 +1,73 @@
+package migration
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoad(t *testing.T) {
+	fsys := fstest.MapFS{
+		"0002_add_index.up.sql":       {Data: []byte("CREATE INDEX idx ON invoices (due_date);")},
+		"0001_create_tables.up.sql":   {Data: []byte("CREATE TABLE a (id BIGINT);")},
+		"0001_create_tables.down.sql": {Data: []byte("DROP TABLE a;")},
+	}
+
+	migrations, err := Load(fsys)
+
+	assert.NoError(t, err)
+	assert.Len(t, migrations, 2)
+	assert.Equal(t, int64(1), migrations[0].Version)
+	assert.Equal(t, "create_tables", migrations[0].Name)
+	assert.Equal(t, "DROP TABLE a;", migrations[0].Down)
+	assert.Equal(t, int64(2), migrations[1].Version)
+	assert.Empty(t, migrations[1].Down)
+}
+
+func TestLoad_InvalidFiles(t *testing.T) {
+	tests := map[string]fstest.MapFS{
+		"bad name":       {"create_tables.sql": {Data: []byte("SELECT 1;")}},
+		"missing up":     {"0001_create_tables.down.sql": {Data: []byte("DROP TABLE a;")}},
+		"name conflict":  {"0001_a.up.sql": {Data: []byte("SELECT 1;")}, "0001_b.down.sql": {Data: []byte("SELECT 1;")}},
+		"empty up":       {"0001_a.up.sql": {Data: []byte("  \n")}},
+		"uppercase name": {"0001_Create.up.sql": {Data: []byte("SELECT 1;")}},
+	}
+
+	for name, fsys := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := Load(fsys)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestChecksum(t *testing.T) {
+	a := Migration{Version: 1, Name: "a", Up: "CREATE TABLE a (id BIGINT);"}
+	b := a
+	b.Up = "CREATE TABLE a (id INT);"
+
+	assert.Len(t, a.Checksum(), 64)
+	assert.Equal(t, a.Checksum(), a.Checksum())
+	assert.NotEqual(t, a.Checksum(), b.Checksum())
+}
+
+func TestSplitStatements(t *testing.T) {
+	script := `-- Table to store companies
+CREATE TABLE companies (
+    id BIGINT,
+    owner_name VARCHAR(255) NOT NULL DEFAULT 'Unknown; really'
+);
+
+-- Comment; with a semicolon
+INSERT INTO companies (owner_name) VALUES ("a;b");
+DROP TABLE x`
+
+	statements := splitStatements(script)
+
+	assert.Equal(t, []string{
+		"CREATE TABLE companies (\n    id BIGINT,\n    owner_name VARCHAR(255) NOT NULL DEFAULT 'Unknown; really'\n)",
+		`INSERT INTO companies (owner_name) VALUES ("a;b")`,
+		"DROP TABLE x",
+	}, statements)
+}
@@ -0,0 +1,325 @@
+package migration
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io/fs"
+	"strings"
+	"time"
+
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/log"
+)
+
+const (
+	// TableName is the table recording the applied migrations
+	TableName = "schema_migrations"
+	// lockName identifies the lock taken while migrating
+	lockName = "uct_schema_migrations"
+)
+
+var (
+	// ErrChecksumMismatch means an applied migration was modified afterwards
+	ErrChecksumMismatch = errors.New("migration checksum mismatch")
+	// ErrPending means some migrations have not been applied yet
+	ErrPending = errors.New("pending migrations")
+	// ErrLocked means another process holds the migration lock
+	ErrLocked = errors.New("migration lock is held by another process")
+)
+
+// Dialect holds the database specific parts of the migrator
+type Dialect struct {
+	// CreateTable creates the migrations table if it doesn't exist yet
+	CreateTable string
+	// Placeholder returns the bind parameter of the n-th (1-based) argument
+	Placeholder func(n int) string
+	// Lock acquires a lock bound to the session of conn, so that only one replica migrates at a time
+	Lock func(ctx context.Context, conn *sql.Conn, timeout time.Duration) error
+	// Unlock releases the lock acquired by Lock
+	Unlock func(ctx context.Context, conn *sql.Conn) error
+}
+
+// MySQL is the dialect for MySQL 8
+var MySQL = Dialect{ // nolint: gochecknoglobals
+	CreateTable: `CREATE TABLE IF NOT EXISTS ` + TableName + ` (
+    version BIGINT PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    checksum CHAR(64) NOT NULL,
+    applied_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+)`,
+	Placeholder: func(int) string { return "?" },
+	Lock: func(ctx context.Context, conn *sql.Conn, timeout time.Duration) error {
+		var got sql.NullInt64
+		err := conn.QueryRowContext(ctx, "SELECT GET_LOCK(?, ?)", lockName, int(timeout.Seconds())).Scan(&got)
+		if err != nil {
+			return fmt.Errorf("failed to acquire migration lock: %w", err)
+		}
+		if !got.Valid || got.Int64 != 1 {
+			return ErrLocked
+		}
+		return nil
+	},
+	Unlock: func(ctx context.Context, conn *sql.Conn) error {
+		_, err := conn.ExecContext(ctx, "SELECT RELEASE_LOCK(?)", lockName)
+		return err
+	},
+}
+
+// Postgres is the dialect for PostgreSQL 13+
+var Postgres = Dialect{ // nolint: gochecknoglobals
+	CreateTable: `CREATE TABLE IF NOT EXISTS ` + TableName + ` (
+    version BIGINT PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    checksum CHAR(64) NOT NULL,
+    applied_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+)`,
+	Placeholder: func(n int) string { return fmt.Sprintf("$%d", n) },
+	Lock: func(ctx context.Context, conn *sql.Conn, timeout time.Duration) error {
+		// pg_advisory_lock waits forever, so the non-blocking variant is polled until the timeout
+		deadline := time.Now().Add(timeout)
+		for {
+			var got bool
+			if err := conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", advisoryLockKey()).Scan(&got); err != nil {
+				return fmt.Errorf("failed to acquire migration lock: %w", err)
+			}
+			if got {
+				return nil
+			}
+			if time.Now().After(deadline) {
+				return ErrLocked
+			}
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(500 * time.Millisecond):
+			}
+		}
+	},
+	Unlock: func(ctx context.Context, conn *sql.Conn) error {
+		_, err := conn.ExecContext(ctx, "SELECT pg_advisory_unlock($1)", advisoryLockKey())
+		return err
+	},
+}
+
+// advisoryLockKey derives the key of the Postgres advisory lock from lockName
+func advisoryLockKey() int64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(lockName))
+	return int64(h.Sum64())
+}
+
+// DB is the database the migrations are applied to
+type DB interface {
+	Conn(ctx context.Context) (*sql.Conn, error)
+}
+
+// Status is the state of a migration in the database
+type Status struct {
+	Migration
+	Applied          bool
+	AppliedAt        time.Time
+	ChecksumMismatch bool
+}
+
+// applied is a row of the migrations table
+type applied struct {
+	version   int64
+	name      string
+	checksum  string
+	appliedAt time.Time
+}
+
+// Migrator applies and reverts the migrations of a database
+type Migrator struct {
+	db          DB
+	dialect     Dialect
+	migrations  []Migration
+	lockTimeout time.Duration
+}
+
+// NewMigrator creates a Migrator for the migrations stored in fsys
+func NewMigrator(db DB, dialect Dialect, fsys fs.FS) (*Migrator, error) {
+	migrations, err := Load(fsys)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{
+		db:          db,
+		dialect:     dialect,
+		migrations:  migrations,
+		lockTimeout: time.Minute,
+	}, nil
+}
+
+// Up applies every pending migration in order and returns the applied ones
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	var done []Migration
+	err := m.locked(ctx, func(conn *sql.Conn) error {
+		statuses, err := m.status(ctx, conn)
+		if err != nil {
+			return err
+		}
+		for _, s := range statuses {
+			if s.ChecksumMismatch {
+				return fmt.Errorf("%w: %d_%s was modified after being applied", ErrChecksumMismatch, s.Version, s.Name)
+			}
+		}
+
+		for _, s := range statuses {
+			if s.Applied {
+				continue
+			}
+			log.Info(ctx, fmt.Sprintf("applying migration %d_%s", s.Version, s.Name))
+			insert := fmt.Sprintf("INSERT INTO %s (version, name, checksum) VALUES (%s, %s, %s)",
+				TableName, m.dialect.Placeholder(1), m.dialect.Placeholder(2), m.dialect.Placeholder(3))
+			if err := m.run(ctx, conn, s.Up, insert, s.Version, s.Name, s.Checksum()); err != nil {
+				return fmt.Errorf("migration %d_%s failed: %w", s.Version, s.Name, err)
+			}
+			done = append(done, s.Migration)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// Down reverts the last steps applied migrations and returns the reverted ones
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	var done []Migration
+	err := m.locked(ctx, func(conn *sql.Conn) error {
+		statuses, err := m.status(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for i := len(statuses) - 1; i >= 0 && len(done) < steps; i-- {
+			s := statuses[i]
+			if !s.Applied {
+				continue
+			}
+			if strings.TrimSpace(s.Down) == "" {
+				return fmt.Errorf("migration %d_%s can't be reverted: no down script", s.Version, s.Name)
+			}
+			log.Info(ctx, fmt.Sprintf("reverting migration %d_%s", s.Version, s.Name))
+			del := fmt.Sprintf("DELETE FROM %s WHERE version = %s", TableName, m.dialect.Placeholder(1))
+			if err := m.run(ctx, conn, s.Down, del, s.Version); err != nil {
+				return fmt.Errorf("reverting migration %d_%s failed: %w", s.Version, s.Name, err)
+			}
+			done = append(done, s.Migration)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// Status returns the state of every known migration
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, m.dialect.CreateTable); err != nil {
+		return nil, fmt.Errorf("failed to create %s: %w", TableName, err)
+	}
+	return m.status(ctx, conn)
+}
+
+// Check returns an error if a migration is pending or was modified after being applied.
+// It is used by the readiness probe
+func (m *Migrator) Check(ctx context.Context) error {
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		return err
+	}
+	for _, s := range statuses {
+		if s.ChecksumMismatch {
+			return fmt.Errorf("%w: %d_%s", ErrChecksumMismatch, s.Version, s.Name)
+		}
+		if !s.Applied {
+			return fmt.Errorf("%w: %d_%s", ErrPending, s.Version, s.Name)
+		}
+	}
+	return nil
+}
+
+// locked runs f while holding the migration lock
+func (m *Migrator) locked(ctx context.Context, f func(conn *sql.Conn) error) error {
+	// The lock is bound to the session, so everything runs on the same connection
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if err := m.dialect.Lock(ctx, conn, m.lockTimeout); err != nil {
+		return err
+	}
+	defer func() {
+		if err := m.dialect.Unlock(context.Background(), conn); err != nil {
+			log.Error(ctx, fmt.Errorf("failed to release migration lock: %+v", err))
+		}
+	}()
+
+	if _, err := conn.ExecContext(ctx, m.dialect.CreateTable); err != nil {
+		return fmt.Errorf("failed to create %s: %w", TableName, err)
+	}
+	return f(conn)
+}
+
+// run executes the statements of script and the bookkeeping query in a transaction.
+// Note that MySQL commits DDL statements implicitly, so a failing migration may be partially applied there,
+// while Postgres rolls the whole migration back
+func (m *Migrator) run(ctx context.Context, conn *sql.Conn, script, bookkeeping string, args ...interface{}) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() // nolint: errcheck
+
+	for _, statement := range splitStatements(script) {
+		if _, err := tx.ExecContext(ctx, statement); err != nil {
+			return err
+		}
+	}
+	if _, err := tx.ExecContext(ctx, bookkeeping, args...); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// status merges the known migrations with the rows of the migrations table.
+// Rows of migrations unknown to this binary (applied by a newer release) are ignored
+func (m *Migrator) status(ctx context.Context, conn *sql.Conn) ([]Status, error) {
+	rows, err := conn.QueryContext(ctx, fmt.Sprintf("SELECT version, name, checksum, applied_at FROM %s", TableName))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", TableName, err)
+	}
+	defer rows.Close()
+
+	rowsByVersion := map[int64]applied{}
+	for rows.Next() {
+		var a applied
+		if err := rows.Scan(&a.version, &a.name, &a.checksum, &a.appliedAt); err != nil {
+			return nil, err
+		}
+		rowsByVersion[a.version] = a
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	statuses := make([]Status, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		s := Status{Migration: migration}
+		if a, ok := rowsByVersion[migration.Version]; ok {
+			s.Applied = true
+			s.AppliedAt = a.appliedAt
+			s.ChecksumMismatch = a.checksum != migration.Checksum()
+		}
+		statuses = append(statuses, s)
+	}
+	return statuses, nil
+}
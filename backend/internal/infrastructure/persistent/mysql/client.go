@@ -2,63 +2,87 @@ package mysql
 
 import (
 	"context"
-	"database/sql"
+	"errors"
 	"fmt"
-	"sync"
-	"time"
+	"net"
 
-	_ "github.com/go-sql-driver/mysql"
-	sqlcommentercore "github.com/google/sqlcommenter/go/core"
-	gosql "github.com/google/sqlcommenter/go/database/sql"
+	"github.com/go-sql-driver/mysql"
 	"github.com/niko-cb/uct/internal/infrastructure/monitor/log"
+	"github.com/niko-cb/uct/internal/infrastructure/persistent/sqldb"
 	"github.com/niko-cb/uct/internal/infrastructure/web/config"
+	semconv "go.opentelemetry.io/otel/semconv/v1.25.0"
+)
+
+const (
+	defaultPort = "3306"
+
+	// MySQL error numbers of transactions aborted because of lock contention, which can be retried
+	errDeadlock        = 1213
+	errLockWaitTimeout = 1205
 )
 
 type MySQLClient struct {
-	*sql.DB
+	*sqldb.Pool
 	dbHost string
 	dbPort string
 	dbUser string
 	dbPass string
 	dbName string
-	once   sync.Once
 }
 
-func NewMySQLClient(cfg *config.Config) *MySQLClient {
-	return &MySQLClient{
-		DB:     &sql.DB{},
+// NewMySQLClient opens the connection pool shared by the whole application and waits until
+// the database answers, retrying with an exponential backoff. It fails if the database is
+// still unreachable after the configured attempts, so a misconfigured instance never starts.
+// The returned cleanup function closes the pool
+func NewMySQLClient(cfg *config.Config) (*MySQLClient, func(), error) {
+	c := &MySQLClient{
 		dbUser: cfg.DbUser,
 		dbPass: cfg.DbPass,
 		dbHost: cfg.DbHost,
 		dbPort: cfg.DbPort,
 		dbName: cfg.DbName,
 	}
-}
-
-func (c *MySQLClient) Connect() {
-	// We only want to establish the connection once
-	// Other calls to Connect() should not re-establish the connection, but just reuse the existing one
-	c.once.Do(func() {
-		dbPool, err := gosql.Open("mysql", c.uri(),
-			sqlcommentercore.CommenterOptions{},
-		)
-		if err != nil {
-			log.Error(context.Background(), fmt.Errorf("failed to open database connection: %+v", err))
-			return
-		}
+	if c.dbPort == "" {
+		c.dbPort = defaultPort
+	}
 
-		dbPool.SetMaxIdleConns(10)
-		dbPool.SetMaxOpenConns(90)
-		dbPool.SetConnMaxLifetime(100 * time.Second)
+	var replicas []sqldb.Replica
+	for _, address := range cfg.DbReplicaHosts {
+		host, port := sqldb.SplitAddress(address, defaultPort)
+		replicas = append(replicas, sqldb.Replica{Name: net.JoinHostPort(host, port), DSN: c.uri(host, port)})
+	}
 
-		c.DB = dbPool
+	pool, err := sqldb.Open(sqldb.Options{
+		Driver:      "mysql",
+		DSN:         c.uri(c.dbHost, c.dbPort),
+		System:      semconv.DBSystemMySQL,
+		Name:        c.dbName,
+		Application: cfg.ServiceName,
+		TxRetries:   cfg.DbTxRetries,
+		Retryable:   isRetryable,
+
+		Replicas:             replicas,
+		ReplicaCheckInterval: cfg.DbReplicaCheckInterval,
+		ReadYourWritesWindow: cfg.DbReadYourWritesWindow,
 	})
+	if err != nil {
+		return nil, nil, err
+	}
+	c.Pool = pool
+
+	if err := pool.Wait(cfg.DbConnectAttempts, cfg.DbConnectBackoff); err != nil {
+		pool.Cleanup()
+		return nil, nil, err
+	}
+	log.Info(context.Background(), fmt.Sprintf("connected to database %s:%s/%s", c.dbHost, c.dbPort, c.dbName))
+
+	return c, pool.Cleanup, nil
 }
 
-// uri returns the connection string for the MySQL database
-func (c *MySQLClient) uri() string {
+// uri returns the connection string for the MySQL database at host:port
+func (c *MySQLClient) uri(host, port string) string {
 	connectionType := "tcp"
-	connectionDetails := fmt.Sprintf("(%s:%s)", c.dbHost, c.dbPort)
+	connectionDetails := fmt.Sprintf("(%s)", net.JoinHostPort(host, port))
 
 	dbURI := fmt.Sprintf("%s:%s@%s%s/%s?parseTime=true",
 		c.dbUser, c.dbPass, connectionType, connectionDetails, c.dbName)
@@ -66,44 +90,11 @@ func (c *MySQLClient) uri() string {
 	return dbURI
 }
 
-func (c *MySQLClient) CtxTxKey() interface{} {
-	return "tx"
-}
-
-// DoInTx executes the given function in a transaction
-func (c *MySQLClient) DoInTx(ctx context.Context, f func(context.Context) error) error {
-	c.Connect() // Ensure connection is established
-
-	tx, err := c.Begin()
-	if err != nil {
-		log.Error(ctx, fmt.Errorf("%+v\n", err))
-		return err
+// isRetryable reports whether err aborted the transaction because of lock contention
+func isRetryable(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	if !errors.As(err, &mysqlErr) {
+		return false
 	}
-	ctx = context.WithValue(ctx, c.CtxTxKey(), tx)
-	log.Debug(ctx, "transaction started")
-
-	var done bool
-	defer func() {
-		if !done {
-			log.Debug(ctx, "transaction rollback")
-			err = tx.Rollback()
-			if err != nil {
-				log.Error(ctx, fmt.Errorf("%+v\n", err))
-			}
-		}
-	}()
-
-	if err = f(ctx); err != nil {
-		log.Error(ctx, fmt.Errorf("%+v\n", err))
-		return err
-	}
-
-	done = true
-	if err = tx.Commit(); err != nil {
-		log.Error(ctx, fmt.Errorf("%+v\n", err))
-		return err
-	}
-
-	log.Debug(ctx, "transaction done and committed")
-	return nil
+	return mysqlErr.Number == errDeadlock || mysqlErr.Number == errLockWaitTimeout
 }
@@ -0,0 +1,29 @@
+package mysql
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"deadlock", &mysql.MySQLError{Number: errDeadlock}, true},
+		{"lock wait timeout", &mysql.MySQLError{Number: errLockWaitTimeout}, true},
+		{"wrapped deadlock", fmt.Errorf("insert failed: %w", &mysql.MySQLError{Number: errDeadlock}), true},
+		{"duplicate entry", &mysql.MySQLError{Number: 1062}, false},
+		{"other error", errors.New("boom"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, isRetryable(tt.err))
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package mysql
+
+import (
+	"embed"
+	"io/fs"
+
+	"github.com/niko-cb/uct/internal/infrastructure/persistent/migration"
+)
+
+// migrations holds the versioned schema changes of the MySQL database
+//
+//go:embed migrations/*.sql
+var migrations embed.FS
+
+// NewMigrator creates the migrator of the MySQL schema
+func NewMigrator(c *MySQLClient) (*migration.Migrator, error) {
+	fsys, err := fs.Sub(migrations, "migrations")
+	if err != nil {
+		return nil, err
+	}
+	return migration.NewMigrator(c, migration.MySQL, fsys)
+}
+
+// Migrations returns the migration files, used to check the other dialects match them
+func Migrations() (fs.FS, error) {
+	return fs.Sub(migrations, "migrations")
+}
@@ -0,0 +1,15 @@
+package mysql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestNewMigrator makes sure the embedded migrations are valid
+func TestNewMigrator(t *testing.T) {
+	migrator, err := NewMigrator(&MySQLClient{})
+
+	assert.NoError(t, err)
+	assert.NotNil(t, migrator)
+}
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS invoices;
+DROP TABLE IF EXISTS bank_accounts;
+DROP TABLE IF EXISTS clients;
+DROP TABLE IF EXISTS users;
+DROP TABLE IF EXISTS companies;
@@ -1,4 +1,5 @@
-USE `uct`;
+-- Initial schema, previously created by db/02_tables.sql.
+-- Tables are only created if missing so databases initialized by that script can adopt the migrations.
 
 -- Table to store companies
 CREATE TABLE IF NOT EXISTS companies (
@@ -54,4 +55,4 @@ CREATE TABLE IF NOT EXISTS invoices (
     status VARCHAR(50) NOT NULL,
     FOREIGN KEY (company_id) REFERENCES companies(id) ON DELETE CASCADE,
     FOREIGN KEY (client_id) REFERENCES clients(id) ON DELETE CASCADE
-);
\ No newline at end of file
+);
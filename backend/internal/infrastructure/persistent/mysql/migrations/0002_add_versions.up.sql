@@ -0,0 +1,6 @@
+-- Row versions for optimistic concurrency control: updates and deletes only apply to the version
+-- the client read (WHERE id = ? AND version = ?), and bump it.
+
+ALTER TABLE invoices ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
+ALTER TABLE clients ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
+ALTER TABLE bank_accounts ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
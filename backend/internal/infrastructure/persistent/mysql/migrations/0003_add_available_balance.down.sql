@@ -0,0 +1 @@
+ALTER TABLE companies DROP COLUMN available_balance;
@@ -0,0 +1,4 @@
+-- Funds a company has available to pay its invoices, compared to the upcoming payouts by the cash forecast.
+-- NULL when the company has not configured it.
+
+ALTER TABLE companies ADD COLUMN available_balance DECIMAL(15,2) NULL;
@@ -0,0 +1,16 @@
+-- Lines of the invoices. The payment amount of an invoice with lines is the sum of their amounts,
+-- which are quantity * unit_price rounded to 2 decimals, consumption tax included.
+-- tax_category is the consumption tax rate of the line: standard (10%) or reduced (8%).
+
+CREATE TABLE IF NOT EXISTS invoice_items (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    invoice_id BIGINT NOT NULL,
+    line_no INT NOT NULL,
+    description VARCHAR(255) NOT NULL,
+    quantity DECIMAL(15,3) NOT NULL,
+    unit_price DECIMAL(15,2) NOT NULL,
+    amount DECIMAL(15,2) NOT NULL,
+    tax_category VARCHAR(20) NOT NULL,
+    UNIQUE KEY uq_invoice_items_line (invoice_id, line_no),
+    FOREIGN KEY (invoice_id) REFERENCES invoices(id) ON DELETE CASCADE
+);
@@ -0,0 +1,16 @@
+-- Consumption tax under the qualified invoice system: the tax of each rate is rounded once per invoice,
+-- to the yen, the way the company configured (floor, round or ceil).
+-- invoice_tax_subtotals is the breakdown of the items of an invoice by rate: their total (tax included) and its tax.
+
+ALTER TABLE companies ADD COLUMN tax_rounding VARCHAR(10) NOT NULL DEFAULT 'floor';
+
+CREATE TABLE IF NOT EXISTS invoice_tax_subtotals (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    invoice_id BIGINT NOT NULL,
+    tax_category VARCHAR(20) NOT NULL,
+    rate INT NOT NULL,
+    amount DECIMAL(15,2) NOT NULL,
+    tax_amount DECIMAL(15,2) NOT NULL,
+    UNIQUE KEY uq_invoice_tax_subtotals_category (invoice_id, tax_category),
+    FOREIGN KEY (invoice_id) REFERENCES invoices(id) ON DELETE CASCADE
+);
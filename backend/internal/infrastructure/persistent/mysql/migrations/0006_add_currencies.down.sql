@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS fx_rates;
+
+ALTER TABLE invoices DROP COLUMN fx_rate;
+ALTER TABLE invoices DROP COLUMN currency;
+
+ALTER TABLE companies DROP COLUMN base_currency;
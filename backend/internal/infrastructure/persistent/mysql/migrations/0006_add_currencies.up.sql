@@ -0,0 +1,17 @@
+-- Invoices in foreign currencies. The amounts of an invoice are in its currency; fx_rate converts them to the
+-- base currency of its company, with the rate of the issue date snapshotted when the invoice is created.
+-- fx_rates holds the daily rates, imported from CSV files: 1 currency = rate base_currency.
+
+ALTER TABLE companies ADD COLUMN base_currency CHAR(3) NOT NULL DEFAULT 'JPY';
+
+ALTER TABLE invoices ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'JPY';
+ALTER TABLE invoices ADD COLUMN fx_rate DECIMAL(18,8) NOT NULL DEFAULT 1;
+
+CREATE TABLE IF NOT EXISTS fx_rates (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    currency CHAR(3) NOT NULL,
+    base_currency CHAR(3) NOT NULL,
+    rate_date DATE NOT NULL,
+    rate DECIMAL(18,8) NOT NULL,
+    UNIQUE KEY uq_fx_rates_pair_date (currency, base_currency, rate_date)
+);
@@ -0,0 +1,19 @@
+-- Credit notes, received when a client overbilled: each one credits part of the payment amount of an invoice
+-- (its currency and rate), with the fee and its tax reversed accordingly. The outstanding balance of an invoice is
+-- its total amount minus the total amount of its credit notes which are not void.
+-- status is issued (received), applied (deducted from a payout or refunded) or void (cancelled).
+
+CREATE TABLE IF NOT EXISTS credit_notes (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    invoice_id BIGINT NOT NULL,
+    issue_date DATE NOT NULL,
+    reason VARCHAR(255) NOT NULL,
+    payment_amount DECIMAL(15,2) NOT NULL,
+    fee_amount DECIMAL(15,2) NOT NULL,
+    tax_amount DECIMAL(15,2) NOT NULL,
+    total_amount DECIMAL(15,2) NOT NULL,
+    status VARCHAR(50) NOT NULL DEFAULT 'issued',
+    version BIGINT NOT NULL DEFAULT 1,
+    INDEX idx_credit_notes_issue_date (issue_date),
+    FOREIGN KEY (invoice_id) REFERENCES invoices(id) ON DELETE CASCADE
+);
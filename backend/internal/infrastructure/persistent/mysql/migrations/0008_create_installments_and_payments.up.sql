@@ -0,0 +1,27 @@
+-- Installments of the invoices, their total amount split into amounts due at their own dates, numbered from 1
+-- by due date. The amounts of the installments of an invoice add up to its total amount, and the due date of
+-- the invoice is the one of its last installment. An invoice without installments is due at once.
+
+CREATE TABLE IF NOT EXISTS invoice_installments (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    invoice_id BIGINT NOT NULL,
+    installment_no INT NOT NULL,
+    due_date DATE NOT NULL,
+    amount DECIMAL(15,2) NOT NULL,
+    UNIQUE KEY uq_invoice_installments_no (invoice_id, installment_no),
+    INDEX idx_invoice_installments_due_date (due_date),
+    FOREIGN KEY (invoice_id) REFERENCES invoices(id) ON DELETE CASCADE
+);
+
+-- Payments recorded against the invoices, partial or not. The outstanding balance of an invoice is its total amount
+-- minus its credit notes which are not void and its payments; its status is derived from them: partially_paid until
+-- nothing is left, then paid.
+
+CREATE TABLE IF NOT EXISTS invoice_payments (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    invoice_id BIGINT NOT NULL,
+    payment_date DATE NOT NULL,
+    amount DECIMAL(15,2) NOT NULL,
+    reference VARCHAR(255) NOT NULL DEFAULT '',
+    FOREIGN KEY (invoice_id) REFERENCES invoices(id) ON DELETE CASCADE
+);
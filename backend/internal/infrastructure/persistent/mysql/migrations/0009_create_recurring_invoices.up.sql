@@ -0,0 +1,27 @@
+-- Recurring invoices, templates of the invoices a client is billed at regular dates (rent, subscriptions, retainers).
+-- frequency is monthly (on day_of_month, the last day of the shorter months), month_end or weekly, every `every`
+-- months or weeks from start_date, until end_date and for at most max_occurrences occurrences if set.
+-- next_date is the next occurrence to generate an invoice for, NULL once the schedule ended: the generator creates
+-- the invoice and moves next_date in the same transaction, at the version read, so each occurrence is billed once.
+-- status is active or paused, a paused template skips the occurrences until it is resumed.
+
+CREATE TABLE IF NOT EXISTS recurring_invoices (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    company_id BIGINT NOT NULL,
+    client_id BIGINT NOT NULL,
+    frequency VARCHAR(50) NOT NULL,
+    every INT NOT NULL DEFAULT 1,
+    day_of_month INT NOT NULL DEFAULT 0,
+    start_date DATE NOT NULL,
+    end_date DATE NULL,
+    max_occurrences INT NOT NULL DEFAULT 0,
+    payment_amount DECIMAL(15,2) NOT NULL,
+    currency CHAR(3) NOT NULL,
+    due_days INT NOT NULL,
+    status VARCHAR(50) NOT NULL DEFAULT 'active',
+    next_date DATE NULL,
+    version BIGINT NOT NULL DEFAULT 1,
+    INDEX idx_recurring_invoices_next_date (next_date),
+    FOREIGN KEY (company_id) REFERENCES companies(id) ON DELETE CASCADE,
+    FOREIGN KEY (client_id) REFERENCES clients(id) ON DELETE CASCADE
+);
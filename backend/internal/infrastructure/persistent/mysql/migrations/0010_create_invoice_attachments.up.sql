@@ -0,0 +1,14 @@
+-- Documents attached to the invoices, e.g. the PDF of the supplier. Only their metadata is stored here: their content
+-- is in the blob store, under a key derived from its SHA-256 hash, so identical files are stored once. The same file
+-- is attached once to an invoice.
+
+CREATE TABLE IF NOT EXISTS invoice_attachments (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    invoice_id BIGINT NOT NULL,
+    file_name VARCHAR(255) NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    size BIGINT NOT NULL,
+    sha256 CHAR(64) NOT NULL,
+    UNIQUE KEY uq_invoice_attachments_sha256 (invoice_id, sha256),
+    FOREIGN KEY (invoice_id) REFERENCES invoices(id) ON DELETE CASCADE
+);
@@ -0,0 +1,2 @@
+DROP INDEX idx_clients_registration_number ON clients;
+ALTER TABLE clients DROP COLUMN registration_number;
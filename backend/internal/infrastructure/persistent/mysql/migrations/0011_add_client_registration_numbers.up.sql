@@ -0,0 +1,5 @@
+-- Registration number of the clients as qualified invoice issuers (適格請求書発行事業者登録番号, T + 13 digits),
+-- which identifies the seller of the electronic invoices imported from UBL documents. NULL when unknown.
+
+ALTER TABLE clients ADD COLUMN registration_number VARCHAR(14) NULL;
+CREATE INDEX idx_clients_registration_number ON clients (company_id, registration_number);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS invoice_issuers;
+ALTER TABLE companies DROP COLUMN registration_number;
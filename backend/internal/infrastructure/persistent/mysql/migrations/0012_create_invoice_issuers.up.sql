@@ -0,0 +1,17 @@
+-- Registration number of the companies as qualified invoice issuers, like the one of the clients. NULL when unknown.
+-- invoice_issuers holds the qualified invoice issuers published by the National Tax Agency, imported from its
+-- downloadable dataset, to check the clients are registered on the issue date of their invoices.
+-- revoked_on is the date the registration was revoked (取消), expired_on the date it ceased (失効): the issuer is
+-- registered from registered_on to the day before the earliest of them.
+
+ALTER TABLE companies ADD COLUMN registration_number VARCHAR(14) NULL;
+
+CREATE TABLE IF NOT EXISTS invoice_issuers (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    registration_number VARCHAR(14) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    registered_on DATE NOT NULL,
+    revoked_on DATE NULL,
+    expired_on DATE NULL,
+    UNIQUE KEY uq_invoice_issuers_registration_number (registration_number)
+);
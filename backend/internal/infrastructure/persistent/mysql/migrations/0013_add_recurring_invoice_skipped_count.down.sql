@@ -0,0 +1 @@
+ALTER TABLE recurring_invoices DROP COLUMN skipped_count;
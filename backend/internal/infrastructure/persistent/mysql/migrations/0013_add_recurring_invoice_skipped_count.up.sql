@@ -0,0 +1,4 @@
+-- Occurrences a recurring invoice skipped while it was paused. They don't count toward max_occurrences, which bounds
+-- the invoices generated: the occurrences before next_date less the skipped ones.
+
+ALTER TABLE recurring_invoices ADD COLUMN skipped_count INT NOT NULL DEFAULT 0;
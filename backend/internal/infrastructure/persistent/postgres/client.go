@@ -0,0 +1,108 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+
+	"github.com/lib/pq"
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/log"
+	"github.com/niko-cb/uct/internal/infrastructure/persistent/sqldb"
+	"github.com/niko-cb/uct/internal/infrastructure/web/config"
+	semconv "go.opentelemetry.io/otel/semconv/v1.25.0"
+)
+
+const (
+	defaultPort = "5432"
+
+	// SQLSTATEs of transactions aborted because of lock contention, which can be retried
+	errSerializationFailure = "40001"
+	errDeadlockDetected     = "40P01"
+	errLockNotAvailable     = "55P03"
+)
+
+type PostgresClient struct {
+	*sqldb.Pool
+	dbHost    string
+	dbPort    string
+	dbUser    string
+	dbPass    string
+	dbName    string
+	dbSSLMode string
+}
+
+// NewPostgresClient opens the connection pool shared by the whole application and waits until
+// the database answers, like NewMySQLClient does. The returned cleanup function closes the pool
+func NewPostgresClient(cfg *config.Config) (*PostgresClient, func(), error) {
+	c := &PostgresClient{
+		dbUser:    cfg.DbUser,
+		dbPass:    cfg.DbPass,
+		dbHost:    cfg.DbHost,
+		dbPort:    cfg.DbPort,
+		dbName:    cfg.DbName,
+		dbSSLMode: cfg.DbSSLMode,
+	}
+	if c.dbPort == "" {
+		c.dbPort = defaultPort
+	}
+
+	var replicas []sqldb.Replica
+	for _, address := range cfg.DbReplicaHosts {
+		host, port := sqldb.SplitAddress(address, defaultPort)
+		replicas = append(replicas, sqldb.Replica{Name: net.JoinHostPort(host, port), DSN: c.uri(host, port)})
+	}
+
+	pool, err := sqldb.Open(sqldb.Options{
+		Driver:      "postgres",
+		DSN:         c.uri(c.dbHost, c.dbPort),
+		System:      semconv.DBSystemPostgreSQL,
+		Name:        c.dbName,
+		Application: cfg.ServiceName,
+		TxRetries:   cfg.DbTxRetries,
+		Retryable:   isRetryable,
+
+		Replicas:             replicas,
+		ReplicaCheckInterval: cfg.DbReplicaCheckInterval,
+		ReadYourWritesWindow: cfg.DbReadYourWritesWindow,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	c.Pool = pool
+
+	if err := pool.Wait(cfg.DbConnectAttempts, cfg.DbConnectBackoff); err != nil {
+		pool.Cleanup()
+		return nil, nil, err
+	}
+	log.Info(context.Background(), fmt.Sprintf("connected to database %s:%s/%s", c.dbHost, c.dbPort, c.dbName))
+
+	return c, pool.Cleanup, nil
+}
+
+// uri returns the connection string for the Postgres database at host:port
+func (c *PostgresClient) uri(host, port string) string {
+	u := url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(c.dbUser, c.dbPass),
+		Host:     net.JoinHostPort(host, port),
+		Path:     c.dbName,
+		RawQuery: url.Values{"sslmode": {c.dbSSLMode}}.Encode(),
+	}
+	return u.String()
+}
+
+// isRetryable reports whether err aborted the transaction because of lock contention
+func isRetryable(err error) bool {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return false
+	}
+	switch pqErr.Code {
+	case errSerializationFailure, errDeadlockDetected, errLockNotAvailable:
+		return true
+	default:
+		return false
+	}
+}
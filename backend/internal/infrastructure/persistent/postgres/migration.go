@@ -0,0 +1,28 @@
+package postgres
+
+import (
+	"embed"
+	"io/fs"
+
+	"github.com/niko-cb/uct/internal/infrastructure/persistent/migration"
+)
+
+// migrations holds the versioned schema changes of the Postgres database.
+// They mirror the MySQL ones: every version exists in both dialects
+//
+//go:embed migrations/*.sql
+var migrations embed.FS
+
+// NewMigrator creates the migrator of the Postgres schema
+func NewMigrator(c *PostgresClient) (*migration.Migrator, error) {
+	fsys, err := fs.Sub(migrations, "migrations")
+	if err != nil {
+		return nil, err
+	}
+	return migration.NewMigrator(c, migration.Postgres, fsys)
+}
+
+// Migrations returns the migration files, used to check they match the MySQL ones
+func Migrations() (fs.FS, error) {
+	return fs.Sub(migrations, "migrations")
+}
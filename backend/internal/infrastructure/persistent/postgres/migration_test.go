@@ -0,0 +1,40 @@
+package postgres
+
+import (
+	"fmt"
+	"io/fs"
+	"testing"
+
+	"github.com/niko-cb/uct/internal/infrastructure/persistent/migration"
+	"github.com/niko-cb/uct/internal/infrastructure/persistent/mysql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestNewMigrator makes sure the embedded migrations are valid
+func TestNewMigrator(t *testing.T) {
+	migrator, err := NewMigrator(&PostgresClient{})
+
+	assert.NoError(t, err)
+	assert.NotNil(t, migrator)
+}
+
+// TestMigrationsMatchMySQL makes sure every migration exists in both dialects
+func TestMigrationsMatchMySQL(t *testing.T) {
+	names := func(fsys fs.FS) []string {
+		migrations, err := migration.Load(fsys)
+		require.NoError(t, err)
+		var names []string
+		for _, m := range migrations {
+			names = append(names, fmt.Sprintf("%d_%s", m.Version, m.Name))
+		}
+		return names
+	}
+
+	pgFS, err := Migrations()
+	require.NoError(t, err)
+	mysqlFS, err := mysql.Migrations()
+	require.NoError(t, err)
+
+	assert.Equal(t, names(mysqlFS), names(pgFS))
+}
@@ -0,0 +1,59 @@
+-- Initial schema, the Postgres counterpart of the MySQL migration of the same version.
+
+-- Table to store companies
+CREATE TABLE IF NOT EXISTS companies (
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    owner_name VARCHAR(255) NOT NULL DEFAULT 'Unknown',
+    phone VARCHAR(20),
+    address TEXT
+);
+
+-- Table to store users associated with a company
+CREATE TABLE IF NOT EXISTS users (
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    company_id BIGINT NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    email VARCHAR(255) UNIQUE NOT NULL,
+    password VARCHAR(255) NOT NULL
+);
+
+-- Table to store client information
+CREATE TABLE IF NOT EXISTS clients (
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    company_id BIGINT NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    phone VARCHAR(20),
+    address TEXT
+);
+
+-- Table to store bank account information for clients
+CREATE TABLE IF NOT EXISTS bank_accounts (
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    client_id BIGINT NOT NULL REFERENCES clients(id) ON DELETE CASCADE,
+    bank_name VARCHAR(255) NOT NULL,
+    branch VARCHAR(255) NOT NULL,
+    account_no VARCHAR(255) NOT NULL,
+    holder VARCHAR(255) NOT NULL
+);
+
+-- Table to store invoices associated with companies and clients
+CREATE TABLE IF NOT EXISTS invoices (
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    company_id BIGINT NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+    client_id BIGINT NOT NULL REFERENCES clients(id) ON DELETE CASCADE,
+    issue_date DATE NOT NULL,
+    due_date DATE NOT NULL,
+    payment_amount NUMERIC(15,2) NOT NULL,
+    fee_amount NUMERIC(15,2) NOT NULL,
+    tax_amount NUMERIC(15,2) NOT NULL,
+    total_amount NUMERIC(15,2) NOT NULL,
+    status VARCHAR(50) NOT NULL
+);
+
+-- MySQL indexes foreign keys implicitly, Postgres doesn't
+CREATE INDEX IF NOT EXISTS users_company_id_idx ON users (company_id);
+CREATE INDEX IF NOT EXISTS clients_company_id_idx ON clients (company_id);
+CREATE INDEX IF NOT EXISTS bank_accounts_client_id_idx ON bank_accounts (client_id);
+CREATE INDEX IF NOT EXISTS invoices_company_id_idx ON invoices (company_id);
+CREATE INDEX IF NOT EXISTS invoices_client_id_idx ON invoices (client_id);
@@ -0,0 +1,3 @@
+ALTER TABLE bank_accounts DROP COLUMN version;
+ALTER TABLE clients DROP COLUMN version;
+ALTER TABLE invoices DROP COLUMN version;
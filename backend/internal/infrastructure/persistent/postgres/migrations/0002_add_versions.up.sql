@@ -0,0 +1,5 @@
+-- Row versions for optimistic concurrency control, see the MySQL migration of the same version.
+
+ALTER TABLE invoices ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
+ALTER TABLE clients ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
+ALTER TABLE bank_accounts ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
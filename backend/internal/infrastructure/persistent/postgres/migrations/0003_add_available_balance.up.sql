@@ -0,0 +1,3 @@
+-- Funds a company has available to pay its invoices, see the MySQL migration of the same version.
+
+ALTER TABLE companies ADD COLUMN IF NOT EXISTS available_balance NUMERIC(15,2) NULL;
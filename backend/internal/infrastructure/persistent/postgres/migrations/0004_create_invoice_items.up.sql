@@ -0,0 +1,13 @@
+-- Lines of the invoices, see the MySQL migration of the same version.
+
+CREATE TABLE IF NOT EXISTS invoice_items (
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    invoice_id BIGINT NOT NULL REFERENCES invoices(id) ON DELETE CASCADE,
+    line_no INT NOT NULL,
+    description VARCHAR(255) NOT NULL,
+    quantity NUMERIC(15,3) NOT NULL,
+    unit_price NUMERIC(15,2) NOT NULL,
+    amount NUMERIC(15,2) NOT NULL,
+    tax_category VARCHAR(20) NOT NULL,
+    CONSTRAINT uq_invoice_items_line UNIQUE (invoice_id, line_no)
+);
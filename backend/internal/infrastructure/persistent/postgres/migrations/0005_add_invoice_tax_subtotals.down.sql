@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS invoice_tax_subtotals;
+
+ALTER TABLE companies DROP COLUMN IF EXISTS tax_rounding;
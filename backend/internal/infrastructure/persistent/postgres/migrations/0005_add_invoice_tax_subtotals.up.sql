@@ -0,0 +1,13 @@
+-- Consumption tax under the qualified invoice system, see the MySQL migration of the same version.
+
+ALTER TABLE companies ADD COLUMN IF NOT EXISTS tax_rounding VARCHAR(10) NOT NULL DEFAULT 'floor';
+
+CREATE TABLE IF NOT EXISTS invoice_tax_subtotals (
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    invoice_id BIGINT NOT NULL REFERENCES invoices(id) ON DELETE CASCADE,
+    tax_category VARCHAR(20) NOT NULL,
+    rate INT NOT NULL,
+    amount NUMERIC(15,2) NOT NULL,
+    tax_amount NUMERIC(15,2) NOT NULL,
+    CONSTRAINT uq_invoice_tax_subtotals_category UNIQUE (invoice_id, tax_category)
+);
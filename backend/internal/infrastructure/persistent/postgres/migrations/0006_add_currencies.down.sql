@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS fx_rates;
+
+ALTER TABLE invoices DROP COLUMN IF EXISTS fx_rate;
+ALTER TABLE invoices DROP COLUMN IF EXISTS currency;
+
+ALTER TABLE companies DROP COLUMN IF EXISTS base_currency;
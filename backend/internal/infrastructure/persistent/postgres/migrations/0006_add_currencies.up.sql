@@ -0,0 +1,15 @@
+-- Invoices in foreign currencies, see the MySQL migration of the same version.
+
+ALTER TABLE companies ADD COLUMN IF NOT EXISTS base_currency CHAR(3) NOT NULL DEFAULT 'JPY';
+
+ALTER TABLE invoices ADD COLUMN IF NOT EXISTS currency CHAR(3) NOT NULL DEFAULT 'JPY';
+ALTER TABLE invoices ADD COLUMN IF NOT EXISTS fx_rate NUMERIC(18,8) NOT NULL DEFAULT 1;
+
+CREATE TABLE IF NOT EXISTS fx_rates (
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    currency CHAR(3) NOT NULL,
+    base_currency CHAR(3) NOT NULL,
+    rate_date DATE NOT NULL,
+    rate NUMERIC(18,8) NOT NULL,
+    CONSTRAINT uq_fx_rates_pair_date UNIQUE (currency, base_currency, rate_date)
+);
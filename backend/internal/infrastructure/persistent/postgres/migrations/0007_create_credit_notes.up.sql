@@ -0,0 +1,17 @@
+-- Credit notes, see the MySQL migration of the same version.
+
+CREATE TABLE IF NOT EXISTS credit_notes (
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    invoice_id BIGINT NOT NULL REFERENCES invoices(id) ON DELETE CASCADE,
+    issue_date DATE NOT NULL,
+    reason VARCHAR(255) NOT NULL,
+    payment_amount NUMERIC(15,2) NOT NULL,
+    fee_amount NUMERIC(15,2) NOT NULL,
+    tax_amount NUMERIC(15,2) NOT NULL,
+    total_amount NUMERIC(15,2) NOT NULL,
+    status VARCHAR(50) NOT NULL DEFAULT 'issued',
+    version BIGINT NOT NULL DEFAULT 1
+);
+
+CREATE INDEX IF NOT EXISTS idx_credit_notes_invoice_id ON credit_notes (invoice_id);
+CREATE INDEX IF NOT EXISTS idx_credit_notes_issue_date ON credit_notes (issue_date);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS invoice_payments;
+DROP TABLE IF EXISTS invoice_installments;
@@ -0,0 +1,22 @@
+-- Installments and payments of the invoices, see the MySQL migration of the same version.
+
+CREATE TABLE IF NOT EXISTS invoice_installments (
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    invoice_id BIGINT NOT NULL REFERENCES invoices(id) ON DELETE CASCADE,
+    installment_no INT NOT NULL,
+    due_date DATE NOT NULL,
+    amount NUMERIC(15,2) NOT NULL,
+    CONSTRAINT uq_invoice_installments_no UNIQUE (invoice_id, installment_no)
+);
+
+CREATE INDEX IF NOT EXISTS idx_invoice_installments_due_date ON invoice_installments (due_date);
+
+CREATE TABLE IF NOT EXISTS invoice_payments (
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    invoice_id BIGINT NOT NULL REFERENCES invoices(id) ON DELETE CASCADE,
+    payment_date DATE NOT NULL,
+    amount NUMERIC(15,2) NOT NULL,
+    reference VARCHAR(255) NOT NULL DEFAULT ''
+);
+
+CREATE INDEX IF NOT EXISTS idx_invoice_payments_invoice_id ON invoice_payments (invoice_id);
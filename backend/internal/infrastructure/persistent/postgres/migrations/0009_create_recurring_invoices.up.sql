@@ -0,0 +1,22 @@
+-- Recurring invoices, see the MySQL migration of the same version.
+
+CREATE TABLE IF NOT EXISTS recurring_invoices (
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    company_id BIGINT NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+    client_id BIGINT NOT NULL REFERENCES clients(id) ON DELETE CASCADE,
+    frequency VARCHAR(50) NOT NULL,
+    every INT NOT NULL DEFAULT 1,
+    day_of_month INT NOT NULL DEFAULT 0,
+    start_date DATE NOT NULL,
+    end_date DATE NULL,
+    max_occurrences INT NOT NULL DEFAULT 0,
+    payment_amount NUMERIC(15,2) NOT NULL,
+    currency CHAR(3) NOT NULL,
+    due_days INT NOT NULL,
+    status VARCHAR(50) NOT NULL DEFAULT 'active',
+    next_date DATE NULL,
+    version BIGINT NOT NULL DEFAULT 1
+);
+
+CREATE INDEX IF NOT EXISTS idx_recurring_invoices_client_id ON recurring_invoices (client_id);
+CREATE INDEX IF NOT EXISTS idx_recurring_invoices_next_date ON recurring_invoices (next_date);
@@ -0,0 +1,11 @@
+-- Attachments of the invoices, see the MySQL migration of the same version.
+
+CREATE TABLE IF NOT EXISTS invoice_attachments (
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    invoice_id BIGINT NOT NULL REFERENCES invoices(id) ON DELETE CASCADE,
+    file_name VARCHAR(255) NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    size BIGINT NOT NULL,
+    sha256 CHAR(64) NOT NULL,
+    CONSTRAINT uq_invoice_attachments_sha256 UNIQUE (invoice_id, sha256)
+);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_clients_registration_number;
+ALTER TABLE clients DROP COLUMN IF EXISTS registration_number;
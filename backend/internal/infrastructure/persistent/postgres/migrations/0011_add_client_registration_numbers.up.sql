@@ -0,0 +1,4 @@
+-- Registration number of the clients, see the MySQL migration of the same version.
+
+ALTER TABLE clients ADD COLUMN IF NOT EXISTS registration_number VARCHAR(14) NULL;
+CREATE INDEX IF NOT EXISTS idx_clients_registration_number ON clients (company_id, registration_number);
@@ -0,0 +1,13 @@
+-- Registration numbers of the companies and qualified invoice issuers, see the MySQL migration of the same version.
+
+ALTER TABLE companies ADD COLUMN IF NOT EXISTS registration_number VARCHAR(14) NULL;
+
+CREATE TABLE IF NOT EXISTS invoice_issuers (
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    registration_number VARCHAR(14) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    registered_on DATE NOT NULL,
+    revoked_on DATE NULL,
+    expired_on DATE NULL,
+    CONSTRAINT uq_invoice_issuers_registration_number UNIQUE (registration_number)
+);
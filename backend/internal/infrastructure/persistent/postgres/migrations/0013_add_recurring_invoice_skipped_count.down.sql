@@ -0,0 +1 @@
+ALTER TABLE recurring_invoices DROP COLUMN IF EXISTS skipped_count;
@@ -0,0 +1,3 @@
+-- Occurrences skipped by the recurring invoices, see the MySQL migration of the same version.
+
+ALTER TABLE recurring_invoices ADD COLUMN IF NOT EXISTS skipped_count INT NOT NULL DEFAULT 0;
@@ -0,0 +1,195 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/XSAM/otelsql"
+	sqlcommentercore "github.com/google/sqlcommenter/go/core"
+	gosql "github.com/google/sqlcommenter/go/database/sql"
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/log"
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/metrics"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.25.0"
+)
+
+// Pool is a connection pool with the transaction handling shared by every SQL database.
+// The database specific clients embed it
+type Pool struct {
+	*sql.DB
+	// txRetries is how many times DoInTx retries a transaction aborted by lock contention
+	txRetries int
+	// retryable reports whether an error aborted the transaction because of lock contention
+	retryable func(err error) bool
+	// replicas are where reads outside a transaction go, if any
+	replicas replicas
+}
+
+// Replica is a read-only copy of the database
+type Replica struct {
+	// Name identifies the replica in the logs and metrics, e.g. its address
+	Name string
+	// DSN is the data source name passed to the driver
+	DSN string
+}
+
+// Options describes how to open a Pool
+type Options struct {
+	// Driver is the name of the registered database/sql driver
+	Driver string
+	// DSN is the data source name passed to the driver
+	DSN string
+	// System identifies the database in the spans, e.g. semconv.DBSystemMySQL
+	System attribute.KeyValue
+	// Name is the name of the database
+	Name string
+	// Application is the name of the service, added to each query by sqlcommenter
+	Application string
+	// TxRetries is how many times a transaction aborted by lock contention is retried
+	TxRetries int
+	// Retryable reports whether an error aborted the transaction because of lock contention
+	Retryable func(err error) bool
+
+	// Replicas are the read replicas, reads go to the primary only if empty
+	Replicas []Replica
+	// ReplicaCheckInterval is how often the replicas are pinged to take the unreachable ones out of rotation
+	ReplicaCheckInterval time.Duration
+	// ReadYourWritesWindow is how long a user reads from the primary after writing
+	ReadYourWritesWindow time.Duration
+}
+
+// Open creates the connection pool of the primary and of each replica. No connection is established
+// to the primary yet, while the replicas are checked right away so the unreachable ones are skipped
+func Open(opts Options) (*Pool, error) {
+	db, err := open(opts, opts.DSN, opts.Name)
+	if err != nil {
+		return nil, err
+	}
+	p := NewPool(db, opts.TxRetries, opts.Retryable)
+
+	if len(opts.Replicas) == 0 {
+		return p, nil
+	}
+	p.replicas.window = opts.ReadYourWritesWindow
+	if opts.ReplicaCheckInterval <= 0 {
+		opts.ReplicaCheckInterval = 5 * time.Second
+	}
+	for _, r := range opts.Replicas {
+		replicaDB, err := open(opts, r.DSN, fmt.Sprintf("%s@%s", opts.Name, r.Name))
+		if err != nil {
+			p.Cleanup()
+			return nil, err
+		}
+		p.AddReplica(r.Name, replicaDB)
+	}
+	p.replicas.check(opts.ReplicaCheckInterval)
+	p.replicas.watch(opts.ReplicaCheckInterval)
+	log.Info(context.Background(), fmt.Sprintf("reading from %d replica(s)", len(opts.Replicas)))
+
+	return p, nil
+}
+
+// open creates the connection pool of a single database, registering its metrics under name
+func open(opts Options, dsn, name string) (*sql.DB, error) {
+	// Wrap the driver so that every query gets its own span
+	driverName, err := otelsql.Register(opts.Driver,
+		otelsql.WithAttributes(opts.System, semconv.DBName(opts.Name)),
+		otelsql.WithSpanOptions(otelsql.SpanOptions{
+			DisableErrSkip:       true,
+			OmitConnResetSession: true,
+			OmitRows:             true,
+		}),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to register traced %s driver: %w", opts.Driver, err)
+	}
+
+	// sqlcommenter appends the route, action (controller) and traceparent of the request
+	// to each query so they can be correlated with the trace on the database side
+	db, err := gosql.Open(driverName, dsn,
+		sqlcommentercore.CommenterOptions{
+			Config: sqlcommentercore.CommenterConfig{
+				EnableDBDriver:    true,
+				EnableRoute:       true,
+				EnableFramework:   true,
+				EnableAction:      true,
+				EnableTraceparent: true,
+				EnableApplication: true,
+			},
+			Tags: sqlcommentercore.StaticTags{
+				Application: opts.Application,
+			},
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database connection: %w", err)
+	}
+
+	db.SetMaxIdleConns(10)
+	db.SetMaxOpenConns(90)
+	db.SetConnMaxLifetime(100 * time.Second)
+
+	if err := metrics.RegisterDB(name, db); err != nil {
+		log.Warning(context.Background(), fmt.Errorf("failed to register database metrics: %+v", err))
+	}
+
+	return db, nil
+}
+
+// NewPool wraps an opened database
+func NewPool(db *sql.DB, txRetries int, retryable func(err error) bool) *Pool {
+	return &Pool{
+		DB:        db,
+		txRetries: txRetries,
+		retryable: retryable,
+	}
+}
+
+// Wait pings the database until it answers, doubling the wait between attempts
+func (p *Pool) Wait(attempts int, backoff time.Duration) error {
+	ctx := context.Background()
+
+	var err error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		pingCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		err = p.PingContext(pingCtx)
+		cancel()
+		if err == nil {
+			return nil
+		}
+
+		if attempt < attempts {
+			log.Warning(ctx, fmt.Errorf("database not reachable (attempt %d/%d), retrying in %s: %w", attempt, attempts, backoff, err))
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+	}
+	return fmt.Errorf("database not reachable after %d attempts: %w", attempts, err)
+}
+
+// Cleanup closes the connection pools, it is the cleanup function of the clients' constructors
+func (p *Pool) Cleanup() {
+	p.replicas.close()
+
+	log.Info(context.Background(), "closing database connection pool")
+	if err := p.DB.Close(); err != nil {
+		log.Error(context.Background(), fmt.Errorf("failed to close database connection pool: %+v", err))
+	}
+}
+
+// Ping checks that the database is reachable
+func (p *Pool) Ping(ctx context.Context) error {
+	return p.PingContext(ctx)
+}
+
+// SplitAddress splits a "host" or "host:port" address, using defaultPort if there is no port
+func SplitAddress(address, defaultPort string) (host, port string) {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return address, defaultPort
+	}
+	return host, port
+}
@@ -0,0 +1,168 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/log"
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/metrics"
+	"github.com/niko-cb/uct/internal/infrastructure/web/config/actx"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+const (
+	targetPrimary = "primary"
+	targetReplica = "replica"
+)
+
+// replica is a read-only copy of the primary database
+type replica struct {
+	name    string
+	db      *sql.DB
+	healthy atomic.Bool
+}
+
+// replicas routes the reads made outside a transaction to the replicas, in turn.
+// A user who wrote recently reads from the primary so they see their own writes despite the replication lag
+type replicas struct {
+	list []*replica
+	next atomic.Uint64
+
+	// window is how long a user reads from the primary after a write
+	window time.Duration
+	// lastWrites holds the time of the last write of each user
+	lastWrites sync.Map
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// Reader returns where to run a read query: the transaction carried by ctx if any, the primary if
+// the user of ctx wrote within the read-your-writes window, or the next healthy replica.
+// The primary is used when there is no healthy replica
+func (p *Pool) Reader(ctx context.Context) boil.ContextExecutor {
+	if state, ok := p.transaction(ctx); ok {
+		return state.tx
+	}
+
+	if r := p.replicas.pick(ctx); r != nil {
+		metrics.DatabaseRead(targetReplica)
+		return r.db
+	}
+	metrics.DatabaseRead(targetPrimary)
+	return p.DB
+}
+
+// AddReplica adds a replica to read from. It is considered healthy until the first failed check
+func (p *Pool) AddReplica(name string, db *sql.DB) {
+	r := &replica{name: name, db: db}
+	r.healthy.Store(true)
+	metrics.ReplicaUp(name, true)
+	p.replicas.list = append(p.replicas.list, r)
+}
+
+// pick returns the replica the read of ctx should go to, or nil for the primary
+func (rs *replicas) pick(ctx context.Context) *replica {
+	if len(rs.list) == 0 || rs.wroteRecently(ctx) {
+		return nil
+	}
+
+	n := uint64(len(rs.list))
+	start := rs.next.Add(1)
+	for i := uint64(0); i < n; i++ {
+		if r := rs.list[(start+i)%n]; r.healthy.Load() {
+			return r
+		}
+	}
+	return nil
+}
+
+// wrote records that the user of ctx wrote to the primary
+func (rs *replicas) wrote(ctx context.Context) {
+	if len(rs.list) == 0 {
+		return
+	}
+	if user, ok := actx.Get(ctx, actx.UserID).(string); ok && user != "" {
+		rs.lastWrites.Store(user, time.Now())
+	}
+}
+
+// wroteRecently reports whether the user of ctx wrote within the read-your-writes window
+func (rs *replicas) wroteRecently(ctx context.Context) bool {
+	user, ok := actx.Get(ctx, actx.UserID).(string)
+	if !ok || user == "" {
+		return false
+	}
+	last, ok := rs.lastWrites.Load(user)
+	return ok && time.Since(last.(time.Time)) < rs.window
+}
+
+// check pings every replica, marking the ones which don't answer within timeout as unhealthy
+func (rs *replicas) check(timeout time.Duration) {
+	var wg sync.WaitGroup
+	for _, r := range rs.list {
+		wg.Add(1)
+		go func(r *replica) {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
+			defer cancel()
+
+			err := r.db.PingContext(ctx)
+			healthy := err == nil
+			if r.healthy.Swap(healthy) != healthy {
+				if healthy {
+					log.Info(ctx, fmt.Sprintf("replica %s is back, reading from it again", r.name))
+				} else {
+					log.Warning(ctx, fmt.Errorf("replica %s is unreachable, reading from the others: %w", r.name, err))
+				}
+			}
+			metrics.ReplicaUp(r.name, healthy)
+		}(r)
+	}
+	wg.Wait()
+
+	// Forget the writes older than the window so the map doesn't grow with every user ever seen
+	rs.lastWrites.Range(func(user, last interface{}) bool {
+		if time.Since(last.(time.Time)) >= rs.window {
+			rs.lastWrites.Delete(user)
+		}
+		return true
+	})
+}
+
+// watch checks the replicas every interval until Cleanup is called
+func (rs *replicas) watch(interval time.Duration) {
+	rs.stop = make(chan struct{})
+	rs.done = make(chan struct{})
+
+	go func() {
+		defer close(rs.done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-rs.stop:
+				return
+			case <-ticker.C:
+				rs.check(interval)
+			}
+		}
+	}()
+}
+
+// close stops the health checks and closes the replicas' connection pools
+func (rs *replicas) close() {
+	if rs.stop != nil {
+		close(rs.stop)
+		<-rs.done
+	}
+	for _, r := range rs.list {
+		if err := r.db.Close(); err != nil {
+			log.Error(context.Background(), fmt.Errorf("failed to close replica %s connection pool: %+v", r.name, err))
+		}
+	}
+}
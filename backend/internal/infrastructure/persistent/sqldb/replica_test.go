@@ -0,0 +1,141 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/niko-cb/uct/internal/infrastructure/web/config/actx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newMockDB(t *testing.T) (*sql.DB, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+	return db, mock
+}
+
+// newReplicatedPool returns a pool with two replicas
+func newReplicatedPool(t *testing.T, window time.Duration) (*Pool, sqlmock.Sqlmock, []*sql.DB, []sqlmock.Sqlmock) {
+	primary, primaryMock := newMockDB(t)
+	p := NewPool(primary, 0, nil)
+	p.replicas.window = window
+
+	var dbs []*sql.DB
+	var mocks []sqlmock.Sqlmock
+	for _, name := range []string{"replica-1", "replica-2"} {
+		db, mock := newMockDB(t)
+		p.AddReplica(name, db)
+		dbs = append(dbs, db)
+		mocks = append(mocks, mock)
+	}
+	return p, primaryMock, dbs, mocks
+}
+
+// TestReader_RoundRobin makes sure reads are spread over the replicas
+func TestReader_RoundRobin(t *testing.T) {
+	p, _, replicas, _ := newReplicatedPool(t, time.Second)
+	ctx := context.Background()
+
+	first := p.Reader(ctx)
+	second := p.Reader(ctx)
+
+	assert.NotSame(t, first, second)
+	assert.Contains(t, []interface{}{replicas[0], replicas[1]}, first)
+	assert.Contains(t, []interface{}{replicas[0], replicas[1]}, second)
+	assert.Same(t, first, p.Reader(ctx))
+}
+
+// TestReader_UnhealthyReplica makes sure unreachable replicas are skipped, falling back to the primary
+func TestReader_UnhealthyReplica(t *testing.T) {
+	p, _, replicas, mocks := newReplicatedPool(t, time.Second)
+	ctx := context.Background()
+
+	mocks[0].ExpectPing().WillReturnError(errors.New("connection refused"))
+	mocks[1].ExpectPing()
+	p.replicas.check(time.Second)
+
+	for i := 0; i < 3; i++ {
+		assert.Same(t, replicas[1], p.Reader(ctx))
+	}
+
+	mocks[0].ExpectPing().WillReturnError(errors.New("connection refused"))
+	mocks[1].ExpectPing().WillReturnError(errors.New("connection refused"))
+	p.replicas.check(time.Second)
+
+	assert.Same(t, p.DB, p.Reader(ctx))
+
+	// A replica is used again once it answers
+	mocks[0].ExpectPing()
+	mocks[1].ExpectPing().WillReturnError(errors.New("connection refused"))
+	p.replicas.check(time.Second)
+
+	assert.Same(t, replicas[0], p.Reader(ctx))
+}
+
+// TestReader_Transaction makes sure reads inside a transaction use it
+func TestReader_Transaction(t *testing.T) {
+	p, primaryMock, _, _ := newReplicatedPool(t, time.Second)
+
+	primaryMock.ExpectBegin()
+	primaryMock.ExpectCommit()
+	err := p.DoInTx(context.Background(), func(ctx context.Context) error {
+		_, ok := p.Reader(ctx).(*sql.Tx)
+		assert.True(t, ok)
+		return nil
+	})
+
+	assert.NoError(t, err)
+}
+
+// TestReader_ReadYourWrites makes sure a user reads from the primary for a while after writing
+func TestReader_ReadYourWrites(t *testing.T) {
+	window := 100 * time.Millisecond
+	p, primaryMock, _, _ := newReplicatedPool(t, window)
+	alice := actx.Context(context.Background(), actx.UserID, "alice")
+	bob := actx.Context(context.Background(), actx.UserID, "bob")
+
+	// A write outside a transaction
+	p.Executor(alice)
+	assert.Same(t, p.DB, p.Reader(alice))
+	assert.NotSame(t, p.DB, p.Reader(bob))
+
+	// A committed transaction
+	primaryMock.ExpectBegin()
+	primaryMock.ExpectCommit()
+	require.NoError(t, p.DoInTx(bob, func(context.Context) error { return nil }))
+	assert.Same(t, p.DB, p.Reader(bob))
+
+	time.Sleep(window)
+	assert.NotSame(t, p.DB, p.Reader(alice))
+	assert.NotSame(t, p.DB, p.Reader(bob))
+}
+
+// TestReader_NoReplica makes sure everything goes to the primary without replicas
+func TestReader_NoReplica(t *testing.T) {
+	db, _ := newMockDB(t)
+	p := NewPool(db, 0, nil)
+
+	assert.Same(t, db, p.Reader(context.Background()))
+}
+
+func TestSplitAddress(t *testing.T) {
+	tests := []struct {
+		address, host, port string
+	}{
+		{"replica", "replica", "3306"},
+		{"replica:3307", "replica", "3307"},
+		{"10.0.0.1:3308", "10.0.0.1", "3308"},
+		{"[::1]:3309", "::1", "3309"},
+	}
+	for _, tt := range tests {
+		host, port := SplitAddress(tt.address, "3306")
+		assert.Equal(t, tt.host, host, tt.address)
+		assert.Equal(t, tt.port, port, tt.address)
+	}
+}
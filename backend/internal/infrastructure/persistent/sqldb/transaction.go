@@ -0,0 +1,11 @@
+package sqldb
+
+import (
+	"github.com/niko-cb/uct/internal/domain/repository"
+)
+
+var _ repository.Transaction = &Pool{}
+
+func NewTransaction(p *Pool) repository.Transaction {
+	return p
+}
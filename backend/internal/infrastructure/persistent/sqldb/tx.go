@@ -0,0 +1,146 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/niko-cb/uct/internal/domain/repository"
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/log"
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/metrics"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+const retryBaseDelay = 10 * time.Millisecond
+
+// txKey is the context key of the active transaction
+type txKey struct{}
+
+// txState is the transaction stored in the context
+type txState struct {
+	pool *Pool
+	tx   *sql.Tx
+	// savepoints is the number of savepoints created so far, used to name them uniquely
+	savepoints int
+}
+
+// transaction returns the transaction of p carried by ctx, if any
+func (p *Pool) transaction(ctx context.Context) (*txState, bool) {
+	state, ok := ctx.Value(txKey{}).(*txState)
+	if !ok || state.pool != p {
+		return nil, false
+	}
+	return state, true
+}
+
+// Executor returns the transaction carried by ctx, or the primary if there is none.
+// Gateways use it for writes so they take part in the caller's transaction, if any, and Reader for reads
+func (p *Pool) Executor(ctx context.Context) boil.ContextExecutor {
+	if state, ok := p.transaction(ctx); ok {
+		return state.tx
+	}
+	p.replicas.wrote(ctx)
+	return p.DB
+}
+
+// DoInTx executes the given function in a transaction, or in a savepoint if ctx already carries one.
+// Transactions aborted by lock contention (deadlock, lock wait timeout, serialization failure) are retried from the start
+func (p *Pool) DoInTx(ctx context.Context, f func(context.Context) error, opts ...repository.TxOption) error {
+	if state, ok := p.transaction(ctx); ok {
+		return p.doInSavepoint(ctx, state, f)
+	}
+
+	var options repository.TxOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	var err error
+	for attempt := 0; attempt <= p.txRetries; attempt++ {
+		if attempt > 0 {
+			delay := retryBaseDelay<<(attempt-1) + time.Duration(rand.Int63n(int64(retryBaseDelay))) // nolint: gosec
+			log.Warning(ctx, fmt.Errorf("transaction aborted, retrying in %s (attempt %d/%d): %w", delay, attempt, p.txRetries, err))
+			metrics.TransactionRetried()
+			time.Sleep(delay)
+		}
+
+		err = p.doInTx(ctx, f, options)
+		if !p.isRetryable(err) {
+			return err
+		}
+	}
+	return err
+}
+
+// doInTx runs a single attempt of a transaction
+func (p *Pool) doInTx(ctx context.Context, f func(context.Context) error, options repository.TxOptions) (err error) {
+	tx, err := p.BeginTx(ctx, &sql.TxOptions{Isolation: options.Isolation, ReadOnly: options.ReadOnly})
+	if err != nil {
+		log.Error(ctx, fmt.Errorf("%+v\n", err))
+		return err
+	}
+	ctx = context.WithValue(ctx, txKey{}, &txState{pool: p, tx: tx})
+	log.Debug(ctx, "transaction started")
+	start := time.Now()
+
+	var done bool
+	defer func() {
+		metrics.ObserveTransaction(start, done && err == nil)
+		if !done {
+			log.Debug(ctx, "transaction rollback")
+			if rerr := tx.Rollback(); rerr != nil {
+				log.Error(ctx, fmt.Errorf("%+v\n", rerr))
+			}
+		}
+	}()
+
+	if err = f(ctx); err != nil {
+		log.Error(ctx, fmt.Errorf("%+v\n", err))
+		return err
+	}
+
+	done = true
+	if err = tx.Commit(); err != nil {
+		log.Error(ctx, fmt.Errorf("%+v\n", err))
+		return err
+	}
+	if !options.ReadOnly {
+		p.replicas.wrote(ctx)
+	}
+
+	log.Debug(ctx, "transaction done and committed")
+	return nil
+}
+
+// doInSavepoint runs f in a savepoint of the transaction of state
+func (p *Pool) doInSavepoint(ctx context.Context, state *txState, f func(context.Context) error) error {
+	state.savepoints++
+	name := fmt.Sprintf("sp_%d", state.savepoints)
+
+	if _, err := state.tx.ExecContext(ctx, "SAVEPOINT "+name); err != nil {
+		return err
+	}
+	log.Debug(ctx, "savepoint "+name+" created")
+
+	if err := f(ctx); err != nil {
+		// A deadlock already rolled back the whole transaction, so there's no savepoint left.
+		// The error is returned as is so the outermost DoInTx can retry
+		if !p.isRetryable(err) {
+			log.Debug(ctx, "rollback to savepoint "+name)
+			if _, rerr := state.tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+name); rerr != nil {
+				log.Error(ctx, fmt.Errorf("%+v\n", rerr))
+			}
+		}
+		return err
+	}
+
+	_, err := state.tx.ExecContext(ctx, "RELEASE SAVEPOINT "+name)
+	return err
+}
+
+// isRetryable reports whether err aborted the transaction because of lock contention
+func (p *Pool) isRetryable(err error) bool {
+	return err != nil && p.retryable != nil && p.retryable(err)
+}
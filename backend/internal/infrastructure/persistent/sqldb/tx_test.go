@@ -0,0 +1,124 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// lockError stands for the driver errors of transactions aborted by lock contention
+type lockError struct{}
+
+func (lockError) Error() string { return "deadlock" }
+
+func newMockPool(t *testing.T) (*Pool, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+	return NewPool(db, 2, func(err error) bool { return errors.As(err, &lockError{}) }), mock
+}
+
+// TestExecutor makes sure gateways get the transaction only inside DoInTx
+func TestExecutor(t *testing.T) {
+	p, mock := newMockPool(t)
+	ctx := context.Background()
+
+	assert.Equal(t, p.DB, p.Executor(ctx))
+
+	mock.ExpectBegin()
+	mock.ExpectCommit()
+	err := p.DoInTx(ctx, func(ctx context.Context) error {
+		_, ok := p.Executor(ctx).(*sql.Tx)
+		assert.True(t, ok)
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// TestDoInTx_Rollback makes sure an error rolls the transaction back and is returned as is
+func TestDoInTx_Rollback(t *testing.T) {
+	p, mock := newMockPool(t)
+	want := errors.New("boom")
+
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+	err := p.DoInTx(context.Background(), func(context.Context) error { return want })
+
+	assert.Equal(t, want, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// TestDoInTx_Savepoint makes sure nested calls only roll back their own savepoint
+func TestDoInTx_Savepoint(t *testing.T) {
+	p, mock := newMockPool(t)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("SAVEPOINT sp_1").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("ROLLBACK TO SAVEPOINT sp_1").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("SAVEPOINT sp_2").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("RELEASE SAVEPOINT sp_2").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	err := p.DoInTx(context.Background(), func(ctx context.Context) error {
+		nested := p.DoInTx(ctx, func(context.Context) error { return errors.New("nested failure") })
+		assert.EqualError(t, nested, "nested failure")
+
+		return p.DoInTx(ctx, func(context.Context) error { return nil })
+	})
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// TestDoInTx_DeadlockRetry makes sure deadlocks are retried, even when raised in a savepoint
+func TestDoInTx_DeadlockRetry(t *testing.T) {
+	p, mock := newMockPool(t)
+	deadlock := fmt.Errorf("insert failed: %w", lockError{})
+
+	mock.ExpectBegin()
+	mock.ExpectExec("SAVEPOINT sp_1").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+	mock.ExpectBegin()
+	mock.ExpectExec("SAVEPOINT sp_1").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("RELEASE SAVEPOINT sp_1").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	var calls int
+	err := p.DoInTx(context.Background(), func(ctx context.Context) error {
+		return p.DoInTx(ctx, func(context.Context) error {
+			calls++
+			if calls == 1 {
+				return deadlock
+			}
+			return nil
+		})
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, calls)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// TestDoInTx_RetriesExhausted makes sure the last error is returned once the retries are used up
+func TestDoInTx_RetriesExhausted(t *testing.T) {
+	p, mock := newMockPool(t)
+	timeout := lockError{}
+
+	for i := 0; i <= p.txRetries; i++ {
+		mock.ExpectBegin()
+		mock.ExpectRollback()
+	}
+
+	err := p.DoInTx(context.Background(), func(context.Context) error { return timeout })
+
+	assert.ErrorIs(t, err, timeout)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -8,6 +8,8 @@ type contextKey string
 
 const (
 	EchoContext contextKey = "EchoContext"
+	// UserID is the subject of the JWT of the request
+	UserID contextKey = "UserID"
 )
 
 // Get retrieves a value from the context
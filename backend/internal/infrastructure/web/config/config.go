@@ -1,25 +1,143 @@
 package config
 
 import (
+	"errors"
+	"fmt"
+	"time"
+
 	"github.com/caarlos0/env/v11"
 )
 
+const (
+	DriverMySQL    = "mysql"
+	DriverPostgres = "postgres"
+	DriverMemory   = "memory"
+)
+
+const (
+	BlobStoreLocal = "local"
+	BlobStoreS3    = "s3"
+)
+
 type Config struct {
 	DbUser    string `env:"DB_USER" envDefault:"user"`
-	DbPass    string `env:"DB_PASS,notEmpty"`
-	DbPort    string `env:"DB_PORT" envDefault:"3306"`
+	DbPass    string `env:"DB_PASS"`
+	DbPort    string `env:"DB_PORT"` // defaults to the standard port of DB_DRIVER
 	DbHost    string `env:"DB_HOST" envDefault:"localhost"`
 	DbName    string `env:"DB_NAME" envDefault:"utc"`
 	JwtSecret string `env:"JWT_SECRET,notEmpty"`
 	Port      string `env:"PORT" envDefault:"8080"`
+
+	// DbDriver selects the storage: mysql, postgres, or memory for a throwaway in-process store (DB_* settings are then ignored)
+	DbDriver string `env:"DB_DRIVER" envDefault:"mysql"`
+	// DbSSLMode is the sslmode of the Postgres connection
+	DbSSLMode string `env:"DB_SSLMODE" envDefault:"disable"`
+	// MemorySeed fills the memory store with deterministic sample data generated from this seed, 0 leaves it empty
+	MemorySeed int64 `env:"MEMORY_SEED" envDefault:"0"`
+
+	// The database is pinged on startup, waiting DB_CONNECT_BACKOFF (doubled each time) between attempts
+	DbConnectAttempts int           `env:"DB_CONNECT_ATTEMPTS" envDefault:"5"`
+	DbConnectBackoff  time.Duration `env:"DB_CONNECT_BACKOFF" envDefault:"1s"`
+
+	// Reads made outside a transaction go to the replicas of DB_REPLICA_HOSTS ("host" or "host:port", same
+	// credentials as the primary) in turn, except for a user who wrote within DB_READ_YOUR_WRITES_WINDOW.
+	// Replicas are pinged every DB_REPLICA_CHECK_INTERVAL and skipped while unreachable
+	DbReplicaHosts         []string      `env:"DB_REPLICA_HOSTS" envSeparator:","`
+	DbReplicaCheckInterval time.Duration `env:"DB_REPLICA_CHECK_INTERVAL" envDefault:"5s"`
+	DbReadYourWritesWindow time.Duration `env:"DB_READ_YOUR_WRITES_WINDOW" envDefault:"5s"`
+
+	// DbTxRetries is how many times a transaction aborted by a deadlock or a lock wait timeout is retried
+	DbTxRetries int `env:"DB_TX_RETRIES" envDefault:"3"`
+
+	// MetricsPort is the port of the internal listener serving /metrics, it must not be exposed publicly
+	MetricsPort string `env:"METRICS_PORT" envDefault:"9090"`
+
+	// IdempotencyTTL is how long the response to a request with an Idempotency-Key is replayed to its retries
+	IdempotencyTTL time.Duration `env:"IDEMPOTENCY_TTL" envDefault:"24h"`
+
+	// BodyMaxSize is the largest request body accepted, in bytes, but for the uploads limited by ATTACHMENT_MAX_SIZE
+	BodyMaxSize int64 `env:"BODY_MAX_SIZE" envDefault:"1048576"`
+
+	// RecurringInterval is how often the server generates the invoices of the recurring invoices due, 0 disables it
+	RecurringInterval time.Duration `env:"RECURRING_INTERVAL" envDefault:"1h"`
+
+	// BlobStore selects where the content of the attachments is stored: local, in the directory BLOB_DIR, or s3,
+	// in the bucket S3_BUCKET of any S3-compatible service (MinIO, ...). The memory driver keeps it in memory instead
+	BlobStore string `env:"BLOB_STORE" envDefault:"local"`
+	BlobDir   string `env:"BLOB_DIR" envDefault:"attachments"`
+	// S3Endpoint is the URL of the S3 service, e.g. http://localhost:9000, AWS if empty. S3PathStyle puts the bucket
+	// in the path instead of the host name, as most S3-compatible services expect
+	S3Endpoint        string `env:"S3_ENDPOINT"`
+	S3Region          string `env:"S3_REGION" envDefault:"us-east-1"`
+	S3Bucket          string `env:"S3_BUCKET"`
+	S3AccessKeyID     string `env:"S3_ACCESS_KEY_ID"`
+	S3SecretAccessKey string `env:"S3_SECRET_ACCESS_KEY"`
+	S3PathStyle       bool   `env:"S3_PATH_STYLE" envDefault:"false"`
+	// S3Timeout bounds each request to the S3 service
+	S3Timeout time.Duration `env:"S3_TIMEOUT" envDefault:"30s"`
+
+	// AttachmentMaxSize is the largest attachment accepted, in bytes
+	AttachmentMaxSize int64 `env:"ATTACHMENT_MAX_SIZE" envDefault:"10485760"`
+
+	// MigrateOnStart applies the pending migrations when the server starts
+	MigrateOnStart bool `env:"MIGRATE_ON_START" envDefault:"false"`
+
+	// ReadinessTimeout bounds each readiness check (e.g. the database ping)
+	ReadinessTimeout time.Duration `env:"READINESS_TIMEOUT" envDefault:"2s"`
+	// ShutdownDrainDelay is how long /readyz fails before the server stops accepting connections,
+	// giving load balancers time to take the instance out of rotation
+	ShutdownDrainDelay time.Duration `env:"SHUTDOWN_DRAIN_DELAY" envDefault:"5s"`
+	// ShutdownTimeout bounds the time given to in-flight requests to complete
+	ShutdownTimeout time.Duration `env:"SHUTDOWN_TIMEOUT" envDefault:"10s"`
+
+	// Tracing settings. The OTLP exporter additionally honours the standard
+	// OTEL_EXPORTER_OTLP_* variables (endpoint, headers, timeout, ...)
+	ServiceName      string  `env:"OTEL_SERVICE_NAME" envDefault:"uct"`
+	TraceExporter    string  `env:"TRACE_EXPORTER" envDefault:"none"` // none, otlp, stdout or file
+	TraceFile        string  `env:"TRACE_FILE" envDefault:"traces.json"`
+	TraceSampleRatio float64 `env:"TRACE_SAMPLE_RATIO" envDefault:"1"`
 }
 
-var Cfg Config // nolint: gochecknoglobals
+// Option overrides settings after the environment variables are parsed, e.g. from command line flags
+type Option func(*Config)
+
+// Parse parses the environment variables into a Config
+func Parse(opts ...Option) (*Config, error) {
+	var cfg Config
+	if err := env.Parse(&cfg); err != nil {
+		return nil, err
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
 
-// Parse parses the environment variables and stores them in the Config struct
-func Parse() error {
-	if err := env.Parse(&Cfg); err != nil {
-		return err
+// Validate checks the settings which depend on each other
+func (cfg *Config) Validate() error {
+	switch cfg.DbDriver {
+	case DriverMySQL, DriverPostgres:
+		if cfg.DbPass == "" {
+			return errors.New(`env: required environment variable "DB_PASS" is not set`)
+		}
+	case DriverMemory:
+	default:
+		return fmt.Errorf("unknown database driver: %s", cfg.DbDriver)
+	}
+	if cfg.DbDriver == DriverMemory {
+		return nil
+	}
+	switch cfg.BlobStore {
+	case BlobStoreLocal:
+	case BlobStoreS3:
+		if cfg.S3Bucket == "" || cfg.S3AccessKeyID == "" || cfg.S3SecretAccessKey == "" {
+			return errors.New("env: S3_BUCKET, S3_ACCESS_KEY_ID and S3_SECRET_ACCESS_KEY are required with BLOB_STORE=s3")
+		}
+	default:
+		return fmt.Errorf("unknown blob store: %s", cfg.BlobStore)
 	}
 	return nil
 }
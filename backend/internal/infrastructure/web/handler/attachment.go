@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/niko-cb/uct/internal/controller"
+)
+
+// attachmentField is the field of the form holding the file of an attachment
+const attachmentField = "file"
+
+type IAttachmentHandler interface {
+	CreateAttachment(echo.Context) error
+	GetAttachments(echo.Context) error
+	GetAttachment(echo.Context) error
+}
+
+var _ IAttachmentHandler = &AttachmentHandler{}
+
+type AttachmentHandler struct {
+	con *controller.AttachmentController
+}
+
+func NewAttachmentHandler(con *controller.AttachmentController) IAttachmentHandler {
+	return &AttachmentHandler{con: con}
+}
+
+// CreateAttachment is a handler function to attach the file of a multipart/form-data form to the invoice in the path.
+// The file is streamed from the request rather than parsed into memory with the whole form first. It answers
+// the metadata of the attachment with 201, or with 200 if the invoice already had the same file
+func (h *AttachmentHandler) CreateAttachment(echo echo.Context) error {
+	return withContext(echo, func(ctx context.Context) error {
+
+		reader, err := echo.Request().MultipartReader()
+		if err != nil {
+			return echo.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		}
+		var file *multipart.Part
+		for file == nil {
+			part, err := reader.NextPart()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				return echo.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+			}
+			if part.FormName() == attachmentField {
+				file = part
+			}
+		}
+
+		var fileName, contentType string
+		var content io.Reader
+		if file != nil {
+			fileName, contentType, content = file.FileName(), file.Header.Get("Content-Type"), file
+		}
+		attachment, created, err := h.con.CreateAttachment(ctx, echo.Param("id"), fileName, contentType, content)
+		if err != nil {
+			return echo.JSON(errorStatus(err), map[string]string{"error": err.Error()})
+		}
+		if created {
+			return echo.JSON(http.StatusCreated, attachment)
+		}
+		return echo.JSON(http.StatusOK, attachment)
+	})
+}
+
+// GetAttachments is a handler function to list the attachments of the invoice in the path
+func (h *AttachmentHandler) GetAttachments(echo echo.Context) error {
+	return withContext(echo, func(ctx context.Context) error {
+
+		attachments, err := h.con.GetAttachments(ctx, echo.Param("id"))
+		if err != nil {
+			return echo.JSON(errorStatus(err), map[string]string{"error": err.Error()})
+		}
+		return echo.JSON(http.StatusOK, attachments)
+	})
+}
+
+// GetAttachment is a handler function to download an attachment of the invoice in the path, as a file
+// which the browsers save rather than display
+func (h *AttachmentHandler) GetAttachment(echo echo.Context) error {
+	return withContext(echo, func(ctx context.Context) error {
+
+		attachment, content, err := h.con.OpenAttachment(ctx, echo.Param("id"), echo.Param("attachment_id"))
+		if err != nil {
+			return echo.JSON(errorStatus(err), map[string]string{"error": err.Error()})
+		}
+		defer content.Close()
+
+		header := echo.Response().Header()
+		header.Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": attachment.FileName}))
+		header.Set("Content-Length", strconv.FormatInt(attachment.Size, 10))
+		header.Set("X-Content-Type-Options", "nosniff")
+		return echo.Stream(http.StatusOK, attachment.ContentType, content)
+	})
+}
@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/niko-cb/uct/internal/controller"
+	"github.com/niko-cb/uct/internal/domain/entity"
+	"github.com/niko-cb/uct/internal/domain/repository"
+)
+
+type ICreditNoteHandler interface {
+	CreateCreditNote(echo.Context) error
+	GetCreditNote(echo.Context) error
+	GetCreditNotesByInvoice(echo.Context) error
+	GetCreditNotesByDateRange(echo.Context) error
+	UpdateCreditNoteStatus(echo.Context) error
+}
+
+var _ ICreditNoteHandler = &CreditNoteHandler{}
+
+type CreditNoteHandler struct {
+	con *controller.CreditNoteController
+}
+
+func NewCreditNoteHandler(con *controller.CreditNoteController) ICreditNoteHandler {
+	return &CreditNoteHandler{con: con}
+}
+
+// CreateCreditNote is a handler function to create a credit note of the invoice in the path, with its version as ETag
+func (h *CreditNoteHandler) CreateCreditNote(echo echo.Context) error {
+	return withContext(echo, func(ctx context.Context) error {
+
+		var creditNote *entity.CreditNote
+		if err := echo.Bind(&creditNote); err != nil {
+			return echo.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		}
+
+		created, err := h.con.CreateCreditNote(ctx, echo.Param("id"), creditNote)
+		if err != nil {
+			return echo.JSON(errorStatus(err), map[string]string{"error": err.Error()})
+		}
+		echo.Response().Header().Set(HeaderETag, etag(created.Version))
+		return echo.JSON(http.StatusOK, created)
+	})
+}
+
+// GetCreditNotesByInvoice is a handler function to list the credit notes of the invoice in the path
+func (h *CreditNoteHandler) GetCreditNotesByInvoice(echo echo.Context) error {
+	return withContext(echo, func(ctx context.Context) error {
+
+		creditNotes, err := h.con.GetCreditNotesByInvoice(ctx, echo.Param("id"))
+		if err != nil {
+			return echo.JSON(errorStatus(err), map[string]string{"error": err.Error()})
+		}
+		return echo.JSON(http.StatusOK, creditNotes)
+	})
+}
+
+// GetCreditNotesByDateRange is a handler function to list the credit notes issued between two dates.
+// With a limit, the Link header gives the URL of the next page, if any
+func (h *CreditNoteHandler) GetCreditNotesByDateRange(echo echo.Context) error {
+	return withContext(echo, func(ctx context.Context) error {
+
+		creditNotes, next, err := h.con.GetCreditNotesByDateRange(ctx, echo.QueryParam("from"), echo.QueryParam("to"),
+			echo.QueryParam("limit"), echo.QueryParam("after"))
+		if err != nil {
+			return echo.JSON(errorStatus(err), map[string]string{"error": err.Error()})
+		}
+		if next != 0 {
+			echo.Response().Header().Set(HeaderLink, nextLink(echo.Request().URL, next))
+		}
+		return echo.JSON(http.StatusOK, creditNotes)
+	})
+}
+
+// GetCreditNote is a handler function to get a credit note, with its version as ETag
+func (h *CreditNoteHandler) GetCreditNote(echo echo.Context) error {
+	return withContext(echo, func(ctx context.Context) error {
+
+		creditNote, err := h.con.GetCreditNote(ctx, echo.Param("id"))
+		if err != nil {
+			return echo.JSON(errorStatus(err), map[string]string{"error": err.Error()})
+		}
+		echo.Response().Header().Set(HeaderETag, etag(creditNote.Version))
+		return echo.JSON(http.StatusOK, creditNote)
+	})
+}
+
+// UpdateCreditNoteStatus is a handler function to move a credit note to another status,
+// with the same If-Match rule as the invoices
+func (h *CreditNoteHandler) UpdateCreditNoteStatus(echo echo.Context) error {
+	return withContext(echo, func(ctx context.Context) error {
+
+		version, ok := ifMatchVersion(echo.Request().Header.Get(HeaderIfMatch))
+		if !ok {
+			return echo.JSON(http.StatusPreconditionFailed, map[string]string{"error": repository.ErrVersionConflict.Error()})
+		}
+
+		var status *entity.CreditNoteStatus
+		if err := echo.Bind(&status); err != nil {
+			return echo.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		}
+
+		updated, err := h.con.UpdateCreditNoteStatus(ctx, echo.Param("id"), version, status)
+		if err != nil {
+			return echo.JSON(errorStatus(err), map[string]string{"error": err.Error()})
+		}
+		echo.Response().Header().Set(HeaderETag, etag(updated.Version))
+		return echo.JSON(http.StatusOK, updated)
+	})
+}
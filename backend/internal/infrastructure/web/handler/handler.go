@@ -7,10 +7,24 @@ import (
 	"github.com/niko-cb/uct/internal/infrastructure/web/config/actx"
 )
 
+const (
+	// HeaderETag holds the version of a single resource
+	HeaderETag = "ETag"
+	// HeaderIfMatch must hold the ETag of the resource to change, see the Preconditions middleware
+	HeaderIfMatch = "If-Match"
+	// HeaderLink holds the URL of the next page of a list
+	HeaderLink = "Link"
+	// HeaderIdempotencyKey identifies a request and its retries, see the Idempotency middleware
+	HeaderIdempotencyKey = "Idempotency-Key"
+	// HeaderIdempotentReplayed is set on the responses replayed to a retry
+	HeaderIdempotentReplayed = "Idempotent-Replayed"
+)
+
 func withContext(c echo.Context, fn func(ctx context.Context) error) error {
 
-	// Add the echo context to the new context
-	ctx := actx.Context(context.Background(), actx.EchoContext, c)
+	// Add the echo context to the request's context, keeping the trace span and
+	// sqlcommenter tags set by the middlewares
+	ctx := actx.Context(c.Request().Context(), actx.EchoContext, c)
 
 	// Replace the request's context with the new context
 	req := c.Request().WithContext(ctx)
@@ -0,0 +1,37 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/health"
+)
+
+type IHealthHandler interface {
+	Liveness(echo.Context) error
+	Readiness(echo.Context) error
+}
+
+var _ IHealthHandler = &HealthHandler{}
+
+type HealthHandler struct {
+	checker *health.Checker
+}
+
+func NewHealthHandler(checker *health.Checker) IHealthHandler {
+	return &HealthHandler{checker: checker}
+}
+
+// Liveness reports that the process is up and able to serve requests
+func (h *HealthHandler) Liveness(echo echo.Context) error {
+	return echo.JSON(http.StatusOK, map[string]string{"status": health.StatusOK})
+}
+
+// Readiness reports whether the dependencies are usable and the server is not shutting down
+func (h *HealthHandler) Readiness(echo echo.Context) error {
+	report := h.checker.Ready(echo.Request().Context())
+	if report.Status != health.StatusOK {
+		return echo.JSON(http.StatusServiceUnavailable, report)
+	}
+	return echo.JSON(http.StatusOK, report)
+}
@@ -2,9 +2,17 @@ package handler
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 
+	"github.com/niko-cb/uct/internal/application/usecase"
 	"github.com/niko-cb/uct/internal/domain/entity"
+	"github.com/niko-cb/uct/internal/domain/repository"
+	"github.com/niko-cb/uct/internal/domain/ubl"
 
 	"github.com/labstack/echo/v4"
 	"github.com/niko-cb/uct/internal/controller"
@@ -12,7 +20,10 @@ import (
 
 type IInvoiceHandler interface {
 	CreateInvoice(echo.Context) error
+	GetInvoice(echo.Context) error
 	GetInvoicesByDateRange(echo.Context) error
+	UpdateInvoice(echo.Context) error
+	DeleteInvoice(echo.Context) error
 }
 
 var _ IInvoiceHandler = &InvoiceHandler{}
@@ -37,23 +48,151 @@ func (h *InvoiceHandler) CreateInvoice(echo echo.Context) error {
 		err := h.con.CreateInvoice(ctx, invoice)
 
 		if err != nil {
-			return echo.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+			return echo.JSON(errorStatus(err), map[string]string{"error": err.Error()})
 		}
 		return echo.JSON(http.StatusOK, map[string]string{"message": "success"})
 	})
 }
 
-// GetInvoicesByDateRange is a handler function to get invoices by date range
+// GetInvoicesByDateRange is a handler function to get invoices by date range, of their due date
+// or of the due dates of their installments. With a limit, the Link header gives the URL of the next page, if any
 func (h *InvoiceHandler) GetInvoicesByDateRange(echo echo.Context) error {
 	return withContext(echo, func(ctx context.Context) error {
 
 		from := echo.QueryParam("from")
 		to := echo.QueryParam("to")
 
-		invoices, err := h.con.GetInvoicesByDateRange(ctx, from, to)
+		invoices, next, err := h.con.GetInvoicesByDateRange(ctx, from, to, echo.QueryParam("by"),
+			echo.QueryParam("limit"), echo.QueryParam("after"))
 		if err != nil {
-			return echo.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+			return echo.JSON(errorStatus(err), map[string]string{"error": err.Error()})
 		}
-		return echo.JSON(http.StatusOK, invoices)
+		if next != 0 {
+			echo.Response().Header().Set(HeaderLink, nextLink(echo.Request().URL, next))
+		}
+		body := make([]*entity.InvoiceDetails, len(invoices))
+		for i, invoice := range invoices {
+			body[i] = entity.NewInvoiceDetails(invoice)
+		}
+		return echo.JSON(http.StatusOK, body)
+	})
+}
+
+// GetInvoice is a handler function to get an invoice, with its version as ETag
+func (h *InvoiceHandler) GetInvoice(echo echo.Context) error {
+	return withContext(echo, func(ctx context.Context) error {
+
+		invoice, err := h.con.GetInvoice(ctx, echo.Param("id"))
+		if err != nil {
+			return echo.JSON(errorStatus(err), map[string]string{"error": err.Error()})
+		}
+		echo.Response().Header().Set(HeaderETag, etag(invoice.Version))
+		return echo.JSON(http.StatusOK, entity.NewInvoiceDetails(invoice))
 	})
 }
+
+// UpdateInvoice is a handler function to replace an invoice.
+// The If-Match header must hold the ETag the client read, so concurrent changes are not overwritten
+func (h *InvoiceHandler) UpdateInvoice(echo echo.Context) error {
+	return withContext(echo, func(ctx context.Context) error {
+
+		version, ok := ifMatchVersion(echo.Request().Header.Get(HeaderIfMatch))
+		if !ok {
+			return echo.JSON(http.StatusPreconditionFailed, map[string]string{"error": repository.ErrVersionConflict.Error()})
+		}
+
+		var invoice *entity.Invoice
+		if err := echo.Bind(&invoice); err != nil {
+			return echo.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		}
+
+		updated, err := h.con.UpdateInvoice(ctx, echo.Param("id"), version, invoice)
+		if err != nil {
+			return echo.JSON(errorStatus(err), map[string]string{"error": err.Error()})
+		}
+		echo.Response().Header().Set(HeaderETag, etag(updated.Version))
+		return echo.JSON(http.StatusOK, entity.NewInvoiceDetails(updated))
+	})
+}
+
+// DeleteInvoice is a handler function to delete an invoice, with the same If-Match rule as UpdateInvoice
+func (h *InvoiceHandler) DeleteInvoice(echo echo.Context) error {
+	return withContext(echo, func(ctx context.Context) error {
+
+		version, ok := ifMatchVersion(echo.Request().Header.Get(HeaderIfMatch))
+		if !ok {
+			return echo.JSON(http.StatusPreconditionFailed, map[string]string{"error": repository.ErrVersionConflict.Error()})
+		}
+
+		if err := h.con.DeleteInvoice(ctx, echo.Param("id"), version); err != nil {
+			return echo.JSON(errorStatus(err), map[string]string{"error": err.Error()})
+		}
+		return echo.NoContent(http.StatusNoContent)
+	})
+}
+
+// nextLink returns a Link header pointing to the page after the id next
+func nextLink(current *url.URL, next int64) string {
+	query := current.Query()
+	query.Set("after", strconv.FormatInt(next, 10))
+	u := url.URL{Path: current.Path, RawQuery: query.Encode()}
+	return fmt.Sprintf(`<%s>; rel="next"`, u.String())
+}
+
+// etag formats a version as a strong entity tag
+func etag(version int64) string {
+	return strconv.Quote(strconv.FormatInt(version, 10))
+}
+
+// ifMatchVersion returns the version held by an If-Match header, usecase.AnyVersion for "*".
+// Anything else (weak or unknown tags, lists) can't match the current version
+func ifMatchVersion(ifMatch string) (int64, bool) {
+	ifMatch = strings.TrimSpace(ifMatch)
+	if ifMatch == "*" {
+		return usecase.AnyVersion, true
+	}
+	unquoted, err := strconv.Unquote(ifMatch)
+	if err != nil || !strings.HasPrefix(ifMatch, `"`) {
+		return 0, false
+	}
+	version, err := strconv.ParseInt(unquoted, 10, 64)
+	if err != nil || version <= 0 {
+		return 0, false
+	}
+	return version, true
+}
+
+// errorStatus maps the errors of the lower layers to an HTTP status
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, controller.ErrInvalidID), errors.Is(err, controller.ErrInvalidDate),
+		errors.Is(err, controller.ErrInvalidRange), errors.Is(err, controller.ErrInvalidGranularity),
+		errors.Is(err, controller.ErrInvalidBool), errors.Is(err, controller.ErrCompanyRequired),
+		errors.Is(err, controller.ErrInvalidItem), errors.Is(err, usecase.ErrPaymentAmountMismatch),
+		errors.Is(err, usecase.ErrInvalidCurrency), errors.Is(err, usecase.ErrBaseCurrencyMismatch),
+		errors.Is(err, controller.ErrInvalidCreditNote), errors.Is(err, usecase.ErrInvalidCreditNoteStatus),
+		errors.Is(err, controller.ErrInvalidInstallment), errors.Is(err, usecase.ErrInstallmentsMismatch),
+		errors.Is(err, controller.ErrInvalidPayment), errors.Is(err, controller.ErrInvalidDateField),
+		errors.Is(err, controller.ErrInvalidRecurringInvoice), errors.Is(err, controller.ErrInvalidCount),
+		errors.Is(err, usecase.ErrInvalidRecurringStatus), errors.Is(err, usecase.ErrNoOccurrence),
+		errors.Is(err, controller.ErrInvalidAttachment), errors.Is(err, controller.ErrInvalidDocument),
+		errors.Is(err, ubl.ErrMalformedDocument):
+		return http.StatusBadRequest
+	case errors.Is(err, controller.ErrAttachmentTooLarge):
+		return http.StatusRequestEntityTooLarge
+	case errors.Is(err, controller.ErrUnsupportedContentType):
+		return http.StatusUnsupportedMediaType
+	case errors.Is(err, usecase.ErrNoAvailableBalance), errors.Is(err, usecase.ErrNoFXRate),
+		errors.Is(err, usecase.ErrCreditExceedsBalance), errors.Is(err, usecase.ErrCreditNoteTransition),
+		errors.Is(err, usecase.ErrInvoiceCredited), errors.Is(err, usecase.ErrPaymentExceedsBalance),
+		errors.Is(err, ubl.ErrInvalidDocument), errors.Is(err, ubl.ErrUnsupportedDocument),
+		errors.Is(err, usecase.ErrImportedTaxMismatch):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, repository.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, repository.ErrVersionConflict):
+		return http.StatusPreconditionFailed
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/niko-cb/uct/internal/controller"
+	"github.com/niko-cb/uct/internal/domain/entity"
+)
+
+type IPaymentHandler interface {
+	RecordPayment(echo.Context) error
+	GetPayments(echo.Context) error
+}
+
+var _ IPaymentHandler = &PaymentHandler{}
+
+type PaymentHandler struct {
+	con *controller.PaymentController
+}
+
+func NewPaymentHandler(con *controller.PaymentController) IPaymentHandler {
+	return &PaymentHandler{con: con}
+}
+
+// RecordPayment is a handler function to record a payment of the invoice in the path. It answers the invoice
+// with its derived status, and its new version as ETag
+func (h *PaymentHandler) RecordPayment(echo echo.Context) error {
+	return withContext(echo, func(ctx context.Context) error {
+
+		var payment *entity.Payment
+		if err := echo.Bind(&payment); err != nil {
+			return echo.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		}
+
+		invoice, err := h.con.RecordPayment(ctx, echo.Param("id"), payment)
+		if err != nil {
+			return echo.JSON(errorStatus(err), map[string]string{"error": err.Error()})
+		}
+		echo.Response().Header().Set(HeaderETag, etag(invoice.Version))
+		return echo.JSON(http.StatusOK, entity.NewInvoiceDetails(invoice))
+	})
+}
+
+// GetPayments is a handler function to list the payments of the invoice in the path
+func (h *PaymentHandler) GetPayments(echo echo.Context) error {
+	return withContext(echo, func(ctx context.Context) error {
+
+		payments, err := h.con.GetPayments(ctx, echo.Param("id"))
+		if err != nil {
+			return echo.JSON(errorStatus(err), map[string]string{"error": err.Error()})
+		}
+		return echo.JSON(http.StatusOK, payments)
+	})
+}
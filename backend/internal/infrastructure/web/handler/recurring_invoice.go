@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/niko-cb/uct/internal/controller"
+	"github.com/niko-cb/uct/internal/domain/entity"
+	"github.com/niko-cb/uct/internal/domain/repository"
+)
+
+type IRecurringInvoiceHandler interface {
+	CreateRecurringInvoice(echo.Context) error
+	GetRecurringInvoice(echo.Context) error
+	GetRecurringInvoicesByClient(echo.Context) error
+	UpdateRecurringInvoiceStatus(echo.Context) error
+	PreviewOccurrences(echo.Context) error
+}
+
+var _ IRecurringInvoiceHandler = &RecurringInvoiceHandler{}
+
+type RecurringInvoiceHandler struct {
+	con *controller.RecurringInvoiceController
+}
+
+func NewRecurringInvoiceHandler(con *controller.RecurringInvoiceController) IRecurringInvoiceHandler {
+	return &RecurringInvoiceHandler{con: con}
+}
+
+// CreateRecurringInvoice is a handler function to create a recurring invoice, with its version as ETag
+func (h *RecurringInvoiceHandler) CreateRecurringInvoice(echo echo.Context) error {
+	return withContext(echo, func(ctx context.Context) error {
+
+		var recurring *entity.RecurringInvoice
+		if err := echo.Bind(&recurring); err != nil {
+			return echo.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		}
+
+		created, err := h.con.CreateRecurringInvoice(ctx, recurring)
+		if err != nil {
+			return echo.JSON(errorStatus(err), map[string]string{"error": err.Error()})
+		}
+		echo.Response().Header().Set(HeaderETag, etag(created.Version))
+		return echo.JSON(http.StatusOK, created)
+	})
+}
+
+// GetRecurringInvoice is a handler function to get a recurring invoice, with its version as ETag
+func (h *RecurringInvoiceHandler) GetRecurringInvoice(echo echo.Context) error {
+	return withContext(echo, func(ctx context.Context) error {
+
+		recurring, err := h.con.GetRecurringInvoice(ctx, echo.Param("id"))
+		if err != nil {
+			return echo.JSON(errorStatus(err), map[string]string{"error": err.Error()})
+		}
+		echo.Response().Header().Set(HeaderETag, etag(recurring.Version))
+		return echo.JSON(http.StatusOK, recurring)
+	})
+}
+
+// GetRecurringInvoicesByClient is a handler function to list the recurring invoices of the client of the query
+func (h *RecurringInvoiceHandler) GetRecurringInvoicesByClient(echo echo.Context) error {
+	return withContext(echo, func(ctx context.Context) error {
+
+		recurring, err := h.con.GetRecurringInvoicesByClient(ctx, echo.QueryParam("client_id"))
+		if err != nil {
+			return echo.JSON(errorStatus(err), map[string]string{"error": err.Error()})
+		}
+		return echo.JSON(http.StatusOK, recurring)
+	})
+}
+
+// UpdateRecurringInvoiceStatus is a handler function to pause or resume a recurring invoice,
+// with the same If-Match rule as the invoices
+func (h *RecurringInvoiceHandler) UpdateRecurringInvoiceStatus(echo echo.Context) error {
+	return withContext(echo, func(ctx context.Context) error {
+
+		version, ok := ifMatchVersion(echo.Request().Header.Get(HeaderIfMatch))
+		if !ok {
+			return echo.JSON(http.StatusPreconditionFailed, map[string]string{"error": repository.ErrVersionConflict.Error()})
+		}
+
+		var status *entity.RecurringInvoiceStatus
+		if err := echo.Bind(&status); err != nil {
+			return echo.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		}
+
+		updated, err := h.con.UpdateRecurringInvoiceStatus(ctx, echo.Param("id"), version, status)
+		if err != nil {
+			return echo.JSON(errorStatus(err), map[string]string{"error": err.Error()})
+		}
+		echo.Response().Header().Set(HeaderETag, etag(updated.Version))
+		return echo.JSON(http.StatusOK, updated)
+	})
+}
+
+// PreviewOccurrences is a handler function to list the next invoices a recurring invoice will generate
+func (h *RecurringInvoiceHandler) PreviewOccurrences(echo echo.Context) error {
+	return withContext(echo, func(ctx context.Context) error {
+
+		occurrences, err := h.con.PreviewOccurrences(ctx, echo.Param("id"), echo.QueryParam("count"))
+		if err != nil {
+			return echo.JSON(errorStatus(err), map[string]string{"error": err.Error()})
+		}
+		return echo.JSON(http.StatusOK, occurrences)
+	})
+}
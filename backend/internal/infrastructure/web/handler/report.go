@@ -0,0 +1,215 @@
+package handler
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/niko-cb/uct/internal/controller"
+	"github.com/niko-cb/uct/internal/domain/entity"
+)
+
+// The formats of the reports, chosen by the format query parameter
+const (
+	FormatJSON = "json"
+	FormatCSV  = "csv"
+)
+
+type IReportHandler interface {
+	GetAgingReport(echo.Context) error
+	GetCashForecast(echo.Context) error
+	GetIssuerReport(echo.Context) error
+}
+
+var _ IReportHandler = &ReportHandler{}
+
+type ReportHandler struct {
+	con *controller.ReportController
+}
+
+func NewReportHandler(con *controller.ReportController) IReportHandler {
+	return &ReportHandler{con: con}
+}
+
+// GetAgingReport is a handler function to get the aging of the outstanding invoices,
+// as JSON or as CSV with format=csv: one row per client, then the totals
+func (h *ReportHandler) GetAgingReport(echo echo.Context) error {
+	return withContext(echo, func(ctx context.Context) error {
+
+		format := echo.QueryParam("format")
+		if format != "" && format != FormatJSON && format != FormatCSV {
+			return echo.JSON(http.StatusBadRequest, map[string]string{"error": fmt.Sprintf("unknown format %q", format)})
+		}
+
+		report, err := h.con.GetAgingReport(ctx, echo.QueryParam("as_of"), echo.QueryParam("base_currency"))
+		if err != nil {
+			return echo.JSON(errorStatus(err), map[string]string{"error": err.Error()})
+		}
+		if format == FormatCSV {
+			return writeCSV(echo, "aging-"+report.AsOf+".csv", func(w io.Writer) error {
+				return writeAgingCSV(w, report)
+			})
+		}
+		return echo.JSON(http.StatusOK, report)
+	})
+}
+
+// GetCashForecast is a handler function to get the upcoming payouts of the open invoices by period,
+// as JSON or as CSV with format=csv: one row per period, then the totals
+func (h *ReportHandler) GetCashForecast(echo echo.Context) error {
+	return withContext(echo, func(ctx context.Context) error {
+
+		format := echo.QueryParam("format")
+		if format != "" && format != FormatJSON && format != FormatCSV {
+			return echo.JSON(http.StatusBadRequest, map[string]string{"error": fmt.Sprintf("unknown format %q", format)})
+		}
+
+		forecast, err := h.con.GetCashForecast(ctx, controller.CashForecastParams{
+			From:           echo.QueryParam("from"),
+			To:             echo.QueryParam("to"),
+			Granularity:    echo.QueryParam("granularity"),
+			CompanyID:      echo.QueryParam("company_id"),
+			ProjectOverdue: echo.QueryParam("project_overdue"),
+			CompareBalance: echo.QueryParam("compare_balance"),
+			BaseCurrency:   echo.QueryParam("base_currency"),
+		})
+		if err != nil {
+			return echo.JSON(errorStatus(err), map[string]string{"error": err.Error()})
+		}
+		if format == FormatCSV {
+			return writeCSV(echo, "cash-forecast-"+forecast.From+"-"+forecast.To+".csv", func(w io.Writer) error {
+				return writeCashForecastCSV(w, forecast)
+			})
+		}
+		return echo.JSON(http.StatusOK, forecast)
+	})
+}
+
+// GetIssuerReport is a handler function to get the invoices whose client was not a registered qualified invoice
+// issuer on the issue date, as JSON or as CSV with format=csv: one row per invoice
+func (h *ReportHandler) GetIssuerReport(echo echo.Context) error {
+	return withContext(echo, func(ctx context.Context) error {
+
+		format := echo.QueryParam("format")
+		if format != "" && format != FormatJSON && format != FormatCSV {
+			return echo.JSON(http.StatusBadRequest, map[string]string{"error": fmt.Sprintf("unknown format %q", format)})
+		}
+
+		report, err := h.con.GetIssuerReport(ctx, controller.IssuerReportParams{
+			From:      echo.QueryParam("from"),
+			To:        echo.QueryParam("to"),
+			CompanyID: echo.QueryParam("company_id"),
+		})
+		if err != nil {
+			return echo.JSON(errorStatus(err), map[string]string{"error": err.Error()})
+		}
+		if format == FormatCSV {
+			return writeCSV(echo, "unregistered-issuers-"+report.From+"-"+report.To+".csv", func(w io.Writer) error {
+				return writeIssuerCSV(w, report)
+			})
+		}
+		return echo.JSON(http.StatusOK, report)
+	})
+}
+
+// writeCSV sends a CSV attachment
+func writeCSV(c echo.Context, filename string, write func(w io.Writer) error) error {
+	c.Response().Header().Set("Content-Type", "text/csv; charset=utf-8")
+	c.Response().Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	c.Response().WriteHeader(http.StatusOK)
+	return write(c.Response())
+}
+
+// writeAgingCSV writes the count and the amount of every bucket, by client and in total
+func writeAgingCSV(w io.Writer, report *entity.AgingReport) error {
+	cw := csv.NewWriter(w)
+
+	header := []string{"client_id", "client_name"}
+	for _, name := range append(entity.AgingBucketNames[:], "total") {
+		header = append(header, name+" count", name+" amount")
+	}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+
+	row := func(clientID, clientName string, breakdown *entity.AgingBreakdown) []string {
+		record := []string{clientID, clientName}
+		for bucket := 0; bucket < entity.AgingBuckets; bucket++ {
+			amount := breakdown.Bucket(bucket)
+			record = append(record, strconv.FormatInt(amount.Count, 10), amount.Amount.String())
+		}
+		return append(record, strconv.FormatInt(breakdown.Total.Count, 10), breakdown.Total.Amount.String())
+	}
+	for _, client := range report.Clients {
+		if err := cw.Write(row(strconv.FormatInt(client.ClientID, 10), client.ClientName, &client.AgingBreakdown)); err != nil {
+			return err
+		}
+	}
+	if err := cw.Write(row("", "total", &report.Totals)); err != nil {
+		return err
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// writeCashForecastCSV writes the cash flow of every period and the total. The remaining balance
+// is only written when the forecast is compared to the available balance
+func writeCashForecastCSV(w io.Writer, forecast *entity.CashForecast) error {
+	cw := csv.NewWriter(w)
+	compared := forecast.AvailableBalance.Big != nil
+
+	header := []string{"start", "end", "count", "payment_amount", "fee_amount", "tax_amount", "total_amount"}
+	if compared {
+		header = append(header, "remaining_balance")
+	}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+
+	row := func(start, end string, flow *entity.CashFlow, remaining string) []string {
+		record := []string{start, end, strconv.FormatInt(flow.Count, 10),
+			flow.PaymentAmount.String(), flow.FeeAmount.String(), flow.TaxAmount.String(), flow.TotalAmount.String()}
+		if compared {
+			record = append(record, remaining)
+		}
+		return record
+	}
+	for _, period := range forecast.Periods {
+		if err := cw.Write(row(period.Start, period.End, &period.CashFlow, period.RemainingBalance.String())); err != nil {
+			return err
+		}
+	}
+	if err := cw.Write(row("total", "", &forecast.Totals, "")); err != nil {
+		return err
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// writeIssuerCSV writes the flagged invoices, one a row
+func writeIssuerCSV(w io.Writer, report *entity.IssuerReport) error {
+	cw := csv.NewWriter(w)
+
+	header := []string{"invoice_id", "company_id", "client_id", "client_name", "registration_number", "issue_date",
+		"currency", "tax_amount", "total_amount", "status"}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	for _, invoice := range report.Invoices {
+		err := cw.Write([]string{strconv.FormatInt(invoice.InvoiceID, 10), strconv.FormatInt(invoice.CompanyID, 10),
+			strconv.FormatInt(invoice.ClientID, 10), invoice.ClientName, invoice.RegistrationNumber, invoice.IssueDate,
+			invoice.Currency, invoice.TaxAmount.String(), invoice.TotalAmount.String(), invoice.Status})
+		if err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,51 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/niko-cb/uct/internal/controller"
+	"github.com/niko-cb/uct/internal/domain/entity"
+)
+
+type IUBLHandler interface {
+	ImportInvoice(echo.Context) error
+	ExportInvoice(echo.Context) error
+}
+
+var _ IUBLHandler = &UBLHandler{}
+
+type UBLHandler struct {
+	con *controller.UBLController
+}
+
+func NewUBLHandler(con *controller.UBLController) IUBLHandler {
+	return &UBLHandler{con: con}
+}
+
+// ImportInvoice is a handler function to create an invoice from the UBL document in the body, received by
+// the company of the company_id query parameter. It answers the invoice with 201, with its version as ETag
+func (h *UBLHandler) ImportInvoice(echo echo.Context) error {
+	return withContext(echo, func(ctx context.Context) error {
+
+		invoice, err := h.con.ImportInvoice(ctx, echo.QueryParam("company_id"), echo.Request().Body)
+		if err != nil {
+			return echo.JSON(errorStatus(err), map[string]string{"error": err.Error()})
+		}
+		echo.Response().Header().Set(HeaderETag, etag(invoice.Version))
+		return echo.JSON(http.StatusCreated, entity.NewInvoiceDetails(invoice))
+	})
+}
+
+// ExportInvoice is a handler function to get the invoice in the path as a UBL document
+func (h *UBLHandler) ExportInvoice(echo echo.Context) error {
+	return withContext(echo, func(ctx context.Context) error {
+
+		document, err := h.con.ExportInvoice(ctx, echo.Param("id"))
+		if err != nil {
+			return echo.JSON(errorStatus(err), map[string]string{"error": err.Error()})
+		}
+		return echo.Blob(http.StatusOK, entity.AttachmentTypeXML, document)
+	})
+}
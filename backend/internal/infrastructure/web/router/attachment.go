@@ -0,0 +1,69 @@
+package router
+
+import (
+	"net/http"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/labstack/echo/v4"
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+	"github.com/niko-cb/uct/internal/infrastructure/web/handler"
+)
+
+// invoiceAttachment is a function to create a new Resource struct for the documents attached to an invoice
+func invoiceAttachment(attachmentHandler handler.IAttachmentHandler) *Resource {
+	id := &Parameter{
+		Name: "id", In: openapi3.ParameterInPath, Description: "Id of the invoice",
+		Schema: openapi3.NewInt64Schema().WithMin(1),
+	}
+	attachmentID := &Parameter{
+		Name: "attachment_id", In: openapi3.ParameterInPath, Description: "Id of the attachment",
+		Schema: openapi3.NewInt64Schema().WithMin(1),
+	}
+
+	return &Resource{
+		Resource: "invoices",
+		Endpoints: []*Endpoint{
+			{
+				Method: echo.POST, SuffixPath: ":id/attachments", HandlerFunc: attachmentHandler.CreateAttachment,
+				Summary: "Attach a document to an invoice: a PDF, PNG, JPEG or XML file sent in the file field of a form. " +
+					"The same file attached again returns the existing attachment with 200 instead of 201",
+				Parameters: []*Parameter{id},
+				Request:    fileUpload{},
+				Responses: map[int]interface{}{
+					http.StatusOK:                    models.InvoiceAttachment{},
+					http.StatusCreated:               models.InvoiceAttachment{},
+					http.StatusBadRequest:            errorBody{},
+					http.StatusUnauthorized:          messageBody{},
+					http.StatusNotFound:              errorBody{},
+					http.StatusRequestEntityTooLarge: errorBody{},
+					http.StatusUnsupportedMediaType:  errorBody{},
+					http.StatusInternalServerError:   errorBody{},
+				},
+			},
+			{
+				Method: echo.GET, SuffixPath: ":id/attachments", HandlerFunc: attachmentHandler.GetAttachments,
+				Summary:    "List the documents attached to an invoice",
+				Parameters: []*Parameter{id},
+				Responses: map[int]interface{}{
+					http.StatusOK:                  []*models.InvoiceAttachment{},
+					http.StatusBadRequest:          errorBody{},
+					http.StatusUnauthorized:        messageBody{},
+					http.StatusNotFound:            errorBody{},
+					http.StatusInternalServerError: errorBody{},
+				},
+			},
+			{
+				Method: echo.GET, SuffixPath: ":id/attachments/:attachment_id", HandlerFunc: attachmentHandler.GetAttachment,
+				Summary:    "Download a document attached to an invoice, with its content type and file name",
+				Parameters: []*Parameter{id, attachmentID},
+				Responses: map[int]interface{}{
+					http.StatusOK:                  fileBody{},
+					http.StatusBadRequest:          errorBody{},
+					http.StatusUnauthorized:        messageBody{},
+					http.StatusNotFound:            errorBody{},
+					http.StatusInternalServerError: errorBody{},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,117 @@
+package router
+
+import (
+	"net/http"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/labstack/echo/v4"
+	"github.com/niko-cb/uct/internal/domain/entity"
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+	"github.com/niko-cb/uct/internal/infrastructure/web/handler"
+)
+
+// invoiceCreditNote is a function to create a new Resource struct for the credit notes of an invoice
+func invoiceCreditNote(creditNoteHandler handler.ICreditNoteHandler) *Resource {
+	id := &Parameter{
+		Name: "id", In: openapi3.ParameterInPath, Description: "Id of the invoice",
+		Schema: openapi3.NewInt64Schema().WithMin(1),
+	}
+
+	return &Resource{
+		Resource: "invoices",
+		Endpoints: []*Endpoint{
+			{
+				Method: echo.POST, SuffixPath: ":id/credit-notes", HandlerFunc: creditNoteHandler.CreateCreditNote,
+				Summary: "Create a credit note of an invoice, reducing its outstanding amount. " +
+					"The fee and its tax are reversed for the payment amount credited",
+				Parameters: []*Parameter{id},
+				Request:    entity.CreditNote{},
+				Responses: map[int]interface{}{
+					http.StatusOK:                  models.CreditNote{},
+					http.StatusBadRequest:          errorBody{},
+					http.StatusUnauthorized:        messageBody{},
+					http.StatusNotFound:            errorBody{},
+					http.StatusUnprocessableEntity: errorBody{},
+					http.StatusInternalServerError: errorBody{},
+				},
+			},
+			{
+				Method: echo.GET, SuffixPath: ":id/credit-notes", HandlerFunc: creditNoteHandler.GetCreditNotesByInvoice,
+				Summary:    "List the credit notes of an invoice, void ones included",
+				Parameters: []*Parameter{id},
+				Responses: map[int]interface{}{
+					http.StatusOK:                  []*models.CreditNote{},
+					http.StatusBadRequest:          errorBody{},
+					http.StatusUnauthorized:        messageBody{},
+					http.StatusNotFound:            errorBody{},
+					http.StatusInternalServerError: errorBody{},
+				},
+			},
+		},
+	}
+}
+
+// creditNote is a function to create a new Resource struct for the credit note API
+func creditNote(creditNoteHandler handler.ICreditNoteHandler) *Resource {
+	id := &Parameter{
+		Name: "id", In: openapi3.ParameterInPath, Schema: openapi3.NewInt64Schema().WithMin(1),
+	}
+	ifMatch := &Parameter{
+		Name: handler.HeaderIfMatch, In: openapi3.ParameterInHeader, Required: true,
+		Description: "ETag of the credit note as read, or * for any version",
+		Schema:      openapi3.NewStringSchema(),
+	}
+
+	return &Resource{
+		Resource: "credit-notes",
+		Endpoints: []*Endpoint{
+			{
+				Method: echo.GET, SuffixPath: "", HandlerFunc: creditNoteHandler.GetCreditNotesByDateRange,
+				Summary: "List the credit notes issued between two dates (inclusive)",
+				Parameters: []*Parameter{
+					{Name: "from", In: openapi3.ParameterInQuery, Required: true, Schema: openapi3.NewStringSchema().WithFormat("date")},
+					{Name: "to", In: openapi3.ParameterInQuery, Required: true, Schema: openapi3.NewStringSchema().WithFormat("date")},
+					{Name: "limit", In: openapi3.ParameterInQuery, Description: "Maximum number of credit notes, the Link header points to the next page",
+						Schema: openapi3.NewIntegerSchema().WithMin(1).WithMax(maxPageSize)},
+					{Name: "after", In: openapi3.ParameterInQuery, Description: "Id of the last credit note of the previous page",
+						Schema: openapi3.NewInt64Schema().WithMin(0)},
+				},
+				Responses: map[int]interface{}{
+					http.StatusOK:                  []*models.CreditNote{},
+					http.StatusBadRequest:          errorBody{},
+					http.StatusUnauthorized:        messageBody{},
+					http.StatusInternalServerError: errorBody{},
+				},
+			},
+			{
+				Method: echo.GET, SuffixPath: ":id", HandlerFunc: creditNoteHandler.GetCreditNote,
+				Summary:    "Get a credit note, with its version as ETag",
+				Parameters: []*Parameter{id},
+				Responses: map[int]interface{}{
+					http.StatusOK:                  models.CreditNote{},
+					http.StatusBadRequest:          errorBody{},
+					http.StatusUnauthorized:        messageBody{},
+					http.StatusNotFound:            errorBody{},
+					http.StatusInternalServerError: errorBody{},
+				},
+			},
+			{
+				Method: echo.PATCH, SuffixPath: ":id", HandlerFunc: creditNoteHandler.UpdateCreditNoteStatus,
+				Summary: "Move a credit note to another status if it is still at the version given by If-Match: " +
+					"issued to applied or void, applied and void being final",
+				Parameters: []*Parameter{id, ifMatch},
+				Request:    entity.CreditNoteStatus{},
+				Responses: map[int]interface{}{
+					http.StatusOK:                   models.CreditNote{},
+					http.StatusBadRequest:           errorBody{},
+					http.StatusUnauthorized:         messageBody{},
+					http.StatusNotFound:             errorBody{},
+					http.StatusPreconditionFailed:   errorBody{},
+					http.StatusPreconditionRequired: errorBody{},
+					http.StatusUnprocessableEntity:  errorBody{},
+					http.StatusInternalServerError:  errorBody{},
+				},
+			},
+		},
+	}
+}
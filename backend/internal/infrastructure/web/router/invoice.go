@@ -1,23 +1,108 @@
 package router
 
 import (
+	"net/http"
+
+	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/labstack/echo/v4"
-	"github.com/niko-cb/uct/internal/di"
-	"github.com/niko-cb/uct/internal/infrastructure/web/config"
+	"github.com/niko-cb/uct/internal/controller"
+	"github.com/niko-cb/uct/internal/domain/entity"
+	"github.com/niko-cb/uct/internal/infrastructure/web/handler"
 )
 
+// maxPageSize is the maximum number of invoices per page
+const maxPageSize = 1000
+
 // invoice is a function to create a new Resource struct for the invoice API
-func invoice() *Resource {
-	var invoiceHandler = di.InitializeInvoiceHandler(&config.Cfg)
+func invoice(invoiceHandler handler.IInvoiceHandler) *Resource {
+	id := &Parameter{
+		Name: "id", In: openapi3.ParameterInPath, Schema: openapi3.NewInt64Schema().WithMin(1),
+	}
+	ifMatch := &Parameter{
+		Name: handler.HeaderIfMatch, In: openapi3.ParameterInHeader, Required: true,
+		Description: "ETag of the invoice as read, or * for any version",
+		Schema:      openapi3.NewStringSchema(),
+	}
 
 	return &Resource{
 		Resource: "invoices",
 		Endpoints: []*Endpoint{
 			{
 				Method: echo.POST, SuffixPath: "", HandlerFunc: invoiceHandler.CreateInvoice,
+				Summary: "Create an invoice, calculating the amounts of its items, its fee, tax and total amount. " +
+					"The exchange rate of its currency on the issue date is saved with it",
+				Request: entity.Invoice{},
+				Responses: map[int]interface{}{
+					http.StatusOK:                  messageBody{},
+					http.StatusBadRequest:          errorBody{},
+					http.StatusUnauthorized:        messageBody{},
+					http.StatusNotFound:            errorBody{},
+					http.StatusUnprocessableEntity: errorBody{},
+					http.StatusInternalServerError: errorBody{},
+				},
 			},
 			{
 				Method: echo.GET, SuffixPath: "", HandlerFunc: invoiceHandler.GetInvoicesByDateRange,
+				Summary: "List the invoices due between two dates (inclusive), or with an installment due between them",
+				Parameters: []*Parameter{
+					{Name: "from", In: openapi3.ParameterInQuery, Required: true, Schema: openapi3.NewStringSchema().WithFormat("date")},
+					{Name: "to", In: openapi3.ParameterInQuery, Required: true, Schema: openapi3.NewStringSchema().WithFormat("date")},
+					{Name: "by", In: openapi3.ParameterInQuery, Description: "Date the range applies to, the due date of the invoices by default",
+						Schema: openapi3.NewStringSchema().WithEnum(controller.ListByDueDate, controller.ListByInstallmentDueDate)},
+					{Name: "limit", In: openapi3.ParameterInQuery, Description: "Maximum number of invoices, the Link header points to the next page",
+						Schema: openapi3.NewIntegerSchema().WithMin(1).WithMax(maxPageSize)},
+					{Name: "after", In: openapi3.ParameterInQuery, Description: "Id of the last invoice of the previous page",
+						Schema: openapi3.NewInt64Schema().WithMin(0)},
+				},
+				Responses: map[int]interface{}{
+					http.StatusOK:                  []*entity.InvoiceDetails{},
+					http.StatusBadRequest:          errorBody{},
+					http.StatusUnauthorized:        messageBody{},
+					http.StatusInternalServerError: errorBody{},
+				},
+			},
+			{
+				Method: echo.GET, SuffixPath: ":id", HandlerFunc: invoiceHandler.GetInvoice,
+				Summary:    "Get an invoice, with its version as ETag",
+				Parameters: []*Parameter{id},
+				Responses: map[int]interface{}{
+					http.StatusOK:                  entity.InvoiceDetails{},
+					http.StatusBadRequest:          errorBody{},
+					http.StatusUnauthorized:        messageBody{},
+					http.StatusNotFound:            errorBody{},
+					http.StatusInternalServerError: errorBody{},
+				},
+			},
+			{
+				Method: echo.PUT, SuffixPath: ":id", HandlerFunc: invoiceHandler.UpdateInvoice,
+				Summary: "Replace an invoice if it is still at the version given by If-Match. " +
+					"The status of an invoice with payments is derived from them",
+				Parameters: []*Parameter{id, ifMatch},
+				Request:    entity.Invoice{},
+				Responses: map[int]interface{}{
+					http.StatusOK:                   entity.InvoiceDetails{},
+					http.StatusBadRequest:           errorBody{},
+					http.StatusUnauthorized:         messageBody{},
+					http.StatusNotFound:             errorBody{},
+					http.StatusPreconditionFailed:   errorBody{},
+					http.StatusPreconditionRequired: errorBody{},
+					http.StatusUnprocessableEntity:  errorBody{},
+					http.StatusInternalServerError:  errorBody{},
+				},
+			},
+			{
+				Method: echo.DELETE, SuffixPath: ":id", HandlerFunc: invoiceHandler.DeleteInvoice,
+				Summary:    "Delete an invoice if it is still at the version given by If-Match",
+				Parameters: []*Parameter{id, ifMatch},
+				Responses: map[int]interface{}{
+					http.StatusNoContent:            nil,
+					http.StatusBadRequest:           errorBody{},
+					http.StatusUnauthorized:         messageBody{},
+					http.StatusNotFound:             errorBody{},
+					http.StatusPreconditionFailed:   errorBody{},
+					http.StatusPreconditionRequired: errorBody{},
+					http.StatusInternalServerError:  errorBody{},
+				},
 			},
 		},
 	}
@@ -0,0 +1,289 @@
+package router
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3gen"
+	"github.com/niko-cb/uct/internal/domain/entity"
+	"github.com/niko-cb/uct/internal/infrastructure/web/handler"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/types"
+)
+
+const (
+	// securityScheme is the name of the JWT authentication in the specification
+	securityScheme = "bearerAuth"
+
+	// decimalPattern matches the decimals as they are marshalled, e.g. "10440.00"
+	decimalPattern = `^-?[0-9]+(\.[0-9]+)?$`
+)
+
+// Parameter documents a path, query or header parameter of an endpoint
+type Parameter struct {
+	Name        string
+	In          string // openapi3.ParameterInPath, ParameterInQuery or ParameterInHeader
+	Description string
+	Required    bool // path parameters are always required
+	Schema      *openapi3.Schema
+}
+
+// errorBody is the body of the error responses
+type errorBody struct {
+	Error string `json:"error"`
+}
+
+// messageBody is the body of the responses without data, and of the authentication errors
+type messageBody struct {
+	Message string `json:"message"`
+}
+
+// fileUpload is the request body of the uploads: a multipart/form-data form with the file in its file field
+type fileUpload struct{}
+
+// fileBody is the body of the downloads: the content of a file, of one of entity.AttachmentTypes
+type fileBody struct{}
+
+// xmlDocument is the body of the electronic invoices, an XML document of entity.AttachmentTypeXML
+type xmlDocument struct{}
+
+// fileField is the field of the form holding the uploaded file
+const fileField = "file"
+
+// knownSchemas are the schemas of the types marshalled differently from their fields
+var knownSchemas = map[reflect.Type]func() *openapi3.Schema{
+	reflect.TypeOf(types.Decimal{}):     func() *openapi3.Schema { return openapi3.NewStringSchema().WithPattern(decimalPattern) },
+	reflect.TypeOf(types.NullDecimal{}): func() *openapi3.Schema { return openapi3.NewStringSchema().WithPattern(decimalPattern).WithNullable() },
+	reflect.TypeOf(null.String{}):       func() *openapi3.Schema { return openapi3.NewStringSchema().WithNullable() },
+	reflect.TypeOf(null.Int64{}):        func() *openapi3.Schema { return openapi3.NewInt64Schema().WithNullable() },
+	reflect.TypeOf(null.Time{}):         func() *openapi3.Schema { return openapi3.NewDateTimeSchema().WithNullable() },
+}
+
+// Path returns the path of an endpoint in the OpenAPI specification, relative to its version
+// (e.g. /invoices/{id} for the suffix :id)
+func (r *Resource) Path(e *Endpoint) string {
+	path := "/" + r.Resource
+	for _, segment := range strings.Split(e.SuffixPath, "/") {
+		if segment == "" {
+			continue
+		}
+		if strings.HasPrefix(segment, ":") {
+			segment = "{" + segment[1:] + "}"
+		}
+		path += "/" + segment
+	}
+	return path
+}
+
+// OpenAPI generates the OpenAPI 3 specification of a version from the router table.
+// Schemas are generated from the Go types of the bodies, so they can't drift from the structs
+func (v *Version) OpenAPI(basePath string) (*openapi3.T, error) {
+	spec := &openapi3.T{
+		OpenAPI: "3.0.3",
+		Info:    &openapi3.Info{Title: "uct", Version: v.Version},
+		Servers: openapi3.Servers{{URL: basePath}},
+		Paths:   openapi3.NewPaths(),
+		Components: &openapi3.Components{
+			Schemas: openapi3.Schemas{},
+			SecuritySchemes: openapi3.SecuritySchemes{
+				securityScheme: &openapi3.SecuritySchemeRef{Value: openapi3.NewJWTSecurityScheme()},
+			},
+		},
+		Security: openapi3.SecurityRequirements{openapi3.NewSecurityRequirement().Authenticate(securityScheme)},
+	}
+	g := &schemaGenerator{components: spec.Components.Schemas, types: map[string]reflect.Type{}}
+
+	for _, resource := range v.Resources {
+		for _, endpoint := range resource.Endpoints {
+			operation, err := g.operation(resource, endpoint)
+			if err != nil {
+				return nil, fmt.Errorf("%s %s: %w", endpoint.Method, resource.Path(endpoint), err)
+			}
+			path := resource.Path(endpoint)
+			item := spec.Paths.Value(path)
+			if item == nil {
+				item = &openapi3.PathItem{}
+				spec.Paths.Set(path, item)
+			}
+			item.SetOperation(endpoint.Method, operation)
+		}
+	}
+
+	// Link the references to the components, as if the specification had been loaded from a file
+	if err := openapi3.NewLoader().ResolveRefsIn(spec, nil); err != nil {
+		return nil, err
+	}
+	return spec, spec.Validate(context.Background())
+}
+
+// schemaGenerator generates the schemas of the bodies, and adds them to the components
+type schemaGenerator struct {
+	components openapi3.Schemas
+	// types records the type of each component, to detect two types with the same name
+	types map[string]reflect.Type
+}
+
+func (g *schemaGenerator) operation(resource *Resource, endpoint *Endpoint) (*openapi3.Operation, error) {
+	operation := openapi3.NewOperation()
+	operation.Summary = endpoint.Summary
+	operation.Tags = []string{resource.Resource}
+
+	for _, p := range endpoint.Parameters {
+		param := &openapi3.Parameter{
+			Name:        p.Name,
+			In:          p.In,
+			Description: p.Description,
+			Required:    p.Required || p.In == openapi3.ParameterInPath,
+			Schema:      openapi3.NewSchemaRef("", p.Schema),
+		}
+		operation.AddParameter(param)
+	}
+
+	// Every change can be retried safely with an Idempotency-Key, see the Idempotency middleware
+	responses := endpoint.Responses
+	if IsChange(endpoint.Method) {
+		operation.AddParameter(openapi3.NewHeaderParameter(handler.HeaderIdempotencyKey).
+			WithDescription("Unique key of the request, its retries with the same key get the same response").
+			WithSchema(openapi3.NewStringSchema().WithMaxLength(255)))
+
+		responses = make(map[int]interface{}, len(endpoint.Responses)+2)
+		for status, body := range endpoint.Responses {
+			responses[status] = body
+		}
+		responses[http.StatusConflict] = errorBody{}
+		responses[http.StatusUnprocessableEntity] = errorBody{}
+	}
+
+	if _, ok := endpoint.Request.(fileUpload); ok {
+		form := openapi3.NewObjectSchema().
+			WithProperty(fileField, openapi3.NewStringSchema().WithFormat("binary")).
+			WithRequired([]string{fileField})
+		operation.RequestBody = &openapi3.RequestBodyRef{Value: openapi3.NewRequestBody().WithRequired(true).
+			WithContent(openapi3.NewContentWithFormDataSchema(form))}
+	} else if _, ok := endpoint.Request.(xmlDocument); ok {
+		operation.RequestBody = &openapi3.RequestBodyRef{Value: openapi3.NewRequestBody().WithRequired(true).
+			WithContent(openapi3.NewContentWithSchema(openapi3.NewStringSchema(), []string{entity.AttachmentTypeXML}))}
+	} else if endpoint.Request != nil {
+		ref, err := g.schemaRef(endpoint.Request, false)
+		if err != nil {
+			return nil, err
+		}
+		operation.RequestBody = &openapi3.RequestBodyRef{Value: openapi3.NewRequestBody().WithRequired(true).WithJSONSchemaRef(ref)}
+	}
+
+	operation.Responses = openapi3.NewResponses()
+	operation.Responses.Delete("default")
+	for status, body := range responses {
+		response := openapi3.NewResponse().WithDescription(http.StatusText(status))
+		if _, ok := body.(fileBody); ok {
+			content := openapi3.Content{}
+			for _, contentType := range entity.AttachmentTypes {
+				content[contentType] = openapi3.NewMediaType().WithSchema(openapi3.NewStringSchema().WithFormat("binary"))
+			}
+			response.Content = content
+		} else if _, ok := body.(xmlDocument); ok {
+			response.Content = openapi3.NewContentWithSchema(openapi3.NewStringSchema(), []string{entity.AttachmentTypeXML})
+		} else if body != nil {
+			ref, err := g.schemaRef(body, true)
+			if err != nil {
+				return nil, err
+			}
+			response = response.WithJSONSchemaRef(ref)
+		}
+		operation.Responses.Set(strconv.Itoa(status), &openapi3.ResponseRef{Value: response})
+	}
+
+	return operation, nil
+}
+
+// schemaRef returns a reference to the component of the type of value (or an array of them for a slice).
+// Request bodies get their own components (suffixed by Input), without required fields
+func (g *schemaGenerator) schemaRef(value interface{}, response bool) (*openapi3.SchemaRef, error) {
+	t := reflect.TypeOf(value)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() == reflect.Slice {
+		items, err := g.schemaRef(reflect.New(t.Elem()).Elem().Interface(), response)
+		if err != nil {
+			return nil, err
+		}
+		return openapi3.NewSchemaRef("", &openapi3.Schema{Type: &openapi3.Types{openapi3.TypeArray}, Items: items}), nil
+	}
+
+	name := strings.TrimSuffix(strings.ToUpper(t.Name()[:1])+t.Name()[1:], "Body")
+	if !response {
+		name += "Input"
+	}
+	ref := "#/components/schemas/" + name
+	if existing, ok := g.types[name]; ok {
+		if existing != t {
+			return nil, fmt.Errorf("schema %s is used by both %s and %s", name, existing, t)
+		}
+		return openapi3.NewSchemaRef(ref, nil), nil
+	}
+
+	schema, err := openapi3gen.NewSchemaRefForValue(reflect.New(t).Elem().Interface(), nil,
+		openapi3gen.SchemaCustomizer(func(_ string, t reflect.Type, _ reflect.StructTag, schema *openapi3.Schema) error {
+			return customizeSchema(t, schema, response)
+		}))
+	if err != nil {
+		return nil, err
+	}
+	g.types[name] = t
+	g.components[name] = schema
+	return openapi3.NewSchemaRef(ref, nil), nil
+}
+
+// customizeSchema fixes the schemas of the known types, and makes the objects strict:
+// unknown fields are rejected, and the fields of responses are required unless omitempty
+func customizeSchema(t reflect.Type, schema *openapi3.Schema, response bool) error {
+	if known, ok := knownSchemas[t]; ok {
+		*schema = *known()
+		return nil
+	}
+	if t.Kind() != reflect.Struct || schema.Properties == nil {
+		return nil
+	}
+
+	schema.AdditionalProperties = openapi3.AdditionalProperties{Has: openapi3.Ptr(false)}
+	if !response {
+		return nil
+	}
+	schema.Required = requiredFields(t)
+	return nil
+}
+
+// requiredFields returns the JSON names of the fields of a struct which are not omitempty,
+// including the ones of its embedded structs
+func requiredFields(t reflect.Type) []string {
+	var required []string
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, options, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "" && field.Anonymous && field.Type.Kind() == reflect.Struct {
+			required = append(required, requiredFields(field.Type)...)
+			continue
+		}
+		if name == "" || name == "-" || strings.Contains(options, "omitempty") {
+			continue
+		}
+		required = append(required, name)
+	}
+	return required
+}
+
+// IsChange reports whether a method changes resources
+func IsChange(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return false
+	default:
+		return true
+	}
+}
@@ -0,0 +1,53 @@
+package router
+
+import (
+	"net/http"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/labstack/echo/v4"
+	"github.com/niko-cb/uct/internal/domain/entity"
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+	"github.com/niko-cb/uct/internal/infrastructure/web/handler"
+)
+
+// invoicePayment is a function to create a new Resource struct for the payments of an invoice
+func invoicePayment(paymentHandler handler.IPaymentHandler) *Resource {
+	id := &Parameter{
+		Name: "id", In: openapi3.ParameterInPath, Description: "Id of the invoice",
+		Schema: openapi3.NewInt64Schema().WithMin(1),
+	}
+
+	return &Resource{
+		Resource: "invoices",
+		Endpoints: []*Endpoint{
+			{
+				Method: echo.POST, SuffixPath: ":id/payments", HandlerFunc: paymentHandler.RecordPayment,
+				Summary: "Record a payment of an invoice, up to its outstanding amount. The invoice is returned with " +
+					"its status derived from its payments, partially_paid or paid, and its new version as ETag",
+				Parameters: []*Parameter{id},
+				Request:    entity.Payment{},
+				Responses: map[int]interface{}{
+					http.StatusOK:                  entity.InvoiceDetails{},
+					http.StatusBadRequest:          errorBody{},
+					http.StatusUnauthorized:        messageBody{},
+					http.StatusNotFound:            errorBody{},
+					http.StatusPreconditionFailed:  errorBody{},
+					http.StatusUnprocessableEntity: errorBody{},
+					http.StatusInternalServerError: errorBody{},
+				},
+			},
+			{
+				Method: echo.GET, SuffixPath: ":id/payments", HandlerFunc: paymentHandler.GetPayments,
+				Summary:    "List the payments of an invoice",
+				Parameters: []*Parameter{id},
+				Responses: map[int]interface{}{
+					http.StatusOK:                  []*models.InvoicePayment{},
+					http.StatusBadRequest:          errorBody{},
+					http.StatusUnauthorized:        messageBody{},
+					http.StatusNotFound:            errorBody{},
+					http.StatusInternalServerError: errorBody{},
+				},
+			},
+		},
+	}
+}
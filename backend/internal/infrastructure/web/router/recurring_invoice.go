@@ -0,0 +1,101 @@
+package router
+
+import (
+	"net/http"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/labstack/echo/v4"
+	"github.com/niko-cb/uct/internal/controller"
+	"github.com/niko-cb/uct/internal/domain/entity"
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+	"github.com/niko-cb/uct/internal/infrastructure/web/handler"
+)
+
+// recurringInvoice is a function to create a new Resource struct for the recurring invoice API
+func recurringInvoice(recurringInvoiceHandler handler.IRecurringInvoiceHandler) *Resource {
+	id := &Parameter{
+		Name: "id", In: openapi3.ParameterInPath, Schema: openapi3.NewInt64Schema().WithMin(1),
+	}
+	ifMatch := &Parameter{
+		Name: handler.HeaderIfMatch, In: openapi3.ParameterInHeader, Required: true,
+		Description: "ETag of the recurring invoice as read, or * for any version",
+		Schema:      openapi3.NewStringSchema(),
+	}
+
+	return &Resource{
+		Resource: "recurring-invoices",
+		Endpoints: []*Endpoint{
+			{
+				Method: echo.POST, SuffixPath: "", HandlerFunc: recurringInvoiceHandler.CreateRecurringInvoice,
+				Summary: "Create a recurring invoice, billing a client monthly on a day, at the end of the month or every N weeks. " +
+					"An invoice is generated on each occurrence from the start date, until the end date or max_occurrences",
+				Request: entity.RecurringInvoice{},
+				Responses: map[int]interface{}{
+					http.StatusOK:                  models.RecurringInvoice{},
+					http.StatusBadRequest:          errorBody{},
+					http.StatusUnauthorized:        messageBody{},
+					http.StatusNotFound:            errorBody{},
+					http.StatusInternalServerError: errorBody{},
+				},
+			},
+			{
+				Method: echo.GET, SuffixPath: "", HandlerFunc: recurringInvoiceHandler.GetRecurringInvoicesByClient,
+				Summary: "List the recurring invoices of a client",
+				Parameters: []*Parameter{
+					{Name: "client_id", In: openapi3.ParameterInQuery, Required: true, Schema: openapi3.NewInt64Schema().WithMin(1)},
+				},
+				Responses: map[int]interface{}{
+					http.StatusOK:                  []*models.RecurringInvoice{},
+					http.StatusBadRequest:          errorBody{},
+					http.StatusUnauthorized:        messageBody{},
+					http.StatusInternalServerError: errorBody{},
+				},
+			},
+			{
+				Method: echo.GET, SuffixPath: ":id", HandlerFunc: recurringInvoiceHandler.GetRecurringInvoice,
+				Summary:    "Get a recurring invoice, with its version as ETag",
+				Parameters: []*Parameter{id},
+				Responses: map[int]interface{}{
+					http.StatusOK:                  models.RecurringInvoice{},
+					http.StatusBadRequest:          errorBody{},
+					http.StatusUnauthorized:        messageBody{},
+					http.StatusNotFound:            errorBody{},
+					http.StatusInternalServerError: errorBody{},
+				},
+			},
+			{
+				Method: echo.PATCH, SuffixPath: ":id", HandlerFunc: recurringInvoiceHandler.UpdateRecurringInvoiceStatus,
+				Summary: "Pause (paused) or resume (active) a recurring invoice if it is still at the version given by If-Match. " +
+					"A resumed recurring invoice skips the occurrences missed while it was paused",
+				Parameters: []*Parameter{id, ifMatch},
+				Request:    entity.RecurringInvoiceStatus{},
+				Responses: map[int]interface{}{
+					http.StatusOK:                   models.RecurringInvoice{},
+					http.StatusBadRequest:           errorBody{},
+					http.StatusUnauthorized:         messageBody{},
+					http.StatusNotFound:             errorBody{},
+					http.StatusPreconditionFailed:   errorBody{},
+					http.StatusPreconditionRequired: errorBody{},
+					http.StatusInternalServerError:  errorBody{},
+				},
+			},
+			{
+				Method: echo.GET, SuffixPath: ":id/occurrences", HandlerFunc: recurringInvoiceHandler.PreviewOccurrences,
+				Summary: "Preview the next invoices a recurring invoice will generate, with their amounts. " +
+					"A paused recurring invoice is previewed as if resumed today",
+				Parameters: []*Parameter{
+					id,
+					{Name: "count", In: openapi3.ParameterInQuery, Description: "Number of occurrences, 12 by default",
+						Schema: openapi3.NewIntegerSchema().WithMin(1).WithMax(controller.MaxPreviewCount)},
+				},
+				Responses: map[int]interface{}{
+					http.StatusOK:                  []*entity.Occurrence{},
+					http.StatusBadRequest:          errorBody{},
+					http.StatusUnauthorized:        messageBody{},
+					http.StatusNotFound:            errorBody{},
+					http.StatusInternalServerError: errorBody{},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,95 @@
+package router
+
+import (
+	"net/http"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/labstack/echo/v4"
+	"github.com/niko-cb/uct/internal/domain/entity"
+	"github.com/niko-cb/uct/internal/infrastructure/web/handler"
+)
+
+// report is a function to create a new Resource struct for the report API
+func report(reportHandler handler.IReportHandler) *Resource {
+	format := &Parameter{
+		Name: "format", In: openapi3.ParameterInQuery, Description: "json (default), or csv for a spreadsheet",
+		Schema: openapi3.NewStringSchema().WithEnum(handler.FormatJSON, handler.FormatCSV),
+	}
+	baseCurrency := &Parameter{
+		Name: "base_currency", In: openapi3.ParameterInQuery,
+		Description: "Currency the amounts are converted to at the rate of each invoice, only the companies of that base currency are included, JPY by default",
+		Schema:      openapi3.NewStringSchema().WithEnum(entity.CurrencyJPY, entity.CurrencyUSD, entity.CurrencyEUR, entity.CurrencyGBP, entity.CurrencyCNY),
+	}
+
+	return &Resource{
+		Resource: "reports",
+		Endpoints: []*Endpoint{
+			{
+				Method: echo.GET, SuffixPath: "aging", HandlerFunc: reportHandler.GetAgingReport,
+				Summary: "Bucket the unpaid invoices by days past their due date (current, 1-30, 31-60, 61-90, 90+), by client",
+				Parameters: []*Parameter{
+					{Name: "as_of", In: openapi3.ParameterInQuery, Description: "Date of the report, today by default",
+						Schema: openapi3.NewStringSchema().WithFormat("date")},
+					baseCurrency,
+					format,
+				},
+				Responses: map[int]interface{}{
+					http.StatusOK:                  entity.AgingReport{},
+					http.StatusBadRequest:          errorBody{},
+					http.StatusUnauthorized:        messageBody{},
+					http.StatusInternalServerError: errorBody{},
+				},
+			},
+			{
+				Method: echo.GET, SuffixPath: "cash-forecast", HandlerFunc: reportHandler.GetCashForecast,
+				Summary: "Sum the payouts of the open invoices by period of their due date, fee and tax separated, " +
+					"optionally compared to the available balance of the company",
+				Parameters: []*Parameter{
+					{Name: "from", In: openapi3.ParameterInQuery, Description: "First due date, today by default",
+						Schema: openapi3.NewStringSchema().WithFormat("date")},
+					{Name: "to", In: openapi3.ParameterInQuery, Description: "Last due date, 30 days after from by default",
+						Schema: openapi3.NewStringSchema().WithFormat("date")},
+					{Name: "granularity", In: openapi3.ParameterInQuery, Description: "Length of the periods, day by default",
+						Schema: openapi3.NewStringSchema().WithEnum(entity.GranularityDay, entity.GranularityWeek, entity.GranularityMonth)},
+					{Name: "company_id", In: openapi3.ParameterInQuery, Description: "Company of the invoices, every company by default",
+						Schema: openapi3.NewInt64Schema().WithMin(1)},
+					{Name: "project_overdue", In: openapi3.ParameterInQuery, Description: "Move the overdue invoices to the next business day",
+						Schema: openapi3.NewBoolSchema()},
+					{Name: "compare_balance", In: openapi3.ParameterInQuery, Description: "Compare the payouts to the available balance of the company",
+						Schema: openapi3.NewBoolSchema()},
+					baseCurrency,
+					format,
+				},
+				Responses: map[int]interface{}{
+					http.StatusOK:                  entity.CashForecast{},
+					http.StatusBadRequest:          errorBody{},
+					http.StatusUnauthorized:        messageBody{},
+					http.StatusNotFound:            errorBody{},
+					http.StatusUnprocessableEntity: errorBody{},
+					http.StatusInternalServerError: errorBody{},
+				},
+			},
+			{
+				Method: echo.GET, SuffixPath: "unregistered-issuers", HandlerFunc: reportHandler.GetIssuerReport,
+				Summary: "Flag the invoices whose client was not a registered qualified invoice issuer on the issue date, " +
+					"according to the imported issuers of the National Tax Agency, with the reason",
+				Parameters: []*Parameter{
+					{Name: "from", In: openapi3.ParameterInQuery, Description: "First issue date, 30 days before to by default",
+						Schema: openapi3.NewStringSchema().WithFormat("date")},
+					{Name: "to", In: openapi3.ParameterInQuery, Description: "Last issue date, today by default",
+						Schema: openapi3.NewStringSchema().WithFormat("date")},
+					{Name: "company_id", In: openapi3.ParameterInQuery, Description: "Company of the invoices, every company by default",
+						Schema: openapi3.NewInt64Schema().WithMin(1)},
+					format,
+				},
+				Responses: map[int]interface{}{
+					http.StatusOK:                  entity.IssuerReport{},
+					http.StatusBadRequest:          errorBody{},
+					http.StatusUnauthorized:        messageBody{},
+					http.StatusNotFound:            errorBody{},
+					http.StatusInternalServerError: errorBody{},
+				},
+			},
+		},
+	}
+}
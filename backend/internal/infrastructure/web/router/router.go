@@ -2,6 +2,7 @@ package router
 
 import (
 	"github.com/labstack/echo/v4"
+	"github.com/niko-cb/uct/internal/di"
 )
 
 type API struct {
@@ -22,15 +23,31 @@ type Endpoint struct {
 	Method      string
 	SuffixPath  string
 	HandlerFunc echo.HandlerFunc
+
+	// The fields below document the endpoint in the OpenAPI specification, which the requests are validated against
+	Summary    string
+	Parameters []*Parameter
+	// Request is a value of the type the handler binds the body to, nil without body
+	Request interface{}
+	// Responses holds a value of the type of the body for each status, nil without body
+	Responses map[int]interface{}
 }
 
-func GetAPIs() *API {
+// GetAPIs returns the API table, with the handlers of the application container
+func GetAPIs(app *di.App) *API {
 	return &API{
 		Versions: []*Version{
 			{
 				Version: "v1",
 				Resources: []*Resource{
-					invoice(),
+					invoice(app.InvoiceHandler),
+					invoiceCreditNote(app.CreditNoteHandler),
+					creditNote(app.CreditNoteHandler),
+					invoicePayment(app.PaymentHandler),
+					invoiceAttachment(app.AttachmentHandler),
+					invoiceUBL(app.UBLHandler),
+					recurringInvoice(app.RecurringInvoiceHandler),
+					report(app.ReportHandler),
 				},
 			},
 		},
@@ -0,0 +1,53 @@
+package router
+
+import (
+	"net/http"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/labstack/echo/v4"
+	"github.com/niko-cb/uct/internal/domain/entity"
+	"github.com/niko-cb/uct/internal/infrastructure/web/handler"
+)
+
+// invoiceUBL is a function to create a new Resource struct for the invoices as UBL / JP PINT electronic invoices
+func invoiceUBL(ublHandler handler.IUBLHandler) *Resource {
+	return &Resource{
+		Resource: "invoices",
+		Endpoints: []*Endpoint{
+			{
+				Method: echo.POST, SuffixPath: "ubl", HandlerFunc: ublHandler.ImportInvoice,
+				Summary: "Import a UBL 2.1 invoice (JP PINT) received by a company: the seller is matched to a client " +
+					"by registration number, else by name, else created, and the document is attached to the invoice",
+				Parameters: []*Parameter{
+					{Name: "company_id", In: openapi3.ParameterInQuery, Required: true, Description: "Id of the company receiving the invoice",
+						Schema: openapi3.NewInt64Schema().WithMin(1)},
+				},
+				Request: xmlDocument{},
+				Responses: map[int]interface{}{
+					http.StatusCreated:               entity.InvoiceDetails{},
+					http.StatusBadRequest:            errorBody{},
+					http.StatusUnauthorized:          messageBody{},
+					http.StatusNotFound:              errorBody{},
+					http.StatusRequestEntityTooLarge: errorBody{},
+					http.StatusInternalServerError:   errorBody{},
+				},
+			},
+			{
+				Method: echo.GET, SuffixPath: ":id/ubl", HandlerFunc: ublHandler.ExportInvoice,
+				Summary: "Export an invoice with items as the UBL 2.1 invoice (JP PINT) its client issued to its company, " +
+					"without its fee",
+				Parameters: []*Parameter{
+					{Name: "id", In: openapi3.ParameterInPath, Description: "Id of the invoice", Schema: openapi3.NewInt64Schema().WithMin(1)},
+				},
+				Responses: map[int]interface{}{
+					http.StatusOK:                  xmlDocument{},
+					http.StatusBadRequest:          errorBody{},
+					http.StatusUnauthorized:        messageBody{},
+					http.StatusNotFound:            errorBody{},
+					http.StatusUnprocessableEntity: errorBody{},
+					http.StatusInternalServerError: errorBody{},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,39 @@
+package server
+
+import (
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+	"testing"
+
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestAttachments_Download checks an attachment is downloaded as the file uploaded, under its base name,
+// and never sniffed by the browsers into something else
+func TestAttachments_Download(t *testing.T) {
+	ts, token := newMockServer(t)
+
+	res := request(t, ts, token, http.MethodPost, "/api/v1/invoices/1/attachments", "",
+		formBody("file", `C:\scans\請求書 6月.pdf`, "application/octet-stream", pdfContent))
+	require.Equal(t, http.StatusCreated, res.StatusCode)
+	var attachment models.InvoiceAttachment
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&attachment))
+	assert.Equal(t, "請求書 6月.pdf", attachment.FileName)
+	assert.Equal(t, "application/pdf", attachment.ContentType)
+
+	res = request(t, ts, token, http.MethodGet, "/api/v1/invoices/1/attachments/1", "", "")
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	body, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	assert.Equal(t, pdfContent, string(body))
+	assert.Equal(t, "application/pdf", res.Header.Get("Content-Type"))
+	assert.Equal(t, "nosniff", res.Header.Get("X-Content-Type-Options"))
+	disposition, params, err := mime.ParseMediaType(res.Header.Get("Content-Disposition"))
+	require.NoError(t, err)
+	assert.Equal(t, "attachment", disposition)
+	assert.Equal(t, "請求書 6月.pdf", params["filename"])
+}
@@ -1,7 +1,11 @@
 package server
 
 import (
+	"github.com/golang-jwt/jwt/v5"
 	echojwt "github.com/labstack/echo-jwt/v4"
+	"github.com/labstack/echo/v4"
+	"github.com/niko-cb/uct/internal/infrastructure/web/config/actx"
+	"github.com/niko-cb/uct/internal/infrastructure/web/token"
 )
 
 // Auth is a middleware to authenticate the user using JWT
@@ -10,5 +14,31 @@ func (s *server) Auth(secretKey string) {
 	// This is just for demonstration purpose
 	// The secret key could be stored in a cloud service like AWS Secrets Manager or GCP Secret Manager
 	// Or in another secure location like a Kubernetes secret or a .env file, etc.
-	s.Use(echojwt.JWT([]byte(secretKey)))
+	s.Use(echojwt.WithConfig(echojwt.Config{
+		SigningKey:    []byte(secretKey),
+		NewClaimsFunc: func(echo.Context) jwt.Claims { return new(token.Claims) },
+		// Orchestrators and load balancers don't have a token, and the specification is public
+		Skipper:        isPublic,
+		SuccessHandler: withUser,
+	}))
+}
+
+// withUser adds the subject of the token to the request's context, e.g. so the database
+// routing knows whose writes to read back from the primary
+func withUser(c echo.Context) {
+	token, ok := c.Get("user").(*jwt.Token)
+	if !ok {
+		return
+	}
+	subject, err := token.Claims.GetSubject()
+	if err != nil || subject == "" {
+		return
+	}
+	ctx := actx.Context(c.Request().Context(), actx.UserID, subject)
+	c.SetRequest(c.Request().WithContext(ctx))
+}
+
+// isPublic reports whether the request is for an endpoint which doesn't require a token
+func isPublic(c echo.Context) bool {
+	return isProbe(c) || isOpenAPI(c)
 }
@@ -0,0 +1,46 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// uploadBodyHeadroom is what the body of an upload may have beyond the largest attachment,
+// e.g. the other parts and the headers of a multipart form
+const uploadBodyHeadroom = 1 << 20
+
+// BodyLimit is a middleware limiting the size of the request bodies, before they are read by the other middlewares
+// and the handlers: maxBodySize for the JSON bodies, maxUploadSize for the uploads and the documents, whose handlers
+// check the size of the file again. Larger bodies answer 413, at once when their Content-Length tells it
+func (s *server) BodyLimit(maxBodySize, maxUploadSize int64) {
+	s.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			limit := maxBodySize
+			if route, ok := s.routes[routeKey(c.Request().Method, c.Path())]; ok && isUpload(route.Operation) {
+				limit = maxUploadSize
+			}
+			if c.Request().ContentLength > limit {
+				return bodyTooLarge(c, limit)
+			}
+			c.Request().Body = http.MaxBytesReader(c.Response(), c.Request().Body, limit)
+			return next(c)
+		}
+	})
+}
+
+// isBodyTooLarge reports whether err is the read of a body beyond the limit of BodyLimit, and returns the limit
+func isBodyTooLarge(err error) (int64, bool) {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		return tooLarge.Limit, true
+	}
+	return 0, false
+}
+
+// bodyTooLarge answers 413 to a request whose body is larger than limit
+func bodyTooLarge(c echo.Context, limit int64) error {
+	return c.JSON(http.StatusRequestEntityTooLarge, map[string]string{"error": fmt.Sprintf("request body larger than %d bytes", limit)})
+}
@@ -0,0 +1,43 @@
+package server
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestBodyLimit checks the JSON bodies are limited to BODY_MAX_SIZE, whether their length is known or not,
+// and the uploads to the largest attachment
+func TestBodyLimit(t *testing.T) {
+	ts, token := newMockServer(t)
+	large := invoiceBody + strings.Repeat(" ", 4<<10)
+
+	res := request(t, ts, token, http.MethodPost, "/api/v1/invoices", "", large)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, res.StatusCode)
+
+	// Without Content-Length, the body is cut when it is read
+	for _, key := range []string{"", "chunked-1"} {
+		req, _ := http.NewRequest(http.MethodPost, ts.URL+"/api/v1/invoices", io.MultiReader(strings.NewReader(large)))
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("Content-Type", "application/json")
+		if key != "" {
+			req.Header.Set("Idempotency-Key", key)
+		}
+		res, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		res.Body.Close()
+		assert.Equal(t, http.StatusRequestEntityTooLarge, res.StatusCode)
+	}
+
+	// An upload larger than a JSON body, and one larger than an attachment with the headroom of the form
+	res = request(t, ts, token, http.MethodPost, "/api/v1/invoices/1/attachments", "",
+		formBody("file", "scan.pdf", "application/pdf", pdfContent+strings.Repeat(" ", 6<<10)))
+	assert.Equal(t, http.StatusCreated, res.StatusCode)
+	res = request(t, ts, token, http.MethodPost, "/api/v1/invoices/1/attachments", "",
+		formBody("file", "scan.pdf", "application/pdf", pdfContent+strings.Repeat(" ", uploadBodyHeadroom+8<<10)))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, res.StatusCode)
+}
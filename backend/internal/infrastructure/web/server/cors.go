@@ -3,12 +3,16 @@ package server
 import (
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
+	"github.com/niko-cb/uct/internal/infrastructure/web/handler"
 )
 
 func (s *server) CORS() {
 	s.Use(middleware.CORSWithConfig(middleware.CORSConfig{
 		AllowOrigins: []string{"*"},
-		AllowHeaders: []string{echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept},
-		AllowMethods: []string{echo.GET, echo.POST, echo.OPTIONS},
+		AllowHeaders: []string{
+			echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept, handler.HeaderIfMatch, handler.HeaderIdempotencyKey,
+		},
+		AllowMethods:  []string{echo.GET, echo.POST, echo.PUT, echo.PATCH, echo.DELETE, echo.OPTIONS},
+		ExposeHeaders: []string{handler.HeaderETag, handler.HeaderLink, handler.HeaderIdempotentReplayed},
 	}))
 }
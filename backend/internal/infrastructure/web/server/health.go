@@ -0,0 +1,22 @@
+package server
+
+import (
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	LivenessPath  = "/healthz"
+	ReadinessPath = "/readyz"
+)
+
+// probes registers the liveness and readiness endpoints used by orchestrators and load balancers.
+// They are outside of the versioned API and don't require authentication
+func (s *server) probes() {
+	s.Router().Add(echo.GET, LivenessPath, s.app.HealthHandler.Liveness)
+	s.Router().Add(echo.GET, ReadinessPath, s.app.HealthHandler.Readiness)
+}
+
+// isProbe reports whether the request is for a probe endpoint
+func isProbe(c echo.Context) bool {
+	return c.Path() == LivenessPath || c.Path() == ReadinessPath
+}
@@ -0,0 +1,152 @@
+package server
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/niko-cb/uct/internal/infrastructure/web/config/actx"
+	"github.com/niko-cb/uct/internal/infrastructure/web/handler"
+	"github.com/niko-cb/uct/internal/infrastructure/web/router"
+)
+
+// idempotencyPruneInterval is how often the expired responses are removed
+const idempotencyPruneInterval = time.Minute
+
+// idempotentRequest is a request made with an Idempotency-Key, and its response once done
+type idempotentRequest struct {
+	fingerprint [sha256.Size]byte
+	done        bool
+	expires     time.Time
+
+	status int
+	header http.Header
+	body   []byte
+}
+
+// idempotencyCache holds the responses to replay, by user and Idempotency-Key.
+// It is local to the instance: a retry reaching another instance is executed again
+type idempotencyCache struct {
+	mu        sync.Mutex
+	ttl       time.Duration
+	requests  map[string]*idempotentRequest
+	lastPrune time.Time
+}
+
+func newIdempotencyCache(ttl time.Duration) *idempotencyCache {
+	return &idempotencyCache{ttl: ttl, requests: make(map[string]*idempotentRequest)}
+}
+
+// start records a new request, or returns the existing one with the same key (started is then false)
+func (c *idempotencyCache) start(key string, fingerprint [sha256.Size]byte, now time.Time) (_ idempotentRequest, started bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if now.Sub(c.lastPrune) > idempotencyPruneInterval {
+		for k, r := range c.requests {
+			if r.done && now.After(r.expires) {
+				delete(c.requests, k)
+			}
+		}
+		c.lastPrune = now
+	}
+
+	if r, ok := c.requests[key]; ok && (!r.done || now.Before(r.expires)) {
+		return *r, false
+	}
+	c.requests[key] = &idempotentRequest{fingerprint: fingerprint}
+	return idempotentRequest{}, true
+}
+
+// finish records the response of a request, to replay it until it expires
+func (c *idempotencyCache) finish(key string, status int, header http.Header, body []byte, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	r := c.requests[key]
+	r.done, r.expires = true, now.Add(c.ttl)
+	r.status, r.header, r.body = status, header, body
+}
+
+// abort forgets a request which failed, so that a retry executes it again
+func (c *idempotencyCache) abort(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.requests, key)
+}
+
+// responseRecorder copies the body written to the client
+type responseRecorder struct {
+	http.ResponseWriter
+	body bytes.Buffer
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
+
+// Idempotency is a middleware making the retries of a change safe: the response to a request with an
+// Idempotency-Key is stored, and replayed to the requests with the same key instead of executing them again.
+// Server errors are not stored, so they can be retried. The body is read to be compared to the one of the first
+// request, within the limit of BodyLimit
+func (s *server) Idempotency(ttl time.Duration) {
+	cache := newIdempotencyCache(ttl)
+
+	s.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			idempotencyKey := c.Request().Header.Get(handler.HeaderIdempotencyKey)
+			if idempotencyKey == "" || !router.IsChange(c.Request().Method) {
+				return next(c)
+			}
+
+			body, err := io.ReadAll(c.Request().Body)
+			if limit, ok := isBodyTooLarge(err); ok {
+				return bodyTooLarge(c, limit)
+			}
+			if err != nil {
+				return err
+			}
+			c.Request().Body = io.NopCloser(bytes.NewReader(body))
+			fingerprint := sha256.Sum256([]byte(c.Request().Method + " " + c.Request().URL.RequestURI() + "\n" + string(body)))
+
+			// Keys are per user, so nobody can replay the response of someone else
+			user, _ := actx.Get(c.Request().Context(), actx.UserID).(string)
+			key := user + " " + idempotencyKey
+
+			previous, started := cache.start(key, fingerprint, time.Now())
+			switch {
+			case started:
+			case previous.fingerprint != fingerprint:
+				return c.JSON(http.StatusUnprocessableEntity, map[string]string{"error": "Idempotency-Key already used for a different request"})
+			case !previous.done:
+				return c.JSON(http.StatusConflict, map[string]string{"error": "a request with this Idempotency-Key is in progress"})
+			default:
+				for name, values := range previous.header {
+					c.Response().Header()[name] = values
+				}
+				c.Response().Header().Set(handler.HeaderIdempotentReplayed, "true")
+				c.Response().WriteHeader(previous.status)
+				_, err := c.Response().Write(previous.body)
+				return err
+			}
+
+			recorder := &responseRecorder{ResponseWriter: c.Response().Writer}
+			c.Response().Writer = recorder
+			err = next(c)
+			c.Response().Writer = recorder.ResponseWriter
+
+			if err != nil || c.Response().Status >= http.StatusInternalServerError {
+				cache.abort(key)
+				return err
+			}
+			cache.finish(key, c.Response().Status, c.Response().Header().Clone(), recorder.body.Bytes(), time.Now())
+			return nil
+		}
+	})
+}
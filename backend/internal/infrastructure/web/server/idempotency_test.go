@@ -0,0 +1,85 @@
+package server
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func requestWithKey(t *testing.T, ts *httptest.Server, token, method, path, ifMatch, key, body string) (*http.Response, string) {
+	req, _ := http.NewRequest(method, ts.URL+path, strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Idempotency-Key", key)
+	if ifMatch != "" {
+		req.Header.Set("If-Match", ifMatch)
+	}
+	res, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer res.Body.Close()
+	data, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	return res, string(data)
+}
+
+// TestIdempotency checks a retried request is not executed twice, and gets the response of the first one
+func TestIdempotency(t *testing.T) {
+	ts, token := newMockServer(t)
+
+	first, firstBody := requestWithKey(t, ts, token, http.MethodPost, "/api/v1/invoices", "", "create-1", invoiceBody)
+	require.Equal(t, http.StatusOK, first.StatusCode)
+	assert.Empty(t, first.Header.Get("Idempotent-Replayed"))
+
+	retry, retryBody := requestWithKey(t, ts, token, http.MethodPost, "/api/v1/invoices", "", "create-1", invoiceBody)
+	assert.Equal(t, http.StatusOK, retry.StatusCode)
+	assert.Equal(t, "true", retry.Header.Get("Idempotent-Replayed"))
+	assert.Equal(t, firstBody, retryBody)
+	assert.Len(t, listInvoices(t, ts, token), 61)
+
+	// The same key can't be used for another request
+	other, _ := requestWithKey(t, ts, token, http.MethodPost, "/api/v1/invoices", "", "create-1", strings.Replace(invoiceBody, "10000", "20000", 1))
+	assert.Equal(t, http.StatusUnprocessableEntity, other.StatusCode)
+
+	// A retried update gets its response, not a version conflict
+	path := "/api/v1/invoices/" + strconv.FormatInt(listInvoices(t, ts, token)[0].ID, 10)
+	update, _ := requestWithKey(t, ts, token, http.MethodPut, path, `"1"`, "update-1", invoiceBody)
+	require.Equal(t, http.StatusOK, update.StatusCode)
+	retry, _ = requestWithKey(t, ts, token, http.MethodPut, path, `"1"`, "update-1", invoiceBody)
+	assert.Equal(t, http.StatusOK, retry.StatusCode)
+	assert.Equal(t, `"2"`, retry.Header.Get("ETag"))
+
+	// With another key, it is a new request
+	conflict, _ := requestWithKey(t, ts, token, http.MethodPut, path, `"1"`, "update-2", invoiceBody)
+	assert.Equal(t, http.StatusPreconditionFailed, conflict.StatusCode)
+}
+
+func TestIdempotencyCache_Expiry(t *testing.T) {
+	cache := newIdempotencyCache(time.Minute)
+	now := time.Now()
+	fingerprint := [32]byte{1}
+
+	_, started := cache.start("key", fingerprint, now)
+	require.True(t, started)
+	previous, started := cache.start("key", fingerprint, now)
+	assert.False(t, started)
+	assert.False(t, previous.done)
+
+	cache.finish("key", http.StatusOK, http.Header{}, []byte("{}"), now)
+	previous, started = cache.start("key", fingerprint, now.Add(30*time.Second))
+	assert.False(t, started)
+	assert.True(t, previous.done)
+
+	_, started = cache.start("key", fingerprint, now.Add(2*time.Minute))
+	assert.True(t, started)
+
+	cache.abort("key")
+	_, started = cache.start("key", fingerprint, now)
+	assert.True(t, started)
+}
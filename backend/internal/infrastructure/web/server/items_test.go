@@ -0,0 +1,67 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/niko-cb/uct/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const itemsBody = `{"company_id":1,"client_id":1,"issue_date":"2025-05-01T00:00:00Z","due_date":"2025-05-31T00:00:00Z","payment_amount":0,"status":"unprocessed",
+	"items":[{"description":"consulting","quantity":2,"unit_price":5500,"tax_category":"standard"},
+	{"description":"lunch boxes","quantity":10,"unit_price":1080,"tax_category":"reduced"}]}`
+
+// TestInvoiceItems checks the payment amount is derived from the items, and the items are returned with the invoice
+// and its tax subtotals
+func TestInvoiceItems(t *testing.T) {
+	ts, token := newMockServer(t)
+
+	res := request(t, ts, token, http.MethodPost, "/api/v1/invoices", "", itemsBody)
+	require.Equal(t, http.StatusOK, res.StatusCode)
+
+	res = request(t, ts, token, http.MethodGet, "/api/v1/invoices?from=2025-05-31&to=2025-05-31", "", "")
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	var invoices []*entity.InvoiceDetails
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&invoices))
+	require.Len(t, invoices, 1)
+	invoice := invoices[0]
+	assert.Equal(t, "21800.00", invoice.PaymentAmount.String())
+	require.Len(t, invoice.Items, 2)
+	assert.Equal(t, "10800.00", invoice.Items[1].Amount.String())
+	assert.Equal(t, entity.TaxCategoryReduced, invoice.Items[1].TaxCategory)
+
+	// The tax included in the items by rate, and the tax of the fee (4% of 21800.00)
+	require.Len(t, invoice.TaxSubtotals, 2)
+	assert.Equal(t, []string{"standard", "11000.00", "1000.00"},
+		[]string{invoice.TaxSubtotals[0].TaxCategory, invoice.TaxSubtotals[0].Amount.String(), invoice.TaxSubtotals[0].TaxAmount.String()})
+	assert.Equal(t, []string{"reduced", "10800.00", "800.00"},
+		[]string{invoice.TaxSubtotals[1].TaxCategory, invoice.TaxSubtotals[1].Amount.String(), invoice.TaxSubtotals[1].TaxAmount.String()})
+	assert.Equal(t, "872.00", invoice.FeeAmount.String())
+	assert.Equal(t, "87.00", invoice.TaxAmount.String())
+	assert.Equal(t, "22759.00", invoice.TotalAmount.String())
+
+	path := "/api/v1/invoices/" + strconv.FormatInt(invoice.ID, 10)
+	res = request(t, ts, token, http.MethodPut, path, `"1"`, strings.Replace(itemsBody, `"quantity":10`, `"quantity":5`, 1))
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	res = request(t, ts, token, http.MethodGet, path, "", "")
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	var updated entity.InvoiceDetails
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&updated))
+	assert.Equal(t, "16400.00", updated.PaymentAmount.String())
+	assert.Equal(t, []int{1, 2}, []int{updated.Items[0].LineNo, updated.Items[1].LineNo})
+
+	for name, body := range map[string]string{
+		"payment amount mismatch": strings.Replace(itemsBody, `"payment_amount":0`, `"payment_amount":20000`, 1),
+		"unknown tax category":    strings.Replace(itemsBody, `"reduced"`, `"zero"`, 1),
+		"no quantity":             strings.Replace(itemsBody, `"quantity":2`, `"quantity":0`, 1),
+		"no description":          strings.Replace(itemsBody, `"consulting"`, `" "`, 1),
+	} {
+		res = request(t, ts, token, http.MethodPost, "/api/v1/invoices", "", body)
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode, name)
+	}
+}
@@ -0,0 +1,51 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/metrics"
+)
+
+// Metrics is a middleware recording the rate, errors and duration of every request, by route
+func (s *server) Metrics() {
+	s.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			done := metrics.RequestStarted()
+			err := next(c)
+
+			route := c.Path()
+			if route == "" {
+				// Don't create a series per unknown URL
+				route = "unmatched"
+			}
+			done(c.Request().Method, route, responseStatus(c, err))
+			return err
+		}
+	})
+}
+
+// responseStatus returns the status code that will be sent for the request.
+// When the handler returns an error, the response is only written later on by Echo's error handler
+func responseStatus(c echo.Context, err error) int {
+	if err == nil || c.Response().Committed {
+		return c.Response().Status
+	}
+	var he *echo.HTTPError
+	if errors.As(err, &he) {
+		return he.Code
+	}
+	return http.StatusInternalServerError
+}
+
+// newMetricsServer creates the server exposing /metrics.
+// It listens on its own port so the metrics are not reachable from the public listener
+func newMetricsServer(port string) *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", metrics.Handler())
+	return &http.Server{
+		Addr:    ":" + port,
+		Handler: mux,
+	}
+}
@@ -0,0 +1,36 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/niko-cb/uct/internal/di"
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/metrics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetrics(t *testing.T) {
+	s := NewServer(&di.App{})
+	s.Router().Add(echo.GET, "/api/v1/metrics-test/:id", func(c echo.Context) error {
+		if c.Param("id") == "fail" {
+			return echo.NewHTTPError(http.StatusServiceUnavailable)
+		}
+		return c.NoContent(http.StatusOK)
+	})
+	s.Metrics()
+
+	for _, id := range []string{"1", "2", "fail"} {
+		s.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/v1/metrics-test/"+id, nil))
+	}
+
+	rec := httptest.NewRecorder()
+	metrics.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body := rec.Body.String()
+
+	// Requests are labelled by the registered route, not the raw URL
+	assert.Contains(t, body, `uct_http_requests_total{method="GET",route="/api/v1/metrics-test/:id",status="200"} 2`)
+	assert.Contains(t, body, `uct_http_requests_total{method="GET",route="/api/v1/metrics-test/:id",status="503"} 1`)
+	assert.Contains(t, body, `uct_http_errors_total{method="GET",route="/api/v1/metrics-test/:id"} 1`)
+}
@@ -0,0 +1,136 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/labstack/echo/v4"
+	"github.com/niko-cb/uct/internal/domain/entity"
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/log"
+	"github.com/niko-cb/uct/internal/infrastructure/web/router"
+)
+
+const (
+	// OpenAPIPath is where the specification of each version is served, e.g. /api/v1/openapi.json
+	OpenAPIPath = "openapi.json"
+)
+
+// openAPI generates the specification of a version and serves it without authentication,
+// so API consumers and code generators can fetch it
+func (s *server) openAPI(version *router.Version) *openapi3.T {
+	basePath := fmt.Sprintf("/%s/%s", BasePath, version.Version)
+	spec, err := version.OpenAPI(basePath)
+	if err != nil {
+		log.Fatal(context.Background(), fmt.Errorf("invalid openapi specification for %s: %+v", version.Version, err))
+	}
+
+	s.Router().Add(echo.GET, fmt.Sprintf("%s/%s", basePath, OpenAPIPath), func(c echo.Context) error {
+		return c.JSON(http.StatusOK, spec)
+	})
+	return spec
+}
+
+// isOpenAPI reports whether the request is for a specification
+func isOpenAPI(c echo.Context) bool {
+	return strings.HasPrefix(c.Path(), "/"+BasePath+"/") && strings.HasSuffix(c.Path(), "/"+OpenAPIPath)
+}
+
+// Validation is a middleware rejecting the requests which don't match the OpenAPI specification
+// (parameters and body) with a 400, before they reach the handlers
+func (s *server) Validation() {
+	s.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			route, ok := s.routes[routeKey(c.Request().Method, c.Path())]
+			if !ok {
+				return next(c)
+			}
+
+			err := openapi3filter.ValidateRequest(c.Request().Context(), &openapi3filter.RequestValidationInput{
+				Request:    c.Request(),
+				PathParams: pathParams(c),
+				Route:      route,
+				Options: &openapi3filter.Options{
+					// The JWT is checked by the Auth middleware
+					AuthenticationFunc: openapi3filter.NoopAuthenticationFunc,
+					// The uploads and the documents are streamed to the handlers, which check them, rather than read whole here
+					ExcludeRequestBody: isUpload(route.Operation),
+				},
+			})
+			if limit, ok := isBodyTooLarge(err); ok {
+				return bodyTooLarge(c, limit)
+			}
+			if err != nil {
+				return c.JSON(http.StatusBadRequest, map[string]string{"error": validationError(err)})
+			}
+			return next(c)
+		}
+	})
+}
+
+// isUpload reports whether an operation takes a multipart/form-data form or an XML document
+func isUpload(operation *openapi3.Operation) bool {
+	if operation.RequestBody == nil {
+		return false
+	}
+	content := operation.RequestBody.Value.Content
+	return content.Get("multipart/form-data") != nil || content.Get(entity.AttachmentTypeXML) != nil
+}
+
+// newRoute returns the route of an operation of the specification, for the validation
+func newRoute(spec *openapi3.T, path, method string) *routers.Route {
+	item := spec.Paths.Value(path)
+	return &routers.Route{
+		Spec:      spec,
+		Server:    spec.Servers[0],
+		Path:      path,
+		PathItem:  item,
+		Method:    method,
+		Operation: item.GetOperation(method),
+	}
+}
+
+// routeKey identifies a route by its method and its echo path (e.g. GET /api/v1/invoices/:id)
+func routeKey(method, path string) string {
+	return method + " " + path
+}
+
+func pathParams(c echo.Context) map[string]string {
+	params := make(map[string]string, len(c.ParamNames()))
+	for i, name := range c.ParamNames() {
+		params[name] = c.ParamValues()[i]
+	}
+	return params
+}
+
+// validationError returns a short description of a validation error, without the schema
+func validationError(err error) string {
+	var requestErr *openapi3filter.RequestError
+	if !errors.As(err, &requestErr) {
+		return err.Error()
+	}
+
+	var schemaErr *openapi3.SchemaError
+	var where string
+	switch {
+	case requestErr.Parameter != nil:
+		where = fmt.Sprintf("parameter %s", requestErr.Parameter.Name)
+	case requestErr.RequestBody != nil:
+		where = "request body"
+	}
+	if errors.As(err, &schemaErr) {
+		if field := strings.Join(schemaErr.JSONPointer(), "."); field != "" {
+			where += " " + field
+		}
+		return fmt.Sprintf("invalid %s: %s", where, schemaErr.Reason)
+	}
+	if requestErr.Err != nil {
+		return fmt.Sprintf("invalid %s: %v", where, requestErr.Err)
+	}
+	return fmt.Sprintf("invalid %s: %s", where, requestErr.Reason)
+}
@@ -0,0 +1,268 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers/legacy"
+	"github.com/niko-cb/uct/internal/domain/entity"
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const creditNoteBody = `{"issue_date":"2024-06-10T00:00:00Z","reason":"overbilled","payment_amount":100}`
+
+const paymentBody = `{"payment_date":"2024-06-15T00:00:00Z","amount":100,"reference":"TRX-1"}`
+
+const recurringBody = `{"company_id":1,"client_id":1,"frequency":"monthly","day_of_month":31,"start_date":"2024-01-01T00:00:00Z","payment_amount":110000,"due_days":30}`
+
+// formBoundary separates the parts of the forms of the uploads, sent as such by request
+const formBoundary = "uct-form-boundary"
+
+// formBody returns a multipart/form-data form with a file in the field field
+func formBody(field, fileName, contentType, content string) string {
+	return "--" + formBoundary + "\r\n" +
+		`Content-Disposition: form-data; name="` + field + `"; filename="` + fileName + "\"\r\n" +
+		"Content-Type: " + contentType + "\r\n\r\n" +
+		content + "\r\n--" + formBoundary + "--\r\n"
+}
+
+// pdfContent is the start of a PDF document, enough to be recognized as one
+const pdfContent = "%PDF-1.7\n1 0 obj\n<< /Type /Catalog >>\nendobj\n"
+
+// installmentsBody is invoiceBody split in two installments, of its total amount of 10440.00
+const installmentsBody = `{"company_id":1,"client_id":1,"issue_date":"2024-05-01T00:00:00Z","due_date":"2024-05-31T00:00:00Z","payment_amount":10000,"status":"unprocessed",` +
+	`"installments":[{"due_date":"2024-05-15T00:00:00Z","amount":5000},{"due_date":"2024-05-31T00:00:00Z","amount":5440}]}`
+
+// ublDocument returns the sample UBL invoice, for the company 1 of the seed
+func ublDocument(t *testing.T) string {
+	data, err := os.ReadFile(filepath.Join("..", "..", "..", "domain", "ubl", "testdata", "invoice.xml"))
+	require.NoError(t, err)
+	return string(data)
+}
+
+// importUBL imports a UBL invoice for the company 1, and returns the id of the invoice
+func importUBL(t *testing.T, ts *httptest.Server, token, document string) string {
+	res := request(t, ts, token, http.MethodPost, "/api/v1/invoices/ubl?company_id=1", "", document)
+	require.Equal(t, http.StatusCreated, res.StatusCode)
+	var invoice models.Invoice
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&invoice))
+	return strconv.FormatInt(invoice.ID, 10)
+}
+
+const invoiceBody = `{"company_id":1,"client_id":1,"issue_date":"2024-05-01T00:00:00Z","due_date":"2024-05-31T00:00:00Z","payment_amount":10000,"status":"unprocessed"}`
+
+// TestOpenAPI_Served checks the specification is public and valid
+func TestOpenAPI_Served(t *testing.T) {
+	ts, _ := newMockServer(t)
+
+	res, err := http.Get(ts.URL + "/api/v1/" + OpenAPIPath)
+	require.NoError(t, err)
+	defer res.Body.Close()
+	require.Equal(t, http.StatusOK, res.StatusCode)
+
+	body, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	spec, err := openapi3.NewLoader().LoadFromData(body)
+	require.NoError(t, err)
+	require.NoError(t, spec.Validate(context.Background()))
+	put := spec.Paths.Find("/invoices/{id}").Put
+	require.NotNil(t, put)
+	assert.NotNil(t, put.Parameters.GetByInAndName(openapi3.ParameterInHeader, "Idempotency-Key"))
+	assert.NotNil(t, put.Responses.Status(http.StatusUnprocessableEntity))
+}
+
+// TestValidation checks the requests which don't match the specification are rejected before the handlers
+func TestValidation(t *testing.T) {
+	ts, token := newMockServer(t)
+
+	tests := []struct {
+		name, method, path, body, err string
+	}{
+		{"missing query parameter", http.MethodGet, "/api/v1/invoices?from=2024-01-01", "", "invalid parameter to"},
+		{"invalid date", http.MethodGet, "/api/v1/invoices?from=2024-01-01&to=tomorrow", "", "invalid parameter to"},
+		{"unknown list date", http.MethodGet, "/api/v1/invoices?from=2024-01-01&to=2024-01-31&by=issue_date", "", "invalid parameter by"},
+		{"invalid id", http.MethodGet, "/api/v1/invoices/abc", "", "invalid parameter id"},
+		{"invalid field type", http.MethodPost, "/api/v1/invoices", strings.Replace(invoiceBody, "10000", `"10000"`, 1), "invalid request body payment_amount"},
+		{"unknown field", http.MethodPost, "/api/v1/invoices", strings.Replace(invoiceBody, "{", `{"amount":1,`, 1), "invalid request body"},
+		{"missing body", http.MethodPost, "/api/v1/invoices", "", "invalid request body"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := request(t, ts, token, tt.method, tt.path, "", tt.body)
+			assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+
+			var body map[string]string
+			require.NoError(t, json.NewDecoder(res.Body).Decode(&body))
+			assert.Contains(t, body["error"], tt.err)
+		})
+	}
+}
+
+// TestResponsesMatchSpec calls every operation and fails if a response is not documented by the specification,
+// so a handler can't drift from it. Every new operation must be added here
+func TestResponsesMatchSpec(t *testing.T) {
+	ts, token := newMockServer(t)
+
+	res, err := http.Get(ts.URL + "/api/v1/" + OpenAPIPath)
+	require.NoError(t, err)
+	defer res.Body.Close()
+	data, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	spec, err := openapi3.NewLoader().LoadFromData(data)
+	require.NoError(t, err)
+	spec.Servers = openapi3.Servers{{URL: ts.URL + "/api/v1"}}
+	specRouter, err := legacy.NewRouter(spec)
+	require.NoError(t, err)
+
+	for _, contentType := range entity.AttachmentTypes {
+		openapi3filter.RegisterBodyDecoder(contentType, openapi3filter.FileBodyDecoder)
+	}
+
+	invoices := listInvoices(t, ts, token)
+	id := strconv.FormatInt(invoices[0].ID, 10)
+	credited := "/api/v1/invoices/" + strconv.FormatInt(invoices[1].ID, 10) + "/credit-notes"
+	paid := "/api/v1/invoices/" + strconv.FormatInt(invoices[2].ID, 10) + "/payments"
+	attached := "/api/v1/invoices/" + strconv.FormatInt(invoices[3].ID, 10) + "/attachments"
+	pdf := formBody("file", "supplier.pdf", "application/pdf", pdfContent)
+	ublInvoice := ublDocument(t)
+	// The imported document is the attachment 1, the PDF attached below the attachment 2
+	imported := "/api/v1/invoices/" + importUBL(t, ts, token, ublInvoice) + "/ubl"
+	tests := []struct {
+		method, path, token, ifMatch, body string
+		status                             int
+	}{
+		{http.MethodGet, "/api/v1/invoices?from=2024-01-01&to=2024-12-31", token, "", "", http.StatusOK},
+		{http.MethodGet, "/api/v1/invoices?from=2024-01-01&to=2024-12-31", "", "", "", http.StatusUnauthorized},
+		{http.MethodPost, "/api/v1/invoices", token, "", invoiceBody, http.StatusOK},
+		{http.MethodPost, "/api/v1/invoices", token, "", strings.Replace(invoiceBody, `"company_id":1`, `"company_id":0`, 1), http.StatusInternalServerError},
+		{http.MethodPost, "/api/v1/invoices", token, "", strings.Replace(invoiceBody, `"company_id":1`, `"company_id":999999`, 1), http.StatusNotFound},
+		{http.MethodPost, "/api/v1/invoices", token, "", strings.Replace(invoiceBody, `"company_id":1`, `"company_id":1,"currency":"USD"`, 1), http.StatusUnprocessableEntity},
+		{http.MethodPost, "/api/v1/invoices", token, "", itemsBody, http.StatusOK},
+		{http.MethodPost, "/api/v1/invoices", token, "", strings.Replace(itemsBody, `"payment_amount":0`, `"payment_amount":1`, 1), http.StatusBadRequest},
+		{http.MethodPost, "/api/v1/invoices", token, "", installmentsBody, http.StatusOK},
+		{http.MethodPost, "/api/v1/invoices", token, "", strings.Replace(installmentsBody, `"amount":5440`, `"amount":5000`, 1), http.StatusBadRequest},
+		{http.MethodGet, "/api/v1/invoices?from=2024-05-15&to=2024-05-15&by=installment_due_date", token, "", "", http.StatusOK},
+		{http.MethodGet, "/api/v1/invoices/" + id, token, "", "", http.StatusOK},
+		{http.MethodGet, "/api/v1/invoices?from=2025-05-31&to=2025-05-31", token, "", "", http.StatusOK},
+		{http.MethodGet, "/api/v1/invoices/999999", token, "", "", http.StatusNotFound},
+		{http.MethodPut, "/api/v1/invoices/" + id, token, "", invoiceBody, http.StatusPreconditionRequired},
+		{http.MethodPut, "/api/v1/invoices/" + id, token, `"1"`, strings.Replace(invoiceBody, `"company_id":1`, `"company_id":1,"currency":"USD"`, 1), http.StatusUnprocessableEntity},
+		{http.MethodPut, "/api/v1/invoices/" + id, token, `"1"`, invoiceBody, http.StatusOK},
+		{http.MethodPut, "/api/v1/invoices/" + id, token, `"1"`, invoiceBody, http.StatusPreconditionFailed},
+		{http.MethodDelete, "/api/v1/invoices/" + id, token, `"2"`, "", http.StatusNoContent},
+		{http.MethodDelete, "/api/v1/invoices/" + id, token, `"2"`, "", http.StatusNotFound},
+		{http.MethodPost, credited, token, "", creditNoteBody, http.StatusOK},
+		{http.MethodPost, credited, token, "", strings.Replace(creditNoteBody, `"payment_amount":100`, `"payment_amount":0`, 1), http.StatusBadRequest},
+		{http.MethodPost, credited, token, "", strings.Replace(creditNoteBody, `"payment_amount":100`, `"payment_amount":100000000`, 1), http.StatusUnprocessableEntity},
+		{http.MethodPost, "/api/v1/invoices/999999/credit-notes", token, "", creditNoteBody, http.StatusNotFound},
+		{http.MethodGet, credited, token, "", "", http.StatusOK},
+		{http.MethodGet, "/api/v1/invoices/999999/credit-notes", token, "", "", http.StatusNotFound},
+		{http.MethodGet, "/api/v1/credit-notes?from=2024-01-01&to=2024-12-31&limit=1", token, "", "", http.StatusOK},
+		{http.MethodGet, "/api/v1/credit-notes/1", token, "", "", http.StatusOK},
+		{http.MethodGet, "/api/v1/credit-notes/999999", token, "", "", http.StatusNotFound},
+		{http.MethodPatch, "/api/v1/credit-notes/1", token, "", `{"status":"void"}`, http.StatusPreconditionRequired},
+		{http.MethodPatch, "/api/v1/credit-notes/1", token, `"1"`, `{"status":"cancelled"}`, http.StatusBadRequest},
+		{http.MethodPatch, "/api/v1/credit-notes/1", token, `"1"`, `{"status":"void"}`, http.StatusOK},
+		{http.MethodPatch, "/api/v1/credit-notes/1", token, `"1"`, `{"status":"void"}`, http.StatusPreconditionFailed},
+		{http.MethodPatch, "/api/v1/credit-notes/1", token, "*", `{"status":"applied"}`, http.StatusUnprocessableEntity},
+		{http.MethodPatch, "/api/v1/credit-notes/999999", token, "*", `{"status":"applied"}`, http.StatusNotFound},
+		{http.MethodPost, paid, token, "", paymentBody, http.StatusOK},
+		{http.MethodPost, paid, token, "", strings.Replace(paymentBody, `"amount":100`, `"amount":0`, 1), http.StatusBadRequest},
+		{http.MethodPost, paid, token, "", strings.Replace(paymentBody, `"amount":100`, `"amount":100000000`, 1), http.StatusUnprocessableEntity},
+		{http.MethodPost, "/api/v1/invoices/999999/payments", token, "", paymentBody, http.StatusNotFound},
+		{http.MethodGet, paid, token, "", "", http.StatusOK},
+		{http.MethodGet, "/api/v1/invoices/999999/payments", token, "", "", http.StatusNotFound},
+		{http.MethodPost, attached, token, "", pdf, http.StatusCreated},
+		{http.MethodPost, attached, token, "", pdf, http.StatusOK},
+		{http.MethodPost, attached, token, "", formBody("document", "supplier.pdf", "application/pdf", pdfContent), http.StatusBadRequest},
+		{http.MethodPost, attached, token, "", invoiceBody, http.StatusBadRequest},
+		{http.MethodPost, attached, token, "", formBody("file", "notes.txt", "text/plain", "paid in cash"), http.StatusUnsupportedMediaType},
+		{http.MethodPost, attached, token, "", formBody("file", "scan.png", "image/png", pdfContent), http.StatusUnsupportedMediaType},
+		{http.MethodPost, attached, token, "", formBody("file", "large.pdf", "application/pdf", pdfContent+strings.Repeat(" ", 8<<10)), http.StatusRequestEntityTooLarge},
+		{http.MethodPost, "/api/v1/invoices/999999/attachments", token, "", pdf, http.StatusNotFound},
+		{http.MethodGet, attached, token, "", "", http.StatusOK},
+		{http.MethodGet, "/api/v1/invoices/999999/attachments", token, "", "", http.StatusNotFound},
+		{http.MethodGet, attached + "/2", token, "", "", http.StatusOK},
+		{http.MethodGet, attached + "/0", token, "", "", http.StatusBadRequest},
+		{http.MethodGet, "/api/v1/invoices/999999/attachments/1", token, "", "", http.StatusNotFound},
+		{http.MethodPost, "/api/v1/invoices/ubl?company_id=1", token, "", ublInvoice, http.StatusCreated},
+		{http.MethodPost, "/api/v1/invoices/ubl?company_id=999999", token, "", ublInvoice, http.StatusNotFound},
+		{http.MethodPost, "/api/v1/invoices/ubl?company_id=1", token, "", xml.Header + "<html></html>", http.StatusBadRequest},
+		{http.MethodPost, "/api/v1/invoices/ubl?company_id=1", token, "", strings.Replace(ublInvoice, "115077", "115000", -1), http.StatusUnprocessableEntity},
+		{http.MethodPost, "/api/v1/invoices/ubl?company_id=1", token, "", ublInvoice + strings.Repeat(" ", 8<<10), http.StatusRequestEntityTooLarge},
+		{http.MethodGet, imported, token, "", "", http.StatusOK},
+		{http.MethodGet, "/api/v1/invoices/" + strconv.FormatInt(invoices[4].ID, 10) + "/ubl", token, "", "", http.StatusUnprocessableEntity},
+		{http.MethodGet, "/api/v1/invoices/999999/ubl", token, "", "", http.StatusNotFound},
+		{http.MethodPost, "/api/v1/recurring-invoices", token, "", recurringBody, http.StatusOK},
+		{http.MethodPost, "/api/v1/recurring-invoices", token, "", strings.Replace(recurringBody, `"monthly"`, `"yearly"`, 1), http.StatusBadRequest},
+		{http.MethodPost, "/api/v1/recurring-invoices", token, "", strings.Replace(recurringBody, `"company_id":1`, `"company_id":999999`, 1), http.StatusNotFound},
+		{http.MethodGet, "/api/v1/recurring-invoices?client_id=1", token, "", "", http.StatusOK},
+		{http.MethodGet, "/api/v1/recurring-invoices?client_id=0", token, "", "", http.StatusBadRequest},
+		{http.MethodGet, "/api/v1/recurring-invoices/1", token, "", "", http.StatusOK},
+		{http.MethodGet, "/api/v1/recurring-invoices/999999", token, "", "", http.StatusNotFound},
+		{http.MethodGet, "/api/v1/recurring-invoices/1/occurrences?count=3", token, "", "", http.StatusOK},
+		{http.MethodGet, "/api/v1/recurring-invoices/1/occurrences?count=0", token, "", "", http.StatusBadRequest},
+		{http.MethodGet, "/api/v1/recurring-invoices/1/occurrences?count=121", token, "", "", http.StatusBadRequest},
+		{http.MethodGet, "/api/v1/recurring-invoices/999999/occurrences", token, "", "", http.StatusNotFound},
+		{http.MethodPatch, "/api/v1/recurring-invoices/1", token, "", `{"status":"paused"}`, http.StatusPreconditionRequired},
+		{http.MethodPatch, "/api/v1/recurring-invoices/1", token, `"1"`, `{"status":"stopped"}`, http.StatusBadRequest},
+		{http.MethodPatch, "/api/v1/recurring-invoices/1", token, `"1"`, `{"status":"paused"}`, http.StatusOK},
+		{http.MethodPatch, "/api/v1/recurring-invoices/1", token, `"1"`, `{"status":"active"}`, http.StatusPreconditionFailed},
+		{http.MethodPatch, "/api/v1/recurring-invoices/999999", token, "*", `{"status":"active"}`, http.StatusNotFound},
+		{http.MethodGet, "/api/v1/reports/aging?as_of=2024-06-30", token, "", "", http.StatusOK},
+		{http.MethodGet, "/api/v1/reports/aging?as_of=2024-06-30", "", "", "", http.StatusUnauthorized},
+		{http.MethodGet, "/api/v1/reports/aging?base_currency=USD", token, "", "", http.StatusOK},
+		{http.MethodGet, "/api/v1/reports/aging?base_currency=XYZ", token, "", "", http.StatusBadRequest},
+		{http.MethodGet, "/api/v1/reports/cash-forecast?from=2024-06-01&to=2024-08-31&granularity=week&project_overdue=true", token, "", "", http.StatusOK},
+		{http.MethodGet, "/api/v1/reports/cash-forecast?compare_balance=true", token, "", "", http.StatusBadRequest},
+		{http.MethodGet, "/api/v1/reports/cash-forecast?company_id=1&base_currency=USD", token, "", "", http.StatusBadRequest},
+		{http.MethodGet, "/api/v1/reports/cash-forecast?company_id=999999&compare_balance=true", token, "", "", http.StatusNotFound},
+		{http.MethodGet, "/api/v1/reports/cash-forecast?company_id=1&compare_balance=true", token, "", "", http.StatusUnprocessableEntity},
+		{http.MethodGet, "/api/v1/reports/unregistered-issuers?from=2024-01-01&to=2024-12-31", token, "", "", http.StatusOK},
+		{http.MethodGet, "/api/v1/reports/unregistered-issuers", "", "", "", http.StatusUnauthorized},
+		{http.MethodGet, "/api/v1/reports/unregistered-issuers?from=2024-12-31&to=2024-01-01", token, "", "", http.StatusBadRequest},
+		{http.MethodGet, "/api/v1/reports/unregistered-issuers?company_id=999999", token, "", "", http.StatusNotFound},
+	}
+
+	covered := map[string]bool{}
+	for _, tt := range tests {
+		res := request(t, ts, tt.token, tt.method, tt.path, tt.ifMatch, tt.body)
+		body, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		require.Equal(t, tt.status, res.StatusCode, "%s %s: %s", tt.method, tt.path, body)
+
+		req, _ := http.NewRequest(tt.method, ts.URL+tt.path, strings.NewReader(tt.body))
+		route, pathParams, err := specRouter.FindRoute(req)
+		require.NoError(t, err)
+		covered[tt.method+" "+route.Path] = true
+
+		err = openapi3filter.ValidateResponse(context.Background(), &openapi3filter.ResponseValidationInput{
+			RequestValidationInput: &openapi3filter.RequestValidationInput{Request: req, PathParams: pathParams, Route: route},
+			Status:                 res.StatusCode,
+			Header:                 res.Header,
+			Body:                   io.NopCloser(bytes.NewReader(body)),
+			Options:                &openapi3filter.Options{IncludeResponseStatus: true},
+		})
+		assert.NoError(t, err, "%s %s", tt.method, tt.path)
+	}
+
+	for path, item := range spec.Paths.Map() {
+		for method := range item.Operations() {
+			assert.True(t, covered[method+" "+path], "%s %s is not tested", method, path)
+		}
+	}
+}
@@ -0,0 +1,24 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/niko-cb/uct/internal/infrastructure/web/handler"
+)
+
+// Preconditions is a middleware requiring an If-Match header on the requests changing a resource,
+// so a client can't overwrite a change it has not seen (428 Precondition Required otherwise)
+func (s *server) Preconditions() {
+	s.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			switch c.Request().Method {
+			case http.MethodPut, http.MethodPatch, http.MethodDelete:
+				if c.Request().Header.Get(handler.HeaderIfMatch) == "" {
+					return c.JSON(http.StatusPreconditionRequired, map[string]string{"error": "If-Match header is required"})
+				}
+			}
+			return next(c)
+		}
+	})
+}
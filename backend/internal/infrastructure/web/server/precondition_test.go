@@ -0,0 +1,78 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func request(t *testing.T, ts *httptest.Server, token, method, path, ifMatch, body string) *http.Response {
+	req, _ := http.NewRequest(method, ts.URL+path, strings.NewReader(body))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	if strings.HasPrefix(body, "--"+formBoundary) {
+		req.Header.Set("Content-Type", "multipart/form-data; boundary="+formBoundary)
+	} else if strings.HasPrefix(body, "<?xml") {
+		req.Header.Set("Content-Type", "application/xml")
+	} else if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if ifMatch != "" {
+		req.Header.Set("If-Match", ifMatch)
+	}
+	res, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { res.Body.Close() })
+	return res
+}
+
+// TestOptimisticConcurrency checks the ETag/If-Match flow of two clients editing the same invoice
+func TestOptimisticConcurrency(t *testing.T) {
+	ts, token := newMockServer(t)
+	invoice := listInvoices(t, ts, token)[0]
+	path := "/api/v1/invoices/" + strconv.FormatInt(invoice.ID, 10)
+	body := `{"company_id":1,"client_id":1,"issue_date":"2024-05-01T00:00:00Z","due_date":"2024-05-31T00:00:00Z","payment_amount":20000,"status":"paid"}`
+
+	res := request(t, ts, token, http.MethodGet, path, "", "")
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	etag := res.Header.Get("ETag")
+	assert.Equal(t, `"1"`, etag)
+
+	// Changes must say which version they apply to
+	res = request(t, ts, token, http.MethodPut, path, "", body)
+	assert.Equal(t, http.StatusPreconditionRequired, res.StatusCode)
+	res = request(t, ts, token, http.MethodDelete, path, "", "")
+	assert.Equal(t, http.StatusPreconditionRequired, res.StatusCode)
+
+	// The first update wins and gets a new ETag
+	res = request(t, ts, token, http.MethodPut, path, etag, body)
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, `"2"`, res.Header.Get("ETag"))
+	var updated models.Invoice
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&updated))
+	assert.Equal(t, "paid", updated.Status)
+	assert.Equal(t, "800.00", updated.FeeAmount.String())
+
+	// The second one still has the old ETag
+	res = request(t, ts, token, http.MethodPut, path, etag, body)
+	assert.Equal(t, http.StatusPreconditionFailed, res.StatusCode)
+	res = request(t, ts, token, http.MethodDelete, path, etag, "")
+	assert.Equal(t, http.StatusPreconditionFailed, res.StatusCode)
+	res = request(t, ts, token, http.MethodDelete, path, `W/"2"`, "")
+	assert.Equal(t, http.StatusPreconditionFailed, res.StatusCode)
+
+	res = request(t, ts, token, http.MethodDelete, path, `"2"`, "")
+	assert.Equal(t, http.StatusNoContent, res.StatusCode)
+	res = request(t, ts, token, http.MethodGet, path, "", "")
+	assert.Equal(t, http.StatusNotFound, res.StatusCode)
+	res = request(t, ts, token, http.MethodDelete, path, "*", "")
+	assert.Equal(t, http.StatusNotFound, res.StatusCode)
+}
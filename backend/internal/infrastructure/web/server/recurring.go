@@ -0,0 +1,49 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/log"
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/metrics"
+)
+
+// generateRecurringInvoices generates the invoices of the recurring invoices due when it starts then every interval,
+// until ctx is done. The returned channel is closed once the generation in progress, if any, has stopped.
+// Every replica may run it, an occurrence is billed once. A stalled generation is alerted on from its metrics,
+// e.g. uct_recurring_last_success_timestamp_seconds, rather than taking the replica out of rotation
+func (s *server) generateRecurringInvoices(ctx context.Context, interval time.Duration) <-chan struct{} {
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			s.generateRecurringInvoicesOnce(ctx, time.Now())
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return done
+}
+
+// generateRecurringInvoicesOnce generates the invoices of the occurrences due on or before the day of now in UTC,
+// the time zone of the dates of the invoices
+func (s *server) generateRecurringInvoicesOnce(ctx context.Context, now time.Time) {
+	now = now.UTC()
+	asOf := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	result, err := s.app.RecurringInvoices.GenerateInvoices(ctx, asOf)
+	metrics.RecurringRun(err == nil, len(result.Generated), result.Failed)
+	if err != nil {
+		log.Error(ctx, fmt.Errorf("failed to generate the recurring invoices: %+v", err))
+		return
+	}
+	if len(result.Generated) > 0 || result.Failed > 0 {
+		log.Info(ctx, fmt.Sprintf("%d recurring invoice(s) generated, %d failed", len(result.Generated), result.Failed))
+	}
+}
@@ -0,0 +1,52 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/niko-cb/uct/internal/application/usecase"
+	"github.com/niko-cb/uct/internal/di"
+	"github.com/niko-cb/uct/internal/infrastructure/monitor/metrics"
+	"github.com/stretchr/testify/assert"
+)
+
+// generator is a RecurringInvoiceUsecase recording the dates it generates the invoices as of
+type generator struct {
+	usecase.RecurringInvoiceUsecase
+	asOf []time.Time
+	err  error
+}
+
+func (g *generator) GenerateInvoices(_ context.Context, asOf time.Time) (usecase.GenerateResult, error) {
+	g.asOf = append(g.asOf, asOf)
+	if g.err != nil {
+		return usecase.GenerateResult{}, g.err
+	}
+	return usecase.GenerateResult{Generated: []int64{1, 2}, Failed: 1}, nil
+}
+
+func TestGenerateRecurringInvoicesOnce(t *testing.T) {
+	recurring := &generator{}
+	s := NewServer(&di.App{RecurringInvoices: recurring})
+
+	// 08:00 in Tokyo is still the day before in UTC
+	tokyo := time.FixedZone("JST", 9*60*60)
+	s.generateRecurringInvoicesOnce(context.Background(), time.Date(2024, 7, 1, 8, 0, 0, 0, tokyo))
+	recurring.err = errors.New("database down")
+	s.generateRecurringInvoicesOnce(context.Background(), time.Date(2024, 7, 1, 10, 0, 0, 0, tokyo))
+	assert.Equal(t, []time.Time{time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC), time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)},
+		recurring.asOf)
+
+	rec := httptest.NewRecorder()
+	metrics.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body := rec.Body.String()
+	assert.Contains(t, body, `uct_recurring_runs_total{result="success"} 1`)
+	assert.Contains(t, body, `uct_recurring_runs_total{result="error"} 1`)
+	assert.Contains(t, body, `uct_recurring_invoices_total{result="generated"} 2`)
+	assert.Contains(t, body, `uct_recurring_invoices_total{result="failed"} 1`)
+	assert.Contains(t, body, "uct_recurring_last_success_timestamp_seconds ")
+}
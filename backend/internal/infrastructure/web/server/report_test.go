@@ -0,0 +1,167 @@
+package server
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/niko-cb/uct/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func getReport(t *testing.T, ts *httptest.Server, token, path string) *http.Response {
+	req, _ := http.NewRequest(http.MethodGet, ts.URL+path, nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	res, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { res.Body.Close() })
+	return res
+}
+
+// TestAgingReport checks the report covers the unpaid invoices of the sample data, as JSON and as CSV
+func TestAgingReport(t *testing.T) {
+	ts, token := newMockServer(t)
+
+	unpaid := 0
+	for _, invoice := range listInvoices(t, ts, token) {
+		if invoice.Status != entity.InvoiceStatusPaid {
+			unpaid++
+		}
+	}
+
+	res := getReport(t, ts, token, "/api/v1/reports/aging?as_of=2024-12-31")
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	var report entity.AgingReport
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&report))
+	assert.Equal(t, "2024-12-31", report.AsOf)
+	assert.Equal(t, int64(unpaid), report.Totals.Total.Count)
+	assert.Equal(t, report.Totals.Total.Count, report.Totals.Over90.Count+report.Totals.Days61To90.Count,
+		"the sample invoices are due by July, so at least 61 days late at the end of the year")
+	var byClient int64
+	for _, client := range report.Clients {
+		byClient += client.Total.Count
+	}
+	assert.Equal(t, report.Totals.Total.Count, byClient)
+
+	res = getReport(t, ts, token, "/api/v1/reports/aging?as_of=2024-12-31&format=csv")
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, "text/csv; charset=utf-8", res.Header.Get("Content-Type"))
+	assert.Contains(t, res.Header.Get("Content-Disposition"), "aging-2024-12-31.csv")
+	records, err := csv.NewReader(res.Body).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, len(report.Clients)+2)
+	assert.Equal(t, []string{"client_id", "client_name", "current count", "current amount"}, records[0][:4])
+	total := records[len(records)-1]
+	assert.Equal(t, "total", total[1])
+	assert.Equal(t, report.Totals.Total.Amount.String(), total[len(total)-1])
+
+	// The sample invoices are in yen, the base currency of every sample company
+	require.NotEmpty(t, report.Clients)
+	assert.Equal(t, "JPY", report.BaseCurrency)
+	require.Len(t, report.Clients[0].Currencies, 1)
+	assert.Equal(t, "JPY", report.Clients[0].Currencies[0].Currency)
+	res = getReport(t, ts, token, "/api/v1/reports/aging?as_of=2024-12-31&base_currency=USD")
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&report))
+	assert.Equal(t, "USD", report.BaseCurrency)
+	assert.Empty(t, report.Clients)
+
+	for _, path := range []string{"/api/v1/reports/aging?as_of=2024-13-01", "/api/v1/reports/aging?base_currency=usd"} {
+		res = getReport(t, ts, token, path)
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode, path)
+	}
+}
+
+// TestCashForecast checks the forecast covers the open invoices of the sample data, as JSON and as CSV
+func TestCashForecast(t *testing.T) {
+	ts, token := newMockServer(t)
+
+	open := 0
+	for _, invoice := range listInvoices(t, ts, token) {
+		if invoice.Status != entity.InvoiceStatusPaid {
+			open++
+		}
+	}
+
+	res := getReport(t, ts, token, "/api/v1/reports/cash-forecast?from=2024-01-01&to=2024-12-31&granularity=month")
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	var forecast entity.CashForecast
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&forecast))
+	assert.Equal(t, "month", forecast.Granularity)
+	require.Len(t, forecast.Periods, 12)
+	assert.Equal(t, "2024-02-01", forecast.Periods[1].Start)
+	assert.Equal(t, "2024-02-29", forecast.Periods[1].End)
+	assert.Equal(t, int64(open), forecast.Totals.Count)
+	assert.Nil(t, forecast.AvailableBalance.Big)
+
+	res = getReport(t, ts, token, "/api/v1/reports/cash-forecast?from=2024-01-01&to=2024-12-31&granularity=month&format=csv")
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Contains(t, res.Header.Get("Content-Disposition"), "cash-forecast-2024-01-01-2024-12-31.csv")
+	records, err := csv.NewReader(res.Body).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 12+2)
+	assert.Equal(t, []string{"start", "end", "count", "payment_amount", "fee_amount", "tax_amount", "total_amount"}, records[0])
+	assert.Equal(t, []string{"total", "", strconv.Itoa(open)}, records[13][:3])
+	assert.Equal(t, forecast.Totals.TotalAmount.String(), records[13][6])
+
+	for path, status := range map[string]int{
+		"/api/v1/reports/cash-forecast?from=2024-12-31&to=2024-01-01":          http.StatusBadRequest,
+		"/api/v1/reports/cash-forecast?from=2024-01-01&to=2030-01-01":          http.StatusBadRequest,
+		"/api/v1/reports/cash-forecast?compare_balance=true":                   http.StatusBadRequest,
+		"/api/v1/reports/cash-forecast?company_id=1&compare_balance=true":      http.StatusUnprocessableEntity,
+		"/api/v1/reports/cash-forecast?company_id=999999&compare_balance=true": http.StatusNotFound,
+		"/api/v1/reports/cash-forecast?company_id=1&project_overdue=true":      http.StatusOK,
+		"/api/v1/reports/cash-forecast?company_id=1&base_currency=USD":         http.StatusBadRequest,
+	} {
+		res = getReport(t, ts, token, path)
+		assert.Equal(t, status, res.StatusCode, path)
+	}
+}
+
+// TestIssuerReport checks every invoice of the sample data is flagged, its clients having no registration number,
+// as JSON and as CSV
+func TestIssuerReport(t *testing.T) {
+	ts, token := newMockServer(t)
+
+	issued := 0
+	for _, invoice := range listInvoices(t, ts, token) {
+		if invoice.IssueDate.Year() == 2024 {
+			issued++
+		}
+	}
+
+	res := getReport(t, ts, token, "/api/v1/reports/unregistered-issuers?from=2024-01-01&to=2024-12-31")
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	var report entity.IssuerReport
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&report))
+	assert.Equal(t, "2024-01-01", report.From)
+	require.Len(t, report.Invoices, issued)
+	require.NotEmpty(t, report.Invoices)
+	assert.Equal(t, entity.IssuerNoRegistrationNumber, report.Invoices[0].Status)
+
+	res = getReport(t, ts, token, "/api/v1/reports/unregistered-issuers?from=2024-01-01&to=2024-12-31&company_id=1&format=csv")
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Contains(t, res.Header.Get("Content-Disposition"), "unregistered-issuers-2024-01-01-2024-12-31.csv")
+	records, err := csv.NewReader(res.Body).ReadAll()
+	require.NoError(t, err)
+	require.Greater(t, len(records), 1)
+	assert.Equal(t, []string{"invoice_id", "company_id", "client_id", "client_name", "registration_number", "issue_date",
+		"currency", "tax_amount", "total_amount", "status"}, records[0])
+	for _, record := range records[1:] {
+		assert.Equal(t, "1", record[1])
+	}
+
+	for path, status := range map[string]int{
+		"/api/v1/reports/unregistered-issuers?from=2024-12-31&to=2024-01-01": http.StatusBadRequest,
+		"/api/v1/reports/unregistered-issuers?from=2020-01-01&to=2024-01-01": http.StatusBadRequest,
+		"/api/v1/reports/unregistered-issuers?company_id=abc":                http.StatusBadRequest,
+		"/api/v1/reports/unregistered-issuers?company_id=999999":             http.StatusNotFound,
+	} {
+		res = getReport(t, ts, token, path)
+		assert.Equal(t, status, res.StatusCode, path)
+	}
+}
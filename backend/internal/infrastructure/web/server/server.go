@@ -2,64 +2,134 @@ package server
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"os"
 	"os/signal"
+	"syscall"
 	"time"
 
+	"github.com/getkin/kin-openapi/routers"
 	"github.com/labstack/echo/v4"
+	"github.com/niko-cb/uct/internal/di"
 	"github.com/niko-cb/uct/internal/infrastructure/monitor/log"
-	"github.com/niko-cb/uct/internal/infrastructure/web/config"
 )
 
 type server struct {
 	*echo.Echo
+	app           *di.App
+	metricsServer *http.Server
+	// routes are the operations of the OpenAPI specifications, by method and path
+	routes map[string]*routers.Route
 }
 
-func NewServer() *server {
+func NewServer(app *di.App) *server {
 	e := echo.New()
-	var a []echo.MiddlewareFunc
-	e.GET("/", nil, a...)
 	return &server{
 		Echo: e,
+		app:  app,
 	}
 }
 
-// Run starts the server with graceful shutdown in mind
+// Handler returns the API with its middlewares without listening, e.g. to serve it with httptest
+func Handler(app *di.App) http.Handler {
+	s := NewServer(app)
+	s.setup()
+	return s
+}
+
+// Run starts the server with graceful shutdown in mind.
+// It returns once the server has stopped and in-flight requests are done
 func (s *server) Run() {
-	cfg := s.getServerConfig()
-	s.routing()
-	s.CORS()
-	s.Auth(cfg.JwtSecret)
+	cfg := s.app.Config
+
+	if cfg.MigrateOnStart && s.app.Migrator != nil {
+		s.migrate()
+	}
 
-	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	s.setup()
+
+	// Orchestrators stop containers with SIGTERM, SIGINT is kept for local use (Ctrl+C)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer stop()
 
 	go func() {
-		if err := s.Start(fmt.Sprintf(":%s", cfg.Port)); err != nil {
+		if err := s.Start(fmt.Sprintf(":%s", cfg.Port)); err != nil && !errors.Is(err, http.ErrServerClosed) {
 			log.Fatal(ctx, fmt.Errorf("server start error: %+v", err))
 		}
 	}()
+
+	s.metricsServer = newMetricsServer(cfg.MetricsPort)
+	go func() {
+		if err := s.metricsServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatal(ctx, fmt.Errorf("metrics server start error: %+v", err))
+		}
+	}()
+
+	var generated <-chan struct{}
+	if cfg.RecurringInterval > 0 {
+		generated = s.generateRecurringInvoices(ctx, cfg.RecurringInterval)
+	}
 	<-ctx.Done()
+	stop()
 
-	s.GracefulShutdown(ctx)
+	s.GracefulShutdown()
+	// The generation in progress was cancelled with ctx, its transaction rolled back
+	if generated != nil {
+		<-generated
+	}
+}
+
+// setup registers the routes and the middlewares
+func (s *server) setup() {
+	cfg := s.app.Config
+
+	s.probes()
+	s.routing()
+	s.Metrics()
+	s.Tracing(cfg.ServiceName)
+	s.CORS()
+	s.Auth(cfg.JwtSecret)
+	s.Preconditions()
+	s.BodyLimit(cfg.BodyMaxSize, cfg.AttachmentMaxSize+uploadBodyHeadroom)
+	s.Validation()
+	s.Idempotency(cfg.IdempotencyTTL)
 }
 
-// GracefulShutdown handles the graceful shutdown process
-func (s *server) GracefulShutdown(ctx context.Context) {
-	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+// GracefulShutdown handles the graceful shutdown process:
+// the readiness probe fails first so load balancers drain the traffic,
+// then the in-flight requests are given time to complete
+func (s *server) GracefulShutdown() {
+	cfg := s.app.Config
+	ctx := context.Background()
+
+	s.app.Checker.Drain()
+	log.Info(ctx, fmt.Sprintf("shutting down, draining traffic for %s", cfg.ShutdownDrainDelay))
+	time.Sleep(cfg.ShutdownDrainDelay)
+
+	ctx, cancel := context.WithTimeout(ctx, cfg.ShutdownTimeout)
 	defer cancel()
 
 	if err := s.Shutdown(ctx); err != nil {
 		log.Fatal(ctx, fmt.Errorf("server shutdown error: %+v", err))
 	}
+
+	// The metrics server is stopped last so the final requests are still scraped
+	if s.metricsServer != nil {
+		if err := s.metricsServer.Shutdown(ctx); err != nil {
+			log.Error(ctx, fmt.Errorf("metrics server shutdown error: %+v", err))
+		}
+	}
 }
 
-func (s *server) getServerConfig() *config.Config {
-	err := config.Parse()
+// migrate applies the pending migrations before the server starts accepting requests.
+// Concurrent replicas wait for the migration lock, so only one of them migrates
+func (s *server) migrate() {
+	ctx := context.Background()
+	applied, err := s.app.Migrator.Up(ctx)
 	if err != nil {
-		log.Fatal(context.Background(), err)
-
+		log.Fatal(ctx, fmt.Errorf("migration error: %+v", err))
 	}
-	return &config.Cfg
+	log.Info(ctx, fmt.Sprintf("%d migration(s) applied", len(applied)))
 }
@@ -0,0 +1,98 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/niko-cb/uct/internal/di"
+	"github.com/niko-cb/uct/internal/domain/entity/models"
+	"github.com/niko-cb/uct/internal/infrastructure/web/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newMockServer starts the full stack on the seeded memory store, like serve --mock
+func newMockServer(t *testing.T) (*httptest.Server, string) {
+	cfg := &config.Config{
+		DbDriver:         config.DriverMemory,
+		MemorySeed:       1,
+		JwtSecret:        "secret",
+		ReadinessTimeout: time.Second,
+		IdempotencyTTL:   time.Hour,
+		BodyMaxSize:      4 << 10,
+		// Small enough to test the limit, large enough for the sample UBL invoice
+		AttachmentMaxSize: 8 << 10,
+	}
+	app, cleanup, err := di.NewApp(context.Background(), cfg)
+	require.NoError(t, err)
+	t.Cleanup(cleanup)
+
+	s := NewServer(app)
+	s.setup()
+	ts := httptest.NewServer(s)
+	t.Cleanup(ts.Close)
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, &jwt.StandardClaims{
+		ExpiresAt: time.Now().Add(time.Hour).Unix(),
+		Subject:   "1",
+	}).SignedString([]byte(cfg.JwtSecret))
+	require.NoError(t, err)
+
+	return ts, token
+}
+
+func listInvoices(t *testing.T, ts *httptest.Server, token string) []*models.Invoice {
+	req, _ := http.NewRequest(http.MethodGet, ts.URL+"/api/v1/invoices?from=2024-01-01&to=2024-12-31", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	res, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer res.Body.Close()
+	require.Equal(t, http.StatusOK, res.StatusCode)
+
+	var invoices []*models.Invoice
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&invoices))
+	return invoices
+}
+
+// TestMockMode makes sure the API runs without a database on the seeded memory store
+func TestMockMode(t *testing.T) {
+	ts, token := newMockServer(t)
+
+	res, err := http.Get(ts.URL + ReadinessPath)
+	require.NoError(t, err)
+	res.Body.Close()
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+
+	invoices := listInvoices(t, ts, token)
+	require.Len(t, invoices, 60)
+
+	body := `{"company_id":1,"client_id":1,"issue_date":"2024-05-01T00:00:00Z","due_date":"2024-05-31T00:00:00Z","payment_amount":10000,"status":"unprocessed"}`
+	req, _ := http.NewRequest(http.MethodPost, ts.URL+"/api/v1/invoices", strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+	res, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	res.Body.Close()
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+
+	assert.Len(t, listInvoices(t, ts, token), 61)
+}
+
+// TestMockMode_Deterministic makes sure every mock server serves the same data
+func TestMockMode_Deterministic(t *testing.T) {
+	ts1, token1 := newMockServer(t)
+	ts2, token2 := newMockServer(t)
+
+	first, err := json.Marshal(listInvoices(t, ts1, token1))
+	require.NoError(t, err)
+	second, err := json.Marshal(listInvoices(t, ts2, token2))
+	require.NoError(t, err)
+
+	assert.JSONEq(t, string(first), string(second))
+}
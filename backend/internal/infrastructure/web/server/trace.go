@@ -0,0 +1,51 @@
+package server
+
+import (
+	"strings"
+
+	sqlcommentercore "github.com/google/sqlcommenter/go/core"
+	"github.com/labstack/echo/v4"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/labstack/echo/otelecho"
+)
+
+// Tracing is a middleware that starts a span for every request (continuing the caller's trace
+// when a W3C traceparent header is present) and stores the route and handler in the request
+// context, so sqlcommenter can attach them to every query issued while serving the request
+func (s *server) Tracing(serviceName string) {
+	s.Use(otelecho.Middleware(serviceName))
+	s.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			ctx := sqlcommentercore.ContextInject(c.Request().Context(), requestTags{c: c})
+			c.SetRequest(c.Request().WithContext(ctx))
+			return next(c)
+		}
+	})
+}
+
+// requestTags implements sqlcommentercore.RequestTagsProvider for Echo
+type requestTags struct {
+	c echo.Context
+}
+
+// Route returns the registered route of the request, e.g. /api/v1/invoices
+func (t requestTags) Route() string {
+	return t.c.Path()
+}
+
+// Action returns the handler (controller) serving the request, e.g. InvoiceHandler.CreateInvoice
+func (t requestTags) Action() string {
+	name := sqlcommentercore.GetFunctionName(t.c.Handler())
+	name = name[strings.LastIndex(name, "/")+1:]
+	name = strings.TrimSuffix(name, "-fm")
+	name = strings.NewReplacer("(*", "", ")", "").Replace(name)
+	// Drop the package name, the type and method are enough to identify the handler
+	if i := strings.Index(name, "."); i >= 0 && strings.Count(name, ".") > 1 {
+		name = name[i+1:]
+	}
+	return name
+}
+
+// Framework returns the web framework name
+func (t requestTags) Framework() string {
+	return "echo:v4"
+}
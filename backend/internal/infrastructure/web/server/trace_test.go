@@ -0,0 +1,34 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/niko-cb/uct/internal/infrastructure/web/handler"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestTags(t *testing.T) {
+	e := echo.New()
+	h := &handler.InvoiceHandler{}
+
+	// Register the route the same way routing() does
+	e.Router().Add(echo.GET, "/api/v1/invoices", h.GetInvoicesByDateRange)
+
+	var tags requestTags
+	e.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			tags = requestTags{c: c}
+			return c.NoContent(http.StatusOK)
+		}
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/invoices?from=2024-01-01", nil)
+	e.ServeHTTP(httptest.NewRecorder(), req)
+
+	assert.Equal(t, "/api/v1/invoices", tags.Route())
+	assert.Equal(t, "InvoiceHandler.GetInvoicesByDateRange", tags.Action())
+	assert.Equal(t, "echo:v4", tags.Framework())
+}
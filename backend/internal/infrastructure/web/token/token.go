@@ -0,0 +1,41 @@
+package token
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Issuer is the issuer of the tokens minted by uct
+const Issuer = "uct"
+
+// Claims are the claims of the API's JWTs. The subject is the id of the user
+type Claims struct {
+	jwt.RegisteredClaims
+	CompanyID int64    `json:"company_id,omitempty"`
+	Roles     []string `json:"roles,omitempty"`
+}
+
+// Mint signs a token for a user of a company, valid for ttl from now
+func Mint(secret string, userID, companyID int64, roles []string, ttl time.Duration, now time.Time) (string, error) {
+	if secret == "" {
+		return "", errors.New("the JWT secret is empty")
+	}
+	if ttl <= 0 {
+		return "", errors.New("the ttl must be positive")
+	}
+
+	claims := &Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    Issuer,
+			Subject:   strconv.FormatInt(userID, 10),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+		},
+		CompanyID: companyID,
+		Roles:     roles,
+	}
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(secret))
+}
@@ -0,0 +1,34 @@
+package token_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/niko-cb/uct/internal/infrastructure/web/token"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMint(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	signed, err := token.Mint("secret", 7, 3, []string{"admin"}, time.Hour, now)
+	require.NoError(t, err)
+
+	claims := &token.Claims{}
+	_, err = jwt.ParseWithClaims(signed, claims, func(*jwt.Token) (interface{}, error) { return []byte("secret"), nil })
+	require.NoError(t, err)
+	assert.Equal(t, "7", claims.Subject)
+	assert.Equal(t, token.Issuer, claims.Issuer)
+	assert.Equal(t, int64(3), claims.CompanyID)
+	assert.Equal(t, []string{"admin"}, claims.Roles)
+	assert.True(t, claims.ExpiresAt.Equal(now.Add(time.Hour)))
+
+	_, err = jwt.ParseWithClaims(signed, &token.Claims{}, func(*jwt.Token) (interface{}, error) { return []byte("other"), nil })
+	assert.Error(t, err)
+
+	_, err = token.Mint("", 7, 3, nil, time.Hour, now)
+	assert.Error(t, err)
+	_, err = token.Mint("secret", 7, 3, nil, 0, now)
+	assert.Error(t, err)
+}
@@ -0,0 +1,75 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+)
+
+// Attachment is a document attached to an invoice, e.g. the PDF of the supplier, as stored by the API.
+// Its content is identified by its SHA-256
+type Attachment struct {
+	ID          int64  `json:"id"`
+	InvoiceID   int64  `json:"invoice_id"`
+	FileName    string `json:"file_name"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+	Sha256      string `json:"sha256"`
+}
+
+// UploadAttachment attaches a PDF, PNG, JPEG or XML document to an invoice, its type being recognized from its
+// content. Uploading a file the invoice already has returns the existing attachment
+func (c *Client) UploadAttachment(ctx context.Context, invoiceID int64, fileName string, content []byte) (*Attachment, error) {
+	var form bytes.Buffer
+	writer := multipart.NewWriter(&form)
+	file, err := writer.CreateFormFile("file", fileName)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := file.Write(content); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	var attachment Attachment
+	_, err = c.do(ctx, request{
+		method:      http.MethodPost,
+		path:        attachmentsPath(invoiceID),
+		body:        form.Bytes(),
+		contentType: writer.FormDataContentType(),
+		out:         &attachment,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &attachment, nil
+}
+
+// ListAttachments returns the attachments of an invoice, without their content
+func (c *Client) ListAttachments(ctx context.Context, invoiceID int64) ([]*Attachment, error) {
+	var attachments []*Attachment
+	if _, err := c.do(ctx, request{method: http.MethodGet, path: attachmentsPath(invoiceID), out: &attachments}); err != nil {
+		return nil, err
+	}
+	return attachments, nil
+}
+
+// DownloadAttachment writes the content of an attachment of an invoice to w, and returns its content type.
+// It returns ErrNotFound if the invoice has no such attachment
+func (c *Client) DownloadAttachment(ctx context.Context, invoiceID int64, id int64, w io.Writer) (string, error) {
+	path := attachmentsPath(invoiceID) + "/" + strconv.FormatInt(id, 10)
+	res, err := c.do(ctx, request{method: http.MethodGet, path: path, download: w})
+	if err != nil {
+		return "", err
+	}
+	return res.Header.Get("Content-Type"), nil
+}
+
+func attachmentsPath(invoiceID int64) string {
+	return "/invoices/" + strconv.FormatInt(invoiceID, 10) + "/attachments"
+}
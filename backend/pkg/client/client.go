@@ -0,0 +1,300 @@
+// Package client is a typed Go client of the uct API.
+//
+//	c, err := client.New("https://uct.example.com", client.WithToken(token))
+//	invoice, err := c.GetInvoice(ctx, 42)
+//
+// Requests are retried on network errors, 409 (a retry in progress), 429 and 5xx. Changes are sent with an
+// Idempotency-Key kept across retries, so the server executes them once even if a response was lost
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	mathrand "math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// APIVersion is the version of the API the client is written for
+	APIVersion = "v1"
+	// apiPath is the path of the API on the server, before the prefix of a proxy in front of it
+	apiPath = "/api/" + APIVersion
+
+	defaultRetries = 3
+	defaultBackoff = 200 * time.Millisecond
+	maxBackoff     = 10 * time.Second
+	// maxErrorBody bounds how much of an error response is read
+	maxErrorBody = 1 << 20
+)
+
+// TokenSource returns the JWT to authenticate a request with. It is called for every attempt,
+// so it may refresh an expired token
+type TokenSource func(ctx context.Context) (string, error)
+
+// Client calls the uct API. It is safe for concurrent use
+type Client struct {
+	baseURL    *url.URL
+	httpClient *http.Client
+	token      TokenSource
+	retries    int
+	backoff    time.Duration
+	newKey     func() string
+}
+
+// Option configures a Client
+type Option func(*Client)
+
+// WithToken authenticates the requests with a fixed JWT
+func WithToken(token string) Option {
+	return func(c *Client) {
+		c.token = func(context.Context) (string, error) { return token, nil }
+	}
+}
+
+// WithTokenSource authenticates the requests with the JWT returned by source
+func WithTokenSource(source TokenSource) Option {
+	return func(c *Client) { c.token = source }
+}
+
+// WithHTTPClient sets the HTTP client used to send the requests (http.DefaultClient by default)
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) { c.httpClient = httpClient }
+}
+
+// WithRetries sets how many times a failed request is retried (3 by default, 0 disables the retries)
+// and the delay before the first retry, doubled for each of the next ones (200ms by default)
+func WithRetries(retries int, backoff time.Duration) Option {
+	return func(c *Client) { c.retries, c.backoff = retries, backoff }
+}
+
+// WithIdempotencyKeys sets the generator of the Idempotency-Key sent with the changes (random by default)
+func WithIdempotencyKeys(newKey func() string) Option {
+	return func(c *Client) { c.newKey = newKey }
+}
+
+// New returns a client of the API served at baseURL (e.g. https://uct.example.com)
+func New(baseURL string, opts ...Option) (*Client, error) {
+	u, err := url.Parse(strings.TrimSuffix(baseURL, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid base url: %w", err)
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("invalid base url %q: scheme and host are required", baseURL)
+	}
+	u.Path += apiPath
+
+	c := &Client{
+		baseURL:    u,
+		httpClient: http.DefaultClient,
+		retries:    defaultRetries,
+		backoff:    defaultBackoff,
+		newKey:     randomKey,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c, nil
+}
+
+// request is an API call
+type request struct {
+	method  string
+	path    string // relative to the version, e.g. /invoices/42
+	query   url.Values
+	header  http.Header
+	in      interface{} // marshalled as the JSON body if not nil
+	out     interface{} // unmarshalled from the JSON body of a successful response if not nil
+	rawPath string      // overrides path and query, e.g. with the URL of a Link header
+
+	// body is sent as is with its contentType instead of in, e.g. a form
+	body        []byte
+	contentType string
+	// download receives the body of a successful response instead of out, e.g. a file
+	download io.Writer
+}
+
+// resolve returns the URL of a link given by the server, e.g. in a Link header. The server doesn't know the prefix
+// of a proxy in front of it, so the paths of its API are taken relative to the base URL, which has the prefix
+func (c *Client) resolve(link string) (*url.URL, error) {
+	ref, err := url.Parse(link)
+	if err != nil {
+		return nil, fmt.Errorf("invalid url %q: %w", link, err)
+	}
+	if path, ok := strings.CutPrefix(ref.Path, apiPath+"/"); ok && !ref.IsAbs() {
+		ref = &url.URL{Path: path, RawQuery: ref.RawQuery}
+		return c.baseURL.JoinPath("/").ResolveReference(ref), nil
+	}
+	return c.baseURL.ResolveReference(ref), nil
+}
+
+// do sends a request, retrying it if needed, and returns the successful response (its body closed)
+func (c *Client) do(ctx context.Context, r request) (*http.Response, error) {
+	body := r.body
+	if r.in != nil {
+		var err error
+		if body, err = json.Marshal(r.in); err != nil {
+			return nil, fmt.Errorf("failed to marshal the request: %w", err)
+		}
+	}
+
+	target := c.baseURL.JoinPath(r.path)
+	target.RawQuery = r.query.Encode()
+	if r.rawPath != "" {
+		var err error
+		if target, err = c.resolve(r.rawPath); err != nil {
+			return nil, err
+		}
+	}
+
+	// The key is the same for every attempt, so the server recognises the retries
+	var idempotencyKey string
+	if r.method != http.MethodGet {
+		idempotencyKey = c.newKey()
+	}
+
+	for attempt := 0; ; attempt++ {
+		res, err := c.send(ctx, r, target.String(), body, idempotencyKey)
+		if err == nil && res.StatusCode < http.StatusBadRequest {
+			if r.download != nil {
+				err = copyBody(res, r.download)
+			} else {
+				err = decode(res, r.out)
+			}
+			return res, err
+		}
+
+		var retryAfter time.Duration
+		if err == nil {
+			retryAfter = parseRetryAfter(res.Header.Get("Retry-After"))
+			err = newError(res)
+		}
+		if attempt >= c.retries || !retryable(err) || ctx.Err() != nil {
+			return nil, err
+		}
+
+		delay := c.delay(attempt)
+		if retryAfter > delay {
+			delay = retryAfter
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+}
+
+// send makes one attempt of a request
+func (c *Client) send(ctx context.Context, r request, target string, body []byte, idempotencyKey string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, r.method, target, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	for name, values := range r.header {
+		req.Header[name] = values
+	}
+	if r.download == nil {
+		req.Header.Set("Accept", "application/json")
+	}
+	if r.contentType != "" {
+		req.Header.Set("Content-Type", r.contentType)
+	} else if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if idempotencyKey != "" {
+		req.Header.Set("Idempotency-Key", idempotencyKey)
+	}
+	if c.token != nil {
+		token, err := c.token(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get a token: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, &transportError{err: err}
+	}
+	return res, nil
+}
+
+// delay returns the exponential backoff before a retry, with jitter so clients don't retry in sync
+func (c *Client) delay(attempt int) time.Duration {
+	d := c.backoff << attempt
+	if d <= 0 || d > maxBackoff {
+		d = maxBackoff
+	}
+	return d/2 + time.Duration(mathrand.Int63n(int64(d/2)+1))
+}
+
+// decode reads a successful response into out, and closes its body
+func decode(res *http.Response, out interface{}) error {
+	defer res.Body.Close()
+	if out == nil || res.StatusCode == http.StatusNoContent {
+		_, _ = io.Copy(io.Discard, res.Body)
+		return nil
+	}
+	if err := json.NewDecoder(res.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode the response: %w", err)
+	}
+	return nil
+}
+
+// copyBody copies the body of a successful response to w, and closes it
+func copyBody(res *http.Response, w io.Writer) error {
+	defer res.Body.Close()
+	if _, err := io.Copy(w, res.Body); err != nil {
+		return fmt.Errorf("failed to read the response: %w", err)
+	}
+	return nil
+}
+
+// transportError is a request which got no response, e.g. a connection reset
+type transportError struct {
+	err error
+}
+
+func (e *transportError) Error() string { return e.err.Error() }
+func (e *transportError) Unwrap() error { return e.err }
+
+// retryable reports whether a request failing with err may succeed if sent again
+func retryable(err error) bool {
+	switch e := err.(type) {
+	case *transportError:
+		return true
+	case *Error:
+		switch e.StatusCode {
+		case http.StatusConflict, http.StatusTooManyRequests, http.StatusInternalServerError,
+			http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return true
+		}
+	}
+	return false
+}
+
+// parseRetryAfter parses a Retry-After header in seconds (0 if absent or given as a date)
+func parseRetryAfter(value string) time.Duration {
+	seconds, err := strconv.Atoi(value)
+	if err != nil || seconds < 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+func randomKey() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(fmt.Sprintf("failed to generate an idempotency key: %v", err))
+	}
+	return hex.EncodeToString(b)
+}
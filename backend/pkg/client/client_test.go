@@ -0,0 +1,414 @@
+package client_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/niko-cb/uct/internal/di"
+	"github.com/niko-cb/uct/internal/infrastructure/web/config"
+	"github.com/niko-cb/uct/internal/infrastructure/web/server"
+	"github.com/niko-cb/uct/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const secret = "secret"
+
+// newServer serves the real API on the seeded memory store
+func newServer(t *testing.T) *httptest.Server {
+	cfg := &config.Config{
+		DbDriver:          config.DriverMemory,
+		MemorySeed:        1,
+		JwtSecret:         secret,
+		ReadinessTimeout:  time.Second,
+		IdempotencyTTL:    time.Hour,
+		BodyMaxSize:       1 << 20,
+		AttachmentMaxSize: 1 << 20,
+	}
+	app, cleanup, err := di.NewApp(context.Background(), cfg)
+	require.NoError(t, err)
+	t.Cleanup(cleanup)
+
+	ts := httptest.NewServer(server.Handler(app))
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func newToken(t *testing.T) string {
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
+		Subject:   "1",
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+	}).SignedString([]byte(secret))
+	require.NoError(t, err)
+	return token
+}
+
+func newClient(t *testing.T, ts *httptest.Server, opts ...client.Option) *client.Client {
+	opts = append([]client.Option{client.WithToken(newToken(t)), client.WithRetries(3, time.Millisecond)}, opts...)
+	c, err := client.New(ts.URL, opts...)
+	require.NoError(t, err)
+	return c
+}
+
+var (
+	from = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to   = time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)
+)
+
+func input(amount float64) *client.InvoiceInput {
+	return &client.InvoiceInput{
+		CompanyID:     1,
+		ClientID:      1,
+		IssueDate:     time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+		DueDate:       time.Date(2024, 5, 31, 0, 0, 0, 0, time.UTC),
+		PaymentAmount: amount,
+		Status:        "unprocessed",
+	}
+}
+
+func countInvoices(t *testing.T, c *client.Client) int {
+	page, err := c.ListInvoices(context.Background(), client.ListInvoicesParams{From: from, To: to})
+	require.NoError(t, err)
+	return len(page.Invoices)
+}
+
+func TestInvoices_Lifecycle(t *testing.T) {
+	ctx := context.Background()
+	c := newClient(t, newServer(t))
+
+	require.NoError(t, c.CreateInvoice(ctx, input(10000)))
+	page, err := c.ListInvoices(ctx, client.ListInvoicesParams{From: from, To: to})
+	require.NoError(t, err)
+	require.Len(t, page.Invoices, 61)
+	assert.False(t, page.HasNext())
+
+	created := page.Invoices[len(page.Invoices)-1]
+	invoice, err := c.GetInvoice(ctx, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, client.Decimal("10440.00"), invoice.TotalAmount)
+	assert.Equal(t, int64(1), invoice.Version)
+
+	updated, err := c.UpdateInvoice(ctx, invoice.ID, invoice.Version, input(20000))
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), updated.Version)
+	fee, err := updated.FeeAmount.Float64()
+	require.NoError(t, err)
+	assert.Equal(t, 800.0, fee)
+
+	// The version read first is outdated
+	_, err = c.UpdateInvoice(ctx, invoice.ID, invoice.Version, input(30000))
+	assert.ErrorIs(t, err, client.ErrVersionConflict)
+	err = c.DeleteInvoice(ctx, invoice.ID, invoice.Version)
+	assert.ErrorIs(t, err, client.ErrVersionConflict)
+
+	require.NoError(t, c.DeleteInvoice(ctx, invoice.ID, client.AnyVersion))
+	_, err = c.GetInvoice(ctx, invoice.ID)
+	assert.ErrorIs(t, err, client.ErrNotFound)
+}
+
+func TestCreditNotes(t *testing.T) {
+	ctx := context.Background()
+	c := newClient(t, newServer(t))
+
+	invoice, err := c.GetInvoice(ctx, 1)
+	require.NoError(t, err)
+	issued := time.Date(2024, 6, 10, 0, 0, 0, 0, time.UTC)
+	creditNote, err := c.CreateCreditNote(ctx, invoice.ID, &client.CreditNoteInput{IssueDate: issued, Reason: "overbilled", PaymentAmount: 1000})
+	require.NoError(t, err)
+	assert.Equal(t, client.Decimal("1000.00"), creditNote.PaymentAmount)
+	assert.Equal(t, client.Decimal("1044.00"), creditNote.TotalAmount)
+	assert.Equal(t, client.CreditNoteStatusIssued, creditNote.Status)
+
+	credited, err := c.GetInvoice(ctx, invoice.ID)
+	require.NoError(t, err)
+	require.Len(t, credited.CreditNotes, 1)
+	total, err := invoice.TotalAmount.Float64()
+	require.NoError(t, err)
+	outstanding, err := credited.OutstandingAmount.Float64()
+	require.NoError(t, err)
+	assert.InDelta(t, total-1044, outstanding, 0.001)
+
+	listed, err := c.ListCreditNotes(ctx, issued, issued)
+	require.NoError(t, err)
+	require.Len(t, listed, 1)
+	assert.Equal(t, creditNote.ID, listed[0].ID)
+
+	voided, err := c.UpdateCreditNoteStatus(ctx, creditNote.ID, creditNote.Version, client.CreditNoteStatusVoid)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), voided.Version)
+	_, err = c.UpdateCreditNoteStatus(ctx, creditNote.ID, client.AnyVersion, client.CreditNoteStatusApplied)
+	var apiErr *client.Error
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusUnprocessableEntity, apiErr.StatusCode)
+	byInvoice, err := c.ListInvoiceCreditNotes(ctx, invoice.ID)
+	require.NoError(t, err)
+	require.Len(t, byInvoice, 1)
+	assert.Equal(t, client.CreditNoteStatusVoid, byInvoice[0].Status)
+}
+
+func TestPayments(t *testing.T) {
+	ctx := context.Background()
+	c := newClient(t, newServer(t))
+
+	invoice, err := c.GetInvoice(ctx, 1)
+	require.NoError(t, err)
+	paid := time.Date(2024, 6, 10, 0, 0, 0, 0, time.UTC)
+	partial, err := c.RecordPayment(ctx, invoice.ID, &client.PaymentInput{PaymentDate: paid, Amount: 1000, Reference: "transfer-1"})
+	require.NoError(t, err)
+	assert.Equal(t, client.InvoiceStatusPartiallyPaid, partial.Status)
+	assert.Equal(t, client.Decimal("1000.00"), partial.PaidAmount)
+	require.Len(t, partial.Payments, 1)
+
+	outstanding, err := partial.OutstandingAmount.Float64()
+	require.NoError(t, err)
+	_, err = c.RecordPayment(ctx, invoice.ID, &client.PaymentInput{PaymentDate: paid, Amount: outstanding + 1})
+	var apiErr *client.Error
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusUnprocessableEntity, apiErr.StatusCode)
+	settled, err := c.RecordPayment(ctx, invoice.ID, &client.PaymentInput{PaymentDate: paid, Amount: outstanding})
+	require.NoError(t, err)
+	assert.Equal(t, client.InvoiceStatusPaid, settled.Status)
+	assert.Equal(t, client.Decimal("0.00"), settled.OutstandingAmount)
+
+	payments, err := c.ListPayments(ctx, invoice.ID)
+	require.NoError(t, err)
+	require.Len(t, payments, 2)
+	assert.Equal(t, "transfer-1", payments[0].Reference)
+}
+
+func TestInvoices_Installments(t *testing.T) {
+	ctx := context.Background()
+	c := newClient(t, newServer(t))
+
+	first := time.Date(2030, 1, 15, 0, 0, 0, 0, time.UTC)
+	due := time.Date(2030, 1, 31, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, c.CreateInvoice(ctx, &client.InvoiceInput{
+		CompanyID: 1, ClientID: 1, IssueDate: time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC), DueDate: due,
+		PaymentAmount: 10000, Status: "unprocessed",
+		Installments: []*client.InstallmentInput{{DueDate: first, Amount: 5000}, {DueDate: due, Amount: 5440}},
+	}))
+
+	page, err := c.ListInvoices(ctx, client.ListInvoicesParams{From: first, To: first, By: client.ListByInstallmentDueDate})
+	require.NoError(t, err)
+	require.Len(t, page.Invoices, 1)
+	require.Len(t, page.Invoices[0].Installments, 2)
+	assert.Equal(t, 2, page.Invoices[0].Installments[1].InstallmentNo)
+	assert.Equal(t, client.Decimal("5440.00"), page.Invoices[0].Installments[1].Amount)
+	page, err = c.ListInvoices(ctx, client.ListInvoicesParams{From: first, To: first})
+	require.NoError(t, err)
+	assert.Empty(t, page.Invoices)
+}
+
+func TestRecurringInvoices(t *testing.T) {
+	ctx := context.Background()
+	c := newClient(t, newServer(t))
+
+	end := time.Date(2030, 4, 30, 0, 0, 0, 0, time.UTC)
+	recurring, err := c.CreateRecurringInvoice(ctx, &client.RecurringInvoiceInput{
+		CompanyID: 1, ClientID: 1, Frequency: client.RecurringFrequencyMonthly, DayOfMonth: 31,
+		StartDate: time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC), EndDate: &end, PaymentAmount: 10000, DueDays: 30,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, recurring.Every)
+	assert.Equal(t, client.RecurringStatusActive, recurring.Status)
+	require.NotNil(t, recurring.NextDate)
+	assert.Equal(t, time.Date(2030, 1, 31, 0, 0, 0, 0, time.UTC), *recurring.NextDate)
+
+	occurrences, err := c.PreviewOccurrences(ctx, recurring.ID, 0)
+	require.NoError(t, err)
+	require.Len(t, occurrences, 4)
+	assert.Equal(t, time.Date(2030, 2, 28, 0, 0, 0, 0, time.UTC), occurrences[1].IssueDate)
+	assert.Equal(t, client.Decimal("10440.00"), occurrences[1].TotalAmount)
+
+	paused, err := c.UpdateRecurringInvoiceStatus(ctx, recurring.ID, recurring.Version, client.RecurringStatusPaused)
+	require.NoError(t, err)
+	assert.Equal(t, client.RecurringStatusPaused, paused.Status)
+	_, err = c.UpdateRecurringInvoiceStatus(ctx, recurring.ID, recurring.Version, client.RecurringStatusActive)
+	assert.ErrorIs(t, err, client.ErrVersionConflict)
+
+	listed, err := c.ListRecurringInvoices(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, listed, 1)
+	assert.Equal(t, paused.Version, listed[0].Version)
+	_, err = c.GetRecurringInvoice(ctx, recurring.ID+1)
+	assert.ErrorIs(t, err, client.ErrNotFound)
+}
+
+func TestAttachments(t *testing.T) {
+	ctx := context.Background()
+	c := newClient(t, newServer(t))
+
+	content := []byte("%PDF-1.7\n1 0 obj\n<< /Type /Catalog >>\nendobj\n")
+	attachment, err := c.UploadAttachment(ctx, 1, "supplier.pdf", content)
+	require.NoError(t, err)
+	assert.Equal(t, "application/pdf", attachment.ContentType)
+	assert.Equal(t, int64(len(content)), attachment.Size)
+	again, err := c.UploadAttachment(ctx, 1, "copy.pdf", content)
+	require.NoError(t, err)
+	assert.Equal(t, attachment.ID, again.ID)
+	_, err = c.UploadAttachment(ctx, 1, "notes.txt", []byte("paid in cash"))
+	var apiErr *client.Error
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusUnsupportedMediaType, apiErr.StatusCode)
+
+	attachments, err := c.ListAttachments(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, attachments, 1)
+	assert.Equal(t, "supplier.pdf", attachments[0].FileName)
+
+	var downloaded bytes.Buffer
+	contentType, err := c.DownloadAttachment(ctx, 1, attachment.ID, &downloaded)
+	require.NoError(t, err)
+	assert.Equal(t, "application/pdf", contentType)
+	assert.Equal(t, content, downloaded.Bytes())
+	_, err = c.DownloadAttachment(ctx, 2, attachment.ID, &downloaded)
+	assert.ErrorIs(t, err, client.ErrNotFound)
+}
+
+func TestUBL(t *testing.T) {
+	ctx := context.Background()
+	c := newClient(t, newServer(t))
+
+	document, err := os.ReadFile(filepath.Join("..", "..", "internal", "domain", "ubl", "testdata", "invoice.xml"))
+	require.NoError(t, err)
+	invoice, err := c.ImportUBLInvoice(ctx, 1, document)
+	require.NoError(t, err)
+	assert.Equal(t, client.Decimal("115077.00"), invoice.PaymentAmount)
+	_, err = c.ImportUBLInvoice(ctx, 1, []byte("<?xml version=\"1.0\"?><html></html>"))
+	assert.ErrorIs(t, err, client.ErrBadRequest)
+
+	var exported bytes.Buffer
+	require.NoError(t, c.ExportUBLInvoice(ctx, invoice.ID, &exported))
+	assert.Contains(t, exported.String(), "<cbc:PayableAmount currencyID=\"JPY\">115077</cbc:PayableAmount>")
+	err = c.ExportUBLInvoice(ctx, invoice.ID+1, &exported)
+	assert.ErrorIs(t, err, client.ErrNotFound)
+}
+
+func TestInvoices_Pagination(t *testing.T) {
+	ctx := context.Background()
+	c := newClient(t, newServer(t))
+
+	var ids []int64
+	for invoice, err := range c.Invoices(ctx, from, to, 7) {
+		require.NoError(t, err)
+		ids = append(ids, invoice.ID)
+	}
+
+	require.Len(t, ids, 60)
+	for i := 1; i < len(ids); i++ {
+		assert.Less(t, ids[i-1], ids[i])
+	}
+
+	// Breaking out of the loop stops fetching pages
+	count := 0
+	for range c.Invoices(ctx, from, to, 7) {
+		if count++; count == 3 {
+			break
+		}
+	}
+	assert.Equal(t, 3, count)
+
+	// Behind a proxy serving the API under a prefix, the next pages keep it
+	proxy := httptest.NewServer(http.StripPrefix("/uct", newServer(t).Config.Handler))
+	t.Cleanup(proxy.Close)
+	c, err := client.New(proxy.URL+"/uct", client.WithToken(newToken(t)))
+	require.NoError(t, err)
+	ids = nil
+	for invoice, err := range c.Invoices(ctx, from, to, 7) {
+		require.NoError(t, err)
+		ids = append(ids, invoice.ID)
+	}
+	assert.Len(t, ids, 60)
+}
+
+func TestErrors(t *testing.T) {
+	ctx := context.Background()
+	ts := newServer(t)
+
+	unauthenticated, err := client.New(ts.URL)
+	require.NoError(t, err)
+	_, err = unauthenticated.GetInvoice(ctx, 1)
+	assert.ErrorIs(t, err, client.ErrUnauthorized)
+
+	c := newClient(t, ts)
+	_, err = c.GetInvoice(ctx, 999999)
+	var apiErr *client.Error
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+	assert.ErrorIs(t, err, client.ErrNotFound)
+
+	_, err = c.ListInvoices(ctx, client.ListInvoicesParams{From: from, To: to, Limit: 100000})
+	assert.ErrorIs(t, err, client.ErrBadRequest)
+
+	_, err = client.New("localhost:8080")
+	assert.Error(t, err)
+}
+
+// lossyTransport drops the response of the first request which changes something, as if the connection
+// was lost after the server handled it
+type lossyTransport struct {
+	dropped atomic.Bool
+	keys    []string
+}
+
+func (l *lossyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	res, err := http.DefaultTransport.RoundTrip(req)
+	if req.Method == http.MethodGet || err != nil {
+		return res, err
+	}
+	l.keys = append(l.keys, req.Header.Get("Idempotency-Key"))
+	if l.dropped.CompareAndSwap(false, true) {
+		res.Body.Close()
+		return nil, errors.New("connection reset by peer")
+	}
+	return res, nil
+}
+
+func TestRetries_IdempotencyKey(t *testing.T) {
+	ctx := context.Background()
+	transport := &lossyTransport{}
+	c := newClient(t, newServer(t), client.WithHTTPClient(&http.Client{Transport: transport}))
+
+	require.NoError(t, c.CreateInvoice(ctx, input(10000)))
+
+	// The retry was sent with the same key, so the invoice was created once
+	require.Len(t, transport.keys, 2)
+	assert.Equal(t, transport.keys[0], transport.keys[1])
+	assert.NotEmpty(t, transport.keys[0])
+	assert.Equal(t, 61, countInvoices(t, c))
+}
+
+func TestRetries_ServerErrors(t *testing.T) {
+	var calls atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer ts.Close()
+
+	c := newClient(t, ts)
+	page, err := c.ListInvoices(context.Background(), client.ListInvoicesParams{From: from, To: to})
+	require.NoError(t, err)
+	assert.Empty(t, page.Invoices)
+	assert.Equal(t, int32(3), calls.Load())
+
+	// Without retries the first error is returned
+	calls.Store(0)
+	c = newClient(t, ts, client.WithRetries(0, 0))
+	_, err = c.ListInvoices(context.Background(), client.ListInvoicesParams{From: from, To: to})
+	assert.ErrorIs(t, err, client.ErrServer)
+	assert.True(t, strings.Contains(err.Error(), "503"))
+}
@@ -0,0 +1,104 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// The statuses of the credit notes: issued ones can be applied or voided, applied and void ones are final
+const (
+	CreditNoteStatusIssued  = "issued"
+	CreditNoteStatusApplied = "applied"
+	CreditNoteStatusVoid    = "void"
+)
+
+// CreditNote is a credit note as stored by the API, crediting part of the payment amount of an invoice.
+// Its fee and tax amounts are the ones reversed, its amounts are in the currency of the invoice
+type CreditNote struct {
+	ID            int64     `json:"id"`
+	InvoiceID     int64     `json:"invoice_id"`
+	IssueDate     time.Time `json:"issue_date"`
+	Reason        string    `json:"reason"`
+	PaymentAmount Decimal   `json:"payment_amount"`
+	FeeAmount     Decimal   `json:"fee_amount"`
+	TaxAmount     Decimal   `json:"tax_amount"`
+	TotalAmount   Decimal   `json:"total_amount"`
+	Status        string    `json:"status"`
+	Version       int64     `json:"version"`
+}
+
+// CreditNoteInput is a credit note to create. The fee, tax and total amount are calculated by the API
+type CreditNoteInput struct {
+	IssueDate     time.Time `json:"issue_date"`
+	Reason        string    `json:"reason"`
+	PaymentAmount float64   `json:"payment_amount"`
+}
+
+// CreateCreditNote creates a credit note of an invoice, and returns it with its calculated amounts.
+// It fails with an *Error of status 422 if it credits more than the payment amount left to the invoice
+func (c *Client) CreateCreditNote(ctx context.Context, invoiceID int64, in *CreditNoteInput) (*CreditNote, error) {
+	var creditNote CreditNote
+	_, err := c.do(ctx, request{method: http.MethodPost, path: invoicePath(invoiceID) + "/credit-notes", in: in, out: &creditNote})
+	if err != nil {
+		return nil, err
+	}
+	return &creditNote, nil
+}
+
+// GetCreditNote returns a credit note, ErrNotFound if it doesn't exist
+func (c *Client) GetCreditNote(ctx context.Context, id int64) (*CreditNote, error) {
+	var creditNote CreditNote
+	if _, err := c.do(ctx, request{method: http.MethodGet, path: creditNotePath(id), out: &creditNote}); err != nil {
+		return nil, err
+	}
+	return &creditNote, nil
+}
+
+// ListInvoiceCreditNotes returns the credit notes of an invoice, void ones included
+func (c *Client) ListInvoiceCreditNotes(ctx context.Context, invoiceID int64) ([]*CreditNote, error) {
+	var creditNotes []*CreditNote
+	_, err := c.do(ctx, request{method: http.MethodGet, path: invoicePath(invoiceID) + "/credit-notes", out: &creditNotes})
+	if err != nil {
+		return nil, err
+	}
+	return creditNotes, nil
+}
+
+// ListCreditNotes returns the credit notes issued between from and to (inclusive)
+func (c *Client) ListCreditNotes(ctx context.Context, from, to time.Time) ([]*CreditNote, error) {
+	query := url.Values{
+		"from": {from.Format(dateFormat)},
+		"to":   {to.Format(dateFormat)},
+	}
+	var creditNotes []*CreditNote
+	_, err := c.do(ctx, request{method: http.MethodGet, path: "/credit-notes", query: query, out: &creditNotes})
+	if err != nil {
+		return nil, err
+	}
+	return creditNotes, nil
+}
+
+// UpdateCreditNoteStatus moves a credit note to a status if it is still at version (or AnyVersion), and returns it
+// with its new version. It returns ErrVersionConflict if someone else changed it in the meantime,
+// and an *Error of status 422 if it can't move to the status
+func (c *Client) UpdateCreditNoteStatus(ctx context.Context, id int64, version int64, status string) (*CreditNote, error) {
+	var creditNote CreditNote
+	_, err := c.do(ctx, request{
+		method: http.MethodPatch,
+		path:   creditNotePath(id),
+		header: ifMatch(version),
+		in:     map[string]string{"status": status},
+		out:    &creditNote,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &creditNote, nil
+}
+
+func creditNotePath(id int64) string {
+	return "/credit-notes/" + strconv.FormatInt(id, 10)
+}
@@ -0,0 +1,83 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// The errors of the API, by status. Use errors.Is to check the error returned by a call, e.g.
+//
+//	if errors.Is(err, client.ErrVersionConflict) { /* read the invoice again */ }
+var (
+	// ErrBadRequest is a request which doesn't match the API specification, or invalid parameters
+	ErrBadRequest = errors.New("bad request")
+	// ErrUnauthorized is a missing, invalid or expired token
+	ErrUnauthorized = errors.New("unauthorized")
+	// ErrNotFound is a resource which doesn't exist (anymore)
+	ErrNotFound = errors.New("not found")
+	// ErrConflict is a request with the same Idempotency-Key still in progress
+	ErrConflict = errors.New("conflict")
+	// ErrVersionConflict is a change of a resource which was changed by someone else since it was read
+	ErrVersionConflict = errors.New("version conflict")
+	// ErrIdempotencyKeyReused is an Idempotency-Key already used for a different request
+	ErrIdempotencyKeyReused = errors.New("idempotency key reused")
+	// ErrPreconditionRequired is a change sent without the version of the resource
+	ErrPreconditionRequired = errors.New("precondition required")
+	// ErrServer is an error of the server, or of a proxy in front of it
+	ErrServer = errors.New("server error")
+)
+
+// Error is an error response of the API
+type Error struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("uct: %d %s: %s", e.StatusCode, http.StatusText(e.StatusCode), e.Message)
+}
+
+// Unwrap returns the error of the taxonomy matching the status, if any
+func (e *Error) Unwrap() error {
+	switch {
+	case e.StatusCode == http.StatusBadRequest:
+		return ErrBadRequest
+	case e.StatusCode == http.StatusUnauthorized:
+		return ErrUnauthorized
+	case e.StatusCode == http.StatusNotFound:
+		return ErrNotFound
+	case e.StatusCode == http.StatusConflict:
+		return ErrConflict
+	case e.StatusCode == http.StatusPreconditionFailed:
+		return ErrVersionConflict
+	case e.StatusCode == http.StatusUnprocessableEntity:
+		return ErrIdempotencyKeyReused
+	case e.StatusCode == http.StatusPreconditionRequired:
+		return ErrPreconditionRequired
+	case e.StatusCode >= http.StatusInternalServerError:
+		return ErrServer
+	}
+	return nil
+}
+
+// newError reads an error response, and closes its body.
+// The handlers answer {"error": "..."}, the authentication and the router {"message": "..."}
+func newError(res *http.Response) *Error {
+	defer res.Body.Close()
+
+	data, _ := io.ReadAll(io.LimitReader(res.Body, maxErrorBody))
+	var body struct {
+		Error   string `json:"error"`
+		Message string `json:"message"`
+	}
+	message := string(data)
+	if err := json.Unmarshal(data, &body); err == nil {
+		if message = body.Error; message == "" {
+			message = body.Message
+		}
+	}
+	return &Error{StatusCode: res.StatusCode, Message: message}
+}
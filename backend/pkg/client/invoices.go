@@ -0,0 +1,253 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// AnyVersion can be given instead of the version of an invoice to change it whatever its version
+const AnyVersion int64 = 0
+
+// dateFormat is the format of the dates in the query parameters
+const dateFormat = "2006-01-02"
+
+// nextLink extracts the URL of the next page from a Link header
+var nextLink = regexp.MustCompile(`<([^>]+)>;\s*rel="next"`)
+
+// Decimal is an exact amount, as returned by the API (e.g. "10440.00")
+type Decimal string
+
+// Float64 returns the amount as a float, for display or approximate computations
+func (d Decimal) Float64() (float64, error) {
+	return strconv.ParseFloat(string(d), 64)
+}
+
+// Invoice is an invoice as stored by the API. Its amounts are in its currency, FXRate converts them to the base
+// currency of its company (the rate on its issue date, saved on creation). OutstandingAmount is its total amount
+// less the credit notes which are not void and the payments
+type Invoice struct {
+	ID            int64     `json:"id"`
+	CompanyID     int64     `json:"company_id"`
+	ClientID      int64     `json:"client_id"`
+	IssueDate     time.Time `json:"issue_date"`
+	DueDate       time.Time `json:"due_date"`
+	PaymentAmount Decimal   `json:"payment_amount"`
+	FeeAmount     Decimal   `json:"fee_amount"`
+	TaxAmount     Decimal   `json:"tax_amount"`
+	TotalAmount   Decimal   `json:"total_amount"`
+	Status        string    `json:"status"`
+	Currency      string    `json:"currency"`
+	FXRate        Decimal   `json:"fx_rate"`
+	Version       int64     `json:"version"`
+	Items         []*Item   `json:"items"`
+
+	Installments      []*Installment `json:"installments"`
+	CreditNotes       []*CreditNote  `json:"credit_notes"`
+	Payments          []*Payment     `json:"payments"`
+	PaidAmount        Decimal        `json:"paid_amount"`
+	OutstandingAmount Decimal        `json:"outstanding_amount"`
+}
+
+// Installment is a part of the total amount of an invoice due at its own date, numbered from 1
+type Installment struct {
+	ID            int64     `json:"id"`
+	InvoiceID     int64     `json:"invoice_id"`
+	InstallmentNo int       `json:"installment_no"`
+	DueDate       time.Time `json:"due_date"`
+	Amount        Decimal   `json:"amount"`
+}
+
+// Item is a line of an invoice, numbered from 1
+type Item struct {
+	ID          int64   `json:"id"`
+	InvoiceID   int64   `json:"invoice_id"`
+	LineNo      int     `json:"line_no"`
+	Description string  `json:"description"`
+	Quantity    Decimal `json:"quantity"`
+	UnitPrice   Decimal `json:"unit_price"`
+	Amount      Decimal `json:"amount"`
+	TaxCategory string  `json:"tax_category"`
+}
+
+// The tax categories of the items
+const (
+	TaxCategoryStandard = "standard"
+	TaxCategoryReduced  = "reduced"
+)
+
+// InvoiceInput is an invoice to create or to replace. The fee, tax and total amount are calculated by the API.
+// With Items, the payment amount is their total and can be left to 0. The currency is one of JPY, USD, EUR, GBP or CNY,
+// the base currency of the company if empty
+type InvoiceInput struct {
+	CompanyID     int64        `json:"company_id"`
+	ClientID      int64        `json:"client_id"`
+	IssueDate     time.Time    `json:"issue_date"`
+	DueDate       time.Time    `json:"due_date"`
+	PaymentAmount float64      `json:"payment_amount"`
+	Status        string       `json:"status"`
+	Currency      string       `json:"currency,omitempty"`
+	Items         []*ItemInput `json:"items,omitempty"`
+	// Installments split the total amount, in order of due date, the last one due at DueDate
+	Installments []*InstallmentInput `json:"installments,omitempty"`
+}
+
+// InstallmentInput is an installment of an invoice to create or to replace
+type InstallmentInput struct {
+	DueDate time.Time `json:"due_date"`
+	Amount  float64   `json:"amount"`
+}
+
+// ItemInput is a line of an invoice to create or to replace. Its unit price includes the tax of its category
+type ItemInput struct {
+	Description string  `json:"description"`
+	Quantity    float64 `json:"quantity"`
+	UnitPrice   float64 `json:"unit_price"`
+	TaxCategory string  `json:"tax_category"`
+}
+
+// The dates ListInvoicesParams can select the invoices by
+const (
+	ListByDueDate            = "due_date"
+	ListByInstallmentDueDate = "installment_due_date"
+)
+
+// ListInvoicesParams selects the invoices due between From and To (inclusive), or with an installment due
+// between them if By is ListByInstallmentDueDate. With a Limit, a page of at most Limit invoices is returned,
+// after the invoice After
+type ListInvoicesParams struct {
+	From  time.Time
+	To    time.Time
+	By    string
+	Limit int
+	After int64
+}
+
+// InvoicePage is a page of invoices
+type InvoicePage struct {
+	Invoices []*Invoice
+	// next is the URL of the next page, empty on the last one
+	next string
+}
+
+// HasNext reports whether there is a page after this one
+func (p *InvoicePage) HasNext() bool {
+	return p.next != ""
+}
+
+// CreateInvoice creates an invoice
+func (c *Client) CreateInvoice(ctx context.Context, in *InvoiceInput) error {
+	_, err := c.do(ctx, request{method: http.MethodPost, path: "/invoices", in: in})
+	return err
+}
+
+// GetInvoice returns an invoice, ErrNotFound if it doesn't exist
+func (c *Client) GetInvoice(ctx context.Context, id int64) (*Invoice, error) {
+	var invoice Invoice
+	if _, err := c.do(ctx, request{method: http.MethodGet, path: invoicePath(id), out: &invoice}); err != nil {
+		return nil, err
+	}
+	return &invoice, nil
+}
+
+// ListInvoices returns the invoices (or the page of invoices) selected by params
+func (c *Client) ListInvoices(ctx context.Context, params ListInvoicesParams) (*InvoicePage, error) {
+	query := url.Values{
+		"from": {params.From.Format(dateFormat)},
+		"to":   {params.To.Format(dateFormat)},
+	}
+	if params.By != "" {
+		query.Set("by", params.By)
+	}
+	if params.Limit > 0 {
+		query.Set("limit", strconv.Itoa(params.Limit))
+	}
+	if params.After > 0 {
+		query.Set("after", strconv.FormatInt(params.After, 10))
+	}
+	return c.listInvoices(ctx, request{method: http.MethodGet, path: "/invoices", query: query})
+}
+
+// NextInvoices returns the page after page
+func (c *Client) NextInvoices(ctx context.Context, page *InvoicePage) (*InvoicePage, error) {
+	if !page.HasNext() {
+		return nil, fmt.Errorf("no page after this one")
+	}
+	return c.listInvoices(ctx, request{method: http.MethodGet, rawPath: page.next})
+}
+
+func (c *Client) listInvoices(ctx context.Context, r request) (*InvoicePage, error) {
+	page := &InvoicePage{}
+	r.out = &page.Invoices
+	res, err := c.do(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	if m := nextLink.FindStringSubmatch(res.Header.Get("Link")); m != nil {
+		page.next = m[1]
+	}
+	return page, nil
+}
+
+// Invoices iterates over the invoices due between from and to, fetching them by pages of pageSize.
+// The iteration stops at the first error, which is yielded with a nil invoice
+func (c *Client) Invoices(ctx context.Context, from, to time.Time, pageSize int) iter.Seq2[*Invoice, error] {
+	return func(yield func(*Invoice, error) bool) {
+		page, err := c.ListInvoices(ctx, ListInvoicesParams{From: from, To: to, Limit: pageSize})
+		for {
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			for _, invoice := range page.Invoices {
+				if !yield(invoice, nil) {
+					return
+				}
+			}
+			if !page.HasNext() {
+				return
+			}
+			page, err = c.NextInvoices(ctx, page)
+		}
+	}
+}
+
+// UpdateInvoice replaces an invoice if it is still at version (the version it was read at, or AnyVersion),
+// and returns it with its new version. It returns ErrVersionConflict if someone else changed it in the meantime
+func (c *Client) UpdateInvoice(ctx context.Context, id int64, version int64, in *InvoiceInput) (*Invoice, error) {
+	var invoice Invoice
+	_, err := c.do(ctx, request{
+		method: http.MethodPut,
+		path:   invoicePath(id),
+		header: ifMatch(version),
+		in:     in,
+		out:    &invoice,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &invoice, nil
+}
+
+// DeleteInvoice deletes an invoice if it is still at version, with the same errors as UpdateInvoice
+func (c *Client) DeleteInvoice(ctx context.Context, id int64, version int64) error {
+	_, err := c.do(ctx, request{method: http.MethodDelete, path: invoicePath(id), header: ifMatch(version)})
+	return err
+}
+
+func invoicePath(id int64) string {
+	return "/invoices/" + strconv.FormatInt(id, 10)
+}
+
+// ifMatch returns the If-Match header for a version, its ETag
+func ifMatch(version int64) http.Header {
+	if version == AnyVersion {
+		return http.Header{"If-Match": {"*"}}
+	}
+	return http.Header{"If-Match": {strconv.Quote(strconv.FormatInt(version, 10))}}
+}
@@ -0,0 +1,50 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// The statuses the API derives from the payments of an invoice
+const (
+	InvoiceStatusPartiallyPaid = "partially_paid"
+	InvoiceStatusPaid          = "paid"
+)
+
+// Payment is a payment recorded against an invoice, in its currency
+type Payment struct {
+	ID          int64     `json:"id"`
+	InvoiceID   int64     `json:"invoice_id"`
+	PaymentDate time.Time `json:"payment_date"`
+	Amount      Decimal   `json:"amount"`
+	Reference   string    `json:"reference"`
+}
+
+// PaymentInput is a payment to record. The reference is optional
+type PaymentInput struct {
+	PaymentDate time.Time `json:"payment_date"`
+	Amount      float64   `json:"amount"`
+	Reference   string    `json:"reference,omitempty"`
+}
+
+// RecordPayment records a payment of an invoice, and returns the invoice with its new status and outstanding amount.
+// It fails with an *Error of status 422 if it pays more than the outstanding amount of the invoice
+func (c *Client) RecordPayment(ctx context.Context, invoiceID int64, in *PaymentInput) (*Invoice, error) {
+	var invoice Invoice
+	_, err := c.do(ctx, request{method: http.MethodPost, path: invoicePath(invoiceID) + "/payments", in: in, out: &invoice})
+	if err != nil {
+		return nil, err
+	}
+	return &invoice, nil
+}
+
+// ListPayments returns the payments of an invoice
+func (c *Client) ListPayments(ctx context.Context, invoiceID int64) ([]*Payment, error) {
+	var payments []*Payment
+	_, err := c.do(ctx, request{method: http.MethodGet, path: invoicePath(invoiceID) + "/payments", out: &payments})
+	if err != nil {
+		return nil, err
+	}
+	return payments, nil
+}
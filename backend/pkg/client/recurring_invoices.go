@@ -0,0 +1,137 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// The frequencies of the recurring invoices
+const (
+	RecurringFrequencyMonthly  = "monthly"   // on DayOfMonth, the last day of the shorter months
+	RecurringFrequencyMonthEnd = "month_end" // on the last day of the month
+	RecurringFrequencyWeekly   = "weekly"    // on the weekday of the start date
+)
+
+// The statuses of the recurring invoices: paused ones generate no invoice until resumed
+const (
+	RecurringStatusActive = "active"
+	RecurringStatusPaused = "paused"
+)
+
+// RecurringInvoice is a template of the invoices a client is billed every Every months or weeks, as stored by the API.
+// NextDate is the issue date of the next invoice generated, nil once the schedule ended. SkippedCount counts the
+// occurrences skipped while paused, which don't count toward MaxOccurrences
+type RecurringInvoice struct {
+	ID             int64      `json:"id"`
+	CompanyID      int64      `json:"company_id"`
+	ClientID       int64      `json:"client_id"`
+	Frequency      string     `json:"frequency"`
+	Every          int        `json:"every"`
+	DayOfMonth     int        `json:"day_of_month"`
+	StartDate      time.Time  `json:"start_date"`
+	EndDate        *time.Time `json:"end_date"`
+	MaxOccurrences int        `json:"max_occurrences"`
+	PaymentAmount  Decimal    `json:"payment_amount"`
+	Currency       string     `json:"currency"`
+	DueDays        int        `json:"due_days"`
+	Status         string     `json:"status"`
+	NextDate       *time.Time `json:"next_date"`
+	Version        int64      `json:"version"`
+	SkippedCount   int        `json:"skipped_count"`
+}
+
+// RecurringInvoiceInput is a recurring invoice to create. Every defaults to 1 and the currency to the base currency
+// of the company. DayOfMonth is only for the monthly frequency, EndDate and MaxOccurrences (0 for none) end the schedule
+type RecurringInvoiceInput struct {
+	CompanyID      int64      `json:"company_id"`
+	ClientID       int64      `json:"client_id"`
+	Frequency      string     `json:"frequency"`
+	Every          int        `json:"every,omitempty"`
+	DayOfMonth     int        `json:"day_of_month,omitempty"`
+	StartDate      time.Time  `json:"start_date"`
+	EndDate        *time.Time `json:"end_date,omitempty"`
+	MaxOccurrences int        `json:"max_occurrences,omitempty"`
+	PaymentAmount  float64    `json:"payment_amount"`
+	Currency       string     `json:"currency,omitempty"`
+	DueDays        int        `json:"due_days"`
+}
+
+// Occurrence is an upcoming invoice of a recurring invoice, with the amounts it will have
+type Occurrence struct {
+	IssueDate     time.Time `json:"issue_date"`
+	DueDate       time.Time `json:"due_date"`
+	PaymentAmount Decimal   `json:"payment_amount"`
+	FeeAmount     Decimal   `json:"fee_amount"`
+	TaxAmount     Decimal   `json:"tax_amount"`
+	TotalAmount   Decimal   `json:"total_amount"`
+	Currency      string    `json:"currency"`
+}
+
+// CreateRecurringInvoice creates a recurring invoice, whose invoices the API generates on each occurrence
+func (c *Client) CreateRecurringInvoice(ctx context.Context, in *RecurringInvoiceInput) (*RecurringInvoice, error) {
+	var recurring RecurringInvoice
+	_, err := c.do(ctx, request{method: http.MethodPost, path: "/recurring-invoices", in: in, out: &recurring})
+	if err != nil {
+		return nil, err
+	}
+	return &recurring, nil
+}
+
+// GetRecurringInvoice returns a recurring invoice, ErrNotFound if it doesn't exist
+func (c *Client) GetRecurringInvoice(ctx context.Context, id int64) (*RecurringInvoice, error) {
+	var recurring RecurringInvoice
+	if _, err := c.do(ctx, request{method: http.MethodGet, path: recurringInvoicePath(id), out: &recurring}); err != nil {
+		return nil, err
+	}
+	return &recurring, nil
+}
+
+// ListRecurringInvoices returns the recurring invoices of a client
+func (c *Client) ListRecurringInvoices(ctx context.Context, clientID int64) ([]*RecurringInvoice, error) {
+	query := url.Values{"client_id": {strconv.FormatInt(clientID, 10)}}
+	var recurring []*RecurringInvoice
+	_, err := c.do(ctx, request{method: http.MethodGet, path: "/recurring-invoices", query: query, out: &recurring})
+	if err != nil {
+		return nil, err
+	}
+	return recurring, nil
+}
+
+// UpdateRecurringInvoiceStatus pauses or resumes a recurring invoice if it is still at version (or AnyVersion), and
+// returns it with its new version. A resumed one skips the occurrences missed while paused.
+// It returns ErrVersionConflict if someone else changed it in the meantime
+func (c *Client) UpdateRecurringInvoiceStatus(ctx context.Context, id int64, version int64, status string) (*RecurringInvoice, error) {
+	var recurring RecurringInvoice
+	_, err := c.do(ctx, request{
+		method: http.MethodPatch,
+		path:   recurringInvoicePath(id),
+		header: ifMatch(version),
+		in:     map[string]string{"status": status},
+		out:    &recurring,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &recurring, nil
+}
+
+// PreviewOccurrences returns the next count invoices of a recurring invoice, 12 if count is 0
+func (c *Client) PreviewOccurrences(ctx context.Context, id int64, count int) ([]*Occurrence, error) {
+	query := url.Values{}
+	if count > 0 {
+		query.Set("count", strconv.Itoa(count))
+	}
+	var occurrences []*Occurrence
+	_, err := c.do(ctx, request{method: http.MethodGet, path: recurringInvoicePath(id) + "/occurrences", query: query, out: &occurrences})
+	if err != nil {
+		return nil, err
+	}
+	return occurrences, nil
+}
+
+func recurringInvoicePath(id int64) string {
+	return "/recurring-invoices/" + strconv.FormatInt(id, 10)
+}
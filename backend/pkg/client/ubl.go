@@ -0,0 +1,34 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// ImportUBLInvoice creates the invoice of a UBL 2.1 (JP PINT) document received by a company. The seller is matched
+// to a client of the company by registration number, else by name, else created, and the document is attached
+// to the invoice
+func (c *Client) ImportUBLInvoice(ctx context.Context, companyID int64, document []byte) (*Invoice, error) {
+	var invoice Invoice
+	_, err := c.do(ctx, request{
+		method:      http.MethodPost,
+		path:        "/invoices/ubl",
+		query:       url.Values{"company_id": {strconv.FormatInt(companyID, 10)}},
+		body:        document,
+		contentType: "application/xml",
+		out:         &invoice,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &invoice, nil
+}
+
+// ExportUBLInvoice writes an invoice with items to w as the UBL 2.1 (JP PINT) document its client issued
+func (c *Client) ExportUBLInvoice(ctx context.Context, id int64, w io.Writer) error {
+	_, err := c.do(ctx, request{method: http.MethodGet, path: invoicePath(id) + "/ubl", download: w})
+	return err
+}